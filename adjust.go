@@ -15,6 +15,7 @@ import (
 	"bytes"
 	"encoding/xml"
 	"io"
+	"regexp"
 	"strconv"
 	"strings"
 	"unicode"
@@ -29,8 +30,20 @@ const (
 	rows    adjustDirection = true
 )
 
+var (
+	// chartFormulaRegexp matches the formula of the data references in the
+	// chart.
+	chartFormulaRegexp = regexp.MustCompile(`(<(?:c:)?f>)([^<]*)(</(?:c:)?f>)`)
+	// chartFormulaUnescaper unescapes the XML entities in the formula of the
+	// data references in the chart.
+	chartFormulaUnescaper = strings.NewReplacer("&apos;", "'", "&#39;", "'", "&quot;", "\"", "&#34;", "\"", "&lt;", "<", "&gt;", ">", "&amp;", "&")
+	// sheetRefRegexp matches the worksheet name prefix of the references in
+	// the formula.
+	sheetRefRegexp = regexp.MustCompile(`'(?:[^']|'')+'!|[\p{L}\p{N}_.]+!`)
+)
+
 // adjustHelperFunc defines functions to adjust helper.
-var adjustHelperFunc = [9]func(*File, *xlsxWorksheet, string, adjustDirection, int, int, int) error{
+var adjustHelperFunc = [11]func(*File, *xlsxWorksheet, string, adjustDirection, int, int, int) error{
 	func(f *File, ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
 		return f.adjustConditionalFormats(ws, sheet, dir, num, offset, sheetID)
 	},
@@ -58,6 +71,12 @@ var adjustHelperFunc = [9]func(*File, *xlsxWorksheet, string, adjustDirection, i
 	func(f *File, ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
 		return f.adjustVolatileDeps(ws, sheet, dir, num, offset, sheetID)
 	},
+	func(f *File, ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
+		return f.adjustCharts(ws, sheet, dir, num, offset, sheetID)
+	},
+	func(f *File, ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
+		return f.adjustPivotCaches(ws, sheet, dir, num, offset, sheetID)
+	},
 }
 
 // adjustHelper provides a function to adjust rows and columns dimensions,
@@ -282,7 +301,7 @@ func (f *File) adjustCellRef(cellRef string, dir adjustDirection, num, offset in
 func (f *File) adjustFormula(sheet, sheetN string, cell *xlsxC, dir adjustDirection, num, offset int, si bool) error {
 	var err error
 	if cell.f != "" {
-		if cell.f, err = f.adjustFormulaRef(sheet, sheetN, cell.f, false, dir, num, offset); err != nil {
+		if cell.f, err = f.adjustFormulaRef(sheet, sheetN, cell.f, false, !si && offset < 0, dir, num, offset); err != nil {
 			return err
 		}
 	}
@@ -298,7 +317,7 @@ func (f *File) adjustFormula(sheet, sheetN string, cell *xlsxC, dir adjustDirect
 		}
 	}
 	if cell.F.Content != "" {
-		if cell.F.Content, err = f.adjustFormulaRef(sheet, sheetN, cell.F.Content, false, dir, num, offset); err != nil {
+		if cell.F.Content, err = f.adjustFormulaRef(sheet, sheetN, cell.F.Content, false, !si && offset < 0, dir, num, offset); err != nil {
 			return err
 		}
 	}
@@ -365,13 +384,12 @@ func adjustFormulaOperandRef(row, col, operand string, abs, keepRelative bool, d
 }
 
 // adjustFormulaOperand adjust range operand tokens for the formula.
-func (f *File) adjustFormulaOperand(sheet, sheetN string, keepRelative bool, token efp.Token, dir adjustDirection, num int, offset int) (string, error) {
+func (f *File) adjustFormulaOperand(sheet, sheetN string, keepRelative, removed bool, token efp.Token, dir adjustDirection, num int, offset int) (string, error) {
 	var (
-		err                          error
-		abs                          bool
-		sheetName, col, row, operand string
-		cell                         = token.TValue
-		tokens                       = strings.Split(token.TValue, "!")
+		err                error
+		sheetName, operand string
+		cell               = token.TValue
+		tokens             = strings.Split(token.TValue, "!")
 	)
 	if len(tokens) == 2 { // have a worksheet
 		sheetName, cell = tokens[0], tokens[1]
@@ -383,6 +401,62 @@ func (f *File) adjustFormulaOperand(sheet, sheetN string, keepRelative bool, tok
 	if sheet != sheetName {
 		return operand + cell, err
 	}
+	if refs := strings.Split(cell, ":"); removed && len(refs) == 2 {
+		start := isRemovedFormulaRef(refs[0], keepRelative, dir, num)
+		if start && isRemovedFormulaRef(refs[1], keepRelative, dir, num) {
+			return formulaErrorREF, err
+		}
+		if start {
+			// The start of the range keeps in place when its row or column
+			// has been removed, only the end of the range will be shrunk.
+			if operand, err = adjustFormulaOperandCell(refs[0], operand, keepRelative, dir, num+1, offset); err != nil {
+				return operand, err
+			}
+			return adjustFormulaOperandCell(refs[1], operand+":", keepRelative, dir, num, offset)
+		}
+	}
+	if removed && isRemovedFormulaRef(cell, keepRelative, dir, num) {
+		return formulaErrorREF, err
+	}
+	return adjustFormulaOperandCell(cell, operand, keepRelative, dir, num, offset)
+}
+
+// isRemovedFormulaRef returns if the row or column of the given cell
+// reference, row reference or column reference in the formula has been
+// removed. The relative reference will be ignored when keepRelative is true.
+func isRemovedFormulaRef(ref string, keepRelative bool, dir adjustDirection, num int) bool {
+	idx := strings.IndexFunc(ref, func(r rune) bool { return '0' <= r && r <= '9' })
+	col, row := ref, ""
+	if idx != -1 {
+		col, row = ref[:idx], ref[idx:]
+	}
+	name := strings.TrimSuffix(col, "$")
+	if dir == rows {
+		name = row
+		if strings.HasSuffix(col, "$") {
+			name = "$" + row
+		}
+	}
+	if keepRelative && !strings.HasPrefix(name, "$") {
+		return false
+	}
+	if name = strings.TrimPrefix(name, "$"); dir == rows {
+		n, err := strconv.Atoi(name)
+		return err == nil && n == num
+	}
+	n, err := ColumnNameToNumber(name)
+	return err == nil && n == num
+}
+
+// adjustFormulaOperandCell adjust the cell reference, row reference or column
+// reference in the operand tokens for the formula, and returns the operand
+// with the adjusted reference.
+func adjustFormulaOperandCell(cell, operand string, keepRelative bool, dir adjustDirection, num, offset int) (string, error) {
+	var (
+		err      error
+		abs      bool
+		col, row string
+	)
 	for _, r := range cell {
 		if r == '$' {
 			if col, operand, _, err = adjustFormulaColumnName(col, operand, abs, keepRelative, dir, num, offset); err != nil {
@@ -414,15 +488,17 @@ func (f *File) adjustFormulaOperand(sheet, sheetN string, keepRelative bool, tok
 }
 
 // adjustFormulaRef returns adjusted formula by giving adjusting direction and
-// the base number of column or row, and offset.
-func (f *File) adjustFormulaRef(sheet, sheetN, formula string, keepRelative bool, dir adjustDirection, num, offset int) (string, error) {
+// the base number of column or row, and offset. The references to the removed
+// row or column will be replaced with the "#REF!" error value when removed is
+// true.
+func (f *File) adjustFormulaRef(sheet, sheetN, formula string, keepRelative, removed bool, dir adjustDirection, num, offset int) (string, error) {
 	var (
 		val          string
 		definedNames []string
 		ps           = efp.ExcelParser()
 	)
 	for _, definedName := range f.GetDefinedName() {
-		if definedName.Scope == "Workbook" || definedName.Scope == sheet {
+		if definedName.Scope == definedNameScopeWorkbook || definedName.Scope == sheet {
 			definedNames = append(definedNames, definedName.Name)
 		}
 	}
@@ -440,7 +516,7 @@ func (f *File) adjustFormulaRef(sheet, sheetN, formula string, keepRelative bool
 				val += token.TValue
 				continue
 			}
-			operand, err := f.adjustFormulaOperand(sheet, sheetN, keepRelative, token, dir, num, offset)
+			operand, err := f.adjustFormulaOperand(sheet, sheetN, keepRelative, removed, token, dir, num, offset)
 			if err != nil {
 				return val, err
 			}
@@ -585,7 +661,7 @@ func getArrayFormulaTokens(sheet, formula string, definedNames []DefinedName) ([
 		if token.TSubType == efp.TokenSubTypeRange && token.TType == efp.TokenTypeOperand {
 			tokenVal := token.TValue
 			for _, definedName := range definedNames {
-				if (definedName.Scope == "Workbook" || definedName.Scope == sheet) && definedName.Name == tokenVal {
+				if (definedName.Scope == definedNameScopeWorkbook || definedName.Scope == sheet) && definedName.Name == tokenVal {
 					tokenVal = definedName.RefersTo
 				}
 			}
@@ -634,7 +710,7 @@ func (f *File) adjustHyperlinks(ws *xlsxWorksheet, sheet string, dir adjustDirec
 	}
 	for i := range ws.Hyperlinks.Hyperlink {
 		link := &ws.Hyperlinks.Hyperlink[i] // get reference
-		link.Ref, _ = f.adjustFormulaRef(sheet, sheet, link.Ref, false, dir, num, offset)
+		link.Ref, _ = f.adjustFormulaRef(sheet, sheet, link.Ref, false, offset < 0, dir, num, offset)
 	}
 }
 
@@ -678,11 +754,11 @@ func (f *File) adjustTable(ws *xlsxWorksheet, sheet string, dir adjustDirection,
 		}
 		t.Ref, _ = coordinatesToRangeRef([]int{x1, y1, x2, y2})
 		if t.AutoFilter != nil {
-			t.AutoFilter.Ref = t.Ref
+			t.AutoFilter.Ref, _ = coordinatesToRangeRef([]int{x1, y1, x2, y2 - t.TotalsRowCount})
 		}
 		_ = f.setTableColumns(sheet, true, x1, y1, x2, &t)
 		// Currently doesn't support query table
-		t.TableType, t.TotalsRowCount, t.ConnectionID = "", 0, 0
+		t.TableType, t.ConnectionID = "", 0
 		table, _ := xml.Marshal(t)
 		f.saveFileList(tableXML, table)
 	}
@@ -991,14 +1067,14 @@ func (f *File) adjustDataValidations(ws *xlsxWorksheet, sheet string, dir adjust
 			}
 			if worksheet.DataValidations.DataValidation[i].Formula1.isFormula() {
 				formula := formulaUnescaper.Replace(worksheet.DataValidations.DataValidation[i].Formula1.Content)
-				if formula, err = f.adjustFormulaRef(sheet, sheetN, formula, false, dir, num, offset); err != nil {
+				if formula, err = f.adjustFormulaRef(sheet, sheetN, formula, false, offset < 0, dir, num, offset); err != nil {
 					return err
 				}
 				worksheet.DataValidations.DataValidation[i].Formula1 = &xlsxInnerXML{Content: formulaEscaper.Replace(formula)}
 			}
 			if worksheet.DataValidations.DataValidation[i].Formula2.isFormula() {
 				formula := formulaUnescaper.Replace(worksheet.DataValidations.DataValidation[i].Formula2.Content)
-				if formula, err = f.adjustFormulaRef(sheet, sheetN, formula, false, dir, num, offset); err != nil {
+				if formula, err = f.adjustFormulaRef(sheet, sheetN, formula, false, offset < 0, dir, num, offset); err != nil {
 					return err
 				}
 				worksheet.DataValidations.DataValidation[i].Formula2 = &xlsxInnerXML{Content: formulaEscaper.Replace(formula)}
@@ -1093,7 +1169,7 @@ func (f *File) adjustDrawings(ws *xlsxWorksheet, sheet string, dir adjustDirecti
 		return err
 	}
 	anchorCb := func(a *xdrCellAnchor) error {
-		if a.GraphicFrame == "" {
+		if a.GraphicFrame == "" || a.From != nil {
 			return a.adjustDrawings(dir, num, offset)
 		}
 		deCellAnchor := decodeCellAnchor{}
@@ -1141,10 +1217,563 @@ func (f *File) adjustDefinedNames(ws *xlsxWorksheet, sheet string, dir adjustDir
 	if wb.DefinedNames != nil {
 		for i := 0; i < len(wb.DefinedNames.DefinedName); i++ {
 			data := wb.DefinedNames.DefinedName[i].Data
-			if data, err = f.adjustFormulaRef(sheet, "", data, true, dir, num, offset); err == nil {
+			if data, err = f.adjustFormulaRef(sheet, "", data, true, offset < 0, dir, num, offset); err == nil {
 				wb.DefinedNames.DefinedName[i].Data = data
 			}
 		}
 	}
 	return nil
 }
+
+// adjustCharts updates the data references of the chart series in the
+// workbook which reference the worksheet when inserting or deleting rows or
+// columns.
+func (f *File) adjustCharts(ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
+	var charts []string
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), "xl/charts/chart") {
+			charts = append(charts, k.(string))
+		}
+		return true
+	})
+	for _, chartXML := range charts {
+		var err error
+		original := string(f.readXML(chartXML))
+		content := chartFormulaRegexp.ReplaceAllStringFunc(original, func(match string) string {
+			parts := chartFormulaRegexp.FindStringSubmatch(match)
+			formula, e := f.adjustFormulaRef(sheet, "", chartFormulaUnescaper.Replace(parts[2]), true, offset < 0, dir, num, offset)
+			if e != nil {
+				err = e
+				return match
+			}
+			var buf bytes.Buffer
+			_ = xml.EscapeText(&buf, []byte(formula))
+			return parts[1] + buf.String() + parts[3]
+		})
+		if err != nil {
+			return err
+		}
+		if content != original {
+			f.saveFileList(chartXML, []byte(content))
+		}
+	}
+	return nil
+}
+
+// adjustPivotCaches updates the source range of the pivot caches in the
+// workbook which reference the worksheet when inserting or deleting rows or
+// columns. The source range will be replaced with the "#REF!" error value when
+// all of its rows or columns have been deleted, or it can't be shifted within
+// the worksheet.
+func (f *File) adjustPivotCaches(ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
+	for _, pivotCacheXML := range f.getPartNames("xl/pivotCache/pivotCacheDefinition") {
+		pc, err := f.pivotCacheReader(pivotCacheXML)
+		if err != nil {
+			return err
+		}
+		if pc.CacheSource == nil || pc.CacheSource.WorksheetSource == nil ||
+			pc.CacheSource.WorksheetSource.Sheet != sheet || pc.CacheSource.WorksheetSource.Ref == "" ||
+			pc.CacheSource.WorksheetSource.Ref == formulaErrorREF {
+			continue
+		}
+		coordinates, err := rangeRefToCoordinates(pc.CacheSource.WorksheetSource.Ref)
+		if err != nil {
+			return err
+		}
+		start, end := 0, 2
+		if dir == rows {
+			start, end = 1, 3
+		}
+		if offset < 0 && coordinates[start] == num {
+			// The first row or column of the source range has been deleted
+			coordinates[end] += offset
+		} else {
+			coordinates = f.adjustAutoFilterHelper(dir, coordinates, num, offset)
+		}
+		ref, err := coordinatesToRangeRef(coordinates)
+		if err != nil || coordinates[end] < coordinates[start] {
+			ref = formulaErrorREF
+		}
+		if ref == pc.CacheSource.WorksheetSource.Ref {
+			continue
+		}
+		pc.CacheSource.WorksheetSource.Ref = ref
+		pivotCache, err := xml.Marshal(pc)
+		if err != nil {
+			return err
+		}
+		f.saveFileList(pivotCacheXML, pivotCache)
+	}
+	return nil
+}
+
+// shiftArea defined the inserted or deleted cells and the direction of
+// shifting the other cells. For the rows direction, the cells in the columns
+// from and to will be shifted vertically, and start and end are the row
+// numbers of the inserted or deleted cells. For the columns direction, the
+// cells in the rows from and to will be shifted horizontally, and start and
+// end are the column numbers of the inserted or deleted cells.
+type shiftArea struct {
+	dir        adjustDirection
+	insert     bool
+	from, to   int
+	start, end int
+}
+
+// newShiftArea returns the shift area by given sorted range coordinates,
+// direction and whether inserting cells.
+func newShiftArea(coordinates []int, dir adjustDirection, insert bool) shiftArea {
+	if dir == rows {
+		return shiftArea{dir: dir, insert: insert, from: coordinates[0], to: coordinates[2], start: coordinates[1], end: coordinates[3]}
+	}
+	return shiftArea{dir: dir, insert: insert, from: coordinates[1], to: coordinates[3], start: coordinates[0], end: coordinates[2]}
+}
+
+// size returns the number of inserted or deleted rows or columns.
+func (a shiftArea) size() int {
+	return a.end - a.start + 1
+}
+
+// limit returns the maximum number of rows or columns in the shifting
+// direction.
+func (a shiftArea) limit() int {
+	if a.dir == rows {
+		return TotalRows
+	}
+	return MaxColumns
+}
+
+// axes returns the position in the shifting direction and the position in
+// the cross direction by given column and row number.
+func (a shiftArea) axes(col, row int) (int, int) {
+	if a.dir == rows {
+		return row, col
+	}
+	return col, row
+}
+
+// coordinates returns the column and row number by given position in the
+// shifting direction and position in the cross direction.
+func (a shiftArea) coordinates(pos, cross int) (int, int) {
+	if a.dir == rows {
+		return cross, pos
+	}
+	return pos, cross
+}
+
+// crossed returns if the given rectangle will be shifted partially, which
+// across the boundary of the shifted cells.
+func (a shiftArea) crossed(rect []int) bool {
+	_, c1 := a.axes(rect[0], rect[1])
+	p2, c2 := a.axes(rect[2], rect[3])
+	return p2 >= a.start && c1 <= a.to && c2 >= a.from && (c1 < a.from || c2 > a.to)
+}
+
+// shiftRect returns the shifted rectangle coordinates by given sorted
+// rectangle coordinates, the rectangle which is not entirely in the shifted
+// cells keeps unchanged. The second return value will be false if the whole
+// rectangle has been deleted.
+func (a shiftArea) shiftRect(rect []int) ([]int, bool) {
+	p1, c1 := a.axes(rect[0], rect[1])
+	p2, c2 := a.axes(rect[2], rect[3])
+	if c1 < a.from || c2 > a.to || p2 < a.start {
+		return rect, true
+	}
+	if a.insert {
+		if p1 >= a.start {
+			p1 += a.size()
+		}
+		if p1 > a.limit() {
+			return nil, false
+		}
+		if p2 += a.size(); p2 > a.limit() {
+			p2 = a.limit()
+		}
+	} else {
+		if p1 >= a.start && p2 <= a.end {
+			return nil, false
+		}
+		if p1 > a.end {
+			p1 -= a.size()
+		} else if p1 >= a.start {
+			p1 = a.start
+		}
+		if p2 > a.end {
+			p2 -= a.size()
+		} else {
+			p2 = a.start - 1
+		}
+	}
+	col1, row1 := a.coordinates(p1, c1)
+	col2, row2 := a.coordinates(p2, c2)
+	return []int{col1, row1, col2, row2}, true
+}
+
+// cell returns the cell by given position in the shifting direction and
+// position in the cross direction, it returns nil if the cell doesn't exist.
+func (a shiftArea) cell(ws *xlsxWorksheet, pos, cross int) *xlsxC {
+	col, row := a.coordinates(pos, cross)
+	if row > len(ws.SheetData.Row) || col > len(ws.SheetData.Row[row-1].C) {
+		return nil
+	}
+	return &ws.SheetData.Row[row-1].C[col-1]
+}
+
+// lastPos returns the last position of the existing cells in the shifting
+// direction.
+func (a shiftArea) lastPos(ws *xlsxWorksheet) int {
+	if a.dir == rows {
+		return len(ws.SheetData.Row)
+	}
+	var last int
+	for row := a.from; row <= a.to && row <= len(ws.SheetData.Row); row++ {
+		if cols := len(ws.SheetData.Row[row-1].C); cols > last {
+			last = cols
+		}
+	}
+	return last
+}
+
+// checkLimit provides a function to check if the cells will be shifted out
+// of the worksheet when inserting cells.
+func (a shiftArea) checkLimit(ws *xlsxWorksheet) error {
+	if !a.insert {
+		return nil
+	}
+	for pos := a.limit() - a.size() + 1; pos <= a.lastPos(ws); pos++ {
+		if pos < a.start {
+			continue
+		}
+		for cross := a.from; cross <= a.to; cross++ {
+			if c := a.cell(ws, pos, cross); c != nil && c.hasValue() {
+				if a.dir == rows {
+					return ErrMaxRows
+				}
+				return ErrColumnNumber
+			}
+		}
+	}
+	return nil
+}
+
+// unshareFormulas provides a function to convert the shared formulas which
+// include any cell in the given sorted range coordinates into normal
+// formulas.
+func (ws *xlsxWorksheet) unshareFormulas(coordinates []int) {
+	si := map[int]bool{}
+	for row := coordinates[1]; row <= coordinates[3] && row <= len(ws.SheetData.Row); row++ {
+		cells := ws.SheetData.Row[row-1].C
+		for col := coordinates[0]; col <= coordinates[2] && col <= len(cells); col++ {
+			if c := &cells[col-1]; c.F != nil && c.F.T == STCellFormulaTypeShared && c.F.Si != nil {
+				si[*c.F.Si] = true
+			}
+		}
+	}
+	ws.convertSharedFormulas(si)
+}
+
+// convertSharedFormulas provides a function to convert the shared formulas
+// into normal formulas by given shared formula indexes.
+func (ws *xlsxWorksheet) convertSharedFormulas(si map[int]bool) {
+	if len(si) == 0 {
+		return
+	}
+	formulas := map[*xlsxC]*xlsxF{}
+	for r := range ws.SheetData.Row {
+		for c := range ws.SheetData.Row[r].C {
+			cell := &ws.SheetData.Row[r].C[c]
+			if cell.F != nil && cell.F.T == STCellFormulaTypeShared && cell.F.Si != nil && si[*cell.F.Si] {
+				formulas[cell] = ws.copyFormula(cell.F, cell.R, 0, 0)
+			}
+		}
+	}
+	for cell, formula := range formulas {
+		cell.F = formula
+	}
+}
+
+// moveCells provides a function to move the cells in the worksheet by the
+// shift area, the inserted cells will be blank.
+func (a shiftArea) moveCells(ws *xlsxWorksheet) {
+	last := a.lastPos(ws)
+	move := func(pos, src int) {
+		for cross := a.from; cross <= a.to; cross++ {
+			var cell xlsxC
+			if src >= a.start && src <= last {
+				if c := a.cell(ws, src, cross); c != nil {
+					cell = *c
+				}
+			}
+			col, row := a.coordinates(pos, cross)
+			if a.cell(ws, pos, cross) == nil {
+				if !cell.hasValue() {
+					continue
+				}
+				ws.prepareSheetXML(col, row)
+			}
+			cell.R, _ = CoordinatesToCellName(col, row)
+			ws.SheetData.Row[row-1].C[col-1] = cell
+		}
+	}
+	if a.insert {
+		pos := last + a.size()
+		if pos > a.limit() {
+			pos = a.limit()
+		}
+		for ; pos >= a.start; pos-- {
+			move(pos, pos-a.size())
+		}
+		return
+	}
+	for pos := a.start; pos <= last; pos++ {
+		move(pos, pos+a.size())
+	}
+}
+
+// shiftCellRef returns the shifted reference by given cell reference or
+// range reference without the worksheet name, the reference which can't be
+// parsed as cell coordinates keeps unchanged.
+func (a shiftArea) shiftCellRef(ref string) string {
+	type cellRef struct {
+		col, row       int
+		colAbs, rowAbs bool
+	}
+	var cellRefs []cellRef
+	for _, cell := range strings.Split(ref, ":") {
+		var (
+			r   cellRef
+			err error
+		)
+		r.colAbs = strings.HasPrefix(cell, "$")
+		cell = strings.TrimPrefix(cell, "$")
+		r.rowAbs = strings.Contains(cell, "$")
+		if r.col, r.row, err = CellNameToCoordinates(strings.ReplaceAll(cell, "$", "")); err != nil {
+			return ref
+		}
+		cellRefs = append(cellRefs, r)
+	}
+	if len(cellRefs) > 2 {
+		return ref
+	}
+	start, end := cellRefs[0], cellRefs[len(cellRefs)-1]
+	if start.col > end.col || start.row > end.row {
+		return ref
+	}
+	rect, ok := a.shiftRect([]int{start.col, start.row, end.col, end.row})
+	if !ok {
+		return formulaErrorREF
+	}
+	cellName := func(r cellRef, col, row int) string {
+		name, _ := ColumnNumberToName(col)
+		if r.colAbs {
+			name = "$" + name
+		}
+		if r.rowAbs {
+			name += "$"
+		}
+		return name + strconv.Itoa(row)
+	}
+	result := cellName(start, rect[0], rect[1])
+	if len(cellRefs) == 2 {
+		result += ":" + cellName(end, rect[2], rect[3])
+	}
+	return result
+}
+
+// shiftFormulaRef returns the formula with shifted references by given
+// worksheet name of the shifted cells, the worksheet name where the formula
+// is located and the formula.
+func (f *File) shiftFormulaRef(sheet, sheetN, formula string, area shiftArea) string {
+	var (
+		val          string
+		definedNames []string
+		ps           = efp.ExcelParser()
+	)
+	for _, definedName := range f.GetDefinedName() {
+		if definedName.Scope == definedNameScopeWorkbook || definedName.Scope == sheet {
+			definedNames = append(definedNames, definedName.Name)
+		}
+	}
+	for _, token := range ps.Parse(formula) {
+		if token.TType == efp.TokenTypeUnknown {
+			return formula
+		}
+		if token.TType == efp.TokenTypeOperand && token.TSubType == efp.TokenSubTypeRange {
+			if inStrSlice(definedNames, token.TValue, true) != -1 || strings.ContainsAny(token.TValue, "[]") {
+				val += token.TValue
+				continue
+			}
+			sheetName, operand, ref := sheetN, "", token.TValue
+			if tokens := strings.Split(token.TValue, "!"); len(tokens) == 2 {
+				sheetName = strings.ReplaceAll(strings.Trim(tokens[0], "'"), "''", "'")
+				operand, ref = tokens[0]+"!", tokens[1]
+			}
+			if sheetName != sheet {
+				val += token.TValue
+				continue
+			}
+			if ref = area.shiftCellRef(ref); ref == formulaErrorREF {
+				operand = ""
+			}
+			val += operand + ref
+			continue
+		}
+		if paren := transformParenthesesToken(token); paren != "" {
+			val += paren
+			continue
+		}
+		if token.TType == efp.TokenTypeOperand && token.TSubType == efp.TokenSubTypeText {
+			val += string(efp.QuoteDouble) + strings.ReplaceAll(token.TValue, "\"", "\"\"") + string(efp.QuoteDouble)
+			continue
+		}
+		val += token.TValue
+	}
+	return val
+}
+
+// shiftFormulas provides a function to update the formulas in the workbook
+// which reference the shifted cells.
+func (f *File) shiftFormulas(sheet string, area shiftArea) error {
+	for _, sheetN := range f.GetSheetList() {
+		ws, err := f.workSheetReader(sheetN)
+		if err != nil {
+			if err.Error() == newNotWorksheetError(sheetN).Error() {
+				continue
+			}
+			return err
+		}
+		si := map[int]bool{}
+		for r := range ws.SheetData.Row {
+			for c := range ws.SheetData.Row[r].C {
+				cell := &ws.SheetData.Row[r].C[c]
+				if cell.F == nil || cell.F.T != STCellFormulaTypeShared || cell.F.Si == nil || si[*cell.F.Si] {
+					continue
+				}
+				content := ws.copyFormula(cell.F, cell.R, 0, 0).Content
+				si[*cell.F.Si] = f.shiftFormulaRef(sheet, sheetN, content, area) != content
+			}
+		}
+		ws.convertSharedFormulas(si)
+		for r := range ws.SheetData.Row {
+			for c := range ws.SheetData.Row[r].C {
+				cell := &ws.SheetData.Row[r].C[c]
+				if cell.f != "" {
+					cell.f = f.shiftFormulaRef(sheet, sheetN, cell.f, area)
+				}
+				if cell.F == nil {
+					continue
+				}
+				if cell.F.Content != "" {
+					cell.F.Content = f.shiftFormulaRef(sheet, sheetN, cell.F.Content, area)
+				}
+				if cell.F.T == STCellFormulaTypeArray && cell.F.Ref != "" && sheetN == sheet {
+					cell.F.Ref = area.shiftCellRef(cell.F.Ref)
+				}
+			}
+		}
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.DefinedNames != nil {
+		for i := range wb.DefinedNames.DefinedName {
+			definedName := &wb.DefinedNames.DefinedName[i]
+			definedName.Data = f.shiftFormulaRef(sheet, "", definedName.Data, area)
+		}
+	}
+	return nil
+}
+
+// shiftMergeCells provides a function to update the merged cells in the
+// shifted cells, the merged cells which have been deleted will be removed.
+func (f *File) shiftMergeCells(ws *xlsxWorksheet, area shiftArea) error {
+	if ws.MergeCells == nil {
+		return nil
+	}
+	for i := 0; i < len(ws.MergeCells.Cells); i++ {
+		mergeCell := ws.MergeCells.Cells[i]
+		rect, err := mergeCell.Rect()
+		if err != nil {
+			return err
+		}
+		rect, ok := area.shiftRect(rect)
+		if !ok || (rect[0] == rect[2] && rect[1] == rect[3]) {
+			f.deleteMergeCell(ws, i)
+			i--
+			continue
+		}
+		mergeCell.rect = rect
+		if mergeCell.Ref, err = coordinatesToRangeRef(rect); err != nil {
+			return err
+		}
+	}
+	if len(ws.MergeCells.Cells) == 0 {
+		ws.MergeCells = nil
+	}
+	return nil
+}
+
+// shiftHyperlinks provides a function to update the hyperlinks in the
+// shifted cells, the hyperlinks which have been deleted will be removed.
+func (f *File) shiftHyperlinks(ws *xlsxWorksheet, sheet string, area shiftArea) {
+	if ws.Hyperlinks == nil {
+		return
+	}
+	for i := 0; i < len(ws.Hyperlinks.Hyperlink); i++ {
+		link := &ws.Hyperlinks.Hyperlink[i]
+		if ref := area.shiftCellRef(link.Ref); ref != formulaErrorREF {
+			link.Ref = ref
+			continue
+		}
+		f.deleteSheetRelationships(sheet, link.RID)
+		ws.Hyperlinks.Hyperlink = append(ws.Hyperlinks.Hyperlink[:i], ws.Hyperlinks.Hyperlink[i+1:]...)
+		i--
+	}
+	if len(ws.Hyperlinks.Hyperlink) == 0 {
+		ws.Hyperlinks = nil
+	}
+}
+
+// shiftCells provides a function to insert or delete cells in a range by
+// given worksheet name, sorted range coordinates, direction and whether
+// inserting cells. The other cells in the same rows or columns of the range
+// will be shifted, and the formulas, merged cells and hyperlinks referencing
+// the shifted cells will be adjusted. The calculation chain will be removed
+// to let the spreadsheet application rebuild it.
+func (f *File) shiftCells(sheet string, coordinates []int, dir adjustDirection, insert bool) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	area := newShiftArea(coordinates, dir, insert)
+	if ws.MergeCells != nil {
+		for _, mergeCell := range ws.MergeCells.Cells {
+			rect, err := mergeCell.Rect()
+			if err != nil {
+				return err
+			}
+			if area.crossed(rect) {
+				return ErrShiftMergedCells
+			}
+		}
+	}
+	ws.mu.Lock()
+	if err = area.checkLimit(ws); err != nil {
+		ws.mu.Unlock()
+		return err
+	}
+	col1, row1 := area.coordinates(area.start, area.from)
+	col2, row2 := area.coordinates(area.lastPos(ws), area.to)
+	ws.unshareFormulas([]int{col1, row1, col2, row2})
+	area.moveCells(ws)
+	ws.mu.Unlock()
+	if err = f.shiftFormulas(sheet, area); err != nil {
+		return err
+	}
+	if err = f.shiftMergeCells(ws, area); err != nil {
+		return err
+	}
+	f.shiftHyperlinks(ws, sheet, area)
+	return f.removeCalcChain()
+}
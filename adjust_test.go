@@ -570,9 +570,9 @@ func TestAdjustFormula(t *testing.T) {
 	assert.Equal(t, newCellNameToCoordinatesError("-", newInvalidCellNameError("-")), f.adjustFormula("Sheet1", "Sheet1", &xlsxC{F: &xlsxF{Ref: "-"}}, rows, 0, 0, false))
 	assert.Equal(t, ErrColumnNumber, f.adjustFormula("Sheet1", "Sheet1", &xlsxC{F: &xlsxF{Ref: "XFD1:XFD1"}}, columns, 0, 1, false))
 
-	_, err := f.adjustFormulaRef("Sheet1", "Sheet1", "XFE1", false, columns, 0, 1)
+	_, err := f.adjustFormulaRef("Sheet1", "Sheet1", "XFE1", false, false, columns, 0, 1)
 	assert.Equal(t, ErrColumnNumber, err)
-	_, err = f.adjustFormulaRef("Sheet1", "Sheet1", "XFD1", false, columns, 0, 1)
+	_, err = f.adjustFormulaRef("Sheet1", "Sheet1", "XFD1", false, false, columns, 0, 1)
 	assert.Equal(t, ErrColumnNumber, err)
 
 	f = NewFile()
@@ -944,6 +944,63 @@ func TestAdjustFormula(t *testing.T) {
 			assert.Equal(t, preset[3], formula)
 		}
 	})
+	t.Run("for_cross_sheet_ref_with_rows_remove", func(t *testing.T) {
+		f := NewFile()
+		_, err := f.NewSheet("Sheet2")
+		assert.NoError(t, err)
+		// Tests the references to the removed row should be replaced with the
+		// "#REF!" error value, and the range boundaries should be shrunk
+		tbl := [][]string{
+			{"A1", "Sheet2!A2+Sheet2!$A$3+Sheet2!A4", "#REF!+Sheet2!$A$2+Sheet2!A3", "#REF!+#REF!+Sheet2!A2"},
+			{"A2", "SUM(Sheet2!A1:A3)", "SUM(Sheet2!A1:A2)", "SUM(Sheet2!A1:A1)"},
+			{"A3", "SUM(Sheet2!A2:A4)", "SUM(Sheet2!A2:A3)", "SUM(Sheet2!A2:A2)"},
+			{"A4", "SUM(Sheet2!A2:B2)", "SUM(#REF!)", "SUM(#REF!)"},
+			{"A5", "SUM(Sheet2!2:4,Sheet2!A:A)", "SUM(Sheet2!2:3,Sheet2!A:A)", "SUM(Sheet2!2:2,Sheet2!A:A)"},
+			{"A6", "Sheet1!A2+A2", "Sheet1!A2+A2", "Sheet1!A2+A2"},
+		}
+		for _, preset := range tbl {
+			assert.NoError(t, f.SetCellFormula("Sheet1", preset[0], preset[1]))
+		}
+		// Test adjust formula on remove row in the middle of the range
+		assert.NoError(t, f.RemoveRow("Sheet2", 2))
+		for _, preset := range tbl {
+			formula, err := f.GetCellFormula("Sheet1", preset[0])
+			assert.NoError(t, err)
+			assert.Equal(t, preset[2], formula)
+		}
+		// Test adjust formula on remove row in the start of the range
+		assert.NoError(t, f.RemoveRow("Sheet2", 2))
+		for _, preset := range tbl {
+			formula, err := f.GetCellFormula("Sheet1", preset[0])
+			assert.NoError(t, err)
+			assert.Equal(t, preset[3], formula)
+		}
+	})
+	t.Run("for_cross_sheet_ref_with_cols_remove", func(t *testing.T) {
+		f := NewFile()
+		_, err := f.NewSheet("Sheet2")
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Name1", RefersTo: "Sheet2!$B$1:$D$1"}))
+		assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Name2", RefersTo: "Sheet2!$B$1"}))
+		tbl := [][]string{
+			{"A1", "Sheet2!B1+Sheet2!$C1+Sheet2!D1", "#REF!+Sheet2!$B1+Sheet2!C1"},
+			{"A2", "SUM(Sheet2!B1:D1)", "SUM(Sheet2!B1:C1)"},
+			{"A3", "SUM(Sheet2!B:B)+SUM(Sheet2!A:C)", "SUM(#REF!)+SUM(Sheet2!A:B)"},
+		}
+		for _, preset := range tbl {
+			assert.NoError(t, f.SetCellFormula("Sheet1", preset[0], preset[1]))
+		}
+		// Test adjust formula and defined names on remove column
+		assert.NoError(t, f.RemoveCol("Sheet2", "B"))
+		for _, preset := range tbl {
+			formula, err := f.GetCellFormula("Sheet1", preset[0])
+			assert.NoError(t, err)
+			assert.Equal(t, preset[2], formula)
+		}
+		definedNames := f.GetDefinedName()
+		assert.Equal(t, "Sheet2!$B$1:$C$1", definedNames[0].RefersTo)
+		assert.Equal(t, "#REF!", definedNames[1].RefersTo)
+	})
 	t.Run("for_cross_sheet_ref_with_chart_sheet)", func(t *testing.T) {
 		assert.NoError(t, f.AddChartSheet("Chart1", &Chart{Type: Line}))
 		assert.NoError(t, f.InsertRows("Sheet1", 2, 1))
@@ -1296,7 +1353,7 @@ func TestAdjustDefinedNames(t *testing.T) {
 	}))
 	assert.NoError(t, f.RemoveCol("Sheet1", "A"))
 	definedNames = f.GetDefinedName()
-	assert.Equal(t, "Sheet1!$A$1", definedNames[0].RefersTo)
+	assert.Equal(t, "#REF!", definedNames[0].RefersTo)
 
 	f = NewFile()
 	assert.NoError(t, f.SetDefinedName(&DefinedName{
@@ -1313,3 +1370,89 @@ func TestAdjustDefinedNames(t *testing.T) {
 	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
 	assert.EqualError(t, f.adjustDefinedNames(nil, "Sheet1", columns, 0, 0, 1), "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestAdjustCharts(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	for idx, row := range [][]interface{}{{nil, "Apple"}, {"Small", 2}, {"Normal", 5}, {"Large", 6}} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet 2", cell, &row))
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "A1", &Chart{
+		Type: Col,
+		Series: []ChartSeries{
+			{Name: "'Sheet 2'!$B$1", Categories: "'Sheet 2'!$A$2:$A$4", Values: "'Sheet 2'!$B$2:$B$4"},
+		},
+	}))
+	assert.NoError(t, f.InsertCols("Sheet 2", "A", 2))
+	charts, err := f.GetCharts("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 1)
+	assert.Equal(t, "'Sheet 2'!$D$1", charts[0].Series[0].Name)
+	assert.Equal(t, "'Sheet 2'!$C$2:$C$4", charts[0].Series[0].Categories)
+	assert.Equal(t, "'Sheet 2'!$D$2:$D$4", charts[0].Series[0].Values)
+	assert.NoError(t, f.RemoveCol("Sheet 2", "A"))
+	charts, err = f.GetCharts("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "'Sheet 2'!$B$2:$B$4", charts[0].Series[0].Categories)
+	// Test adjust charts with references on the other worksheet
+	assert.NoError(t, f.InsertCols("Sheet1", "A", 1))
+	charts, err = f.GetCharts("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "'Sheet 2'!$C$2:$C$4", charts[0].Series[0].Values)
+	// Test adjust charts with invalid references
+	f.Pkg.Store("xl/charts/chart1.xml", []byte(`<chartSpace><f>'Sheet 2'!$XFD$1</f></chartSpace>`))
+	assert.Equal(t, ErrColumnNumber, f.InsertCols("Sheet 2", "A", 1))
+	assert.NoError(t, f.Close())
+}
+
+func TestAdjustPivotCaches(t *testing.T) {
+	f := NewFile()
+	pivotCacheXML := "xl/pivotCache/pivotCacheDefinition1.xml"
+	f.Pkg.Store(pivotCacheXML, []byte(`<pivotCacheDefinition xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><cacheSource type="worksheet"><worksheetSource ref="B1:E31" sheet="Sheet1"/></cacheSource></pivotCacheDefinition>`))
+	assert.NoError(t, f.InsertCols("Sheet1", "A", 2))
+	pc, err := f.pivotCacheReader(pivotCacheXML)
+	assert.NoError(t, err)
+	assert.Equal(t, "D1:G31", pc.CacheSource.WorksheetSource.Ref)
+	assert.NoError(t, f.RemoveRow("Sheet1", 2))
+	pc, err = f.pivotCacheReader(pivotCacheXML)
+	assert.NoError(t, err)
+	assert.Equal(t, "D1:G30", pc.CacheSource.WorksheetSource.Ref)
+	// Test adjust pivot caches with source on the other worksheet
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.InsertCols("Sheet2", "A", 2))
+	pc, err = f.pivotCacheReader(pivotCacheXML)
+	assert.NoError(t, err)
+	assert.Equal(t, "D1:G30", pc.CacheSource.WorksheetSource.Ref)
+	// Test adjust pivot caches with deleting the first column of the source
+	assert.NoError(t, f.RemoveCol("Sheet1", "D"))
+	pc, err = f.pivotCacheReader(pivotCacheXML)
+	assert.NoError(t, err)
+	assert.Equal(t, "D1:F30", pc.CacheSource.WorksheetSource.Ref)
+	// Test adjust pivot caches with deleting all columns of the source
+	f.Pkg.Store(pivotCacheXML, []byte(`<pivotCacheDefinition xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><cacheSource type="worksheet"><worksheetSource ref="B1:B31" sheet="Sheet1"/></cacheSource></pivotCacheDefinition>`))
+	assert.NoError(t, f.RemoveCol("Sheet1", "B"))
+	pc, err = f.pivotCacheReader(pivotCacheXML)
+	assert.NoError(t, err)
+	assert.Equal(t, "#REF!", pc.CacheSource.WorksheetSource.Ref)
+	assert.NoError(t, f.InsertRows("Sheet1", 1, 1))
+	pc, err = f.pivotCacheReader(pivotCacheXML)
+	assert.NoError(t, err)
+	assert.Equal(t, "#REF!", pc.CacheSource.WorksheetSource.Ref)
+	// Test adjust pivot caches with the source shifted out of the worksheet
+	f.Pkg.Store(pivotCacheXML, []byte(`<pivotCacheDefinition xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><cacheSource type="worksheet"><worksheetSource ref="A1:XFD10" sheet="Sheet1"/></cacheSource></pivotCacheDefinition>`))
+	assert.NoError(t, f.InsertCols("Sheet1", "B", 1))
+	pc, err = f.pivotCacheReader(pivotCacheXML)
+	assert.NoError(t, err)
+	assert.Equal(t, "#REF!", pc.CacheSource.WorksheetSource.Ref)
+	// Test adjust pivot caches with invalid source reference
+	f.Pkg.Store(pivotCacheXML, []byte(`<pivotCacheDefinition xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><cacheSource type="worksheet"><worksheetSource ref="A" sheet="Sheet1"/></cacheSource></pivotCacheDefinition>`))
+	assert.Equal(t, ErrParameterInvalid, f.InsertCols("Sheet1", "A", 1))
+	// Test adjust pivot caches with unsupported charset
+	f.Pkg.Store(pivotCacheXML, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.InsertCols("Sheet1", "A", 1), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
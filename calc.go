@@ -804,6 +804,7 @@ type formulaFuncs struct {
 //	WORKDAY.INTL
 //	XIRR
 //	XLOOKUP
+//	XMATCH
 //	XNPV
 //	XOR
 //	YEAR
@@ -1640,7 +1641,7 @@ func (f *File) cellResolver(ctx *calcContext, sheet, cell string) (formulaArg, e
 		return arg, err
 	}
 	arg = newStringFormulaArg(value)
-	cellType, _ := f.GetCellType(sheet, cell)
+	cellType, _ := f.getCellType(sheet, cell, false)
 	switch cellType {
 	case CellTypeBool:
 		return arg.ToBool(), err
@@ -12082,12 +12083,12 @@ func (fn *formulaFuncs) DATE(argsList *list.List) formulaArg {
 		return newErrorFormulaArg(formulaErrorVALUE, "DATE requires 3 number arguments")
 	}
 	d := makeDate(int(year.Number), time.Month(month.Number), int(day.Number))
-	return newNumberFormulaArg(daysBetween(excelMinTime1900.Unix(), d) + 1)
+	return newNumberFormulaArg(fn.toDateSystem(daysBetween(excelMinTime1900.Unix(), d) + 1))
 }
 
 // calcDateDif is an implementation of the formula function DATEDIF,
 // calculation difference between two dates.
-func calcDateDif(unit string, diff float64, seq []int, startArg, endArg formulaArg) float64 {
+func (fn *formulaFuncs) calcDateDif(unit string, diff float64, seq []int, startArg, endArg formulaArg) float64 {
 	ey, sy, em, sm, ed, sd := seq[0], seq[1], seq[2], seq[3], seq[4], seq[5]
 	switch unit {
 	case "d":
@@ -12097,7 +12098,7 @@ func calcDateDif(unit string, diff float64, seq []int, startArg, endArg formulaA
 		if ed < sd {
 			smMD--
 		}
-		diff = endArg.Number - daysBetween(excelMinTime1900.Unix(), makeDate(ey, time.Month(smMD), sd)) - 1
+		diff = endArg.Number - fn.toDateSystem(daysBetween(excelMinTime1900.Unix(), makeDate(ey, time.Month(smMD), sd))+1)
 	case "ym":
 		diff = float64(em - sm)
 		if ed < sd {
@@ -12137,7 +12138,7 @@ func (fn *formulaFuncs) DATEDIF(argsList *list.List) formulaArg {
 		return newNumberFormulaArg(0)
 	}
 	unit := strings.ToLower(argsList.Back().Value.(formulaArg).Value())
-	startDate, endDate := timeFromExcelTime(startArg.Number, false), timeFromExcelTime(endArg.Number, false)
+	startDate, endDate := timeFromExcelTime(startArg.Number, fn.date1904()), timeFromExcelTime(endArg.Number, fn.date1904())
 	sy, smm, sd := startDate.Date()
 	ey, emm, ed := endDate.Date()
 	sm, em, diff := int(smm), int(emm), 0.0
@@ -12159,7 +12160,7 @@ func (fn *formulaFuncs) DATEDIF(argsList *list.List) formulaArg {
 		}
 		diff = float64(yDiff*12 + mDiff)
 	case "d", "md", "ym", "yd":
-		diff = calcDateDif(unit, diff, []int{ey, sy, em, sm, ed, sd}, startArg, endArg)
+		diff = fn.calcDateDif(unit, diff, []int{ey, sy, em, sm, ed, sd}, startArg, endArg)
 	default:
 		return newErrorFormulaArg(formulaErrorVALUE, "DATEDIF has invalid unit")
 	}
@@ -12416,7 +12417,7 @@ func (fn *formulaFuncs) DATEVALUE(argsList *list.List) formulaArg {
 	if err.Type == ArgError {
 		return err
 	}
-	return newNumberFormulaArg(daysBetween(excelMinTime1900.Unix(), makeDate(y, time.Month(m), d)) + 1)
+	return newNumberFormulaArg(fn.toDateSystem(daysBetween(excelMinTime1900.Unix(), makeDate(y, time.Month(m), d)) + 1))
 }
 
 // DAY function returns the day of a date, represented by a serial number. The
@@ -12446,10 +12447,10 @@ func (fn *formulaFuncs) DAY(argsList *list.List) formulaArg {
 	if num.Number < 0 {
 		return newErrorFormulaArg(formulaErrorNUM, "DAY only accepts positive argument")
 	}
-	if num.Number <= 60 {
+	if num.Number <= 60 && !fn.date1904() {
 		return newNumberFormulaArg(math.Mod(num.Number, 31.0))
 	}
-	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, false).Day()))
+	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, fn.date1904()).Day()))
 }
 
 // DAYS function returns the number of days between two supplied dates. The
@@ -12479,15 +12480,15 @@ func (fn *formulaFuncs) DAYS360(argsList *list.List) formulaArg {
 	if argsList.Len() > 3 {
 		return newErrorFormulaArg(formulaErrorVALUE, "DAYS360 requires at most 3 arguments")
 	}
-	startDate := toExcelDateArg(argsList.Front().Value.(formulaArg))
+	startDate := fn.toExcelDateArg(argsList.Front().Value.(formulaArg))
 	if startDate.Type != ArgNumber {
 		return startDate
 	}
-	endDate := toExcelDateArg(argsList.Front().Next().Value.(formulaArg))
+	endDate := fn.toExcelDateArg(argsList.Front().Next().Value.(formulaArg))
 	if endDate.Type != ArgNumber {
 		return endDate
 	}
-	start, end := timeFromExcelTime(startDate.Number, false), timeFromExcelTime(endDate.Number, false)
+	start, end := timeFromExcelTime(startDate.Number, fn.date1904()), timeFromExcelTime(endDate.Number, fn.date1904())
 	sy, sm, sd, ey, em, ed := start.Year(), int(start.Month()), start.Day(), end.Year(), int(end.Month()), end.Day()
 	method := newBoolFormulaArg(false)
 	if argsList.Len() > 2 {
@@ -12545,7 +12546,7 @@ func (fn *formulaFuncs) ISOWEEKNUM(argsList *list.List) formulaArg {
 		if num.Number < 0 {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
-		_, weekNum = timeFromExcelTime(num.Number, false).ISOWeek()
+		_, weekNum = timeFromExcelTime(num.Number, fn.date1904()).ISOWeek()
 	}
 	return newNumberFormulaArg(float64(weekNum))
 }
@@ -12577,7 +12578,7 @@ func (fn *formulaFuncs) EDATE(argsList *list.List) formulaArg {
 		if num.Number < 0 {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
-		dateTime = timeFromExcelTime(num.Number, false)
+		dateTime = timeFromExcelTime(num.Number, fn.date1904())
 	}
 	month := argsList.Back().Value.(formulaArg).ToNumber()
 	if month.Type != ArgNumber {
@@ -12599,7 +12600,7 @@ func (fn *formulaFuncs) EDATE(argsList *list.List) formulaArg {
 			d = days
 		}
 	}
-	result, _ := timeToExcelTime(time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC), false)
+	result, _ := timeToExcelTime(time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC), fn.date1904())
 	return newNumberFormulaArg(result)
 }
 
@@ -12631,7 +12632,7 @@ func (fn *formulaFuncs) EOMONTH(argsList *list.List) formulaArg {
 		if num.Number < 0 {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
-		dateTime = timeFromExcelTime(num.Number, false)
+		dateTime = timeFromExcelTime(num.Number, fn.date1904())
 	}
 	months := argsList.Back().Value.(formulaArg).ToNumber()
 	if months.Type != ArgNumber {
@@ -12647,7 +12648,7 @@ func (fn *formulaFuncs) EOMONTH(argsList *list.List) formulaArg {
 	if m = m % 12; m < 0 {
 		m += 12
 	}
-	result, _ := timeToExcelTime(time.Date(y, time.Month(m+1), getDaysInMonth(y, m+1), 0, 0, 0, 0, time.UTC), false)
+	result, _ := timeToExcelTime(time.Date(y, time.Month(m+1), getDaysInMonth(y, m+1), 0, 0, 0, 0, time.UTC), fn.date1904())
 	return newNumberFormulaArg(result)
 }
 
@@ -12681,7 +12682,7 @@ func (fn *formulaFuncs) HOUR(argsList *list.List) formulaArg {
 	if num.Number < 0 {
 		return newErrorFormulaArg(formulaErrorNUM, "HOUR only accepts positive argument")
 	}
-	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, false).Hour()))
+	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, fn.date1904()).Hour()))
 }
 
 // MINUTE function returns an integer representing the minute component of a
@@ -12711,7 +12712,7 @@ func (fn *formulaFuncs) MINUTE(argsList *list.List) formulaArg {
 	if num.Number < 0 {
 		return newErrorFormulaArg(formulaErrorNUM, "MINUTE only accepts positive argument")
 	}
-	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, false).Minute()))
+	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, fn.date1904()).Minute()))
 }
 
 // MONTH function returns the month of a date represented by a serial number.
@@ -12741,7 +12742,7 @@ func (fn *formulaFuncs) MONTH(argsList *list.List) formulaArg {
 	if num.Number < 0 {
 		return newErrorFormulaArg(formulaErrorNUM, "MONTH only accepts positive argument")
 	}
-	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, false).Month()))
+	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, fn.date1904()).Month()))
 }
 
 // genWeekendMask generate weekend mask of a series of seven 0's and 1's which
@@ -12761,8 +12762,8 @@ func genWeekendMask(weekend int) []byte {
 }
 
 // isWorkday check if the date is workday.
-func isWorkday(weekendMask []byte, date float64) bool {
-	dateTime := timeFromExcelTime(date, false)
+func (fn *formulaFuncs) isWorkday(weekendMask []byte, date float64) bool {
+	dateTime := timeFromExcelTime(date, fn.date1904())
 	weekday := dateTime.Weekday()
 	if weekday == time.Sunday {
 		weekday = 7
@@ -12800,7 +12801,7 @@ func prepareWorkday(weekend formulaArg) ([]byte, int) {
 
 // toExcelDateArg function converts a text representation of a time, into an
 // Excel date time number formula argument.
-func toExcelDateArg(arg formulaArg) formulaArg {
+func (fn *formulaFuncs) toExcelDateArg(arg formulaArg) formulaArg {
 	num := arg.ToNumber()
 	if num.Type != ArgNumber {
 		dateString := strings.ToLower(arg.Value())
@@ -12813,7 +12814,7 @@ func toExcelDateArg(arg formulaArg) formulaArg {
 		if err.Type == ArgError {
 			return err
 		}
-		num.Number, _ = timeToExcelTime(time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC), false)
+		num.Number, _ = timeToExcelTime(time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC), fn.date1904())
 		return newNumberFormulaArg(num.Number)
 	}
 	if arg.Number < 0 {
@@ -12824,10 +12825,10 @@ func toExcelDateArg(arg formulaArg) formulaArg {
 
 // prepareHolidays function converts array type formula arguments to into an
 // Excel date time number formula arguments list.
-func prepareHolidays(args formulaArg) []int {
+func (fn *formulaFuncs) prepareHolidays(args formulaArg) []int {
 	var holidays []int
 	for _, arg := range args.ToList() {
-		num := toExcelDateArg(arg)
+		num := fn.toExcelDateArg(arg)
 		if num.Type != ArgNumber {
 			continue
 		}
@@ -12837,7 +12838,7 @@ func prepareHolidays(args formulaArg) []int {
 }
 
 // workdayIntl is an implementation of the formula function WORKDAY.INTL.
-func workdayIntl(endDate, sign int, holidays []int, weekendMask []byte, startDate float64) int {
+func (fn *formulaFuncs) workdayIntl(endDate, sign int, holidays []int, weekendMask []byte, startDate float64) int {
 	for i := 0; i < len(holidays); i++ {
 		holiday := holidays[i]
 		if sign > 0 {
@@ -12851,18 +12852,18 @@ func workdayIntl(endDate, sign int, holidays []int, weekendMask []byte, startDat
 		}
 		if sign > 0 {
 			if holiday > int(math.Ceil(startDate)) {
-				if isWorkday(weekendMask, float64(holiday)) {
+				if fn.isWorkday(weekendMask, float64(holiday)) {
 					endDate += sign
-					for !isWorkday(weekendMask, float64(endDate)) {
+					for !fn.isWorkday(weekendMask, float64(endDate)) {
 						endDate += sign
 					}
 				}
 			}
 		} else {
 			if holiday < int(math.Ceil(startDate)) {
-				if isWorkday(weekendMask, float64(holiday)) {
+				if fn.isWorkday(weekendMask, float64(holiday)) {
 					endDate += sign
-					for !isWorkday(weekendMask, float64(endDate)) {
+					for !fn.isWorkday(weekendMask, float64(endDate)) {
 						endDate += sign
 					}
 				}
@@ -12908,11 +12909,11 @@ func (fn *formulaFuncs) NETWORKDAYSdotINTL(argsList *list.List) formulaArg {
 	if argsList.Len() > 4 {
 		return newErrorFormulaArg(formulaErrorVALUE, "NETWORKDAYS.INTL requires at most 4 arguments")
 	}
-	startDate := toExcelDateArg(argsList.Front().Value.(formulaArg))
+	startDate := fn.toExcelDateArg(argsList.Front().Value.(formulaArg))
 	if startDate.Type != ArgNumber {
 		return startDate
 	}
-	endDate := toExcelDateArg(argsList.Front().Next().Value.(formulaArg))
+	endDate := fn.toExcelDateArg(argsList.Front().Next().Value.(formulaArg))
 	if endDate.Type != ArgNumber {
 		return endDate
 	}
@@ -12922,7 +12923,7 @@ func (fn *formulaFuncs) NETWORKDAYSdotINTL(argsList *list.List) formulaArg {
 	}
 	var holidays []int
 	if argsList.Len() == 4 {
-		holidays = prepareHolidays(argsList.Back().Value.(formulaArg))
+		holidays = fn.prepareHolidays(argsList.Back().Value.(formulaArg))
 		sort.Ints(holidays)
 	}
 	weekendMask, workdaysPerWeek := prepareWorkday(weekend)
@@ -12940,14 +12941,14 @@ func (fn *formulaFuncs) NETWORKDAYSdotINTL(argsList *list.List) formulaArg {
 	count := int(math.Floor(offset/7) * float64(workdaysPerWeek))
 	daysMod := int(offset) % 7
 	for daysMod >= 0 {
-		if isWorkday(weekendMask, endDate.Number-float64(daysMod)) {
+		if fn.isWorkday(weekendMask, endDate.Number-float64(daysMod)) {
 			count++
 		}
 		daysMod--
 	}
 	for i := 0; i < len(holidays); i++ {
 		holiday := float64(holidays[i])
-		if isWorkday(weekendMask, holiday) && holiday >= startDate.Number && holiday <= endDate.Number {
+		if fn.isWorkday(weekendMask, holiday) && holiday >= startDate.Number && holiday <= endDate.Number {
 			count--
 		}
 	}
@@ -12989,7 +12990,7 @@ func (fn *formulaFuncs) WORKDAYdotINTL(argsList *list.List) formulaArg {
 	if argsList.Len() > 4 {
 		return newErrorFormulaArg(formulaErrorVALUE, "WORKDAY.INTL requires at most 4 arguments")
 	}
-	startDate := toExcelDateArg(argsList.Front().Value.(formulaArg))
+	startDate := fn.toExcelDateArg(argsList.Front().Value.(formulaArg))
 	if startDate.Type != ArgNumber {
 		return startDate
 	}
@@ -13003,7 +13004,7 @@ func (fn *formulaFuncs) WORKDAYdotINTL(argsList *list.List) formulaArg {
 	}
 	var holidays []int
 	if argsList.Len() == 4 {
-		holidays = prepareHolidays(argsList.Back().Value.(formulaArg))
+		holidays = fn.prepareHolidays(argsList.Back().Value.(formulaArg))
 		sort.Ints(holidays)
 	}
 	if days.Number == 0 {
@@ -13021,13 +13022,13 @@ func (fn *formulaFuncs) WORKDAYdotINTL(argsList *list.List) formulaArg {
 	daysMod := int(days.Number) % workdaysPerWeek
 	endDate := int(math.Ceil(startDate.Number)) + offset*7
 	if daysMod == 0 {
-		for !isWorkday(weekendMask, float64(endDate)) {
+		for !fn.isWorkday(weekendMask, float64(endDate)) {
 			endDate -= sign
 		}
 	} else {
 		for daysMod != 0 {
 			endDate += sign
-			if isWorkday(weekendMask, float64(endDate)) {
+			if fn.isWorkday(weekendMask, float64(endDate)) {
 				if daysMod < 0 {
 					daysMod++
 					continue
@@ -13036,7 +13037,7 @@ func (fn *formulaFuncs) WORKDAYdotINTL(argsList *list.List) formulaArg {
 			}
 		}
 	}
-	return newNumberFormulaArg(float64(workdayIntl(endDate, sign, holidays, weekendMask, startDate.Number)))
+	return newNumberFormulaArg(float64(fn.workdayIntl(endDate, sign, holidays, weekendMask, startDate.Number)))
 }
 
 // YEAR function returns an integer representing the year of a supplied date.
@@ -13065,7 +13066,7 @@ func (fn *formulaFuncs) YEAR(argsList *list.List) formulaArg {
 	if num.Number < 0 {
 		return newErrorFormulaArg(formulaErrorNUM, "YEAR only accepts positive argument")
 	}
-	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, false).Year()))
+	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, fn.date1904()).Year()))
 }
 
 // yearFracBasisCond is an implementation of the yearFracBasis1.
@@ -13075,8 +13076,8 @@ func yearFracBasisCond(sy, sm, sd, ey, em, ed int) bool {
 
 // yearFracBasis0 function returns the fraction of a year that between two
 // supplied dates in US (NASD) 30/360 type of day.
-func yearFracBasis0(startDate, endDate float64) (dayDiff, daysInYear float64) {
-	startTime, endTime := timeFromExcelTime(startDate, false), timeFromExcelTime(endDate, false)
+func (fn *formulaFuncs) yearFracBasis0(startDate, endDate float64) (dayDiff, daysInYear float64) {
+	startTime, endTime := timeFromExcelTime(startDate, fn.date1904()), timeFromExcelTime(endDate, fn.date1904())
 	sy, smM, sd := startTime.Date()
 	ey, emM, ed := endTime.Date()
 	sm, em := int(smM), int(emM)
@@ -13098,8 +13099,8 @@ func yearFracBasis0(startDate, endDate float64) (dayDiff, daysInYear float64) {
 
 // yearFracBasis1 function returns the fraction of a year that between two
 // supplied dates in actual type of day.
-func yearFracBasis1(startDate, endDate float64) (dayDiff, daysInYear float64) {
-	startTime, endTime := timeFromExcelTime(startDate, false), timeFromExcelTime(endDate, false)
+func (fn *formulaFuncs) yearFracBasis1(startDate, endDate float64) (dayDiff, daysInYear float64) {
+	startTime, endTime := timeFromExcelTime(startDate, fn.date1904()), timeFromExcelTime(endDate, fn.date1904())
 	sy, smM, sd := startTime.Date()
 	ey, emM, ed := endTime.Date()
 	sm, em := int(smM), int(emM)
@@ -13127,8 +13128,8 @@ func yearFracBasis1(startDate, endDate float64) (dayDiff, daysInYear float64) {
 
 // yearFracBasis4 function returns the fraction of a year that between two
 // supplied dates in European 30/360 type of day.
-func yearFracBasis4(startDate, endDate float64) (dayDiff, daysInYear float64) {
-	startTime, endTime := timeFromExcelTime(startDate, false), timeFromExcelTime(endDate, false)
+func (fn *formulaFuncs) yearFracBasis4(startDate, endDate float64) (dayDiff, daysInYear float64) {
+	startTime, endTime := timeFromExcelTime(startDate, fn.date1904()), timeFromExcelTime(endDate, fn.date1904())
 	sy, smM, sd := startTime.Date()
 	ey, emM, ed := endTime.Date()
 	sm, em := int(smM), int(emM)
@@ -13144,17 +13145,17 @@ func yearFracBasis4(startDate, endDate float64) (dayDiff, daysInYear float64) {
 }
 
 // yearFrac is an implementation of the formula function YEARFRAC.
-func yearFrac(startDate, endDate float64, basis int) formulaArg {
-	startTime, endTime := timeFromExcelTime(startDate, false), timeFromExcelTime(endDate, false)
+func (fn *formulaFuncs) yearFrac(startDate, endDate float64, basis int) formulaArg {
+	startTime, endTime := timeFromExcelTime(startDate, fn.date1904()), timeFromExcelTime(endDate, fn.date1904())
 	if startTime == endTime {
 		return newNumberFormulaArg(0)
 	}
 	var dayDiff, daysInYear float64
 	switch basis {
 	case 0:
-		dayDiff, daysInYear = yearFracBasis0(startDate, endDate)
+		dayDiff, daysInYear = fn.yearFracBasis0(startDate, endDate)
 	case 1:
-		dayDiff, daysInYear = yearFracBasis1(startDate, endDate)
+		dayDiff, daysInYear = fn.yearFracBasis1(startDate, endDate)
 	case 2:
 		dayDiff = endDate - startDate
 		daysInYear = 360
@@ -13162,7 +13163,7 @@ func yearFrac(startDate, endDate float64, basis int) formulaArg {
 		dayDiff = endDate - startDate
 		daysInYear = 365
 	case 4:
-		dayDiff, daysInYear = yearFracBasis4(startDate, endDate)
+		dayDiff, daysInYear = fn.yearFracBasis4(startDate, endDate)
 	default:
 		return newErrorFormulaArg(formulaErrorNUM, "invalid basis")
 	}
@@ -13205,7 +13206,7 @@ func (fn *formulaFuncs) YEARFRAC(argsList *list.List) formulaArg {
 			return basis
 		}
 	}
-	return yearFrac(start.Number, end.Number, int(basis.Number))
+	return fn.yearFrac(start.Number, end.Number, int(basis.Number))
 }
 
 // NOW function returns the current date and time. The function receives no
@@ -13218,7 +13219,7 @@ func (fn *formulaFuncs) NOW(argsList *list.List) formulaArg {
 	}
 	now := time.Now()
 	_, offset := now.Zone()
-	return newNumberFormulaArg(25569.0 + float64(now.Unix()+int64(offset))/86400)
+	return newNumberFormulaArg(fn.toDateSystem(25569.0 + float64(now.Unix()+int64(offset))/86400))
 }
 
 // SECOND function returns an integer representing the second component of a
@@ -13248,7 +13249,7 @@ func (fn *formulaFuncs) SECOND(argsList *list.List) formulaArg {
 	if num.Number < 0 {
 		return newErrorFormulaArg(formulaErrorNUM, "SECOND only accepts positive argument")
 	}
-	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, false).Second()))
+	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, fn.date1904()).Second()))
 }
 
 // TIME function accepts three integer arguments representing hours, minutes
@@ -13314,7 +13315,7 @@ func (fn *formulaFuncs) TODAY(argsList *list.List) formulaArg {
 	}
 	now := time.Now()
 	_, offset := now.Zone()
-	return newNumberFormulaArg(daysBetween(excelMinTime1900.Unix(), now.Unix()+int64(offset)) + 1)
+	return newNumberFormulaArg(fn.toDateSystem(daysBetween(excelMinTime1900.Unix(), now.Unix()+int64(offset)) + 1))
 }
 
 // makeDate return date as a Unix time, the number of seconds elapsed since
@@ -13327,6 +13328,20 @@ func makeDate(y int, m time.Month, d int) int64 {
 	return date.Unix()
 }
 
+// date1904 returns if the workbook of the formula uses the 1904 date system.
+func (fn *formulaFuncs) date1904() bool {
+	return fn.f != nil && fn.f.isDate1904()
+}
+
+// toDateSystem converts the serial number in the 1900 date system to the date
+// system used by the workbook of the formula.
+func (fn *formulaFuncs) toDateSystem(serial float64) float64 {
+	if fn.date1904() {
+		return serial - 1462
+	}
+	return serial
+}
+
 // daysBetween return time interval of the given start timestamp and end
 // timestamp.
 func daysBetween(startDate, endDate int64) float64 {
@@ -13363,7 +13378,7 @@ func (fn *formulaFuncs) WEEKDAY(argsList *list.List) formulaArg {
 		if num.Number < 0 {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
-		weekday = int(timeFromExcelTime(num.Number, false).Weekday())
+		weekday = int(timeFromExcelTime(num.Number, fn.date1904()).Weekday())
 	}
 	if argsList.Len() == 2 {
 		returnTypeArg := argsList.Back().Value.(formulaArg).ToNumber()
@@ -13455,7 +13470,7 @@ func (fn *formulaFuncs) WEEKNUM(argsList *list.List) formulaArg {
 		if num.Number < 0 {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
-		snTime = timeFromExcelTime(num.Number, false)
+		snTime = timeFromExcelTime(num.Number, fn.date1904())
 	}
 	if argsList.Len() == 2 {
 		returnTypeArg := argsList.Back().Value.(formulaArg).ToNumber()
@@ -14473,7 +14488,7 @@ func (fn *formulaFuncs) VALUE(argsList *list.List) formulaArg {
 	y, m, d, _, err := strToDate(text)
 	errDate := err.Type == ArgError
 	if !errDate {
-		dateValue = daysBetween(excelMinTime1900.Unix(), makeDate(y, time.Month(m), d)) + 1
+		dateValue = fn.toDateSystem(daysBetween(excelMinTime1900.Unix(), makeDate(y, time.Month(m), d)) + 1)
 	}
 	if errTime && errDate {
 		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
@@ -15434,6 +15449,56 @@ func (fn *formulaFuncs) XLOOKUP(argsList *list.List) formulaArg {
 	return fn.xlookup(lookupRows, lookupCols, returnArrayRows, returnArrayCols, matchIdx, condition1, condition2, condition3, condition4, returnArray)
 }
 
+// XMATCH function searches for a specified item in a range or an array, and
+// then returns the item's relative position. The syntax of the function is:
+//
+//	XMATCH(lookup_value,lookup_array,[match_mode],[search_mode])
+func (fn *formulaFuncs) XMATCH(argsList *list.List) formulaArg {
+	if argsList.Len() < 2 {
+		return newErrorFormulaArg(formulaErrorVALUE, "XMATCH requires at least 2 arguments")
+	}
+	if argsList.Len() > 4 {
+		return newErrorFormulaArg(formulaErrorVALUE, "XMATCH allows at most 4 arguments")
+	}
+	lookupValue := argsList.Front().Value.(formulaArg)
+	lookupArray := argsList.Front().Next().Value.(formulaArg)
+	matchMode, searchMode := newNumberFormulaArg(matchModeExact), newNumberFormulaArg(searchModeLinear)
+	if argsList.Len() > 2 {
+		if matchMode = argsList.Front().Next().Next().Value.(formulaArg).ToNumber(); matchMode.Type != ArgNumber {
+			return matchMode
+		}
+	}
+	if argsList.Len() > 3 {
+		if searchMode = argsList.Back().Value.(formulaArg).ToNumber(); searchMode.Type != ArgNumber {
+			return searchMode
+		}
+	}
+	if lookupArray.Type != ArgMatrix {
+		return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
+	}
+	if !validateMatchMode(matchMode.Number) || !validateSearchMode(searchMode.Number) {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	lookupRows, lookupCols := len(lookupArray.Matrix), 0
+	if lookupRows > 0 {
+		lookupCols = len(lookupArray.Matrix[0])
+	}
+	if lookupRows != 1 && lookupCols != 1 {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	var matchIdx int
+	switch searchMode.Number {
+	case searchModeLinear, searchModeReverseLinear:
+		matchIdx, _ = lookupLinearSearch(lookupRows >= lookupCols, lookupValue, lookupArray, matchMode, searchMode)
+	default:
+		matchIdx, _ = lookupBinarySearch(lookupRows >= lookupCols, lookupValue, lookupArray, matchMode, searchMode)
+	}
+	if matchIdx == -1 {
+		return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
+	}
+	return newNumberFormulaArg(float64(matchIdx + 1))
+}
+
 // INDEX function returns a reference to a cell that lies in a specified row
 // and column of a range of cells. The syntax of the function is:
 //
@@ -15680,7 +15745,7 @@ func (fn *formulaFuncs) ACCRINT(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
 		}
 	}
-	frac1 := yearFrac(issue.Number, settlement.Number, int(basis.Number))
+	frac1 := fn.yearFrac(issue.Number, settlement.Number, int(basis.Number))
 	if frac1.Type != ArgNumber {
 		return frac1
 	}
@@ -15717,7 +15782,7 @@ func (fn *formulaFuncs) ACCRINTM(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	frac := yearFrac(issue.Number, settlement.Number, int(basis.Number))
+	frac := fn.yearFrac(issue.Number, settlement.Number, int(basis.Number))
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -15799,7 +15864,7 @@ func (fn *formulaFuncs) AMORDEGRC(argsList *list.List) formulaArg {
 		amorCoeff = 2
 	}
 	rate.Number *= amorCoeff
-	frac := yearFrac(datePurchased.Number, firstPeriod.Number, int(basis.Number))
+	frac := fn.yearFrac(datePurchased.Number, firstPeriod.Number, int(basis.Number))
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -15837,7 +15902,7 @@ func (fn *formulaFuncs) AMORLINC(argsList *list.List) formulaArg {
 		return args
 	}
 	cost, datePurchased, firstPeriod, salvage, period, rate, basis := args.List[0], args.List[1], args.List[2], args.List[3], args.List[4], args.List[5], args.List[6]
-	frac := yearFrac(datePurchased.Number, firstPeriod.Number, int(basis.Number))
+	frac := fn.yearFrac(datePurchased.Number, firstPeriod.Number, int(basis.Number))
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -15962,8 +16027,8 @@ func (fn *formulaFuncs) COUPDAYBS(argsList *list.List) formulaArg {
 	if args.Type != ArgList {
 		return args
 	}
-	settlement := timeFromExcelTime(args.List[0].Number, false)
-	pcd := timeFromExcelTime(fn.COUPPCD(argsList).Number, false)
+	settlement := timeFromExcelTime(args.List[0].Number, fn.date1904())
+	pcd := timeFromExcelTime(fn.COUPPCD(argsList).Number, fn.date1904())
 	return newNumberFormulaArg(coupdays(pcd, settlement, int(args.List[3].Number)))
 }
 
@@ -15979,7 +16044,7 @@ func (fn *formulaFuncs) COUPDAYS(argsList *list.List) formulaArg {
 	freq := args.List[2].Number
 	basis := int(args.List[3].Number)
 	if basis == 1 {
-		pcd := timeFromExcelTime(fn.COUPPCD(argsList).Number, false)
+		pcd := timeFromExcelTime(fn.COUPPCD(argsList).Number, fn.date1904())
 		next := pcd.AddDate(0, 12/int(freq), 0)
 		return newNumberFormulaArg(coupdays(pcd, next, basis))
 	}
@@ -15995,16 +16060,16 @@ func (fn *formulaFuncs) COUPDAYSNC(argsList *list.List) formulaArg {
 	if args.Type != ArgList {
 		return args
 	}
-	settlement := timeFromExcelTime(args.List[0].Number, false)
+	settlement := timeFromExcelTime(args.List[0].Number, fn.date1904())
 	basis := int(args.List[3].Number)
-	ncd := timeFromExcelTime(fn.COUPNCD(argsList).Number, false)
+	ncd := timeFromExcelTime(fn.COUPNCD(argsList).Number, fn.date1904())
 	return newNumberFormulaArg(coupdays(settlement, ncd, basis))
 }
 
 // coupons is an implementation of the formula functions COUPNCD and COUPPCD.
 func (fn *formulaFuncs) coupons(name string, arg formulaArg) formulaArg {
-	settlement := timeFromExcelTime(arg.List[0].Number, false)
-	maturity := timeFromExcelTime(arg.List[1].Number, false)
+	settlement := timeFromExcelTime(arg.List[0].Number, fn.date1904())
+	maturity := timeFromExcelTime(arg.List[1].Number, fn.date1904())
 	maturityDays := (maturity.Year()-settlement.Year())*12 + (int(maturity.Month()) - int(settlement.Month()))
 	coupon := 12 / int(arg.List[2].Number)
 	mod := maturityDays % coupon
@@ -16032,7 +16097,7 @@ func (fn *formulaFuncs) coupons(name string, arg formulaArg) formulaArg {
 	} else if day > 27 && day > days {
 		day = days
 	}
-	return newNumberFormulaArg(daysBetween(excelMinTime1900.Unix(), makeDate(year, time.Month(month), day)) + 1)
+	return newNumberFormulaArg(fn.toDateSystem(daysBetween(excelMinTime1900.Unix(), makeDate(year, time.Month(month), day)) + 1))
 }
 
 // COUPNCD function calculates the number of coupons payable, between a
@@ -16058,7 +16123,7 @@ func (fn *formulaFuncs) COUPNUM(argsList *list.List) formulaArg {
 	if args.Type != ArgList {
 		return args
 	}
-	frac := yearFrac(args.List[0].Number, args.List[1].Number, 0)
+	frac := fn.yearFrac(args.List[0].Number, args.List[1].Number, 0)
 	return newNumberFormulaArg(math.Ceil(frac.Number * args.List[2].Number))
 }
 
@@ -16326,7 +16391,7 @@ func (fn *formulaFuncs) discIntrate(name string, argsList *list.List) formulaArg
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	frac := yearFrac(settlement.Number, maturity.Number, int(basis.Number))
+	frac := fn.yearFrac(settlement.Number, maturity.Number, int(basis.Number))
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -16483,7 +16548,7 @@ func (fn *formulaFuncs) prepareDurationArgs(name string, argsList *list.List) fo
 
 // duration is an implementation of the formula function DURATION.
 func (fn *formulaFuncs) duration(settlement, maturity, coupon, yld, frequency, basis formulaArg) formulaArg {
-	frac := yearFrac(settlement.Number, maturity.Number, int(basis.Number))
+	frac := fn.yearFrac(settlement.Number, maturity.Number, int(basis.Number))
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -17070,7 +17135,7 @@ func changeMonth(date time.Time, numMonths float64, returnLastMonth bool) time.T
 	newDate := date.AddDate(0, int(numMonths), offsetDay)
 	if returnLastMonth {
 		lastDay := getDaysInMonth(newDate.Year(), int(newDate.Month()))
-		return timeFromExcelTime(daysBetween(excelMinTime1900.Unix(), makeDate(newDate.Year(), newDate.Month(), lastDay))+1, false)
+		return time.Date(newDate.Year(), newDate.Month(), lastDay, 0, 0, 0, 0, time.UTC)
 	}
 	return newDate
 }
@@ -17101,8 +17166,8 @@ func datesAggregate(startDate, endDate time.Time, numMonths float64, f func(pcd,
 }
 
 // coupNumber is a part of implementation of the formula function ODDFPRICE.
-func coupNumber(maturity, settlement, numMonths float64) float64 {
-	maturityTime, settlementTime := timeFromExcelTime(maturity, false), timeFromExcelTime(settlement, false)
+func (fn *formulaFuncs) coupNumber(maturity, settlement, numMonths float64) float64 {
+	maturityTime, settlementTime := timeFromExcelTime(maturity, fn.date1904()), timeFromExcelTime(settlement, fn.date1904())
 	my, mm, md := maturityTime.Year(), maturityTime.Month(), maturityTime.Day()
 	sy, sm, sd := settlementTime.Year(), settlementTime.Month(), settlementTime.Day()
 	couponsTemp, endOfMonthTemp := 0.0, getDaysInMonth(my, int(mm)) == md
@@ -17206,10 +17271,10 @@ func (fn *formulaFuncs) ODDFPRICE(argsList *list.List) formulaArg {
 	if basisArg.Number < 0 || basisArg.Number > 4 {
 		return newErrorFormulaArg(formulaErrorNUM, "invalid basis")
 	}
-	issueTime := timeFromExcelTime(issue.Number, false)
-	settlementTime := timeFromExcelTime(settlement.Number, false)
-	maturityTime := timeFromExcelTime(maturity.Number, false)
-	firstCouponTime := timeFromExcelTime(firstCoupon.Number, false)
+	issueTime := timeFromExcelTime(issue.Number, fn.date1904())
+	settlementTime := timeFromExcelTime(settlement.Number, fn.date1904())
+	maturityTime := timeFromExcelTime(maturity.Number, fn.date1904())
+	firstCouponTime := timeFromExcelTime(firstCoupon.Number, fn.date1904())
 	basis := int(basisArg.Number)
 	monthDays := getDaysInMonth(maturityTime.Year(), int(maturityTime.Month()))
 	returnLastMonth := monthDays == maturityTime.Day()
@@ -17255,9 +17320,9 @@ func (fn *formulaFuncs) ODDFPRICE(argsList *list.List) formulaArg {
 	nc := fn.COUPNUM(fnArgs)
 	lastCoupon := firstCoupon.Number
 	aggrFunc := func(acc []float64, index float64) []float64 {
-		lastCouponTime := timeFromExcelTime(lastCoupon, false)
-		earlyCoupon := daysBetween(excelMinTime1900.Unix(), makeDate(lastCouponTime.Year(), time.Month(float64(lastCouponTime.Month())+numMonthsNeg), lastCouponTime.Day())) + 1
-		earlyCouponTime := timeFromExcelTime(earlyCoupon, false)
+		lastCouponTime := timeFromExcelTime(lastCoupon, fn.date1904())
+		earlyCoupon := fn.toDateSystem(daysBetween(excelMinTime1900.Unix(), makeDate(lastCouponTime.Year(), time.Month(float64(lastCouponTime.Month())+numMonthsNeg), lastCouponTime.Day())) + 1)
+		earlyCouponTime := timeFromExcelTime(earlyCoupon, fn.date1904())
 		nl := e.Number
 		if basis == 1 {
 			nl = coupdays(earlyCouponTime, lastCouponTime, basis)
@@ -17274,8 +17339,8 @@ func (fn *formulaFuncs) ODDFPRICE(argsList *list.List) formulaArg {
 		if settlement.Number < lastCoupon {
 			endDate = settlement.Number
 		}
-		startDateTime := timeFromExcelTime(startDate, false)
-		endDateTime := timeFromExcelTime(endDate, false)
+		startDateTime := timeFromExcelTime(startDate, fn.date1904())
+		endDateTime := timeFromExcelTime(endDate, fn.date1904())
 		a := coupdays(startDateTime, endDateTime, basis)
 		lastCoupon = earlyCoupon
 		dcnl := acc[0]
@@ -17290,14 +17355,14 @@ func (fn *formulaFuncs) ODDFPRICE(argsList *list.List) formulaArg {
 	fnArgs.PushBack(firstCoupon)
 	fnArgs.PushBack(frequency)
 	if basis == 2 || basis == 3 {
-		d := timeFromExcelTime(fn.COUPNCD(fnArgs).Number, false)
+		d := timeFromExcelTime(fn.COUPNCD(fnArgs).Number, fn.date1904())
 		dsc = coupdays(settlementTime, d, basis)
 	} else {
-		d := timeFromExcelTime(fn.COUPPCD(fnArgs).Number, false)
+		d := timeFromExcelTime(fn.COUPPCD(fnArgs).Number, fn.date1904())
 		a := coupdays(d, settlementTime, basis)
 		dsc = e.Number - a
 	}
-	nq := coupNumber(firstCoupon.Number, settlement.Number, numMonths)
+	nq := fn.coupNumber(firstCoupon.Number, settlement.Number, numMonths)
 	fnArgs.Init()
 	fnArgs.PushBack(firstCoupon)
 	fnArgs.PushBack(maturity)
@@ -17350,8 +17415,8 @@ func (fn *formulaFuncs) ODDFYIELD(argsList *list.List) formulaArg {
 	if basisArg.Number < 0 || basisArg.Number > 4 {
 		return newErrorFormulaArg(formulaErrorNUM, "invalid basis")
 	}
-	settlementTime := timeFromExcelTime(settlement.Number, false)
-	maturityTime := timeFromExcelTime(maturity.Number, false)
+	settlementTime := timeFromExcelTime(settlement.Number, fn.date1904())
+	maturityTime := timeFromExcelTime(maturity.Number, fn.date1904())
 	years := coupdays(settlementTime, maturityTime, int(basisArg.Number))
 	px := pr.Number - 100
 	num := rate.Number*years*100 - px
@@ -17437,8 +17502,8 @@ func (fn *formulaFuncs) oddl(name string, argsList *list.List) formulaArg {
 	if basisArg.Number < 0 || basisArg.Number > 4 {
 		return newErrorFormulaArg(formulaErrorNUM, "invalid basis")
 	}
-	settlementTime := timeFromExcelTime(settlement.Number, false)
-	maturityTime := timeFromExcelTime(maturity.Number, false)
+	settlementTime := timeFromExcelTime(settlement.Number, fn.date1904())
+	maturityTime := timeFromExcelTime(maturity.Number, fn.date1904())
 	basis := int(basisArg.Number)
 	numMonths := 12 / frequency.Number
 	fnArgs := list.New().Init()
@@ -17449,9 +17514,9 @@ func (fn *formulaFuncs) oddl(name string, argsList *list.List) formulaArg {
 	nc := fn.COUPNUM(fnArgs)
 	earlyCoupon := lastInterest.Number
 	aggrFunc := func(acc []float64, index float64) []float64 {
-		earlyCouponTime := timeFromExcelTime(earlyCoupon, false)
+		earlyCouponTime := timeFromExcelTime(earlyCoupon, fn.date1904())
 		lateCouponTime := changeMonth(earlyCouponTime, numMonths, false)
-		lateCoupon, _ := timeToExcelTime(lateCouponTime, false)
+		lateCoupon, _ := timeToExcelTime(lateCouponTime, fn.date1904())
 		nl := coupdays(earlyCouponTime, lateCouponTime, basis)
 		dci := coupdays(earlyCouponTime, maturityTime, basis)
 		if index < nc.Number {
@@ -17471,8 +17536,8 @@ func (fn *formulaFuncs) oddl(name string, argsList *list.List) formulaArg {
 		if maturity.Number < lateCoupon {
 			endDate = maturity.Number
 		}
-		startDateTime := timeFromExcelTime(startDate, false)
-		endDateTime := timeFromExcelTime(endDate, false)
+		startDateTime := timeFromExcelTime(startDate, fn.date1904())
+		endDateTime := timeFromExcelTime(endDate, fn.date1904())
 		dsc := coupdays(startDateTime, endDateTime, basis)
 		earlyCoupon = lateCoupon
 		dcnl := acc[0]
@@ -17737,7 +17802,7 @@ func (fn *formulaFuncs) PRICEDISC(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	frac := yearFrac(settlement.Number, maturity.Number, int(basis.Number))
+	frac := fn.yearFrac(settlement.Number, maturity.Number, int(basis.Number))
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -17783,12 +17848,12 @@ func (fn *formulaFuncs) PRICEMAT(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	dsm := yearFrac(settlement.Number, maturity.Number, int(basis.Number))
+	dsm := fn.yearFrac(settlement.Number, maturity.Number, int(basis.Number))
 	if dsm.Type != ArgNumber {
 		return dsm
 	}
-	dis := yearFrac(issue.Number, settlement.Number, int(basis.Number))
-	dim := yearFrac(issue.Number, maturity.Number, int(basis.Number))
+	dis := fn.yearFrac(issue.Number, settlement.Number, int(basis.Number))
+	dim := fn.yearFrac(issue.Number, maturity.Number, int(basis.Number))
 	return newNumberFormulaArg(((1+dim.Number*rate.Number)/(1+dsm.Number*yld.Number) - dis.Number*rate.Number) * 100)
 }
 
@@ -17940,7 +18005,7 @@ func (fn *formulaFuncs) RECEIVED(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	frac := yearFrac(settlement.Number, maturity.Number, int(basis.Number))
+	frac := fn.yearFrac(settlement.Number, maturity.Number, int(basis.Number))
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -18464,7 +18529,7 @@ func (fn *formulaFuncs) YIELDDISC(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	frac := yearFrac(settlement.Number, maturity.Number, int(basis.Number))
+	frac := fn.yearFrac(settlement.Number, maturity.Number, int(basis.Number))
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -18516,12 +18581,12 @@ func (fn *formulaFuncs) YIELDMAT(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	dim := yearFrac(issue.Number, maturity.Number, int(basis.Number))
+	dim := fn.yearFrac(issue.Number, maturity.Number, int(basis.Number))
 	if dim.Type != ArgNumber {
 		return dim
 	}
-	dis := yearFrac(issue.Number, settlement.Number, int(basis.Number))
-	dsm := yearFrac(settlement.Number, maturity.Number, int(basis.Number))
+	dis := fn.yearFrac(issue.Number, settlement.Number, int(basis.Number))
+	dsm := fn.yearFrac(settlement.Number, maturity.Number, int(basis.Number))
 	f1 := dim.Number * rate.Number
 	result := 1 + math.Nextafter(f1, f1)
 	result /= pr.Number/100 + dis.Number*rate.Number
@@ -5721,6 +5721,58 @@ func TestCalcXLOOKUP(t *testing.T) {
 	}
 }
 
+func TestCalcXMATCH(t *testing.T) {
+	cellData := [][]interface{}{
+		{"Salesperson", "Item", "Amont"},
+		{"B", "Apples", 30, 25, 15, 50, 45, 18},
+		{"L", "Oranges", 25},
+		{"C", "Grapes", 15},
+		{"L", "Lemons", 50},
+		{"L", "Oranges", 45},
+		{"C", "Peaches", 18},
+		{"B", "Pears", 40},
+		{"B", "Apples", 55},
+	}
+	f := prepareCalcData(cellData)
+	formulaList := map[string]string{
+		"=XMATCH(\"Grapes\",B2:B9)":       "3",
+		"=_xlfn.XMATCH(\"Grapes\",B2:B9)": "3",
+		"=XMATCH(45,C2:H2)":               "5",
+		// Test match mode with partial match (wildcards)
+		"=XMATCH(\"*p*\",B2:B9,2)": "1",
+		// Test match mode with approximate match (next larger item)
+		"=XMATCH(48,C2:H2,1)": "4",
+		// Test match mode with approximate match (next smaller item)
+		"=XMATCH(29,C2:H2,-1)": "2",
+		// Test search mode
+		"=XMATCH(\"L\",A2:A9,0,1)":  "2",
+		"=XMATCH(\"L\",A2:A9,0,-1)": "5",
+	}
+	for formula, expected := range formulaList {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "J1", formula))
+		result, err := f.CalcCellValue("Sheet1", "J1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	calcError := map[string][]string{
+		"=XMATCH()":                   {"#VALUE!", "XMATCH requires at least 2 arguments"},
+		"=XMATCH(\"L\",A2:A9,0,1,1)":  {"#VALUE!", "XMATCH allows at most 4 arguments"},
+		"=XMATCH(\"L\",A2)":           {"#N/A", "#N/A"},
+		"=XMATCH(\"L\",A2:B9)":        {"#VALUE!", "#VALUE!"},
+		"=XMATCH(\"L\",A2:A9,3)":      {"#VALUE!", "#VALUE!"},
+		"=XMATCH(\"L\",A2:A9,0,0)":    {"#VALUE!", "#VALUE!"},
+		"=XMATCH(\"L\",A2:A9,\"\")":   {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
+		"=XMATCH(\"L\",A2:A9,0,\"\")": {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
+		"=XMATCH(\"*p*\",B2:B9,0)":    {"#N/A", "#N/A"},
+	}
+	for formula, expected := range calcError {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "D3", formula))
+		result, err := f.CalcCellValue("Sheet1", "D3")
+		assert.Equal(t, expected[0], result, formula)
+		assert.EqualError(t, err, expected[1], formula)
+	}
+}
+
 func TestCalcXNPV(t *testing.T) {
 	cellData := [][]interface{}{
 		{nil, 0.05},
@@ -52,18 +52,38 @@ func (f *File) deleteCalcChain(index int, cell string) error {
 		})
 	}
 	if len(calc.C) == 0 {
-		f.CalcChain = nil
-		f.Pkg.Delete(defaultXMLPathCalcChain)
-		content, err := f.contentTypesReader()
-		if err != nil {
-			return err
+		return f.removeCalcChain()
+	}
+	return err
+}
+
+// removeCalcChain provides a function to remove the calculation chain part,
+// its content type and the workbook relationship to it from the workbook.
+func (f *File) removeCalcChain() error {
+	f.CalcChain = nil
+	f.Pkg.Delete(defaultXMLPathCalcChain)
+	content, err := f.contentTypesReader()
+	if err != nil {
+		return err
+	}
+	content.mu.Lock()
+	for k, v := range content.Overrides {
+		if v.PartName == "/xl/calcChain.xml" {
+			content.Overrides = append(content.Overrides[:k], content.Overrides[k+1:]...)
+			break
 		}
-		content.mu.Lock()
-		defer content.mu.Unlock()
-		for k, v := range content.Overrides {
-			if v.PartName == "/xl/calcChain.xml" {
-				content.Overrides = append(content.Overrides[:k], content.Overrides[k+1:]...)
-			}
+	}
+	content.mu.Unlock()
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	if err != nil || rels == nil {
+		return err
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for k := 0; k < len(rels.Relationships); k++ {
+		if rels.Relationships[k].Type == SourceRelationshipCalcChain {
+			rels.Relationships = append(rels.Relationships[:k], rels.Relationships[k+1:]...)
+			k--
 		}
 	}
 	return err
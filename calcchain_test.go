@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -44,3 +45,33 @@ func TestDeleteCalcChain(t *testing.T) {
 	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
 	assert.EqualError(t, f.deleteCalcChain(1, "A1"), "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestRemoveCalcChain(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "=A1"))
+	f.CalcChain = &xlsxCalcChain{C: []xlsxCalcChainC{{R: "B1", I: 1}}}
+	f.ContentTypes.Overrides = append(f.ContentTypes.Overrides, xlsxOverride{
+		PartName: "/xl/calcChain.xml",
+	})
+	rels, err := f.relsReader(defaultXMLPathWorkbookRels)
+	assert.NoError(t, err)
+	rels.Relationships = append(rels.Relationships, xlsxRelationship{
+		ID: "rId100", Type: SourceRelationshipCalcChain, Target: "calcChain.xml",
+	})
+	// Test remove calculation chain with the workbook relationship
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", 1))
+	assert.Nil(t, f.CalcChain)
+	for _, override := range f.ContentTypes.Overrides {
+		assert.NotEqual(t, "/xl/calcChain.xml", override.PartName)
+	}
+	for _, rel := range rels.Relationships {
+		assert.NotEqual(t, SourceRelationshipCalcChain, rel.Type)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemoveCalcChain.xlsx")))
+	assert.NoError(t, f.Close())
+	// Test remove calculation chain with unsupported charset workbook relationships
+	f = NewFile()
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.removeCalcChain(), "XML syntax error on line 1: invalid UTF-8")
+}
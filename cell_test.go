@@ -1,9 +1,14 @@
 package excelize
 
 import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"fmt"
 	_ "image/jpeg"
 	"math"
+	"math/big"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -12,6 +17,7 @@ import (
 	"sync"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -91,6 +97,11 @@ func TestConcurrency(t *testing.T) {
 			visible, err := f.GetColVisible("Sheet1", "A")
 			assert.NoError(t, err)
 			assert.Equal(t, true, visible)
+			// Concurrency set default row height
+			assert.NoError(t, f.SetDefaultRowHeight("Sheet1", 20))
+			// Concurrency get displayed row height
+			_, err = f.GetRowDisplayHeight("Sheet1", val)
+			assert.NoError(t, err)
 			// Concurrency add data validation
 			dv := NewDataValidation(true)
 			dv.Sqref = fmt.Sprintf("A%d:B%d", val, val)
@@ -116,6 +127,50 @@ func TestConcurrency(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestConcurrencyOnDifferentSheets(t *testing.T) {
+	f := NewFile()
+	for i := 2; i <= 5; i++ {
+		_, err := f.NewSheet(fmt.Sprintf("Sheet%d", i))
+		assert.NoError(t, err)
+	}
+	wg := new(sync.WaitGroup)
+	for i := 1; i <= 5; i++ {
+		wg.Add(1)
+		go func(sheet string, t *testing.T) {
+			defer wg.Done()
+			for row := 1; row <= 10; row++ {
+				// Concurrency set cell value on different worksheets
+				assert.NoError(t, f.SetCellValue(sheet, fmt.Sprintf("A%d", row), row))
+				// Concurrency merge cells on different worksheets
+				assert.NoError(t, f.MergeCell(sheet, fmt.Sprintf("B%d", row), fmt.Sprintf("C%d", row)))
+				// Concurrency add comments and form controls on different worksheets
+				assert.NoError(t, f.AddComment(sheet, Comment{Cell: fmt.Sprintf("D%d", row), Author: "Excelize", Text: sheet}))
+				assert.NoError(t, f.AddFormControl(sheet, FormControl{Cell: fmt.Sprintf("E%d", row), Type: FormControlButton, Text: sheet}))
+				assert.NoError(t, f.AddThreadedComment(sheet, ThreadedComment{Cell: fmt.Sprintf("F%d", row), Author: "Excelize", Text: sheet}))
+				_, err := f.GetComments(sheet)
+				assert.NoError(t, err)
+				_, err = f.GetFormControls(sheet)
+				assert.NoError(t, err)
+			}
+		}(fmt.Sprintf("Sheet%d", i), t)
+	}
+	wg.Wait()
+	for i := 1; i <= 5; i++ {
+		sheet := fmt.Sprintf("Sheet%d", i)
+		val, err := f.GetCellValue(sheet, "A10")
+		assert.NoError(t, err)
+		assert.Equal(t, "10", val)
+		comments, err := f.GetComments(sheet)
+		assert.NoError(t, err)
+		assert.Len(t, comments, 20)
+		formControls, err := f.GetFormControls(sheet)
+		assert.NoError(t, err)
+		assert.Len(t, formControls, 10)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestConcurrencyOnDifferentSheets.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
 func TestCheckCellInRangeRef(t *testing.T) {
 	f := NewFile()
 	expectedTrueCellInRangeRefList := [][2]string{
@@ -330,6 +385,91 @@ func TestSetCellValue(t *testing.T) {
 	}
 }
 
+func TestSetCellValueExtendedTypes(t *testing.T) {
+	f := NewFile()
+	num, str, bigInt := 1.5, "text", new(big.Int)
+	bigInt.SetString("123456789012345678901234567890", 10)
+	var (
+		nilNum    *float64
+		nilBigInt *big.Int
+		nilBigFlt *big.Float
+		nilNull   *sql.NullString
+	)
+	for cell, value := range map[string]interface{}{
+		"A1": &num, "A2": &str, "A3": nilNum,
+		"B1": json.Number("123"), "B2": json.Number("1.5e3"), "B3": json.Number("N/A"),
+		"C1": bigInt, "C2": big.NewFloat(0.25), "C3": nilBigInt, "C4": nilBigFlt,
+		"D1": sql.NullString{String: "null", Valid: true}, "D2": sql.NullString{},
+		"D3": sql.NullInt64{Int64: 10, Valid: true}, "D4": sql.NullFloat64{Float64: 2.5, Valid: true},
+		"D5": sql.NullBool{Bool: true, Valid: true}, "D6": &sql.NullInt32{Int32: 3, Valid: true}, "D7": nilNull,
+	} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value), cell)
+	}
+	// Test the digits of the big integer will be stored as is
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, "123456789012345678901234567890", ws.(*xlsxWorksheet).SheetData.Row[0].C[2].V)
+	for cell, expected := range map[string]struct {
+		value    string
+		cellType CellType
+	}{
+		"A1": {"1.5", CellTypeNumber}, "A2": {"text", CellTypeSharedString}, "A3": {"", CellTypeUnset},
+		"B1": {"123", CellTypeNumber}, "B2": {"1500", CellTypeNumber}, "B3": {"N/A", CellTypeSharedString},
+		"C1": {"1.23456789012346E+29", CellTypeNumber}, "C2": {"0.25", CellTypeNumber},
+		"C3": {"", CellTypeUnset}, "C4": {"", CellTypeUnset},
+		"D1": {"null", CellTypeSharedString}, "D2": {"", CellTypeUnset}, "D3": {"10", CellTypeNumber},
+		"D4": {"2.5", CellTypeNumber}, "D5": {"TRUE", CellTypeBool}, "D6": {"3", CellTypeNumber}, "D7": {"", CellTypeUnset},
+	} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.value, value, cell)
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.cellType, cellType, cell)
+	}
+	// Test set the non-decimal, non-finite or out of range numbers
+	hugeInt, hugeFlt := new(big.Int).Lsh(big.NewInt(1), 1100), new(big.Float).SetMantExp(big.NewFloat(1), 1100)
+	for cell, value := range map[string]interface{}{
+		"E1": json.Number("0x1p4"), "E2": json.Number("1e3"), "E3": json.Number("Inf"),
+		"E4": json.Number("NaN"), "E5": json.Number("1e400"), "E6": hugeInt,
+		"E7": hugeFlt, "E8": new(big.Float).SetInf(false), "E9": big.NewFloat(1e20),
+	} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value), cell)
+	}
+	for cell, expected := range map[string]struct {
+		value    string
+		cellType CellType
+	}{
+		"E1": {"16", CellTypeNumber}, "E2": {"1000", CellTypeNumber}, "E3": {"Inf", CellTypeSharedString},
+		"E4": {"NaN", CellTypeSharedString}, "E5": {"1e400", CellTypeSharedString}, "E6": {hugeInt.String(), CellTypeSharedString},
+		"E7": {hugeFlt.Text('g', -1), CellTypeSharedString}, "E8": {"+Inf", CellTypeSharedString}, "E9": {"1E+20", CellTypeNumber},
+	} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.value, value, cell)
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.cellType, cellType, cell)
+	}
+	// Test set cell value with nil will clear the value and formula
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "1+1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", nil))
+	formula, err := f.GetCellFormula("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	value, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Empty(t, value)
+	// Test set cell value with driver.Valuer returns error
+	assert.EqualError(t, f.SetCellValue("Sheet1", "F1", errValuer{}), "valuer error")
+}
+
+// errValuer implements the driver.Valuer interface which always returns an
+// error.
+type errValuer struct{}
+
+func (errValuer) Value() (driver.Value, error) { return nil, errors.New("valuer error") }
+
 func TestSetCellValues(t *testing.T) {
 	f := NewFile()
 	err := f.SetCellValue("Sheet1", "A1", time.Date(2010, time.December, 31, 0, 0, 0, 0, time.UTC))
@@ -348,11 +488,171 @@ func TestSetCellValues(t *testing.T) {
 	assert.Equal(t, v, "1600-12-31T00:00:00Z")
 }
 
+func TestSetCellStrSharedStrings(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 1000; row++ {
+		assert.NoError(t, f.SetCellStr("Sheet1", fmt.Sprintf("A%d", row), "Excelize"))
+		assert.NoError(t, f.SetCellStr("Sheet1", fmt.Sprintf("B%d", row), strconv.Itoa(row%10)))
+	}
+	// Test the identical strings reuse the shared string table items
+	sst, err := f.sharedStringsReader()
+	assert.NoError(t, err)
+	assert.Len(t, sst.SI, 11)
+	assert.Len(t, f.sharedStringsMap, 11)
+	assert.NoError(t, f.Close())
+
+	// Test set string type cell values as inline strings
+	f = NewFile(Options{InlineStrings: true})
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", " <Excelize> "))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"Excelize", 1}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	c := ws.(*xlsxWorksheet).SheetData.Row[0].C[0]
+	assert.Equal(t, "inlineStr", c.T)
+	assert.Equal(t, "preserve", c.IS.T.Space.Value)
+	assert.Equal(t, "inlineStr", ws.(*xlsxWorksheet).SheetData.Row[1].C[0].T)
+	sst, err = f.sharedStringsReader()
+	assert.NoError(t, err)
+	assert.Empty(t, sst.SI)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellStrSharedStrings.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestSetCellStrSharedStrings.xlsx"))
+	assert.NoError(t, err)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{" <Excelize> "}, {"Excelize", "1"}}, rows)
+	assert.NoError(t, f.Close())
+
+	// Test set inline strings exceeds the total number of cell characters
+	f = NewFile(Options{InlineStrings: true})
+	assert.NoError(t, f.SetCellStr("Sheet1", "A1", strings.Repeat("\u4e00", TotalCellChars+1)))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]string{strings.Repeat("a", TotalCellChars+1)}))
+	for _, cell := range []string{"A1", "A2"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, TotalCellChars, utf8.RuneCountInString(val))
+	}
+	assert.NoError(t, f.Close())
+}
+
 func TestSetCellBool(t *testing.T) {
 	f := NewFile()
 	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellBool("Sheet1", "A", true))
 	// Test set cell boolean data type value with invalid sheet name
 	assert.Equal(t, ErrSheetNameInvalid, f.SetCellBool("Sheet:1", "A1", true))
+	// Test boolean value round trip
+	assert.NoError(t, f.SetCellBool("Sheet1", "A1", true))
+	assert.NoError(t, f.SetCellBool("Sheet1", "B1", false))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C = append(ws.(*xlsxWorksheet).SheetData.Row[0].C,
+		xlsxC{R: "C1", T: "b", V: "true"}, xlsxC{R: "D1", T: "b", V: "false"})
+	path := filepath.Join("test", "TestSetCellBool.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	f, err := OpenFile(path)
+	assert.NoError(t, err)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"TRUE", "FALSE", "TRUE", "FALSE"}}, rows)
+	rows, err = f.GetRows("Sheet1", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1", "0", "true", "false"}}, rows)
+	cellType, err := f.GetCellType("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeBool, cellType)
+	assert.NoError(t, f.Close())
+}
+
+func TestSetCellError(t *testing.T) {
+	f := NewFile()
+	for _, value := range cellErrorValues {
+		assert.NoError(t, f.SetCellError("Sheet1", "A1", value))
+		val, err := f.GetCellError("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Equal(t, value, val)
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "NA()"))
+	assert.NoError(t, f.SetCellError("Sheet1", "B1", "#N/A"))
+	formula, err := f.GetCellFormula("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	// Test the error value can be distinguished from the string
+	assert.NoError(t, f.SetCellStr("Sheet1", "C1", "#N/A"))
+	path := filepath.Join("test", "TestSetCellError.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	for cell, expected := range map[string][]interface{}{
+		"B1": {"#N/A", "#N/A", CellTypeError},
+		"C1": {"#N/A", "", CellTypeSharedString},
+		"D1": {"", "", CellTypeUnset},
+	} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[0], val, cell)
+		val, err = f.GetCellError("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[1], val, cell)
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[2], cellType, cell)
+	}
+	assert.NoError(t, f.Close())
+	// Test set cell error with invalid error value
+	assert.Equal(t, ErrParameterInvalid, f.SetCellError("Sheet1", "A1", "#ERROR"))
+	// Test set cell error with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellError("Sheet1", "A", "#N/A"))
+	// Test set and get cell error with invalid sheet name
+	assert.Equal(t, ErrSheetNameInvalid, f.SetCellError("Sheet:1", "A1", "#N/A"))
+	_, err = f.GetCellError("Sheet:1", "A1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+}
+
+func TestSetCellDateTime(t *testing.T) {
+	f := NewFile()
+	loc := time.FixedZone("UTC+9", 9*60*60)
+	value := time.Date(2024, time.January, 1, 20, 30, 15, 0, time.UTC)
+	assert.NoError(t, f.SetCellDate("Sheet1", "A1", value))
+	assert.NoError(t, f.SetCellDate("Sheet1", "A2", value, DateOptions{Location: loc}))
+	assert.NoError(t, f.SetCellDateTime("Sheet1", "B1", value))
+	assert.NoError(t, f.SetCellDateTime("Sheet1", "B2", value, DateOptions{Location: loc}))
+	assert.NoError(t, f.SetCellTime("Sheet1", "C1", value))
+	assert.NoError(t, f.SetCellTime("Sheet1", "C2", value, DateOptions{Location: loc}))
+	assert.NoError(t, f.SetCellDate("Sheet1", "D1", time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)))
+	assert.NoError(t, f.SetCellDate("Sheet1", "D2", time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC), DateOptions{IgnoreLeapYearBug: true}))
+	for cell, expected := range map[string][]string{
+		"A1": {"45292", "01-01-24"}, "A2": {"45293", "01-02-24"},
+		"B1": {"45292.85434027778", "1/1/24 20:30"}, "B2": {"45293.22934027778", "1/2/24 05:30"},
+		"C1": {"0.8543402777777778", "20:30:15"}, "C2": {"0.22934027777777777", "05:30:15"},
+	} {
+		raw, err := f.GetCellValue("Sheet1", cell, Options{RawCellValue: true})
+		assert.NoError(t, err)
+		assert.Equal(t, expected[0], raw, cell)
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[1], val, cell)
+	}
+	for cell, expected := range map[string]string{"D1": "1", "D2": "2"} {
+		raw, err := f.GetCellValue("Sheet1", cell, Options{RawCellValue: true})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, raw, cell)
+	}
+	// Test set date time in the 1904 date system
+	assert.NoError(t, f.SetWorkbookProps(&WorkbookPropsOptions{Date1904: boolPtr(true)}))
+	assert.NoError(t, f.SetCellDate("Sheet1", "E1", time.Date(1904, time.January, 2, 0, 0, 0, 0, time.UTC)))
+	raw, err := f.GetCellValue("Sheet1", "E1", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "1", raw)
+	// Test set date time before the epoch
+	assert.Equal(t, newInvalidExcelTimeError(time.Date(1903, time.January, 1, 0, 0, 0, 0, time.UTC), excel1904Epoc),
+		f.SetCellDateTime("Sheet1", "E1", time.Date(1903, time.January, 1, 0, 0, 0, 0, time.UTC)))
+	// Test set date time with invalid sheet name
+	assert.Equal(t, ErrSheetNameInvalid, f.SetCellDate("Sheet:1", "A1", value))
+	assert.Equal(t, ErrSheetNameInvalid, f.SetCellTime("Sheet:1", "A1", value))
+	// Test set date time with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellDateTime("Sheet1", "A1", value), "XML syntax error on line 1: invalid UTF-8")
 }
 
 func TestSetCellTime(t *testing.T) {
@@ -549,6 +849,37 @@ func TestGetCellType(t *testing.T) {
 	cellType, err = f.GetCellType("Sheet1", "A1")
 	assert.NoError(t, err)
 	assert.Equal(t, CellTypeSharedString, cellType)
+	for cell, value := range map[string]interface{}{
+		"B1": 1, "C1": true, "D1": nil,
+	} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	assert.NoError(t, f.SetCellStr("Sheet1", "E1", "E1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F1", "B1+1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "G1", "=\"G1\""))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[5].T, ws.(*xlsxWorksheet).SheetData.Row[0].C[5].V = "", "2"
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[6].V = "G1"
+	ws.(*xlsxWorksheet).SheetData.Row[0].C = append(ws.(*xlsxWorksheet).SheetData.Row[0].C,
+		xlsxC{R: "H1", T: "e", V: "#N/A"}, xlsxC{R: "I1", T: "d", V: "2024-01-01T00:00:00"},
+		xlsxC{R: "J1", T: "inlineStr", IS: &xlsxSI{T: &xlsxT{Val: "J1"}}})
+	for cell, expected := range map[string]CellType{
+		"B1": CellTypeNumber, "C1": CellTypeBool, "D1": CellTypeUnset, "E1": CellTypeSharedString,
+		"F1": CellTypeFormula, "G1": CellTypeFormula, "H1": CellTypeError, "I1": CellTypeDate,
+		"J1": CellTypeInlineString, "K1": CellTypeUnset,
+	} {
+		cellType, err = f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, cellType, cell)
+	}
+	// Test get the data type of the cached value of the formula cell
+	cellType, err = f.getCellType("Sheet1", "F1", false)
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeNumber, cellType)
+	cellType, err = f.getCellType("Sheet1", "G1", false)
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeFormula, cellType)
 	_, err = f.GetCellType("Sheet1", "A")
 	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
 	// Test get cell type with invalid sheet name
@@ -793,6 +1124,20 @@ func TestGetCellRichText(t *testing.T) {
 	runs, err = f.GetCellRichText("Sheet1", "A1")
 	assert.NoError(t, err)
 	assert.Equal(t, []RichTextRun{{Text: "A"}, {Text: "1"}}, runs)
+	// Test get cell rich text with vertical align and run without text
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0] = xlsxC{
+		T: "inlineStr",
+		IS: &xlsxSI{R: []xlsxR{
+			{T: &xlsxT{Val: "2"}, RPr: &xlsxRPr{VertAlign: &attrValString{Val: stringPtr("superscript")}}},
+			{RPr: &xlsxRPr{}},
+		}},
+	}
+	runs, err = f.GetCellRichText("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Len(t, runs, 2)
+	assert.Equal(t, "2", runs[0].Text)
+	assert.Equal(t, "superscript", runs[0].Font.VertAlign)
+	assert.Empty(t, runs[1].Text)
 
 	// Test get cell rich text when string item index overflow
 	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
@@ -949,6 +1294,68 @@ func TestSetCellRichText(t *testing.T) {
 	assert.EqualError(t, f.SetCellRichText("Sheet1", "A1", richTextRun), ErrCellCharsLength.Error())
 }
 
+func TestCellPhonetic(t *testing.T) {
+	f := NewFile()
+	phonetic, err := f.GetCellPhonetic("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Nil(t, phonetic)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "東京都"))
+	expected := &Phonetic{
+		Runs:      []PhoneticRun{{Start: 0, End: 2, Text: "トウキョウ"}, {Start: 2, End: 3, Text: "ト"}},
+		Type:      "Hiragana",
+		Alignment: "center",
+		Show:      true,
+	}
+	assert.NoError(t, f.SetCellPhonetic("Sheet1", "A1", expected))
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "東京都", val)
+	// Test the phonetic guide will be kept on round-trip
+	path := filepath.Join("test", "TestCellPhonetic.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	phonetic, err = f.GetCellPhonetic("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, phonetic)
+	// Test remove the phonetic guide
+	assert.NoError(t, f.SetCellPhonetic("Sheet1", "A1", nil))
+	phonetic, err = f.GetCellPhonetic("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Nil(t, phonetic)
+	assert.NoError(t, f.Close())
+
+	// Test set phonetic guide on inline string cell
+	f = NewFile()
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row = []xlsxRow{{R: 1, C: []xlsxC{{R: "A1", T: "inlineStr", IS: &xlsxSI{T: &xlsxT{Val: "大阪"}}}}}}
+	assert.NoError(t, f.SetCellPhonetic("Sheet1", "A1", &Phonetic{Runs: []PhoneticRun{{Start: 0, End: 2, Text: "おおさか"}}}))
+	phonetic, err = f.GetCellPhonetic("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, &Phonetic{Runs: []PhoneticRun{{Start: 0, End: 2, Text: "おおさか"}}}, phonetic)
+	// Test set phonetic guide with invalid parameters
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", 1))
+	assert.Equal(t, ErrParameterInvalid, f.SetCellPhonetic("Sheet1", "B1", &Phonetic{}))
+	assert.Equal(t, ErrParameterInvalid, f.SetCellPhonetic("Sheet1", "A1", &Phonetic{Runs: []PhoneticRun{{Start: 1, End: 3}}}))
+	assert.Equal(t, newInvalidOptionalValue("Type", "Katakana", supportedPhoneticTypes), f.SetCellPhonetic("Sheet1", "A1", &Phonetic{Type: "Katakana"}))
+	assert.Equal(t, newInvalidOptionalValue("Alignment", "right", supportedPhoneticAlignments), f.SetCellPhonetic("Sheet1", "A1", &Phonetic{Alignment: "right"}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellPhonetic("Sheet1", "A", nil))
+	assert.EqualError(t, f.SetCellPhonetic("SheetN", "A1", nil), "sheet SheetN does not exist")
+	_, err = f.GetCellPhonetic("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	_, err = f.GetCellPhonetic("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test set phonetic guide with unsupported charset shared strings table
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", "text"))
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellPhonetic("Sheet1", "C1", nil), "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.GetCellPhonetic("Sheet1", "C1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestFormattedValue(t *testing.T) {
 	f := NewFile()
 	result, err := f.formattedValue(&xlsxC{S: 0, V: "43528"}, false, CellTypeNumber)
@@ -1101,6 +1508,9 @@ func TestSharedStringsError(t *testing.T) {
 	f.tempFiles.Store(defaultXMLPathSharedStrings, "")
 	err = f.SetCellRichText("Sheet1", "A19", []RichTextRun{})
 	assert.Error(t, err)
+	// The temporary file of the shared strings table is kept when failed to
+	// read it, remove the invalid path before close the workbook
+	f.tempFiles.Delete(defaultXMLPathSharedStrings)
 	assert.NoError(t, f.Close())
 
 	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipXMLSizeLimit: 128})
@@ -1156,3 +1566,54 @@ func TestSharedStringsError(t *testing.T) {
 func TestSIString(t *testing.T) {
 	assert.Empty(t, xlsxSI{}.String())
 }
+
+func TestClearRange(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Item"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "A1&A2"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "C1", "https://github.com/xuri/excelize", "External"))
+	formulaType, sharedRef := STCellFormulaTypeShared, "D1:D2"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "A1", FormulaOpts{Type: &formulaType, Ref: &sharedRef}))
+	assert.NoError(t, f.ClearRange("Sheet1", "D1:A1"))
+	for _, cell := range []string{"A1", "B1", "D1"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, val, cell)
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, formula, cell)
+	}
+	formula, err := f.GetCellFormula("Sheet1", "D2")
+	assert.NoError(t, err)
+	assert.Equal(t, "A2", formula)
+	link, _, err := f.GetCellHyperLink("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.False(t, link)
+	styleID, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+
+	// Test clear cell with styles
+	assert.NoError(t, f.ClearCell("Sheet1", "A1", ClearOptions{Styles: true}))
+	styleID, err = f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Zero(t, styleID)
+	// Test clear range keeps the hyperlink which partially in the range
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A5:B5", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.ClearRange("Sheet1", "A5"))
+	link, _, err = f.GetCellHyperLink("Sheet1", "B5")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	// Test clear range with invalid parameters
+	assert.Equal(t, newInvalidCellNameError("A1:B1"), f.ClearCell("Sheet1", "A1:B1"))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.ClearRange("Sheet1", "A:B1"))
+	assert.EqualError(t, f.ClearRange("SheetN", "A1"), "sheet SheetN does not exist")
+	// Test clear range with invalid hyperlink reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).Hyperlinks = &xlsxHyperlinks{Hyperlink: []xlsxHyperlink{{Ref: "-"}}}
+	assert.Equal(t, newCellNameToCoordinatesError("-", newInvalidCellNameError("-")), f.ClearRange("Sheet1", "A1"))
+}
@@ -12,8 +12,12 @@
 package excelize
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -91,6 +95,35 @@ const (
 	ChartLineAutomatic
 )
 
+// ChartTrendlineType is the type of supported chart series trendline types.
+type ChartTrendlineType byte
+
+// This section defines the currently supported chart series trendline types
+// enumeration.
+const (
+	ChartTrendlineUnset ChartTrendlineType = iota
+	ChartTrendlineExponential
+	ChartTrendlineLinear
+	ChartTrendlineLogarithmic
+	ChartTrendlineMovingAverage
+	ChartTrendlinePolynomial
+	ChartTrendlinePower
+)
+
+// ChartErrorBarsType is the type of supported chart series error bars value
+// types.
+type ChartErrorBarsType byte
+
+// This section defines the currently supported chart series error bars value
+// types enumeration.
+const (
+	ChartErrorBarsUnset ChartErrorBarsType = iota
+	ChartErrorBarsFixedValue
+	ChartErrorBarsPercentage
+	ChartErrorBarsStandardDeviation
+	ChartErrorBarsStandardError
+)
+
 // ChartTickLabelPositionType is the type of supported chart tick label position
 // types.
 type ChartTickLabelPositionType byte
@@ -522,6 +555,29 @@ var (
 		Col3DCylinderStacked,
 		Col3DCylinderPercentStacked,
 	}
+	plotAreaChartShape = map[ChartType]string{
+		Bar3DConeClustered:          "cone",
+		Bar3DConeStacked:            "cone",
+		Bar3DConePercentStacked:     "cone",
+		Bar3DPyramidClustered:       "pyramid",
+		Bar3DPyramidStacked:         "pyramid",
+		Bar3DPyramidPercentStacked:  "pyramid",
+		Bar3DCylinderClustered:      "cylinder",
+		Bar3DCylinderStacked:        "cylinder",
+		Bar3DCylinderPercentStacked: "cylinder",
+		Col3DCone:                   "cone",
+		Col3DConeClustered:          "cone",
+		Col3DConeStacked:            "cone",
+		Col3DConePercentStacked:     "cone",
+		Col3DPyramid:                "pyramid",
+		Col3DPyramidClustered:       "pyramid",
+		Col3DPyramidStacked:         "pyramid",
+		Col3DPyramidPercentStacked:  "pyramid",
+		Col3DCylinder:               "cylinder",
+		Col3DCylinderClustered:      "cylinder",
+		Col3DCylinderStacked:        "cylinder",
+		Col3DCylinderPercentStacked: "cylinder",
+	}
 	orientation = map[bool]string{
 		true:  "maxMin",
 		false: "minMax",
@@ -544,6 +600,34 @@ var (
 		Contour:          "none",
 		WireframeContour: "none",
 	}
+	chartTrendlineTypes = map[ChartTrendlineType]string{
+		ChartTrendlineExponential:   "exp",
+		ChartTrendlineLinear:        "linear",
+		ChartTrendlineLogarithmic:   "log",
+		ChartTrendlineMovingAverage: "movingAvg",
+		ChartTrendlinePolynomial:    "poly",
+		ChartTrendlinePower:         "power",
+	}
+	chartErrorBarsTypes = map[ChartErrorBarsType]string{
+		ChartErrorBarsFixedValue:        "fixedVal",
+		ChartErrorBarsPercentage:        "percentage",
+		ChartErrorBarsStandardDeviation: "stdDev",
+		ChartErrorBarsStandardError:     "stdErr",
+	}
+	chartErrorBarsDefaultValue = map[ChartErrorBarsType]float64{
+		ChartErrorBarsFixedValue:        1,
+		ChartErrorBarsPercentage:        5,
+		ChartErrorBarsStandardDeviation: 1,
+	}
+	supportedChartTrendline = map[ChartType]bool{
+		Area: true, Bar: true, Col: true, Line: true, Scatter: true, Bubble: true,
+	}
+	supportedChartErrorBars = map[ChartType]bool{
+		Area: true, AreaStacked: true, AreaPercentStacked: true,
+		Bar: true, BarStacked: true, BarPercentStacked: true,
+		Col: true, ColStacked: true, ColPercentStacked: true,
+		Line: true, Scatter: true, Bubble: true,
+	}
 )
 
 // parseChartOptions provides a function to parse the format settings of the
@@ -749,6 +833,8 @@ func (opts *Chart) parseTitle() {
 //	Line
 //	Marker
 //	DataLabelPosition
+//	Trendline
+//	ErrorBars
 //
 // Name: Set the name for the series. The name is displayed in the chart legend
 // and in the formula bar. The 'Name' property is optional and if it isn't
@@ -763,6 +849,12 @@ func (opts *Chart) parseTitle() {
 // mandatory option for every chart object. This option links the chart with
 // the worksheet data that it displays.
 //
+// The 'Categories', 'Values' and 'Sizes' properties also accept non-contiguous
+// cell ranges separated by commas, for example:
+// "Sheet1!$B$2:$B$4,Sheet1!$B$7:$B$9". To plot a series on the secondary
+// vertical axis, add it as the combo chart and set the 'Secondary' property of
+// the 'YAxis' in that chart.
+//
 // Sizes: This sets the bubble size in a data series. The 'Sizes' property is
 // optional and the default value was same with 'Values'.
 //
@@ -793,6 +885,59 @@ func (opts *Chart) parseTitle() {
 //
 // DataLabelPosition: This sets the position of the chart series data label.
 //
+// Trendline: This sets the trendline of the chart series. The trendline is
+// supported in the 2D area, bar, column, line, scatter and bubble charts
+// without stacked. The options that can be set are:
+//
+//	Type
+//	Order
+//	Period
+//	Forward
+//	Backward
+//	Intercept
+//	DisplayEquation
+//	DisplayRSquared
+//
+// The enumeration value of 'Type' are:
+//
+//	ChartTrendlineExponential
+//	ChartTrendlineLinear
+//	ChartTrendlineLogarithmic
+//	ChartTrendlineMovingAverage
+//	ChartTrendlinePolynomial
+//	ChartTrendlinePower
+//
+// The 'Order' specifies the order of the polynomial trendline, the range is
+// 2-6 (default value is 2). The 'Period' specifies the period of the moving
+// average trendline, the range is 2-255 (default value is 2). The 'Forward' and
+// 'Backward' specifies the number of periods that the trendline extends
+// forward and backward. The 'Intercept' specifies the value where the
+// trendline crosses the vertical axis. The 'DisplayEquation' and
+// 'DisplayRSquared' specifies if display the trendline equation and R-squared
+// value on the chart.
+//
+// ErrorBars: This sets the error bars of the chart series. The error bars is
+// supported in the 2D area, bar, column, line, scatter and bubble charts. The
+// options that can be set are:
+//
+//	Type
+//	Direction
+//	Value
+//	NoEndCap
+//
+// The enumeration value of 'Type' are:
+//
+//	ChartErrorBarsFixedValue
+//	ChartErrorBarsPercentage
+//	ChartErrorBarsStandardDeviation
+//	ChartErrorBarsStandardError
+//
+// The 'Direction' specifies the direction of the error bars, the optional
+// values are 'both', 'plus' and 'minus' (default value is 'both'). The 'Value'
+// specifies the fixed value, percentage or number of standard deviations of
+// the error amount, default value is 1, 5 and 1. The 'NoEndCap' specifies if
+// hide the end caps of the error bars.
+//
 // Set properties of the chart legend. The options that can be set are:
 //
 //	Position
@@ -919,7 +1064,9 @@ func (opts *Chart) parseTitle() {
 //
 // Secondary: Specifies the current series vertical axis as the secondary axis,
 // this only works for the second and later chart in the combo chart. The
-// default value is false.
+// default value is false. The second and later chart in the combo chart
+// without secondary axis will share the primary axes with the first chart, and
+// the axis options of them will be ignored.
 //
 // TickLabelSkip: Specifies how many tick labels to skip between label that is
 // drawn. The 'TickLabelSkip' property is optional. The default value is auto.
@@ -1151,7 +1298,6 @@ func (f *File) AddChartSheet(sheet string, chart *Chart, combo ...*Chart) error
 			SheetView: []*xlsxChartsheetView{{ZoomScaleAttr: 100, ZoomToFitAttr: true}},
 		},
 	}
-	f.SheetCount++
 	wb, _ := f.workbookReader()
 	sheetID := 0
 	for _, v := range wb.Sheets.Sheet {
@@ -1161,21 +1307,24 @@ func (f *File) AddChartSheet(sheet string, chart *Chart, combo ...*Chart) error
 	}
 	sheetID++
 	path := "xl/chartsheets/sheet" + strconv.Itoa(sheetID) + ".xml"
-	f.sheetMap[sheet] = path
-	f.Sheet.Store(path, nil)
 	drawingID := f.countDrawings() + 1
 	chartID := f.countCharts() + 1
 	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
-	f.prepareChartSheetDrawing(&cs, drawingID, sheet)
+	// Update [Content_Types].xml before the chartsheet registered in the
+	// workbook, avoid leaving a partially created sheet on error
+	if err = f.addContentTypePart(chartID, "chart"); err != nil {
+		return err
+	}
 	drawingRels := "xl/drawings/_rels/drawing" + strconv.Itoa(drawingID) + ".xml.rels"
 	drawingRID := f.addRels(drawingRels, SourceRelationshipChart, "../charts/chart"+strconv.Itoa(chartID)+".xml", "")
 	if err = f.addSheetDrawingChart(drawingXML, drawingRID, &opts.Format); err != nil {
 		return err
 	}
+	f.SheetCount++
+	f.sheetMap[sheet] = path
+	f.Sheet.Store(path, nil)
+	f.prepareChartSheetDrawing(&cs, drawingID, sheet)
 	f.addChart(opts, comboCharts)
-	if err = f.addContentTypePart(chartID, "chart"); err != nil {
-		return err
-	}
 	_ = f.addContentTypePart(sheetID, "chartsheet")
 	_ = f.addContentTypePart(drawingID, "drawings")
 	// Update workbook.xml.rels
@@ -1212,34 +1361,600 @@ func (f *File) getChartOptions(opts *Chart, combo []*Chart) (*Chart, []*Chart, e
 	return options, comboCharts, err
 }
 
-// DeleteChart provides a function to delete chart in spreadsheet by given
-// worksheet name and cell reference.
-func (f *File) DeleteChart(sheet, cell string) error {
+// GetCharts provides a function to get the charts anchored at the given cell
+// in a worksheet by given worksheet name and cell reference. The returned
+// charts contain the chart type, series data source references, title,
+// legend and axis settings. For the combo chart, the primary chart will be
+// followed by the combo charts in plot order, and the 'Secondary' property of
+// the 'YAxis' of the combo chart indicates if it plots on the secondary
+// vertical axis. For example, get the charts anchored at cell E1 on Sheet1:
+//
+//	charts, err := f.GetCharts("Sheet1", "E1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, chart := range charts {
+//	    fmt.Println(chart.Type, chart.Series)
+//	}
+func (f *File) GetCharts(sheet, cell string) ([]Chart, error) {
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	col--
 	row--
+	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
-		return err
+		f.mu.Unlock()
+		return nil, err
 	}
+	f.mu.Unlock()
 	if ws.Drawing == nil {
+		return nil, err
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+	drawingRels := "xl/drawings/_rels/" + filepath.Base(drawingXML) + ".rels"
+	anchors, err := f.getChartAnchors(drawingXML)
+	if err != nil {
+		return nil, err
+	}
+	var charts []Chart
+	for _, anchor := range anchors {
+		if anchor.From.Col != col || anchor.From.Row != row {
+			continue
+		}
+		rels := f.getDrawingRelationships(drawingRels, anchor.GraphicFrame.Graphic.GraphicData.Chart.RID)
+		if rels == nil {
+			continue
+		}
+		plots, err := f.getChart(chartPartPath(rels.Target))
+		if err != nil {
+			return charts, err
+		}
+		for idx := range plots {
+			plots[idx].Format.AltText = anchor.GraphicFrame.NvGraphicFramePr.CNvPr.Descr
+		}
+		charts = append(charts, plots...)
+	}
+	return charts, err
+}
+
+// GetChartCells returns all chart cell references in a worksheet by given
+// worksheet name. The charts anchored at the same cell will be reported once.
+// For example, get all charts in the worksheet named Sheet1:
+//
+//	cells, err := f.GetChartCells("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, cell := range cells {
+//	    charts, err := f.GetCharts("Sheet1", cell)
+//	    if err != nil {
+//	        fmt.Println(err)
+//	        return
+//	    }
+//	    for _, chart := range charts {
+//	        fmt.Println(cell, chart.Type)
+//	    }
+//	}
+func (f *File) GetChartCells(sheet string) ([]string, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	if ws.Drawing == nil {
+		return nil, err
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+	anchors, err := f.getChartAnchors(drawingXML)
+	if err != nil {
+		return nil, err
+	}
+	var cells []string
+	for _, anchor := range anchors {
+		cell, err := CoordinatesToCellName(anchor.From.Col+1, anchor.From.Row+1)
+		if err != nil {
+			return cells, err
+		}
+		if inStrSlice(cells, cell, true) == -1 {
+			cells = append(cells, cell)
+		}
+	}
+	return cells, err
+}
+
+// getChartAnchors provides a function to get the decoded cell anchors of the
+// charts in the drawing part by given drawing part path.
+func (f *File) getChartAnchors(drawingXML string) ([]*decodeCellAnchor, error) {
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return nil, err
+	}
+	wsDr.mu.Lock()
+	anchors := append(append([]*xdrCellAnchor{}, wsDr.TwoCellAnchor...), wsDr.OneCellAnchor...)
+	wsDr.mu.Unlock()
+	var deAnchors []*decodeCellAnchor
+	for _, anchor := range anchors {
+		deAnchor := new(decodeCellAnchor)
+		if err = f.xmlNewDecoder(strings.NewReader("<decodeCellAnchor>" + anchor.GraphicFrame + "</decodeCellAnchor>")).
+			Decode(deAnchor); err != nil && err != io.EOF {
+			return deAnchors, err
+		}
+		if anchor.From != nil {
+			deAnchor.From = &decodeFrom{Col: anchor.From.Col, Row: anchor.From.Row}
+		}
+		if deAnchor.From == nil || deAnchor.GraphicFrame == nil || deAnchor.GraphicFrame.Graphic == nil ||
+			deAnchor.GraphicFrame.Graphic.GraphicData.Chart == nil {
+			continue
+		}
+		deAnchors = append(deAnchors, deAnchor)
+	}
+	return deAnchors, nil
+}
+
+// chartPartPath returns the chart part path by given relationship target in
+// the drawing relationships.
+func chartPartPath(target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return filepath.ToSlash(filepath.Clean("xl/drawings/" + target))
+}
+
+// getChart provides a function to get the charts in plot order by given chart
+// part path.
+func (f *File) getChart(chartXML string) ([]Chart, error) {
+	var (
+		charts     []Chart
+		chartSpace xlsxChartSpace
+		deChart    decodeChartSpace
+		content    = namespaceStrictToTransitional(f.readXML(chartXML))
+	)
+	if err := f.xmlNewDecoder(bytes.NewReader(content)).Decode(&chartSpace); err != nil && err != io.EOF {
+		return charts, err
+	}
+	if err := f.xmlNewDecoder(bytes.NewReader(content)).Decode(&deChart); err != nil && err != io.EOF {
+		return charts, err
+	}
+	if chartSpace.Chart.PlotArea == nil {
+		return charts, nil
+	}
+	axes, titles := map[int]*cAxs{}, map[int][]RichTextRun{}
+	pa := chartSpace.Chart.PlotArea
+	for _, ax := range append(append(append([]*cAxs{}, pa.CatAx...), pa.ValAx...), pa.SerAx...) {
+		if ax.AxID != nil && ax.AxID.Val != nil {
+			axes[*ax.AxID.Val] = ax
+		}
+	}
+	if deChart.Chart.PlotArea != nil {
+		dePa := deChart.Chart.PlotArea
+		for _, ax := range append(append(append([]decodeChartAxis{}, dePa.CatAx...), dePa.ValAx...), dePa.SerAx...) {
+			if ax.AxID.Val != nil {
+				titles[*ax.AxID.Val] = extractChartTitle(ax.Title)
+			}
+		}
+	}
+	var primaryAxID int
+	for _, plot := range extractChartPlots(pa) {
+		chart := Chart{
+			Type:         plot.typ,
+			Title:        extractChartTitle(deChart.Chart.Title),
+			Legend:       ChartLegend{Position: "none"},
+			VaryColors:   extractChartBool(plot.c.VaryColors),
+			ShowBlanksAs: "gap",
+		}
+		if chartSpace.Chart.Legend != nil {
+			chart.Legend.Position = defaultChartLegendPosition
+			if legendPos := chartSpace.Chart.Legend.LegendPos; legendPos != nil && legendPos.Val != nil {
+				for pos, val := range chartLegendPosition {
+					if val == *legendPos.Val {
+						chart.Legend.Position = pos
+					}
+				}
+			}
+		}
+		if chartSpace.Chart.DispBlanksAs != nil && chartSpace.Chart.DispBlanksAs.Val != nil {
+			chart.ShowBlanksAs = *chartSpace.Chart.DispBlanksAs.Val
+		}
+		if plot.c.HoleSize != nil && plot.c.HoleSize.Val != nil {
+			chart.HoleSize = *plot.c.HoleSize.Val
+		}
+		if plot.c.BubbleScale != nil && plot.c.BubbleScale.Val != nil {
+			chart.BubbleSize = int(*plot.c.BubbleScale.Val)
+		}
+		if plot.c.GapWidth != nil && plot.c.GapWidth.Val != nil && *plot.c.GapWidth.Val >= 0 {
+			chart.GapWidth = uintPtr(uint(*plot.c.GapWidth.Val))
+		}
+		if plot.c.Overlap != nil && plot.c.Overlap.Val != nil {
+			chart.Overlap = intPtr(*plot.c.Overlap.Val)
+		}
+		if plot.c.Ser != nil {
+			for _, ser := range *plot.c.Ser {
+				chart.Series = append(chart.Series, extractChartSeries(ser))
+			}
+		}
+		if len(plot.c.AxID) > 1 && plot.c.AxID[0].Val != nil && plot.c.AxID[1].Val != nil {
+			xAxID, yAxID := *plot.c.AxID[0].Val, *plot.c.AxID[1].Val
+			chart.XAxis = extractChartAxis(axes[xAxID], titles[xAxID])
+			chart.YAxis = extractChartAxis(axes[yAxID], titles[yAxID])
+			if len(charts) == 0 {
+				primaryAxID = yAxID
+			}
+			chart.YAxis.Secondary = len(charts) > 0 && yAxID != primaryAxID
+		}
+		charts = append(charts, chart)
+	}
+	return charts, nil
+}
+
+// chartPlot defines the chart type and properties of a chart in the plot
+// area.
+type chartPlot struct {
+	typ ChartType
+	c   *cCharts
+}
+
+// extractChartPlots returns the charts in the plot area in ascending order of
+// the series order.
+func extractChartPlots(pa *cPlotArea) []chartPlot {
+	var plots []chartPlot
+	for _, elem := range []struct {
+		name   string
+		charts []*cCharts
+	}{
+		{"areaChart", pa.AreaChart}, {"area3DChart", pa.Area3DChart},
+		{"barChart", pa.BarChart}, {"bar3DChart", pa.Bar3DChart},
+		{"bubbleChart", pa.BubbleChart}, {"doughnutChart", pa.DoughnutChart},
+		{"lineChart", pa.LineChart}, {"line3DChart", pa.Line3DChart},
+		{"pieChart", pa.PieChart}, {"pie3DChart", pa.Pie3DChart},
+		{"ofPieChart", pa.OfPieChart}, {"radarChart", pa.RadarChart},
+		{"scatterChart", pa.ScatterChart}, {"surface3DChart", pa.Surface3DChart},
+		{"surfaceChart", pa.SurfaceChart},
+	} {
+		for _, c := range elem.charts {
+			plots = append(plots, chartPlot{typ: extractChartType(elem.name, c), c: c})
+		}
+	}
+	seriesOrder := func(c *cCharts) int {
+		if c.Ser != nil && len(*c.Ser) > 0 && (*c.Ser)[0].Order != nil && (*c.Ser)[0].Order.Val != nil {
+			return *(*c.Ser)[0].Order.Val
+		}
+		return 0
+	}
+	sort.SliceStable(plots, func(i, j int) bool {
+		return seriesOrder(plots[i].c) < seriesOrder(plots[j].c)
+	})
+	return plots
+}
+
+// extractChartType returns the chart type by given chart element name in the
+// plot area and the chart properties.
+func extractChartType(name string, c *cCharts) ChartType {
+	attrVal := func(v *attrValString) string {
+		if v != nil && v.Val != nil {
+			return *v.Val
+		}
+		return ""
+	}
+	candidates := map[string][]ChartType{
+		"areaChart":   {Area, AreaStacked, AreaPercentStacked},
+		"area3DChart": {Area3D, Area3DStacked, Area3DPercentStacked},
+		"barChart":    {Col, ColStacked, ColPercentStacked, Bar, BarStacked, BarPercentStacked},
+	}
+	for _, typ := range barColChartTypes {
+		if !inChartTypes(candidates["barChart"], typ) {
+			candidates["bar3DChart"] = append(candidates["bar3DChart"], typ)
+		}
+	}
+	if types, ok := candidates[name]; ok {
+		shape := attrVal(c.Shape)
+		if shape == "box" {
+			shape = ""
+		}
+		for _, typ := range types {
+			if (c.BarDir == nil || plotAreaChartBarDir[typ] == attrVal(c.BarDir)) &&
+				plotAreaChartGrouping[typ] == attrVal(c.Grouping) && plotAreaChartShape[typ] == shape {
+				return typ
+			}
+		}
+		return types[0]
+	}
+	switch name {
+	case "bubbleChart":
+		if c.Ser != nil {
+			for _, ser := range *c.Ser {
+				if ser.Bubble3D != nil && ser.Bubble3D.Val != nil && *ser.Bubble3D.Val {
+					return Bubble3D
+				}
+			}
+		}
+		return Bubble
+	case "doughnutChart":
+		return Doughnut
+	case "line3DChart":
+		return Line3D
+	case "pieChart":
+		return Pie
+	case "pie3DChart":
+		return Pie3D
+	case "ofPieChart":
+		if attrVal(c.OfPieType) == "bar" {
+			return BarOfPie
+		}
+		return PieOfPie
+	case "radarChart":
+		return Radar
+	case "scatterChart":
+		return Scatter
+	case "surface3DChart":
+		if c.Wireframe != nil && c.Wireframe.Val != nil && *c.Wireframe.Val {
+			return WireframeSurface3D
+		}
+		return Surface3D
+	case "surfaceChart":
+		if c.Wireframe != nil && c.Wireframe.Val != nil && *c.Wireframe.Val {
+			return WireframeContour
+		}
+		return Contour
+	}
+	return Line
+}
+
+// inChartTypes returns if the given chart type exists in the chart types.
+func inChartTypes(types []ChartType, typ ChartType) bool {
+	for _, t := range types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// extractChartSeries returns the chart series settings by given chart series
+// element.
+func extractChartSeries(ser cSer) ChartSeries {
+	var series ChartSeries
+	if ser.Tx != nil && ser.Tx.StrRef != nil {
+		series.Name = ser.Tx.StrRef.F
+	}
+	for _, cat := range []*cCat{ser.Cat, ser.XVal} {
+		if cat != nil && cat.StrRef != nil {
+			series.Categories = cat.StrRef.F
+		}
+		if cat != nil && cat.NumRef != nil {
+			series.Categories = cat.NumRef.F
+		}
+	}
+	for _, val := range []*cVal{ser.Val, ser.YVal} {
+		if val != nil && val.NumRef != nil {
+			series.Values = val.NumRef.F
+		}
+	}
+	if ser.BubbleSize != nil && ser.BubbleSize.NumRef != nil && ser.BubbleSize.NumRef.F != series.Values {
+		series.Sizes = ser.BubbleSize.NumRef.F
+	}
+	if ser.Smooth != nil && ser.Smooth.Val != nil {
+		series.Line.Smooth = *ser.Smooth.Val
+	}
+	if ser.Trendline != nil {
+		series.Trendline = extractChartTrendline(ser.Trendline)
+	}
+	if ser.ErrBars != nil {
+		series.ErrorBars = extractChartErrorBars(ser.ErrBars)
+	}
+	return series
+}
+
+// extractChartTrendline returns the chart series trendline settings by given
+// trendline element.
+func extractChartTrendline(tl *cTrendline) ChartTrendline {
+	var trendline ChartTrendline
+	if tl.TrendlineType != nil && tl.TrendlineType.Val != nil {
+		for typ, val := range chartTrendlineTypes {
+			if val == *tl.TrendlineType.Val {
+				trendline.Type = typ
+			}
+		}
+	}
+	if tl.Order != nil && tl.Order.Val != nil {
+		trendline.Order = *tl.Order.Val
+	}
+	if tl.Period != nil && tl.Period.Val != nil {
+		trendline.Period = *tl.Period.Val
+	}
+	if tl.Forward != nil && tl.Forward.Val != nil {
+		trendline.Forward = *tl.Forward.Val
+	}
+	if tl.Backward != nil && tl.Backward.Val != nil {
+		trendline.Backward = *tl.Backward.Val
+	}
+	if tl.Intercept != nil && tl.Intercept.Val != nil {
+		trendline.Intercept = float64Ptr(*tl.Intercept.Val)
+	}
+	if tl.DispEq != nil && tl.DispEq.Val != nil {
+		trendline.DisplayEquation = *tl.DispEq.Val
+	}
+	if tl.DispRSqr != nil && tl.DispRSqr.Val != nil {
+		trendline.DisplayRSquared = *tl.DispRSqr.Val
+	}
+	return trendline
+}
+
+// extractChartErrorBars returns the chart series error bars settings by given
+// error bars element.
+func extractChartErrorBars(eb *cErrBars) ChartErrorBars {
+	errorBars := ChartErrorBars{Direction: "both"}
+	if eb.ErrValType != nil && eb.ErrValType.Val != nil {
+		for typ, val := range chartErrorBarsTypes {
+			if val == *eb.ErrValType.Val {
+				errorBars.Type = typ
+			}
+		}
+	}
+	if eb.ErrBarType != nil && eb.ErrBarType.Val != nil {
+		errorBars.Direction = *eb.ErrBarType.Val
+	}
+	if eb.Val != nil && eb.Val.Val != nil {
+		errorBars.Value = *eb.Val.Val
+	}
+	if eb.NoEndCap != nil && eb.NoEndCap.Val != nil {
+		errorBars.NoEndCap = *eb.NoEndCap.Val
+	}
+	return errorBars
+}
+
+// extractChartAxis returns the chart axis settings by given axis element and
+// axis title.
+func extractChartAxis(ax *cAxs, title []RichTextRun) ChartAxis {
+	axis := ChartAxis{Title: title}
+	if ax == nil {
+		return axis
+	}
+	if ax.Delete != nil && ax.Delete.Val != nil {
+		axis.None = *ax.Delete.Val
+	}
+	axis.MajorGridLines = ax.MajorGridlines != nil
+	axis.MinorGridLines = ax.MinorGridlines != nil
+	if ax.MajorUnit != nil && ax.MajorUnit.Val != nil {
+		axis.MajorUnit = *ax.MajorUnit.Val
+	}
+	if ax.TickLblSkip != nil && ax.TickLblSkip.Val != nil {
+		axis.TickLabelSkip = *ax.TickLblSkip.Val
+	}
+	if ax.TickLblPos != nil && ax.TickLblPos.Val != nil {
+		for pos, val := range tickLblPosVal {
+			if val == *ax.TickLblPos.Val {
+				axis.TickLabelPosition = pos
+			}
+		}
+	}
+	if ax.Scaling != nil {
+		if ax.Scaling.Orientation != nil && ax.Scaling.Orientation.Val != nil {
+			axis.ReverseOrder = *ax.Scaling.Orientation.Val == orientation[true]
+		}
+		if ax.Scaling.Max != nil && ax.Scaling.Max.Val != nil {
+			axis.Maximum = float64Ptr(*ax.Scaling.Max.Val)
+		}
+		if ax.Scaling.Min != nil && ax.Scaling.Min.Val != nil {
+			axis.Minimum = float64Ptr(*ax.Scaling.Min.Val)
+		}
+		if ax.Scaling.LogBase != nil && ax.Scaling.LogBase.Val != nil {
+			axis.LogBase = *ax.Scaling.LogBase.Val
+		}
+	}
+	if ax.NumFmt != nil {
+		axis.NumFmt = ChartNumFmt{CustomNumFmt: ax.NumFmt.FormatCode, SourceLinked: ax.NumFmt.SourceLinked}
+	}
+	return axis
+}
+
+// extractChartTitle returns the rich text runs by given chart title.
+func extractChartTitle(title *decodeChartTitle) []RichTextRun {
+	var runs []RichTextRun
+	if title == nil || title.Rich == nil {
+		return runs
+	}
+	for _, p := range title.Rich.P {
+		for _, r := range p.R {
+			runs = append(runs, RichTextRun{Text: r.T})
+		}
+	}
+	return runs
+}
+
+// extractChartBool returns the boolean pointer by given boolean attribute
+// value.
+func extractChartBool(v *attrValBool) *bool {
+	if v == nil || v.Val == nil {
+		return nil
+	}
+	return boolPtr(*v.Val)
+}
+
+// DeleteChart provides a function to delete chart in spreadsheet by given
+// worksheet name and cell reference or chart name. The chart part, the
+// relationships and content types of the chart will be removed with the
+// drawing anchor. For example, delete the chart anchored at cell E1 on
+// Sheet1:
+//
+//	err := f.DeleteChart("Sheet1", "E1")
+//
+// Delete the chart named "Chart 1" on Sheet1:
+//
+//	err := f.DeleteChart("Sheet1", "Chart 1")
+func (f *File) DeleteChart(sheet, cell string) error {
+	col, row, cellErr := CellNameToCoordinates(cell)
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
 		return err
 	}
+	if ws.Drawing == nil {
+		return cellErr
+	}
 	drawingXML := strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID), "..", "xl")
-	_, err = f.deleteDrawing(col, row, drawingXML, "Chart")
+	drawingRels := "xl/drawings/_rels/" + filepath.Base(drawingXML) + ".rels"
+	col, row = col-1, row-1
+	anchors, err := f.getChartAnchors(drawingXML)
+	if err != nil {
+		return err
+	}
+	if cellErr != nil {
+		var found bool
+		for _, anchor := range anchors {
+			if anchor.GraphicFrame.NvGraphicFramePr.CNvPr.Name == cell {
+				col, row, found = anchor.From.Col, anchor.From.Row, true
+				break
+			}
+		}
+		if !found {
+			return cellErr
+		}
+	}
+	// All charts anchored at the cell will be deleted with the drawing anchors
+	var rIDs []string
+	for _, anchor := range anchors {
+		if anchor.From.Col == col && anchor.From.Row == row {
+			rIDs = append(rIDs, anchor.GraphicFrame.Graphic.GraphicData.Chart.RID)
+		}
+	}
+	if _, err = f.deleteDrawing(col, row, drawingXML, "Chart"); err != nil {
+		return err
+	}
+	for _, rID := range rIDs {
+		rels := f.getDrawingRelationships(drawingRels, rID)
+		if rels == nil {
+			continue
+		}
+		chartXML := chartPartPath(rels.Target)
+		chartRels := "xl/charts/_rels/" + filepath.Base(chartXML) + ".rels"
+		f.deleteDrawingRels(drawingRels, rID)
+		f.Pkg.Delete(chartXML)
+		f.Pkg.Delete(chartRels)
+		f.Relationships.Delete(chartRels)
+		if err = f.removeContentTypesPart(ContentTypeDrawingML, "/"+chartXML); err != nil {
+			return err
+		}
+	}
 	return err
 }
 
-// countCharts provides a function to get chart files count storage in the
-// folder xl/charts.
+// countCharts provides a function to get the maximum index of the chart
+// files storage in the folder xl/charts.
 func (f *File) countCharts() int {
 	count := 0
 	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/charts/chart") {
-			count++
+		if name := k.(string); strings.HasPrefix(name, "xl/charts/chart") {
+			if idx, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, "xl/charts/chart"), ".xml")); err == nil && idx > count {
+				count = idx
+			}
 		}
 		return true
 	})
@@ -355,11 +355,30 @@ func TestAddChartSheet(t *testing.T) {
 	assert.NoError(t, f.UpdateLinkedValue())
 
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartSheet.xlsx")))
+	// Test delete chartsheet
+	assert.NoError(t, f.AddChartSheet("Chart3", &Chart{Type: Col, Series: series}))
+	sheetXMLPath, ok := f.getSheetXMLPath("Chart3")
+	assert.True(t, ok)
+	assert.NoError(t, f.DeleteSheet("Chart3"))
+	assert.Equal(t, []string{"Sheet1", "Chart1"}, f.GetSheetList())
+	contentTypes, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	for _, override := range contentTypes.Overrides {
+		assert.NotEqual(t, "/"+sheetXMLPath, override.PartName)
+	}
+	_, ok = f.Relationships.Load("xl/chartsheets/_rels/" + filepath.Base(sheetXMLPath) + ".rels")
+	assert.False(t, ok)
+	assert.NoError(t, f.Close())
 	// Test add chart sheet with unsupported charset content types
 	f = NewFile()
 	f.ContentTypes = nil
 	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
 	assert.EqualError(t, f.AddChartSheet("Chart4", &Chart{Type: Col, Series: []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$30:$D$30"}}, Title: []RichTextRun{{Text: "2D Column Chart"}}}), "XML syntax error on line 1: invalid UTF-8")
+	assert.Equal(t, 1, f.SheetCount)
+	assert.Equal(t, []string{"Sheet1"}, f.GetSheetList())
+	idx, err := f.GetSheetIndex("Chart4")
+	assert.NoError(t, err)
+	assert.Equal(t, -1, idx)
 }
 
 func TestDeleteChart(t *testing.T) {
@@ -406,6 +425,62 @@ func TestDeleteChart(t *testing.T) {
 	// Test delete chart on no chart worksheet
 	assert.NoError(t, NewFile().DeleteChart("Sheet1", "A1"))
 	assert.NoError(t, f.Close())
+
+	// Test delete chart removes the chart part, relationships and content types
+	f = NewFile()
+	chart := &Chart{Type: Col, Series: []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$B$1:$D$1"}}}
+	for _, cell := range []string{"A1", "J1", "A20"} {
+		assert.NoError(t, f.AddChart("Sheet1", cell, chart))
+	}
+	assert.NoError(t, f.DeleteChart("Sheet1", "A1"))
+	_, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.False(t, ok)
+	rels, err := f.relsReader("xl/drawings/_rels/drawing1.xml.rels")
+	assert.NoError(t, err)
+	assert.Len(t, rels.Relationships, 2)
+	contentTypes, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	for _, override := range contentTypes.Overrides {
+		assert.NotEqual(t, "/xl/charts/chart1.xml", override.PartName)
+	}
+	// Test delete chart by chart name
+	assert.NoError(t, f.DeleteChart("Sheet1", "Chart 3"))
+	_, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.False(t, ok)
+	charts, err := f.GetCharts("Sheet1", "A20")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 1)
+	// Test add chart after deleting charts doesn't overwrite existing chart part
+	assert.NoError(t, f.AddChart("Sheet1", "J20", chart))
+	_, ok = f.Pkg.Load("xl/charts/chart4.xml")
+	assert.True(t, ok)
+	charts, err = f.GetCharts("Sheet1", "A20")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 1)
+	// Test delete chart with not exists chart name
+	assert.EqualError(t, f.DeleteChart("Sheet1", "Chart 0"), newCellNameToCoordinatesError("Chart 0", newInvalidCellNameError("Chart 0")).Error())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteChart2.xlsx")))
+	assert.NoError(t, f.Close())
+	// Test delete charts anchored at the same cell removes all chart parts
+	f = NewFile()
+	assert.NoError(t, f.AddChart("Sheet1", "A1", chart))
+	assert.NoError(t, f.AddChart("Sheet1", "A1", chart))
+	assert.NoError(t, f.DeleteChart("Sheet1", "A1"))
+	for _, chartXML := range []string{"xl/charts/chart1.xml", "xl/charts/chart2.xml"} {
+		_, ok = f.Pkg.Load(chartXML)
+		assert.False(t, ok)
+	}
+	rels, err = f.relsReader("xl/drawings/_rels/drawing1.xml.rels")
+	assert.NoError(t, err)
+	assert.Empty(t, rels.Relationships)
+	assert.NoError(t, f.Close())
+	// Test delete chart by chart name with unsupported charset drawing part
+	f = NewFile()
+	assert.NoError(t, f.AddChart("Sheet1", "A1", chart))
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.DeleteChart("Sheet1", "Chart 2"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
 }
 
 func TestChartWithLogarithmicBase(t *testing.T) {
@@ -509,3 +584,258 @@ func TestChartWithLogarithmicBase(t *testing.T) {
 		}
 	}
 }
+
+func TestChartSeriesRef(t *testing.T) {
+	for ref, expected := range map[string]string{
+		"":                                    "",
+		"Sheet1!$B$2:$B$4":                    "Sheet1!$B$2:$B$4",
+		"Sheet1!$B$2:$B$4,Sheet1!$B$7:$B$9":   "(Sheet1!$B$2:$B$4,Sheet1!$B$7:$B$9)",
+		"(Sheet1!$B$2:$B$4,Sheet1!$B$7:$B$9)": "(Sheet1!$B$2:$B$4,Sheet1!$B$7:$B$9)",
+		"'Sheet,1'!$B$2:$B$4":                 "'Sheet,1'!$B$2:$B$4",
+		"'Sheet,1'!$B$2,'Sheet,1'!$B$4":       "('Sheet,1'!$B$2,'Sheet,1'!$B$4)",
+	} {
+		assert.Equal(t, expected, chartSeriesRef(ref), ref)
+	}
+	// Test add combo chart with non-contiguous series data source and
+	// secondary vertical axis
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{nil, "Revenue", "Margin"}, {"Q1", 100, 0.1}, {"Q2", 120, 0.12},
+		{"Subtotal", 220, 0.11}, {"Q3", 130, 0.15}, {"Q4", 150, 0.18},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type: Col,
+		Series: []ChartSeries{{
+			Name:       "Sheet1!$B$1",
+			Categories: "Sheet1!$A$2:$A$3,Sheet1!$A$5:$A$6",
+			Values:     "Sheet1!$B$2:$B$3,Sheet1!$B$5:$B$6",
+		}},
+	}, &Chart{
+		Type: Line,
+		Series: []ChartSeries{{
+			Name:       "Sheet1!$C$1",
+			Categories: "Sheet1!$A$2:$A$3,Sheet1!$A$5:$A$6",
+			Values:     "Sheet1!$C$2:$C$3,Sheet1!$C$5:$C$6",
+		}},
+		YAxis: ChartAxis{Secondary: true},
+	}))
+	chart, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	var chartSpace xlsxChartSpace
+	assert.NoError(t, xml.Unmarshal(chart.([]byte), &chartSpace))
+	for _, c := range [][]*cCharts{chartSpace.Chart.PlotArea.BarChart, chartSpace.Chart.PlotArea.LineChart} {
+		assert.Len(t, c, 1)
+		assert.Equal(t, "(Sheet1!$A$2:$A$3,Sheet1!$A$5:$A$6)", (*c[0].Ser)[0].Cat.StrRef.F)
+	}
+	assert.Equal(t, "(Sheet1!$B$2:$B$3,Sheet1!$B$5:$B$6)", (*chartSpace.Chart.PlotArea.BarChart[0].Ser)[0].Val.NumRef.F)
+	assert.Equal(t, "(Sheet1!$C$2:$C$3,Sheet1!$C$5:$C$6)", (*chartSpace.Chart.PlotArea.LineChart[0].Ser)[0].Val.NumRef.F)
+	assert.Len(t, chartSpace.Chart.PlotArea.ValAx, 2)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartSeriesRef.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddComboChartAxes(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{nil, "Revenue", "Cost", "Margin"}, {"Q1", 100, 80, 0.2},
+		{"Q2", 120, 90, 0.25}, {"Q3", 130, 100, 0.23},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	maximum := 200.0
+	assert.NoError(t, f.AddChart("Sheet1", "F1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$B$2:$B$4"}},
+		XAxis:  ChartAxis{Title: []RichTextRun{{Text: "Quarter"}}},
+		YAxis:  ChartAxis{Title: []RichTextRun{{Text: "Amount"}}, Maximum: &maximum},
+	}, &Chart{
+		Type:   Line,
+		Series: []ChartSeries{{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$C$2:$C$4"}},
+	}, &Chart{
+		Type:   Line,
+		Series: []ChartSeries{{Name: "Sheet1!$D$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$D$2:$D$4"}},
+		YAxis:  ChartAxis{Secondary: true, Title: []RichTextRun{{Text: "Margin"}}},
+	}))
+	chart, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	var chartSpace xlsxChartSpace
+	assert.NoError(t, xml.Unmarshal(chart.([]byte), &chartSpace))
+	plotArea := chartSpace.Chart.PlotArea
+	assert.Len(t, plotArea.LineChart, 2)
+	assert.Len(t, plotArea.CatAx, 2)
+	assert.Len(t, plotArea.ValAx, 2)
+	// Test the primary axes options are kept with combo chart
+	for _, title := range []string{"Quarter", "Amount", "Margin"} {
+		assert.Contains(t, string(chart.([]byte)), "<a:t>"+title+"</a:t>")
+	}
+	assert.NotNil(t, plotArea.ValAx[0].Scaling.Max)
+	assert.Equal(t, 100000001, *plotArea.ValAx[0].AxID.Val)
+	assert.Equal(t, 100000004, *plotArea.ValAx[1].AxID.Val)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddComboChartAxes.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestGetCharts(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{nil, "Revenue", "Cost", "Margin"}, {"Q1", 100, 80, 0.2},
+		{"Q2", 120, 90, 0.25}, {"Q3", 130, 100, 0.23},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	maximum, minimum := 200.0, 10.0
+	assert.NoError(t, f.AddChart("Sheet1", "F1", &Chart{
+		Type:   BarStacked,
+		Series: []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$B$2:$B$4"}},
+		Title:  []RichTextRun{{Text: "Revenue"}},
+		Legend: ChartLegend{Position: "left"},
+		XAxis:  ChartAxis{Title: []RichTextRun{{Text: "Quarter"}}, ReverseOrder: true},
+		YAxis:  ChartAxis{Title: []RichTextRun{{Text: "Amount"}}, Maximum: &maximum, Minimum: &minimum, MajorGridLines: true},
+	}, &Chart{
+		Type:   Line,
+		Series: []ChartSeries{{Name: "Sheet1!$D$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$D$2:$D$4", Line: ChartLine{Smooth: true}}},
+		YAxis:  ChartAxis{Secondary: true, Title: []RichTextRun{{Text: "Margin"}}},
+	}))
+	assert.NoError(t, f.AddChart("Sheet1", "F20", &Chart{
+		Type:   Col3DCone,
+		Series: []ChartSeries{{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$C$2:$C$4"}},
+		Legend: ChartLegend{Position: "none"},
+	}))
+	check := func(f *File) {
+		charts, err := f.GetCharts("Sheet1", "F1")
+		assert.NoError(t, err)
+		assert.Len(t, charts, 2)
+		assert.Equal(t, BarStacked, charts[0].Type)
+		assert.Equal(t, []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$B$2:$B$4"}}, charts[0].Series)
+		assert.Equal(t, []RichTextRun{{Text: "Revenue"}}, charts[0].Title)
+		assert.Equal(t, "left", charts[0].Legend.Position)
+		assert.Equal(t, []RichTextRun{{Text: "Quarter"}}, charts[0].XAxis.Title)
+		assert.True(t, charts[0].XAxis.ReverseOrder)
+		assert.Equal(t, []RichTextRun{{Text: "Amount"}}, charts[0].YAxis.Title)
+		assert.Equal(t, &maximum, charts[0].YAxis.Maximum)
+		assert.Equal(t, &minimum, charts[0].YAxis.Minimum)
+		assert.True(t, charts[0].YAxis.MajorGridLines)
+		assert.False(t, charts[0].YAxis.Secondary)
+		assert.Equal(t, Line, charts[1].Type)
+		assert.Equal(t, "Sheet1!$D$2:$D$4", charts[1].Series[0].Values)
+		assert.True(t, charts[1].Series[0].Line.Smooth)
+		assert.True(t, charts[1].YAxis.Secondary)
+		assert.Equal(t, []RichTextRun{{Text: "Margin"}}, charts[1].YAxis.Title)
+
+		charts, err = f.GetCharts("Sheet1", "F20")
+		assert.NoError(t, err)
+		assert.Len(t, charts, 1)
+		assert.Equal(t, Col3DCone, charts[0].Type)
+		assert.Equal(t, "none", charts[0].Legend.Position)
+		// Test get charts on the cell without chart
+		charts, err = f.GetCharts("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Empty(t, charts)
+		cells, err := f.GetChartCells("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"F1", "F20"}, cells)
+	}
+	check(f)
+	// Test get charts from the saved workbook
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetCharts.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err := OpenFile(filepath.Join("test", "TestGetCharts.xlsx"))
+	assert.NoError(t, err)
+	check(f)
+	// Test get charts with invalid cell reference
+	_, err = f.GetCharts("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test get charts on not exists worksheet
+	_, err = f.GetCharts("SheetN", "F1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get chart cells on the worksheet without drawing
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	cells, err := f.GetChartCells("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, cells)
+	// Test get chart cells on not exists worksheet
+	_, err = f.GetChartCells("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get charts with unsupported charset chart part
+	f.Pkg.Store("xl/charts/chart1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetCharts("Sheet1", "F1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get chart cells with unsupported charset drawing part
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetChartCells("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test get charts on the worksheet without drawing
+	f = NewFile()
+	charts, err := f.GetCharts("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Nil(t, charts)
+	assert.NoError(t, f.Close())
+}
+
+func TestChartSeriesTrendlineErrorBars(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"Q1", 10, 12}, {"Q2", 15, 11}, {"Q3", 13, 16}, {"Q4", 18, 17}} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	intercept := 5.0
+	series := []ChartSeries{
+		{
+			Name: "Sheet1!$B$1", Categories: "Sheet1!$A$1:$A$4", Values: "Sheet1!$B$1:$B$4",
+			Trendline: ChartTrendline{Type: ChartTrendlinePolynomial, Order: 3, Forward: 1, Backward: 0.5, Intercept: &intercept, DisplayEquation: true, DisplayRSquared: true},
+			ErrorBars: ChartErrorBars{Type: ChartErrorBarsPercentage, Direction: "plus", Value: 10, NoEndCap: true},
+		},
+		{
+			Name: "Sheet1!$C$1", Categories: "Sheet1!$A$1:$A$4", Values: "Sheet1!$C$1:$C$4",
+			Trendline: ChartTrendline{Type: ChartTrendlineMovingAverage, Period: 300, Forward: 1},
+			ErrorBars: ChartErrorBars{Type: ChartErrorBarsFixedValue, Direction: "unknown"},
+		},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{Type: Line, Series: series}))
+	assert.NoError(t, f.AddChart("Sheet1", "E20", &Chart{Type: Scatter, Series: series[:1]}))
+	assert.NoError(t, f.AddChart("Sheet1", "N1", &Chart{Type: Pie, Series: series[:1]}))
+	assert.NoError(t, f.AddChart("Sheet1", "N20", &Chart{Type: ColStacked, Series: series[:1]}))
+	check := func(f *File) {
+		charts, err := f.GetCharts("Sheet1", "E1")
+		assert.NoError(t, err)
+		assert.Len(t, charts, 1)
+		assert.Equal(t, ChartTrendline{Type: ChartTrendlinePolynomial, Order: 3, Forward: 1, Backward: 0.5, Intercept: &intercept, DisplayEquation: true, DisplayRSquared: true}, charts[0].Series[0].Trendline)
+		assert.Equal(t, ChartErrorBars{Type: ChartErrorBarsPercentage, Direction: "plus", Value: 10, NoEndCap: true}, charts[0].Series[0].ErrorBars)
+		// Test the default value of the trendline period and error bars
+		assert.Equal(t, ChartTrendline{Type: ChartTrendlineMovingAverage, Period: 2}, charts[0].Series[1].Trendline)
+		assert.Equal(t, ChartErrorBars{Type: ChartErrorBarsFixedValue, Direction: "both", Value: 1}, charts[0].Series[1].ErrorBars)
+		// Test the trendline and error bars are ignored for unsupported chart types
+		charts, err = f.GetCharts("Sheet1", "N1")
+		assert.NoError(t, err)
+		assert.Equal(t, ChartTrendline{}, charts[0].Series[0].Trendline)
+		assert.Equal(t, ChartErrorBars{}, charts[0].Series[0].ErrorBars)
+		charts, err = f.GetCharts("Sheet1", "N20")
+		assert.NoError(t, err)
+		assert.Equal(t, ChartTrendline{}, charts[0].Series[0].Trendline)
+		assert.Equal(t, ChartErrorBars{Type: ChartErrorBarsPercentage, Direction: "plus", Value: 10, NoEndCap: true}, charts[0].Series[0].ErrorBars)
+	}
+	check(f)
+	chart, ok := f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	var chartSpace xlsxChartSpace
+	assert.NoError(t, xml.Unmarshal(chart.([]byte), &chartSpace))
+	assert.Equal(t, "y", *(*chartSpace.Chart.PlotArea.ScatterChart[0].Ser)[0].ErrBars.ErrDir.Val)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartSeriesTrendlineErrorBars.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err := OpenFile(filepath.Join("test", "TestChartSeriesTrendlineErrorBars.xlsx"))
+	assert.NoError(t, err)
+	check(f)
+	assert.NoError(t, f.Close())
+}
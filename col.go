@@ -45,7 +45,8 @@ type Cols struct {
 // given worksheet name, returned as a two-dimensional array, where the value
 // of the cell is converted to the `string` type. If the cell format can be
 // applied to the value of the cell, the applied value will be used, otherwise
-// the original value will be used.
+// the original value will be used. Set the FullRange option to get rectangular
+// results padded with empty strings to the length of the longest column.
 //
 // For example, get and traverse the value of all cells by columns on a
 // worksheet named
@@ -72,7 +73,10 @@ func (f *File) GetCols(sheet string, opts ...Options) ([][]string, error) {
 		col, _ := cols.Rows(opts...)
 		results = append(results, col)
 	}
-	return results, nil
+	if !f.getOptions(opts...).FullRange {
+		return results, nil
+	}
+	return fillUsedRange(results), nil
 }
 
 // Next will return true if the next column is found.
@@ -222,8 +226,13 @@ func (f *File) Cols(sheet string) (*Cols, error) {
 		output, _ := xml.Marshal(ws)
 		f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
 	}
-	var colIterator columnXMLIterator
-	colIterator.cols.sheetXML = f.readBytes(name)
+	var (
+		colIterator columnXMLIterator
+		err         error
+	)
+	if colIterator.cols.sheetXML, err = f.readPart(name, true); err != nil {
+		return nil, err
+	}
 	decoder := f.xmlNewDecoder(bytes.NewReader(colIterator.cols.sheetXML))
 	for {
 		token, _ := decoder.Token()
@@ -395,29 +404,141 @@ func (f *File) SetColOutlineLevel(sheet, col string, level uint8) error {
 	if err != nil {
 		return err
 	}
+	var prev uint8
 	if ws.Cols == nil {
 		cols := xlsxCols{}
 		cols.Col = append(cols.Col, colData)
 		ws.Cols = &cols
+	} else {
+		for _, c := range ws.Cols.Col {
+			if c.Min <= colNum && colNum <= c.Max {
+				prev = c.OutlineLevel
+			}
+		}
+		ws.Cols.Col = flatCols(colData, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
+			fc.BestFit = c.BestFit
+			fc.Collapsed = c.Collapsed
+			fc.CustomWidth = c.CustomWidth
+			fc.Hidden = c.Hidden
+			fc.Phonetic = c.Phonetic
+			fc.Style = c.Style
+			fc.Width = c.Width
+			return fc
+		})
+	}
+	ws.setSheetOutlineLevel(columns, prev, level)
+	return err
+}
+
+// SetColCollapsed provides a function to collapse or expand the outline
+// group of detail columns by given worksheet name, column name of the summary
+// column and collapsed state. The detail columns are the adjacent columns
+// with an outline level greater than the summary column, which are placed on
+// the left of the summary column when summary columns appear to the right of
+// detail (default), or on the right of the summary column otherwise. When
+// expanding, the columns of nested groups which are collapsed remain hidden.
+// For example, group columns B-D in Sheet1 summarized by column E and
+// collapse them:
+//
+//	for _, col := range []string{"B", "C", "D"} {
+//	    if err := f.SetColOutlineLevel("Sheet1", col, 1); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	}
+//	err := f.SetColCollapsed("Sheet1", "E", true)
+func (f *File) SetColCollapsed(sheet, col string, collapsed bool) error {
+	colNum, err := ColumnNameToNumber(col)
+	if err != nil {
 		return err
 	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	step := -1
+	if ws.SheetPr != nil && ws.SheetPr.OutlinePr != nil &&
+		ws.SheetPr.OutlinePr.SummaryRight != nil && !*ws.SheetPr.OutlinePr.SummaryRight {
+		step = 1
+	}
+	summary := ws.getCol(colNum)
+	skipLevel := uint8(0)
+	for c := colNum + step; c >= MinColumns && c <= MaxColumns; c += step {
+		detail := ws.getCol(c)
+		if detail.OutlineLevel <= summary.OutlineLevel {
+			break
+		}
+		if collapsed {
+			detail.Hidden = true
+			ws.setCol(detail)
+			continue
+		}
+		if skipLevel > 0 && detail.OutlineLevel > skipLevel {
+			continue
+		}
+		skipLevel, detail.Hidden = 0, false
+		if detail.Collapsed {
+			skipLevel = detail.OutlineLevel
+		}
+		ws.setCol(detail)
+	}
+	summary.Collapsed = collapsed
+	ws.setCol(summary)
+	return err
+}
+
+// GetColCollapsed provides a function to get the collapsed state of the
+// outline group summarized by a single column by given worksheet name and
+// column name. For example, get collapsed state of column E in Sheet1:
+//
+//	collapsed, err := f.GetColCollapsed("Sheet1", "E")
+func (f *File) GetColCollapsed(sheet, col string) (bool, error) {
+	colNum, err := ColumnNameToNumber(col)
+	if err != nil {
+		return false, err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return false, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	return ws.getCol(colNum).Collapsed, err
+}
+
+// getCol returns the properties of a single column by given column number.
+func (ws *xlsxWorksheet) getCol(col int) xlsxCol {
+	colData := xlsxCol{Min: col, Max: col}
+	if ws.Cols != nil {
+		for _, c := range ws.Cols.Col {
+			if c.Min <= col && col <= c.Max {
+				colData = c
+				colData.Min, colData.Max = col, col
+			}
+		}
+	}
+	return colData
+}
+
+// setCol overwrites the properties of a single column by given column
+// properties.
+func (ws *xlsxWorksheet) setCol(colData xlsxCol) {
+	if ws.Cols == nil {
+		ws.Cols = &xlsxCols{Col: []xlsxCol{colData}}
+		return
+	}
 	ws.Cols.Col = flatCols(colData, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
-		fc.BestFit = c.BestFit
-		fc.Collapsed = c.Collapsed
-		fc.CustomWidth = c.CustomWidth
-		fc.Hidden = c.Hidden
-		fc.Phonetic = c.Phonetic
-		fc.Style = c.Style
-		fc.Width = c.Width
 		return fc
 	})
-	return err
 }
 
 // SetColStyle provides a function to set style of columns by given worksheet
 // name, columns range and style ID. This function is concurrency safe. Note
 // that this will overwrite the existing styles for the columns, it won't
-// append or merge style with existing styles.
+// append or merge style with existing styles. The style is stored in the
+// column definition, and only the existing cells in the columns will be
+// updated, so no cell records will be created for the empty cells.
 //
 // For example set style of column H on Sheet1:
 //
@@ -450,6 +571,15 @@ func (f *File) SetColStyle(sheet, columns string, styleID int) error {
 	}
 	s.mu.Unlock()
 	ws.mu.Lock()
+	ws.setColStyle(minVal, maxVal, styleID)
+	ws.mu.Unlock()
+	return err
+}
+
+// setColStyle provides a function to set style of columns by given columns
+// range and style ID, and update the style of the existing cells in the
+// columns.
+func (ws *xlsxWorksheet) setColStyle(minVal, maxVal, styleID int) {
 	if ws.Cols == nil {
 		ws.Cols = &xlsxCols{}
 	}
@@ -472,15 +602,13 @@ func (f *File) SetColStyle(sheet, columns string, styleID int) error {
 		fc.Width = c.Width
 		return fc
 	})
-	ws.mu.Unlock()
-	if rows := len(ws.SheetData.Row); rows > 0 {
-		for col := minVal; col <= maxVal; col++ {
-			from, _ := CoordinatesToCellName(col, 1)
-			to, _ := CoordinatesToCellName(col, rows)
-			err = f.SetCellStyle(sheet, from, to, styleID)
+	for rowIdx := range ws.SheetData.Row {
+		for cellIdx, c := range ws.SheetData.Row[rowIdx].C {
+			if col, _, err := CellNameToCoordinates(c.R); err == nil && minVal <= col && col <= maxVal {
+				ws.SheetData.Row[rowIdx].C[cellIdx].S = styleID
+			}
 		}
 	}
-	return err
 }
 
 // SetColWidth provides a function to set the width of a single column or
@@ -730,6 +858,75 @@ func (f *File) GetColWidth(sheet, col string) (float64, error) {
 	return defaultColWidth, err
 }
 
+// GetColDisplayWidth provides a function to get the effective width of the
+// column as displayed by the spreadsheet application by given worksheet name
+// and column name. Unlike the GetColWidth function, it returns 0 if the column
+// is hidden. This function is concurrency safe. For example, get the displayed
+// width of the column A in Sheet1:
+//
+//	width, err := f.GetColDisplayWidth("Sheet1", "A")
+func (f *File) GetColDisplayWidth(sheet, col string) (float64, error) {
+	colNum, err := ColumnNameToNumber(col)
+	if err != nil {
+		return defaultColWidth, err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return defaultColWidth, err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	width, hidden := defaultColWidth, false
+	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultColWidth > 0 {
+		width = ws.SheetFormatPr.DefaultColWidth
+	}
+	if ws.Cols != nil {
+		for _, v := range ws.Cols.Col {
+			if v.Min <= colNum && colNum <= v.Max {
+				if hidden = v.Hidden; v.Width != nil && *v.Width > 0 {
+					width = *v.Width
+				}
+			}
+		}
+	}
+	if hidden {
+		return 0, err
+	}
+	return width, err
+}
+
+// SetDefaultColWidth provides a function to set the default width of the
+// columns in the worksheet by given worksheet name and width, which will be
+// used for the columns without custom width. This function is concurrency
+// safe. For example, set the default column width of Sheet1 to 12:
+//
+//	err := f.SetDefaultColWidth("Sheet1", 12)
+func (f *File) SetDefaultColWidth(sheet string, width float64) error {
+	if width > MaxColumnWidth {
+		return ErrColumnWidth
+	}
+	if width <= 0 {
+		return ErrParameterInvalid
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.SheetFormatPr == nil {
+		ws.SheetFormatPr = &xlsxSheetFormatPr{DefaultRowHeight: defaultRowHeight}
+	}
+	ws.SheetFormatPr.DefaultColWidth = width
+	return err
+}
+
 // InsertCols provides a function to insert new columns before the given column
 // name and number of columns. For example, create two columns before column
 // C in Sheet1:
@@ -759,7 +956,9 @@ func (f *File) InsertCols(sheet, col string, n int) error {
 // Use this method with caution, which will affect changes in references such
 // as formulas, charts, and so on. If there is any referenced value of the
 // worksheet, it will cause a file error when you open it. The excelize only
-// partially updates these references currently.
+// partially updates these references currently. The references to the
+// removed cells in the formulas, defined names and chart series will be
+// replaced with the "#REF!" error value.
 func (f *File) RemoveCol(sheet, col string) error {
 	num, err := ColumnNameToNumber(col)
 	if err != nil {
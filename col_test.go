@@ -329,6 +329,129 @@ func TestOutlineLevel(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestOutlineCollapsed(t *testing.T) {
+	f := NewFile()
+	// Test collapse and expand rows with nested outline groups
+	for row, level := range map[int]uint8{2: 1, 3: 2, 4: 2, 5: 1, 6: 1} {
+		assert.NoError(t, f.SetRowOutlineLevel("Sheet1", row, level))
+	}
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, uint8(2), ws.(*xlsxWorksheet).SheetFormatPr.OutlineLevelRow)
+	assert.NoError(t, f.SetRowCollapsed("Sheet1", 5, true))
+	assert.NoError(t, f.SetRowCollapsed("Sheet1", 7, true))
+	for row, visible := range map[int]bool{1: true, 2: false, 3: false, 4: false, 5: false, 6: false, 7: true} {
+		v, err := f.GetRowVisible("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, visible, v, row)
+	}
+	assert.NoError(t, f.SetRowCollapsed("Sheet1", 7, false))
+	for row, visible := range map[int]bool{2: true, 3: false, 4: false, 5: true, 6: true} {
+		v, err := f.GetRowVisible("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, visible, v, row)
+	}
+	collapsed, err := f.GetRowCollapsed("Sheet1", 5)
+	assert.NoError(t, err)
+	assert.True(t, collapsed)
+	collapsed, err = f.GetRowCollapsed("Sheet1", 7)
+	assert.NoError(t, err)
+	assert.False(t, collapsed)
+	collapsed, err = f.GetRowCollapsed("Sheet1", 100)
+	assert.NoError(t, err)
+	assert.False(t, collapsed)
+
+	// Test collapse rows with summary rows above detail
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{OutlineSummaryBelow: boolPtr(false)}))
+	assert.NoError(t, f.SetRowCollapsed("Sheet1", 1, true))
+	visible, err := f.GetRowVisible("Sheet1", 6)
+	assert.NoError(t, err)
+	assert.False(t, visible)
+
+	// Test collapse and expand columns
+	for _, col := range []string{"B", "C", "D"} {
+		assert.NoError(t, f.SetColOutlineLevel("Sheet1", col, 1))
+	}
+	assert.NoError(t, f.SetColWidth("Sheet1", "C", "C", 20))
+	assert.Equal(t, uint8(1), ws.(*xlsxWorksheet).SheetFormatPr.OutlineLevelCol)
+	assert.NoError(t, f.SetColCollapsed("Sheet1", "E", true))
+	for _, col := range []string{"B", "C", "D"} {
+		visible, err := f.GetColVisible("Sheet1", col)
+		assert.NoError(t, err)
+		assert.False(t, visible, col)
+	}
+	collapsed, err = f.GetColCollapsed("Sheet1", "E")
+	assert.NoError(t, err)
+	assert.True(t, collapsed)
+	assert.NoError(t, f.SetColCollapsed("Sheet1", "E", false))
+	visible, err = f.GetColVisible("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.True(t, visible)
+	width, err := f.GetColWidth("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	collapsed, err = f.GetColCollapsed("Sheet1", "E")
+	assert.NoError(t, err)
+	assert.False(t, collapsed)
+
+	// Test collapse columns with summary columns on the left of detail
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{OutlineSummaryRight: boolPtr(false)}))
+	assert.NoError(t, f.SetColCollapsed("Sheet1", "A", true))
+	visible, err = f.GetColVisible("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOutlineCollapsed.xlsx")))
+
+	// Test collapse columns without columns properties
+	f = NewFile()
+	assert.NoError(t, f.SetColCollapsed("Sheet1", "A", true))
+	collapsed, err = f.GetColCollapsed("Sheet1", "A")
+	assert.NoError(t, err)
+	assert.True(t, collapsed)
+
+	// Test collapse and get collapsed state with invalid row number
+	assert.EqualError(t, f.SetRowCollapsed("Sheet1", 0, true), newInvalidRowNumberError(0).Error())
+	_, err = f.GetRowCollapsed("Sheet1", 0)
+	assert.EqualError(t, err, newInvalidRowNumberError(0).Error())
+	// Test collapse and get collapsed state with invalid column name
+	assert.EqualError(t, f.SetColCollapsed("Sheet1", "*", true), newInvalidColumnNameError("*").Error())
+	_, err = f.GetColCollapsed("Sheet1", "*")
+	assert.EqualError(t, err, newInvalidColumnNameError("*").Error())
+	// Test collapse and get collapsed state on not exists worksheet
+	assert.EqualError(t, f.SetRowCollapsed("SheetN", 1, true), "sheet SheetN does not exist")
+	_, err = f.GetRowCollapsed("SheetN", 1)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.EqualError(t, f.SetColCollapsed("SheetN", "A", true), "sheet SheetN does not exist")
+	_, err = f.GetColCollapsed("SheetN", "A")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
+func TestSheetOutlineLevel(t *testing.T) {
+	f := NewFile()
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	// Test set outline level of the column in the worksheet without columns
+	assert.NoError(t, f.SetColOutlineLevel("Sheet1", "B", 2))
+	assert.Equal(t, uint8(2), ws.(*xlsxWorksheet).SheetFormatPr.OutlineLevelCol)
+	assert.NoError(t, f.SetColOutlineLevel("Sheet1", "C", 1))
+	assert.Equal(t, uint8(2), ws.(*xlsxWorksheet).SheetFormatPr.OutlineLevelCol)
+	// Test decrease the outline level of the column with the maximum level
+	assert.NoError(t, f.SetColOutlineLevel("Sheet1", "B", 1))
+	assert.Equal(t, uint8(1), ws.(*xlsxWorksheet).SheetFormatPr.OutlineLevelCol)
+	// Test set outline level of rows
+	for _, level := range []uint8{3, 1, 2} {
+		assert.NoError(t, f.SetRowOutlineLevel("Sheet1", int(level)+1, level))
+	}
+	assert.Equal(t, uint8(3), ws.(*xlsxWorksheet).SheetFormatPr.OutlineLevelRow)
+	// Test decrease the outline level of the row with the maximum level
+	assert.NoError(t, f.SetRowOutlineLevel("Sheet1", 4, 1))
+	assert.Equal(t, uint8(2), ws.(*xlsxWorksheet).SheetFormatPr.OutlineLevelRow)
+	assert.NoError(t, f.SetRowOutlineLevel("Sheet1", 2, 1))
+	assert.Equal(t, uint8(2), ws.(*xlsxWorksheet).SheetFormatPr.OutlineLevelRow)
+	assert.Equal(t, uint8(1), ws.(*xlsxWorksheet).SheetFormatPr.OutlineLevelCol)
+	assert.NoError(t, f.Close())
+}
+
 func TestSetColStyle(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "Hello"))
@@ -357,7 +480,9 @@ func TestSetColStyle(t *testing.T) {
 	assert.NoError(t, f.SetColStyle("Sheet1", "D:C", styleID))
 	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
 	assert.True(t, ok)
-	ws.(*xlsxWorksheet).SheetData.Row[1].C[2].S = 0
+	// Test set column style doesn't create cells for the columns
+	assert.Len(t, ws.(*xlsxWorksheet).SheetData.Row[1].C, 2)
+	assert.Equal(t, styleID, ws.(*xlsxWorksheet).SheetData.Row[1].C[1].S)
 	cellStyleID, err := f.GetCellStyle("Sheet1", "C2")
 	assert.NoError(t, err)
 	assert.Equal(t, styleID, cellStyleID)
@@ -418,6 +543,39 @@ func TestColWidth(t *testing.T) {
 	convertRowHeightToPixels(0)
 }
 
+func TestColDisplayWidth(t *testing.T) {
+	f := NewFile()
+	width, err := f.GetColDisplayWidth("Sheet1", "A")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultColWidth, width)
+	assert.NoError(t, f.SetDefaultColWidth("Sheet1", 12))
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "C", 20))
+	assert.NoError(t, f.SetColVisible("Sheet1", "C:D", false))
+	for col, expected := range map[string]float64{"A": 12, "B": 20, "C": 0, "D": 0, "E": 12} {
+		width, err = f.GetColDisplayWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, width, col)
+	}
+	width, err = f.GetColWidth("Sheet1", "A")
+	assert.NoError(t, err)
+	assert.Equal(t, 12.0, width)
+	// Test set default column width with existing sheet format properties
+	assert.NoError(t, f.SetDefaultColWidth("Sheet1", 15))
+	props, err := f.GetSheetProps("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 15.0, *props.DefaultColWidth)
+	assert.Equal(t, defaultRowHeight, *props.DefaultRowHeight)
+
+	// Test get and set default column width with invalid arguments
+	_, err = f.GetColDisplayWidth("Sheet1", "*")
+	assert.Equal(t, newInvalidColumnNameError("*"), err)
+	_, err = f.GetColDisplayWidth("SheetN", "A")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.Equal(t, ErrColumnWidth, f.SetDefaultColWidth("Sheet1", MaxColumnWidth+1))
+	assert.Equal(t, ErrParameterInvalid, f.SetDefaultColWidth("Sheet1", 0))
+	assert.EqualError(t, f.SetDefaultColWidth("SheetN", 10), "sheet SheetN does not exist")
+}
+
 func TestGetColStyle(t *testing.T) {
 	f := NewFile()
 	styleID, err := f.GetColStyle("Sheet1", "A")
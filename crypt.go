@@ -15,6 +15,7 @@ import (
 	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/hmac"
 	"crypto/md5"
 	"crypto/rand"
 	"crypto/sha1"
@@ -23,6 +24,7 @@ import (
 	"encoding/base64"
 	"encoding/binary"
 	"encoding/xml"
+	"fmt"
 	"hash"
 	"math"
 	"path/filepath"
@@ -36,9 +38,18 @@ import (
 	"golang.org/x/text/encoding/unicode"
 )
 
+// keyEncryptorPasswordURI specifies the schema used by password-based key
+// encryptors.
+const keyEncryptorPasswordURI = "http://schemas.microsoft.com/office/2006/keyEncryptor/password"
+
 var (
 	blockKey                    = []byte{0x14, 0x6e, 0x0b, 0xe7, 0xab, 0xac, 0xd0, 0xd6} // Block keys used for encryption
+	blockKeyVerifierHashInput   = []byte{0xfe, 0xa7, 0xd2, 0x76, 0x3b, 0x4b, 0x9e, 0x79}
+	blockKeyVerifierHashValue   = []byte{0xd7, 0xaa, 0x0f, 0x6d, 0x30, 0x61, 0x34, 0x4e}
+	blockKeyIntegrityHmacKey    = []byte{0x5f, 0xb2, 0xad, 0x01, 0x0c, 0xb9, 0xe1, 0xf6}
+	blockKeyIntegrityHmacValue  = []byte{0xa0, 0x67, 0x7f, 0x02, 0xb2, 0x2c, 0x84, 0x33}
 	oleIdentifier               = []byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1}
+	agileEncryptionSpinCount    = 100000
 	headerCLSID                 = make([]byte, 16)
 	difSect                     = -4
 	endOfChain                  = -2
@@ -129,14 +140,6 @@ type StandardEncryptionVerifier struct {
 	EncryptedVerifierHash []byte
 }
 
-// encryptionInfo structure is used for standard encryption with SHA1
-// cryptographic algorithm.
-type encryption struct {
-	BlockSize, SaltSize                                                                  int
-	EncryptedKeyValue, EncryptedVerifierHashInput, EncryptedVerifierHashValue, SaltValue []byte
-	KeyBits                                                                              uint32
-}
-
 // Decrypt API decrypts the CFB file format with ECMA-376 agile encryption and
 // standard encryption. Support cryptographic algorithm: MD4, MD5, RIPEMD-160,
 // SHA1, SHA256, SHA384 and SHA512 currently.
@@ -156,31 +159,48 @@ func Decrypt(raw []byte, opts *Options) (packageBuf []byte, err error) {
 	return standardDecrypt(encryptionInfoBuf, encryptedPackageBuf, opts)
 }
 
-// Encrypt API encrypt data with the password.
+// Encrypt API encrypt data with the password by ECMA-376 agile encryption,
+// uses the AES-256 cipher algorithm with the SHA512 hash algorithm.
 func Encrypt(raw []byte, opts *Options) ([]byte, error) {
-	encryptor := encryption{
-		EncryptedVerifierHashInput: make([]byte, 16),
-		EncryptedVerifierHashValue: make([]byte, 32),
-		SaltValue:                  make([]byte, 16),
-		BlockSize:                  16,
-		KeyBits:                    128,
-		SaltSize:                   16,
+	if len(opts.Password) == 0 || len(opts.Password) > MaxFieldLength {
+		return nil, ErrPasswordLengthInvalid
 	}
+	keyDataSaltValue, _ := randomBytes(16)
+	encryptedKeySaltValue, _ := randomBytes(16)
+	keyData := KeyData{
+		SaltSize:        16,
+		BlockSize:       16,
+		KeyBits:         256,
+		HashSize:        64,
+		CipherAlgorithm: "AES",
+		CipherChaining:  "ChainingModeCBC",
+		HashAlgorithm:   "SHA512",
+	}
+	encryption := Encryption{KeyData: keyData}
+	encryption.KeyData.SaltValue = base64.StdEncoding.EncodeToString(keyDataSaltValue)
+	encryptedKey := EncryptedKey{SpinCount: agileEncryptionSpinCount, KeyData: keyData}
+	encryptedKey.SaltValue = base64.StdEncoding.EncodeToString(encryptedKeySaltValue)
+	encryption.KeyEncryptors.KeyEncryptor = []KeyEncryptor{{URI: keyEncryptorPasswordURI, EncryptedKey: encryptedKey}}
 	// Key Encryption
-	encryptionInfoBuffer, err := encryptor.standardKeyEncryption(opts.Password)
-	if err != nil {
+	packageKey, _ := randomBytes(keyData.KeyBits / 8)
+	if err := agileKeyEncryption(opts.Password, packageKey, &encryption); err != nil {
 		return nil, err
 	}
 	// Package Encryption
-	encryptedPackage := make([]byte, 8)
-	binary.LittleEndian.PutUint64(encryptedPackage, uint64(len(raw)))
-	encryptedPackage = append(encryptedPackage, encryptor.encrypt(raw)...)
+	encryptedPackage, err := encryptPackage(packageKey, raw, encryption)
+	if err != nil {
+		return nil, err
+	}
+	// Data Integrity
+	if err = agileDataIntegrity(packageKey, encryptedPackage, &encryption); err != nil {
+		return nil, err
+	}
 	// Create a new CFB
 	compoundFile := &cfb{
 		paths:   []string{"Root Entry/"},
 		sectors: []sector{{name: "Root Entry", typeID: 5}},
 	}
-	compoundFile.put("EncryptionInfo", encryptionInfoBuffer)
+	compoundFile.put("EncryptionInfo", agileEncryptionInfo(encryption))
 	compoundFile.put("EncryptedPackage", encryptedPackage)
 	return compoundFile.write(), nil
 }
@@ -329,68 +349,6 @@ func standardXORBytes(a, b []byte) []byte {
 	return buf
 }
 
-// encrypt provides a function to encrypt given value with AES cryptographic
-// algorithm.
-func (e *encryption) encrypt(input []byte) []byte {
-	inputBytes := len(input)
-	if pad := inputBytes % e.BlockSize; pad != 0 {
-		inputBytes += e.BlockSize - pad
-	}
-	var output, chunk []byte
-	encryptedChunk := make([]byte, e.BlockSize)
-	for i := 0; i < inputBytes; i += e.BlockSize {
-		if i+e.BlockSize <= len(input) {
-			chunk = input[i : i+e.BlockSize]
-		} else {
-			chunk = input[i:]
-		}
-		chunk = append(chunk, make([]byte, e.BlockSize-len(chunk))...)
-		c, _ := aes.NewCipher(e.EncryptedKeyValue)
-		c.Encrypt(encryptedChunk, chunk)
-		output = append(output, encryptedChunk...)
-	}
-	return output
-}
-
-// standardKeyEncryption encrypt convert the password to an encryption key.
-func (e *encryption) standardKeyEncryption(password string) ([]byte, error) {
-	if len(password) == 0 || len(password) > MaxFieldLength {
-		return nil, ErrPasswordLengthInvalid
-	}
-	var storage cfb
-	storage.writeUint16(0x0003)
-	storage.writeUint16(0x0002)
-	storage.writeUint32(0x24)
-	storage.writeUint32(0xA4)
-	storage.writeUint32(0x24)
-	storage.writeUint32(0x00)
-	storage.writeUint32(0x660E)
-	storage.writeUint32(0x8004)
-	storage.writeUint32(0x80)
-	storage.writeUint32(0x18)
-	storage.writeUint64(0x00)
-	providerName := "Microsoft Enhanced RSA and AES Cryptographic Provider (Prototype)"
-	storage.writeStrings(providerName)
-	storage.writeUint16(0x00)
-	storage.writeUint32(0x10)
-	keyDataSaltValue, _ := randomBytes(16)
-	verifierHashInput, _ := randomBytes(16)
-	e.SaltValue = keyDataSaltValue
-	e.EncryptedKeyValue, _ = standardConvertPasswdToKey(
-		StandardEncryptionHeader{KeySize: e.KeyBits},
-		StandardEncryptionVerifier{Salt: e.SaltValue},
-		&Options{Password: password})
-	verifierHashInputKey := hashing("sha1", verifierHashInput)
-	e.EncryptedVerifierHashInput = e.encrypt(verifierHashInput)
-	e.EncryptedVerifierHashValue = e.encrypt(verifierHashInputKey)
-	storage.writeBytes(e.SaltValue)
-	storage.writeBytes(e.EncryptedVerifierHashInput)
-	storage.writeUint32(0x14)
-	storage.writeBytes(e.EncryptedVerifierHashValue)
-	storage.position = 0
-	return storage.stream, nil
-}
-
 // ECMA-376 Agile Encryption
 
 // agileDecrypt decrypt the CFB file format with ECMA-376 agile encryption.
@@ -416,9 +374,136 @@ func agileDecrypt(encryptionInfoBuf, encryptedPackageBuf []byte, opts *Options)
 	if err != nil {
 		return
 	}
+	if err = verifyAgilePasswd(opts.Password, saltValue, encryptionInfo); err != nil {
+		return
+	}
 	packageKey, _ := decrypt(key, saltValue, encryptedKeyValue)
 	// Use the package key to decrypt the package.
-	return decryptPackage(packageKey, encryptedPackageBuf, encryptionInfo)
+	if packageBuf, err = decryptPackage(packageKey, encryptedPackageBuf, encryptionInfo); err != nil {
+		return
+	}
+	if len(encryptedPackageBuf) >= packageOffset {
+		if size := binary.LittleEndian.Uint64(encryptedPackageBuf[:packageOffset]); size < uint64(len(packageBuf)) {
+			packageBuf = packageBuf[:size]
+		}
+	}
+	return
+}
+
+// verifyAgilePasswd checks the given password by the encrypted verifier hash
+// input and value of the password key encryptor.
+func verifyAgilePasswd(passwd string, saltValue []byte, encryption Encryption) error {
+	encryptedKey := encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey
+	if encryptedKey.EncryptedVerifierHashInput == "" || encryptedKey.EncryptedVerifierHashValue == "" {
+		return nil
+	}
+	verifier := make([][]byte, 2)
+	for i, item := range []struct {
+		blockKey []byte
+		value    string
+		size     int
+	}{
+		{blockKeyVerifierHashInput, encryptedKey.EncryptedVerifierHashInput, encryptedKey.SaltSize},
+		{blockKeyVerifierHashValue, encryptedKey.EncryptedVerifierHashValue, encryptedKey.HashSize},
+	} {
+		key, err := convertPasswdToKey(passwd, item.blockKey, encryption)
+		if err != nil {
+			return err
+		}
+		value, err := base64.StdEncoding.DecodeString(item.value)
+		if err != nil {
+			return err
+		}
+		if value, err = decrypt(key, saltValue, value); err != nil {
+			return err
+		}
+		if item.size > 0 && item.size < len(value) {
+			value = value[:item.size]
+		}
+		verifier[i] = value
+	}
+	if !bytes.Equal(hashing(encryptedKey.HashAlgorithm, verifier[0]), verifier[1]) {
+		return ErrWorkbookPassword
+	}
+	return nil
+}
+
+// agileKeyEncryption generates the encrypted verifier hash input, verifier
+// hash value and package key value of the password key encryptor.
+func agileKeyEncryption(passwd string, packageKey []byte, encryption *Encryption) error {
+	encryptedKey := &encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey
+	saltValue, err := base64.StdEncoding.DecodeString(encryptedKey.SaltValue)
+	if err != nil {
+		return err
+	}
+	verifierHashInput, _ := randomBytes(encryptedKey.SaltSize)
+	for _, item := range []struct {
+		blockKey, value []byte
+		attr            *string
+	}{
+		{blockKeyVerifierHashInput, verifierHashInput, &encryptedKey.EncryptedVerifierHashInput},
+		{blockKeyVerifierHashValue, hashing(encryptedKey.HashAlgorithm, verifierHashInput), &encryptedKey.EncryptedVerifierHashValue},
+		{blockKey, packageKey, &encryptedKey.EncryptedKeyValue},
+	} {
+		key, err := convertPasswdToKey(passwd, item.blockKey, *encryption)
+		if err != nil {
+			return err
+		}
+		value, err := encrypt(key, saltValue, item.value)
+		if err != nil {
+			return err
+		}
+		*item.attr = base64.StdEncoding.EncodeToString(value)
+	}
+	return nil
+}
+
+// agileDataIntegrity generates the encrypted HMAC key and value of the
+// encrypted package stream.
+func agileDataIntegrity(packageKey, encryptedPackage []byte, encryption *Encryption) error {
+	hmacKey, _ := randomBytes(encryption.KeyData.HashSize)
+	handler := hmac.New(sha512.New, hmacKey)
+	_, _ = handler.Write(encryptedPackage)
+	for _, item := range []struct {
+		blockKey, value []byte
+		attr            *string
+	}{
+		{blockKeyIntegrityHmacKey, hmacKey, &encryption.DataIntegrity.EncryptedHmacKey},
+		{blockKeyIntegrityHmacValue, handler.Sum(nil), &encryption.DataIntegrity.EncryptedHmacValue},
+	} {
+		iv, err := createIV(item.blockKey, *encryption)
+		if err != nil {
+			return err
+		}
+		value, err := encrypt(packageKey, iv, item.value)
+		if err != nil {
+			return err
+		}
+		*item.attr = base64.StdEncoding.EncodeToString(value)
+	}
+	return nil
+}
+
+// agileEncryptionInfo generates the EncryptionInfo stream with ECMA-376 agile
+// encryption by given encryption info.
+func agileEncryptionInfo(encryption Encryption) []byte {
+	keyDataAttrs := func(keyData KeyData) string {
+		return fmt.Sprintf(`saltSize="%d" blockSize="%d" keyBits="%d" hashSize="%d" cipherAlgorithm="%s" cipherChaining="%s" hashAlgorithm="%s" saltValue="%s"`,
+			keyData.SaltSize, keyData.BlockSize, keyData.KeyBits, keyData.HashSize,
+			keyData.CipherAlgorithm, keyData.CipherChaining, keyData.HashAlgorithm, keyData.SaltValue)
+	}
+	encryptedKey := encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey
+	var storage cfb
+	storage.writeUint16(0x0004)
+	storage.writeUint16(0x0004)
+	storage.writeUint32(0x40)
+	storage.writeBytes([]byte(xml.Header[:len(xml.Header)-1] + "\r\n" + fmt.Sprintf(
+		`<encryption xmlns="http://schemas.microsoft.com/office/2006/encryption" xmlns:p="%s"><keyData %s/><dataIntegrity encryptedHmacKey="%s" encryptedHmacValue="%s"/><keyEncryptors><keyEncryptor uri="%s"><p:encryptedKey spinCount="%d" %s encryptedVerifierHashInput="%s" encryptedVerifierHashValue="%s" encryptedKeyValue="%s"/></keyEncryptor></keyEncryptors></encryption>`,
+		keyEncryptorPasswordURI, keyDataAttrs(encryption.KeyData),
+		encryption.DataIntegrity.EncryptedHmacKey, encryption.DataIntegrity.EncryptedHmacValue,
+		keyEncryptorPasswordURI, encryptedKey.SpinCount, keyDataAttrs(encryptedKey.KeyData),
+		encryptedKey.EncryptedVerifierHashInput, encryptedKey.EncryptedVerifierHashValue, encryptedKey.EncryptedKeyValue)))
+	return storage.stream
 }
 
 // convertPasswdToKey convert the password into an encryption key.
@@ -502,6 +587,46 @@ func decrypt(key, iv, input []byte) (packageKey []byte, err error) {
 	return input, nil
 }
 
+// encrypt provides a function to encrypt input with the AES cipher algorithm
+// and CBC cipher chaining by given key and initialization vector, the input
+// will be padded to an integer multiple of the block size.
+func encrypt(key, iv, input []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	output := make([]byte, len(input))
+	copy(output, input)
+	if remainder := len(output) % block.BlockSize(); remainder != 0 {
+		output = append(output, make([]byte, block.BlockSize()-remainder)...)
+	}
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(output, output)
+	return output, nil
+}
+
+// encryptPackage encrypt package by given packageKey and encryption info, the
+// first 8 bytes of the encrypted package stream are the size of the package.
+func encryptPackage(packageKey, input []byte, encryption Encryption) ([]byte, error) {
+	output := make([]byte, packageOffset)
+	binary.LittleEndian.PutUint64(output, uint64(len(input)))
+	for i, start := 0, 0; start < len(input); i, start = i+1, start+packageEncryptionChunkSize {
+		end := start + packageEncryptionChunkSize
+		if end > len(input) {
+			end = len(input)
+		}
+		iv, err := createIV(i, encryption)
+		if err != nil {
+			return nil, err
+		}
+		outputChunk, err := encrypt(packageKey, iv, input[start:end])
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, outputChunk...)
+	}
+	return output, nil
+}
+
 // decryptPackage decrypt package by given packageKey and encryption
 // info.
 func decryptPackage(packageKey, input []byte, encryption Encryption) (outputChunks []byte, err error) {
@@ -944,7 +1069,7 @@ func (c *cfb) writeSectorChains(location []int) sector {
 		if sectorSize = len(sector.content); sectorSize == 0 || sectorSize >= 0x1000 {
 			continue
 		}
-		sector.start = offset
+		c.sectors[j].start = offset
 		offset = writeSectorChain((sectorSize+0x3F)>>6, offset)
 	}
 	for c.position&0x1FF != 0 {
@@ -34,10 +34,17 @@ func TestEncrypt(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "SECRET", cell)
 	assert.NoError(t, f.Close())
-	// Test decrypt spreadsheet with unsupported encrypt mechanism
+	// Test decrypt spreadsheet with unsupported encrypt mechanism, locate the
+	// EncryptionInfo stream by its first mini sector in the compound file, and
+	// change the minor version of the stream to 3 for the extensible encryption
 	raw, err := os.ReadFile(filepath.Join("test", "encryptAES.xlsx"))
 	assert.NoError(t, err)
-	raw[2050] = 3
+	doc, err := mscfb.New(bytes.NewReader(raw))
+	assert.NoError(t, err)
+	encryptionInfoBuf, _ := extractPart(doc)
+	offset := bytes.Index(raw, encryptionInfoBuf[:64])
+	assert.NotEqual(t, -1, offset)
+	binary.LittleEndian.PutUint16(raw[offset+2:offset+4], 3)
 	_, err = Decrypt(raw, &Options{Password: "password"})
 	assert.Equal(t, ErrUnsupportedEncryptMechanism, err)
 
@@ -51,13 +58,36 @@ func TestEncrypt(t *testing.T) {
 	cell, err = f.GetCellValue("Sheet1", "A1")
 	assert.NoError(t, err)
 	assert.Equal(t, "SECRET", cell)
+	// Test decrypt spreadsheet with incorrect password after encrypted
+	_, err = OpenFile(filepath.Join("test", "Encryption.xlsx"), Options{Password: "password"})
+	assert.Equal(t, ErrWorkbookPassword, err)
+	// Test encrypt spreadsheet with ECMA-376 agile encryption
+	raw, err = os.ReadFile(filepath.Join("test", "Encryption.xlsx"))
+	assert.NoError(t, err)
+	doc, err = mscfb.New(bytes.NewReader(raw))
+	assert.NoError(t, err)
+	encryptionInfoBuf, encryptedPackageBuf := extractPart(doc)
+	mechanism, err := encryptionMechanism(encryptionInfoBuf)
+	assert.NoError(t, err)
+	assert.Equal(t, "agile", mechanism)
+	encryptionInfo, err := parseEncryptionInfo(encryptionInfoBuf[8:])
+	assert.NoError(t, err)
+	assert.Equal(t, "SHA512", encryptionInfo.KeyData.HashAlgorithm)
+	assert.Equal(t, 256, encryptionInfo.KeyEncryptors.KeyEncryptor[0].EncryptedKey.KeyBits)
+	assert.Equal(t, agileEncryptionSpinCount, encryptionInfo.KeyEncryptors.KeyEncryptor[0].EncryptedKey.SpinCount)
+	assert.NotEmpty(t, encryptionInfo.DataIntegrity.EncryptedHmacValue)
+	packageBuf, err := agileDecrypt(encryptionInfoBuf, encryptedPackageBuf, &Options{Password: "passwd"})
+	assert.NoError(t, err)
+	assert.Equal(t, binary.LittleEndian.Uint64(encryptedPackageBuf[:8]), uint64(len(packageBuf)))
 	// Test remove password by save workbook with options
 	assert.NoError(t, f.Save(Options{Password: ""}))
 	assert.NoError(t, f.Close())
 
-	doc, err := mscfb.New(bytes.NewReader(raw))
+	raw, err = os.ReadFile(filepath.Join("test", "encryptAES.xlsx"))
 	assert.NoError(t, err)
-	encryptionInfoBuf, encryptedPackageBuf := extractPart(doc)
+	doc, err = mscfb.New(bytes.NewReader(raw))
+	assert.NoError(t, err)
+	encryptionInfoBuf, encryptedPackageBuf = extractPart(doc)
 	binary.LittleEndian.PutUint64(encryptionInfoBuf[20:32], uint64(0))
 	_, err = standardDecrypt(encryptionInfoBuf, encryptedPackageBuf, &Options{Password: "password"})
 	assert.NoError(t, err)
@@ -73,6 +103,50 @@ func TestEncrypt(t *testing.T) {
 	assert.EqualError(t, err, "illegal base64 data at input byte 0")
 	_, err = createIV([]byte{0}, Encryption{KeyData: KeyData{SaltValue: "=="}})
 	assert.EqualError(t, err, "illegal base64 data at input byte 0")
+	_, err = encrypt(nil, nil, nil)
+	assert.EqualError(t, err, "crypto/aes: invalid key size 0")
+	_, err = encryptPackage(nil, []byte{0}, Encryption{KeyData: KeyData{SaltValue: "=="}})
+	assert.EqualError(t, err, "illegal base64 data at input byte 0")
+	_, err = encryptPackage(nil, []byte{0}, Encryption{KeyData: KeyData{HashAlgorithm: "SHA512", BlockSize: 16}})
+	assert.EqualError(t, err, "crypto/aes: invalid key size 0")
+	encryption := Encryption{KeyEncryptors: KeyEncryptors{KeyEncryptor: []KeyEncryptor{
+		{EncryptedKey: EncryptedKey{KeyData: KeyData{SaltValue: "=="}}},
+	}}}
+	assert.EqualError(t, agileKeyEncryption("password", nil, &encryption), "illegal base64 data at input byte 0")
+	encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey.EncryptedVerifierHashInput = "=="
+	encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey.EncryptedVerifierHashValue = "=="
+	assert.EqualError(t, verifyAgilePasswd("password", nil, encryption), "illegal base64 data at input byte 0")
+	assert.EqualError(t, agileDataIntegrity(nil, nil, &Encryption{KeyData: KeyData{SaltValue: "=="}}), "illegal base64 data at input byte 0")
+	assert.EqualError(t, agileDataIntegrity(nil, nil, &Encryption{KeyData: KeyData{HashAlgorithm: "SHA512", BlockSize: 16}}), "crypto/aes: invalid key size 0")
+}
+
+func TestCompoundFileWrite(t *testing.T) {
+	// Test write compound file with multiple streams in the mini stream
+	compoundFile := &cfb{
+		paths:   []string{"Root Entry/"},
+		sectors: []sector{{name: "Root Entry", typeID: 5}},
+	}
+	streams := map[string][]byte{
+		"EncryptionInfo":   bytes.Repeat([]byte{1}, 100),
+		"EncryptedPackage": bytes.Repeat([]byte{2}, 0x1000),
+		"Stream":           bytes.Repeat([]byte{3}, 200),
+	}
+	for _, name := range []string{"EncryptionInfo", "EncryptedPackage", "Stream"} {
+		compoundFile.put(name, streams[name])
+	}
+	doc, err := mscfb.New(bytes.NewReader(compoundFile.write()))
+	assert.NoError(t, err)
+	var count int
+	for entry, err := doc.Next(); err == nil; entry, err = doc.Next() {
+		if expected, ok := streams[entry.Name]; ok {
+			buf := make([]byte, entry.Size)
+			_, err = doc.Read(buf)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, buf)
+			count++
+		}
+	}
+	assert.Equal(t, len(streams), count)
 }
 
 func TestEncryptionMechanism(t *testing.T) {
@@ -12,6 +12,7 @@
 package excelize
 
 import (
+	"encoding/xml"
 	"fmt"
 	"io"
 	"math"
@@ -208,8 +209,16 @@ func (dv *DataValidation) SetRange(f1, f2 interface{}, t DataValidationType, o D
 //	dv.Sqref = "A7:B8"
 //	dv.SetSqrefDropList("$E$1:$E$3")
 //	err := f.AddDataValidation("Sheet1", dv)
+//
+// The source reference range could be on another worksheet, for example, set
+// data validation on Sheet1!A9 with validation criteria source Sheet2!A1:A3:
+//
+//	dv := excelize.NewDataValidation(true)
+//	dv.Sqref = "A9"
+//	dv.SetSqrefDropList("Sheet2!$A$1:$A$3")
+//	err := f.AddDataValidation("Sheet1", dv)
 func (dv *DataValidation) SetSqrefDropList(sqref string) {
-	dv.Formula1 = sqref
+	dv.Formula1 = formulaEscaper.Replace(sqref)
 	dv.Type = dataValidationTypeMap[DataValidationTypeList]
 }
 
@@ -363,7 +372,9 @@ func getDataValidations(dvs *xlsxDataValidations) []*DataValidation {
 // DeleteDataValidation delete data validation by given worksheet name and
 // reference sequence. This function is concurrency safe.
 // All data validations in the worksheet will be deleted
-// if not specify reference sequence parameter.
+// if not specify reference sequence parameter. The data validations stored
+// in the worksheet extension list, such as list source from another worksheet
+// created by Excel 2010, will be deleted as well.
 func (f *File) DeleteDataValidation(sheet string, sqref ...string) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -371,23 +382,45 @@ func (f *File) DeleteDataValidation(sheet string, sqref ...string) error {
 	}
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
-	if ws.DataValidations == nil {
+	if ws.DataValidations == nil && ws.ExtLst == nil {
 		return nil
 	}
-	if sqref == nil {
-		ws.DataValidations = nil
-		return nil
+	var delCells map[int][][]int
+	if sqref != nil {
+		if delCells, err = flatSqref(sqref[0]); err != nil {
+			return err
+		}
 	}
-	delCells, err := flatSqref(sqref[0])
-	if err != nil {
-		return err
+	if ws.DataValidations != nil {
+		dv := ws.DataValidations
+		if dv.DataValidation, err = deleteDataValidation(dv.DataValidation, delCells, false); err != nil {
+			return err
+		}
+		dv.Count = len(dv.DataValidation)
+		if dv.Count == 0 {
+			ws.DataValidations = nil
+		}
+	}
+	return f.deleteExtDataValidation(ws, delCells)
+}
+
+// deleteDataValidation removes the given cells from the reference sequence of
+// data validations, the data validation will be removed if no cell left in
+// the reference sequence. All data validations will be removed if the given
+// cells is nil.
+func deleteDataValidation(dvs []*xlsxDataValidation, delCells map[int][][]int, ext bool) ([]*xlsxDataValidation, error) {
+	if delCells == nil {
+		return nil, nil
 	}
-	dv := ws.DataValidations
-	for i := 0; i < len(dv.DataValidation); i++ {
+	for i := 0; i < len(dvs); i++ {
 		var applySqref []string
-		colCells, err := flatSqref(dv.DataValidation[i].Sqref)
+		ref := dvs[i].Sqref
+		if ext {
+			ref = dvs[i].XMSqref
+		}
+		colCells, err := flatSqref(ref)
 		if err != nil {
-			return err
+			return dvs, err
 		}
 		for col, cells := range delCells {
 			for _, cell := range cells {
@@ -400,17 +433,61 @@ func (f *File) DeleteDataValidation(sheet string, sqref ...string) error {
 		for _, col := range colCells {
 			applySqref = append(applySqref, squashSqref(col)...)
 		}
-		dv.DataValidation[i].Sqref = strings.Join(applySqref, " ")
+		if ext {
+			dvs[i].XMSqref = strings.Join(applySqref, " ")
+		} else {
+			dvs[i].Sqref = strings.Join(applySqref, " ")
+		}
 		if len(applySqref) == 0 {
-			dv.DataValidation = append(dv.DataValidation[:i], dv.DataValidation[i+1:]...)
+			dvs = append(dvs[:i], dvs[i+1:]...)
 			i--
 		}
 	}
-	dv.Count = len(dv.DataValidation)
-	if dv.Count == 0 {
-		ws.DataValidations = nil
+	return dvs, nil
+}
+
+// deleteExtDataValidation removes the given cells from the data validations
+// in the worksheet extension list. All data validations in the extension
+// list will be removed if the given cells is nil.
+func (f *File) deleteExtDataValidation(ws *xlsxWorksheet, delCells map[int][][]int) error {
+	if ws.ExtLst == nil {
+		return nil
 	}
-	return nil
+	decodeExtLst := new(decodeExtLst)
+	if err := f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return err
+	}
+	for i := 0; i < len(decodeExtLst.Ext); i++ {
+		ext := decodeExtLst.Ext[i]
+		if ext.URI != ExtURIDataValidations {
+			continue
+		}
+		decodeDataValidations := new(xlsxDataValidations)
+		_ = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(decodeDataValidations)
+		dvs, err := deleteDataValidation(decodeDataValidations.DataValidation, delCells, true)
+		if err != nil {
+			return err
+		}
+		if len(dvs) == 0 {
+			decodeExtLst.Ext = append(decodeExtLst.Ext[:i], decodeExtLst.Ext[i+1:]...)
+			i--
+			continue
+		}
+		dataValidations := &xlsxX14DataValidations{XMLNSXM: NameSpaceSpreadSheetExcel2006Main.Value, Count: len(dvs)}
+		for _, dv := range dvs {
+			dataValidations.DataValidation = append(dataValidations.DataValidation, (*xlsxX14DataValidation)(dv))
+		}
+		dataValidationsBytes, _ := xml.Marshal(dataValidations)
+		ext.Content = string(dataValidationsBytes)
+	}
+	if len(decodeExtLst.Ext) == 0 {
+		ws.ExtLst = nil
+		return nil
+	}
+	extLstBytes, err := xml.Marshal(decodeExtLst)
+	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+	return err
 }
 
 // squashSqref generates cell reference sequence by given cells coordinates list.
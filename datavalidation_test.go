@@ -242,4 +242,35 @@ func TestDeleteDataValidation(t *testing.T) {
 	// Test delete all data validations in the worksheet
 	assert.NoError(t, f.DeleteDataValidation("Sheet1"))
 	assert.Nil(t, ws.(*xlsxWorksheet).DataValidations)
+
+	// Test delete data validations which storage in the extension lists
+	extLst := fmt.Sprintf(`<ext uri="%s" xmlns:x14="%s"><x14:dataValidations count="2"><x14:dataValidation type="list" allowBlank="1"><x14:formula1><xm:f>Sheet2!$B$1:$B$5</xm:f></x14:formula1><xm:sqref>A7:B8</xm:sqref></x14:dataValidation><x14:dataValidation type="list"><x14:formula1><xm:f>Sheet2!$C$1:$C$5</xm:f></x14:formula1><xm:sqref>D1</xm:sqref></x14:dataValidation></x14:dataValidations></ext>`, ExtURIDataValidations, NameSpaceSpreadSheetX14.Value)
+	ws.(*xlsxWorksheet).ExtLst = &xlsxExtLst{Ext: extLst}
+	assert.NoError(t, f.DeleteDataValidation("Sheet1", "A7:A8"))
+	assert.NoError(t, f.DeleteDataValidation("Sheet1", "D1"))
+	dataValidations, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []*DataValidation{{AllowBlank: true, Type: "list", Formula1: "Sheet2!$B$1:$B$5", Sqref: "B7:B8"}}, dataValidations)
+	assert.NoError(t, f.DeleteDataValidation("Sheet1", "B7:B8"))
+	assert.Nil(t, ws.(*xlsxWorksheet).ExtLst)
+	// Test delete all data validations in the extension lists
+	ws.(*xlsxWorksheet).ExtLst = &xlsxExtLst{Ext: extLst}
+	assert.NoError(t, f.DeleteDataValidation("Sheet1"))
+	assert.Nil(t, ws.(*xlsxWorksheet).ExtLst)
+	// Test delete data validations with invalid reference in the extension lists
+	ws.(*xlsxWorksheet).ExtLst = &xlsxExtLst{Ext: strings.ReplaceAll(extLst, "D1", "D")}
+	assert.Equal(t, newCellNameToCoordinatesError("D", newInvalidCellNameError("D")), f.DeleteDataValidation("Sheet1", "A7"))
+	// Test delete data validations with invalid extension list characters
+	ws.(*xlsxWorksheet).ExtLst = &xlsxExtLst{Ext: "</ext>"}
+	assert.EqualError(t, f.DeleteDataValidation("Sheet1", "A7"), "XML syntax error on line 1: element <extLst> closed by </ext>")
+	ws.(*xlsxWorksheet).ExtLst = nil
+	// Test set data validation with list source from another worksheet
+	dv = NewDataValidation(true)
+	dv.Sqref = "A9"
+	dv.SetSqrefDropList("'R&D'!$A$1:$A$3")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	dataValidations, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "'R&D'!$A$1:$A$3", dataValidations[0].Formula1)
+	assert.Equal(t, "'R&amp;D'!$A$1:$A$3", ws.(*xlsxWorksheet).DataValidations.DataValidation[0].Formula1.Content)
 }
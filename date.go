@@ -163,12 +163,100 @@ func timeFromExcelTime(excelTime float64, date1904 bool) time.Time {
 	return date.Truncate(time.Second)
 }
 
-// ExcelDateToTime converts a float-based Excel date representation to a time.Time.
-func ExcelDateToTime(excelDate float64, use1904Format bool) (time.Time, error) {
+// DateOptions defines the options for converting between the time.Time and
+// the float-based Excel date representation.
+//
+// Location specifies the time zone of the date. When converting a time.Time
+// to the Excel date, the time will be converted to this time zone before
+// taking its wall clock time. When converting an Excel date to the
+// time.Time, the returned time will have the wall clock time of the Excel
+// date in this time zone. The time zone of the time.Time will be used when
+// converting it to the Excel date, and UTC will be used when converting the
+// Excel date to the time.Time by default.
+//
+// IgnoreLeapYearBug specifies if the dates before March 1, 1900 should be
+// converted by the actual calendar. The spreadsheet applications treat the
+// year 1900 as a leap year to be compatible with Lotus 1-2-3, so the serial
+// number 1 represents January 1, 1900, the serial number 60 represents the
+// nonexistent date February 29, 1900, which will be converted to February
+// 28, 1900, and the serial numbers before 1 represent the time of day on
+// December 30, 1899. If this option is true, the serial numbers will be
+// counted from December 30, 1899 for all dates. This option will be ignored
+// for the 1904 date system.
+type DateOptions struct {
+	Location          *time.Location
+	IgnoreLeapYearBug bool
+}
+
+// getDateOptions provides a function to parse the optional settings for
+// converting the date.
+func getDateOptions(opts ...DateOptions) DateOptions {
+	var options DateOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	return options
+}
+
+// ExcelDateToTime converts a float-based Excel date representation to a
+// time.Time. The optional settings could be specified by the DateOptions. For
+// example, convert the serial number 45292.5 in the 1900 date system to the
+// time in the Asia/Shanghai time zone:
+//
+//	loc, err := time.LoadLocation("Asia/Shanghai")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	t, err := excelize.ExcelDateToTime(45292.5, false, excelize.DateOptions{Location: loc})
+func ExcelDateToTime(excelDate float64, use1904Format bool, opts ...DateOptions) (time.Time, error) {
 	if excelDate < 0 {
 		return time.Time{}, newInvalidExcelDateError(excelDate)
 	}
-	return timeFromExcelTime(excelDate, use1904Format), nil
+	options := getDateOptions(opts...)
+	t := timeFromExcelTime(excelDate, use1904Format)
+	if !use1904Format && !options.IgnoreLeapYearBug && excelDate >= 1 && excelDate < 60 {
+		t = t.AddDate(0, 0, 1)
+	}
+	if options.Location != nil {
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), options.Location)
+	}
+	return t, nil
+}
+
+// TimeToExcelDate converts a time.Time to a float-based Excel date
+// representation, the wall clock time of the time.Time will be converted. The
+// optional settings could be specified by the DateOptions. For example,
+// convert the current time in the America/New_York time zone to the serial
+// number in the 1900 date system:
+//
+//	loc, err := time.LoadLocation("America/New_York")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	excelDate, err := excelize.TimeToExcelDate(time.Now(), false, excelize.DateOptions{Location: loc})
+func TimeToExcelDate(t time.Time, use1904Format bool, opts ...DateOptions) (float64, error) {
+	options := getDateOptions(opts...)
+	if options.Location != nil {
+		t = t.In(options.Location)
+	}
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	epoch := excel1900Epoc
+	if use1904Format {
+		epoch = excel1904Epoc
+	}
+	if t.Before(epoch) {
+		return 0, newInvalidExcelTimeError(t, epoch)
+	}
+	if t.Before(excelMinTime1900) {
+		return float64(t.Sub(epoch)) / nanosInADay, nil
+	}
+	excelTime, err := timeToExcelTime(t, use1904Format)
+	if options.IgnoreLeapYearBug && !use1904Format && !t.After(excelBuggyPeriodStart) {
+		excelTime++
+	}
+	return excelTime, err
 }
 
 // isLeapYear determine if leap year for a given year.
@@ -114,3 +114,80 @@ func TestExcelDateToTime(t *testing.T) {
 	_, err := ExcelDateToTime(-1, false)
 	assert.EqualError(t, err, newInvalidExcelDateError(-1).Error())
 }
+
+func TestExcelDateToTimeWithOptions(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*60*60)
+	timeValue, err := ExcelDateToTime(45292.5, false, DateOptions{Location: loc})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, time.January, 1, 12, 0, 0, 0, loc), timeValue)
+	// Test convert the dates before March 1, 1900 with or without the leap
+	// year bug compatibility
+	for excelDate, expected := range map[float64][]time.Time{
+		0.5:  {time.Date(1899, time.December, 30, 12, 0, 0, 0, time.UTC), time.Date(1899, time.December, 30, 12, 0, 0, 0, time.UTC)},
+		1:    {time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(1899, time.December, 31, 0, 0, 0, 0, time.UTC)},
+		59:   {time.Date(1900, time.February, 28, 0, 0, 0, 0, time.UTC), time.Date(1900, time.February, 27, 0, 0, 0, 0, time.UTC)},
+		60:   {time.Date(1900, time.February, 28, 0, 0, 0, 0, time.UTC), time.Date(1900, time.February, 28, 0, 0, 0, 0, time.UTC)},
+		61:   {time.Date(1900, time.March, 1, 0, 0, 0, 0, time.UTC), time.Date(1900, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		61.5: {time.Date(1900, time.March, 1, 12, 0, 0, 0, time.UTC), time.Date(1900, time.March, 1, 12, 0, 0, 0, time.UTC)},
+	} {
+		timeValue, err = ExcelDateToTime(excelDate, false)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[0], timeValue, excelDate)
+		timeValue, err = ExcelDateToTime(excelDate, false, DateOptions{IgnoreLeapYearBug: true})
+		assert.NoError(t, err)
+		assert.Equal(t, expected[1], timeValue, excelDate)
+	}
+}
+
+func TestTimeToExcelDate(t *testing.T) {
+	for i, test := range trueExpectedDateList {
+		if test.GoValue.Before(excelMinTime1900) {
+			continue
+		}
+		t.Run(fmt.Sprintf("TestData%d", i+1), func(t *testing.T) {
+			excelDate, err := TimeToExcelDate(test.GoValue, false)
+			assert.NoError(t, err)
+			assert.Equal(t, test.ExcelValue, excelDate)
+		})
+	}
+	// Test convert the time with time zone
+	loc := time.FixedZone("UTC-5", -5*60*60)
+	excelDate, err := TimeToExcelDate(time.Date(2024, time.January, 1, 17, 0, 0, 0, time.UTC), false, DateOptions{Location: loc})
+	assert.NoError(t, err)
+	assert.Equal(t, 45292.5, excelDate)
+	excelDate, err = TimeToExcelDate(time.Date(2024, time.January, 1, 12, 0, 0, 0, loc), false)
+	assert.NoError(t, err)
+	assert.Equal(t, 45292.5, excelDate)
+	// Test convert the time in the 1904 date system
+	excelDate, err = TimeToExcelDate(time.Date(1904, time.January, 2, 0, 0, 0, 0, time.UTC), true)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, excelDate)
+	// Test convert the dates before March 1, 1900 with or without the leap
+	// year bug compatibility
+	for expected, value := range map[[2]float64]time.Time{
+		{0.5, 1.5}:     time.Date(1899, time.December, 31, 12, 0, 0, 0, time.UTC),
+		{1, 2}:         time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC),
+		{59, 60}:       time.Date(1900, time.February, 28, 0, 0, 0, 0, time.UTC),
+		{61, 61}:       time.Date(1900, time.March, 1, 0, 0, 0, 0, time.UTC),
+		{45292, 45292}: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		excelDate, err = TimeToExcelDate(value, false)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[0], excelDate, value)
+		excelDate, err = TimeToExcelDate(value, false, DateOptions{IgnoreLeapYearBug: true})
+		assert.NoError(t, err)
+		assert.Equal(t, expected[1], excelDate, value)
+	}
+	// Test convert the time of day on December 30, 1899
+	for _, opts := range []DateOptions{{}, {IgnoreLeapYearBug: true}} {
+		excelDate, err = TimeToExcelDate(time.Date(1899, time.December, 30, 6, 0, 0, 0, time.UTC), false, opts)
+		assert.NoError(t, err)
+		assert.Equal(t, 0.25, excelDate)
+	}
+	// Test convert the time before the epoch
+	value := time.Date(1899, time.December, 29, 0, 0, 0, 0, time.UTC)
+	_, err = TimeToExcelDate(value, false)
+	assert.Equal(t, newInvalidExcelTimeError(value, excel1900Epoc), err)
+	_, err = TimeToExcelDate(value, true)
+	assert.EqualError(t, err, "invalid time 1899-12-29T00:00:00Z, the time before 1904-01-01 is not supported")
+}
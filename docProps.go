@@ -15,7 +15,11 @@ import (
 	"bytes"
 	"encoding/xml"
 	"io"
+	"math"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // SetAppProps provides a function to set document application properties. The
@@ -94,7 +98,7 @@ func (f *File) SetAppProps(appProperties *AppProperties) error {
 	app.Vt = NameSpaceDocumentPropertiesVariantTypes.Value
 	output, err = xml.Marshal(app)
 	f.saveFileList(defaultXMLPathDocPropsApp, output)
-	return err
+	return f.setDocPropsPart(defaultXMLPathDocPropsApp, SourceRelationshipExtendProperties, ContentTypeExtendedProperties)
 }
 
 // GetAppProps provides a function to get document application properties.
@@ -195,7 +199,32 @@ func (f *File) SetDocProps(docProperties *DocProperties) error {
 		Decode(core); err != nil && err != io.EOF {
 		return err
 	}
-	newProps = &xlsxCoreProperties{
+	newProps = newCoreProperties(core)
+	fields = []string{
+		"Category", "ContentStatus", "Creator", "Description", "Identifier", "Keywords",
+		"LastModifiedBy", "Revision", "Subject", "Title", "Language", "Version",
+	}
+	immutable, mutable = reflect.ValueOf(*docProperties), reflect.ValueOf(newProps).Elem()
+	for _, field = range fields {
+		if val = immutable.FieldByName(field).String(); val != "" {
+			mutable.FieldByName(field).SetString(val)
+		}
+	}
+	if docProperties.Created != "" {
+		newProps.Created = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: docProperties.Created}
+	}
+	if docProperties.Modified != "" {
+		newProps.Modified = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: docProperties.Modified}
+	}
+	output, err = xml.Marshal(newProps)
+	f.saveFileList(defaultXMLPathDocPropsCore, output)
+	return f.setDocPropsPart(defaultXMLPathDocPropsCore, SourceRelationshipCoreProperties, ContentTypeCoreProperties)
+}
+
+// newCoreProperties provides a function to create the core properties for
+// serialization by given deserialized core properties.
+func newCoreProperties(core *decodeCoreProperties) *xlsxCoreProperties {
+	props := &xlsxCoreProperties{
 		Dc:             NameSpaceDublinCore,
 		Dcterms:        NameSpaceDublinCoreTerms,
 		Dcmitype:       NameSpaceDublinCoreMetadataInitiative,
@@ -214,30 +243,80 @@ func (f *File) SetDocProps(docProperties *DocProperties) error {
 		Version:        core.Version,
 	}
 	if core.Created != nil {
-		newProps.Created = &xlsxDcTerms{Type: core.Created.Type, Text: core.Created.Text}
+		props.Created = &xlsxDcTerms{Type: core.Created.Type, Text: core.Created.Text}
 	}
 	if core.Modified != nil {
-		newProps.Modified = &xlsxDcTerms{Type: core.Modified.Type, Text: core.Modified.Text}
+		props.Modified = &xlsxDcTerms{Type: core.Modified.Type, Text: core.Modified.Text}
 	}
-	fields = []string{
-		"Category", "ContentStatus", "Creator", "Description", "Identifier", "Keywords",
-		"LastModifiedBy", "Revision", "Subject", "Title", "Language", "Version",
+	return props
+}
+
+// removeDocPropsPersonalInfo provides a function to remove the author and
+// last modified by of the core properties, and the manager and company of
+// the application properties.
+func (f *File) removeDocPropsPersonalInfo() error {
+	if content := f.readXML(defaultXMLPathDocPropsCore); len(content) > 0 {
+		core := new(decodeCoreProperties)
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content))).
+			Decode(core); err != nil && err != io.EOF {
+			return err
+		}
+		props := newCoreProperties(core)
+		props.Creator, props.LastModifiedBy = "", ""
+		output, err := xml.Marshal(props)
+		if err != nil {
+			return err
+		}
+		f.saveFileList(defaultXMLPathDocPropsCore, output)
 	}
-	immutable, mutable = reflect.ValueOf(*docProperties), reflect.ValueOf(newProps).Elem()
-	for _, field = range fields {
-		if val = immutable.FieldByName(field).String(); val != "" {
-			mutable.FieldByName(field).SetString(val)
+	if content := f.readXML(defaultXMLPathDocPropsApp); len(content) > 0 {
+		app := new(xlsxProperties)
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content))).
+			Decode(app); err != nil && err != io.EOF {
+			return err
+		}
+		app.Manager, app.Company = "", ""
+		app.Vt = NameSpaceDocumentPropertiesVariantTypes.Value
+		output, err := xml.Marshal(app)
+		if err != nil {
+			return err
 		}
+		f.saveFileList(defaultXMLPathDocPropsApp, output)
 	}
-	if docProperties.Created != "" {
-		newProps.Created = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: docProperties.Created}
+	return nil
+}
+
+// setDocPropsPart provides a function to add the package relationship and
+// the content type of the document properties part if they don't exist, such
+// as the workbook generated by other applications without document properties.
+func (f *File) setDocPropsPart(partName, relType, contentType string) error {
+	rels, err := f.relsReader(defaultXMLPathRels)
+	if err != nil {
+		return err
 	}
-	if docProperties.Modified != "" {
-		newProps.Modified = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: docProperties.Modified}
+	var exist bool
+	if rels != nil {
+		rels.mu.Lock()
+		for _, rel := range rels.Relationships {
+			exist = exist || rel.Type == relType
+		}
+		rels.mu.Unlock()
 	}
-	output, err = xml.Marshal(newProps)
-	f.saveFileList(defaultXMLPathDocPropsCore, output)
-
+	if !exist {
+		f.addRels(defaultXMLPathRels, relType, partName, "")
+	}
+	content, err := f.contentTypesReader()
+	if err != nil {
+		return err
+	}
+	content.mu.Lock()
+	defer content.mu.Unlock()
+	for _, override := range content.Overrides {
+		if override.PartName == "/"+partName {
+			return err
+		}
+	}
+	content.Overrides = append(content.Overrides, xlsxOverride{PartName: "/" + partName, ContentType: contentType})
 	return err
 }
 
@@ -271,3 +350,137 @@ func (f *File) GetDocProps() (ret *DocProperties, err error) {
 	}
 	return
 }
+
+// SetCustomProperty provides a function to set the custom document property
+// by given property name and value. The custom property will be created if it
+// doesn't exist, otherwise the value of the property will be overwritten. The
+// data type of the value should be one of the following: int32, int, int64,
+// float32, float64, bool, string, time.Time, and the property will be deleted
+// if the value is nil. For example, set the workbook ID and approval state:
+//
+//	err := f.SetCustomProperty(excelize.CustomProperty{
+//	    Name:  "Workbook ID",
+//	    Value: "WB-0001",
+//	})
+//	err = f.SetCustomProperty(excelize.CustomProperty{
+//	    Name:  "Approved",
+//	    Value: true,
+//	})
+func (f *File) SetCustomProperty(prop CustomProperty) error {
+	if prop.Name == "" {
+		return ErrParameterRequired
+	}
+	custom := new(decodeCustomProperties)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsCustom)))).
+		Decode(custom); err != nil && err != io.EOF {
+		return err
+	}
+	var value string
+	if prop.Value != nil {
+		var err error
+		if value, err = marshalCustomPropertyValue(prop.Value); err != nil {
+			return err
+		}
+	}
+	props := xlsxCustomProperties{Vt: NameSpaceDocumentPropertiesVariantTypes.Value}
+	pid, exist := 1, false
+	for _, p := range custom.Property {
+		if p.PID > pid {
+			pid = p.PID
+		}
+		if strings.EqualFold(p.Name, prop.Name) {
+			if exist = true; prop.Value == nil {
+				continue
+			}
+			p.Content, p.LinkTarget = value, ""
+		}
+		props.Property = append(props.Property, xlsxCustomProperty{
+			FmtID: p.FmtID, PID: p.PID, Name: p.Name, LinkTarget: p.LinkTarget, Value: p.Content,
+		})
+	}
+	if !exist && prop.Value != nil {
+		props.Property = append(props.Property, xlsxCustomProperty{
+			FmtID: "{D5CDD505-2E9C-101B-9397-08002B2CF9AE}", PID: pid + 1, Name: prop.Name, Value: value,
+		})
+	}
+	output, err := xml.Marshal(props)
+	if err != nil {
+		return err
+	}
+	f.saveFileList(defaultXMLPathDocPropsCustom, output)
+	return f.setDocPropsPart(defaultXMLPathDocPropsCustom, SourceRelationshipCustomProperties, ContentTypeCustomProperties)
+}
+
+// marshalCustomPropertyValue returns the document property variant type
+// element by given custom property value.
+func marshalCustomPropertyValue(value interface{}) (string, error) {
+	var typ, text string
+	switch v := value.(type) {
+	case int32:
+		typ, text = "i4", strconv.FormatInt(int64(v), 10)
+	case int:
+		typ, text = "i4", strconv.Itoa(v)
+		if v > math.MaxInt32 || v < math.MinInt32 {
+			typ = "i8"
+		}
+	case int64:
+		typ, text = "i4", strconv.FormatInt(v, 10)
+		if v > math.MaxInt32 || v < math.MinInt32 {
+			typ = "i8"
+		}
+	case float32:
+		typ, text = "r8", strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		typ, text = "r8", strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		typ, text = "bool", strconv.FormatBool(v)
+	case string:
+		typ, text = "lpwstr", v
+	case time.Time:
+		typ, text = "filetime", v.UTC().Format(time.RFC3339)
+	default:
+		return "", ErrParameterInvalid
+	}
+	output, err := xml.Marshal(decodeCustomPropertyValue{XMLName: xml.Name{Local: "vt:" + typ}, Text: text})
+	return string(output), err
+}
+
+// GetCustomProperties provides a function to get all custom document
+// properties of the workbook. The data type of the property value will be
+// one of the following: int32, int64, float64, bool, string and time.Time,
+// the value of other variant types will be returned as string.
+func (f *File) GetCustomProperties() ([]CustomProperty, error) {
+	var props []CustomProperty
+	custom := new(decodeCustomProperties)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsCustom)))).
+		Decode(custom); err != nil && err != io.EOF {
+		return props, err
+	}
+	for _, p := range custom.Property {
+		value, err := unmarshalCustomPropertyValue(p.Value)
+		if err != nil {
+			return props, err
+		}
+		props = append(props, CustomProperty{Name: p.Name, Value: value})
+	}
+	return props, nil
+}
+
+// unmarshalCustomPropertyValue returns the custom property value by given
+// document property variant type element.
+func unmarshalCustomPropertyValue(value decodeCustomPropertyValue) (interface{}, error) {
+	switch value.XMLName.Local {
+	case "i1", "i2", "i4", "int":
+		v, err := strconv.ParseInt(value.Text, 10, 32)
+		return int32(v), err
+	case "i8":
+		return strconv.ParseInt(value.Text, 10, 64)
+	case "r4", "r8", "decimal":
+		return strconv.ParseFloat(value.Text, 64)
+	case "bool":
+		return strconv.ParseBool(value.Text)
+	case "date", "filetime":
+		return time.Parse(time.RFC3339, value.Text)
+	}
+	return value.Text, nil
+}
@@ -12,8 +12,10 @@
 package excelize
 
 import (
+	"math"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -95,6 +97,50 @@ func TestSetDocProps(t *testing.T) {
 	f = NewFile()
 	f.Pkg.Store(defaultXMLPathDocPropsCore, MacintoshCyrillicCharset)
 	assert.EqualError(t, f.SetDocProps(&DocProperties{}), "XML syntax error on line 1: invalid UTF-8")
+
+	// Test the creation time of the new workbook
+	f = NewFile()
+	props, err := f.GetDocProps()
+	assert.NoError(t, err)
+	created, err := time.Parse(time.RFC3339, props.Created)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), created, time.Minute)
+	assert.Equal(t, props.Created, props.Modified)
+
+	// Test set document properties on the workbook without document properties
+	f.Pkg.Delete(defaultXMLPathDocPropsCore)
+	f.Pkg.Delete(defaultXMLPathDocPropsApp)
+	f.Relationships.Store(defaultXMLPathRels, &xlsxRelationships{Relationships: []xlsxRelationship{
+		{ID: "rId1", Type: SourceRelationshipOfficeDocument, Target: "xl/workbook.xml"},
+	}})
+	f.ContentTypes.Overrides = nil
+	assert.NoError(t, f.SetDocProps(&DocProperties{Title: "Title", Creator: "Creator"}))
+	assert.NoError(t, f.SetDocProps(&DocProperties{Subject: "Subject"}))
+	assert.NoError(t, f.SetAppProps(&AppProperties{Application: "Go Excelize"}))
+	rels, err := f.relsReader(defaultXMLPathRels)
+	assert.NoError(t, err)
+	assert.Equal(t, []xlsxRelationship{
+		{ID: "rId1", Type: SourceRelationshipOfficeDocument, Target: "xl/workbook.xml"},
+		{ID: "rId2", Type: SourceRelationshipCoreProperties, Target: defaultXMLPathDocPropsCore},
+		{ID: "rId3", Type: SourceRelationshipExtendProperties, Target: defaultXMLPathDocPropsApp},
+	}, rels.Relationships)
+	assert.Equal(t, []xlsxOverride{
+		{PartName: "/" + defaultXMLPathDocPropsCore, ContentType: ContentTypeCoreProperties},
+		{PartName: "/" + defaultXMLPathDocPropsApp, ContentType: ContentTypeExtendedProperties},
+	}, f.ContentTypes.Overrides)
+	props, err = f.GetDocProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "Title", props.Title)
+	assert.Equal(t, "Subject", props.Subject)
+	// Test set document properties with unsupported charset relationships
+	f.Relationships.Delete(defaultXMLPathRels)
+	f.Pkg.Store(defaultXMLPathRels, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetDocProps(&DocProperties{}), "XML syntax error on line 1: invalid UTF-8")
+	// Test set document properties with unsupported charset content types
+	f = NewFile()
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetDocProps(&DocProperties{}), "XML syntax error on line 1: invalid UTF-8")
 }
 
 func TestGetDocProps(t *testing.T) {
@@ -116,3 +162,68 @@ func TestGetDocProps(t *testing.T) {
 	_, err = f.GetDocProps()
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestCustomProperties(t *testing.T) {
+	f := NewFile()
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, prop := range []CustomProperty{
+		{Name: "Workbook ID", Value: "WB-0001"},
+		{Name: "Revision", Value: int32(1)},
+		{Name: "Big", Value: int64(math.MaxInt32 + 1)},
+		{Name: "Amount", Value: 12.5},
+		{Name: "Approved", Value: false},
+		{Name: "Approved At", Value: created},
+		{Name: "Temporary", Value: 1},
+	} {
+		assert.NoError(t, f.SetCustomProperty(prop))
+	}
+	// Test overwrite and delete custom properties
+	assert.NoError(t, f.SetCustomProperty(CustomProperty{Name: "approved", Value: true}))
+	assert.NoError(t, f.SetCustomProperty(CustomProperty{Name: "Temporary"}))
+	assert.NoError(t, f.SetCustomProperty(CustomProperty{Name: "Not Exists"}))
+	expected := []CustomProperty{
+		{Name: "Workbook ID", Value: "WB-0001"},
+		{Name: "Revision", Value: int32(1)},
+		{Name: "Big", Value: int64(math.MaxInt32 + 1)},
+		{Name: "Amount", Value: 12.5},
+		{Name: "Approved", Value: true},
+		{Name: "Approved At", Value: created},
+	}
+	props, err := f.GetCustomProperties()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, props)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCustomProperties.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestCustomProperties.xlsx"))
+	assert.NoError(t, err)
+	props, err = f.GetCustomProperties()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, props)
+	// Test the property identifier of the new custom property
+	assert.NoError(t, f.SetCustomProperty(CustomProperty{Name: "Reviewer", Value: float32(1.5)}))
+	custom, ok := f.Pkg.Load(defaultXMLPathDocPropsCustom)
+	assert.True(t, ok)
+	assert.Contains(t, string(custom.([]byte)), `pid="8" name="Reviewer"><vt:r8>1.5</vt:r8>`)
+	// Test set custom property with empty name
+	assert.Equal(t, ErrParameterRequired, f.SetCustomProperty(CustomProperty{Value: "value"}))
+	// Test set custom property with unsupported value data type
+	assert.Equal(t, ErrParameterInvalid, f.SetCustomProperty(CustomProperty{Name: "Name", Value: []string{}}))
+	assert.NoError(t, f.Close())
+
+	// Test get custom properties with other variant types
+	f = NewFile()
+	f.Pkg.Store(defaultXMLPathDocPropsCustom, []byte(`<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/custom-properties" xmlns:vt="http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes"><property fmtid="{D5CDD505-2E9C-101B-9397-08002B2CF9AE}" pid="2" name="Code"><vt:lpstr>A1</vt:lpstr></property><property fmtid="{D5CDD505-2E9C-101B-9397-08002B2CF9AE}" pid="3" name="Invalid"><vt:i4>A</vt:i4></property></Properties>`))
+	_, err = f.GetCustomProperties()
+	assert.EqualError(t, err, `strconv.ParseInt: parsing "A": invalid syntax`)
+	assert.NoError(t, f.SetCustomProperty(CustomProperty{Name: "Invalid"}))
+	props, err = f.GetCustomProperties()
+	assert.NoError(t, err)
+	assert.Equal(t, []CustomProperty{{Name: "Code", Value: "A1"}}, props)
+	// Test set and get custom properties with unsupported charset
+	f.Pkg.Store(defaultXMLPathDocPropsCustom, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCustomProperty(CustomProperty{Name: "Name", Value: "value"}), "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.GetCustomProperties()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
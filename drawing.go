@@ -727,30 +727,7 @@ func (f *File) drawChartOverlap(opts *Chart) *attrValInt {
 // drawChartShape provides a function to draw the c:shape element by given
 // format sets.
 func (f *File) drawChartShape(opts *Chart) *attrValString {
-	shapes := map[ChartType]string{
-		Bar3DConeClustered:          "cone",
-		Bar3DConeStacked:            "cone",
-		Bar3DConePercentStacked:     "cone",
-		Bar3DPyramidClustered:       "pyramid",
-		Bar3DPyramidStacked:         "pyramid",
-		Bar3DPyramidPercentStacked:  "pyramid",
-		Bar3DCylinderClustered:      "cylinder",
-		Bar3DCylinderStacked:        "cylinder",
-		Bar3DCylinderPercentStacked: "cylinder",
-		Col3DCone:                   "cone",
-		Col3DConeClustered:          "cone",
-		Col3DConeStacked:            "cone",
-		Col3DConePercentStacked:     "cone",
-		Col3DPyramid:                "pyramid",
-		Col3DPyramidClustered:       "pyramid",
-		Col3DPyramidStacked:         "pyramid",
-		Col3DPyramidPercentStacked:  "pyramid",
-		Col3DCylinder:               "cylinder",
-		Col3DCylinderClustered:      "cylinder",
-		Col3DCylinderStacked:        "cylinder",
-		Col3DCylinderPercentStacked: "cylinder",
-	}
-	if shape, ok := shapes[opts.Type]; ok {
+	if shape, ok := plotAreaChartShape[opts.Type]; ok {
 		return &attrValString{Val: stringPtr(shape)}
 	}
 	return nil
@@ -774,6 +751,8 @@ func (f *File) drawChartSeries(opts *Chart) *[]cSer {
 			DPt:              f.drawChartSeriesDPt(k, opts),
 			DLbls:            f.drawChartSeriesDLbls(k, opts),
 			InvertIfNegative: &attrValBool{Val: boolPtr(false)},
+			Trendline:        f.drawChartSeriesTrendline(opts.Series[k], opts),
+			ErrBars:          f.drawChartSeriesErrBars(opts.Series[k], opts),
 			Cat:              f.drawChartSeriesCat(opts.Series[k], opts),
 			Smooth:           &attrValBool{Val: boolPtr(opts.Series[k].Line.Smooth)},
 			Val:              f.drawChartSeriesVal(opts.Series[k], opts),
@@ -857,12 +836,26 @@ func (f *File) drawChartSeriesDPt(i int, opts *Chart) []*cDPt {
 	return chartSeriesDPt[opts.Type]
 }
 
+// chartSeriesRef provides a function to prepare the formula of the chart
+// series data source reference. The non-contiguous cell ranges separated by
+// commas, such as "Sheet1!$A$2:$A$3,Sheet1!$A$5:$A$6", will be wrapped in
+// parentheses as the union reference.
+func chartSeriesRef(ref string) string {
+	if strings.HasPrefix(ref, "(") && strings.HasSuffix(ref, ")") {
+		return ref
+	}
+	if len(splitRefs(ref)) > 1 {
+		return "(" + ref + ")"
+	}
+	return ref
+}
+
 // drawChartSeriesCat provides a function to draw the c:cat element by given
 // chart series and format sets.
 func (f *File) drawChartSeriesCat(v ChartSeries, opts *Chart) *cCat {
 	cat := &cCat{
 		StrRef: &cStrRef{
-			F: v.Categories,
+			F: chartSeriesRef(v.Categories),
 		},
 	}
 	chartSeriesCat := map[ChartType]*cCat{Scatter: nil, Bubble: nil, Bubble3D: nil}
@@ -877,7 +870,7 @@ func (f *File) drawChartSeriesCat(v ChartSeries, opts *Chart) *cCat {
 func (f *File) drawChartSeriesVal(v ChartSeries, opts *Chart) *cVal {
 	val := &cVal{
 		NumRef: &cNumRef{
-			F: v.Values,
+			F: chartSeriesRef(v.Values),
 		},
 	}
 	chartSeriesVal := map[ChartType]*cVal{Scatter: nil, Bubble: nil, Bubble3D: nil}
@@ -928,7 +921,7 @@ func (f *File) drawChartSeriesMarker(i int, opts *Chart) *cMarker {
 func (f *File) drawChartSeriesXVal(v ChartSeries, opts *Chart) *cCat {
 	cat := &cCat{
 		StrRef: &cStrRef{
-			F: v.Categories,
+			F: chartSeriesRef(v.Categories),
 		},
 	}
 	chartSeriesXVal := map[ChartType]*cCat{Scatter: cat, Bubble: cat, Bubble3D: cat}
@@ -940,7 +933,7 @@ func (f *File) drawChartSeriesXVal(v ChartSeries, opts *Chart) *cCat {
 func (f *File) drawChartSeriesYVal(v ChartSeries, opts *Chart) *cVal {
 	val := &cVal{
 		NumRef: &cNumRef{
-			F: v.Values,
+			F: chartSeriesRef(v.Values),
 		},
 	}
 	chartSeriesYVal := map[ChartType]*cVal{Scatter: val, Bubble: val, Bubble3D: val}
@@ -959,7 +952,7 @@ func (f *File) drawCharSeriesBubbleSize(v ChartSeries, opts *Chart) *cVal {
 	}
 	return &cVal{
 		NumRef: &cNumRef{
-			F: fVal,
+			F: chartSeriesRef(fVal),
 		},
 	}
 }
@@ -973,6 +966,76 @@ func (f *File) drawCharSeriesBubble3D(opts *Chart) *attrValBool {
 	return &attrValBool{Val: boolPtr(true)}
 }
 
+// drawChartSeriesTrendline provides a function to draw the c:trendline
+// element by given chart series and format sets.
+func (f *File) drawChartSeriesTrendline(v ChartSeries, opts *Chart) *cTrendline {
+	trendlineType, ok := chartTrendlineTypes[v.Trendline.Type]
+	if !ok || !supportedChartTrendline[opts.Type] {
+		return nil
+	}
+	trendline := &cTrendline{
+		TrendlineType: &attrValString{Val: stringPtr(trendlineType)},
+		DispRSqr:      &attrValBool{Val: boolPtr(v.Trendline.DisplayRSquared)},
+		DispEq:        &attrValBool{Val: boolPtr(v.Trendline.DisplayEquation)},
+	}
+	switch v.Trendline.Type {
+	case ChartTrendlinePolynomial:
+		order := 2
+		if v.Trendline.Order >= 2 && v.Trendline.Order <= 6 {
+			order = v.Trendline.Order
+		}
+		trendline.Order = &attrValInt{Val: intPtr(order)}
+	case ChartTrendlineMovingAverage:
+		period := 2
+		if v.Trendline.Period >= 2 && v.Trendline.Period <= 255 {
+			period = v.Trendline.Period
+		}
+		trendline.Period = &attrValInt{Val: intPtr(period)}
+	}
+	if v.Trendline.Type != ChartTrendlineMovingAverage {
+		if v.Trendline.Forward > 0 {
+			trendline.Forward = &attrValFloat{Val: float64Ptr(v.Trendline.Forward)}
+		}
+		if v.Trendline.Backward > 0 {
+			trendline.Backward = &attrValFloat{Val: float64Ptr(v.Trendline.Backward)}
+		}
+	}
+	if _, ok := map[ChartTrendlineType]bool{
+		ChartTrendlineExponential: true, ChartTrendlineLinear: true, ChartTrendlinePolynomial: true,
+	}[v.Trendline.Type]; ok && v.Trendline.Intercept != nil {
+		trendline.Intercept = &attrValFloat{Val: float64Ptr(*v.Trendline.Intercept)}
+	}
+	return trendline
+}
+
+// drawChartSeriesErrBars provides a function to draw the c:errBars element by
+// given chart series and format sets.
+func (f *File) drawChartSeriesErrBars(v ChartSeries, opts *Chart) *cErrBars {
+	errValType, ok := chartErrorBarsTypes[v.ErrorBars.Type]
+	if !ok || !supportedChartErrorBars[opts.Type] {
+		return nil
+	}
+	errBarType := "both"
+	if inStrSlice([]string{"plus", "minus"}, v.ErrorBars.Direction, true) != -1 {
+		errBarType = v.ErrorBars.Direction
+	}
+	errBars := &cErrBars{
+		ErrBarType: &attrValString{Val: stringPtr(errBarType)},
+		ErrValType: &attrValString{Val: stringPtr(errValType)},
+		NoEndCap:   &attrValBool{Val: boolPtr(v.ErrorBars.NoEndCap)},
+	}
+	if _, ok := map[ChartType]bool{Scatter: true, Bubble: true}[opts.Type]; ok {
+		errBars.ErrDir = &attrValString{Val: stringPtr("y")}
+	}
+	if value, ok := chartErrorBarsDefaultValue[v.ErrorBars.Type]; ok {
+		if v.ErrorBars.Value > 0 {
+			value = v.ErrorBars.Value
+		}
+		errBars.Val = &attrValFloat{Val: float64Ptr(value)}
+	}
+	return errBars
+}
+
 // drawChartNumFmt provides a function to draw the c:numFmt element by given
 // data labels format sets.
 func (f *File) drawChartNumFmt(labels ChartNumFmt) *cNumFmt {
@@ -1033,6 +1096,9 @@ func (f *File) drawChartSeriesDLbls(i int, opts *Chart) *cDLbls {
 
 // drawPlotAreaCatAx provides a function to draw the c:catAx element.
 func (f *File) drawPlotAreaCatAx(pa *cPlotArea, opts *Chart) []*cAxs {
+	if opts.order > 0 && !opts.YAxis.Secondary && pa.CatAx != nil {
+		return pa.CatAx
+	}
 	maxVal := &attrValFloat{Val: opts.XAxis.Maximum}
 	minVal := &attrValFloat{Val: opts.XAxis.Minimum}
 	if opts.XAxis.Maximum == nil {
@@ -1088,6 +1154,9 @@ func (f *File) drawPlotAreaCatAx(pa *cPlotArea, opts *Chart) []*cAxs {
 
 // drawPlotAreaValAx provides a function to draw the c:valAx element.
 func (f *File) drawPlotAreaValAx(pa *cPlotArea, opts *Chart) []*cAxs {
+	if opts.order > 0 && !opts.YAxis.Secondary && pa.ValAx != nil {
+		return pa.ValAx
+	}
 	maxVal := &attrValFloat{Val: opts.YAxis.Maximum}
 	minVal := &attrValFloat{Val: opts.YAxis.Minimum}
 	if opts.YAxis.Maximum == nil {
@@ -1215,7 +1284,7 @@ func (f *File) drawPlotAreaTitles(runs []RichTextRun, vert string) *cTitle {
 		drawChartFont(run.Font, &r.RPr)
 		title.Tx.Rich.P = append(title.Tx.Rich.P, aP{
 			PPr:        &aPPr{DefRPr: aRPr{}},
-			R:          r,
+			R:          []*aR{r},
 			EndParaRPr: &aEndParaRPr{Lang: "en-US", AltLang: "en-US"},
 		})
 	}
@@ -1504,6 +1573,9 @@ func (f *File) deleteDrawing(col, row int, drawingXML, drawingType string) (stri
 		if err = nil; wsDr.TwoCellAnchor[idx].From != nil && xdrCellAnchorFuncs[drawingType](wsDr.TwoCellAnchor[idx]) {
 			if onAnchorCell(wsDr.TwoCellAnchor[idx].From.Col, wsDr.TwoCellAnchor[idx].From.Row) {
 				rID, _ = extractEmbedRID(wsDr.TwoCellAnchor[idx].Pic, nil, rIDs)
+				if drawingType == "Chart" {
+					rID = f.extractChartRID(wsDr.TwoCellAnchor[idx].GraphicFrame)
+				}
 				wsDr.TwoCellAnchor = append(wsDr.TwoCellAnchor[:idx], wsDr.TwoCellAnchor[idx+1:]...)
 				idx--
 				continue
@@ -1520,6 +1592,9 @@ func (f *File) deleteDrawing(col, row int, drawingXML, drawingType string) (stri
 		if err = nil; deTwoCellAnchor.From != nil && decodeCellAnchorFuncs[drawingType](deTwoCellAnchor) {
 			if onAnchorCell(deTwoCellAnchor.From.Col, deTwoCellAnchor.From.Row) {
 				rID, _ = extractEmbedRID(nil, deTwoCellAnchor.Pic, rIDs)
+				if drawingType == "Chart" {
+					rID = f.extractChartRID(wsDr.TwoCellAnchor[idx].GraphicFrame)
+				}
 				wsDr.TwoCellAnchor = append(wsDr.TwoCellAnchor[:idx], wsDr.TwoCellAnchor[idx+1:]...)
 				idx--
 				continue
@@ -1548,6 +1623,21 @@ func extractEmbedRID(pic *xlsxPic, decodePic *decodePic, rIDs []string) (string,
 	return "", rIDs
 }
 
+// extractChartRID returns the chart relationship ID by given graphic frame of
+// the cell anchor.
+func (f *File) extractChartRID(graphicFrame string) string {
+	deAnchor := new(decodeCellAnchor)
+	if err := f.xmlNewDecoder(strings.NewReader("<decodeCellAnchor>" + graphicFrame + "</decodeCellAnchor>")).
+		Decode(deAnchor); err != nil && err != io.EOF {
+		return ""
+	}
+	if deAnchor.GraphicFrame == nil || deAnchor.GraphicFrame.Graphic == nil ||
+		deAnchor.GraphicFrame.Graphic.GraphicData.Chart == nil {
+		return ""
+	}
+	return deAnchor.GraphicFrame.Graphic.GraphicData.Chart.RID
+}
+
 // deleteDrawingRels provides a function to delete relationships in
 // xl/drawings/_rels/drawings%d.xml.rels by giving drawings relationships path
 // and relationship ID.
@@ -14,6 +14,8 @@ package excelize
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 )
 
 var (
@@ -51,6 +53,9 @@ var (
 	// ErrDefinedNameScope defined the error message on not found defined name
 	// in the given scope.
 	ErrDefinedNameScope = errors.New("no defined name on the scope")
+	// ErrExistsNamedStyle defined the error message on given named cell style
+	// already exists.
+	ErrExistsNamedStyle = errors.New("the same name cell style already exists")
 	// ErrExistsSheet defined the error message on given sheet already exists.
 	ErrExistsSheet = errors.New("the same name sheet already exists")
 	// ErrExistsTableName defined the error message on given table already exists.
@@ -65,6 +70,9 @@ var (
 	ErrFormControlValue = fmt.Errorf("scroll value must be between 0 and %d", MaxFormControlValue)
 	// ErrGroupSheets defined the error message on group sheets.
 	ErrGroupSheets = errors.New("group worksheet must contain an active worksheet")
+	// ErrHeaderFooterLength defined the error message on the length of the
+	// header or footer exceeds the limit.
+	ErrHeaderFooterLength = fmt.Errorf("the header or footer must be less than or equal to %d characters", MaxFieldLength)
 	// ErrImgExt defined the error message on receive an unsupported image
 	// extension.
 	ErrImgExt = errors.New("unsupported image extension")
@@ -82,6 +90,12 @@ var (
 	// ErrNameLength defined the error message on receiving the defined name or
 	// table name length exceeds the limit.
 	ErrNameLength = fmt.Errorf("the name length exceeds the %d characters limit", MaxFieldLength)
+	// ErrNumFmts defined the error message on custom number formats exceeds the
+	// limit.
+	ErrNumFmts = fmt.Errorf("the custom number formats exceeds the %d limit", MaxCustomNumFmts)
+	// ErrOptionsCompressionLevel defined the error message for receiving
+	// invalid CompressionLevel.
+	ErrOptionsCompressionLevel = errors.New("the value of CompressionLevel should be between -1 and 9")
 	// ErrOptionsUnzipSizeLimit defined the error message for receiving
 	// invalid UnzipSizeLimit and UnzipXMLSizeLimit.
 	ErrOptionsUnzipSizeLimit = errors.New("the value of UnzipSizeLimit should be greater than or equal to UnzipXMLSizeLimit")
@@ -91,6 +105,9 @@ var (
 	// ErrPageSetupAdjustTo defined the error message for receiving a page setup
 	// adjust to value exceeds limit.
 	ErrPageSetupAdjustTo = errors.New("adjust to value must be between 10 and 400")
+	// ErrPanesSplit defined the error message for receiving an invalid
+	// horizontal or vertical split position of the panes.
+	ErrPanesSplit = errors.New("the split position of the panes must be a non-negative number, and less than the maximum number of columns and rows on frozen panes")
 	// ErrParameterInvalid defined the error message on receive the invalid
 	// parameter.
 	ErrParameterInvalid = errors.New("parameter is invalid")
@@ -103,6 +120,9 @@ var (
 	// ErrPivotTableClassicLayout defined the error message on enable
 	// ClassicLayout and CompactData in the same time.
 	ErrPivotTableClassicLayout = errors.New("cannot enable ClassicLayout and CompactData in the same time")
+	// ErrPivotTableGroupItems defined the error message on the number of group
+	// items of the pivot table field exceeds the limit.
+	ErrPivotTableGroupItems = fmt.Errorf("the number of group items of the pivot table field must be less than or equal to %d", TotalRows)
 	// ErrSave defined the error message for saving file.
 	ErrSave = errors.New("no path defined for file, consider File.WriteTo or File.Write")
 	// ErrSheetIdx defined the error message on receive the invalid worksheet
@@ -120,6 +140,9 @@ var (
 	// ErrSheetNameSingleQuote defined the error message on the first or last
 	// character of the sheet name was a single quote.
 	ErrSheetNameSingleQuote = errors.New("the first or last character of the sheet name can not be a single quote")
+	// ErrShiftMergedCells defined the error message on shifting cells which
+	// will change part of the merged cells.
+	ErrShiftMergedCells = errors.New("cannot shift cells that would change part of a merged cell")
 	// ErrSparkline defined the error message on receive the invalid sparkline
 	// parameters.
 	ErrSparkline = errors.New("must have the same number of 'Location' and 'Range' parameters")
@@ -138,9 +161,21 @@ var (
 	// ErrStreamSetColWidth defined the error message on set column width in
 	// stream writing mode.
 	ErrStreamSetColWidth = errors.New("must call the SetColWidth function before the SetRow function")
+	// ErrStreamSetColStyle defined the error message on set column style in
+	// stream writing mode.
+	ErrStreamSetColStyle = errors.New("must call the SetColStyle function before the SetRow function")
+	// ErrStreamSetColVisible defined the error message on set column visible
+	// in stream writing mode.
+	ErrStreamSetColVisible = errors.New("must call the SetColVisible function before the SetRow function")
+	// ErrStreamSetColOutlineLevel defined the error message on set column
+	// outline level in stream writing mode.
+	ErrStreamSetColOutlineLevel = errors.New("must call the SetColOutlineLevel function before the SetRow function")
 	// ErrStreamSetPanes defined the error message on set panes in stream
 	// writing mode.
 	ErrStreamSetPanes = errors.New("must call the SetPanes function before the SetRow function")
+	// ErrTemplateRange defined the error message on receiving the repeating
+	// region marker without end marker in the template.
+	ErrTemplateRange = errors.New("the range marker in the template must be closed by an end marker")
 	// ErrTotalSheetHyperlinks defined the error message on hyperlinks count
 	// overflow.
 	ErrTotalSheetHyperlinks = errors.New("over maximum limit hyperlinks in a worksheet")
@@ -168,6 +203,9 @@ var (
 	// ErrUnsupportedNumberFormat defined the error message on unsupported number format
 	// expression.
 	ErrUnsupportedNumberFormat = errors.New("unsupported number format token")
+	// ErrUnsupportedSignatureAlgorithm defined the error message on
+	// unsupported digital signature algorithm or key type.
+	ErrUnsupportedSignatureAlgorithm = errors.New("unsupported digital signature algorithm")
 	// ErrWorkbookFileFormat defined the error message on receive an
 	// unsupported workbook file format.
 	ErrWorkbookFileFormat = errors.New("unsupported workbook file format")
@@ -240,6 +278,12 @@ func newInvalidExcelDateError(dateValue float64) error {
 	return fmt.Errorf("invalid date value %f, negative values are not supported", dateValue)
 }
 
+// newInvalidExcelTimeError defined the error message on receiving the time
+// before the epoch of the serial date.
+func newInvalidExcelTimeError(t, epoch time.Time) error {
+	return fmt.Errorf("invalid time %s, the time before %s is not supported", t.Format(time.RFC3339), epoch.Format(time.DateOnly))
+}
+
 // newInvalidLinkTypeError defined the error message on receiving the invalid
 // hyper link type.
 func newInvalidLinkTypeError(linkType string) error {
@@ -252,12 +296,24 @@ func newInvalidNameError(name string) error {
 	return fmt.Errorf("invalid name %q, the name should be starts with a letter or underscore, can not include a space or character, and can not conflict with an existing name in the workbook", name)
 }
 
+// newInvalidOptionalValue defined the error message on receiving the invalid
+// optional value.
+func newInvalidOptionalValue(name, value string, values []string) error {
+	return fmt.Errorf("invalid %s value %q, acceptable value should be one of %s", name, value, strings.Join(values, ", "))
+}
+
 // newInvalidPageLayoutValueError defined the error message on receiving the invalid
 // page layout options value.
 func newInvalidPageLayoutValueError(name, value, msg string) error {
 	return fmt.Errorf("invalid %s value %q, acceptable value should be one of %s", name, value, msg)
 }
 
+// newInvalidPanesValueError defined the error message on receiving the invalid
+// panes options value.
+func newInvalidPanesValueError(name, value string) error {
+	return fmt.Errorf("invalid panes %s value %q, acceptable value should be one of %s", name, value, strings.Join(paneTypes, ", "))
+}
+
 // newInvalidRowNumberError defined the error message on receiving the invalid
 // row number.
 func newInvalidRowNumberError(row int) error {
@@ -270,12 +326,30 @@ func newInvalidSlicerNameError(name string) error {
 	return fmt.Errorf("invalid slicer name %q", name)
 }
 
+// newInvalidStructTagError defined the error message on receiving the invalid
+// "xlsx" struct tag of the field.
+func newInvalidStructTagError(field, tag string) error {
+	return fmt.Errorf("invalid xlsx tag %q on field %s", tag, field)
+}
+
 // newInvalidStyleID defined the error message on receiving the invalid style
 // ID.
 func newInvalidStyleID(styleID int) error {
 	return fmt.Errorf("invalid style ID %d", styleID)
 }
 
+// newInvalidTemplateFieldError defined the error message on receiving the
+// invalid field in the template placeholder.
+func newInvalidTemplateFieldError(name string) error {
+	return fmt.Errorf("invalid template field %q", name)
+}
+
+// newNoExistNamedStyleError defined the error message on receiving the non
+// existing named cell style name.
+func newNoExistNamedStyleError(name string) error {
+	return fmt.Errorf("cell style %s does not exist", name)
+}
+
 // newNoExistSlicerError defined the error message on receiving the non existing
 // slicer name.
 func newNoExistSlicerError(name string) error {
@@ -312,6 +386,12 @@ func newStreamSetRowError(row int) error {
 	return fmt.Errorf("row %d has already been written", row)
 }
 
+// newUnknownFormulaFunctionError defined the error message on receiving a
+// unknown function name in the formula.
+func newUnknownFormulaFunctionError(name string) error {
+	return fmt.Errorf("unknown formula function %s", name)
+}
+
 // newUnknownFilterTokenError defined the error message on receiving a unknown
 // filter operator token.
 func newUnknownFilterTokenError(token string) error {
@@ -324,6 +404,12 @@ func newUnsupportedChartType(chartType ChartType) error {
 	return fmt.Errorf("unsupported chart type %d", chartType)
 }
 
+// newUnsupportedFieldTypeError defined the error message on receiving the
+// unsupported struct field type.
+func newUnsupportedFieldTypeError(typ string) error {
+	return fmt.Errorf("unsupported field type %s", typ)
+}
+
 // newUnzipSizeLimitError defined the error message on unzip size exceeds the
 // limit.
 func newUnzipSizeLimitError(unzipSizeLimit int64) error {
@@ -15,9 +15,11 @@ package excelize
 import (
 	"archive/zip"
 	"bytes"
+	"compress/flate"
 	"encoding/xml"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -26,19 +28,29 @@ import (
 	"golang.org/x/net/html/charset"
 )
 
-// File define a populated spreadsheet file struct.
+// File define a populated spreadsheet file struct. The functions of the File
+// are protected by the internal locks, so that multiple goroutines can set or
+// get cell values, styles, comments, form controls, pictures and so on in the
+// same or different worksheets concurrently. Workbook-level operations, such
+// as adding, copying, moving or deleting worksheets, inserting or removing
+// rows and columns which adjust the references across worksheets, streaming
+// writing, saving and closing the workbook, should not be called concurrently
+// with other functions of the same File.
 type File struct {
 	mu               sync.Mutex
 	checked          sync.Map
 	formulaChecked   bool
+	formulaRefs      formulaRefsCache
 	options          *Options
 	sharedStringItem [][]uint
 	sharedStringsMap map[string]int
 	sharedStringTemp *os.File
 	sheetMap         map[string]string
+	signatures       map[string]*DigitalSignatureOptions
 	streams          map[string]*StreamWriter
 	tempFiles        sync.Map
 	xmlAttr          sync.Map
+	zipFiles         sync.Map
 	CalcChain        *xlsxCalcChain
 	CharsetReader    charsetTranscoderFn
 	Comments         map[string]*xlsxComments
@@ -68,11 +80,26 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 // MaxCalcIterations specifies the maximum iterations for iterative
 // calculation, the default value is 0.
 //
-// Password specifies the password of the spreadsheet in plain text.
+// Password specifies the password of the spreadsheet in plain text. When
+// opening a spreadsheet, it is used to decrypt the workbook encrypted by
+// ECMA-376 agile or standard encryption. When saving a spreadsheet, the
+// workbook will be encrypted by ECMA-376 agile encryption with the AES-256
+// cipher algorithm and the SHA512 hash algorithm.
 //
 // RawCellValue specifies if apply the number format for the cell value or get
 // the raw value.
 //
+// FullRange specifies if GetRows and GetCols return rectangular results which
+// cover the read cell values, the trailing empty cells will be padded with
+// empty strings to the length of the longest row or column, and the empty rows
+// or columns will be returned as slices of empty strings instead of being
+// skipped, the default value is false.
+//
+// InlineStrings specifies if set the string type cell values as inline
+// strings in the worksheet instead of adding them into the shared string
+// table, which reduces memory usage and speeds up writing the workbook that
+// will be written only once, the default value is false.
+//
 // UnzipSizeLimit specifies to unzip size limit in bytes on open the
 // spreadsheet, this value should be greater than or equal to
 // UnzipXMLSizeLimit, the default size limit is 16GB.
@@ -96,22 +123,52 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 // LongTimePattern specifies the long time number format code.
 //
 // CultureInfo specifies the country code for applying built-in language number
-// format code these effect by the system's local language settings.
+// format code these effect by the system's local language settings, the
+// built-in currency number formats with ID from 5 to 8 will be applied for the
+// country code en-US.
+//
+// UseTempFiles specifies if spool the worksheets and shared string table to
+// the system temporary directory instead of holding them in memory, which
+// enables processing huge workbooks with limited memory. When opening the
+// spreadsheet, these parts will be extracted to temporary files regardless
+// of the UnzipXMLSizeLimit, and the raw content of the worksheet will be
+// released after it was parsed. When saving the spreadsheet, the generated
+// worksheets will be written to temporary files and copied to the package
+// from there. The temporary files will be removed by the Close function.
+//
+// CompressionLevel specifies the deflate compression level of the parts in
+// the package when saving the spreadsheet, the value is the same with the
+// compress/flate package, ranges from 1 (flate.BestSpeed) to 9
+// (flate.BestCompression), and 0 or -1 (flate.DefaultCompression) for the
+// default compression level. The parts which have not been unzipped from the
+// original workbook will be decompressed and recompressed when a non-default
+// compression level was specified.
+//
+// StoreOnly specifies if store the parts in the package without compression
+// when saving the spreadsheet, the CompressionLevel will be ignored when it
+// was set.
 type Options struct {
 	MaxCalcIterations uint
 	Password          string
 	RawCellValue      bool
+	FullRange         bool
+	InlineStrings     bool
 	UnzipSizeLimit    int64
 	UnzipXMLSizeLimit int64
 	ShortDatePattern  string
 	LongDatePattern   string
 	LongTimePattern   string
 	CultureInfo       CultureName
+	UseTempFiles      bool
+	CompressionLevel  int
+	StoreOnly         bool
 }
 
 // OpenFile take the name of a spreadsheet file and returns a populated
-// spreadsheet file struct for it. For example, open spreadsheet with
-// password protection:
+// spreadsheet file struct for it. The worksheets will be unzipped and parsed
+// on demand when they are accessed for the first time, and the worksheets
+// which have not been accessed will be copied to the saved workbook as is.
+// For example, open spreadsheet with password protection:
 //
 //	f, err := excelize.OpenFile("Book1.xlsx", excelize.Options{Password: "password"})
 //
@@ -139,10 +196,11 @@ func newFile() *File {
 		xmlAttr:          sync.Map{},
 		checked:          sync.Map{},
 		sheetMap:         make(map[string]string),
+		signatures:       make(map[string]*DigitalSignatureOptions),
 		tempFiles:        sync.Map{},
+		zipFiles:         sync.Map{},
 		Comments:         make(map[string]*xlsxComments),
 		Drawings:         sync.Map{},
-		sharedStringsMap: make(map[string]int),
 		Sheet:            sync.Map{},
 		DecodeVMLDrawing: make(map[string]*decodeVmlDrawing),
 		VMLDrawing:       make(map[string]*vmlDrawing),
@@ -169,11 +227,17 @@ func (f *File) checkOpenReaderOptions() error {
 	if f.options.UnzipXMLSizeLimit > f.options.UnzipSizeLimit {
 		return ErrOptionsUnzipSizeLimit
 	}
+	if f.options.CompressionLevel < flate.DefaultCompression || f.options.CompressionLevel > flate.BestCompression {
+		return ErrOptionsCompressionLevel
+	}
 	return f.checkDateTimePattern()
 }
 
 // OpenReader read data stream from io.Reader and return a populated
-// spreadsheet file.
+// spreadsheet file. The XLSB (binary workbook) will be converted to the XML
+// parts on opening, includes the worksheets structure, cell values, shared
+// strings and cell number formats, and the formulas will be read as the
+// cached values.
 func OpenReader(r io.Reader, opts ...Options) (*File, error) {
 	b, err := io.ReadAll(r)
 	if err != nil {
@@ -186,10 +250,53 @@ func OpenReader(r io.Reader, opts ...Options) (*File, error) {
 	}
 	if bytes.Contains(b, oleIdentifier) {
 		if b, err = Decrypt(b, f.options); err != nil {
+			if err == ErrWorkbookPassword {
+				return nil, err
+			}
 			return nil, ErrWorkbookFileFormat
 		}
 	}
-	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	return f.openZipReader(bytes.NewReader(b), int64(len(b)))
+}
+
+// OpenReaderAt read data from io.ReaderAt with the given size in bytes and
+// return a populated spreadsheet file. Unlike OpenReader, the workbook will
+// not be buffered into memory as a whole, the parts in the package will be
+// decompressed from the reader directly, and the worksheets which have not
+// been accessed will be read from the reader on saving, so the reader should
+// be kept open until the spreadsheet was saved or closed. The password
+// protected spreadsheet will be read into memory for decryption. For example,
+// open spreadsheet from the file:
+//
+//	file, err := os.Open("Book1.xlsx")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer file.Close()
+//	info, err := file.Stat()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	f, err := excelize.OpenReaderAt(file, info.Size())
+func OpenReaderAt(r io.ReaderAt, size int64, opts ...Options) (*File, error) {
+	header := make([]byte, len(oleIdentifier))
+	if n, _ := r.ReadAt(header, 0); n == len(header) && bytes.Equal(header, oleIdentifier) {
+		return OpenReader(io.NewSectionReader(r, 0, size), opts...)
+	}
+	f := newFile()
+	f.options = f.getOptions(opts...)
+	if err := f.checkOpenReaderOptions(); err != nil {
+		return nil, err
+	}
+	return f.openZipReader(r, size)
+}
+
+// openZipReader provides a function to read the workbook package from the
+// given io.ReaderAt and size, and populate the spreadsheet file.
+func (f *File) openZipReader(r io.ReaderAt, size int64) (*File, error) {
+	zr, err := zip.NewReader(r, size)
 	if err != nil {
 		if len(f.options.Password) > 0 {
 			return nil, ErrWorkbookPassword
@@ -204,6 +311,9 @@ func OpenReader(r io.Reader, opts ...Options) (*File, error) {
 	for k, v := range file {
 		f.Pkg.Store(k, v)
 	}
+	if err = f.convertXLSB(); err != nil {
+		return nil, err
+	}
 	if f.CalcChain, err = f.calcChainReader(); err != nil {
 		return f, err
 	}
@@ -281,15 +391,19 @@ func (f *File) workSheetReader(sheet string) (ws *xlsxWorksheet, err error) {
 		}
 	}
 	ws = new(xlsxWorksheet)
+	var content []byte
+	if content, err = f.readPart(name, true); err != nil {
+		return
+	}
 	if attrs, ok := f.xmlAttr.Load(name); !ok {
-		d := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readBytes(name))))
+		d := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content)))
 		if attrs == nil {
 			attrs = []xml.Attr{}
 		}
 		attrs = append(attrs.([]xml.Attr), getRootElement(d)...)
 		f.xmlAttr.Store(name, attrs)
 	}
-	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readBytes(name)))).
+	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content))).
 		Decode(ws); err != nil && err != io.EOF {
 		return
 	}
@@ -301,6 +415,9 @@ func (f *File) workSheetReader(sheet string) (ws *xlsxWorksheet, err error) {
 		}
 		f.checked.Store(name, true)
 	}
+	if _, ok = f.tempFiles.Load(name); ok && f.options != nil && f.options.UseTempFiles {
+		f.Pkg.Delete(name)
+	}
 	f.Sheet.Store(name, ws)
 	return
 }
@@ -561,7 +678,12 @@ func (f *File) AddVBAProject(file []byte) error {
 		})
 	}
 	f.Pkg.Store("xl/vbaProject.bin", file)
-	return err
+	contentType := ContentTypeMacro
+	if ct, ok := supportedContentTypes[strings.ToLower(filepath.Ext(f.Path))]; ok &&
+		ct != ContentTypeSheetML && ct != ContentTypeTemplate {
+		contentType = ct
+	}
+	return f.setContentTypePartProjectExtensions(contentType)
 }
 
 // setContentTypePartProjectExtensions provides a function to set the content
@@ -593,6 +715,66 @@ func (f *File) setContentTypePartProjectExtensions(contentType string) error {
 	return err
 }
 
+// removeVBAProject provides a function to get the output of the parts without
+// the VBA project parts and relationships of the workbook, which are not
+// allowed in the macro-free workbook. The returned map contains the content
+// of the workbook relationships and content types parts to be written
+// instead, and the VBA project parts with the nil content to be omitted. The
+// workbook in memory will not be changed.
+func (f *File) removeVBAProject() (map[string][]byte, error) {
+	relsPath := f.getWorkbookRelsPath()
+	rels, err := f.relsReader(relsPath)
+	if err != nil || rels == nil {
+		return nil, err
+	}
+	rels.mu.Lock()
+	var (
+		parts         []string
+		relationships []xlsxRelationship
+	)
+	for _, rel := range rels.Relationships {
+		if rel.Type != SourceRelationshipVBAProject {
+			relationships = append(relationships, rel)
+			continue
+		}
+		part := path.Join(path.Dir(f.getWorkbookPath()), rel.Target)
+		if strings.HasPrefix(rel.Target, "/") {
+			part = strings.TrimPrefix(rel.Target, "/")
+		}
+		vbaRelsPath := path.Join(path.Dir(part), "_rels", path.Base(part)+".rels")
+		parts = append(parts, part, vbaRelsPath)
+		if vbaRels, err := f.relsReader(vbaRelsPath); err == nil && vbaRels != nil {
+			for _, vbaRel := range vbaRels.Relationships {
+				parts = append(parts, path.Join(path.Dir(part), vbaRel.Target))
+			}
+		}
+	}
+	rels.mu.Unlock()
+	if len(parts) == 0 {
+		return nil, err
+	}
+	content, err := f.contentTypesReader()
+	if err != nil {
+		return nil, err
+	}
+	output := map[string][]byte{}
+	for _, part := range parts {
+		output[part] = nil
+	}
+	content.mu.Lock()
+	contentTypes := xlsxTypes{Defaults: content.Defaults}
+	for _, override := range content.Overrides {
+		if _, ok := output[strings.TrimPrefix(override.PartName, "/")]; !ok {
+			contentTypes.Overrides = append(contentTypes.Overrides, override)
+		}
+	}
+	content.mu.Unlock()
+	output[defaultXMLPathContentTypes], _ = xml.Marshal(&contentTypes)
+	relsOutput, _ := xml.Marshal(&xlsxRelationships{Relationships: relationships})
+	output[relsPath] = replaceRelationshipsBytes(relsOutput)
+	return output, err
+}
+
 // metadataReader provides a function to get the pointer to the structure
 // after deserialization of xl/metadata.xml.
 func (f *File) metadataReader() (*xlsxMetadata, error) {
@@ -242,6 +242,82 @@ func TestSaveAsWrongPath(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestOpenFileLazySheets(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	// Test the worksheets are not unzipped before accessed
+	for _, sheetXMLPath := range []string{"xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml"} {
+		_, ok := f.Pkg.Load(sheetXMLPath)
+		assert.False(t, ok)
+		_, ok = f.zipFiles.Load(sheetXMLPath)
+		assert.True(t, ok)
+	}
+	val, err := f.GetCellValue("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Monitor", val)
+	_, ok := f.Pkg.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	_, ok = f.zipFiles.Load("xl/worksheets/sheet2.xml")
+	assert.False(t, ok)
+	_, ok = f.Pkg.Load("xl/worksheets/sheet1.xml")
+	assert.False(t, ok)
+	// Test the shared strings index are not built before setting cell value
+	assert.Nil(t, f.sharedStringsMap)
+	assert.NoError(t, f.SetCellValue("Sheet2", "A1", "Monitor"))
+	assert.NotNil(t, f.sharedStringsMap)
+	// Test save workbook with the worksheet which has not been unzipped
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOpenFileLazySheets.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestOpenFileLazySheets.xlsx"))
+	assert.NoError(t, err)
+	for sheet, cell := range map[string]string{"Sheet1": "A19", "Sheet2": "A1"} {
+		val, err := f.GetCellValue(sheet, cell)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, val)
+	}
+	// Test delete the worksheet which has not been unzipped
+	assert.NoError(t, f.DeleteSheet("Sheet1"))
+	_, ok = f.zipFiles.Load("xl/worksheets/sheet1.xml")
+	assert.False(t, ok)
+	assert.NoError(t, f.Close())
+}
+
+func TestOpenFileLazySheetsReadError(t *testing.T) {
+	// Create a worksheet part stored without compression in the zip, and
+	// corrupt its content to fail the CRC-32 checksum verification
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	fi, err := zw.CreateHeader(&zip.FileHeader{Name: "xl/worksheets/sheet1.xml", Method: zip.Store})
+	assert.NoError(t, err)
+	_, err = fi.Write([]byte(xml.Header + `<worksheet xmlns="` + NameSpaceSpreadSheet.Value + `"><sheetData/></worksheet>`))
+	assert.NoError(t, err)
+	assert.NoError(t, zw.Close())
+	raw := buf.Bytes()
+	idx := bytes.Index(raw, []byte("<sheetData/>"))
+	raw[idx+1] = 'S'
+	zr, err := zip.NewReader(bytes.NewReader(raw), int64(len(raw)))
+	assert.NoError(t, err)
+	corrupt := func() *File {
+		f := NewFile()
+		f.Sheet.Delete("xl/worksheets/sheet1.xml")
+		f.Pkg.Delete("xl/worksheets/sheet1.xml")
+		f.checked.Delete("xl/worksheets/sheet1.xml")
+		f.zipFiles.Store("xl/worksheets/sheet1.xml", zr.File[0])
+		return f
+	}
+	// Test read the worksheet which failed to unzip
+	f := corrupt()
+	_, err = f.GetCellValue("Sheet1", "A1")
+	assert.Equal(t, zip.ErrChecksum, err)
+	_, err = f.Rows("Sheet1")
+	assert.Equal(t, zip.ErrChecksum, err)
+	_, err = f.Cols("Sheet1")
+	assert.Equal(t, zip.ErrChecksum, err)
+	_, err = f.SearchSheet("Sheet1", "A")
+	assert.Equal(t, zip.ErrChecksum, err)
+	assert.NoError(t, f.Close())
+}
+
 func TestCharsetTranscoder(t *testing.T) {
 	f := NewFile()
 	f.CharsetTranscoder(*new(charsetTranscoderFn))
@@ -353,6 +429,93 @@ func TestOpenReader(t *testing.T) {
 	assert.EqualError(t, err, zip.ErrAlgorithm.Error())
 }
 
+func TestOpenReaderAt(t *testing.T) {
+	file, err := os.Open(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	defer file.Close()
+	info, err := file.Stat()
+	assert.NoError(t, err)
+	f, err := OpenReaderAt(file, info.Size())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1", "Sheet2"}, f.GetSheetList())
+	val, err := f.GetCellValue("Sheet2", "D11")
+	assert.NoError(t, err)
+	assert.Equal(t, "37", val)
+	// Test save the workbook with the worksheets copied from the reader
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOpenReaderAt.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test open password protected spreadsheet
+	file, err = os.Open(filepath.Join("test", "encryptAES.xlsx"))
+	assert.NoError(t, err)
+	defer file.Close()
+	info, err = file.Stat()
+	assert.NoError(t, err)
+	f, err = OpenReaderAt(file, info.Size(), Options{Password: "password"})
+	assert.NoError(t, err)
+	val, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SECRET", val)
+	assert.NoError(t, f.Close())
+
+	// Test open spreadsheet with invalid options
+	_, err = OpenReaderAt(strings.NewReader(""), 0, Options{UnzipSizeLimit: 1, UnzipXMLSizeLimit: 2})
+	assert.Equal(t, ErrOptionsUnzipSizeLimit, err)
+	// Test open spreadsheet with invalid zip format
+	_, err = OpenReaderAt(strings.NewReader(""), 0)
+	assert.Equal(t, zip.ErrFormat, err)
+	_, err = OpenReaderAt(strings.NewReader(""), 0, Options{Password: "password"})
+	assert.Equal(t, ErrWorkbookPassword, err)
+}
+
+func TestUseTempFiles(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UseTempFiles: true})
+	assert.NoError(t, err)
+	for _, name := range []string{"xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml", defaultXMLPathSharedStrings} {
+		_, ok := f.tempFiles.Load(name)
+		assert.True(t, ok, name)
+		_, ok = f.zipFiles.Load(name)
+		assert.False(t, ok, name)
+	}
+	// Test the raw content of the worksheet will be released after parsed
+	val, err := f.GetCellValue("Sheet2", "D11")
+	assert.NoError(t, err)
+	assert.Equal(t, "37", val)
+	_, ok := f.Pkg.Load("xl/worksheets/sheet2.xml")
+	assert.False(t, ok)
+	// Test the generated worksheet will be written to the temporary file
+	path, _ := f.tempFiles.Load("xl/worksheets/sheet2.xml")
+	assert.NoError(t, f.SetCellValue("Sheet2", "D11", "Temp"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestUseTempFiles.xlsx")))
+	newPath, ok := f.tempFiles.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	assert.NotEqual(t, path, newPath)
+	_, err = os.Stat(path.(string))
+	assert.True(t, os.IsNotExist(err))
+	_, ok = f.Pkg.Load("xl/worksheets/sheet2.xml")
+	assert.False(t, ok)
+	// Test save the workbook repeatedly
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestUseTempFiles.xlsx")))
+	// Test delete worksheet which was saved in the temporary file
+	path, _ = f.tempFiles.Load("xl/worksheets/sheet1.xml")
+	assert.NoError(t, f.DeleteSheet("Sheet1"))
+	_, ok = f.tempFiles.Load("xl/worksheets/sheet1.xml")
+	assert.False(t, ok)
+	_, err = os.Stat(path.(string))
+	assert.True(t, os.IsNotExist(err))
+	// Test the temporary files will be removed on close
+	assert.NoError(t, f.Close())
+	_, err = os.Stat(newPath.(string))
+	assert.True(t, os.IsNotExist(err))
+
+	f, err = OpenFile(filepath.Join("test", "TestUseTempFiles.xlsx"))
+	assert.NoError(t, err)
+	val, err = f.GetCellValue("Sheet2", "D11")
+	assert.NoError(t, err)
+	assert.Equal(t, "Temp", val)
+	assert.NoError(t, f.Close())
+}
+
 func TestBrokenFile(t *testing.T) {
 	// Test write file with broken file struct
 	f := File{}
@@ -467,6 +630,111 @@ func TestSetCellHyperLink(t *testing.T) {
 	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "Sheet1!D8", "Location"))
 	ws.(*xlsxWorksheet).Hyperlinks.Hyperlink[0].Ref = "A:A"
 	assert.Error(t, f.SetCellHyperLink("Sheet1", "B2", "", "None"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")))
+
+	// Test set hyperlink for a range of cells, defined name and email address
+	f = NewFile()
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "C3:A1", "mailto:support@example.com", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "D1", "SalesData", "Location"))
+	link, target, err = f.GetCellHyperLink("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "mailto:support@example.com", target)
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, "A1:C3", ws.(*xlsxWorksheet).Hyperlinks.Hyperlink[0].Ref)
+	// Test set hyperlink with invalid range reference
+	assert.Equal(t, newCellNameToCoordinatesError("B", newInvalidCellNameError("B")), f.SetCellHyperLink("Sheet1", "A1:B", "SalesData", "Location"))
+
+	// Test set hyperlink with the built-in hyperlink cell style
+	f = NewFile()
+	opts := HyperlinkOpts{ApplyStyle: true}
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B2:A1", "https://github.com/xuri/excelize", "External", opts))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "C1", "Sheet1!A1", "Location", opts))
+	styleID, err := f.GetNamedStyleID("Hyperlink")
+	assert.NoError(t, err)
+	for _, cell := range []string{"A1", "B2", "C1"} {
+		cellStyleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, styleID, cellStyleID)
+	}
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, "single", style.Font.Underline)
+	assert.Equal(t, intPtr(10), style.Font.ColorTheme)
+	assert.Len(t, f.Styles.CellStyles.CellStyle, 2)
+	assert.Equal(t, intPtr(8), f.Styles.CellStyles.CellStyle[1].BuiltInID)
+	// Test set hyperlink with the built-in hyperlink cell style with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellHyperLink("Sheet1", "D1", "Sheet1!A1", "Location", opts), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestGetCellHyperLinks(t *testing.T) {
+	f := NewFile()
+	links, err := f.GetCellHyperLinks("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, links)
+	display, tooltip := "Excelize", "Excelize on GitHub"
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External", HyperlinkOpts{
+		Display: &display,
+		Tooltip: &tooltip,
+	}))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B1:B3", "Sheet1!D8", "Location"))
+	links, err = f.GetCellHyperLinks("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []CellHyperLink{
+		{Cell: "A1", Link: "https://github.com/xuri/excelize", LinkType: "External", Display: display, Tooltip: tooltip},
+		{Cell: "B1:B3", Link: "Sheet1!D8", LinkType: "Location"},
+	}, links)
+	// Test get hyperlinks with not exist worksheet
+	_, err = f.GetCellHyperLinks("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
+func TestRemoveCellHyperLink(t *testing.T) {
+	f := NewFile()
+	// Test remove hyperlink on a worksheet without hyperlinks
+	assert.NoError(t, f.RemoveCellHyperLink("Sheet1", "A1"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B1:B3", "Sheet1!D8", "Location"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "C1", "Sheet1!D8", "Location"))
+	assert.NoError(t, f.RemoveCellHyperLink("Sheet1", "A1"))
+	assert.NoError(t, f.RemoveCellHyperLink("Sheet1", "B3:B1"))
+	links, err := f.GetCellHyperLinks("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []CellHyperLink{{Cell: "C1", Link: "Sheet1!D8", LinkType: "Location"}}, links)
+	rels, err := f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.NoError(t, err)
+	assert.Empty(t, rels.Relationships)
+	assert.NoError(t, f.RemoveCellHyperLink("Sheet1", "C1"))
+	link, _, err := f.GetCellHyperLink("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.False(t, link)
+	// Test remove hyperlinks which intersect with the range
+	for _, ref := range []string{"A1", "C2:C4", "B3:E3", "D1:F1", "F5"} {
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", ref, "Sheet1!D8", "Location"))
+	}
+	assert.NoError(t, f.RemoveCellHyperLink("Sheet1", "D4:B2"))
+	links, err = f.GetCellHyperLinks("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []CellHyperLink{
+		{Cell: "A1", Link: "Sheet1!D8", LinkType: "Location"},
+		{Cell: "D1:F1", Link: "Sheet1!D8", LinkType: "Location"},
+		{Cell: "F5", Link: "Sheet1!D8", LinkType: "Location"},
+	}, links)
+	assert.NoError(t, f.RemoveCellHyperLink("Sheet1", "E1"))
+	links, err = f.GetCellHyperLinks("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, links, 2)
+	// Test remove hyperlink with invalid hyperlink reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).Hyperlinks.Hyperlink[0].Ref = "A"
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.RemoveCellHyperLink("Sheet1", "B1"))
+	// Test remove hyperlink with invalid cell reference
+	assert.Equal(t, newInvalidCellNameError("A"), f.RemoveCellHyperLink("Sheet1", "A"))
+	// Test remove hyperlink with not exist worksheet
+	assert.EqualError(t, f.RemoveCellHyperLink("SheetN", "A1"), "sheet SheetN does not exist")
 }
 
 func TestGetCellHyperLink(t *testing.T) {
@@ -913,6 +1181,30 @@ func TestSetCellStyleLangNumberFormat(t *testing.T) {
 	assert.Equal(t, ErrUnsupportedNumberFormat, err)
 }
 
+func TestGetCellValueCurrencyNumberFormat(t *testing.T) {
+	for lang, expected := range map[CultureName][][]string{
+		CultureNameUnknown: {{"1234.125", "-1234.125"}, {"1234.125", "-1234.125"}, {"1234.125", "-1234.125"}, {"1234.125", "-1234.125"}},
+		CultureNameEnUS:    {{"$1,234 ", "($1,234)"}, {"$1,234 ", "($1,234)"}, {"$1,234.13 ", "($1,234.13)"}, {"$1,234.13 ", "($1,234.13)"}},
+	} {
+		f := NewFile(Options{CultureInfo: lang})
+		styleSheet, err := f.stylesReader()
+		assert.NoError(t, err)
+		for numFmtID := 5; numFmtID <= 8; numFmtID++ {
+			styleSheet.CellXfs.Xf = append(styleSheet.CellXfs.Xf, xlsxXf{NumFmtID: intPtr(numFmtID)})
+			assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", numFmtID-4), &[]float64{1234.125, -1234.125}))
+			assert.NoError(t, f.SetCellStyle("Sheet1", fmt.Sprintf("A%d", numFmtID-4), fmt.Sprintf("B%d", numFmtID-4), len(styleSheet.CellXfs.Xf)-1))
+		}
+		rows, err := f.GetRows("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, rows)
+		// Test get the raw cell value without applying the number format
+		val, err := f.GetCellValue("Sheet1", "B3", Options{RawCellValue: true})
+		assert.NoError(t, err)
+		assert.Equal(t, "-1234.125", val)
+		assert.NoError(t, f.Close())
+	}
+}
+
 func TestSetCellStyleCustomNumberFormat(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 42920.5))
@@ -1058,6 +1350,159 @@ func TestCopySheetError(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCopySheetError.xlsx")))
 }
 
+func TestCopySheetBetweenFiles(t *testing.T) {
+	src := NewFile()
+	assert.NoError(t, src.SetCellValue("Sheet1", "A1", "Hello"))
+	assert.NoError(t, src.SetCellRichText("Sheet1", "A2", []RichTextRun{
+		{Text: "Rich ", Font: &Font{Bold: true}}, {Text: "Text"},
+	}))
+	assert.NoError(t, src.SetCellValue("Sheet1", "B1", 1))
+	assert.NoError(t, src.SetCellValue("Sheet1", "B2", 2))
+	style, err := src.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"94D3A2"}, Pattern: 1}, NumFmt: 10})
+	assert.NoError(t, err)
+	assert.NoError(t, src.SetCellStyle("Sheet1", "B1", "B2", style))
+	assert.NoError(t, src.SetColStyle("Sheet1", "D", style))
+	assert.NoError(t, src.MergeCell("Sheet1", "C1", "C2"))
+	assert.NoError(t, src.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External"))
+	format, err := src.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	assert.NoError(t, src.SetConditionalFormat("Sheet1", "B1:B2", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: &format, Value: "1"},
+	}))
+	assert.NoError(t, src.AddPicture("Sheet1", "E1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, src.AddChart("Sheet1", "E10", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$B$1:$B$2"}},
+	}))
+	assert.NoError(t, src.SetSheetBackground("Sheet1", filepath.Join("test", "images", "background.jpg")))
+	assert.NoError(t, src.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	assert.NoError(t, src.AddThreadedComment("Sheet1", ThreadedComment{Cell: "B1", Author: "Excelize", Text: "Threaded comment"}))
+
+	f := NewFile()
+	_, err = f.NewStyle(&Style{Font: &Font{Italic: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "World"))
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.jpg"), nil))
+	assert.NoError(t, f.CopySheetBetweenFiles(src, "Sheet1", "Sheet2"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCopySheetBetweenFiles.xlsx")))
+	assert.NoError(t, src.Close())
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestCopySheetBetweenFiles.xlsx"))
+	assert.NoError(t, err)
+	for cell, expected := range map[string]string{"A1": "Hello", "A2": "Rich Text", "B1": "100.00%", "B2": "200.00%"} {
+		val, err := f.GetCellValue("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+	runs, err := f.GetCellRichText("Sheet2", "A2")
+	assert.NoError(t, err)
+	assert.Len(t, runs, 2)
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "World", val)
+	styleID, err := f.GetCellStyle("Sheet2", "B1")
+	assert.NoError(t, err)
+	cellStyle, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"94D3A2"}, cellStyle.Fill.Color)
+	colStyle, err := f.GetColStyle("Sheet2", "D")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, colStyle)
+	mergeCells, err := f.GetMergeCells("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	link, target, err := f.GetCellHyperLink("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/xuri/excelize", target)
+	opts, err := f.GetConditionalFormats("Sheet2")
+	assert.NoError(t, err)
+	condStyle, err := f.GetConditionalStyle(*opts["B1:B2"][0].Format)
+	assert.NoError(t, err)
+	assert.Equal(t, "9A0511", condStyle.Font.Color)
+	pics, err := f.GetPictures("Sheet2", "E1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.Equal(t, ".png", pics[0].Extension)
+	pics, err = f.GetPictures("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.Equal(t, ".jpeg", pics[0].Extension)
+	charts := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), "xl/charts/chart") {
+			charts++
+		}
+		return true
+	})
+	assert.Equal(t, 1, charts)
+	// Test the data references of the copied chart use the new worksheet name
+	chart := string(f.readXML("xl/charts/chart1.xml"))
+	assert.Contains(t, chart, "<f>Sheet2!$B$1:$B$2</f>")
+	assert.NotContains(t, chart, "Sheet1!")
+	comments, err := f.GetComments("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+	threadedComments, err := f.GetThreadedComments("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, threadedComments, 1)
+	assert.Equal(t, "Threaded comment", threadedComments[0].Text)
+	assert.NoError(t, f.Close())
+
+	// Test copy worksheet with chart to the worksheet name with spaces
+	f, src = NewFile(), NewFile()
+	assert.NoError(t, src.AddChart("Sheet1", "E1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$A$2:$A$3", Values: "'Sheet1'!$B$2:$B$3"}},
+	}))
+	assert.NoError(t, f.CopySheetBetweenFiles(src, "Sheet1", "Q1 Report"))
+	chart = string(f.readXML("xl/charts/chart1.xml"))
+	assert.Contains(t, chart, "<f>&#39;Q1 Report&#39;!$A$2:$A$3</f>")
+	assert.Contains(t, chart, "<f>&#39;Q1 Report&#39;!$B$2:$B$3</f>")
+	// Test copy worksheet with unsupported charset content types of the
+	// source workbook, the new worksheet should be removed
+	src.ContentTypes = nil
+	src.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.CopySheetBetweenFiles(src, "Sheet1", "Sheet3"), "XML syntax error on line 1: invalid UTF-8")
+	idx, err := f.GetSheetIndex("Sheet3")
+	assert.NoError(t, err)
+	assert.Equal(t, -1, idx)
+	assert.NoError(t, f.Close())
+	assert.NoError(t, src.Close())
+
+	// Test copy worksheet with invalid parameters
+	f, src = NewFile(), NewFile()
+	assert.Equal(t, ErrParameterInvalid, f.CopySheetBetweenFiles(nil, "Sheet1", "Sheet2"))
+	assert.Equal(t, ErrExistsSheet, f.CopySheetBetweenFiles(src, "Sheet1", "Sheet1"))
+	assert.Equal(t, ErrSheetNameInvalid, f.CopySheetBetweenFiles(src, "Sheet1", "Sheet:1"))
+	assert.EqualError(t, f.CopySheetBetweenFiles(src, "SheetN", "Sheet2"), "sheet SheetN does not exist")
+	// Test copy worksheet with invalid style index
+	assert.NoError(t, src.SetCellValue("Sheet1", "A1", 1))
+	ws, ok := src.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].S = 100
+	assert.Equal(t, newInvalidStyleID(100), f.CopySheetBetweenFiles(src, "Sheet1", "Sheet2"))
+	// Test copy worksheet with unsupported charset shared strings table
+	src.SharedStrings = nil
+	src.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.CopySheetBetweenFiles(src, "Sheet1", "Sheet2"), "XML syntax error on line 1: invalid UTF-8")
+	// Test copy worksheet with slicers
+	src = NewFile()
+	ws, ok = src.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).ExtLst = &xlsxExtLst{Ext: "<ext uri=\"" + ExtURISlicerListX14 + "\"></ext>"}
+	assert.NoError(t, f.CopySheetBetweenFiles(src, "Sheet1", "Sheet2"))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	assert.Nil(t, ws.(*xlsxWorksheet).ExtLst)
+	ws, ok = src.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	// Test copy worksheet with invalid extension list
+	ws.(*xlsxWorksheet).ExtLst = &xlsxExtLst{Ext: "<ext></x14:slicerList>"}
+	assert.EqualError(t, f.CopySheetBetweenFiles(src, "Sheet1", "Sheet3"), "XML syntax error on line 1: element <ext> closed by </slicerList>")
+}
+
 func TestGetSheetComments(t *testing.T) {
 	f := NewFile()
 	assert.Equal(t, "", f.getSheetComments("sheet0"))
@@ -1339,6 +1784,45 @@ func TestSetSheetRow(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestSetSheetTypedCells(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetRowStyle("Sheet1", 1, 1, style))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "SUM(1,2)"))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Name", " Excelize "}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]int{1, -2, 3}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]float64{1.5, math.Inf(1)}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A4", &[]bool{true, false}))
+	assert.NoError(t, f.SetSheetCol("Sheet1", "D1", &[]string{"A", "B", "C"}))
+	assert.NoError(t, f.SetSheetCol("Sheet1", "E1", &[]float64{}))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Name", " Excelize ", "", "A"},
+		{"1", "-2", "3", "B"},
+		{"1.5", "+Inf", "", "C"},
+		{"TRUE", "FALSE"},
+	}, rows)
+	// Test the typed cells inherit the row style and the formula is removed
+	styleID, err := f.GetCellStyle("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	formula, err := f.GetCellFormula("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	// Test set typed cells value with invalid sheet name
+	assert.Equal(t, ErrSheetNameInvalid, f.SetSheetRow("Sheet:1", "A1", &[]string{"A"}))
+	// Test set typed cells value exceeds the maximum column or row number
+	assert.Equal(t, ErrColumnNumber, f.SetSheetRow("Sheet1", "XFD1", &[]int{1, 2}))
+	assert.Equal(t, ErrMaxRows, f.SetSheetCol("Sheet1", "A1048576", &[]bool{true, false}))
+	// Test set typed cells value with unsupported charset shared string table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"A"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestHSL(t *testing.T) {
 	var hsl HSL
 	r, g, b, a := hsl.RGBA()
@@ -1395,6 +1879,16 @@ func TestProtectSheet(t *testing.T) {
 	ws, err := f.workSheetReader(sheetName)
 	assert.NoError(t, err)
 	assert.Equal(t, "83AF", ws.SheetProtection.Password)
+	assert.NoError(t, f.ProtectSheet(sheetName, &SheetProtectionOptions{
+		AlgorithmName: "XOR",
+		Password:      "password",
+		FormatCells:   true,
+		InsertRows:    true,
+	}))
+	assert.Equal(t, "83AF", ws.SheetProtection.Password)
+	assert.False(t, ws.SheetProtection.FormatCells)
+	assert.False(t, ws.SheetProtection.InsertRows)
+	assert.True(t, ws.SheetProtection.DeleteRows)
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestProtectSheet.xlsx")))
 	// Test protect worksheet with SHA-512 hash algorithm
 	assert.NoError(t, f.ProtectSheet(sheetName, &SheetProtectionOptions{
@@ -1474,6 +1968,17 @@ func TestProtectWorkbook(t *testing.T) {
 	assert.Len(t, wb.WorkbookProtection.WorkbookSaltValue, 24)
 	assert.Len(t, wb.WorkbookProtection.WorkbookHashValue, 88)
 	assert.Equal(t, int(workbookProtectionSpinCount), wb.WorkbookProtection.WorkbookSpinCount)
+	// Test protect workbook with XOR hash algorithm
+	assert.NoError(t, f.ProtectWorkbook(&WorkbookProtectionOptions{
+		AlgorithmName: "XOR",
+		Password:      "password",
+		LockStructure: true,
+	}))
+	wb, err = f.workbookReader()
+	assert.NoError(t, err)
+	assert.Equal(t, "83AF", wb.WorkbookProtection.WorkbookPassword)
+	assert.Empty(t, wb.WorkbookProtection.WorkbookAlgorithmName)
+	assert.Empty(t, wb.WorkbookProtection.WorkbookHashValue)
 
 	// Test protect workbook with password exceeds the limit length
 	assert.EqualError(t, f.ProtectWorkbook(&WorkbookProtectionOptions{
@@ -1506,6 +2011,10 @@ func TestUnprotectWorkbook(t *testing.T) {
 	assert.EqualError(t, f.UnprotectWorkbook("wrongPassword"), ErrUnprotectWorkbookPassword.Error())
 	// Test remove workbook protection with password verification
 	assert.NoError(t, f.UnprotectWorkbook("password"))
+	// Test remove workbook protection with XOR hash algorithm password
+	assert.NoError(t, f.ProtectWorkbook(&WorkbookProtectionOptions{AlgorithmName: "XOR", Password: "password"}))
+	assert.EqualError(t, f.UnprotectWorkbook("wrongPassword"), ErrUnprotectWorkbookPassword.Error())
+	assert.NoError(t, f.UnprotectWorkbook("password"))
 	// Test with invalid salt value
 	assert.NoError(t, f.ProtectWorkbook(&WorkbookProtectionOptions{
 		AlgorithmName: "SHA-512",
@@ -1546,6 +2055,105 @@ func TestAddVBAProject(t *testing.T) {
 	f.Relationships.Delete(defaultXMLPathWorkbookRels)
 	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
 	assert.EqualError(t, f.AddVBAProject(file), "XML syntax error on line 1: invalid UTF-8")
+	// Test add VBA project with unsupported charset content types
+	f = NewFile()
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddVBAProject(file), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestSaveVBAProject(t *testing.T) {
+	f := NewFile()
+	file, err := os.ReadFile(filepath.Join("test", "vbaProject.bin"))
+	assert.NoError(t, err)
+	f.Path = "Book1.xltm"
+	assert.NoError(t, f.AddVBAProject(file))
+	f.Path = ""
+	// Test the content types are consistent without the file path
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	content, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	assert.Contains(t, content.Defaults, xlsxDefault{Extension: "bin", ContentType: ContentTypeVBA})
+	assert.Contains(t, content.Overrides, xlsxOverride{PartName: "/xl/workbook.xml", ContentType: ContentTypeTemplateMacro})
+	// Test preserve the VBA project and custom UI parts on save
+	customUI := []byte(`<customUI xmlns="http://schemas.microsoft.com/office/2009/07/customui"><ribbon/></customUI>`)
+	f.Pkg.Store("customUI/customUI14.xml", customUI)
+	f.addRels(defaultXMLPathRels, "http://schemas.microsoft.com/office/2007/relationships/ui/extensibility", "customUI/customUI14.xml", "")
+	f.Pkg.Store("xl/_rels/vbaProject.bin.rels", []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.microsoft.com/office/2006/relationships/vbaProjectSignature" Target="vbaProjectSignature.bin"/></Relationships>`))
+	f.Pkg.Store("xl/vbaProjectSignature.bin", []byte{0})
+	content.Overrides = append(content.Overrides, xlsxOverride{PartName: "/xl/vbaProjectSignature.bin", ContentType: "application/vnd.ms-office.vbaProjectSignature"})
+	wb := filepath.Join("test", "TestSaveVBAProject.xlsm")
+	assert.NoError(t, f.SaveAs(wb))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(wb)
+	assert.NoError(t, err)
+	for _, part := range []string{"xl/vbaProject.bin", "xl/vbaProjectSignature.bin", "customUI/customUI14.xml"} {
+		_, ok := f.Pkg.Load(part)
+		assert.True(t, ok, part)
+	}
+	content, err = f.contentTypesReader()
+	assert.NoError(t, err)
+	assert.Contains(t, content.Overrides, xlsxOverride{PartName: "/xl/workbook.xml", ContentType: ContentTypeMacro})
+	// Test remove the VBA project on save as the macro-free workbook
+	wb = filepath.Join("test", "TestSaveVBAProject.xlsx")
+	assert.NoError(t, f.SaveAs(wb))
+	// Test the VBA project in memory is kept after save as the macro-free workbook
+	for _, part := range []string{"xl/vbaProject.bin", "xl/vbaProjectSignature.bin"} {
+		_, ok := f.Pkg.Load(part)
+		assert.True(t, ok, part)
+	}
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	var vbaParts []string
+	for _, file := range zr.File {
+		if strings.HasPrefix(file.Name, "xl/vbaProject") || file.Name == "xl/_rels/vbaProject.bin.rels" {
+			vbaParts = append(vbaParts, file.Name)
+		}
+	}
+	assert.ElementsMatch(t, []string{"xl/vbaProject.bin", "xl/vbaProjectSignature.bin", "xl/_rels/vbaProject.bin.rels"}, vbaParts)
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(wb)
+	assert.NoError(t, err)
+	for _, part := range []string{"xl/vbaProject.bin", "xl/vbaProjectSignature.bin", "xl/_rels/vbaProject.bin.rels"} {
+		_, ok := f.Pkg.Load(part)
+		assert.False(t, ok, part)
+	}
+	_, ok := f.Pkg.Load("customUI/customUI14.xml")
+	assert.True(t, ok)
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	assert.NoError(t, err)
+	for _, rel := range rels.Relationships {
+		assert.NotEqual(t, SourceRelationshipVBAProject, rel.Type)
+	}
+	content, err = f.contentTypesReader()
+	assert.NoError(t, err)
+	assert.Contains(t, content.Overrides, xlsxOverride{PartName: "/xl/workbook.xml", ContentType: ContentTypeSheetML})
+	for _, override := range content.Overrides {
+		assert.NotEqual(t, "/xl/vbaProjectSignature.bin", override.PartName)
+	}
+	assert.NoError(t, f.Close())
+
+	// Test remove the VBA project with unsupported charset workbook relationships
+	f = NewFile()
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	_, err = f.removeVBAProject()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	f.Path = "Book1.xlsx"
+	_, err = f.WriteTo(bytes.NewBuffer(nil))
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test remove the VBA project with unsupported charset content types
+	f = NewFile()
+	assert.NoError(t, f.AddVBAProject(file))
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	_, err = f.removeVBAProject()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
 func TestContentTypesReader(t *testing.T) {
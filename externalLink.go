@@ -0,0 +1,339 @@
+// Copyright 2016 - 2025 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.20 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/efp"
+)
+
+// GetExternalLinks provides a function to get the external workbook
+// references in the workbook. The Index of each external link is the index
+// which used in the formulas to reference the external workbook. For
+// example, get the external links in the workbook:
+//
+//	links, err := f.GetExternalLinks()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, link := range links {
+//	    fmt.Println(link.Index, link.Target, link.SheetNames)
+//	}
+func (f *File) GetExternalLinks() ([]ExternalLink, error) {
+	var links []ExternalLink
+	linkPaths, err := f.getExternalLinkPaths()
+	if err != nil {
+		return links, err
+	}
+	for i, linkPath := range linkPaths {
+		link := ExternalLink{Index: i + 1}
+		externalLink, err := f.externalLinkReader(linkPath)
+		if err != nil {
+			return links, err
+		}
+		if book := externalLink.ExternalBook; book != nil {
+			if book.SheetNames != nil {
+				for _, sheetName := range book.SheetNames.SheetName {
+					if sheetName.Val != nil {
+						link.SheetNames = append(link.SheetNames, *sheetName.Val)
+					}
+				}
+			}
+			rel, err := f.getExternalLinkRel(linkPath, book.RID)
+			if err != nil {
+				return links, err
+			}
+			if rel != nil {
+				link.Target = rel.Target
+			}
+		}
+		links = append(links, link)
+	}
+	return links, err
+}
+
+// SetExternalLink provides a function to update the path of the linked
+// workbook by given external link index and target. For example, change the
+// first external link to the workbook "Book2.xlsx" in the same directory:
+//
+//	err := f.SetExternalLink(1, "Book2.xlsx")
+func (f *File) SetExternalLink(index int, target string) error {
+	linkPaths, err := f.getExternalLinkPaths()
+	if err != nil {
+		return err
+	}
+	if index < 1 || index > len(linkPaths) || target == "" {
+		return ErrParameterInvalid
+	}
+	externalLink, err := f.externalLinkReader(linkPaths[index-1])
+	if err != nil {
+		return err
+	}
+	if externalLink.ExternalBook == nil {
+		return ErrParameterInvalid
+	}
+	rel, err := f.getExternalLinkRel(linkPaths[index-1], externalLink.ExternalBook.RID)
+	if err != nil {
+		return err
+	}
+	if rel == nil {
+		return ErrParameterInvalid
+	}
+	rel.Target, rel.TargetMode = target, "External"
+	return err
+}
+
+// DeleteExternalLink provides a function to break the external workbook
+// reference by given external link index. The formulas which reference the
+// external workbook will be converted to their cached values, the defined
+// names which reference the external workbook will be replaced with the
+// "#REF!" error value, and the indexes of the subsequent external links in
+// the formulas will be updated. For example, break the first external link in
+// the workbook:
+//
+//	err := f.DeleteExternalLink(1)
+func (f *File) DeleteExternalLink(index int) error {
+	linkPaths, err := f.getExternalLinkPaths()
+	if err != nil {
+		return err
+	}
+	if index < 1 || index > len(linkPaths) {
+		return ErrParameterInvalid
+	}
+	for _, sheet := range f.GetSheetList() {
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			if err.Error() == newNotWorksheetError(sheet).Error() {
+				continue
+			}
+			return err
+		}
+		ws.breakExternalLink(index)
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.DefinedNames != nil {
+		for i := range wb.DefinedNames.DefinedName {
+			definedName := &wb.DefinedNames.DefinedName[i]
+			var broken bool
+			if definedName.Data, broken = adjustExternalLinkFormula(definedName.Data, index); broken {
+				definedName.Data = formulaErrorREF
+			}
+		}
+	}
+	rID := wb.ExternalReferences.ExternalReference[index-1].RID
+	wb.ExternalReferences.ExternalReference = append(wb.ExternalReferences.ExternalReference[:index-1], wb.ExternalReferences.ExternalReference[index:]...)
+	if len(wb.ExternalReferences.ExternalReference) == 0 {
+		wb.ExternalReferences = nil
+	}
+	f.deleteSheetFromWorkbookRels(rID)
+	if linkPath := linkPaths[index-1]; linkPath != "" {
+		linkRels := getExternalLinkRelsPath(linkPath)
+		f.Relationships.Delete(linkRels)
+		f.Pkg.Delete(linkRels)
+		f.Pkg.Delete(linkPath)
+		if err = f.removeContentTypesPart(ContentTypeSpreadSheetMLExternalLink, "/"+linkPath); err != nil {
+			return err
+		}
+	}
+	return f.removeCalcChain()
+}
+
+// getExternalLinkPaths provides a function to get the paths of the external
+// link parts in the order of the external references in the workbook. The
+// path will be empty if the relationship of the external reference doesn't
+// exist.
+func (f *File) getExternalLinkPaths() ([]string, error) {
+	var linkPaths []string
+	wb, err := f.workbookReader()
+	if err != nil || wb.ExternalReferences == nil {
+		return linkPaths, err
+	}
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	if err != nil {
+		return linkPaths, err
+	}
+	for _, ref := range wb.ExternalReferences.ExternalReference {
+		var linkPath string
+		if rels != nil {
+			rels.mu.Lock()
+			for _, rel := range rels.Relationships {
+				if rel.ID != ref.RID || rel.Type != SourceRelationshipExternalLink {
+					continue
+				}
+				if linkPath = strings.TrimPrefix(rel.Target, "/"); linkPath == rel.Target {
+					linkPath = path.Join(path.Dir(f.getWorkbookPath()), rel.Target)
+				}
+			}
+			rels.mu.Unlock()
+		}
+		linkPaths = append(linkPaths, linkPath)
+	}
+	return linkPaths, err
+}
+
+// getExternalLinkRelsPath returns the relationships part path of the
+// external link part by given external link part path.
+func getExternalLinkRelsPath(linkPath string) string {
+	return path.Join(path.Dir(linkPath), "_rels", path.Base(linkPath)+".rels")
+}
+
+// getExternalLinkRel provides a function to get the relationship of the
+// linked workbook by given external link part path and relationship ID.
+func (f *File) getExternalLinkRel(linkPath, rID string) (*xlsxRelationship, error) {
+	rels, err := f.relsReader(getExternalLinkRelsPath(linkPath))
+	if err != nil || rels == nil {
+		return nil, err
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for i := range rels.Relationships {
+		if rels.Relationships[i].ID == rID {
+			return &rels.Relationships[i], err
+		}
+	}
+	return nil, err
+}
+
+// externalLinkReader provides a function to get the pointer to the structure
+// after deserialization of the external link part by given path.
+func (f *File) externalLinkReader(path string) (*xlsxExternalLink, error) {
+	externalLink := &xlsxExternalLink{}
+	if content, ok := f.Pkg.Load(path); ok && content != nil {
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+			Decode(externalLink); err != nil && err != io.EOF {
+			return nil, err
+		}
+	}
+	return externalLink, nil
+}
+
+// breakExternalLink provides a function to convert the formulas which
+// reference the external workbook to their cached values, and update the
+// indexes of the subsequent external links in the formulas by given external
+// link index.
+func (ws *xlsxWorksheet) breakExternalLink(index int) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	si := map[int]bool{}
+	for r := range ws.SheetData.Row {
+		for c := range ws.SheetData.Row[r].C {
+			cell := &ws.SheetData.Row[r].C[c]
+			if cell.F == nil || (cell.F.T == STCellFormulaTypeShared && cell.F.Ref == "") {
+				continue
+			}
+			var broken bool
+			if cell.F.Content, broken = adjustExternalLinkFormula(cell.F.Content, index); !broken {
+				cell.f, _ = adjustExternalLinkFormula(cell.f, index)
+				continue
+			}
+			if cell.F.T == STCellFormulaTypeShared && cell.F.Si != nil {
+				si[*cell.F.Si] = true
+			}
+			cell.F, cell.f = nil, ""
+		}
+	}
+	for r := range ws.SheetData.Row {
+		for c := range ws.SheetData.Row[r].C {
+			cell := &ws.SheetData.Row[r].C[c]
+			if cell.F != nil && cell.F.T == STCellFormulaTypeShared && cell.F.Si != nil && si[*cell.F.Si] {
+				cell.F, cell.f = nil, ""
+			}
+		}
+	}
+}
+
+// adjustExternalLinkFormula provides a function to update the indexes of the
+// external links which greater than the given external link index in the
+// formula. The returned boolean value will be true and the formula will be
+// kept if the formula references the external link with the given index.
+func adjustExternalLinkFormula(formula string, index int) (string, bool) {
+	var (
+		val     string
+		changed bool
+		ps      = efp.ExcelParser()
+	)
+	for _, token := range ps.Parse(formula) {
+		if token.TType == efp.TokenTypeUnknown {
+			return formula, false
+		}
+		if token.TType == efp.TokenTypeOperand && token.TSubType == efp.TokenSubTypeRange {
+			if idx, ref, ok := parseExternalLinkOperand(token.TValue); ok {
+				if idx == index {
+					return formula, true
+				}
+				if idx > index {
+					idx, changed = idx-1, true
+				}
+				val += escapeExternalLinkOperand("["+strconv.Itoa(idx)+"]", ref)
+				continue
+			}
+			if !strings.ContainsAny(token.TValue, "[]") {
+				val += escapeExternalLinkOperand("", token.TValue)
+				continue
+			}
+		}
+		if paren := transformParenthesesToken(token); paren != "" {
+			val += paren
+			continue
+		}
+		if token.TType == efp.TokenTypeOperand && token.TSubType == efp.TokenSubTypeText {
+			val += string(efp.QuoteDouble) + strings.ReplaceAll(token.TValue, "\"", "\"\"") + string(efp.QuoteDouble)
+			continue
+		}
+		val += token.TValue
+	}
+	if !changed {
+		return formula, false
+	}
+	return val, false
+}
+
+// parseExternalLinkOperand returns the external link index and the rest part
+// of the operand if the formula operand references an external workbook.
+func parseExternalLinkOperand(operand string) (int, string, bool) {
+	if !strings.HasPrefix(operand, "[") {
+		return 0, operand, false
+	}
+	end := strings.Index(operand, "]")
+	if end == -1 {
+		return 0, operand, false
+	}
+	idx, err := strconv.Atoi(operand[1:end])
+	if err != nil {
+		return 0, operand, false
+	}
+	return idx, operand[end+1:], true
+}
+
+// escapeExternalLinkOperand returns the formula operand with the given
+// external link prefix, the worksheet name in the operand will be enclosed in
+// single quotation marks if needed.
+func escapeExternalLinkOperand(prefix, operand string) string {
+	i := strings.LastIndex(operand, "!")
+	if i == -1 {
+		return prefix + operand
+	}
+	sheet := strings.ReplaceAll(strings.Trim(operand[:i], "'"), "''", "'")
+	if escaped := escapeSheetName(sheet); sheet != "" && escaped != sheet {
+		return "'" + prefix + escaped[1:] + operand[i:]
+	}
+	return prefix + sheet + operand[i:]
+}
@@ -0,0 +1,161 @@
+package excelize
+
+import (
+	"encoding/xml"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareExternalLinks(t *testing.T, f *File, targets ...string) {
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	wb.ExternalReferences = &xlsxExternalReferences{}
+	for i, target := range targets {
+		name := "externalLink" + strconv.Itoa(i+1) + ".xml"
+		rID := f.addRels(f.getWorkbookRelsPath(), SourceRelationshipExternalLink, "externalLinks/"+name, "")
+		wb.ExternalReferences.ExternalReference = append(wb.ExternalReferences.ExternalReference, xlsxExternalReference{RID: "rId" + strconv.Itoa(rID)})
+		f.Pkg.Store("xl/externalLinks/"+name, []byte(xml.Header+`<externalLink xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><externalBook r:id="rId1"><sheetNames><sheetName val="Sheet1"/><sheetName val="My Sheet"/></sheetNames></externalBook></externalLink>`))
+		f.addRels("xl/externalLinks/_rels/"+name+".rels", SourceRelationshipExternalLinkPath, target, "External")
+		content, err := f.contentTypesReader()
+		assert.NoError(t, err)
+		content.Overrides = append(content.Overrides, xlsxOverride{PartName: "/xl/externalLinks/" + name, ContentType: ContentTypeSpreadSheetMLExternalLink})
+	}
+}
+
+func TestExternalLinks(t *testing.T) {
+	f := NewFile()
+	links, err := f.GetExternalLinks()
+	assert.NoError(t, err)
+	assert.Empty(t, links)
+	prepareExternalLinks(t, f, "Book2.xlsx", "file:///C:\\Book3.xlsx")
+
+	links, err = f.GetExternalLinks()
+	assert.NoError(t, err)
+	assert.Equal(t, []ExternalLink{
+		{Index: 1, Target: "Book2.xlsx", SheetNames: []string{"Sheet1", "My Sheet"}},
+		{Index: 2, Target: "file:///C:\\Book3.xlsx", SheetNames: []string{"Sheet1", "My Sheet"}},
+	}, links)
+
+	// Test update the path of the linked workbook
+	assert.NoError(t, f.SetExternalLink(2, "Book4.xlsx"))
+	links, err = f.GetExternalLinks()
+	assert.NoError(t, err)
+	assert.Equal(t, "Book4.xlsx", links[1].Target)
+	for _, index := range []int{0, 3} {
+		assert.Equal(t, ErrParameterInvalid, f.SetExternalLink(index, "Book4.xlsx"))
+	}
+	assert.Equal(t, ErrParameterInvalid, f.SetExternalLink(1, ""))
+
+	// Test break the external link
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "[1]Sheet1!A1+1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 2))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].F = &xlsxF{Content: "[1]Sheet1!A1+1"}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "SUM('[2]My Sheet'!$A$1:$B$2)&\"[1]x\""))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "[1]Sheet1!A1*2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "=[1]Sheet1!A1*2", FormulaOpts{Ref: stringPtr("C1:C3"), Type: stringPtr(STCellFormulaTypeShared)}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "a", RefersTo: "[1]Sheet1!$A$1"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "b", RefersTo: "[2]Sheet1!$A$1"}))
+	assert.NoError(t, f.DeleteExternalLink(1))
+	for cell, expected := range map[string]string{"A1": "", "B1": "SUM('[1]My Sheet'!$A$1:$B$2)&\"[1]x\"", "C1": "", "C2": "", "C3": ""} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", val)
+	assert.Equal(t, []DefinedName{
+		{Name: "a", RefersTo: formulaErrorREF, Scope: "Workbook"},
+		{Name: "b", RefersTo: "[1]Sheet1!$A$1", Scope: "Workbook"},
+	}, f.GetDefinedName())
+	links, err = f.GetExternalLinks()
+	assert.NoError(t, err)
+	assert.Equal(t, []ExternalLink{{Index: 1, Target: "Book4.xlsx", SheetNames: []string{"Sheet1", "My Sheet"}}}, links)
+	_, ok = f.Pkg.Load("xl/externalLinks/externalLink1.xml")
+	assert.False(t, ok)
+	assert.Equal(t, ErrParameterInvalid, f.DeleteExternalLink(2))
+	assert.NoError(t, f.DeleteExternalLink(1))
+	links, err = f.GetExternalLinks()
+	assert.NoError(t, err)
+	assert.Empty(t, links)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestExternalLinks.xlsx")))
+
+	// Test external link without external book
+	f = NewFile()
+	prepareExternalLinks(t, f, "Book2.xlsx")
+	f.Pkg.Store("xl/externalLinks/externalLink1.xml", []byte(`<externalLink xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"/>`))
+	links, err = f.GetExternalLinks()
+	assert.NoError(t, err)
+	assert.Equal(t, []ExternalLink{{Index: 1}}, links)
+	assert.Equal(t, ErrParameterInvalid, f.SetExternalLink(1, "Book3.xlsx"))
+
+	// Test external link without relationships
+	f = NewFile()
+	prepareExternalLinks(t, f, "Book2.xlsx")
+	f.Pkg.Delete("xl/externalLinks/_rels/externalLink1.xml.rels")
+	f.Relationships.Delete("xl/externalLinks/_rels/externalLink1.xml.rels")
+	assert.Equal(t, ErrParameterInvalid, f.SetExternalLink(1, "Book3.xlsx"))
+
+	// Test external links with unsupported charset external link part
+	f.Pkg.Store("xl/externalLinks/externalLink1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetExternalLinks()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.SetExternalLink(1, "Book3.xlsx"), "XML syntax error on line 1: invalid UTF-8")
+
+	// Test external links with unsupported charset relationships part
+	f = NewFile()
+	prepareExternalLinks(t, f, "Book2.xlsx")
+	f.Relationships.Delete("xl/externalLinks/_rels/externalLink1.xml.rels")
+	f.Pkg.Store("xl/externalLinks/_rels/externalLink1.xml.rels", MacintoshCyrillicCharset)
+	_, err = f.GetExternalLinks()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.SetExternalLink(1, "Book3.xlsx"), "XML syntax error on line 1: invalid UTF-8")
+
+	// Test external links with unsupported charset worksheet
+	f = NewFile()
+	prepareExternalLinks(t, f, "Book2.xlsx")
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	assert.EqualError(t, f.DeleteExternalLink(1), "XML syntax error on line 1: invalid UTF-8")
+
+	// Test external links with unsupported charset workbook relationships
+	f = NewFile()
+	prepareExternalLinks(t, f, "Book2.xlsx")
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	_, err = f.GetExternalLinks()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.SetExternalLink(1, "Book3.xlsx"), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.DeleteExternalLink(1), "XML syntax error on line 1: invalid UTF-8")
+
+	// Test external links with unsupported charset workbook
+	f = NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetExternalLinks()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestAdjustExternalLinkFormula(t *testing.T) {
+	for _, c := range []struct {
+		formula, expected string
+		broken            bool
+	}{
+		{formula: "[2]Sheet1!A1+[3]!Name", expected: "[1]Sheet1!A1+[2]!Name"},
+		{formula: "'[2]It''s'!A1&'Sheet 1'!A1", expected: "'[1]It''s'!A1&'Sheet 1'!A1"},
+		{formula: "Table1[[#This Row],[a]]+[1]Sheet1!A1", expected: "Table1[[#This Row],[a]]+[1]Sheet1!A1", broken: true},
+		{formula: "[x]Sheet1!A1+[", expected: "[x]Sheet1!A1+["},
+		{formula: "\"", expected: "\""},
+	} {
+		formula, broken := adjustExternalLinkFormula(c.formula, 1)
+		assert.Equal(t, c.expected, formula, c.formula)
+		assert.Equal(t, c.broken, broken, c.formula)
+	}
+}
@@ -14,13 +14,16 @@ package excelize
 import (
 	"archive/zip"
 	"bytes"
+	"compress/flate"
 	"encoding/xml"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 )
 
 // NewFile provides a function to create new file by default template.
@@ -29,9 +32,9 @@ import (
 //	f := NewFile()
 func NewFile(opts ...Options) *File {
 	f := newFile()
-	f.Pkg.Store("_rels/.rels", []byte(xml.Header+templateRels))
+	f.Pkg.Store(defaultXMLPathRels, []byte(xml.Header+templateRels))
 	f.Pkg.Store(defaultXMLPathDocPropsApp, []byte(xml.Header+templateDocpropsApp))
-	f.Pkg.Store(defaultXMLPathDocPropsCore, []byte(xml.Header+templateDocpropsCore))
+	f.Pkg.Store(defaultXMLPathDocPropsCore, []byte(xml.Header+fmt.Sprintf(templateDocpropsCore, time.Now().UTC().Format(time.RFC3339))))
 	f.Pkg.Store(defaultXMLPathWorkbookRels, []byte(xml.Header+templateWorkbookRels))
 	f.Pkg.Store("xl/theme/theme1.xml", []byte(xml.Header+templateTheme))
 	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(xml.Header+templateSheet))
@@ -66,15 +69,23 @@ func (f *File) Save(opts ...Options) error {
 }
 
 // SaveAs provides a function to create or update to a spreadsheet at the
-// provided path.
+// provided path. The content types of the workbook will be updated by the
+// file extension, and the VBA project will be omitted from the output when
+// saving the workbook as the macro-free XLSX or XLTX file, the VBA project of
+// the workbook in memory will be kept. The workbook will be
+// converted to the OpenDocument spreadsheet when saving with the ODS file
+// extension, for example:
+//
+//	err := f.SaveAs("Book1.ods")
 func (f *File) SaveAs(name string, opts ...Options) error {
 	if len(name) > MaxFilePathLength {
 		return ErrMaxFilePathLength
 	}
-	f.Path = name
-	if _, ok := supportedContentTypes[strings.ToLower(filepath.Ext(f.Path))]; !ok {
+	ext := strings.ToLower(filepath.Ext(name))
+	if _, ok := supportedContentTypes[ext]; !ok && ext != ".ods" {
 		return ErrWorkbookFileFormat
 	}
+	f.Path = name
 	file, err := os.OpenFile(filepath.Clean(name), os.O_WRONLY|os.O_TRUNC|os.O_CREATE, os.ModePerm)
 	if err != nil {
 		return err
@@ -83,18 +94,27 @@ func (f *File) SaveAs(name string, opts ...Options) error {
 	return f.Write(file, opts...)
 }
 
-// Close closes and cleanup the open temporary file for the spreadsheet.
+// Close closes and cleanup the open temporary files for the spreadsheet,
+// includes the extracted worksheets and shared string table, and releases
+// the temporary files and in-memory buffers of the stream writers. Close the
+// spreadsheet when it is no longer used to avoid leaking these resources in
+// the long-running programs, and it is safe to call Close more than once.
 func (f *File) Close() error {
 	var err error
 	if f.sharedStringTemp != nil {
 		if err := f.sharedStringTemp.Close(); err != nil {
 			return err
 		}
+		f.sharedStringItem, f.sharedStringTemp = nil, nil
 	}
 	f.tempFiles.Range(func(k, v interface{}) bool {
-		if err = os.Remove(v.(string)); err != nil {
-			return false
+		if removeErr := os.Remove(v.(string)); removeErr != nil {
+			if err == nil {
+				err = removeErr
+			}
+			return true
 		}
+		f.tempFiles.Delete(k)
 		return true
 	})
 	for _, stream := range f.streams {
@@ -114,6 +134,10 @@ func (f *File) WriteTo(w io.Writer, opts ...Options) (int64, error) {
 	for i := range opts {
 		f.options = &opts[i]
 	}
+	if strings.EqualFold(filepath.Ext(f.Path), ".ods") {
+		return f.writeODS(w)
+	}
+	var parts map[string][]byte
 	if len(f.Path) != 0 {
 		contentType, ok := supportedContentTypes[strings.ToLower(filepath.Ext(f.Path))]
 		if !ok {
@@ -122,15 +146,21 @@ func (f *File) WriteTo(w io.Writer, opts ...Options) (int64, error) {
 		if err := f.setContentTypePartProjectExtensions(contentType); err != nil {
 			return 0, err
 		}
+		if contentType == ContentTypeSheetML || contentType == ContentTypeTemplate {
+			var err error
+			if parts, err = f.removeVBAProject(); err != nil {
+				return 0, err
+			}
+		}
 	}
 	if f.options != nil && f.options.Password != "" {
-		buf, err := f.WriteToBuffer()
+		buf, err := f.writeToBuffer(parts)
 		if err != nil {
 			return 0, err
 		}
 		return buf.WriteTo(w)
 	}
-	if err := f.writeDirectToWriter(w); err != nil {
+	if err := f.writeDirectToWriter(w, parts); err != nil {
 		return 0, err
 	}
 	return 0, nil
@@ -139,10 +169,19 @@ func (f *File) WriteTo(w io.Writer, opts ...Options) (int64, error) {
 // WriteToBuffer provides a function to get bytes.Buffer from the saved file,
 // and it allocates space in memory. Be careful when the file size is large.
 func (f *File) WriteToBuffer() (*bytes.Buffer, error) {
-	buf := new(bytes.Buffer)
-	zw := zip.NewWriter(buf)
+	return f.writeToBuffer(nil)
+}
 
-	if err := f.writeToZip(zw); err != nil {
+// writeToBuffer provides a function to get bytes.Buffer from the saved file
+// by given content of the parts to be written instead of the parts in the
+// workbook, the part with the nil content will be omitted.
+func (f *File) writeToBuffer(parts map[string][]byte) (*bytes.Buffer, error) {
+	buf := new(bytes.Buffer)
+	zw, err := f.newZipWriter(buf)
+	if err != nil {
+		return buf, err
+	}
+	if err := f.writeToZip(zw, parts); err != nil {
 		return buf, zw.Close()
 	}
 
@@ -161,18 +200,54 @@ func (f *File) WriteToBuffer() (*bytes.Buffer, error) {
 	return buf, zw.Close()
 }
 
-// writeDirectToWriter provides a function to write to io.Writer.
-func (f *File) writeDirectToWriter(w io.Writer) error {
-	zw := zip.NewWriter(w)
-	if err := f.writeToZip(zw); err != nil {
+// writeDirectToWriter provides a function to write to io.Writer by given
+// content of the parts to be written instead of the parts in the workbook.
+func (f *File) writeDirectToWriter(w io.Writer, parts map[string][]byte) error {
+	zw, err := f.newZipWriter(w)
+	if err != nil {
+		return err
+	}
+	if err := f.writeToZip(zw, parts); err != nil {
 		_ = zw.Close()
 		return err
 	}
 	return zw.Close()
 }
 
-// writeToZip provides a function to write to zip.Writer
-func (f *File) writeToZip(zw *zip.Writer) error {
+// newZipWriter provides a function to create a zip writer which compresses
+// the parts with the compression level specified in the options.
+func (f *File) newZipWriter(w io.Writer) (*zip.Writer, error) {
+	var level int
+	if f.options != nil {
+		level = f.options.CompressionLevel
+	}
+	if level < flate.DefaultCompression || level > flate.BestCompression {
+		return nil, ErrOptionsCompressionLevel
+	}
+	zw := zip.NewWriter(w)
+	if level > 0 {
+		zw.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+			return flate.NewWriter(out, level)
+		})
+	}
+	return zw, nil
+}
+
+// createZipPart provides a function to add a part to the zip writer by given
+// part path, the part will be stored without compression when the StoreOnly
+// option was set.
+func (f *File) createZipPart(zw *zip.Writer, path string) (io.Writer, error) {
+	header := &zip.FileHeader{Name: path, Method: zip.Deflate}
+	if f.options != nil && f.options.StoreOnly {
+		header.Method = zip.Store
+	}
+	return zw.CreateHeader(header)
+}
+
+// writeToZip provides a function to write to zip.Writer by given content of
+// the parts to be written instead of the parts in the workbook, the part with
+// the nil content will be omitted.
+func (f *File) writeToZip(zw *zip.Writer, parts map[string][]byte) error {
 	f.calcChainWriter()
 	f.commentsWriter()
 	f.contentTypesWriter()
@@ -180,15 +255,20 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 	f.volatileDepsWriter()
 	f.vmlDrawingWriter()
 	f.workBookWriter()
-	f.workSheetWriter()
+	if err := f.workSheetWriter(); err != nil {
+		return err
+	}
 	f.relsWriter()
 	_ = f.sharedStringsLoader()
 	f.sharedStringsWriter()
 	f.styleSheetWriter()
 	f.themeWriter()
+	if err := f.signaturesWriter(); err != nil {
+		return err
+	}
 
 	for path, stream := range f.streams {
-		fi, err := zw.Create(path)
+		fi, err := f.createZipPart(zw, path)
 		if err != nil {
 			return err
 		}
@@ -202,39 +282,125 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 		}
 	}
 	var (
-		err              error
-		files, tempFiles []string
+		err                        error
+		files, tempFiles, zipFiles []string
 	)
 	f.Pkg.Range(func(path, content interface{}) bool {
 		if _, ok := f.streams[path.(string)]; ok {
 			return true
 		}
+		if content, ok := parts[path.(string)]; ok && content == nil {
+			return true
+		}
 		files = append(files, path.(string))
 		return true
 	})
 	sort.Sort(sort.Reverse(sort.StringSlice(files)))
 	for _, path := range files {
 		var fi io.Writer
-		if fi, err = zw.Create(path); err != nil {
+		if fi, err = f.createZipPart(zw, path); err != nil {
 			break
 		}
-		content, _ := f.Pkg.Load(path)
-		_, err = fi.Write(content.([]byte))
+		content, ok := parts[path]
+		if !ok {
+			data, _ := f.Pkg.Load(path)
+			content = data.([]byte)
+		}
+		_, err = fi.Write(content)
 	}
 	f.tempFiles.Range(func(path, content interface{}) bool {
 		if _, ok := f.Pkg.Load(path); ok {
 			return true
 		}
+		if _, ok := parts[path.(string)]; ok {
+			return true
+		}
 		tempFiles = append(tempFiles, path.(string))
 		return true
 	})
 	sort.Sort(sort.Reverse(sort.StringSlice(tempFiles)))
 	for _, path := range tempFiles {
 		var fi io.Writer
-		if fi, err = zw.Create(path); err != nil {
+		if fi, err = f.createZipPart(zw, path); err != nil {
+			break
+		}
+		if f.options == nil || !f.options.UseTempFiles {
+			var content []byte
+			if content, err = f.readPart(path, true); err != nil {
+				break
+			}
+			if _, err = fi.Write(content); err != nil {
+				break
+			}
+			continue
+		}
+		if err = f.copyTempFile(fi, path); err != nil {
 			break
 		}
-		_, err = fi.Write(f.readBytes(path))
 	}
+	f.zipFiles.Range(func(path, file interface{}) bool {
+		if _, ok := f.Pkg.Load(path); ok {
+			return true
+		}
+		if _, ok := parts[path.(string)]; ok {
+			return true
+		}
+		zipFiles = append(zipFiles, path.(string))
+		return true
+	})
+	sort.Sort(sort.Reverse(sort.StringSlice(zipFiles)))
+	for _, path := range zipFiles {
+		if err != nil {
+			break
+		}
+		if file, ok := f.zipFiles.Load(path); ok {
+			err = f.copyZipFile(zw, path, file.(*zip.File))
+		}
+	}
+	return err
+}
+
+// copyTempFile provides a function to copy the content of the part which was
+// saved in the system temporary directory to the writer by given part path.
+func (f *File) copyTempFile(w io.Writer, path string) error {
+	file, err := f.readTemp(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(w, file)
+	return err
+}
+
+// copyZipFile provides a function to copy the compressed data of the part
+// which has not been unzipped from the original workbook to the zip writer by
+// given part path, without decompressing and recompressing it. The part will
+// be decompressed and recompressed if a non-default compression level or the
+// StoreOnly option was specified.
+func (f *File) copyZipFile(zw *zip.Writer, path string, file *zip.File) error {
+	if f.options != nil && (f.options.CompressionLevel > 0 || f.options.StoreOnly) {
+		fi, err := f.createZipPart(zw, path)
+		if err != nil {
+			return err
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+		_, err = io.Copy(fi, rc)
+		return err
+	}
+	header := file.FileHeader
+	header.Name = path
+	fi, err := zw.CreateRaw(&header)
+	if err != nil {
+		return err
+	}
+	rc, err := file.OpenRaw()
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fi, rc)
 	return err
 }
@@ -1,8 +1,11 @@
 package excelize
 
 import (
+	"archive/zip"
 	"bufio"
 	"bytes"
+	"compress/flate"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -94,4 +97,104 @@ func TestClose(t *testing.T) {
 	f := NewFile()
 	f.tempFiles.Store("/d/", "/d/")
 	require.Error(t, f.Close())
+
+	// Test close the spreadsheet with temporary files repeatedly
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipXMLSizeLimit: 128})
+	require.NoError(t, err)
+	sw, err := f.NewStreamWriter("Sheet2")
+	require.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"A1"}))
+	sw.rawData.tmp, err = os.CreateTemp(os.TempDir(), "excelize-")
+	require.NoError(t, err)
+	assert.NoError(t, sw.Flush())
+	tempFiles := []string{sw.rawData.tmp.Name()}
+	f.tempFiles.Range(func(k, v interface{}) bool {
+		tempFiles = append(tempFiles, v.(string))
+		return true
+	})
+	assert.Len(t, tempFiles, 4)
+	// Test save the spreadsheet repeatedly before close
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, f.SaveAs(filepath.Join("test", "TestClose.xlsx")))
+	}
+	assert.NoError(t, f.Close())
+	for _, path := range tempFiles {
+		_, err = os.Stat(path)
+		assert.True(t, os.IsNotExist(err), path)
+	}
+	assert.Zero(t, sw.rawData.buf.Len())
+	assert.NoError(t, f.Close())
+
+	// Test save the spreadsheet with unsupported file extension will not
+	// change the path of the spreadsheet
+	f = NewFile()
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestClose.xlsx")))
+	assert.Equal(t, ErrWorkbookFileFormat, f.SaveAs(filepath.Join("test", "TestClose.txt")))
+	assert.Equal(t, filepath.Join("test", "TestClose.xlsx"), f.Path)
+	assert.NoError(t, f.Save())
+	assert.NoError(t, f.Close())
+}
+
+func TestWriteToCompressionLevel(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	require.NoError(t, err)
+	sizes := make(map[string]int)
+	for name, opts := range map[string]Options{
+		"store":   {StoreOnly: true, CompressionLevel: flate.BestCompression},
+		"default": {CompressionLevel: flate.DefaultCompression},
+		"zero":    {},
+		"speed":   {CompressionLevel: flate.BestSpeed},
+		"best":    {CompressionLevel: flate.BestCompression},
+	} {
+		buf := new(bytes.Buffer)
+		assert.NoError(t, f.Write(buf, opts))
+		sizes[name] = buf.Len()
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		assert.NoError(t, err)
+		for _, file := range zr.File {
+			if opts.StoreOnly {
+				assert.Equal(t, zip.Store, file.Method, file.Name)
+				continue
+			}
+			assert.Equal(t, zip.Deflate, file.Method, file.Name)
+		}
+		// Test open the workbook written with the compression level
+		wb, err := OpenReader(buf)
+		assert.NoError(t, err)
+		rows, err := wb.GetRows("Sheet2")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, rows)
+		assert.NoError(t, wb.Close())
+	}
+	assert.Greater(t, sizes["store"], sizes["speed"])
+	assert.GreaterOrEqual(t, sizes["speed"], sizes["best"])
+	assert.Equal(t, sizes["zero"], sizes["default"])
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestWriteToCompressionLevel.xlsx"), Options{CompressionLevel: flate.BestCompression}))
+	// Test write with invalid compression level
+	for _, level := range []int{flate.HuffmanOnly, 10} {
+		assert.Equal(t, ErrOptionsCompressionLevel, f.Write(new(bytes.Buffer), Options{CompressionLevel: level}))
+		_, err = f.WriteToBuffer()
+		assert.Equal(t, ErrOptionsCompressionLevel, err)
+	}
+	assert.NoError(t, f.Close())
+	// Test open workbook with invalid compression level
+	for _, level := range []int{flate.HuffmanOnly, 10} {
+		_, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{CompressionLevel: level})
+		assert.Equal(t, ErrOptionsCompressionLevel, err)
+	}
+	// Test write as ODS with invalid compression level
+	f = NewFile()
+	f.Path = "Book1.ods"
+	assert.Equal(t, ErrOptionsCompressionLevel, f.Write(new(bytes.Buffer), Options{CompressionLevel: 10}))
+	// Test copy the part which has not been unzipped with unsupported
+	// compression method
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	_, err = zw.CreateRaw(&zip.FileHeader{Name: "xl/worksheets/sheet2.xml", Method: 99})
+	assert.NoError(t, err)
+	assert.NoError(t, zw.Close())
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	f.options.CompressionLevel = flate.BestSpeed
+	assert.Equal(t, zip.ErrAlgorithm, f.copyZipFile(zip.NewWriter(io.Discard), "xl/worksheets/sheet2.xml", zr.File[0]))
 }
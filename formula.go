@@ -0,0 +1,723 @@
+// Copyright 2016 - 2025 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.20 or later.
+
+package excelize
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/xuri/efp"
+)
+
+// FormulaTokenType is the type of formula token.
+type FormulaTokenType byte
+
+// Formula token types enumeration.
+const (
+	FormulaTokenTypeUnknown FormulaTokenType = iota
+	FormulaTokenTypeOperand
+	FormulaTokenTypeFunction
+	FormulaTokenTypeSubexpression
+	FormulaTokenTypeArgument
+	FormulaTokenTypeOperatorPrefix
+	FormulaTokenTypeOperatorInfix
+	FormulaTokenTypeOperatorPostfix
+	FormulaTokenTypeWhitespace
+)
+
+// FormulaTokenSubType is the sub type of formula token.
+type FormulaTokenSubType byte
+
+// Formula token sub types enumeration.
+const (
+	FormulaTokenSubTypeNone FormulaTokenSubType = iota
+	FormulaTokenSubTypeStart
+	FormulaTokenSubTypeStop
+	FormulaTokenSubTypeText
+	FormulaTokenSubTypeNumber
+	FormulaTokenSubTypeLogical
+	FormulaTokenSubTypeError
+	FormulaTokenSubTypeRange
+	FormulaTokenSubTypeMath
+	FormulaTokenSubTypeConcatenation
+	FormulaTokenSubTypeIntersection
+	FormulaTokenSubTypeUnion
+)
+
+// formulaTokenTypes defined the formula token types mapping from the formula
+// parser.
+var formulaTokenTypes = map[string]FormulaTokenType{
+	efp.TokenTypeOperand:         FormulaTokenTypeOperand,
+	efp.TokenTypeFunction:        FormulaTokenTypeFunction,
+	efp.TokenTypeSubexpression:   FormulaTokenTypeSubexpression,
+	efp.TokenTypeArgument:        FormulaTokenTypeArgument,
+	efp.TokenTypeOperatorPrefix:  FormulaTokenTypeOperatorPrefix,
+	efp.TokenTypeOperatorInfix:   FormulaTokenTypeOperatorInfix,
+	efp.TokenTypeOperatorPostfix: FormulaTokenTypeOperatorPostfix,
+	efp.TokenTypeWhitespace:      FormulaTokenTypeWhitespace,
+}
+
+// formulaTokenSubTypes defined the formula token sub types mapping from the
+// formula parser.
+var formulaTokenSubTypes = map[string]FormulaTokenSubType{
+	efp.TokenSubTypeStart:         FormulaTokenSubTypeStart,
+	efp.TokenSubTypeStop:          FormulaTokenSubTypeStop,
+	efp.TokenSubTypeText:          FormulaTokenSubTypeText,
+	efp.TokenSubTypeNumber:        FormulaTokenSubTypeNumber,
+	efp.TokenSubTypeLogical:       FormulaTokenSubTypeLogical,
+	efp.TokenSubTypeError:         FormulaTokenSubTypeError,
+	efp.TokenSubTypeRange:         FormulaTokenSubTypeRange,
+	efp.TokenSubTypeMath:          FormulaTokenSubTypeMath,
+	efp.TokenSubTypeConcatenation: FormulaTokenSubTypeConcatenation,
+	efp.TokenSubTypeIntersection:  FormulaTokenSubTypeIntersection,
+	efp.TokenSubTypeUnion:         FormulaTokenSubTypeUnion,
+}
+
+// FormulaToken directly maps the token of the formula. The Value is the text
+// of the token, such as the function name for the function start token, the
+// reference or defined name for the range operand token, the unquoted text for
+// the text operand token, and the operator for the operator token, the value
+// of the intersection operator token is a space.
+type FormulaToken struct {
+	Type    FormulaTokenType
+	SubType FormulaTokenSubType
+	Value   string
+}
+
+// ParseFormula provides a function to parse the formula into a list of
+// tokens, which could be used for analyzing the references, functions and
+// operators in the formula, or validating the formula before writing it into
+// the cell. The leading equal sign of the formula is optional. The references
+// operand tokens with sub type FormulaTokenSubTypeRange contain the cell
+// references, range references, defined names and the structured references,
+// the quotes around the worksheet name will be removed. The array constants
+// will be parsed as the function ARRAY and ARRAYROW. Use the RenderFormula
+// function to build the formula from the tokens. It returns
+// ErrInvalidFormula if the parentheses or braces in the formula are
+// unbalanced. For example, get the tokens of the formula
+// =SUM(A1:B2,Sheet2!C3)*2:
+//
+//	tokens, err := excelize.ParseFormula("=SUM(A1:B2,Sheet2!C3)*2")
+//
+// The tokens will be:
+//
+//	Type                          | SubType                     | Value
+//	------------------------------+-----------------------------+-----------
+//	FormulaTokenTypeFunction      | FormulaTokenSubTypeStart    | SUM
+//	FormulaTokenTypeOperand       | FormulaTokenSubTypeRange    | A1:B2
+//	FormulaTokenTypeArgument      | FormulaTokenSubTypeNone     | ,
+//	FormulaTokenTypeOperand       | FormulaTokenSubTypeRange    | Sheet2!C3
+//	FormulaTokenTypeFunction      | FormulaTokenSubTypeStop     |
+//	FormulaTokenTypeOperatorInfix | FormulaTokenSubTypeMath     | *
+//	FormulaTokenTypeOperand       | FormulaTokenSubTypeNumber   | 2
+func ParseFormula(formula string) ([]FormulaToken, error) {
+	ps := efp.ExcelParser()
+	var (
+		tokens []FormulaToken
+		depth  int
+	)
+	for _, token := range ps.Parse(strings.TrimSpace(formula)) {
+		formulaToken := FormulaToken{
+			Type:    formulaTokenTypes[token.TType],
+			SubType: formulaTokenSubTypes[token.TSubType],
+			Value:   token.TValue,
+		}
+		if formulaToken.SubType == FormulaTokenSubTypeIntersection {
+			formulaToken.Value = " "
+		}
+		if formulaToken.Type == FormulaTokenTypeFunction || formulaToken.Type == FormulaTokenTypeSubexpression {
+			if formulaToken.SubType == FormulaTokenSubTypeStart {
+				depth++
+			}
+			if formulaToken.SubType == FormulaTokenSubTypeStop {
+				if depth--; depth < 0 {
+					return tokens, ErrInvalidFormula
+				}
+			}
+		}
+		tokens = append(tokens, formulaToken)
+	}
+	if depth != 0 {
+		return tokens, ErrInvalidFormula
+	}
+	return tokens, nil
+}
+
+// RenderFormula provides a function to build the formula by given formula
+// tokens, which could be used to write the formula back into the cell after
+// analyzing or modifying the tokens returned by the ParseFormula function.
+// The worksheet names in the references will be quoted when needed, the text
+// operands will be quoted, the functions ARRAY and ARRAYROW will be rendered
+// as the array constants, and the leading equal sign will not be added. For
+// example, rename the worksheet in the references of the formula:
+//
+//	tokens, err := excelize.ParseFormula("SUM('My Sheet'!A1:B2)")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for i, token := range tokens {
+//	    if token.SubType == excelize.FormulaTokenSubTypeRange {
+//	        tokens[i].Value = strings.Replace(token.Value, "My Sheet!", "Sheet 2!", 1)
+//	    }
+//	}
+//	formula := excelize.RenderFormula(tokens)
+func RenderFormula(tokens []FormulaToken) string {
+	var (
+		buf   strings.Builder
+		stack []string
+	)
+	for _, token := range tokens {
+		switch token.Type {
+		case FormulaTokenTypeFunction, FormulaTokenTypeSubexpression:
+			if token.SubType == FormulaTokenSubTypeStart {
+				name := ""
+				if token.Type == FormulaTokenTypeFunction {
+					name = token.Value
+				}
+				stack = append(stack, name)
+				switch name {
+				case "ARRAY":
+					buf.WriteString("{")
+				case "ARRAYROW":
+				default:
+					buf.WriteString(name + "(")
+				}
+				continue
+			}
+			var name string
+			if len(stack) > 0 {
+				name, stack = stack[len(stack)-1], stack[:len(stack)-1]
+			}
+			switch name {
+			case "ARRAY":
+				buf.WriteString("}")
+			case "ARRAYROW":
+			default:
+				buf.WriteString(")")
+			}
+		case FormulaTokenTypeArgument:
+			if len(stack) > 0 && stack[len(stack)-1] == "ARRAY" {
+				buf.WriteString(";")
+				continue
+			}
+			buf.WriteString(",")
+		case FormulaTokenTypeOperand:
+			buf.WriteString(renderFormulaOperand(token))
+		default:
+			buf.WriteString(token.Value)
+		}
+	}
+	return buf.String()
+}
+
+// renderFormulaOperand returns the text of the operand token in the formula,
+// the text will be quoted and the worksheet name in the reference will be
+// quoted when needed.
+func renderFormulaOperand(token FormulaToken) string {
+	switch token.SubType {
+	case FormulaTokenSubTypeText:
+		return "\"" + strings.ReplaceAll(token.Value, "\"", "\"\"") + "\""
+	case FormulaTokenSubTypeRange:
+		if idx := strings.LastIndex(token.Value, "!"); idx != -1 {
+			return escapeSheetName(token.Value[:idx]) + token.Value[idx:]
+		}
+	}
+	return token.Value
+}
+
+// GetCellPrecedents provides a function to get the references which directly
+// referred by the formula of the cell by given worksheet name and cell
+// reference, which could be used for analyzing the impact of the cells. Each
+// reference will be returned with the worksheet name, such as Sheet1!A1,
+// Sheet1!A1:B2, Sheet1!A:A or Sheet1!1:1, the worksheet names will be
+// returned as they are in the workbook, the 3D references, such as
+// Sheet1:Sheet3!A1, will be expanded to the references on each worksheet, and
+// the defined names in the formula will be resolved to the references they
+// referred to. The structured references and the references to the external
+// workbooks will be returned as is. It returns an empty list if the cell has
+// no formula. For example, get the precedents of the cell C1 on Sheet1:
+//
+//	precedents, err := f.GetCellPrecedents("Sheet1", "C1")
+func (f *File) GetCellPrecedents(sheet, cell string) ([]string, error) {
+	formula, err := f.getCellFormula(sheet, cell, true)
+	if err != nil || formula == "" {
+		return nil, err
+	}
+	var precedents []string
+	for _, ref := range f.getFormulaRefs(sheet, formula, f.GetSheetList(), map[string]bool{}) {
+		precedents = append(precedents, ref.String())
+	}
+	return precedents, err
+}
+
+// GetCellDependents provides a function to get the formula cells which
+// directly refer to the cell by given worksheet name and cell reference
+// across all worksheets in the workbook, which could be used for analyzing the
+// impact of changing the cell. Each dependent cell will be returned with the
+// worksheet name, such as Sheet1!C1. The worksheet names are matched case
+// insensitively. The references of the formulas are cached in the workbook,
+// and the cache will be rebuilt when the worksheets or defined names were
+// changed. For example, get the dependents of the cell A1 on Sheet1:
+//
+//	dependents, err := f.GetCellDependents("Sheet1", "A1")
+func (f *File) GetCellDependents(sheet, cell string) ([]string, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = f.workSheetReader(sheet); err != nil {
+		return nil, err
+	}
+	if !f.formulaChecked {
+		if err = f.setArrayFormulaCells(); err != nil {
+			return nil, err
+		}
+		f.formulaChecked = true
+	}
+	var dependents []string
+	sheetList := f.GetSheetList()
+	cache := f.formulaRefsCacheReader(sheetList)
+	for _, sheetN := range sheetList {
+		ws, err := f.workSheetReader(sheetN)
+		if err != nil {
+			if err.Error() == newNotWorksheetError(sheetN).Error() {
+				continue
+			}
+			return dependents, err
+		}
+		var cells []string
+		ws.mu.Lock()
+		for _, r := range ws.SheetData.Row {
+			for _, c := range r.C {
+				if c.F != nil || c.f != "" {
+					cells = append(cells, c.R)
+				}
+			}
+		}
+		ws.mu.Unlock()
+		for _, formulaCell := range cells {
+			formula, err := f.getCellFormula(sheetN, formulaCell, true)
+			if err != nil {
+				return dependents, err
+			}
+			for _, ref := range cache.load(f, sheetN, formula, sheetList) {
+				if ref.coordinates == nil || !strings.EqualFold(ref.sheet, sheet) {
+					continue
+				}
+				if coordinates := ref.coordinates; coordinates[0] <= col && col <= coordinates[2] &&
+					coordinates[1] <= row && row <= coordinates[3] {
+					dependents = append(dependents, escapeSheetName(sheetN)+"!"+formulaCell)
+					break
+				}
+			}
+		}
+	}
+	return dependents, err
+}
+
+// formulaRef directly maps the reference in the formula. The sheet is the
+// worksheet name of the reference, and the coordinates is the sorted range
+// coordinates of the reference. The sheet and coordinates are empty for the
+// reference which could not be resolved, such as the structured reference.
+type formulaRef struct {
+	sheet, ref  string
+	coordinates []int
+}
+
+// String returns the reference with the worksheet name.
+func (r formulaRef) String() string {
+	if r.sheet == "" {
+		return r.ref
+	}
+	return escapeSheetName(r.sheet) + "!" + r.ref
+}
+
+// formulaRefsCache directly maps the references of the formulas in the
+// workbook. The key is generated by the worksheet names and defined names of
+// the workbook, which the references depend on.
+type formulaRefsCache struct {
+	mu   sync.Mutex
+	key  string
+	refs map[string][]formulaRef
+}
+
+// formulaRefsCacheReader provides a function to get the cache of the formula
+// references by given worksheet names, the cache will be reset if the
+// worksheets or defined names of the workbook were changed.
+func (f *File) formulaRefsCacheReader(sheetList []string) *formulaRefsCache {
+	var key strings.Builder
+	for _, name := range sheetList {
+		key.WriteString(name + "\x00")
+	}
+	for _, dn := range f.GetDefinedName() {
+		key.WriteString(dn.Name + "\x00" + dn.Scope + "\x00" + dn.RefersTo + "\x00")
+	}
+	f.formulaRefs.mu.Lock()
+	defer f.formulaRefs.mu.Unlock()
+	if f.formulaRefs.refs == nil || f.formulaRefs.key != key.String() {
+		f.formulaRefs.key, f.formulaRefs.refs = key.String(), map[string][]formulaRef{}
+	}
+	return &f.formulaRefs
+}
+
+// load provides a function to get the references of the formula from the
+// cache by given worksheet name, formula and worksheet names of the workbook,
+// the formula will be parsed if the references not exist in the cache.
+func (c *formulaRefsCache) load(f *File, sheet, formula string, sheetList []string) []formulaRef {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := sheet + "!" + formula
+	refs, ok := c.refs[key]
+	if !ok {
+		refs = f.getFormulaRefs(sheet, formula, sheetList, map[string]bool{})
+		c.refs[key] = refs
+	}
+	return refs
+}
+
+// getFormulaRefs returns the references with worksheet name in the formula by
+// given worksheet name, formula and worksheet names of the workbook. The
+// worksheet names in the references will be matched case insensitively, the
+// 3D references will be expanded to each worksheet, the defined names will be
+// resolved to the references they referred to, and the visited defined names
+// will be skipped to avoid circular reference.
+func (f *File) getFormulaRefs(sheet, formula string, sheetList []string, visited map[string]bool) []formulaRef {
+	var refs []formulaRef
+	appendRef := func(ref formulaRef) {
+		for _, r := range refs {
+			if r.sheet == ref.sheet && r.ref == ref.ref {
+				return
+			}
+		}
+		refs = append(refs, ref)
+	}
+	getSheetIndex := func(name string) int {
+		for idx, sheetName := range sheetList {
+			if strings.EqualFold(sheetName, name) {
+				return idx
+			}
+		}
+		return -1
+	}
+	tokens, _ := ParseFormula(formula)
+	for _, token := range tokens {
+		if token.Type != FormulaTokenTypeOperand || token.SubType != FormulaTokenSubTypeRange {
+			continue
+		}
+		sheetN, ref := sheet, token.Value
+		idx := strings.LastIndex(ref, "!")
+		if idx != -1 {
+			sheetN, ref = ref[:idx], ref[idx+1:]
+		}
+		ref = strings.ReplaceAll(ref, "$", "")
+		if coordinates, err := formulaRefToCoordinates(ref); err == nil {
+			first, last := sheetN, sheetN
+			if names := strings.Split(sheetN, ":"); len(names) == 2 {
+				first, last = names[0], names[1]
+			}
+			start, end := getSheetIndex(first), getSheetIndex(last)
+			if start == -1 || end == -1 {
+				appendRef(formulaRef{ref: escapeSheetName(sheetN) + "!" + ref})
+				continue
+			}
+			if start > end {
+				start, end = end, start
+			}
+			for i := start; i <= end; i++ {
+				appendRef(formulaRef{sheet: sheetList[i], ref: ref, coordinates: coordinates})
+			}
+			continue
+		}
+		if idx == -1 {
+			if visited[ref] {
+				continue
+			}
+			if refTo := f.getDefinedNameRefTo(ref, sheet); refTo != "" {
+				visited[ref] = true
+				for _, definedNameRef := range f.getFormulaRefs(sheet, refTo, sheetList, visited) {
+					appendRef(definedNameRef)
+				}
+				continue
+			}
+		}
+		appendRef(formulaRef{ref: token.Value})
+	}
+	return refs
+}
+
+// formulaRefToCoordinates converts the cell reference, range reference, whole
+// column or whole row reference without worksheet name to the sorted range
+// coordinates.
+func formulaRefToCoordinates(ref string) ([]int, error) {
+	parts := strings.Split(ref, ":")
+	if len(parts) == 1 {
+		col, row, err := CellNameToCoordinates(ref)
+		return []int{col, row, col, row}, err
+	}
+	if len(parts) != 2 {
+		return nil, ErrParameterInvalid
+	}
+	coordinates, err := cellRefsToCoordinates(parts[0], parts[1])
+	if err != nil {
+		col1, err1 := ColumnNameToNumber(parts[0])
+		col2, err2 := ColumnNameToNumber(parts[1])
+		if err1 == nil && err2 == nil {
+			coordinates, err = []int{col1, 1, col2, TotalRows}, nil
+		}
+		row1, err1 := strconv.Atoi(parts[0])
+		row2, err2 := strconv.Atoi(parts[1])
+		if err1 == nil && err2 == nil && row1 > 0 && row2 > 0 && row1 <= TotalRows && row2 <= TotalRows {
+			coordinates, err = []int{1, row1, MaxColumns, row2}, nil
+		}
+	}
+	if err == nil {
+		_ = sortCoordinates(coordinates)
+	}
+	return coordinates, err
+}
+
+// formulaFutureFunctions defined the functions which introduced in the newer
+// versions of the spreadsheet application and the prefix of them.
+var formulaFutureFunctions = map[string]string{
+	"ACOT": "_xlfn.", "ACOTH": "_xlfn.", "AGGREGATE": "_xlfn.",
+	"ANCHORARRAY": "_xlfn.", "ARABIC": "_xlfn.", "ARRAYTOTEXT": "_xlfn.",
+	"BASE": "_xlfn.", "BETA.DIST": "_xlfn.", "BETA.INV": "_xlfn.",
+	"BINOM.DIST": "_xlfn.", "BINOM.DIST.RANGE": "_xlfn.",
+	"BINOM.INV": "_xlfn.", "BITAND": "_xlfn.", "BITLSHIFT": "_xlfn.",
+	"BITOR": "_xlfn.", "BITRSHIFT": "_xlfn.", "BITXOR": "_xlfn.",
+	"BYCOL": "_xlfn.", "BYROW": "_xlfn.", "CEILING.MATH": "_xlfn.",
+	"CEILING.PRECISE": "_xlfn.", "CHISQ.DIST": "_xlfn.",
+	"CHISQ.DIST.RT": "_xlfn.", "CHISQ.INV": "_xlfn.",
+	"CHISQ.INV.RT": "_xlfn.", "CHISQ.TEST": "_xlfn.",
+	"CHOOSECOLS": "_xlfn.", "CHOOSEROWS": "_xlfn.", "COMBINA": "_xlfn.",
+	"CONCAT": "_xlfn.", "CONFIDENCE.NORM": "_xlfn.",
+	"CONFIDENCE.T": "_xlfn.", "COT": "_xlfn.", "COTH": "_xlfn.",
+	"COVARIANCE.P": "_xlfn.", "COVARIANCE.S": "_xlfn.", "CSC": "_xlfn.",
+	"CSCH": "_xlfn.", "DAYS": "_xlfn.", "DBCS": "_xlfn.",
+	"DECIMAL": "_xlfn.", "DETECTLANGUAGE": "_xlfn.", "DISPIMG": "_xlfn.",
+	"DROP": "_xlfn.", "ECMA.CEILING": "_xlfn.", "ENCODEURL": "_xlfn.",
+	"ERF.PRECISE": "_xlfn.", "ERFC.PRECISE": "_xlfn.", "EXPAND": "_xlfn.",
+	"EXPON.DIST": "_xlfn.", "F.DIST": "_xlfn.", "F.DIST.RT": "_xlfn.",
+	"F.INV": "_xlfn.", "F.INV.RT": "_xlfn.", "F.TEST": "_xlfn.",
+	"FIELDVALUE": "_xlfn.", "FILTER": "_xlfn._xlws.", "FILTERXML": "_xlfn.",
+	"FLOOR.MATH": "_xlfn.", "FLOOR.PRECISE": "_xlfn.",
+	"FORECAST.ETS": "_xlfn.", "FORECAST.ETS.CONFINT": "_xlfn.",
+	"FORECAST.ETS.SEASONALITY": "_xlfn.", "FORECAST.ETS.STAT": "_xlfn.",
+	"FORECAST.LINEAR": "_xlfn.", "FORMULATEXT": "_xlfn.", "GAMMA": "_xlfn.",
+	"GAMMA.DIST": "_xlfn.", "GAMMA.INV": "_xlfn.",
+	"GAMMALN.PRECISE": "_xlfn.", "GAUSS": "_xlfn.", "GROUPBY": "_xlfn.",
+	"HSTACK": "_xlfn.", "HYPGEOM.DIST": "_xlfn.", "IFNA": "_xlfn.",
+	"IFS": "_xlfn.", "IMAGE": "_xlfn.", "IMCOSH": "_xlfn.",
+	"IMCOT": "_xlfn.", "IMCSC": "_xlfn.", "IMCSCH": "_xlfn.",
+	"IMSEC": "_xlfn.", "IMSECH": "_xlfn.", "IMSINH": "_xlfn.",
+	"IMTAN": "_xlfn.", "ISFORMULA": "_xlfn.", "ISO.CEILING": "_xlfn.",
+	"ISOMITTED": "_xlfn.", "ISOWEEKNUM": "_xlfn.", "LOGNORM.DIST": "_xlfn.",
+	"LOGNORM.INV": "_xlfn.", "MAKEARRAY": "_xlfn.", "MAP": "_xlfn.",
+	"MAXIFS": "_xlfn.", "MINIFS": "_xlfn.", "MODE.MULT": "_xlfn.",
+	"MODE.SNGL": "_xlfn.", "MUNIT": "_xlfn.", "NEGBINOM.DIST": "_xlfn.",
+	"NETWORKDAYS.INTL": "_xlfn.", "NORM.DIST": "_xlfn.",
+	"NORM.INV": "_xlfn.", "NORM.S.DIST": "_xlfn.", "NORM.S.INV": "_xlfn.",
+	"NUMBERVALUE": "_xlfn.", "PDURATION": "_xlfn.",
+	"PERCENTILE.EXC": "_xlfn.", "PERCENTILE.INC": "_xlfn.",
+	"PERCENTOF": "_xlfn.", "PERCENTRANK.EXC": "_xlfn.",
+	"PERCENTRANK.INC": "_xlfn.", "PERMUTATIONA": "_xlfn.", "PHI": "_xlfn.",
+	"PIVOTBY": "_xlfn.", "POISSON.DIST": "_xlfn.", "QUARTILE.EXC": "_xlfn.",
+	"QUARTILE.INC": "_xlfn.", "RANDARRAY": "_xlfn.", "RANK.AVG": "_xlfn.",
+	"RANK.EQ": "_xlfn.", "REDUCE": "_xlfn.", "REGEXEXTRACT": "_xlfn.",
+	"REGEXREPLACE": "_xlfn.", "REGEXTEST": "_xlfn.", "RRI": "_xlfn.",
+	"SCAN": "_xlfn.", "SEC": "_xlfn.", "SECH": "_xlfn.",
+	"SEQUENCE": "_xlfn.", "SHEET": "_xlfn.", "SHEETS": "_xlfn.",
+	"SINGLE": "_xlfn.", "SKEW.P": "_xlfn.", "SORT": "_xlfn._xlws.",
+	"SORTBY": "_xlfn.", "STDEV.P": "_xlfn.", "STDEV.S": "_xlfn.",
+	"STOCKHISTORY": "_xlfn.", "SWITCH": "_xlfn.", "T.DIST": "_xlfn.",
+	"T.DIST.2T": "_xlfn.", "T.DIST.RT": "_xlfn.", "T.INV": "_xlfn.",
+	"T.INV.2T": "_xlfn.", "T.TEST": "_xlfn.", "TAKE": "_xlfn.",
+	"TEXTAFTER": "_xlfn.", "TEXTBEFORE": "_xlfn.", "TEXTJOIN": "_xlfn.",
+	"TEXTSPLIT": "_xlfn.", "TOCOL": "_xlfn.", "TOROW": "_xlfn.",
+	"TRANSLATE": "_xlfn.", "TRIMRANGE": "_xlfn.", "UNICHAR": "_xlfn.",
+	"UNICODE": "_xlfn.", "UNIQUE": "_xlfn.", "VALUETOTEXT": "_xlfn.",
+	"VAR.P": "_xlfn.", "VAR.S": "_xlfn.", "VSTACK": "_xlfn.",
+	"WEBSERVICE": "_xlfn.", "WEIBULL.DIST": "_xlfn.",
+	"WORKDAY.INTL": "_xlfn.", "WRAPCOLS": "_xlfn.", "WRAPROWS": "_xlfn.",
+	"XLOOKUP": "_xlfn.", "XMATCH": "_xlfn.", "XOR": "_xlfn.",
+	"Z.TEST": "_xlfn.",
+}
+
+// formulaBuiltinFunctions defined the built-in functions of the spreadsheet
+// application which don't require the prefix. The LET and LAMBDA functions
+// will not be prefixed automatically, because the names of their parameters
+// require the _xlpm. prefix as well.
+var formulaBuiltinFunctions = map[string]bool{
+	"ABS": true, "ACCRINT": true, "ACCRINTM": true, "ACOS": true,
+	"ACOSH": true, "ADDRESS": true, "AMORDEGRC": true, "AMORLINC": true,
+	"AND": true, "AREAS": true, "ASC": true, "ASIN": true, "ASINH": true,
+	"ATAN": true, "ATAN2": true, "ATANH": true, "AVEDEV": true,
+	"AVERAGE": true, "AVERAGEA": true, "AVERAGEIF": true,
+	"AVERAGEIFS": true, "BAHTTEXT": true, "BESSELI": true, "BESSELJ": true,
+	"BESSELK": true, "BESSELY": true, "BETADIST": true, "BETAINV": true,
+	"BIN2DEC": true, "BIN2HEX": true, "BIN2OCT": true, "BINOMDIST": true,
+	"CALL": true, "CEILING": true, "CELL": true, "CHAR": true,
+	"CHIDIST": true, "CHIINV": true, "CHITEST": true, "CHOOSE": true,
+	"CLEAN": true, "CODE": true, "COLUMN": true, "COLUMNS": true,
+	"COMBIN": true, "COMPLEX": true, "CONCATENATE": true,
+	"CONFIDENCE": true, "CONVERT": true, "CORREL": true, "COS": true,
+	"COSH": true, "COUNT": true, "COUNTA": true, "COUNTBLANK": true,
+	"COUNTIF": true, "COUNTIFS": true, "COUPDAYBS": true, "COUPDAYS": true,
+	"COUPDAYSNC": true, "COUPNCD": true, "COUPNUM": true, "COUPPCD": true,
+	"COVAR": true, "CRITBINOM": true, "CUBEKPIMEMBER": true,
+	"CUBEMEMBER": true, "CUBEMEMBERPROPERTY": true,
+	"CUBERANKEDMEMBER": true, "CUBESET": true, "CUBESETCOUNT": true,
+	"CUBEVALUE": true, "CUMIPMT": true, "CUMPRINC": true, "DATE": true,
+	"DATEDIF": true, "DATEVALUE": true, "DAVERAGE": true, "DAY": true,
+	"DAYS360": true, "DB": true, "DCOUNT": true, "DCOUNTA": true,
+	"DDB": true, "DEC2BIN": true, "DEC2HEX": true, "DEC2OCT": true,
+	"DEGREES": true, "DELTA": true, "DEVSQ": true, "DGET": true,
+	"DISC": true, "DMAX": true, "DMIN": true, "DOLLAR": true,
+	"DOLLARDE": true, "DOLLARFR": true, "DPRODUCT": true, "DSTDEV": true,
+	"DSTDEVP": true, "DSUM": true, "DURATION": true, "DVAR": true,
+	"DVARP": true, "EDATE": true, "EFFECT": true, "EOMONTH": true,
+	"ERF": true, "ERFC": true, "ERROR.TYPE": true, "EUROCONVERT": true,
+	"EVEN": true, "EXACT": true, "EXP": true, "EXPONDIST": true,
+	"FACT": true, "FACTDOUBLE": true, "FALSE": true, "FDIST": true,
+	"FIND": true, "FINDB": true, "FINV": true, "FISHER": true,
+	"FISHERINV": true, "FIXED": true, "FLOOR": true, "FORECAST": true,
+	"FREQUENCY": true, "FTEST": true, "FV": true, "FVSCHEDULE": true,
+	"GAMMADIST": true, "GAMMAINV": true, "GAMMALN": true, "GCD": true,
+	"GEOMEAN": true, "GESTEP": true, "GETPIVOTDATA": true, "GROWTH": true,
+	"HARMEAN": true, "HEX2BIN": true, "HEX2DEC": true, "HEX2OCT": true,
+	"HLOOKUP": true, "HOUR": true, "HYPERLINK": true, "HYPGEOMDIST": true,
+	"IF": true, "IFERROR": true, "IMABS": true, "IMAGINARY": true,
+	"IMARGUMENT": true, "IMCONJUGATE": true, "IMCOS": true, "IMDIV": true,
+	"IMEXP": true, "IMLN": true, "IMLOG10": true, "IMLOG2": true,
+	"IMPOWER": true, "IMPRODUCT": true, "IMREAL": true, "IMSIN": true,
+	"IMSQRT": true, "IMSUB": true, "IMSUM": true, "INDEX": true,
+	"INDIRECT": true, "INFO": true, "INT": true, "INTERCEPT": true,
+	"INTRATE": true, "IPMT": true, "IRR": true, "ISBLANK": true,
+	"ISERR": true, "ISERROR": true, "ISEVEN": true, "ISLOGICAL": true,
+	"ISNA": true, "ISNONTEXT": true, "ISNUMBER": true, "ISODD": true,
+	"ISPMT": true, "ISREF": true, "ISTEXT": true, "JIS": true, "KURT": true,
+	"LAMBDA": true, "LARGE": true, "LCM": true, "LEFT": true, "LEFTB": true,
+	"LEN": true, "LENB": true, "LET": true, "LINEST": true, "LN": true,
+	"LOG": true, "LOG10": true, "LOGEST": true, "LOGINV": true,
+	"LOGNORMDIST": true, "LOOKUP": true, "LOWER": true, "MATCH": true,
+	"MAX": true, "MAXA": true, "MDETERM": true, "MDURATION": true,
+	"MEDIAN": true, "MID": true, "MIDB": true, "MIN": true, "MINA": true,
+	"MINUTE": true, "MINVERSE": true, "MIRR": true, "MMULT": true,
+	"MOD": true, "MODE": true, "MONTH": true, "MROUND": true,
+	"MULTINOMIAL": true, "N": true, "NA": true, "NEGBINOMDIST": true,
+	"NETWORKDAYS": true, "NOMINAL": true, "NORMDIST": true, "NORMINV": true,
+	"NORMSDIST": true, "NORMSINV": true, "NOT": true, "NOW": true,
+	"NPER": true, "NPV": true, "OCT2BIN": true, "OCT2DEC": true,
+	"OCT2HEX": true, "ODD": true, "ODDFPRICE": true, "ODDFYIELD": true,
+	"ODDLPRICE": true, "ODDLYIELD": true, "OFFSET": true, "OR": true,
+	"PEARSON": true, "PERCENTILE": true, "PERCENTRANK": true,
+	"PERMUT": true, "PHONETIC": true, "PI": true, "PMT": true,
+	"POISSON": true, "POWER": true, "PPMT": true, "PRICE": true,
+	"PRICEDISC": true, "PRICEMAT": true, "PROB": true, "PRODUCT": true,
+	"PROPER": true, "PV": true, "QUARTILE": true, "QUOTIENT": true,
+	"RADIANS": true, "RAND": true, "RANDBETWEEN": true, "RANK": true,
+	"RATE": true, "RECEIVED": true, "REGISTER.ID": true, "REPLACE": true,
+	"REPLACEB": true, "REPT": true, "RIGHT": true, "RIGHTB": true,
+	"ROMAN": true, "ROUND": true, "ROUNDDOWN": true, "ROUNDUP": true,
+	"ROW": true, "ROWS": true, "RSQ": true, "RTD": true, "SEARCH": true,
+	"SEARCHB": true, "SECOND": true, "SERIESSUM": true, "SIGN": true,
+	"SIN": true, "SINH": true, "SKEW": true, "SLN": true, "SLOPE": true,
+	"SMALL": true, "SQL.REQUEST": true, "SQRT": true, "SQRTPI": true,
+	"STANDARDIZE": true, "STDEV": true, "STDEVA": true, "STDEVP": true,
+	"STDEVPA": true, "STEYX": true, "SUBSTITUTE": true, "SUBTOTAL": true,
+	"SUM": true, "SUMIF": true, "SUMIFS": true, "SUMPRODUCT": true,
+	"SUMSQ": true, "SUMX2MY2": true, "SUMX2PY2": true, "SUMXMY2": true,
+	"SYD": true, "T": true, "TAN": true, "TANH": true, "TBILLEQ": true,
+	"TBILLPRICE": true, "TBILLYIELD": true, "TDIST": true, "TEXT": true,
+	"TIME": true, "TIMEVALUE": true, "TINV": true, "TODAY": true,
+	"TRANSPOSE": true, "TREND": true, "TRIM": true, "TRIMMEAN": true,
+	"TRUE": true, "TRUNC": true, "TTEST": true, "TYPE": true, "UPPER": true,
+	"USDOLLAR": true, "VALUE": true, "VAR": true, "VARA": true,
+	"VARP": true, "VARPA": true, "VDB": true, "VLOOKUP": true,
+	"WEEKDAY": true, "WEEKNUM": true, "WEIBULL": true, "WORKDAY": true,
+	"XIRR": true, "XNPV": true, "YEAR": true, "YEARFRAC": true,
+	"YIELD": true, "YIELDDISC": true, "YIELDMAT": true, "ZTEST": true,
+}
+
+// prepareFormulaFunctions check the function names in the formula and add the
+// prefix for the newer functions by given formula options.
+func prepareFormulaFunctions(formula string, opts FormulaOpts) (string, error) {
+	if !opts.ValidateFunctions && !opts.PrefixFunctions {
+		return formula, nil
+	}
+	var (
+		buf     strings.Builder
+		runes   = []rune(formula)
+		quote   rune
+		bracket int
+	)
+	isNameChar := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+	}
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if quote != 0 || r == '"' || r == '\'' {
+			if quote == 0 {
+				quote = r
+			} else if r == quote {
+				quote = 0
+			}
+			buf.WriteRune(r)
+			continue
+		}
+		if r == '[' || r == ']' || bracket > 0 || !isNameChar(r) {
+			if r == '[' {
+				bracket++
+			}
+			if r == ']' {
+				bracket--
+			}
+			buf.WriteRune(r)
+			continue
+		}
+		j := i
+		for j < len(runes) && isNameChar(runes[j]) {
+			j++
+		}
+		name := string(runes[i:j])
+		if j < len(runes) && runes[j] == '(' && (unicode.IsLetter(r) || r == '_') {
+			var err error
+			if name, err = prepareFormulaFunction(name, opts); err != nil {
+				return formula, err
+			}
+		}
+		buf.WriteString(name)
+		i = j - 1
+	}
+	return buf.String(), nil
+}
+
+// prepareFormulaFunction check the function name and add the prefix for the
+// newer function by given formula options.
+func prepareFormulaFunction(name string, opts FormulaOpts) (string, error) {
+	upper := strings.ToUpper(name)
+	fn := strings.TrimPrefix(strings.TrimPrefix(upper, "_XLFN."), "_XLWS.")
+	if strings.HasPrefix(fn, "_XLUDF.") {
+		return name, nil
+	}
+	prefix, future := formulaFutureFunctions[fn]
+	if opts.ValidateFunctions && !future && !formulaBuiltinFunctions[fn] {
+		return name, newUnknownFormulaFunctionError(name)
+	}
+	if opts.PrefixFunctions && future && fn == upper {
+		return prefix + name, nil
+	}
+	return name, nil
+}
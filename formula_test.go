@@ -0,0 +1,234 @@
+package excelize
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFormula(t *testing.T) {
+	tokens, err := ParseFormula("=SUM(A1:B2,'Sheet 2'!C3)*-2%&\"x\"")
+	assert.NoError(t, err)
+	assert.Equal(t, []FormulaToken{
+		{Type: FormulaTokenTypeFunction, SubType: FormulaTokenSubTypeStart, Value: "SUM"},
+		{Type: FormulaTokenTypeOperand, SubType: FormulaTokenSubTypeRange, Value: "A1:B2"},
+		{Type: FormulaTokenTypeArgument, Value: ","},
+		{Type: FormulaTokenTypeOperand, SubType: FormulaTokenSubTypeRange, Value: "Sheet 2!C3"},
+		{Type: FormulaTokenTypeFunction, SubType: FormulaTokenSubTypeStop},
+		{Type: FormulaTokenTypeOperatorInfix, SubType: FormulaTokenSubTypeMath, Value: "*"},
+		{Type: FormulaTokenTypeOperatorPrefix, Value: "-"},
+		{Type: FormulaTokenTypeOperand, SubType: FormulaTokenSubTypeNumber, Value: "2"},
+		{Type: FormulaTokenTypeOperatorPostfix, Value: "%"},
+		{Type: FormulaTokenTypeOperatorInfix, SubType: FormulaTokenSubTypeConcatenation, Value: "&"},
+		{Type: FormulaTokenTypeOperand, SubType: FormulaTokenSubTypeText, Value: "x"},
+	}, tokens)
+
+	tokens, err = ParseFormula("(TRUE)+#N/A")
+	assert.NoError(t, err)
+	assert.Equal(t, []FormulaToken{
+		{Type: FormulaTokenTypeSubexpression, SubType: FormulaTokenSubTypeStart},
+		{Type: FormulaTokenTypeOperand, SubType: FormulaTokenSubTypeLogical, Value: "TRUE"},
+		{Type: FormulaTokenTypeSubexpression, SubType: FormulaTokenSubTypeStop},
+		{Type: FormulaTokenTypeOperatorInfix, SubType: FormulaTokenSubTypeMath, Value: "+"},
+		{Type: FormulaTokenTypeOperand, SubType: FormulaTokenSubTypeError, Value: "#N/A"},
+	}, tokens)
+
+	tokens, err = ParseFormula("'My Sheet'!A1:B2 B2:C3")
+	assert.NoError(t, err)
+	assert.Equal(t, []FormulaToken{
+		{Type: FormulaTokenTypeOperand, SubType: FormulaTokenSubTypeRange, Value: "My Sheet!A1:B2"},
+		{Type: FormulaTokenTypeOperatorInfix, SubType: FormulaTokenSubTypeIntersection, Value: " "},
+		{Type: FormulaTokenTypeOperand, SubType: FormulaTokenSubTypeRange, Value: "B2:C3"},
+	}, tokens)
+
+	tokens, err = ParseFormula("")
+	assert.NoError(t, err)
+	assert.Empty(t, tokens)
+
+	// Test parse formula with unbalanced parentheses
+	for _, formula := range []string{"SUM(1", "1+)", "{1,2"} {
+		_, err = ParseFormula(formula)
+		assert.Equal(t, ErrInvalidFormula, err, formula)
+	}
+}
+
+func TestRenderFormula(t *testing.T) {
+	for formula, expected := range map[string]string{
+		"=SUM(A1:B2,'Sheet 2'!C3)*-2%&\"x\"":        "SUM(A1:B2,'Sheet 2'!C3)*-2%&\"x\"",
+		"'My Sheet'!A1:B2 B2:C3":                    "'My Sheet'!A1:B2 B2:C3",
+		"SUM('O''Brien'!A1,'Sheet 1:Sheet 3'!$A$1)": "SUM('O''Brien'!A1,'Sheet 1:Sheet 3'!$A$1)",
+		"IF((TRUE),{1,2;3,\"a\"\"b\"},#N/A)":        "IF((TRUE),{1,2;3,\"a\"\"b\"},#N/A)",
+		"Sheet1!A1<>Table1[Amount]":                 "Sheet1!A1<>Table1[Amount]",
+	} {
+		tokens, err := ParseFormula(formula)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, RenderFormula(tokens), formula)
+	}
+	assert.Empty(t, RenderFormula(nil))
+	// Test render formula with modified tokens
+	tokens, err := ParseFormula("SUM('My Sheet'!A1:B2)")
+	assert.NoError(t, err)
+	tokens[1].Value = "Sheet1!A1:B2"
+	assert.Equal(t, "SUM(Sheet1!A1:B2)", RenderFormula(tokens))
+	// Test render formula with unbalanced tokens
+	assert.Equal(t, ")", RenderFormula([]FormulaToken{{Type: FormulaTokenTypeFunction, SubType: FormulaTokenSubTypeStop}}))
+}
+
+func TestGetCellPrecedentsAndDependents(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Rate", RefersTo: "'Sheet 2'!$B$1"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Loop", RefersTo: "Loop+Sheet1!A1"}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "SUM(A1:B2,$A$1)*Rate"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C2", "SUM(A:A)+SUM(2:2)+Table1[Amount]"))
+	assert.NoError(t, f.SetCellFormula("Sheet 2", "A1", "Sheet1!A1+Loop"))
+	assert.NoError(t, f.SetCellFormula("Sheet 2", "A2", "'Sheet 2'!B1*2"))
+
+	precedents, err := f.GetCellPrecedents("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1!A1:B2", "Sheet1!A1", "'Sheet 2'!B1"}, precedents)
+	precedents, err = f.GetCellPrecedents("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1!A:A", "Sheet1!2:2", "Table1[Amount]"}, precedents)
+	precedents, err = f.GetCellPrecedents("Sheet 2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1!A1"}, precedents)
+	precedents, err = f.GetCellPrecedents("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Empty(t, precedents)
+
+	dependents, err := f.GetCellDependents("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1!C1", "Sheet1!C2", "'Sheet 2'!A1"}, dependents)
+	dependents, err = f.GetCellDependents("Sheet1", "D2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1!C2"}, dependents)
+	dependents, err = f.GetCellDependents("Sheet 2", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1!C1", "'Sheet 2'!A2"}, dependents)
+	dependents, err = f.GetCellDependents("Sheet 2", "C3")
+	assert.NoError(t, err)
+	assert.Empty(t, dependents)
+
+	// Test get precedents and dependents with invalid arguments
+	_, err = f.GetCellPrecedents("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	_, err = f.GetCellDependents("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	_, err = f.GetCellDependents("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get dependents with chart sheet and unsupported charset worksheet
+	assert.NoError(t, f.AddChartSheet("Chart", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$A$1", Values: "Sheet1!$B$1"}},
+	}))
+	dependents, err = f.GetCellDependents("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Len(t, dependents, 3)
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	name, ok := f.getSheetXMLPath("Sheet3")
+	assert.True(t, ok)
+	f.Sheet.Delete(name)
+	f.Pkg.Store(name, MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	_, err = f.GetCellDependents("Sheet1", "A1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	f.formulaChecked = false
+	_, err = f.GetCellDependents("Sheet1", "A1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestGetCellDependentsSheetNames(t *testing.T) {
+	f := NewFile()
+	for _, sheet := range []string{"Sheet2", "Sheet3", "Sheet4"} {
+		_, err := f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "SUM(Sheet2:Sheet3!B2)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A2", "SHEET3!B2+sheet4!B2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", "SUM(SheetN:Sheet3!B2)"))
+	// Test get precedents with 3D references and case insensitive worksheet names
+	precedents, err := f.GetCellPrecedents("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet2!B2", "Sheet3!B2"}, precedents)
+	precedents, err = f.GetCellPrecedents("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet3!B2", "Sheet4!B2"}, precedents)
+	precedents, err = f.GetCellPrecedents("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"'SheetN:Sheet3'!B2"}, precedents)
+	for sheet, expected := range map[string][]string{
+		"Sheet2": {"Sheet1!A1"}, "sheet3": {"Sheet1!A1", "Sheet1!A2"}, "Sheet4": {"Sheet1!A2"},
+	} {
+		dependents, err := f.GetCellDependents(sheet, "B2")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, dependents, sheet)
+	}
+	// Test get dependents after the worksheets and formulas were changed
+	assert.NoError(t, f.MoveSheet("Sheet4", "Sheet3"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A2", "Sheet2!B2"))
+	dependents, err := f.GetCellDependents("Sheet4", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1!A1"}, dependents)
+	dependents, err = f.GetCellDependents("Sheet2", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1!A1", "Sheet1!A2"}, dependents)
+	assert.NoError(t, f.Close())
+}
+
+func TestFormulaRefToCoordinates(t *testing.T) {
+	for ref, expected := range map[string][]int{
+		"B2": {2, 2, 2, 2}, "C3:A1": {1, 1, 3, 3}, "B:A": {1, 1, 2, TotalRows}, "3:2": {1, 2, MaxColumns, 3},
+	} {
+		coordinates, err := formulaRefToCoordinates(ref)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, coordinates, ref)
+	}
+	for _, ref := range []string{"A1:B2:C3", "Rate", "A:1", "0:1"} {
+		_, err := formulaRefToCoordinates(ref)
+		assert.Error(t, err, ref)
+	}
+}
+
+func TestSetCellFormulaFunctions(t *testing.T) {
+	f := NewFile()
+	opts := FormulaOpts{ValidateFunctions: true, PrefixFunctions: true}
+	for formula, expected := range map[string]string{
+		"=TEXTJOIN(\",\",TRUE,A1:B1)":                                  "=_xlfn.TEXTJOIN(\",\",TRUE,A1:B1)",
+		"=ifs(A1>1,SUM(A1,B1),TRUE,norm.s.dist(1,TRUE))":               "=_xlfn.ifs(A1>1,SUM(A1,B1),TRUE,_xlfn.norm.s.dist(1,TRUE))",
+		"=_xlfn.IFS(A1>1,1)&SORT(A1:A2)&CONCAT(\"IFS(\",'IFS(1'!A1)":   "=_xlfn.IFS(A1>1,1)&_xlfn._xlws.SORT(A1:A2)&_xlfn.CONCAT(\"IFS(\",'IFS(1'!A1)",
+		"=SUM(Table1[IFS(])+OFFSET(A1,1,1)+_xludf.MyFunc(1)":           "=SUM(Table1[IFS(])+OFFSET(A1,1,1)+_xludf.MyFunc(1)",
+		"=GROUPBY(A1:A2,B1:B2,SUM)&PERCENTOF(1,2)&REGEXTEST(A1,\"a\")": "=_xlfn.GROUPBY(A1:A2,B1:B2,SUM)&_xlfn.PERCENTOF(1,2)&_xlfn.REGEXTEST(A1,\"a\")",
+		"=ROWS(TRIMRANGE(A:A))&FIELDVALUE(A1,\"Price\")":               "=ROWS(_xlfn.TRIMRANGE(A:A))&_xlfn.FIELDVALUE(A1,\"Price\")",
+		"=LET(x,1,x+1)&LAMBDA(x,x+1)(1)":                               "=LET(x,1,x+1)&LAMBDA(x,x+1)(1)",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "C1", formula, opts))
+		result, err := f.GetCellFormula("Sheet1", "C1")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result, formula)
+	}
+	// Test set formula with prefix functions only
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "MyFunc(1)+XLOOKUP(1,A:A,B:B)", FormulaOpts{PrefixFunctions: true}))
+	result, err := f.GetCellFormula("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "MyFunc(1)+_xlfn.XLOOKUP(1,A:A,B:B)", result)
+	// Test set formula with unknown function
+	assert.Equal(t, newUnknownFormulaFunctionError("MyFunc"), f.SetCellFormula("Sheet1", "C2", "=1+MyFunc(1)", opts))
+	cellType, err := f.GetCellType("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeUnset, cellType)
+	assert.EqualError(t, f.SetCellFormula("Sheet1", "C2", "=_xlfn.UNKNOWN(1)", FormulaOpts{ValidateFunctions: true}), "unknown formula function _xlfn.UNKNOWN")
+	// Test the functions supported by the formula calculation engine are
+	// included in the built-in functions
+	fnType := reflect.TypeOf(&formulaFuncs{})
+	for i := 0; i < fnType.NumMethod(); i++ {
+		name := strings.ReplaceAll(fnType.Method(i).Name, "dot", ".")
+		_, future := formulaFutureFunctions[name]
+		assert.True(t, future || formulaBuiltinFunctions[name], name)
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2016 - 2025 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.20 or later.
+
+package excelize
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// htmlBorderStyles defined the CSS border styles by the index of the cell
+// border line styles.
+var htmlBorderStyles = []string{
+	"none", "1px solid", "2px solid", "1px dashed", "1px dotted", "3px solid",
+	"3px double", "1px dotted", "2px dashed", "1px dashed", "2px dashed",
+	"1px dotted", "2px dotted", "2px dashed",
+}
+
+// htmlColor returns the CSS color by given RGB or ARGB hex color.
+func htmlColor(color string) string {
+	color = strings.TrimPrefix(color, "#")
+	if len(color) == 8 {
+		color = color[2:]
+	}
+	if len(color) != 6 {
+		return ""
+	}
+	return "#" + strings.ToUpper(color)
+}
+
+// htmlCellStyle provides a function to convert the cell style to the inline
+// CSS declarations.
+func htmlCellStyle(style *Style) string {
+	var css []string
+	if style.Fill.Type == "pattern" && style.Fill.Pattern > 0 && len(style.Fill.Color) > 0 {
+		if color := htmlColor(style.Fill.Color[0]); color != "" {
+			css = append(css, "background-color:"+color)
+		}
+	}
+	if style.Fill.Type == "gradient" && len(style.Fill.Color) > 0 {
+		if color := htmlColor(style.Fill.Color[0]); color != "" {
+			css = append(css, "background-color:"+color)
+		}
+	}
+	if fnt := style.Font; fnt != nil {
+		if fnt.Bold {
+			css = append(css, "font-weight:bold")
+		}
+		if fnt.Italic {
+			css = append(css, "font-style:italic")
+		}
+		var decorations []string
+		if fnt.Underline != "" && fnt.Underline != "none" {
+			decorations = append(decorations, "underline")
+		}
+		if fnt.Strike {
+			decorations = append(decorations, "line-through")
+		}
+		if len(decorations) > 0 {
+			css = append(css, "text-decoration:"+strings.Join(decorations, " "))
+		}
+		if fnt.Family != "" {
+			css = append(css, fmt.Sprintf("font-family:'%s'", strings.ReplaceAll(fnt.Family, "'", "")))
+		}
+		if fnt.Size > 0 {
+			css = append(css, fmt.Sprintf("font-size:%gpt", fnt.Size))
+		}
+		if color := htmlColor(fnt.Color); color != "" {
+			css = append(css, "color:"+color)
+		}
+	}
+	for _, border := range style.Border {
+		if border.Style <= 0 || border.Style >= len(htmlBorderStyles) ||
+			inStrSlice([]string{"left", "right", "top", "bottom"}, border.Type, true) == -1 {
+			continue
+		}
+		color := htmlColor(border.Color)
+		if color == "" {
+			color = "#000000"
+		}
+		css = append(css, fmt.Sprintf("border-%s:%s %s", border.Type, htmlBorderStyles[border.Style], color))
+	}
+	if align := style.Alignment; align != nil {
+		switch align.Horizontal {
+		case "left", "right", "center", "justify":
+			css = append(css, "text-align:"+align.Horizontal)
+		case "centerContinuous", "distributed":
+			css = append(css, "text-align:center")
+		}
+		switch align.Vertical {
+		case "top", "bottom":
+			css = append(css, "vertical-align:"+align.Vertical)
+		case "center":
+			css = append(css, "vertical-align:middle")
+		}
+		if align.WrapText {
+			css = append(css, "white-space:pre-wrap")
+		}
+		if align.Indent > 0 {
+			css = append(css, fmt.Sprintf("padding-left:%dpx", align.Indent*9))
+		}
+	}
+	return strings.Join(css, ";")
+}
+
+// countVisible returns the number of visible rows or columns.
+func countVisible(visible []bool) int {
+	var count int
+	for _, v := range visible {
+		if v {
+			count++
+		}
+	}
+	return count
+}
+
+// SheetToHTML provides a function to export the worksheet to an HTML table
+// by given worksheet name, which could be used for previewing the worksheet
+// in the web applications. The cell values will be formatted by the number
+// formats, the cell styles including fills, fonts, borders and alignments
+// will be converted to the inline styles, the merged cells will be converted
+// to the cells with the row span and column span, and the hidden rows and
+// columns will be ignored. For example, export Sheet1 to HTML:
+//
+//	table, err := f.SheetToHTML("Sheet1")
+func (f *File) SheetToHTML(sheet string) (string, error) {
+	rows, err := f.GetRows(sheet)
+	if err != nil {
+		return "", err
+	}
+	mergeCells, err := f.GetMergeCells(sheet)
+	if err != nil {
+		return "", err
+	}
+	maxCol, maxRow := 0, len(rows)
+	for _, row := range rows {
+		if len(row) > maxCol {
+			maxCol = len(row)
+		}
+	}
+	spans, covered := map[[2]int][2]int{}, map[[2]int]bool{}
+	for _, mergeCell := range mergeCells {
+		rect, err := rangeRefToCoordinates(mergeCell[0])
+		if err != nil {
+			return "", err
+		}
+		_ = sortCoordinates(rect)
+		if rect[2] > maxCol {
+			maxCol = rect[2]
+		}
+		if rect[3] > maxRow {
+			maxRow = rect[3]
+		}
+		for col := rect[0]; col <= rect[2]; col++ {
+			for row := rect[1]; row <= rect[3]; row++ {
+				covered[[2]int{col, row}] = true
+			}
+		}
+		spans[[2]int{rect[0], rect[1]}] = [2]int{rect[2] - rect[0] + 1, rect[3] - rect[1] + 1}
+	}
+	var (
+		buf        strings.Builder
+		colVisible = make([]bool, maxCol+1)
+		rowVisible = make([]bool, maxRow+1)
+		styles     = map[int]string{}
+	)
+	for row := 1; row <= maxRow; row++ {
+		rowVisible[row], _ = f.GetRowVisible(sheet, row)
+	}
+	buf.WriteString(`<table style="border-collapse:collapse"><colgroup>`)
+	for col := 1; col <= maxCol; col++ {
+		name, _ := ColumnNumberToName(col)
+		if colVisible[col], _ = f.GetColVisible(sheet, name); !colVisible[col] {
+			continue
+		}
+		width, _ := f.GetColWidth(sheet, name)
+		buf.WriteString(fmt.Sprintf(`<col style="width:%gpx">`, convertColWidthToPixels(width)))
+	}
+	buf.WriteString("</colgroup><tbody>")
+	for row := 1; row <= maxRow; row++ {
+		if !rowVisible[row] {
+			continue
+		}
+		height, _ := f.GetRowHeight(sheet, row)
+		buf.WriteString(fmt.Sprintf(`<tr style="height:%gpx">`, convertRowHeightToPixels(height)))
+		for col := 1; col <= maxCol; col++ {
+			span, isTopLeft := spans[[2]int{col, row}]
+			if !colVisible[col] || (covered[[2]int{col, row}] && !isTopLeft) {
+				continue
+			}
+			cell, _ := CoordinatesToCellName(col, row)
+			styleID, err := f.GetCellStyle(sheet, cell)
+			if err != nil {
+				return "", err
+			}
+			css, ok := styles[styleID]
+			if !ok {
+				style, err := f.GetStyle(styleID)
+				if err != nil {
+					return "", err
+				}
+				css = htmlCellStyle(style)
+				styles[styleID] = css
+			}
+			buf.WriteString("<td")
+			if isTopLeft {
+				if colSpan := countVisible(colVisible[col : col+span[0]]); colSpan > 1 {
+					buf.WriteString(fmt.Sprintf(` colspan="%d"`, colSpan))
+				}
+				if rowSpan := countVisible(rowVisible[row : row+span[1]]); rowSpan > 1 {
+					buf.WriteString(fmt.Sprintf(` rowspan="%d"`, rowSpan))
+				}
+			}
+			if css != "" {
+				buf.WriteString(fmt.Sprintf(` style="%s"`, html.EscapeString(css)))
+			}
+			buf.WriteString(">")
+			if row <= len(rows) && col <= len(rows[row-1]) {
+				buf.WriteString(strings.ReplaceAll(html.EscapeString(rows[row-1][col-1]), "\n", "<br>"))
+			}
+			buf.WriteString("</td>")
+		}
+		buf.WriteString("</tr>")
+	}
+	buf.WriteString("</tbody></table>")
+	return buf.String(), err
+}
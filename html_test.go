@@ -0,0 +1,68 @@
+package excelize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSheetToHTML(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Amount", "Hidden", "Note"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"<Apple>", 1234.5, "x", "line1\nline2"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"Merged"}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", "Hidden row"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A3", "D4"))
+	assert.NoError(t, f.SetColVisible("Sheet1", "C", false))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 5, false))
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "A", 20))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 1, 30))
+	header, err := f.NewStyle(&Style{
+		Font:      &Font{Bold: true, Italic: true, Underline: "single", Strike: true, Family: "Arial", Size: 12, Color: "FF0000"},
+		Fill:      Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1},
+		Border:    []Border{{Type: "bottom", Color: "0000FF", Style: 2}, {Type: "top", Style: 6}, {Type: "diagonalUp", Style: 1}},
+		Alignment: &Alignment{Horizontal: "center", Vertical: "center", WrapText: true, Indent: 1},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "D1", header))
+	amount, err := f.NewStyle(&Style{
+		NumFmt:    4,
+		Fill:      Fill{Type: "gradient", Color: []string{"FFFFFF", "E0EBF5"}, Shading: 1},
+		Alignment: &Alignment{Horizontal: "distributed", Vertical: "top"},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B2", amount))
+	table, err := f.SheetToHTML("Sheet1")
+	assert.NoError(t, err)
+	const (
+		font       = "font-family:&#39;Calibri&#39;;font-size:11pt;color:#000000"
+		headerFont = "font-weight:bold;font-style:italic;text-decoration:underline line-through;font-family:&#39;Arial&#39;;font-size:12pt;color:#FF0000"
+		headerCSS  = "background-color:#FFFF00;" + headerFont + ";border-top:3px double #000000;border-bottom:2px solid #0000FF;text-align:center;vertical-align:middle;white-space:pre-wrap;padding-left:9px"
+	)
+	assert.Equal(t, `<table style="border-collapse:collapse"><colgroup><col style="width:146px"><col style="width:70px"><col style="width:70px"></colgroup><tbody>`+
+		`<tr style="height:36px"><td style="`+headerCSS+`">Name</td><td style="`+headerCSS+`">Amount</td><td style="`+headerCSS+`">Note</td></tr>`+
+		`<tr style="height:18px"><td style="`+font+`">&lt;Apple&gt;</td><td style="background-color:#FFFFFF;`+font+`;text-align:center;vertical-align:top">1,234.50</td><td style="`+font+`">line1<br>line2</td></tr>`+
+		`<tr style="height:18px"><td colspan="3" rowspan="2" style="`+font+`">Merged</td></tr>`+
+		`<tr style="height:18px"></tr>`+
+		`</tbody></table>`, table)
+	// Test export worksheet to HTML on not exists worksheet
+	_, err = f.SheetToHTML("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test export worksheet to HTML with invalid merged cell reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A3:D"}}}
+	_, err = f.SheetToHTML("Sheet1")
+	assert.Equal(t, newCellNameToCoordinatesError("D", newInvalidCellNameError("D")), err)
+	ws.(*xlsxWorksheet).MergeCells = nil
+	// Test export worksheet to HTML with invalid style ID
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].S = 100
+	_, err = f.SheetToHTML("Sheet1")
+	assert.Equal(t, newInvalidStyleID(100), err)
+}
+
+func TestHTMLColor(t *testing.T) {
+	assert.Equal(t, "#FF0000", htmlColor("#ff0000"))
+	assert.Equal(t, "#FF0000", htmlColor("FFFF0000"))
+	assert.Empty(t, htmlColor("FF00"))
+}
@@ -13,6 +13,7 @@ package excelize
 
 import (
 	"archive/zip"
+	"bufio"
 	"bytes"
 	"container/list"
 	"encoding/xml"
@@ -22,11 +23,13 @@ import (
 	"math/big"
 	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
 
-// ReadZipReader extract spreadsheet with given options.
+// ReadZipReader extract spreadsheet with given options. The worksheet parts
+// will be unzipped on demand when they are accessed for the first time.
 func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
 	var (
 		err     error
@@ -48,7 +51,7 @@ func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
 		if partName, ok := docPart[strings.ToLower(fileName)]; ok {
 			fileName = partName
 		}
-		if strings.EqualFold(fileName, defaultXMLPathSharedStrings) && fileSize > f.options.UnzipXMLSizeLimit {
+		if strings.EqualFold(fileName, defaultXMLPathSharedStrings) && (fileSize > f.options.UnzipXMLSizeLimit || f.options.UseTempFiles) {
 			tempFile, err := f.unzipToTemp(v)
 			if tempFile != "" {
 				f.tempFiles.Store(fileName, tempFile)
@@ -59,7 +62,7 @@ func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
 		}
 		if strings.HasPrefix(strings.ToLower(fileName), "xl/worksheets/sheet") {
 			worksheets++
-			if fileSize > f.options.UnzipXMLSizeLimit && !v.FileInfo().IsDir() {
+			if (fileSize > f.options.UnzipXMLSizeLimit || f.options.UseTempFiles) && !v.FileInfo().IsDir() {
 				tempFile, err := f.unzipToTemp(v)
 				if tempFile != "" {
 					f.tempFiles.Store(fileName, tempFile)
@@ -68,6 +71,10 @@ func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
 					continue
 				}
 			}
+			if !v.FileInfo().IsDir() {
+				f.zipFiles.Store(fileName, v)
+				continue
+			}
 		}
 		if fileList[fileName], err = readFile(v); err != nil {
 			return nil, 0, err
@@ -96,31 +103,115 @@ func (f *File) unzipToTemp(zipFile *zip.File) (string, error) {
 	return tmp.Name(), tmp.Close()
 }
 
-// readXML provides a function to read XML content as bytes.
-func (f *File) readXML(name string) []byte {
-	if content, _ := f.Pkg.Load(name); content != nil {
-		return content.([]byte)
+// saveTempFile provides a function to save the content of the part to the
+// system temporary directory by given part path, and remove the part from the
+// package in memory. The content of the part will be written to the temporary
+// file by given write function.
+func (f *File) saveTempFile(name string, write func(w io.Writer) error) error {
+	tmp, err := os.CreateTemp(os.TempDir(), "excelize-")
+	if err != nil {
+		return err
 	}
-	if content, ok := f.streams[name]; ok {
-		return content.rawData.buf.Bytes()
+	bw := bufio.NewWriter(tmp)
+	if err = write(bw); err == nil {
+		err = bw.Flush()
+	}
+	if err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
 	}
-	return []byte{}
+	if path, ok := f.tempFiles.Load(name); ok {
+		_ = os.Remove(path.(string))
+	}
+	f.tempFiles.Store(name, tmp.Name())
+	f.Pkg.Delete(name)
+	f.zipFiles.Delete(name)
+	return nil
+}
+
+// getPartNames provides a function to get the sorted paths of the parts by
+// given path prefix, the parts in the package, the system temporary directory
+// and the unread zip entries will be included.
+func (f *File) getPartNames(prefix string) []string {
+	var (
+		names []string
+		found = map[string]bool{}
+		add   = func(k, v interface{}) bool {
+			if name := k.(string); strings.HasPrefix(name, prefix) && !found[name] {
+				found[name] = true
+				names = append(names, name)
+			}
+			return true
+		}
+	)
+	f.Pkg.Range(add)
+	f.tempFiles.Range(add)
+	f.zipFiles.Range(add)
+	sort.Strings(names)
+	return names
+}
+
+// deletePart provides a function to remove the part from the package, the
+// system temporary directory and the unread zip entries by given part path.
+func (f *File) deletePart(name string) {
+	f.Pkg.Delete(name)
+	f.zipFiles.Delete(name)
+	if path, ok := f.tempFiles.LoadAndDelete(name); ok {
+		_ = os.Remove(path.(string))
+	}
+}
+
+// readXML provides a function to read XML content as bytes.
+func (f *File) readXML(name string) []byte {
+	content, _ := f.readPart(name, false)
+	return content
 }
 
 // readBytes read file as bytes by given path.
 func (f *File) readBytes(name string) []byte {
-	content := f.readXML(name)
-	if len(content) != 0 {
-		return content
+	content, _ := f.readPart(name, true)
+	return content
+}
+
+// readPart provides a function to read the content of the part as bytes by
+// given part path, the part in the system temporary directory will be read
+// when temp is true. The error will be returned if reading the part from the
+// zip package or the system temporary file failed.
+func (f *File) readPart(name string, temp bool) ([]byte, error) {
+	if content, _ := f.Pkg.Load(name); content != nil {
+		return content.([]byte), nil
+	}
+	if content, ok := f.streams[name]; ok {
+		return content.rawData.buf.Bytes(), nil
+	}
+	if file, ok := f.zipFiles.Load(name); ok {
+		content, err := readFile(file.(*zip.File))
+		if err != nil {
+			return []byte{}, err
+		}
+		f.Pkg.Store(name, content)
+		f.zipFiles.Delete(name)
+		return content, nil
+	}
+	if !temp {
+		return []byte{}, nil
 	}
 	file, err := f.readTemp(name)
+	if err != nil || file == nil {
+		return []byte{}, err
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
 	if err != nil {
-		return content
+		return []byte{}, err
 	}
-	content, _ = io.ReadAll(file)
 	f.Pkg.Store(name, content)
-	_ = file.Close()
-	return content
+	return content, nil
 }
 
 // readTemp read file from system temporary directory by given path.
@@ -147,7 +238,10 @@ func readFile(file *zip.File) ([]byte, error) {
 	}
 	dat := make([]byte, 0, file.FileInfo().Size())
 	buff := bytes.NewBuffer(dat)
-	_, _ = io.Copy(buff, rc)
+	if _, err = io.Copy(buff, rc); err != nil {
+		_ = rc.Close()
+		return nil, err
+	}
 	return buff.Bytes(), rc.Close()
 }
 
@@ -350,7 +444,7 @@ func (f *File) getDefinedNameRefTo(definedNameName, currentSheet string) (refTo
 	for _, definedName := range f.GetDefinedName() {
 		if definedName.Name == definedNameName {
 			// worksheet scope takes precedence over scope workbook when both definedNames exist
-			if definedName.Scope == "Workbook" {
+			if definedName.Scope == definedNameScopeWorkbook {
 				workbookRefTo = definedName.RefersTo
 			}
 			if definedName.Scope == currentSheet {
@@ -406,6 +500,26 @@ func inCoordinates(a [][]int, x []int) int {
 	return -1
 }
 
+// splitRefs provides a function to split the comma separated references, the
+// commas in the quoted worksheet names, such as 'Q1,Q2'!A1, will be skipped.
+func splitRefs(refs string) []string {
+	var (
+		parts   []string
+		inQuote bool
+		start   int
+	)
+	for i, r := range refs {
+		if r == '\'' {
+			inQuote = !inQuote
+		}
+		if r == ',' && !inQuote {
+			parts = append(parts, refs[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, refs[start:])
+}
+
 // inStrSlice provides a method to check if an element is present in an array,
 // and return the index of its location, otherwise return -1.
 func inStrSlice(a []string, x string, caseSensitive bool) int {
@@ -786,6 +900,9 @@ func isNumeric(s string) (bool, int, float64) {
 var (
 	bstrExp       = regexp.MustCompile(`_x[a-fA-F\d]{4}_`)
 	bstrEscapeExp = regexp.MustCompile(`x[a-fA-F\d]{4}_`)
+	// decimalExp matches the number string in the decimal form without the
+	// exponent.
+	decimalExp = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
 )
 
 // bstrUnmarshal parses the binary basic string, this will trim escaped string
@@ -922,3 +1039,11 @@ func (stack *Stack) Len() int {
 func (stack *Stack) Empty() bool {
 	return stack.list.Len() == 0
 }
+
+// genGUID generates a random globally unique identifier in the registry
+// format, for example: {8E9B0F6A-5C1D-4E2F-9A3B-7C6D5E4F3A2B}.
+func genGUID() string {
+	b, _ := randomBytes(16)
+	b[6], b[8] = b[6]&0x0f|0x40, b[8]&0x3f|0x80
+	return fmt.Sprintf("{%X-%X-%X-%X-%X}", b[:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
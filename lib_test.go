@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -360,6 +361,69 @@ func TestReadBytes(t *testing.T) {
 	assert.Equal(t, []byte{}, f.readBytes(sheet))
 }
 
+func TestSaveTempFile(t *testing.T) {
+	f := NewFile()
+	write := func(w io.Writer) error {
+		_, err := w.Write([]byte("content"))
+		return err
+	}
+	assert.NoError(t, f.saveTempFile("xl/worksheets/sheet1.xml", write))
+	assert.Equal(t, []byte("content"), f.readBytes("xl/worksheets/sheet1.xml"))
+	// Test save temporary file with the write function returned error
+	assert.Equal(t, ErrParameterInvalid, f.saveTempFile("xl/worksheets/sheet1.xml", func(w io.Writer) error {
+		return ErrParameterInvalid
+	}))
+	assert.Equal(t, []byte("content"), f.readBytes("xl/worksheets/sheet1.xml"))
+	assert.NoError(t, f.Close())
+	// Test save workbook with the worksheets encoded into temporary files
+	f = NewFile(Options{UseTempFiles: true})
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	for r := 1; r <= 3; r++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r), &[]interface{}{r, "text", true}))
+	}
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "B2", Author: "Excelize", Text: "comment"}))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "C3", "https://github.com/xuri/excelize", "External"))
+	var buf bytes.Buffer
+	assert.NoError(t, f.Write(&buf))
+	for _, sheet := range []string{"xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml"} {
+		_, ok := f.tempFiles.Load(sheet)
+		assert.True(t, ok)
+		_, ok = f.Pkg.Load(sheet)
+		assert.False(t, ok)
+	}
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1", "text", "TRUE"}, {"2", "text", "TRUE"}, {"3", "text", "TRUE"}}, rows)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(&buf)
+	assert.NoError(t, err)
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1", "text", "TRUE"}, {"2", "text", "TRUE"}, {"3", "text", "TRUE"}}, rows)
+	link, target, err := f.GetCellHyperLink("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/xuri/excelize", target)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.NoError(t, f.Close())
+	// Test save temporary file with nonexistent temporary directory
+	t.Setenv("TMPDIR", filepath.Join("test", "nonexistent"))
+	f = NewFile(Options{UseTempFiles: true})
+	require.Error(t, f.saveTempFile("xl/worksheets/sheet1.xml", write))
+	_, ok := f.Pkg.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	// Test save workbook with the temporary file error
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1))
+	require.Error(t, f.Write(io.Discard))
+	// Test save workbook with nonexistent temporary file
+	f = NewFile()
+	f.tempFiles.Store("xl/worksheets/sheet2.xml", filepath.Join("test", "nonexistent"))
+	assert.True(t, os.IsNotExist(f.Write(io.Discard)))
+}
+
 func TestUnzipToTemp(t *testing.T) {
 	os.Setenv("TMPDIR", "test")
 	defer os.Unsetenv("TMPDIR")
@@ -390,3 +454,9 @@ func TestUnzipToTemp(t *testing.T) {
 	_, err = f.unzipToTemp(z.File[0])
 	assert.EqualError(t, err, "EOF")
 }
+
+func TestSplitRefs(t *testing.T) {
+	assert.Equal(t, []string{""}, splitRefs(""))
+	assert.Equal(t, []string{"A1", "B2:C3"}, splitRefs("A1,B2:C3"))
+	assert.Equal(t, []string{"'Q1,Q2'!A1", "'O''Brien, Q3'!$A$1:$B$2", "Sheet1!A1"}, splitRefs("'Q1,Q2'!A1,'O''Brien, Q3'!$A$1:$B$2,Sheet1!A1"))
+}
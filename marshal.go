@@ -0,0 +1,479 @@
+// Copyright 2016 - 2025 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.20 or later.
+
+package excelize
+
+import (
+	"database/sql"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SQLRowsOptions directly maps the settings of writing the SQL query result
+// into the worksheet.
+type SQLRowsOptions struct {
+	// Table specified the table settings to create a table over the written
+	// cell range, the Range of the table will be ignored.
+	Table *Table
+}
+
+// structField directly maps the worksheet column settings of a struct field
+// which parsed from the "xlsx" struct tag.
+type structField struct {
+	index        []int
+	name         string
+	col          int
+	numFmt       int
+	customNumFmt *string
+}
+
+// getStructFields provides a function to parse the exported fields of the
+// given struct type with the "xlsx" struct tag, and returns the fields sorted
+// by the relative column number.
+func getStructFields(typ reflect.Type) ([]structField, error) {
+	var fields []structField
+	used := map[int]bool{}
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		tag := sf.Tag.Get("xlsx")
+		if tag == "-" {
+			continue
+		}
+		field := structField{index: sf.Index, name: sf.Name}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name = strings.TrimSpace(name); name != "" {
+			field.name = name
+		}
+		for opts != "" {
+			var opt string
+			if strings.HasPrefix(opts, "customNumFmt=") {
+				opt, opts = opts, ""
+			} else {
+				opt, opts, _ = strings.Cut(opts, ",")
+			}
+			key, val, _ := strings.Cut(opt, "=")
+			var err error
+			switch strings.TrimSpace(key) {
+			case "col":
+				if field.col, err = strconv.Atoi(val); err != nil || field.col < 1 || used[field.col] {
+					return fields, newInvalidStructTagError(sf.Name, tag)
+				}
+				used[field.col] = true
+			case "numFmt":
+				if field.numFmt, err = strconv.Atoi(val); err != nil {
+					return fields, newInvalidStructTagError(sf.Name, tag)
+				}
+			case "customNumFmt":
+				field.customNumFmt = stringPtr(val)
+			default:
+				return fields, newInvalidStructTagError(sf.Name, tag)
+			}
+		}
+		fields = append(fields, field)
+	}
+	col := 1
+	for i := range fields {
+		if fields[i].col != 0 {
+			continue
+		}
+		for used[col] {
+			col++
+		}
+		fields[i].col, used[col] = col, true
+	}
+	for i := 1; i < len(fields); i++ {
+		for j := i; j > 0 && fields[j].col < fields[j-1].col; j-- {
+			fields[j], fields[j-1] = fields[j-1], fields[j]
+		}
+	}
+	return fields, nil
+}
+
+// structSliceType returns the struct type of the given slice element, the
+// element should be a struct or a pointer to a struct.
+func structSliceType(typ reflect.Type) (reflect.Type, bool) {
+	if typ.Kind() != reflect.Slice && typ.Kind() != reflect.Array {
+		return nil, false
+	}
+	typ = typ.Elem()
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ, typ.Kind() == reflect.Struct
+}
+
+// SetSheetFromSlice provides a function to write a slice of structs into the
+// worksheet by given worksheet name, top-left cell reference and slice. The
+// first row will be the header row with the column names, and each element of
+// the slice will be written into a row after the header row. The value of the
+// slice should be a slice or an array of structs or pointers to structs, and
+// the nil pointer element will be written as an empty row. Each exported
+// struct field will be mapped to a column, and the column settings can be
+// specified by the "xlsx" struct tag, the first part of the tag is the header
+// name of the column, which default is the field name, followed by the comma
+// separated options:
+//
+//	 Option       | Description
+//	--------------+----------------------------------------------------------
+//	 col          | The column number relative to the top-left cell, which
+//	              | starting from 1. The fields without this option will be
+//	              | placed in the free columns by the fields order.
+//	              |
+//	 numFmt       | The built-in number format index of the column cells.
+//	              |
+//	 customNumFmt | The custom number format code of the column cells, this
+//	              | option should be the last option of the tag.
+//
+// The field with a "-" tag will be ignored. For example, write the products
+// list into the Sheet1 starting from the cell A1, and the price column will
+// be placed in the third column with a custom number format:
+//
+//	type Product struct {
+//	    Name      string    `xlsx:"Product Name"`
+//	    Price     float64   `xlsx:"Price,col=3,customNumFmt=#,##0.00"`
+//	    Published time.Time `xlsx:"Published,numFmt=14"`
+//	    Internal  string    `xlsx:"-"`
+//	}
+//	err := f.SetSheetFromSlice("Sheet1", "A1", []Product{
+//	    {Name: "Apple", Price: 1.5, Published: time.Now()},
+//	    {Name: "Banana", Price: 2.25, Published: time.Now()},
+//	})
+func (f *File) SetSheetFromSlice(sheet, cell string, slice interface{}) error {
+	v := reflect.ValueOf(slice)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return ErrParameterInvalid
+	}
+	typ, ok := structSliceType(v.Type())
+	if !ok {
+		return ErrParameterInvalid
+	}
+	fields, err := getStructFields(typ)
+	if err != nil {
+		return err
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	if len(fields) > 0 && col+fields[len(fields)-1].col-1 > MaxColumns {
+		return ErrColumnNumber
+	}
+	if row+v.Len() > TotalRows {
+		return ErrMaxRows
+	}
+	for _, field := range fields {
+		name, _ := CoordinatesToCellName(col+field.col-1, row)
+		if err = f.SetCellStr(sheet, name, field.name); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		if elem.Kind() == reflect.Ptr {
+			if elem.IsNil() {
+				continue
+			}
+			elem = elem.Elem()
+		}
+		for _, field := range fields {
+			fv := elem.FieldByIndex(field.index)
+			if fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			name, _ := CoordinatesToCellName(col+field.col-1, row+i+1)
+			if err = f.SetCellValue(sheet, name, fv.Interface()); err != nil {
+				return err
+			}
+		}
+	}
+	if v.Len() == 0 {
+		return err
+	}
+	for _, field := range fields {
+		if field.numFmt == 0 && field.customNumFmt == nil {
+			continue
+		}
+		styleID, err := f.NewStyle(&Style{NumFmt: field.numFmt, CustomNumFmt: field.customNumFmt})
+		if err != nil {
+			return err
+		}
+		topLeftCell, _ := CoordinatesToCellName(col+field.col-1, row+1)
+		bottomRightCell, _ := CoordinatesToCellName(col+field.col-1, row+v.Len())
+		if err = f.SetCellStyle(sheet, topLeftCell, bottomRightCell, styleID); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// GetSheetInto provides a function to read the worksheet into a slice of
+// structs by given worksheet name and a pointer to the slice. The first row of
+// the worksheet should be the header row, and the columns will be mapped to
+// the struct fields by the header names which specified by the "xlsx" struct
+// tag, the same as the SetSheetFromSlice function. The column without mapped
+// field will be ignored, and the empty rows will be skipped. The supported
+// field types are string, bool, integer, float, time.Time and the pointers to
+// those types. For example, read the products list from the Sheet1:
+//
+//	var products []Product
+//	err := f.GetSheetInto("Sheet1", &products)
+func (f *File) GetSheetInto(sheet string, slice interface{}) error {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return ErrParameterInvalid
+	}
+	v = v.Elem()
+	typ, ok := structSliceType(v.Type())
+	if !ok {
+		return ErrParameterInvalid
+	}
+	fields, err := getStructFields(typ)
+	if err != nil {
+		return err
+	}
+	rows, err := f.GetRows(sheet)
+	if err != nil {
+		return err
+	}
+	rawRows, _ := f.GetRows(sheet, Options{RawCellValue: true})
+	date1904 := f.isDate1904()
+	result := reflect.MakeSlice(v.Type(), 0, len(rows))
+	if len(rows) == 0 {
+		v.Set(result)
+		return err
+	}
+	header := map[string]int{}
+	for idx, name := range rows[0] {
+		if _, ok := header[strings.TrimSpace(name)]; !ok {
+			header[strings.TrimSpace(name)] = idx
+		}
+	}
+	for r := 1; r < len(rows); r++ {
+		if strings.Join(rows[r], "") == "" {
+			continue
+		}
+		elem := reflect.New(typ).Elem()
+		for _, field := range fields {
+			idx, ok := header[field.name]
+			if !ok || idx >= len(rows[r]) {
+				continue
+			}
+			raw := rows[r][idx]
+			if r < len(rawRows) && idx < len(rawRows[r]) {
+				raw = rawRows[r][idx]
+			}
+			if err = setStructFieldValue(elem.FieldByIndex(field.index), rows[r][idx], raw, date1904); err != nil {
+				return err
+			}
+		}
+		if v.Type().Elem().Kind() == reflect.Ptr {
+			elem = elem.Addr()
+		}
+		result = reflect.Append(result, elem)
+	}
+	v.Set(result)
+	return err
+}
+
+// setStructFieldValue provides a function to set the struct field value by
+// given formatted and raw cell value.
+func setStructFieldValue(fv reflect.Value, val, raw string, date1904 bool) error {
+	if val == "" && raw == "" {
+		return nil
+	}
+	if fv.Kind() == reflect.Ptr {
+		ptr := reflect.New(fv.Type().Elem())
+		if err := setStructFieldValue(ptr.Elem(), val, raw, date1904); err != nil {
+			return err
+		}
+		fv.Set(ptr)
+		return nil
+	}
+	if fv.Type() == reflect.TypeOf(time.Time{}) {
+		if excelTime, err := strconv.ParseFloat(raw, 64); err == nil {
+			t, err := ExcelDateToTime(excelTime, date1904)
+			if err != nil {
+				return err
+			}
+			fv.Set(reflect.ValueOf(t))
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(t))
+		return nil
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(val)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, fv.Type().Bits())
+		if err != nil {
+			num, e := strconv.ParseFloat(raw, 64)
+			if e != nil || fv.OverflowInt(int64(num)) {
+				return err
+			}
+			n = int64(num)
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, fv.Type().Bits())
+		if err != nil {
+			num, e := strconv.ParseFloat(raw, 64)
+			if e != nil || num < 0 || fv.OverflowUint(uint64(num)) {
+				return err
+			}
+			n = uint64(num)
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(n)
+	default:
+		return newUnsupportedFieldTypeError(fv.Type().String())
+	}
+	return nil
+}
+
+// sqlNumericTypes defined the list of database column type names which the
+// value should be written as a number.
+var sqlNumericTypes = []string{
+	"BIGINT", "DEC", "DECIMAL", "DOUBLE", "FLOAT", "FLOAT4", "FLOAT8", "INT",
+	"INT2", "INT4", "INT8", "INTEGER", "MEDIUMINT", "MONEY", "NUMBER", "NUMERIC",
+	"REAL", "SMALLINT", "TINYINT",
+}
+
+// sqlCellValue provides a function to convert the scanned database value to
+// the cell value by given database column type name.
+func sqlCellValue(val interface{}, typeName string) interface{} {
+	var str string
+	switch v := val.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	default:
+		return val
+	}
+	if inStrSlice(sqlNumericTypes, strings.ToUpper(typeName), true) != -1 {
+		if num, err := strconv.ParseFloat(str, 64); err == nil {
+			return num
+		}
+	}
+	return str
+}
+
+// SetSheetFromSQLRows provides a function to write the SQL query result into
+// the worksheet by given worksheet name, top-left cell reference, the rows of
+// the query result and optional settings. The first row will be the header
+// row with the column names, and each row of the result will be written into
+// a row after the header row one by one, the numeric, boolean, and date time
+// values will be written as the cell of corresponding types, and the text
+// values of the numeric database columns, such as DECIMAL and NUMERIC, will
+// be written as numbers. This function will not close the rows. Set the Table
+// options to create a table over the written cell range. For example, write
+// the query result into the Sheet1 starting from the cell A1, and create a
+// table over it:
+//
+//	rows, err := db.Query("SELECT id, name, price, created_at FROM products")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer rows.Close()
+//	err = f.SetSheetFromSQLRows("Sheet1", "A1", rows, excelize.SQLRowsOptions{
+//	    Table: &excelize.Table{Name: "products", StyleName: "TableStyleMedium2"},
+//	})
+func (f *File) SetSheetFromSQLRows(sheet, cell string, rows *sql.Rows, opts ...SQLRowsOptions) error {
+	if rows == nil {
+		return ErrParameterInvalid
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		return err
+	}
+	if col+len(columns)-1 > MaxColumns {
+		return ErrColumnNumber
+	}
+	header := make([]interface{}, len(columns))
+	for i, column := range columns {
+		header[i] = column.Name()
+	}
+	if err = f.SetSheetRow(sheet, cell, &header); err != nil {
+		return err
+	}
+	values, dest := make([]interface{}, len(columns)), make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	lastRow := row
+	for rows.Next() {
+		if lastRow++; lastRow > TotalRows {
+			return ErrMaxRows
+		}
+		if err = rows.Scan(dest...); err != nil {
+			return err
+		}
+		cells := make([]interface{}, len(columns))
+		for i, column := range columns {
+			cells[i] = sqlCellValue(values[i], column.DatabaseTypeName())
+		}
+		name, _ := CoordinatesToCellName(col, lastRow)
+		if err = f.SetSheetRow(sheet, name, &cells); err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	for _, opt := range opts {
+		if opt.Table == nil || len(columns) == 0 {
+			continue
+		}
+		table := *opt.Table
+		if lastRow == row {
+			lastRow++
+		}
+		topLeftCell, _ := CoordinatesToCellName(col, row)
+		bottomRightCell, _ := CoordinatesToCellName(col+len(columns)-1, lastRow)
+		table.Range = topLeftCell + ":" + bottomRightCell
+		if err = f.AddTable(sheet, &table); err != nil {
+			return err
+		}
+	}
+	return err
+}
@@ -0,0 +1,289 @@
+package excelize
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testProduct struct {
+	Name      string    `xlsx:"Product Name"`
+	Price     float64   `xlsx:"Price,col=4,customNumFmt=#,##0.00"`
+	Quantity  int       `xlsx:"Qty"`
+	Stock     *uint     `xlsx:"Stock"`
+	Available bool      `xlsx:",col=3"`
+	Published time.Time `xlsx:"Published,numFmt=14"`
+	Rating    *float32
+	Internal  string `xlsx:"-"`
+	internal  string
+}
+
+func TestSetSheetFromSlice(t *testing.T) {
+	f := NewFile()
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	stock, rating := uint(20), float32(4.5)
+	products := []*testProduct{
+		{Name: "Apple", Price: 1234.5, Quantity: 3, Stock: &stock, Available: true, Published: date, Rating: &rating, Internal: "x", internal: "y"},
+		nil,
+		{Name: "Banana", Price: 2.25, Quantity: -1, Published: date.AddDate(0, 1, 0)},
+	}
+	assert.NoError(t, f.SetSheetFromSlice("Sheet1", "B2", products))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		nil,
+		{"", "Product Name", "Qty", "Available", "Price", "Stock", "Published", "Rating"},
+		{"", "Apple", "3", "TRUE", "1,234.50", "20", "05-01-24", "4.5"},
+		nil,
+		{"", "Banana", "-1", "FALSE", "2.25", "", "06-01-24"},
+	}, rows)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSheetFromSlice.xlsx")))
+
+	// Test read the worksheet into a slice of structs
+	assert.NoError(t, f.RemoveRow("Sheet1", 1))
+	assert.NoError(t, f.RemoveCol("Sheet1", "A"))
+	var result []testProduct
+	assert.NoError(t, f.GetSheetInto("Sheet1", &result))
+	assert.Equal(t, []testProduct{
+		{Name: "Apple", Price: 1234.5, Quantity: 3, Stock: &stock, Available: true, Published: date, Rating: &rating},
+		{Name: "Banana", Price: 2.25, Quantity: -1, Published: date.AddDate(0, 1, 0)},
+	}, result)
+	var ptrs []*testProduct
+	assert.NoError(t, f.GetSheetInto("Sheet1", &ptrs))
+	assert.Len(t, ptrs, 2)
+	assert.Equal(t, "Banana", ptrs[1].Name)
+	// Test read the worksheet with different field types
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A5", &[]interface{}{"Cherry", 200, nil, 1e3, nil, "2024-07-01T00:00:00Z"}))
+	type product struct {
+		Name      string    `xlsx:"Product Name"`
+		Quantity  int16     `xlsx:"Qty"`
+		Price     int       `xlsx:"Price"`
+		Published time.Time `xlsx:"Published"`
+	}
+	var items []product
+	assert.NoError(t, f.GetSheetInto("Sheet1", &items))
+	assert.Equal(t, []product{
+		{Name: "Apple", Quantity: 3, Price: 1234, Published: date},
+		{Name: "Banana", Quantity: -1, Price: 2, Published: date.AddDate(0, 1, 0)},
+		{Name: "Cherry", Quantity: 200, Price: 1000, Published: date.AddDate(0, 2, 0)},
+	}, items)
+	// Test read the worksheet with overflow integer value
+	assert.NoError(t, f.SetCellValue("Sheet1", "B5", 1e5))
+	assert.EqualError(t, f.GetSheetInto("Sheet1", &items), "strconv.ParseInt: parsing \"100000\": value out of range")
+	// Test read the worksheet into an empty slice
+	var empty []testProduct
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.GetSheetInto("Sheet2", &empty))
+	assert.Empty(t, empty)
+	assert.NotNil(t, empty)
+	// Test write an empty slice
+	assert.NoError(t, f.SetSheetFromSlice("Sheet2", "A1", [0]testProduct{}))
+	assert.NoError(t, f.SetSheetFromSlice("Sheet2", "A1", &[]struct{}{{}}))
+}
+
+func TestSetSheetFromSliceError(t *testing.T) {
+	f := NewFile()
+	type invalidCol struct {
+		A string `xlsx:"A,col=0"`
+	}
+	type duplicateCol struct {
+		A string `xlsx:"A,col=1"`
+		B string `xlsx:"B,col=1"`
+	}
+	type invalidNumFmt struct {
+		A int `xlsx:"A,numFmt=x"`
+	}
+	type invalidOption struct {
+		A int `xlsx:"A,width=10"`
+	}
+	for _, c := range []struct {
+		slice interface{}
+		err   error
+	}{
+		{nil, ErrParameterInvalid},
+		{"", ErrParameterInvalid},
+		{[]int{1}, ErrParameterInvalid},
+		{[]invalidCol{{}}, newInvalidStructTagError("A", "A,col=0")},
+		{[]duplicateCol{{}}, newInvalidStructTagError("B", "B,col=1")},
+		{[]invalidNumFmt{{}}, newInvalidStructTagError("A", "A,numFmt=x")},
+		{[]invalidOption{{}}, newInvalidStructTagError("A", "A,width=10")},
+	} {
+		assert.Equal(t, c.err, f.SetSheetFromSlice("Sheet1", "A1", c.slice))
+	}
+	assert.Equal(t, newInvalidStructTagError("A", "A,col=0"), f.GetSheetInto("Sheet1", &[]invalidCol{}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetSheetFromSlice("Sheet1", "A", []testProduct{}))
+	assert.Equal(t, ErrColumnNumber, f.SetSheetFromSlice("Sheet1", "XFA1", []testProduct{}))
+	assert.Equal(t, ErrMaxRows, f.SetSheetFromSlice("Sheet1", "A"+strconv.Itoa(TotalRows), []testProduct{{}}))
+	assert.EqualError(t, f.SetSheetFromSlice("SheetN", "A1", []testProduct{{}}), "sheet SheetN does not exist")
+	// Test write the slice with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetSheetFromSlice("Sheet1", "A1", []testProduct{{}}), "XML syntax error on line 1: invalid UTF-8")
+
+	// Test read the worksheet with invalid parameters
+	f = NewFile()
+	var result []testProduct
+	assert.Equal(t, ErrParameterInvalid, f.GetSheetInto("Sheet1", result))
+	assert.Equal(t, ErrParameterInvalid, f.GetSheetInto("Sheet1", (*[]testProduct)(nil)))
+	assert.Equal(t, ErrParameterInvalid, f.GetSheetInto("Sheet1", &[]int{}))
+	assert.EqualError(t, f.GetSheetInto("SheetN", &result), "sheet SheetN does not exist")
+	// Test read the worksheet with invalid cell values
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Qty", "Stock", "Available", "Published", "Rating", "Price"}))
+	for _, c := range []struct {
+		cell  string
+		value interface{}
+		err   string
+	}{
+		{"A2", "x", "strconv.ParseInt: parsing \"x\": invalid syntax"},
+		{"B2", "-1", "strconv.ParseUint: parsing \"-1\": invalid syntax"},
+		{"C2", "x", "strconv.ParseBool: parsing \"x\": invalid syntax"},
+		{"D2", "x", "parsing time \"x\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"x\" as \"2006\""},
+		{"D2", -1, "invalid date value -1.000000, negative values are not supported"},
+		{"E2", "x", "strconv.ParseFloat: parsing \"x\": invalid syntax"},
+	} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{nil, nil, nil, nil, nil}))
+		assert.NoError(t, f.SetCellValue("Sheet1", c.cell, c.value))
+		assert.EqualError(t, f.GetSheetInto("Sheet1", &result), c.err)
+	}
+	// Test read the worksheet with unsupported field type
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{1, nil, nil, nil, nil, 1}))
+	var unsupported []struct{ Price []byte }
+	assert.EqualError(t, f.GetSheetInto("Sheet1", &unsupported), "unsupported field type []uint8")
+}
+
+// testSQLDriver is a database driver which returns the predefined query
+// results for testing, the query string is the key of the results.
+type testSQLDriver struct{}
+
+type testSQLConn struct{}
+
+type testSQLStmt struct{ query string }
+
+type testSQLRows struct {
+	result *testSQLResult
+	idx    int
+}
+
+type testSQLResult struct {
+	columns, types []string
+	rows           [][]driver.Value
+	err            error
+}
+
+var testSQLResults = map[string]*testSQLResult{}
+
+func (testSQLDriver) Open(string) (driver.Conn, error) { return testSQLConn{}, nil }
+
+func (testSQLConn) Prepare(query string) (driver.Stmt, error) { return testSQLStmt{query}, nil }
+
+func (testSQLConn) Close() error { return nil }
+
+func (testSQLConn) Begin() (driver.Tx, error) { return nil, driver.ErrSkip }
+
+func (testSQLStmt) Close() error { return nil }
+
+func (testSQLStmt) NumInput() int { return 0 }
+
+func (testSQLStmt) Exec([]driver.Value) (driver.Result, error) { return nil, driver.ErrSkip }
+
+func (s testSQLStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &testSQLRows{result: testSQLResults[s.query]}, nil
+}
+
+func (r *testSQLRows) Columns() []string { return r.result.columns }
+
+func (r *testSQLRows) ColumnTypeDatabaseTypeName(idx int) string { return r.result.types[idx] }
+
+func (r *testSQLRows) Close() error { return nil }
+
+func (r *testSQLRows) Next(dest []driver.Value) error {
+	if r.idx >= len(r.result.rows) {
+		if r.result.err != nil {
+			return r.result.err
+		}
+		return io.EOF
+	}
+	copy(dest, r.result.rows[r.idx])
+	r.idx++
+	return nil
+}
+
+func init() {
+	sql.Register("excelize_test", testSQLDriver{})
+}
+
+func TestSetSheetFromSQLRows(t *testing.T) {
+	db, err := sql.Open("excelize_test", "")
+	assert.NoError(t, err)
+	defer db.Close()
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	testSQLResults["products"] = &testSQLResult{
+		columns: []string{"id", "name", "price", "available", "created_at", "note"},
+		types:   []string{"INTEGER", "VARCHAR", "DECIMAL", "BOOLEAN", "TIMESTAMP", "TEXT"},
+		rows: [][]driver.Value{
+			{int64(1), []byte("Apple"), []byte("1.50"), true, date, nil},
+			{int64(2), "Banana", "x", false, date, []byte("12")},
+		},
+	}
+	testSQLResults["empty"] = &testSQLResult{columns: []string{"id", "name"}, types: []string{"INT", "TEXT"}}
+	testSQLResults["none"] = &testSQLResult{}
+	testSQLResults["error"] = &testSQLResult{columns: []string{"id"}, types: []string{"INT"}, err: errors.New("query error")}
+	query := func(query string) *sql.Rows {
+		rows, err := db.Query(query)
+		assert.NoError(t, err)
+		return rows
+	}
+	f := NewFile()
+	rows := query("products")
+	assert.NoError(t, f.SetSheetFromSQLRows("Sheet1", "B2", rows, SQLRowsOptions{
+		Table: &Table{Name: "products", Range: "A1:A2", StyleName: "TableStyleMedium2"},
+	}))
+	assert.NoError(t, rows.Close())
+	result, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		nil,
+		{"", "id", "name", "price", "available", "created_at", "note"},
+		{"", "1", "Apple", "1.5", "TRUE", "May-24"},
+		{"", "2", "Banana", "x", "FALSE", "May-24", "12"},
+	}, result)
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, tables, 1)
+	assert.Equal(t, "B2:G4", tables[0].Range)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSheetFromSQLRows.xlsx")))
+	// Test write the query result without rows
+	rows = query("empty")
+	assert.NoError(t, f.SetSheetFromSQLRows("Sheet1", "J1", rows, SQLRowsOptions{Table: &Table{Name: "empty"}}, SQLRowsOptions{}))
+	assert.NoError(t, rows.Close())
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "J1:K2", tables[1].Range)
+	rows = query("none")
+	assert.NoError(t, f.SetSheetFromSQLRows("Sheet1", "A1", rows, SQLRowsOptions{Table: &Table{}}))
+	assert.NoError(t, rows.Close())
+	// Test write the query result with invalid parameters
+	assert.Equal(t, ErrParameterInvalid, f.SetSheetFromSQLRows("Sheet1", "A1", nil))
+	rows = query("products")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetSheetFromSQLRows("Sheet1", "A", rows))
+	assert.Equal(t, ErrColumnNumber, f.SetSheetFromSQLRows("Sheet1", "XFA1", rows))
+	assert.EqualError(t, f.SetSheetFromSQLRows("SheetN", "A1", rows), "sheet SheetN does not exist")
+	assert.Equal(t, ErrMaxRows, f.SetSheetFromSQLRows("Sheet1", "A"+strconv.Itoa(TotalRows), rows))
+	assert.NoError(t, rows.Close())
+	assert.EqualError(t, f.SetSheetFromSQLRows("Sheet1", "A1", rows), "sql: Rows are closed")
+	rows = query("products")
+	assert.Equal(t, ErrNameLength, f.SetSheetFromSQLRows("Sheet1", "A10", rows, SQLRowsOptions{Table: &Table{Name: strings.Repeat("c", MaxFieldLength+1)}}))
+	assert.NoError(t, rows.Close())
+	rows = query("error")
+	assert.EqualError(t, f.SetSheetFromSQLRows("Sheet1", "A20", rows), "query error")
+	assert.NoError(t, rows.Close())
+}
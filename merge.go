@@ -34,7 +34,9 @@ func (mc *xlsxMergeCell) Rect() ([]int, error) {
 //	err := f.MergeCell("Sheet1", "D3", "E9")
 //
 // If you create a merged cell that overlaps with another existing merged cell,
-// those merged cells that already exist will be removed. The cell references
+// those merged cells that already exist will be removed, and the merged range
+// will be expanded to contain all of them, this will repeat until the merged
+// range doesn't overlap with any other merged cells. The cell references
 // tuple after merging in the following range will be: A1(x3,y1) D1(x2,y1)
 // A8(x3,y4) D8(x2,y4)
 //
@@ -162,6 +164,46 @@ func (f *File) GetMergeCells(sheet string) ([]MergeCell, error) {
 	return mergeCells, err
 }
 
+// GetMergeCell provides a function to get the merged cell which contains the
+// given cell by worksheet name and cell reference, the value of the merged cell
+// is the value of the top-left cell of the merged range. It returns nil if the
+// cell isn't in any merged range. For example, get the merged range and the
+// value of the cell D5 on Sheet1:
+//
+//	mergeCell, err := f.GetMergeCell("Sheet1", "D5")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if mergeCell != nil {
+//	    fmt.Println(mergeCell.GetStartAxis(), mergeCell.GetEndAxis(), mergeCell.GetCellValue())
+//	}
+func (f *File) GetMergeCell(sheet, cell string) (MergeCell, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return nil, err
+	}
+	if ws.MergeCells == nil {
+		return nil, err
+	}
+	if err = f.mergeOverlapCells(ws); err != nil {
+		return nil, err
+	}
+	for _, mergeCell := range ws.MergeCells.Cells {
+		rect, _ := mergeCell.Rect()
+		if cellInRange([]int{col, row}, rect) {
+			ref := mergeCell.Ref
+			val, err := f.GetCellValue(sheet, strings.Split(ref, ":")[0])
+			return []string{ref, val}, err
+		}
+	}
+	return nil, err
+}
+
 // overlapRange calculate overlap range of merged cells, and returns max
 // column and rows of the range.
 func overlapRange(ws *xlsxWorksheet) (row, col int, err error) {
@@ -190,36 +232,43 @@ func overlapRange(ws *xlsxWorksheet) (row, col int, err error) {
 	return
 }
 
-// flatMergedCells convert merged cells range reference to cell-matrix.
+// flatMergedCells convert merged cells range reference to cell-matrix. If the
+// merged cell overlaps with the previous merged cells, they will be merged
+// into the smallest range that contains all of them, and the range will keep
+// growing until it doesn't overlap with any other merged cells.
 func flatMergedCells(ws *xlsxWorksheet, matrix [][]*xlsxMergeCell) error {
 	for i, cell := range ws.MergeCells.Cells {
-		rect, err := cell.Rect()
-		if err != nil {
+		if cell == nil {
+			continue
+		}
+		if _, err := cell.Rect(); err != nil {
 			return err
 		}
-		x1, y1, x2, y2 := rect[0]-1, rect[1]-1, rect[2]-1, rect[3]-1
-		var overlapCells []*xlsxMergeCell
-		for x := x1; x <= x2; x++ {
-			for y := y1; y <= y2; y++ {
-				if matrix[x][y] != nil {
-					overlapCells = append(overlapCells, matrix[x][y])
-				}
-				matrix[x][y] = cell
-			}
+		newCell, merged := cell, map[*xlsxMergeCell]bool{cell: true}
+		for overlapCell := overlapMergedCell(newCell, matrix, merged); overlapCell != nil; overlapCell = overlapMergedCell(newCell, matrix, merged) {
+			newCell, merged[overlapCell] = mergeCell(newCell, overlapCell), true
 		}
-		if len(overlapCells) != 0 {
-			newCell := cell
-			for _, overlapCell := range overlapCells {
-				newCell = mergeCell(cell, overlapCell)
+		rect, _ := newCell.Rect()
+		for x := rect[0] - 1; x <= rect[2]-1; x++ {
+			for y := rect[1] - 1; y <= rect[3]-1; y++ {
+				matrix[x][y] = newCell
 			}
-			newRect, _ := newCell.Rect()
-			x1, y1, x2, y2 := newRect[0]-1, newRect[1]-1, newRect[2]-1, newRect[3]-1
-			for x := x1; x <= x2; x++ {
-				for y := y1; y <= y2; y++ {
-					matrix[x][y] = newCell
-				}
+		}
+		ws.MergeCells.Cells[i] = newCell
+	}
+	return nil
+}
+
+// overlapMergedCell returns the first merged cell in the cell-matrix which
+// overlaps with the given merged cell and hasn't been merged, returns nil if
+// not overlapped.
+func overlapMergedCell(cell *xlsxMergeCell, matrix [][]*xlsxMergeCell, merged map[*xlsxMergeCell]bool) *xlsxMergeCell {
+	rect, _ := cell.Rect()
+	for x := rect[0] - 1; x <= rect[2]-1; x++ {
+		for y := rect[1] - 1; y <= rect[3]-1; y++ {
+			if matrix[x][y] != nil && !merged[matrix[x][y]] {
+				return matrix[x][y]
 			}
-			ws.MergeCells.Cells[i] = newCell
 		}
 	}
 	return nil
@@ -238,9 +287,14 @@ func (f *File) mergeOverlapCells(ws *xlsxWorksheet) error {
 	for i := range matrix {
 		matrix[i] = make([]*xlsxMergeCell, rows)
 	}
-	_ = flatMergedCells(ws, matrix)
+	if err = flatMergedCells(ws, matrix); err != nil {
+		return err
+	}
 	mergeCells := ws.MergeCells.Cells[:0]
 	for _, cell := range ws.MergeCells.Cells {
+		if cell == nil {
+			continue
+		}
 		rect, _ := cell.Rect()
 		x1, y1, x2, y2 := rect[0]-1, rect[1]-1, rect[2]-1, rect[3]-1
 		if matrix[x1][y1] == cell {
@@ -256,29 +310,27 @@ func (f *File) mergeOverlapCells(ws *xlsxWorksheet) error {
 	return nil
 }
 
-// mergeCell merge two cells.
+// mergeCell merge two cells into a new cell, the range of the given cells
+// will not be changed.
 func mergeCell(cell1, cell2 *xlsxMergeCell) *xlsxMergeCell {
 	rect1, _ := cell1.Rect()
 	rect2, _ := cell2.Rect()
-
-	if rect1[0] > rect2[0] {
-		rect1[0], rect2[0] = rect2[0], rect1[0]
+	rect := append([]int{}, rect1...)
+	if rect2[0] < rect[0] {
+		rect[0] = rect2[0]
 	}
-
-	if rect1[2] < rect2[2] {
-		rect1[2], rect2[2] = rect2[2], rect1[2]
+	if rect2[1] < rect[1] {
+		rect[1] = rect2[1]
 	}
-
-	if rect1[1] > rect2[1] {
-		rect1[1], rect2[1] = rect2[1], rect1[1]
+	if rect2[2] > rect[2] {
+		rect[2] = rect2[2]
 	}
-
-	if rect1[3] < rect2[3] {
-		rect1[3], rect2[3] = rect2[3], rect1[3]
+	if rect2[3] > rect[3] {
+		rect[3] = rect2[3]
 	}
-	topLeftCell, _ := CoordinatesToCellName(rect1[0], rect1[1])
-	bottomRightCell, _ := CoordinatesToCellName(rect1[2], rect1[3])
-	return &xlsxMergeCell{rect: rect1, Ref: topLeftCell + ":" + bottomRightCell}
+	topLeftCell, _ := CoordinatesToCellName(rect[0], rect[1])
+	bottomRightCell, _ := CoordinatesToCellName(rect[2], rect[3])
+	return &xlsxMergeCell{rect: rect, Ref: topLeftCell + ":" + bottomRightCell}
 }
 
 // MergeCell define a merged cell data.
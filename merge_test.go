@@ -108,6 +108,49 @@ func TestMergeCellOverlap(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestMergeCellOverlapMultiple(t *testing.T) {
+	f := NewFile()
+	// Test merge a range which overlaps with multiple merged cells, and the
+	// merged range overlaps with another merged cell
+	for _, cells := range [][]string{{"A1", "B2"}, {"D1", "E2"}, {"F3", "G4"}, {"B2", "D2"}, {"E2", "F3"}} {
+		assert.NoError(t, f.MergeCell("Sheet1", cells[0], cells[1]))
+	}
+	mc, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mc, 1)
+	assert.Equal(t, "A1:G4", mc[0][0])
+}
+
+func TestGetMergeCell(t *testing.T) {
+	f := NewFile()
+	mc, err := f.GetMergeCell("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Nil(t, mc)
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "anchor"))
+	assert.NoError(t, f.MergeCell("Sheet1", "B2", "C4"))
+	assert.NoError(t, f.MergeCell("Sheet1", "E1", "E1"))
+	mc, err = f.GetMergeCell("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.Equal(t, "B2", mc.GetStartAxis())
+	assert.Equal(t, "C4", mc.GetEndAxis())
+	assert.Equal(t, "anchor", mc.GetCellValue())
+	mc, err = f.GetMergeCell("Sheet1", "D3")
+	assert.NoError(t, err)
+	assert.Nil(t, mc)
+	// Test get merged cell with invalid cell reference
+	_, err = f.GetMergeCell("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get merged cell on not exists worksheet
+	_, err = f.GetMergeCell("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get merged cell with invalid merged range reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A:A"}}}
+	_, err = f.GetMergeCell("Sheet1", "A1")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+}
+
 func TestGetMergeCells(t *testing.T) {
 	wants := []struct {
 		value string
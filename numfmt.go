@@ -44,7 +44,7 @@ type numberFormat struct {
 	ap, localCode, result, value, valueSectionType                           string
 	switchArgument, currencyString                                           string
 	fracHolder, fracPadding, intHolder, intPadding, expBaseLen               int
-	percent                                                                  int
+	percent, thousandsScale                                                  int
 	useCommaSep, useFraction, usePointer, usePositive, useScientificNotation bool
 }
 
@@ -4713,6 +4713,14 @@ func (f *File) langNumFmtFuncEnUS(numFmtID int) string {
 	if 32 <= numFmtID && numFmtID <= 35 {
 		return longTimePattern
 	}
+	if 5 <= numFmtID && numFmtID <= 8 {
+		return []string{
+			"\"$\"#,##0_);\\(\"$\"#,##0\\)",
+			"\"$\"#,##0_);[Red]\\(\"$\"#,##0\\)",
+			"\"$\"#,##0.00_);\\(\"$\"#,##0.00\\)",
+			"\"$\"#,##0.00_);[Red]\\(\"$\"#,##0.00\\)",
+		}[numFmtID-5]
+	}
 	if (27 <= numFmtID && numFmtID <= 31) || (50 <= numFmtID && numFmtID <= 58) {
 		return shortDatePattern
 	}
@@ -4854,6 +4862,9 @@ func (f *File) getBuiltInNumFmtCode(numFmtID int) (string, bool) {
 			return fn(f, numFmtID), true
 		}
 	}
+	if 5 <= numFmtID && numFmtID <= 8 && f.options.CultureInfo == CultureNameEnUS {
+		return f.langNumFmtFuncEnUS(numFmtID), true
+	}
 	return "", false
 }
 
@@ -4921,7 +4932,13 @@ func (nf *numberFormat) getNumberPartLen() (int, int) {
 // getNumberFmtConf generate the number format padding and placeholder
 // configurations.
 func (nf *numberFormat) getNumberFmtConf() {
-	for _, token := range nf.section[nf.sectionIdx].Items {
+	items := nf.section[nf.sectionIdx].Items
+	for i, token := range items {
+		if token.TType == nfp.TokenTypeLiteral && token.TValue == "," && i > 0 &&
+			items[i-1].TType == nfp.TokenTypeThousandsSeparator && !hasDigitPlaceHolder(items[i:]) {
+			// Consecutive trailing commas scale the number by thousand each
+			items[i].TType, token.TType = nfp.TokenTypeThousandsSeparator, nfp.TokenTypeThousandsSeparator
+		}
 		if token.TType == nfp.TokenTypeHashPlaceHolder {
 			if nf.usePointer {
 				nf.fracHolder += len(token.TValue)
@@ -4933,7 +4950,11 @@ func (nf *numberFormat) getNumberFmtConf() {
 			nf.useScientificNotation = true
 		}
 		if token.TType == nfp.TokenTypeThousandsSeparator {
-			nf.useCommaSep = true
+			if hasDigitPlaceHolder(items[i:]) {
+				nf.useCommaSep = true
+			} else {
+				nf.thousandsScale++
+			}
 		}
 		if token.TType == nfp.TokenTypePercent {
 			nf.percent += len(token.TValue)
@@ -4960,6 +4981,19 @@ func (nf *numberFormat) getNumberFmtConf() {
 			nf.intPadding += len(token.TValue)
 		}
 	}
+	nf.number /= math.Pow(1000, float64(nf.thousandsScale))
+}
+
+// hasDigitPlaceHolder returns if the number format tokens contain any digit
+// placeholder.
+func hasDigitPlaceHolder(items []nfp.Token) bool {
+	for _, token := range items {
+		switch token.TType {
+		case nfp.TokenTypeHashPlaceHolder, nfp.TokenTypeZeroPlaceHolder, nfp.TokenTypeDigitalPlaceHolder:
+			return true
+		}
+	}
+	return false
 }
 
 // printNumberLiteral apply literal tokens for the pre-formatted text.
@@ -5101,6 +5135,7 @@ func (nf *numberFormat) numberHandler() string {
 	)
 	if isNum, precision, decimal := isNumeric(nf.value); isNum {
 		if precision > 15 && intLen+fracLen > 15 && !nf.useScientificNotation {
+			decimal /= math.Pow(1000, float64(nf.thousandsScale))
 			return nf.printNumberLiteral(nf.printBigNumber(decimal, fracLen))
 		}
 	}
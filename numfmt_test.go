@@ -3575,6 +3575,12 @@ func TestNumFmt(t *testing.T) {
 		{"1234.5678", "0.00", "1234.57"},
 		{"1234.5678", "#,##0", "1,235"},
 		{"1234.5678", "#,##0.00", "1,234.57"},
+		{"1234567", "#,##0,\"K\"", "1,235K"},
+		{"1234567", "0.0,,\"M\"", "1.2M"},
+		{"1234567", "0,", "1235"},
+		{"-1234567", "#,##0,,\"M\";-#,##0,", "-1,235"},
+		{"999", "#,", "1"},
+		{"12345678901234567", "#,##0,,", "12,345,678,901"},
 		{"1234.5678", "0%", "123457%"},
 		{"1234.5678", "#,##0 ;(#,##0)", "1,235 "},
 		{"1234.5678", "#,##0 ;[red](#,##0)", "1,235 "},
@@ -0,0 +1,386 @@
+// Copyright 2016 - 2025 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.20 or later.
+
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/nfp"
+)
+
+// Namespaces and media types of the OpenDocument spreadsheet package.
+const (
+	odsMediaType         = "application/vnd.oasis.opendocument.spreadsheet"
+	odsVersion           = "1.2"
+	odsNameSpaceFo       = "urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0"
+	odsNameSpaceManifest = "urn:oasis:names:tc:opendocument:xmlns:manifest:1.0"
+	odsNameSpaceOffice   = "urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+	odsNameSpaceStyle    = "urn:oasis:names:tc:opendocument:xmlns:style:1.0"
+	odsNameSpaceTable    = "urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+	odsNameSpaceText     = "urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+)
+
+// odsBorderStyles defined the OpenDocument border width and line styles by
+// the index of the cell border line styles.
+var odsBorderStyles = []string{
+	"none", "0.74pt solid", "1.76pt solid", "0.74pt dashed", "0.74pt dotted",
+	"2.49pt solid", "2.24pt double", "0.74pt dotted", "1.76pt dashed",
+	"0.74pt dashed", "1.76pt dashed", "0.74pt dotted", "1.76pt dotted",
+	"1.76pt dashed",
+}
+
+// odsWriter provides the automatic styles of the OpenDocument spreadsheet
+// content during the workbook conversion.
+type odsWriter struct {
+	content    *odsDocumentContent
+	date1904   bool
+	cellStyles map[int]string
+	dateStyles map[int]bool
+	colStyles  map[string]string
+	rowStyles  map[string]string
+}
+
+// addStyle add the automatic style to the content and returns the style name
+// by given style name prefix and the style.
+func (w *odsWriter) addStyle(prefix string, styles map[string]string, key string, style *odsStyle) string {
+	if name, ok := styles[key]; ok {
+		return name
+	}
+	style.Name = prefix + strconv.Itoa(len(styles)+1)
+	styles[key] = style.Name
+	w.content.AutomaticStyles.Style = append(w.content.AutomaticStyles.Style, style)
+	return style.Name
+}
+
+// isDateNumFmt returns if the number format of the given style is a date or
+// time number format.
+func (f *File) isDateNumFmt(style *Style) bool {
+	fmtCode, _ := f.getBuiltInNumFmtCode(style.NumFmt)
+	if style.CustomNumFmt != nil {
+		fmtCode = *style.CustomNumFmt
+	}
+	p := nfp.NumberFormatParser()
+	for _, section := range p.Parse(fmtCode) {
+		for _, token := range section.Items {
+			if token.TType == nfp.TokenTypeDateTimes {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// odsCellStyle provides a function to convert the cell style to the
+// OpenDocument cell automatic style.
+func odsCellStyle(style *Style) *odsStyle {
+	cellStyle := &odsStyle{Family: "table-cell", TableCellProperties: &odsTableCellProperties{}}
+	if len(style.Fill.Color) > 0 && (style.Fill.Type == "gradient" ||
+		(style.Fill.Type == "pattern" && style.Fill.Pattern > 0)) {
+		cellStyle.TableCellProperties.BackgroundColor = htmlColor(style.Fill.Color[0])
+	}
+	for _, border := range style.Border {
+		if border.Style <= 0 || border.Style >= len(odsBorderStyles) {
+			continue
+		}
+		color := htmlColor(border.Color)
+		if color == "" {
+			color = "#000000"
+		}
+		value := odsBorderStyles[border.Style] + " " + color
+		switch border.Type {
+		case "left":
+			cellStyle.TableCellProperties.BorderLeft = value
+		case "right":
+			cellStyle.TableCellProperties.BorderRight = value
+		case "top":
+			cellStyle.TableCellProperties.BorderTop = value
+		case "bottom":
+			cellStyle.TableCellProperties.BorderBottom = value
+		}
+	}
+	if fnt := style.Font; fnt != nil {
+		cellStyle.TextProperties = &odsTextProperties{FontFamily: fnt.Family, Color: htmlColor(fnt.Color)}
+		if fnt.Size > 0 {
+			cellStyle.TextProperties.FontSize = fmt.Sprintf("%gpt", fnt.Size)
+		}
+		if fnt.Bold {
+			cellStyle.TextProperties.FontWeight = "bold"
+		}
+		if fnt.Italic {
+			cellStyle.TextProperties.FontStyle = "italic"
+		}
+		if fnt.Underline != "" && fnt.Underline != "none" {
+			cellStyle.TextProperties.TextUnderlineStyle = "solid"
+		}
+		if fnt.Strike {
+			cellStyle.TextProperties.TextLineThroughStyle = "solid"
+		}
+	}
+	if align := style.Alignment; align != nil {
+		cellStyle.ParagraphProperties = &odsParagraphProperties{}
+		switch align.Horizontal {
+		case "left":
+			cellStyle.ParagraphProperties.TextAlign = "start"
+		case "right":
+			cellStyle.ParagraphProperties.TextAlign = "end"
+		case "center", "centerContinuous", "distributed":
+			cellStyle.ParagraphProperties.TextAlign = "center"
+		case "justify":
+			cellStyle.ParagraphProperties.TextAlign = "justify"
+		}
+		if align.Indent > 0 {
+			cellStyle.ParagraphProperties.MarginLeft = fmt.Sprintf("%gpt", float64(align.Indent)*6.75)
+		}
+		switch align.Vertical {
+		case "top", "bottom":
+			cellStyle.TableCellProperties.VerticalAlign = align.Vertical
+		case "center":
+			cellStyle.TableCellProperties.VerticalAlign = "middle"
+		}
+		if align.WrapText {
+			cellStyle.TableCellProperties.WrapOption = "wrap"
+		}
+	}
+	return cellStyle
+}
+
+// odsCell provides a function to convert the cell to the OpenDocument table
+// cell by given worksheet name, cell reference, raw and formatted value.
+func (f *File) odsCell(w *odsWriter, sheet, cell, raw, value string) (odsTableCell, error) {
+	tableCell := odsTableCell{XMLName: xml.Name{Local: "table:table-cell"}}
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return tableCell, err
+	}
+	if _, ok := w.cellStyles[styleID]; !ok {
+		style, err := f.GetStyle(styleID)
+		if err != nil {
+			return tableCell, err
+		}
+		cellStyle := odsCellStyle(style)
+		cellStyle.Name = "ce" + strconv.Itoa(len(w.cellStyles)+1)
+		w.cellStyles[styleID], w.dateStyles[styleID] = cellStyle.Name, f.isDateNumFmt(style)
+		w.content.AutomaticStyles.Style = append(w.content.AutomaticStyles.Style, cellStyle)
+	}
+	tableCell.StyleName = w.cellStyles[styleID]
+	if raw == "" {
+		return tableCell, err
+	}
+	tableCell.P, tableCell.ValueType = strings.Split(value, "\n"), "string"
+	cellType, err := f.getCellType(sheet, cell, false)
+	if err != nil {
+		return tableCell, err
+	}
+	switch cellType {
+	case CellTypeBool:
+		tableCell.ValueType, tableCell.BooleanValue = "boolean", strconv.FormatBool(raw == "1")
+	case CellTypeNumber, CellTypeUnset:
+		num, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			break
+		}
+		if w.dateStyles[styleID] {
+			tableCell.ValueType = "date"
+			tableCell.DateValue = timeFromExcelTime(num, w.date1904).Format("2006-01-02T15:04:05")
+			break
+		}
+		tableCell.ValueType, tableCell.Value = "float", raw
+	}
+	return tableCell, nil
+}
+
+// odsTable provides a function to convert the worksheet to the OpenDocument
+// table by given worksheet name.
+func (f *File) odsTable(w *odsWriter, sheet string) (*odsTable, error) {
+	rows, err := f.GetRows(sheet)
+	if err != nil {
+		return nil, err
+	}
+	raws, _ := f.GetRows(sheet, Options{RawCellValue: true})
+	ws, _ := f.workSheetReader(sheet)
+	mergeCells, err := f.GetMergeCells(sheet)
+	if err != nil {
+		return nil, err
+	}
+	maxCol, maxRow := 1, len(rows)
+	for _, row := range rows {
+		if len(row) > maxCol {
+			maxCol = len(row)
+		}
+	}
+	spans, covered := map[[2]int][2]int{}, map[[2]int]bool{}
+	for _, mergeCell := range mergeCells {
+		rect, err := rangeRefToCoordinates(mergeCell[0])
+		if err != nil {
+			return nil, err
+		}
+		_ = sortCoordinates(rect)
+		if rect[2] > maxCol {
+			maxCol = rect[2]
+		}
+		if rect[3] > maxRow {
+			maxRow = rect[3]
+		}
+		for col := rect[0]; col <= rect[2]; col++ {
+			for row := rect[1]; row <= rect[3]; row++ {
+				covered[[2]int{col, row}] = true
+			}
+		}
+		spans[[2]int{rect[0], rect[1]}] = [2]int{rect[2] - rect[0] + 1, rect[3] - rect[1] + 1}
+	}
+	if maxRow == 0 {
+		maxRow = 1
+	}
+	table := &odsTable{Name: sheet, StyleName: "ta1"}
+	if visible, _ := f.GetSheetVisible(sheet); !visible {
+		table.StyleName = "ta2"
+	}
+	for col := 1; col <= maxCol; col++ {
+		name, _ := ColumnNumberToName(col)
+		width, _ := f.GetColWidth(sheet, name)
+		columnWidth := fmt.Sprintf("%gpt", convertColWidthToPixels(width)*0.75)
+		column := odsTableColumn{StyleName: w.addStyle("co", w.colStyles, columnWidth, &odsStyle{
+			Family: "table-column", TableColumnProperties: &odsTableColumnProperties{ColumnWidth: columnWidth},
+		})}
+		if visible, _ := f.GetColVisible(sheet, name); !visible {
+			column.Visibility = "collapse"
+		}
+		table.Column = append(table.Column, column)
+	}
+	for row := 1; row <= maxRow; row++ {
+		height, _ := f.GetRowHeight(sheet, row)
+		rowHeight := fmt.Sprintf("%gpt", height)
+		tableRow := &odsTableRow{StyleName: w.addStyle("ro", w.rowStyles, rowHeight, &odsStyle{
+			Family: "table-row", TableRowProperties: &odsTableRowProperties{RowHeight: rowHeight},
+		})}
+		if visible, _ := f.GetRowVisible(sheet, row); !visible && row <= len(ws.SheetData.Row) {
+			tableRow.Visibility = "collapse"
+		}
+		for col := 1; col <= maxCol; col++ {
+			span, isTopLeft := spans[[2]int{col, row}]
+			if covered[[2]int{col, row}] && !isTopLeft {
+				tableRow.Cell = append(tableRow.Cell, odsTableCell{XMLName: xml.Name{Local: "table:covered-table-cell"}})
+				continue
+			}
+			var raw, value string
+			if row <= len(rows) && col <= len(rows[row-1]) {
+				value = rows[row-1][col-1]
+			}
+			if row <= len(raws) && col <= len(raws[row-1]) {
+				raw = raws[row-1][col-1]
+			}
+			cell, _ := CoordinatesToCellName(col, row)
+			tableCell, err := f.odsCell(w, sheet, cell, raw, value)
+			if err != nil {
+				return nil, err
+			}
+			if isTopLeft {
+				tableCell.ColumnsSpanned, tableCell.RowsSpanned = span[0], span[1]
+			}
+			tableRow.Cell = append(tableRow.Cell, tableCell)
+		}
+		table.Row = append(table.Row, tableRow)
+	}
+	return table, nil
+}
+
+// writeODS provides a function to convert the workbook to the OpenDocument
+// spreadsheet and write to an io.Writer. The cell values, number formatted
+// display text, basic cell styles, merged cells, column widths, row heights
+// and visibility of the worksheets will be converted, the formulas will be
+// written as the cached values, and the chart sheets will be ignored.
+func (f *File) writeODS(w io.Writer) (int64, error) {
+	if _, err := f.workbookReader(); err != nil {
+		return 0, err
+	}
+	defaultStyle, err := f.GetStyle(0)
+	if err != nil {
+		return 0, err
+	}
+	writer := &odsWriter{
+		content: &odsDocumentContent{
+			XMLNSOffice: odsNameSpaceOffice, XMLNSStyle: odsNameSpaceStyle, XMLNSText: odsNameSpaceText,
+			XMLNSTable: odsNameSpaceTable, XMLNSFo: odsNameSpaceFo, Version: odsVersion,
+			AutomaticStyles: odsAutomaticStyles{Style: []*odsStyle{
+				{Name: "ta1", Family: "table", TableProperties: &odsTableProperties{Display: true}},
+				{Name: "ta2", Family: "table", TableProperties: &odsTableProperties{Display: false}},
+			}},
+		},
+		date1904:   f.isDate1904(),
+		cellStyles: map[int]string{},
+		dateStyles: map[int]bool{},
+		colStyles:  map[string]string{},
+		rowStyles:  map[string]string{},
+	}
+	for _, sheet := range f.GetSheetList() {
+		table, err := f.odsTable(writer, sheet)
+		if err != nil {
+			if err.Error() == newNotWorksheetError(sheet).Error() {
+				continue
+			}
+			return 0, err
+		}
+		writer.content.Body.Spreadsheet.Table = append(writer.content.Body.Spreadsheet.Table, table)
+	}
+	buf := new(bytes.Buffer)
+	zw, err := f.newZipWriter(buf)
+	if err != nil {
+		return 0, err
+	}
+	fi, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return 0, err
+	}
+	if _, err = fi.Write([]byte(odsMediaType)); err != nil {
+		return 0, err
+	}
+	stylesContent := &odsDocumentStyles{
+		XMLNSOffice: odsNameSpaceOffice, XMLNSStyle: odsNameSpaceStyle, XMLNSFo: odsNameSpaceFo, Version: odsVersion,
+		Styles: odsStyles{DefaultStyle: []odsStyle{
+			{Family: "table-cell", TextProperties: odsCellStyle(defaultStyle).TextProperties},
+		}},
+	}
+	manifest := &odsManifest{XMLNSManifest: odsNameSpaceManifest, Version: odsVersion, FileEntry: []odsManifestEntry{
+		{FullPath: "/", MediaType: odsMediaType, Version: odsVersion},
+		{FullPath: "content.xml", MediaType: "text/xml"},
+		{FullPath: "styles.xml", MediaType: "text/xml"},
+	}}
+	for _, part := range []struct {
+		name    string
+		content interface{}
+	}{
+		{"META-INF/manifest.xml", manifest},
+		{"styles.xml", stylesContent},
+		{"content.xml", writer.content},
+	} {
+		output, err := xml.Marshal(part.content)
+		if err != nil {
+			return 0, err
+		}
+		if fi, err = f.createZipPart(zw, part.name); err != nil {
+			return 0, err
+		}
+		if _, err = fi.Write(append([]byte(xml.Header), output...)); err != nil {
+			return 0, err
+		}
+	}
+	if err = zw.Close(); err != nil {
+		return 0, err
+	}
+	return buf.WriteTo(w)
+}
@@ -0,0 +1,130 @@
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveAsODS(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Amount", "Date", "Paid"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"<Apple>", 1234.5, 45292, true}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", "line1\nline2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B3", "B2*2"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A4", "Merged"))
+	assert.NoError(t, f.SetSheetCol("Sheet1", "E1", &[]interface{}{"Top", "Bottom"}))
+	assert.NoError(t, f.MergeCell("Sheet1", "A4", "B5"))
+	assert.NoError(t, f.SetColVisible("Sheet1", "D", false))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 3, false))
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "A", 20))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 1, 30))
+	header, err := f.NewStyle(&Style{
+		Font:      &Font{Bold: true, Italic: true, Underline: "single", Strike: true, Family: "Arial", Size: 12, Color: "FF0000"},
+		Fill:      Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1},
+		Border:    []Border{{Type: "left", Style: 1}, {Type: "right", Style: 2}, {Type: "top", Color: "0000FF", Style: 6}, {Type: "bottom", Style: 5}, {Type: "diagonalUp", Style: 1}},
+		Alignment: &Alignment{Horizontal: "center", Vertical: "center", WrapText: true, Indent: 1},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "D1", header))
+	for cell, alignment := range map[string]Alignment{
+		"E1": {Horizontal: "left", Vertical: "top"}, "E2": {Horizontal: "right", Vertical: "bottom"}, "B2": {Horizontal: "justify"},
+	} {
+		style, err := f.NewStyle(&Style{Alignment: &alignment})
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetCellStyle("Sheet1", cell, cell, style))
+	}
+	date, err := f.NewStyle(&Style{NumFmt: 14})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C2", "C2", date))
+	_, err = f.NewSheet("Hidden")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetVisible("Hidden", false))
+	assert.NoError(t, f.AddChartSheet("Chart", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$A$2", Values: "Sheet1!$B$2"}},
+	}))
+
+	path := filepath.Join(t.TempDir(), "Book1.ods")
+	assert.NoError(t, f.SaveAs(path))
+	zr, err := zip.OpenReader(path)
+	assert.NoError(t, err)
+	defer zr.Close()
+	parts := map[string]string{}
+	for _, file := range zr.File {
+		rc, err := file.Open()
+		assert.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		assert.NoError(t, err)
+		assert.NoError(t, rc.Close())
+		parts[file.Name] = string(content)
+	}
+	assert.Equal(t, "mimetype", zr.File[0].Name)
+	assert.Equal(t, zip.Store, zr.File[0].Method)
+	assert.Equal(t, odsMediaType, parts["mimetype"])
+	assert.Contains(t, parts["META-INF/manifest.xml"], `<manifest:file-entry manifest:full-path="content.xml" manifest:media-type="text/xml"></manifest:file-entry>`)
+	assert.Contains(t, parts["styles.xml"], `<style:default-style style:family="table-cell"><style:text-properties fo:font-family="Calibri" fo:font-size="11pt" fo:color="#000000"></style:text-properties></style:default-style>`)
+	content := parts["content.xml"]
+	for _, expected := range []string{
+		`<style:style style:name="ta2" style:family="table"><style:table-properties table:display="false"></style:table-properties></style:style>`,
+		`<style:style style:name="co1" style:family="table-column"><style:table-column-properties style:column-width="109.5pt"></style:table-column-properties></style:style>`,
+		`<style:style style:name="ro1" style:family="table-row"><style:table-row-properties style:row-height="30pt" style:use-optimal-row-height="false"></style:table-row-properties></style:style>`,
+		`<style:table-cell-properties fo:background-color="#FFFF00" fo:border-left="0.74pt solid #000000" fo:border-right="1.76pt solid #000000" fo:border-top="2.24pt double #0000FF" fo:border-bottom="2.49pt solid #000000" style:vertical-align="middle" fo:wrap-option="wrap"></style:table-cell-properties><style:paragraph-properties fo:text-align="center" fo:margin-left="6.75pt"></style:paragraph-properties><style:text-properties fo:font-family="Arial" fo:font-size="12pt" fo:font-weight="bold" fo:font-style="italic" fo:color="#FF0000" style:text-underline-style="solid" style:text-line-through-style="solid"></style:text-properties>`,
+		`<style:table-cell-properties style:vertical-align="top"></style:table-cell-properties><style:paragraph-properties fo:text-align="start"></style:paragraph-properties>`,
+		`<style:table-cell-properties style:vertical-align="bottom"></style:table-cell-properties><style:paragraph-properties fo:text-align="end"></style:paragraph-properties>`,
+		`<style:paragraph-properties fo:text-align="justify"></style:paragraph-properties>`,
+		`<table:table table:name="Sheet1" table:style-name="ta1"><table:table-column table:style-name="co1"></table:table-column><table:table-column table:style-name="co2"></table:table-column><table:table-column table:style-name="co2"></table:table-column><table:table-column table:style-name="co2" table:visibility="collapse"></table:table-column><table:table-column table:style-name="co2"></table:table-column>`,
+		`<table:table-cell table:style-name="ce3" office:value-type="string"><text:p>&lt;Apple&gt;</text:p></table:table-cell>`,
+		`office:value-type="float" office:value="1234.5"><text:p>1234.5</text:p></table:table-cell>`,
+		`office:value-type="date" office:date-value="2024-01-01T00:00:00"><text:p>01-01-24</text:p></table:table-cell>`,
+		`office:value-type="boolean" office:boolean-value="true"><text:p>TRUE</text:p></table:table-cell>`,
+		`<table:table-row table:style-name="ro2" table:visibility="collapse"><table:table-cell table:style-name="ce3" office:value-type="string"><text:p>line1</text:p><text:p>line2</text:p></table:table-cell>`,
+		`<table:table-cell table:style-name="ce3" office:value-type="string" table:number-columns-spanned="2" table:number-rows-spanned="2"><text:p>Merged</text:p></table:table-cell><table:covered-table-cell></table:covered-table-cell>`,
+		`<table:table table:name="Hidden" table:style-name="ta2"><table:table-column table:style-name="co2"></table:table-column><table:table-row table:style-name="ro2"><table:table-cell table:style-name="ce3"></table:table-cell></table:table-row></table:table>`,
+	} {
+		assert.Contains(t, content, expected)
+	}
+	assert.NotContains(t, content, `table:name="Chart"`)
+
+	// Test write the workbook as ODS to the io.Writer
+	buf := new(bytes.Buffer)
+	assert.NoError(t, f.Write(buf))
+	assert.Equal(t, "mimetype", string(buf.Bytes()[30:38]))
+
+	// Test save as ODS with invalid merged cell reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A4:B"}}}
+	assert.Equal(t, newCellNameToCoordinatesError("B", newInvalidCellNameError("B")), f.SaveAs(path))
+	ws.(*xlsxWorksheet).MergeCells = nil
+	// Test save as ODS with invalid style ID
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].S = 100
+	assert.Equal(t, newInvalidStyleID(100), f.SaveAs(path))
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].S = 0
+	// Test save as ODS with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet2.xml")
+	f.Pkg.Store("xl/worksheets/sheet2.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	assert.EqualError(t, f.SaveAs(path), "XML syntax error on line 1: invalid UTF-8")
+	// Test save as ODS with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SaveAs(path), "XML syntax error on line 1: invalid UTF-8")
+	// Test save as ODS with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SaveAs(path), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestIsDateNumFmt(t *testing.T) {
+	f := NewFile()
+	assert.True(t, f.isDateNumFmt(&Style{NumFmt: 22}))
+	assert.True(t, f.isDateNumFmt(&Style{CustomNumFmt: stringPtr("yyyy/mm/dd")}))
+	assert.False(t, f.isDateNumFmt(&Style{NumFmt: 4}))
+	assert.False(t, f.isDateNumFmt(&Style{CustomNumFmt: stringPtr("0.00")}))
+}
@@ -0,0 +1,488 @@
+// Copyright 2016 - 2025 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.20 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/richardlehane/mscfb"
+)
+
+// packageCLSID defined the class ID of the OLE package object.
+var packageCLSID = []byte{0x0C, 0x00, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}
+
+// AddOLEObject provides the method to embed a file as an OLE object in a
+// worksheet by given worksheet name and OLE object settings. The object will
+// be displayed as an icon at the given cell by the picture specified with
+// Icon and IconExtension. The Word, PowerPoint and Excel documents with
+// ".docx", ".docm", ".pptx", ".pptm", ".xlsx" and ".xlsm" extension will be
+// embedded as package parts directly. The Data with the ".bin" extension will
+// be used as the OLE compound file binary, and the ProgID is required in this
+// case. The files with other extensions, such as PDF or text files, will be
+// wrapped in the OLE package object with the program ID "Package", the
+// FileName specifies the file name displayed for the object. For example,
+// embed a PDF file in Sheet1!B2:
+//
+//	pdf, err := os.ReadFile("Report.pdf")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	icon, err := os.ReadFile("icon.png")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.AddOLEObject("Sheet1", &excelize.OLEObject{
+//	    Cell:          "B2",
+//	    FileName:      "Report.pdf",
+//	    Extension:     ".pdf",
+//	    Data:          pdf,
+//	    Icon:          icon,
+//	    IconExtension: ".png",
+//	})
+func (f *File) AddOLEObject(sheet string, opts *OLEObject) error {
+	if opts == nil || len(opts.Data) == 0 {
+		return ErrParameterInvalid
+	}
+	iconExt, ok := supportedImageTypes[strings.ToLower(opts.IconExtension)]
+	if !ok || len(opts.Icon) == 0 {
+		return ErrImgExt
+	}
+	col, row, err := CellNameToCoordinates(opts.Cell)
+	if err != nil {
+		return err
+	}
+	ext, progID, data := strings.ToLower(opts.Extension), opts.ProgID, opts.Data
+	relType, contentType := SourceRelationshipOLEObject, ContentTypeOLEObject
+	if oleType, ok := supportedOLEObjectTypes[ext]; ok {
+		relType, contentType = SourceRelationshipPackage, oleType[1]
+		if progID == "" {
+			progID = oleType[0]
+		}
+	} else if ext != ".bin" {
+		fileName := opts.FileName
+		if fileName == "" {
+			fileName = "Object" + ext
+		}
+		ext, progID, data = ".bin", "Package", packageOLEObject(fileName, opts.Data)
+	}
+	if progID == "" {
+		return ErrParameterInvalid
+	}
+	width, height := int(opts.Width), int(opts.Height)
+	if width == 0 {
+		width = 80
+	}
+	if height == 0 {
+		height = 60
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+	embedding := "xl/embeddings/oleObject" + strconv.Itoa(f.countEmbeddings()+1) + ext
+	f.Pkg.Store(embedding, data)
+	objectID := f.addRels(sheetRels, relType, ".."+strings.TrimPrefix(embedding, "xl"), "")
+	media := ".." + strings.TrimPrefix(f.addMedia(opts.Icon, iconExt), "xl")
+	iconID := f.addRels(sheetRels, SourceRelationshipImage, media, "")
+	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(sheet, col, row, 0, 0, width, height)
+	anchor := fmt.Sprintf("%d, 0, %d, 0, %d, %d, %d, %d", colStart, rowStart, colEnd, x2, rowEnd, y2)
+	shapeID, err := f.addOLEObjectVML(sheet, sheetRels, media, anchor, width, height)
+	if err != nil {
+		return err
+	}
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	f.addSheetNameSpace(sheet, NameSpaceDrawingMLSpreadSheet)
+	f.addSheetNameSpace(sheet, NameSpaceSpreadSheetX14)
+	oleObject := xlsxOleObject{
+		ProgID: progID, DvAspect: "DVASPECT_ICON", ShapeID: shapeID, RID: "rId" + strconv.Itoa(objectID),
+	}
+	fallback, _ := xml.Marshal(xlsxFallback{Content: string(marshalOleObject(oleObject))})
+	oleObject.ObjectPr = &xlsxObjectPr{
+		RID: "rId" + strconv.Itoa(iconID),
+		Anchor: &xlsxObjectAnchor{
+			MoveWithCells: true,
+			From:          xlsxFrom{Col: colStart, Row: rowStart},
+			To:            xlsxTo{Col: colEnd, ColOff: x2 * EMU, Row: rowEnd, RowOff: y2 * EMU},
+		},
+	}
+	choice, _ := xml.Marshal(xlsxChoice{Requires: NameSpaceSpreadSheetX14.Name.Local, Content: string(marshalOleObject(oleObject))})
+	var buf bytes.Buffer
+	_ = xml.NewEncoder(&buf).EncodeElement(xlsxAlternateContent{
+		XMLNSMC: SourceRelationshipCompatibility.Value,
+		Content: string(choice) + string(fallback),
+	}, xml.StartElement{Name: xml.Name{Local: "mc:AlternateContent"}})
+	if ws.OleObjects == nil {
+		ws.OleObjects = &xlsxInnerXML{}
+	}
+	ws.OleObjects.Content += buf.String()
+	if err = f.setContentTypePartImageExtensions(); err != nil {
+		return err
+	}
+	if err = f.setContentTypePartVMLExtensions(); err != nil {
+		return err
+	}
+	return f.setContentTypePartDefaultExtension(strings.TrimPrefix(ext, "."), contentType)
+}
+
+// marshalOleObject returns the XML bytes of the oleObject element.
+func marshalOleObject(oleObject xlsxOleObject) []byte {
+	output, _ := xml.Marshal(oleObject)
+	return output
+}
+
+// countEmbeddings provides a function to get embedded files count storage in
+// the folder xl/embeddings.
+func (f *File) countEmbeddings() int {
+	count := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), "xl/embeddings/") {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// addOLEObjectVML provides a function to add the VML shape which represents
+// the OLE object in the legacy drawing of the worksheet, and returns the
+// shape ID. The shape ID 1025 is reserved for the comments and form controls.
+func (f *File) addOLEObjectVML(sheet, sheetRels, media, anchor string, width, height int) (int, error) {
+	ws, _ := f.workSheetReader(sheet)
+	vmlID := f.countVMLDrawing() + 1
+	sheetRelationshipsDrawingVML := "../drawings/vmlDrawing" + strconv.Itoa(vmlID) + ".vml"
+	if ws.LegacyDrawing != nil {
+		// The worksheet already has a VML relationships, use the relationships drawing ../drawings/vmlDrawing%d.vml.
+		sheetRelationshipsDrawingVML = f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID)
+		vmlID, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(sheetRelationshipsDrawingVML, "../drawings/vmlDrawing"), ".vml"))
+	} else {
+		// Add first VML drawing for given sheet.
+		rID := f.addRels(sheetRels, SourceRelationshipDrawingVML, sheetRelationshipsDrawingVML, "")
+		f.addSheetLegacyDrawing(sheet, rID)
+	}
+	drawingVML := strings.ReplaceAll(sheetRelationshipsDrawingVML, "..", "xl")
+	vml := f.VMLDrawing[drawingVML]
+	if vml == nil {
+		vml = &vmlDrawing{
+			XMLNSv: "urn:schemas-microsoft-com:vml",
+			XMLNSo: "urn:schemas-microsoft-com:office:office",
+			XMLNSx: "urn:schemas-microsoft-com:office:excel",
+			ShapeLayout: &xlsxShapeLayout{
+				Ext: "edit", IDmap: &xlsxIDmap{Ext: "edit", Data: f.getSheetID(sheet)},
+			},
+		}
+		// Load exist VML shapes from xl/drawings/vmlDrawing%d.vml
+		d, err := f.decodeVMLDrawingReader(drawingVML)
+		if err != nil {
+			return 0, err
+		}
+		if d != nil {
+			vml.ShapeType = d.shapeTypes()
+			for _, v := range d.Shape {
+				vml.Shape = append(vml.Shape, xlsxShape{
+					ID:          v.ID,
+					SpID:        v.SpID,
+					Type:        v.Type,
+					Style:       v.Style,
+					Button:      v.Button,
+					Filled:      v.Filled,
+					FillColor:   v.FillColor,
+					InsetMode:   v.InsetMode,
+					Stroked:     v.Stroked,
+					StrokeColor: v.StrokeColor,
+					Val:         v.Val,
+				})
+			}
+		}
+	}
+	vml.addShapeType(newVMLPictureShapeType())
+	shapeID := 1026
+	for _, shape := range vml.Shape {
+		if ID, err := strconv.Atoi(strings.TrimPrefix(shape.ID, "_x0000_s")); err == nil && ID >= shapeID {
+			shapeID = ID + 1
+		}
+	}
+	drawingVMLRels := "xl/drawings/_rels/vmlDrawing" + strconv.Itoa(vmlID) + ".vml.rels"
+	imageID := f.addRels(drawingVMLRels, SourceRelationshipImage, media, "")
+	sp, _ := xml.Marshal(encodeShape{
+		Fill:      &vFill{Color2: "window [65]"},
+		ImageData: &vImageData{RelID: "rId" + strconv.Itoa(imageID)},
+		ClientData: &xClientData{
+			ObjectType:    "Pict",
+			SizeWithCells: stringPtr(""),
+			Anchor:        anchor,
+			CF:            "Pict",
+			AutoPict:      stringPtr(""),
+		},
+	})
+	vml.Shape = append(vml.Shape, xlsxShape{
+		ID:          fmt.Sprintf("_x0000_s%d", shapeID),
+		Type:        "#_x0000_t75",
+		Style:       fmt.Sprintf("position:absolute;margin-left:0;margin-top:0;width:%gpt;height:%gpt;z-index:1", float64(width)*0.75, float64(height)*0.75),
+		Filled:      "t",
+		FillColor:   "window [65]",
+		Stroked:     "t",
+		StrokeColor: "windowText [64]",
+		Val:         string(sp[13 : len(sp)-14]),
+	})
+	f.VMLDrawing[drawingVML] = vml
+	return shapeID, nil
+}
+
+// setContentTypePartDefaultExtension provides a function to set the default
+// content type for the given file extension.
+func (f *File) setContentTypePartDefaultExtension(extension, contentType string) error {
+	content, err := f.contentTypesReader()
+	if err != nil {
+		return err
+	}
+	content.mu.Lock()
+	defer content.mu.Unlock()
+	for _, file := range content.Defaults {
+		if strings.EqualFold(file.Extension, extension) {
+			return err
+		}
+	}
+	content.Defaults = append(content.Defaults, xlsxDefault{
+		Extension:   extension,
+		ContentType: contentType,
+	})
+	return err
+}
+
+// GetOLEObjects provides a function to get the embedded OLE objects in a
+// worksheet by given worksheet name. The file name and the raw content of the
+// file wrapped in the OLE package object will be extracted, and the raw
+// content of the compound file binary will be returned with the ".bin"
+// extension for the other OLE objects. Note that, this function does not
+// support getting the width and height of the objects currently. For
+// example, extract the embedded files in Sheet1:
+//
+//	objects, err := f.GetOLEObjects("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for idx, object := range objects {
+//	    name := object.FileName
+//	    if name == "" {
+//	        name = fmt.Sprintf("object%d%s", idx+1, object.Extension)
+//	    }
+//	    if err := os.WriteFile(name, object.Data, 0644); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	}
+func (f *File) GetOLEObjects(sheet string) ([]OLEObject, error) {
+	var objects []OLEObject
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.OleObjects == nil {
+		return objects, err
+	}
+	var decodeObjects decodeOleObjects
+	if err = f.xmlNewDecoder(strings.NewReader("<decodeOleObjects>" + ws.OleObjects.Content + "</decodeOleObjects>")).
+		Decode(&decodeObjects); err != nil {
+		return objects, err
+	}
+	oleObjects := decodeObjects.OleObject
+	for _, ac := range decodeObjects.AlternateContent {
+		if ac.Choice != nil && ac.Choice.OleObject != nil {
+			oleObjects = append(oleObjects, *ac.Choice.OleObject)
+			continue
+		}
+		if ac.Fallback != nil && ac.Fallback.OleObject != nil {
+			oleObjects = append(oleObjects, *ac.Fallback.OleObject)
+		}
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	for _, oleObject := range oleObjects {
+		object := OLEObject{ProgID: oleObject.ProgID}
+		if part := getSheetPartPath(sheetXMLPath, f.getSheetRelationshipsTargetByID(sheet, oleObject.RID)); part != "" {
+			if content, ok := f.Pkg.Load(part); ok && content != nil {
+				object.Data, object.Extension = content.([]byte), path.Ext(part)
+			}
+		}
+		if object.Extension == ".bin" {
+			if fileName, data, ok := extractPackageOLEObject(object.Data); ok {
+				object.FileName, object.Extension, object.Data = fileName, path.Ext(fileName), data
+			}
+		}
+		if oleObject.ObjectPr == nil {
+			if object.Cell, err = f.getOLEObjectVMLCell(sheet, ws, oleObject.ShapeID); err != nil {
+				return objects, err
+			}
+			objects = append(objects, object)
+			continue
+		}
+		if part := getSheetPartPath(sheetXMLPath, f.getSheetRelationshipsTargetByID(sheet, oleObject.ObjectPr.RID)); part != "" {
+			if content, ok := f.Pkg.Load(part); ok && content != nil {
+				object.Icon, object.IconExtension = content.([]byte), path.Ext(part)
+			}
+		}
+		if oleObject.ObjectPr.Anchor != nil {
+			from := oleObject.ObjectPr.Anchor.From
+			if object.Cell, err = CoordinatesToCellName(from.Col+1, from.Row+1); err != nil {
+				return objects, err
+			}
+		}
+		objects = append(objects, object)
+	}
+	return objects, err
+}
+
+// getSheetPartPath returns the part path by given worksheet part path and the
+// relationship target in the worksheet relationships.
+func getSheetPartPath(sheetXMLPath, target string) string {
+	if target == "" {
+		return target
+	}
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Join(path.Dir(sheetXMLPath), target)
+}
+
+// getOLEObjectVMLCell provides a function to get the cell reference of the
+// OLE object by given worksheet and the VML shape ID of the object.
+func (f *File) getOLEObjectVMLCell(sheet string, ws *xlsxWorksheet, shapeID int) (string, error) {
+	if ws.LegacyDrawing == nil {
+		return "", nil
+	}
+	drawingVML := strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID), "..", "xl")
+	var shapes []xlsxShape
+	if vml := f.VMLDrawing[drawingVML]; vml != nil {
+		shapes = vml.Shape
+	} else {
+		d, err := f.decodeVMLDrawingReader(drawingVML)
+		if err != nil || d == nil {
+			return "", err
+		}
+		for _, sp := range d.Shape {
+			shapes = append(shapes, xlsxShape{ID: sp.ID, Val: sp.Val})
+		}
+	}
+	for _, sp := range shapes {
+		if sp.ID != fmt.Sprintf("_x0000_s%d", shapeID) {
+			continue
+		}
+		var shapeVal decodeShapeVal
+		if err := xml.Unmarshal([]byte(fmt.Sprintf("<shape>%s</shape>", sp.Val)), &shapeVal); err != nil {
+			return "", err
+		}
+		col, row, err := extractAnchorCell(shapeVal.ClientData.Anchor)
+		if err != nil {
+			return "", err
+		}
+		return CoordinatesToCellName(col+1, row+1)
+	}
+	return "", nil
+}
+
+// packageOLEObject returns the compound file binary of the OLE package object
+// which wraps the file by given file name and raw content.
+func packageOLEObject(fileName string, data []byte) []byte {
+	native := new(bytes.Buffer)
+	_ = binary.Write(native, binary.LittleEndian, uint16(2))
+	native.WriteString(fileName + "\x00" + fileName + "\x00")
+	_ = binary.Write(native, binary.LittleEndian, uint32(0x00030000))
+	_ = binary.Write(native, binary.LittleEndian, uint32(len(fileName)+1))
+	native.WriteString(fileName + "\x00")
+	_ = binary.Write(native, binary.LittleEndian, uint32(len(data)))
+	native.Write(data)
+	stream := new(bytes.Buffer)
+	_ = binary.Write(stream, binary.LittleEndian, uint32(native.Len()))
+	stream.Write(native.Bytes())
+	compObj := new(bytes.Buffer)
+	_ = binary.Write(compObj, binary.LittleEndian, []uint32{0xFFFE0001, 0x00000A03, 0xFFFFFFFF})
+	compObj.Write(packageCLSID)
+	for _, userType := range []string{"OLE Package", "", "Package"} {
+		if userType == "" {
+			_ = binary.Write(compObj, binary.LittleEndian, uint32(0))
+			continue
+		}
+		_ = binary.Write(compObj, binary.LittleEndian, uint32(len(userType)+1))
+		compObj.WriteString(userType + "\x00")
+	}
+	_ = binary.Write(compObj, binary.LittleEndian, []uint32{0x71B239F4, 0, 0, 0})
+	compoundFile := &cfb{
+		paths:   []string{"Root Entry/"},
+		sectors: []sector{{name: "Root Entry", typeID: 5, clsID: packageCLSID}},
+	}
+	compoundFile.put("\x01CompObj", compObj.Bytes())
+	compoundFile.put("\x01Ole10Native", stream.Bytes())
+	return compoundFile.write()
+}
+
+// extractPackageOLEObject returns the file name and the raw content of the
+// file wrapped in the OLE package object by given compound file binary.
+func extractPackageOLEObject(raw []byte) (string, []byte, bool) {
+	doc, err := mscfb.New(bytes.NewReader(raw))
+	if err != nil {
+		return "", nil, false
+	}
+	for entry, err := doc.Next(); err == nil; entry, err = doc.Next() {
+		if strings.TrimPrefix(entry.Name, "\x01") != "Ole10Native" {
+			continue
+		}
+		buf := make([]byte, entry.Size)
+		n, _ := doc.Read(buf)
+		return parseOle10Native(buf[:n])
+	}
+	return "", nil, false
+}
+
+// parseOle10Native returns the file name and the raw content of the file by
+// given the Ole10Native stream of the OLE package object.
+func parseOle10Native(buf []byte) (string, []byte, bool) {
+	var fileName string
+	// Skip the native data size and the type of the stream
+	pos := 6
+	for i := 0; i < 2; i++ {
+		if pos > len(buf) {
+			return "", nil, false
+		}
+		end := bytes.IndexByte(buf[pos:], 0)
+		if end == -1 {
+			return "", nil, false
+		}
+		if i == 0 {
+			fileName = string(buf[pos : pos+end])
+		}
+		pos += end + 1
+	}
+	// Skip the reserved field, the temporary path and its length
+	pos += 4
+	if pos+4 > len(buf) {
+		return "", nil, false
+	}
+	pos += 4 + int(binary.LittleEndian.Uint32(buf[pos:]))
+	if pos+4 > len(buf) {
+		return "", nil, false
+	}
+	size := int(binary.LittleEndian.Uint32(buf[pos:]))
+	if pos += 4; size > len(buf)-pos {
+		return "", nil, false
+	}
+	return fileName, buf[pos : pos+size], true
+}
@@ -0,0 +1,174 @@
+package excelize
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOLEObject(t *testing.T) {
+	icon, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	book, err := os.ReadFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	assert.NoError(t, f.AddOLEObject("Sheet1", &OLEObject{
+		Cell: "B2", FileName: "Report.pdf", Extension: ".pdf", Data: []byte("%PDF-1.4"), Icon: icon, IconExtension: ".png",
+	}))
+	assert.NoError(t, f.AddOLEObject("Sheet1", &OLEObject{
+		Cell: "D2", Extension: ".xlsx", Data: book, Icon: icon, IconExtension: ".png", Width: 200, Height: 100,
+	}))
+	assert.NoError(t, f.AddOLEObject("Sheet1", &OLEObject{
+		Cell: "F2", ProgID: "Acrobat.Document.DC", Extension: ".bin", Data: []byte("OLE"), Icon: icon, IconExtension: ".png",
+	}))
+	assert.NoError(t, f.AddOLEObject("Sheet1", &OLEObject{
+		Cell: "H2", Data: []byte("Text"), Icon: icon, IconExtension: ".png",
+	}))
+	objects, err := f.GetOLEObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []OLEObject{
+		{Cell: "B2", ProgID: "Package", FileName: "Report.pdf", Extension: ".pdf", Data: []byte("%PDF-1.4"), Icon: icon, IconExtension: ".png"},
+		{Cell: "D2", ProgID: "Excel.Sheet.12", Extension: ".xlsx", Data: book, Icon: icon, IconExtension: ".png"},
+		{Cell: "F2", ProgID: "Acrobat.Document.DC", Extension: ".bin", Data: []byte("OLE"), Icon: icon, IconExtension: ".png"},
+		{Cell: "H2", ProgID: "Package", FileName: "Object", Data: []byte("Text"), Icon: icon, IconExtension: ".png"},
+	}, objects)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, []string{"_x0000_t202", "_x0000_t75"}, vmlShapeTypeIDs(f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]))
+	path := filepath.Join("test", "TestOLEObject.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	// Test get OLE objects from the saved workbook
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	objects, err = f.GetOLEObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, objects, 4)
+	assert.Equal(t, "Report.pdf", objects[0].FileName)
+	assert.Equal(t, book, objects[1].Data)
+	// Test add OLE object in the worksheet with existing VML drawing part
+	assert.NoError(t, f.AddOLEObject("Sheet1", &OLEObject{
+		Cell: "J2", Extension: ".docx", Data: []byte("Document"), Icon: icon, IconExtension: ".png",
+	}))
+	assert.Equal(t, "_x0000_s1030", f.VMLDrawing["xl/drawings/vmlDrawing1.vml"].Shape[5].ID)
+	assert.Equal(t, []string{"_x0000_t202", "_x0000_t75"}, vmlShapeTypeIDs(f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]))
+	objects, err = f.GetOLEObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, OLEObject{Cell: "J2", ProgID: "Word.Document.12", Extension: ".docx", Data: []byte("Document"), Icon: icon, IconExtension: ".png"}, objects[4])
+	f.VMLDrawing = map[string]*vmlDrawing{}
+	// Test get OLE objects without object properties
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).OleObjects.Content = `<oleObject progId="Package" shapeId="1026" r:id="rId3"/><oleObject progId="Package" shapeId="2048"/>`
+	objects, err = f.GetOLEObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []OLEObject{{Cell: "B2", ProgID: "Package", FileName: "Report.pdf", Extension: ".pdf", Data: []byte("%PDF-1.4")}, {ProgID: "Package"}}, objects)
+	// Test get OLE objects with invalid VML anchor
+	f.DecodeVMLDrawing["xl/drawings/vmlDrawing1.vml"].Shape[1].Val = "<x:ClientData><x:Anchor>A</x:Anchor></x:ClientData>"
+	_, err = f.GetOLEObjects("Sheet1")
+	assert.Equal(t, ErrParameterInvalid, err)
+	f.DecodeVMLDrawing["xl/drawings/vmlDrawing1.vml"].Shape[1].Val = "<x:ClientData>"
+	_, err = f.GetOLEObjects("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: element <ClientData> closed by </shape>")
+	// Test get OLE objects with invalid anchor
+	ws.(*xlsxWorksheet).OleObjects.Content = `<oleObject><objectPr><anchor><from><col>-1</col></from></anchor></objectPr></oleObject>`
+	_, err = f.GetOLEObjects("Sheet1")
+	assert.Equal(t, newCoordinatesToCellNameError(0, 1), err)
+	// Test get OLE objects with invalid OLE objects
+	ws.(*xlsxWorksheet).OleObjects.Content = `<oleObject>`
+	_, err = f.GetOLEObjects("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: element <oleObject> closed by </decodeOleObjects>")
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test add OLE object with invalid options
+	assert.Equal(t, ErrParameterInvalid, f.AddOLEObject("Sheet1", nil))
+	assert.Equal(t, ErrImgExt, f.AddOLEObject("Sheet1", &OLEObject{Data: []byte("Text"), Icon: icon, IconExtension: ".txt"}))
+	assert.Equal(t, ErrImgExt, f.AddOLEObject("Sheet1", &OLEObject{Data: []byte("Text"), IconExtension: ".png"}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddOLEObject("Sheet1", &OLEObject{Cell: "A", Data: []byte("Text"), Icon: icon, IconExtension: ".png"}))
+	assert.Equal(t, ErrParameterInvalid, f.AddOLEObject("Sheet1", &OLEObject{Cell: "A1", Extension: ".bin", Data: []byte("Text"), Icon: icon, IconExtension: ".png"}))
+	// Test add OLE object on not exists worksheet
+	assert.EqualError(t, f.AddOLEObject("SheetN", &OLEObject{Cell: "A1", Data: []byte("Text"), Icon: icon, IconExtension: ".png"}), "sheet SheetN does not exist")
+	_, err = f.GetOLEObjects("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test add OLE object with unsupported charset VML drawing
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).LegacyDrawing = &xlsxLegacyDrawing{RID: "rId1"}
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddOLEObject("Sheet1", &OLEObject{Cell: "A1", Data: []byte("Text"), Icon: icon, IconExtension: ".png"}), "XML syntax error on line 2: invalid UTF-8")
+	// Test add OLE object with unsupported charset content types
+	f = NewFile()
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.setContentTypePartDefaultExtension("bin", ContentTypeOLEObject), "XML syntax error on line 1: invalid UTF-8")
+	f = NewFile()
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddOLEObject("Sheet1", &OLEObject{Cell: "A1", Data: []byte("Text"), Icon: icon, IconExtension: ".png"}), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestExtractPackageOLEObject(t *testing.T) {
+	_, _, ok := extractPackageOLEObject([]byte("OLE"))
+	assert.False(t, ok)
+	compoundFile := &cfb{
+		paths:   []string{"Root Entry/"},
+		sectors: []sector{{name: "Root Entry", typeID: 5}},
+	}
+	compoundFile.put("Contents", []byte("OLE"))
+	_, _, ok = extractPackageOLEObject(compoundFile.write())
+	assert.False(t, ok)
+	for _, buf := range [][]byte{
+		make([]byte, 4),
+		append(make([]byte, 6), 'a'),
+		append(make([]byte, 6), 'a', 0, 'b'),
+		append(make([]byte, 6), 'a', 0, 'b', 0),
+		append(make([]byte, 6), 'a', 0, 'b', 0, 0, 0, 0, 0, 1, 0, 0, 0, 0),
+		append(make([]byte, 6), 'a', 0, 'b', 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 2, 0, 0, 0, 'c'),
+	} {
+		_, _, ok = parseOle10Native(buf)
+		assert.False(t, ok)
+	}
+}
+
+func TestOLEObjectWithComments(t *testing.T) {
+	icon, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	// Test add comment after adding OLE object in the same VML drawing
+	f := NewFile()
+	assert.NoError(t, f.AddOLEObject("Sheet1", &OLEObject{Cell: "B2", Data: []byte("Text"), Icon: icon, IconExtension: ".png"}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	assert.Equal(t, []string{"_x0000_t75", "_x0000_t202"}, vmlShapeTypeIDs(f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]))
+	path := filepath.Join("test", "TestOLEObjectWithComments.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	objects, err := f.GetOLEObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, objects, 1)
+	// Test add comment keeps the shape types of the existing VML drawing
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "C3", Author: "Excelize", Text: "Comment"}))
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	assert.Equal(t, []string{"_x0000_t75", "_x0000_t202"}, vmlShapeTypeIDs(vml))
+	assert.Contains(t, vml.ShapeType[0].Val, "v:formulas")
+	assert.NoError(t, f.Close())
+}
+
+// vmlShapeTypeIDs returns the IDs of the shape types in the VML drawing.
+func vmlShapeTypeIDs(vml *vmlDrawing) []string {
+	var IDs []string
+	for _, st := range vml.ShapeType {
+		IDs = append(IDs, st.ID)
+	}
+	return IDs
+}
@@ -138,7 +138,8 @@ func parseGraphicOptions(opts *GraphicOptions) *GraphicOptions {
 // for the graph object, the default value of that is 'false'.
 //
 // The optional parameter "AutoFit" specifies if you make graph object size
-// auto-fits the cell, the default value of that is 'false'.
+// auto-fits the cell, the default value of that is 'false'. If the cell is in
+// a merged range, the graph object will fit the merged range of cells.
 //
 // The optional parameter "AutoFitIgnoreAspect" specifies if fill the cell with
 // the image and ignore its aspect ratio, the default value of that is 'false'.
@@ -156,6 +157,14 @@ func parseGraphicOptions(opts *GraphicOptions) *GraphicOptions {
 // The optional parameter "ScaleY" specifies the vertical scale of graph object,
 // the default value of that is 1.0 which presents 100%.
 //
+// The optional parameter "Width" specifies the width of the picture in pixels,
+// the "ScaleX" will be ignored if this parameter was set. This option doesn't
+// work when the "AutoFit" is enabled.
+//
+// The optional parameter "Height" specifies the height of the picture in
+// pixels, the "ScaleY" will be ignored if this parameter was set. This option
+// doesn't work when the "AutoFit" is enabled.
+//
 // The optional parameter "Hyperlink" specifies the hyperlink of the graph
 // object.
 //
@@ -286,6 +295,35 @@ func (f *File) AddPictureFromBytes(sheet, cell string, pic *Picture) error {
 	return err
 }
 
+// AddPictureFromReader provides the method to add picture in a sheet by given
+// picture format set, extension name and the reader of the image content, so
+// that the images generated in memory or downloaded from the network can be
+// inserted without writing to disk first. Supported image types are the same
+// with the AddPictureFromBytes function. For example, add a picture from a
+// URL:
+//
+//	resp, err := http.Get("https://github.com/xuri/excelize/raw/master/test/images/excel.png")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer resp.Body.Close()
+//	if err := f.AddPictureFromReader("Sheet1", "A2", ".png", resp.Body,
+//	    &excelize.GraphicOptions{AltText: "Excel Logo"},
+//	); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) AddPictureFromReader(sheet, cell, extension string, r io.Reader, opts *GraphicOptions) error {
+	if _, ok := supportedImageTypes[strings.ToLower(extension)]; !ok {
+		return ErrImgExt
+	}
+	file, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return f.AddPictureFromBytes(sheet, cell, &Picture{Extension: extension, File: file, Format: opts})
+}
+
 // addSheetLegacyDrawing provides a function to add legacy drawing element to
 // xl/worksheets/sheet%d.xml by given worksheet name and relationship index.
 func (f *File) addSheetLegacyDrawing(sheet string, rID int) {
@@ -365,6 +403,12 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, hyper
 	} else {
 		width = int(float64(width) * opts.ScaleX)
 		height = int(float64(height) * opts.ScaleY)
+		if opts.Width > 0 {
+			width = int(opts.Width)
+		}
+		if opts.Height > 0 {
+			height = int(opts.Height)
+		}
 	}
 	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(sheet, col, row, opts.OffsetX, opts.OffsetY, width, height)
 	content, cNvPrID, err := f.drawingParser(drawingXML)
@@ -520,7 +564,25 @@ func (f *File) GetPictures(sheet, cell string) ([]Picture, error) {
 }
 
 // GetPictureCells returns all picture cell references in a worksheet by a
-// specific worksheet name.
+// specific worksheet name. Use it with the GetPictures function to extract all
+// pictures in a worksheet, the anchor cell, image bytes and format of each
+// picture can be read. For example, get all pictures on Sheet1:
+//
+//	cells, err := f.GetPictureCells("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, cell := range cells {
+//	    pics, err := f.GetPictures("Sheet1", cell)
+//	    if err != nil {
+//	        fmt.Println(err)
+//	        return
+//	    }
+//	    for _, pic := range pics {
+//	        fmt.Println(cell, pic.Extension, len(pic.File), pic.InsertType)
+//	    }
+//	}
 func (f *File) GetPictureCells(sheet string) ([]string, error) {
 	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
@@ -549,7 +611,23 @@ func (f *File) GetPictureCells(sheet string) ([]string, error) {
 }
 
 // DeletePicture provides a function to delete all pictures in a cell by given
-// worksheet name and cell reference.
+// worksheet name and cell reference. The image file will be deleted from the
+// workbook if it's not used by other pictures. For example, replace the
+// picture in the cell A2 on Sheet1:
+//
+//	if err := f.DeletePicture("Sheet1", "A2"); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	file, err := os.ReadFile("image.png")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.AddPictureFromBytes("Sheet1", "A2", &excelize.Picture{
+//	    Extension: ".png",
+//	    File:      file,
+//	})
 func (f *File) DeletePicture(sheet, cell string) error {
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
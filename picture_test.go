@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"bytes"
 	"fmt"
 	"image"
 	_ "image/gif"
@@ -11,6 +12,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/stretchr/testify/assert"
 	_ "golang.org/x/image/bmp"
@@ -118,6 +120,33 @@ func TestAddPicture(t *testing.T) {
 	assert.EqualError(t, f.AddPicture("Sheet:1", "A1", filepath.Join("test", "images", "excel.jpg"), nil), ErrSheetNameInvalid.Error())
 }
 
+func TestAddPictureWithSize(t *testing.T) {
+	f := NewFile()
+	// Test add picture with explicit width and height in pixels
+	assert.NoError(t, f.AddPicture("Sheet1", "B2", filepath.Join("test", "images", "excel.jpg"), &GraphicOptions{Width: 128, Height: 36, ScaleX: 0.5, Positioning: "absolute"}))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	anchor := drawing.(*xlsxWsDr).TwoCellAnchor[0]
+	assert.Equal(t, "absolute", anchor.EditAs)
+	assert.Equal(t, []int{1, 1, 3, 3}, []int{anchor.From.Col, anchor.From.Row, anchor.To.Col, anchor.To.Row})
+	assert.Equal(t, []int{0, 0}, []int{anchor.To.ColOff, anchor.To.RowOff})
+}
+
+func TestAddPictureFromReader(t *testing.T) {
+	f := NewFile()
+	file, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddPictureFromReader("Sheet1", "A1", ".png", bytes.NewReader(file), &GraphicOptions{AltText: "Excel Logo"}))
+	pics, err := f.GetPictures("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.Equal(t, file, pics[0].File)
+	// Test add picture from reader with unsupported image extension
+	assert.Equal(t, ErrImgExt, f.AddPictureFromReader("Sheet1", "A1", ".txt", bytes.NewReader(file), nil))
+	// Test add picture from reader with read error
+	assert.Equal(t, iotest.ErrTimeout, f.AddPictureFromReader("Sheet1", "A1", ".png", iotest.TimeoutReader(iotest.OneByteReader(bytes.NewReader(file))), nil))
+}
+
 func TestAddPictureErrors(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
@@ -16,17 +16,26 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"math"
 	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
-
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
+	"time"
 )
 
 // PivotTableOptions directly maps the format settings of the pivot table.
 //
+// Filter specifies the report filter (page) fields of the pivot table, the
+// report filters will be displayed as drop-down lists in a single column
+// above the pivot table, so that the PivotTableRange should leave enough rows
+// above for the report filters.
+//
+// SaveData specifies whether to save the source data in the pivot cache
+// records, the unique items of each field will be stored as shared items of
+// the pivot cache, so that the spreadsheet application could render the
+// pivot table without reading the source data range.
+//
 // PivotTableStyleName: The built-in pivot table style names
 //
 //	PivotStyleLight1 - PivotStyleLight28
@@ -61,6 +70,7 @@ type PivotTableOptions struct {
 	ShowLastColumn      bool
 	FieldPrintTitles    bool
 	ItemPrintTitles     bool
+	SaveData            bool
 	PivotTableStyleName string
 }
 
@@ -88,6 +98,27 @@ type PivotTableOptions struct {
 // NumFmt specifies the number format ID of the data field, this filed only
 // accepts built-in number format ID and does not support custom number format
 // expression currently.
+//
+// GroupBy specifies the grouping of the values in the rows or columns field,
+// the start and end of the group will be detected from the data range
+// automatically. The possible values for this attribute are:
+//
+//	Seconds
+//	Minutes
+//	Hours
+//	Days
+//	Months
+//	Quarters
+//	Years
+//	Range
+//
+// The Range grouping groups the numeric values, and other values group the
+// date-time values of the field.
+//
+// GroupInterval specifies the interval of the numeric values for the Range
+// grouping, or the number of days for the Days grouping. The default value
+// is 1. An error will be returned if the grouping is unsupported or the
+// number of group items exceeds 1048576.
 type PivotTableField struct {
 	Compact         bool
 	Data            string
@@ -98,6 +129,8 @@ type PivotTableField struct {
 	Subtotal        string
 	DefaultSubtotal bool
 	NumFmt          int
+	GroupBy         string
+	GroupInterval   float64
 }
 
 // AddPivotTable provides the method to add pivot table by given pivot table
@@ -189,6 +222,11 @@ func (f *File) AddPivotTable(opts *PivotTableOptions) error {
 	if err = f.addContentTypePart(pivotTableID, "pivotTable"); err != nil {
 		return err
 	}
+	if opts.SaveData {
+		if err = f.addContentTypePart(pivotCacheID, "pivotRecords"); err != nil {
+			return err
+		}
+	}
 	return f.addContentTypePart(pivotCacheID, "pivotCache")
 }
 
@@ -320,11 +358,405 @@ func (f *File) addPivotCache(opts *PivotTableOptions) error {
 		})
 	}
 	pc.CacheFields.Count = len(pc.CacheFields.CacheField)
+	if err = f.addPivotCacheFieldGroups(&pc, dataSheet, coordinates, opts); err != nil {
+		return err
+	}
+	if opts.SaveData {
+		if err = f.addPivotCacheRecords(&pc, dataSheet, coordinates, opts); err != nil {
+			return err
+		}
+	}
 	pivotCache, err := xml.Marshal(pc)
 	f.saveFileList(opts.pivotCacheXML, pivotCache)
 	return err
 }
 
+// pivotCacheItem directly maps the value of the pivot cache record, the type
+// of the value is one of "m" (missing), "n" (numeric) or "s" (character).
+type pivotCacheItem struct {
+	typ, val string
+}
+
+// getPivotCacheItems provides a function to get the values of the pivot cache
+// records in the given column of the data range, the header row of the data
+// range will be skipped.
+func (f *File) getPivotCacheItems(dataSheet string, col, fromRow, toRow int) ([]pivotCacheItem, error) {
+	var items []pivotCacheItem
+	for row := fromRow; row <= toRow; row++ {
+		cell, _ := CoordinatesToCellName(col, row)
+		val, err := f.GetCellValue(dataSheet, cell, Options{RawCellValue: true})
+		if err != nil {
+			return items, err
+		}
+		if val == "" {
+			items = append(items, pivotCacheItem{typ: "m"})
+			continue
+		}
+		cellType, _ := f.getCellType(dataSheet, cell, false)
+		if cellType == CellTypeUnset || cellType == CellTypeNumber {
+			if _, err = strconv.ParseFloat(val, 64); err == nil {
+				items = append(items, pivotCacheItem{typ: "n", val: val})
+				continue
+			}
+		}
+		items = append(items, pivotCacheItem{typ: "s", val: val})
+	}
+	return items, nil
+}
+
+// setPivotCacheSharedItems provides a function to set the shared items of the
+// pivot cache field by given the values of the pivot cache records, and
+// returns the index of the shared item for each value. The shared items are
+// ordered by missing, numeric and character values, which is the same as the
+// serialization order of the shared items.
+func setPivotCacheSharedItems(field *xlsxCacheField, items []pivotCacheItem) []int {
+	var (
+		sharedItems               = &xlsxSharedItems{}
+		missing, numbers, strs    []string
+		dates                     []string
+		unique                    = map[pivotCacheItem]int{}
+		indexes                   = make([]int, len(items))
+		minValue, maxValue        float64
+		hasNumber, isInteger      = false, true
+		containsString, semiMixed bool
+	)
+	for _, item := range items {
+		if _, ok := unique[item]; ok {
+			continue
+		}
+		unique[item] = 0
+		switch item.typ {
+		case "m":
+			missing = append(missing, item.val)
+		case "n":
+			numbers = append(numbers, item.val)
+			num, _ := strconv.ParseFloat(item.val, 64)
+			if !hasNumber || num < minValue {
+				minValue = num
+			}
+			if !hasNumber || num > maxValue {
+				maxValue = num
+			}
+			hasNumber, isInteger = true, isInteger && num == float64(int64(num))
+		case "d":
+			dates = append(dates, item.val)
+			if sharedItems.MinDate == "" || item.val < sharedItems.MinDate {
+				sharedItems.MinDate = item.val
+			}
+			if sharedItems.MaxDate == "" || item.val > sharedItems.MaxDate {
+				sharedItems.MaxDate = item.val
+			}
+		default:
+			strs = append(strs, item.val)
+		}
+	}
+	for idx, val := range missing {
+		unique[pivotCacheItem{typ: "m", val: val}] = idx
+		sharedItems.M = append(sharedItems.M, xlsxMissing{})
+	}
+	for idx, val := range numbers {
+		unique[pivotCacheItem{typ: "n", val: val}] = len(missing) + idx
+		num, _ := strconv.ParseFloat(val, 64)
+		sharedItems.N = append(sharedItems.N, xlsxNumber{V: num})
+	}
+	for idx, val := range strs {
+		unique[pivotCacheItem{typ: "s", val: val}] = len(missing) + len(numbers) + idx
+		sharedItems.S = append(sharedItems.S, xlsxString{V: val})
+	}
+	for idx, val := range dates {
+		unique[pivotCacheItem{typ: "d", val: val}] = len(missing) + len(numbers) + len(strs) + idx
+		sharedItems.D = append(sharedItems.D, xlsxDateTime{V: val})
+	}
+	for idx, item := range items {
+		indexes[idx] = unique[item]
+	}
+	containsString, semiMixed = len(strs) > 0, len(strs) > 0 || len(missing) > 0
+	sharedItems.ContainsBlank = len(missing) > 0
+	sharedItems.ContainsMixedTypes = len(strs) > 0 && hasNumber
+	sharedItems.Count = len(missing) + len(numbers) + len(strs) + len(dates)
+	if len(dates) > 0 {
+		sharedItems.ContainsDate = true
+		if len(strs) == 0 && !hasNumber {
+			sharedItems.ContainsNonDate = boolPtr(false)
+		}
+	}
+	if !containsString {
+		sharedItems.ContainsString = boolPtr(false)
+	}
+	if !semiMixed {
+		sharedItems.ContainsSemiMixedTypes = boolPtr(false)
+	}
+	if hasNumber {
+		sharedItems.ContainsNumber, sharedItems.ContainsInteger = true, isInteger
+		sharedItems.MinValue, sharedItems.MaxValue = minValue, maxValue
+	}
+	field.SharedItems = sharedItems
+	return indexes
+}
+
+// pivotCacheDateItems provides a function to convert the numeric values of
+// the pivot cache records to the date-time values.
+func (f *File) pivotCacheDateItems(items []pivotCacheItem) []pivotCacheItem {
+	date1904 := f.isDate1904()
+	dateItems := make([]pivotCacheItem, len(items))
+	for idx, item := range items {
+		dateItems[idx] = item
+		if item.typ == "n" {
+			num, _ := strconv.ParseFloat(item.val, 64)
+			dateItems[idx] = pivotCacheItem{typ: "d", val: timeFromExcelTime(num, date1904).Format(pivotCacheDateLayout)}
+		}
+	}
+	return dateItems
+}
+
+// isDate1904 provides a function to check if the workbook uses the 1904 date
+// system.
+func (f *File) isDate1904() bool {
+	wb, _ := f.workbookReader()
+	return wb != nil && wb.WorkbookPr != nil && wb.WorkbookPr.Date1904
+}
+
+// pivotCacheGroupItems defined the labels of the group items for each date
+// grouping of the pivot cache field.
+var pivotCacheGroupItems = map[string]func() []string{
+	"seconds": func() []string {
+		return pivotCacheGroupLabels(60, func(i int) string { return fmt.Sprintf(":%02d", i) })
+	},
+	"minutes": func() []string {
+		return pivotCacheGroupLabels(60, func(i int) string { return fmt.Sprintf(":%02d", i) })
+	},
+	"hours": func() []string {
+		return pivotCacheGroupLabels(24, func(i int) string {
+			return time.Date(2000, 1, 1, i, 0, 0, 0, time.UTC).Format("3 PM")
+		})
+	},
+	"days": func() []string {
+		return pivotCacheGroupLabels(366, func(i int) string {
+			return time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, i).Format("2-Jan")
+		})
+	},
+	"months": func() []string {
+		return pivotCacheGroupLabels(12, func(i int) string { return time.Month(i + 1).String()[:3] })
+	},
+	"quarters": func() []string {
+		return pivotCacheGroupLabels(4, func(i int) string { return fmt.Sprintf("Qtr%d", i+1) })
+	},
+}
+
+// pivotCacheGroupLabels provides a function to generate the labels of group
+// items by given count of items and label function.
+func pivotCacheGroupLabels(count int, fn func(i int) string) []string {
+	labels := make([]string, count)
+	for i := range labels {
+		labels[i] = fn(i)
+	}
+	return labels
+}
+
+// getPivotTableFieldGroup provides a function to get the grouping settings
+// of the field in the rows, columns or report filter area by given field name
+// and pivot table options.
+func getPivotTableFieldGroup(name string, opts *PivotTableOptions) (string, float64, error) {
+	for _, fields := range [][]PivotTableField{opts.Rows, opts.Columns, opts.Filter} {
+		for _, field := range fields {
+			if field.Data == name && field.GroupBy != "" {
+				groupBy := strings.ToLower(field.GroupBy)
+				if _, ok := pivotCacheGroupItems[groupBy]; !ok && groupBy != "years" && groupBy != "range" {
+					return "", 0, ErrParameterInvalid
+				}
+				interval := field.GroupInterval
+				if interval <= 0 {
+					interval = 1
+				}
+				return groupBy, interval, nil
+			}
+		}
+	}
+	return "", 0, nil
+}
+
+// addPivotCacheFieldGroups provides a function to set the range grouping of
+// the pivot cache fields by given pivot cache definition, data sheet name,
+// coordinates of the data range and pivot table options.
+func (f *File) addPivotCacheFieldGroups(pc *xlsxPivotCacheDefinition, dataSheet string, coordinates []int, opts *PivotTableOptions) error {
+	for fieldIdx, field := range pc.CacheFields.CacheField {
+		groupBy, interval, err := getPivotTableFieldGroup(field.Name, opts)
+		if err != nil {
+			return err
+		}
+		if groupBy == "" {
+			continue
+		}
+		items, err := f.getPivotCacheItems(dataSheet, coordinates[0]+fieldIdx, coordinates[1]+1, coordinates[3])
+		if err != nil {
+			return err
+		}
+		var (
+			minValue, maxValue float64
+			hasNumber, isInt   = false, true
+			containsBlank      bool
+		)
+		for _, item := range items {
+			if item.typ == "m" {
+				containsBlank = true
+			}
+			if item.typ != "n" {
+				continue
+			}
+			num, _ := strconv.ParseFloat(item.val, 64)
+			if !hasNumber || num < minValue {
+				minValue = num
+			}
+			if !hasNumber || num > maxValue {
+				maxValue = num
+			}
+			hasNumber, isInt = true, isInt && num == math.Trunc(num)
+		}
+		if !hasNumber {
+			continue
+		}
+		field.FieldGroup = &xlsxFieldGroup{Base: intPtr(fieldIdx)}
+		field.SharedItems = &xlsxSharedItems{ContainsBlank: containsBlank, ContainsSemiMixedTypes: boolPtr(containsBlank), ContainsString: boolPtr(false)}
+		if groupBy == "range" {
+			err = setPivotCacheFieldRangeGroup(field, minValue, maxValue, interval, isInt)
+		} else {
+			err = setPivotCacheFieldDateGroup(field, groupBy, minValue, maxValue, interval, f.isDate1904())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setPivotCacheFieldRangeGroup provides a function to set the numeric range
+// grouping of the pivot cache field by given the minimum and maximum values of
+// the field, group interval and if all values are integer. It returns an
+// error if the number of group items exceeds the limit.
+func setPivotCacheFieldRangeGroup(field *xlsxCacheField, minValue, maxValue, interval float64, isInt bool) error {
+	startNum, endNum := math.Floor(minValue), maxValue
+	count := math.Floor((endNum-startNum)/interval) + 1
+	if count+2 > TotalRows {
+		return ErrPivotTableGroupItems
+	}
+	field.SharedItems.ContainsNumber, field.SharedItems.ContainsInteger = true, isInt
+	field.SharedItems.MinValue, field.SharedItems.MaxValue = minValue, maxValue
+	if !isInt {
+		field.SharedItems.ContainsSemiMixedTypes = boolPtr(false)
+	}
+	labels := []string{"<" + strconv.FormatFloat(startNum, 'f', -1, 64)}
+	for i := 0; i < int(count); i++ {
+		value := startNum + float64(i)*interval
+		upper := value + interval
+		if isInt {
+			upper--
+		}
+		labels = append(labels, strconv.FormatFloat(value, 'f', -1, 64)+"-"+strconv.FormatFloat(upper, 'f', -1, 64))
+	}
+	labels = append(labels, ">"+strconv.FormatFloat(startNum+count*interval, 'f', -1, 64))
+	field.FieldGroup.RangePr = &xlsxRangePr{
+		StartNum:      float64Ptr(startNum),
+		EndNum:        float64Ptr(endNum),
+		GroupInterval: interval,
+	}
+	setPivotCacheFieldGroupItems(field, labels)
+	return nil
+}
+
+// setPivotCacheFieldDateGroup provides a function to set the date-time
+// grouping of the pivot cache field by given group type, the minimum and
+// maximum serial number of the date-time, group interval and if the workbook
+// uses the 1904 date system. It returns an error if the number of group items
+// exceeds the limit.
+func setPivotCacheFieldDateGroup(field *xlsxCacheField, groupBy string, minValue, maxValue, interval float64, date1904 bool) error {
+	startDate := timeFromExcelTime(math.Floor(minValue), date1904)
+	endDate := timeFromExcelTime(math.Floor(maxValue), date1904).AddDate(0, 0, 1)
+	field.SharedItems.ContainsNonDate, field.SharedItems.ContainsDate = boolPtr(false), true
+	field.SharedItems.MinDate = timeFromExcelTime(minValue, date1904).Format(pivotCacheDateLayout)
+	field.SharedItems.MaxDate = timeFromExcelTime(maxValue, date1904).Format(pivotCacheDateLayout)
+	var labels []string
+	switch {
+	case groupBy == "years":
+		for year := startDate.Year(); year <= endDate.AddDate(0, 0, -1).Year(); year++ {
+			labels = append(labels, strconv.Itoa(year))
+		}
+	case groupBy == "days" && interval > 1:
+		totalDays, step := math.Floor(maxValue)-math.Floor(minValue)+1, math.Floor(interval)
+		count := math.Ceil(totalDays / step)
+		if count+2 > TotalRows {
+			return ErrPivotTableGroupItems
+		}
+		days := int(math.Min(step, totalDays))
+		for i := 0; i < int(count); i++ {
+			date := startDate.AddDate(0, 0, i*days)
+			labels = append(labels, date.Format("1/2/2006")+" - "+date.AddDate(0, 0, days-1).Format("1/2/2006"))
+		}
+	default:
+		labels = pivotCacheGroupItems[groupBy]()
+	}
+	field.FieldGroup.RangePr = &xlsxRangePr{
+		GroupBy:   groupBy,
+		StartDate: startDate.Format(pivotCacheDateLayout),
+		EndDate:   endDate.Format(pivotCacheDateLayout),
+	}
+	if groupBy == "days" && interval > 1 {
+		field.FieldGroup.RangePr.GroupInterval = interval
+	}
+	labels = append([]string{"<" + startDate.Format("1/2/2006")}, labels...)
+	setPivotCacheFieldGroupItems(field, append(labels, ">"+endDate.Format("1/2/2006")))
+	return nil
+}
+
+// setPivotCacheFieldGroupItems provides a function to set the group items of
+// the pivot cache field by given labels.
+func setPivotCacheFieldGroupItems(field *xlsxCacheField, labels []string) {
+	field.FieldGroup.GroupItems = &xlsxGroupItems{Count: len(labels)}
+	for _, label := range labels {
+		field.FieldGroup.GroupItems.S = append(field.FieldGroup.GroupItems.S, xlsxString{V: label})
+	}
+}
+
+// addPivotCacheRecords provides a function to create the pivot cache records
+// part by given pivot cache definition, data sheet name, coordinates of the
+// data range and pivot table options. The values of the fields on the rows,
+// columns or report filter area, and the fields contain character values
+// will be stored as shared items of the pivot cache field, and the numeric
+// values of the other fields will be stored in the records directly.
+func (f *File) addPivotCacheRecords(pc *xlsxPivotCacheDefinition, dataSheet string, coordinates []int, opts *PivotTableOptions) error {
+	recordCount := coordinates[3] - coordinates[1]
+	records := xlsxPivotCacheRecords{Count: recordCount, R: make([]xlsxPivotCacheRecord, recordCount)}
+	for fieldIdx, field := range pc.CacheFields.CacheField {
+		items, err := f.getPivotCacheItems(dataSheet, coordinates[0]+fieldIdx, coordinates[1]+1, coordinates[3])
+		if err != nil {
+			return err
+		}
+		fieldGroup := field.FieldGroup
+		if fieldGroup != nil && fieldGroup.RangePr.GroupBy != "" {
+			items = f.pivotCacheDateItems(items)
+		}
+		indexes := setPivotCacheSharedItems(field, items)
+		shared := inPivotTableField(opts.Rows, field.Name) != -1 || inPivotTableField(opts.Columns, field.Name) != -1 ||
+			inPivotTableField(opts.Filter, field.Name) != -1 || field.SharedItems.ContainsString == nil
+		if !shared {
+			field.SharedItems.M, field.SharedItems.N, field.SharedItems.Count = nil, nil, 0
+		}
+		for idx, item := range items {
+			value := xlsxPivotCacheRecordValue{XMLName: xml.Name{Local: item.typ}, V: item.val}
+			if shared {
+				value = xlsxPivotCacheRecordValue{XMLName: xml.Name{Local: "x"}, V: strconv.Itoa(indexes[idx])}
+			}
+			records.R[idx].Values = append(records.R[idx].Values, value)
+		}
+	}
+	pivotCacheRecordsXML := strings.Replace(opts.pivotCacheXML, "pivotCacheDefinition", "pivotCacheRecords", 1)
+	pivotCacheRels := "xl/pivotCache/_rels/" + filepath.Base(opts.pivotCacheXML) + ".rels"
+	rID := f.addRels(pivotCacheRels, SourceRelationshipPivotCacheRecords, filepath.Base(pivotCacheRecordsXML), "")
+	pc.RID, pc.SaveData, pc.RecordCount = "rId"+strconv.Itoa(rID), true, recordCount
+	pivotCacheRecords, err := xml.Marshal(records)
+	f.saveFileList(pivotCacheRecordsXML, pivotCacheRecords)
+	return err
+}
+
 // addPivotTable provides a function to create a pivot table by given pivot
 // table ID and properties.
 func (f *File) addPivotTable(cacheID, pivotTableID int, opts *PivotTableOptions) error {
@@ -458,9 +890,11 @@ func (f *File) addPivotPageFields(pt *xlsxPivotTableDefinition, opts *PivotTable
 		})
 	}
 
-	// count page fields
+	// count page fields, the report filters are placed in a single column
+	// above the pivot table
 	if pt.PageFields != nil {
 		pt.PageFields.Count = len(pt.PageFields.PageField)
+		pt.Location.RowPageCount, pt.Location.ColPageCount = pt.PageFields.Count, 1
 	}
 	return err
 }
@@ -564,7 +998,11 @@ func (f *File) addPivotFields(pt *xlsxPivotTableDefinition, opts *PivotTableOpti
 		return err
 	}
 	x := 0
-	for _, name := range order {
+	pc, err := f.pivotCacheReader(opts.pivotCacheXML)
+	if err != nil {
+		return err
+	}
+	for fieldIdx, name := range order {
 		if inPivotTableField(opts.Rows, name) != -1 {
 			rowOptions, ok := f.getPivotTableFieldOptions(name, opts.Rows)
 			var items []*xlsxItem
@@ -573,6 +1011,9 @@ func (f *File) addPivotFields(pt *xlsxPivotTableDefinition, opts *PivotTableOpti
 			} else {
 				items = append(items, &xlsxItem{T: "default"})
 			}
+			if sharedItems := getPivotFieldSharedItems(pc, fieldIdx, rowOptions.DefaultSubtotal); sharedItems != nil {
+				items = sharedItems
+			}
 			fld := &xlsxPivotField{
 				Name:            f.getPivotTableFieldName(name, opts.Rows),
 				Axis:            "axisRow",
@@ -592,10 +1033,15 @@ func (f *File) addPivotFields(pt *xlsxPivotTableDefinition, opts *PivotTableOpti
 			continue
 		}
 		if inPivotTableField(opts.Filter, name) != -1 {
+			filterOptions, _ := f.getPivotTableFieldOptions(name, opts.Filter)
 			fld := &xlsxPivotField{
-				Axis:      "axisPage",
-				DataField: inPivotTableField(opts.Data, name) != -1,
-				Name:      f.getPivotTableFieldName(name, opts.Columns),
+				Axis:           "axisPage",
+				DataField:      inPivotTableField(opts.Data, name) != -1,
+				Name:           f.getPivotTableFieldName(name, opts.Filter),
+				Compact:        &filterOptions.Compact,
+				Outline:        &filterOptions.Outline,
+				ShowAll:        filterOptions.ShowAll,
+				InsertBlankRow: filterOptions.InsertBlankRow,
 				Items: &xlsxItems{
 					Count: 1,
 					Item: []*xlsxItem{
@@ -603,6 +1049,9 @@ func (f *File) addPivotFields(pt *xlsxPivotTableDefinition, opts *PivotTableOpti
 					},
 				},
 			}
+			if sharedItems := getPivotFieldSharedItems(pc, fieldIdx, true); sharedItems != nil {
+				fld.Items = &xlsxItems{Count: len(sharedItems), Item: sharedItems}
+			}
 			fld.setClassicLayout(opts.ClassicLayout)
 			pt.PivotFields.PivotField = append(pt.PivotFields.PivotField, fld)
 			continue
@@ -615,6 +1064,9 @@ func (f *File) addPivotFields(pt *xlsxPivotTableDefinition, opts *PivotTableOpti
 			} else {
 				items = append(items, &xlsxItem{T: "default"})
 			}
+			if sharedItems := getPivotFieldSharedItems(pc, fieldIdx, columnOptions.DefaultSubtotal); sharedItems != nil {
+				items = sharedItems
+			}
 			fld := &xlsxPivotField{
 				Name:            f.getPivotTableFieldName(name, opts.Columns),
 				Axis:            "axisCol",
@@ -648,29 +1100,65 @@ func (f *File) addPivotFields(pt *xlsxPivotTableDefinition, opts *PivotTableOpti
 	return err
 }
 
+// getPivotFieldSharedItems provides a function to get the items of the pivot
+// field by given pivot cache definition, index of the field and if the field
+// displays the default subtotal. Each item references a shared item of the
+// pivot cache field, returns nil if the pivot cache field has no shared items.
+func getPivotFieldSharedItems(pc *xlsxPivotCacheDefinition, fieldIdx int, defaultSubtotal bool) []*xlsxItem {
+	if pc.CacheFields == nil || fieldIdx >= len(pc.CacheFields.CacheField) {
+		return nil
+	}
+	count := 0
+	if sharedItems := pc.CacheFields.CacheField[fieldIdx].SharedItems; sharedItems != nil {
+		count = sharedItems.Count
+	}
+	if fieldGroup := pc.CacheFields.CacheField[fieldIdx].FieldGroup; fieldGroup != nil && fieldGroup.GroupItems != nil {
+		count = fieldGroup.GroupItems.Count
+	}
+	if count == 0 {
+		return nil
+	}
+	items := make([]*xlsxItem, count)
+	for idx := range items {
+		items[idx] = &xlsxItem{X: intPtr(idx)}
+	}
+	if defaultSubtotal {
+		items = append(items, &xlsxItem{T: "default"})
+	}
+	return items
+}
+
 // countPivotTables provides a function to get pivot table files count storage
-// in the folder xl/pivotTables.
+// in the folder xl/pivotTables. The largest index of the pivot table files
+// will be returned if the pivot table files are not continuous.
 func (f *File) countPivotTables() int {
-	count := 0
-	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/pivotTables/pivotTable") {
-			count++
-		}
-		return true
-	})
-	return count
+	return f.countPivotParts("xl/pivotTables/pivotTable")
 }
 
 // countPivotCache provides a function to get pivot table cache definition files
-// count storage in the folder xl/pivotCache.
+// count storage in the folder xl/pivotCache. The largest index of the pivot
+// cache definition files will be returned if the files are not continuous.
 func (f *File) countPivotCache() int {
-	count := 0
+	return f.countPivotParts("xl/pivotCache/pivotCacheDefinition")
+}
+
+// countPivotParts provides a function to get the largest index of the pivot
+// table or pivot cache parts by given part name prefix.
+func (f *File) countPivotParts(prefix string) int {
+	count, maxIdx := 0, 0
 	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/pivotCache/pivotCacheDefinition") {
+		if strings.Contains(k.(string), prefix) {
 			count++
+			idx, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(k.(string), prefix), ".xml"))
+			if err == nil && idx > maxIdx {
+				maxIdx = idx
+			}
 		}
 		return true
 	})
+	if maxIdx > count {
+		return maxIdx
+	}
 	return count
 }
 
@@ -872,16 +1360,24 @@ func (f *File) getPivotTable(sheet, pivotTableXML, pivotCacheRels string) (Pivot
 		pivotTableXML:    pivotTableXML,
 		pivotCacheXML:    pivotCacheXML,
 		pivotSheetName:   sheet,
-		DataRange:        fmt.Sprintf("%s!%s", pc.CacheSource.WorksheetSource.Sheet, pc.CacheSource.WorksheetSource.Ref),
-		PivotTableRange:  fmt.Sprintf("%s!%s", sheet, pt.Location.Ref),
 		Name:             pt.Name,
 		ClassicLayout:    pt.GridDropZones,
 		FieldPrintTitles: pt.FieldPrintTitles,
 		ItemPrintTitles:  pt.ItemPrintTitles,
+		SaveData:         pc.SaveData,
 	}
-	if pc.CacheSource.WorksheetSource.Name != "" {
-		opts.DataRange = pc.CacheSource.WorksheetSource.Name
-		_ = f.getPivotTableDataRange(&opts)
+	if pt.Location != nil {
+		opts.PivotTableRange = fmt.Sprintf("%s!%s", sheet, pt.Location.Ref)
+	}
+	if pc.CacheSource != nil && pc.CacheSource.WorksheetSource != nil {
+		ws := pc.CacheSource.WorksheetSource
+		if ws.Ref != "" {
+			opts.DataRange = fmt.Sprintf("%s!%s", ws.Sheet, ws.Ref)
+		}
+		if ws.Name != "" {
+			opts.DataRange = ws.Name
+			_ = f.getPivotTableDataRange(&opts)
+		}
 	}
 	fields := []string{"RowGrandTotals", "ColGrandTotals", "ShowDrill", "UseAutoFormatting", "PageOverThenDown", "MergeItem", "CompactData", "ShowError"}
 	immutable, mutable := reflect.ValueOf(*pt), reflect.ValueOf(&opts).Elem()
@@ -899,14 +1395,58 @@ func (f *File) getPivotTable(sheet, pivotTableXML, pivotCacheRels string) (Pivot
 		opts.ShowLastColumn = si.ShowLastColumn
 		opts.PivotTableStyleName = si.Name
 	}
-	order, err := f.getTableFieldsOrder(&opts)
+	order, err := f.getPivotCacheFieldsOrder(pc, &opts)
 	if err != nil {
 		return opts, err
 	}
 	f.extractPivotTableFields(order, pt, &opts)
+	extractPivotTableFieldGroups(pc, &opts)
 	return opts, err
 }
 
+// extractPivotTableFieldGroups provides a function to extract the grouping
+// settings of the rows, columns and report filter fields by given pivot cache
+// definition.
+func extractPivotTableFieldGroups(pc *xlsxPivotCacheDefinition, opts *PivotTableOptions) {
+	if pc.CacheFields == nil {
+		return
+	}
+	for _, field := range pc.CacheFields.CacheField {
+		if field.FieldGroup == nil || field.FieldGroup.RangePr == nil {
+			continue
+		}
+		groupBy := field.FieldGroup.RangePr.GroupBy
+		if groupBy == "" {
+			groupBy = "range"
+		}
+		for _, fields := range [][]PivotTableField{opts.Rows, opts.Columns, opts.Filter} {
+			for idx := range fields {
+				if fields[idx].Data == field.Name {
+					fields[idx].GroupBy = strings.ToUpper(groupBy[:1]) + groupBy[1:]
+					fields[idx].GroupInterval = field.FieldGroup.RangePr.GroupInterval
+				}
+			}
+		}
+	}
+}
+
+// getPivotCacheFieldsOrder provides a function to get order list of pivot
+// table fields by given pivot cache definition and pivot table options. The
+// field names will be read from the data range of the worksheet, and fallback
+// to the cache field names if the data source is not a worksheet range.
+func (f *File) getPivotCacheFieldsOrder(pc *xlsxPivotCacheDefinition, opts *PivotTableOptions) ([]string, error) {
+	if opts.DataRange != "" {
+		return f.getTableFieldsOrder(opts)
+	}
+	var order []string
+	if pc.CacheFields != nil {
+		for _, field := range pc.CacheFields.CacheField {
+			order = append(order, field.Name)
+		}
+	}
+	return order, nil
+}
+
 // pivotTableReader provides a function to get the pointer to the structure
 // after deserialization of xl/pivotTables/pivotTable%d.xml.
 func (f *File) pivotTableReader(path string) (*xlsxPivotTableDefinition, error) {
@@ -924,13 +1464,14 @@ func (f *File) pivotTableReader(path string) (*xlsxPivotTableDefinition, error)
 // pivotCacheReader provides a function to get the pointer to the structure
 // after deserialization of xl/pivotCache/pivotCacheDefinition%d.xml.
 func (f *File) pivotCacheReader(path string) (*xlsxPivotCacheDefinition, error) {
-	content, ok := f.Pkg.Load(path)
+	content, err := f.readPart(path, true)
+	if err != nil {
+		return nil, err
+	}
 	pivotCache := &xlsxPivotCacheDefinition{}
-	if ok && content != nil {
-		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
-			Decode(pivotCache); err != nil && err != io.EOF {
-			return nil, err
-		}
+	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content))).
+		Decode(pivotCache); err != nil && err != io.EOF {
+		return nil, err
 	}
 	return pivotCache, nil
 }
@@ -938,29 +1479,57 @@ func (f *File) pivotCacheReader(path string) (*xlsxPivotCacheDefinition, error)
 // extractPivotTableFields provides a function to extract all pivot table fields
 // settings by given pivot table fields.
 func (f *File) extractPivotTableFields(order []string, pt *xlsxPivotTableDefinition, opts *PivotTableOptions) {
+	if pt.PivotFields == nil {
+		return
+	}
 	for fieldIdx, field := range pt.PivotFields.PivotField {
+		if fieldIdx >= len(order) {
+			break
+		}
 		if field.Axis == "axisRow" {
 			opts.Rows = append(opts.Rows, extractPivotTableField(order[fieldIdx], field))
 		}
 		if field.Axis == "axisCol" {
 			opts.Columns = append(opts.Columns, extractPivotTableField(order[fieldIdx], field))
 		}
-		if field.Axis == "axisPage" {
+		if field.Axis == "axisPage" && pt.PageFields == nil {
 			opts.Filter = append(opts.Filter, extractPivotTableField(order[fieldIdx], field))
 		}
 	}
+	if pt.PageFields != nil {
+		for _, pageField := range pt.PageFields.PageField {
+			if pageField.Fld < 0 || pageField.Fld >= len(order) || pageField.Fld >= len(pt.PivotFields.PivotField) {
+				continue
+			}
+			opts.Filter = append(opts.Filter, extractPivotTableField(order[pageField.Fld], pt.PivotFields.PivotField[pageField.Fld]))
+		}
+	}
 	if pt.DataFields != nil {
 		for _, field := range pt.DataFields.DataField {
+			if field.Fld < 0 || field.Fld >= len(order) {
+				continue
+			}
 			opts.Data = append(opts.Data, PivotTableField{
 				Data:     order[field.Fld],
 				Name:     field.Name,
-				Subtotal: cases.Title(language.English).String(field.Subtotal),
+				Subtotal: extractPivotTableSubtotal(field.Subtotal),
 				NumFmt:   field.NumFmtID,
 			})
 		}
 	}
 }
 
+// extractPivotTableSubtotal provides a function to convert the aggregation
+// function of the data field to the subtotal name of the pivot table field,
+// for example, convert "countNums" to "CountNums". The default aggregation
+// function of the data field is sum.
+func extractPivotTableSubtotal(subtotal string) string {
+	if subtotal == "" {
+		return "Sum"
+	}
+	return strings.ToUpper(subtotal[:1]) + subtotal[1:]
+}
+
 // extractPivotTableField provides a function to extract pivot table field
 // settings by given pivot table fields.
 func extractPivotTableField(data string, fld *xlsxPivotField) PivotTableField {
@@ -1037,9 +1606,49 @@ func (f *File) deleteWorkbookPivotCache(opt PivotTableOptions) error {
 	return err
 }
 
+// deletePivotCache provides a function to remove the pivot cache definition
+// part, the pivot cache records parts, the relationships and the content type
+// parts of the pivot cache by given pivot table options.
+func (f *File) deletePivotCache(opt PivotTableOptions) error {
+	if err := f.deleteWorkbookPivotCache(opt); err != nil {
+		return err
+	}
+	pivotCacheRels := "xl/pivotCache/_rels/" + filepath.Base(opt.pivotCacheXML) + ".rels"
+	rels, err := f.relsReader(pivotCacheRels)
+	if err != nil {
+		return err
+	}
+	if rels != nil {
+		for _, v := range rels.Relationships {
+			if v.Type == SourceRelationshipPivotCacheRecords {
+				pivotCacheRecordsXML := "xl/pivotCache/" + filepath.Base(v.Target)
+				f.Pkg.Delete(pivotCacheRecordsXML)
+				_ = f.removeContentTypesPart(ContentTypeSpreadSheetMLPivotCacheRecords, "/"+pivotCacheRecordsXML)
+			}
+		}
+	}
+	f.Relationships.Delete(pivotCacheRels)
+	f.Pkg.Delete(pivotCacheRels)
+	f.Pkg.Delete(opt.pivotCacheXML)
+	return f.removeContentTypesPart(ContentTypeSpreadSheetMLPivotCacheDefinition, "/"+opt.pivotCacheXML)
+}
+
+// deletePivotTablePart provides a function to remove the pivot table
+// definition part, the relationships and the content type part of the pivot
+// table by given pivot table options.
+func (f *File) deletePivotTablePart(opt PivotTableOptions) error {
+	pivotTableRels := "xl/pivotTables/_rels/" + filepath.Base(opt.pivotTableXML) + ".rels"
+	f.Relationships.Delete(pivotTableRels)
+	f.Pkg.Delete(pivotTableRels)
+	f.Pkg.Delete(opt.pivotTableXML)
+	return f.removeContentTypesPart(ContentTypeSpreadSheetMLPivotTable, "/"+opt.pivotTableXML)
+}
+
 // DeletePivotTable delete a pivot table by giving the worksheet name and pivot
-// table name. Note that this function does not clean cell values in the pivot
-// table range.
+// table name. The pivot table definition, the relationships and the content
+// type parts will be removed, and the pivot cache will be removed if it is not
+// referenced by other pivot tables. Note that this function does not clean
+// cell values in the pivot table range.
 func (f *File) DeletePivotTable(sheet, name string) error {
 	sheetXML, ok := f.getSheetXMLPath(sheet)
 	if !ok {
@@ -1070,10 +1679,12 @@ func (f *File) DeletePivotTable(sheet, name string) error {
 				pivotTableXML := strings.ReplaceAll(v.Target, "..", "xl")
 				if opt.Name == name && opt.pivotTableXML == pivotTableXML {
 					if pivotTableCaches[opt.pivotCacheXML] == 1 {
-						err = f.deleteWorkbookPivotCache(opt)
+						if err = f.deletePivotCache(opt); err != nil {
+							return err
+						}
 					}
 					f.deleteSheetRelationships(sheet, v.ID)
-					return err
+					return f.deletePivotTablePart(opt)
 				}
 			}
 		}
@@ -1,11 +1,13 @@
 package excelize
 
 import (
+	"encoding/xml"
 	"fmt"
 	"math/rand"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -127,6 +129,21 @@ func TestPivotTable(t *testing.T) {
 		ShowLastColumn:      true,
 		PivotTableStyleName: "PivotStyleLight19",
 	}))
+	// Create pivot table with multiple report filters
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!A1:E31",
+		PivotTableRange: "Sheet1!AR4:AT20",
+		Rows:            []PivotTableField{{Data: "Month"}},
+		Filter:          []PivotTableField{{Data: "Region", Name: "Sales Region", Compact: true}, {Data: "Year", ShowAll: true}},
+		Data:            []PivotTableField{{Data: "Sales", Subtotal: "Sum", Name: "Summarize by Sum"}},
+	}))
+	pivotTables, err = f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []PivotTableField{{Data: "Region", Name: "Sales Region", Compact: true}, {Data: "Year", ShowAll: true}}, pivotTables[len(pivotTables)-1].Filter)
+	pt, err := f.pivotTableReader(pivotTables[len(pivotTables)-1].pivotTableXML)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, pt.Location.RowPageCount)
+	assert.Equal(t, 1, pt.Location.ColPageCount)
 	_, err = f.NewSheet("Sheet2")
 	assert.NoError(t, err)
 	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
@@ -258,12 +275,33 @@ func TestPivotTable(t *testing.T) {
 	}))
 	// Test delete pivot table
 	pivotTables, err = f.GetPivotTables("Sheet1")
-	assert.Len(t, pivotTables, 7)
+	assert.Len(t, pivotTables, 8)
 	assert.NoError(t, err)
 	assert.NoError(t, f.DeletePivotTable("Sheet1", "PivotTable1"))
 	pivotTables, err = f.GetPivotTables("Sheet1")
-	assert.Len(t, pivotTables, 6)
+	assert.Len(t, pivotTables, 7)
+	assert.NoError(t, err)
+	// Test delete pivot table removes the pivot table and pivot cache parts
+	for _, part := range []string{
+		"xl/pivotTables/pivotTable1.xml",
+		"xl/pivotTables/_rels/pivotTable1.xml.rels",
+		"xl/pivotCache/pivotCacheDefinition1.xml",
+	} {
+		_, ok := f.Pkg.Load(part)
+		assert.False(t, ok, part)
+	}
+	content, err := f.contentTypesReader()
 	assert.NoError(t, err)
+	for _, override := range content.Overrides {
+		assert.NotEqual(t, "/xl/pivotTables/pivotTable1.xml", override.PartName)
+		assert.NotEqual(t, "/xl/pivotCache/pivotCacheDefinition1.xml", override.PartName)
+	}
+	// Test add pivot table after deleted pivot table without part name conflict
+	pivotTableCount, pivotCacheCount := f.countPivotTables(), f.countPivotCache()
+	f.Pkg.Delete("xl/pivotTables/pivotTable2.xml")
+	f.Pkg.Delete("xl/pivotCache/pivotCacheDefinition2.xml")
+	assert.Equal(t, pivotTableCount, f.countPivotTables())
+	assert.Equal(t, pivotCacheCount, f.countPivotCache())
 
 	// Test add pivot table with invalid sheet name
 	assert.Error(t, f.AddPivotTable(&PivotTableOptions{
@@ -356,6 +394,262 @@ func TestPivotTable(t *testing.T) {
 	_, err = f.getPivotTables()
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 	assert.NoError(t, f.Close())
+	// Test get pivot table with the data source is not a worksheet range
+	f, err = OpenFile(filepath.Join("test", "TestAddPivotTable1.xlsx"))
+	assert.NoError(t, err)
+	pc, err := f.pivotCacheReader("xl/pivotCache/pivotCacheDefinition1.xml")
+	assert.NoError(t, err)
+	pc.CacheSource = &xlsxCacheSource{Type: "external", ConnectionID: 1}
+	pc.CacheFields.CacheField[2].Name = "Category"
+	output, err := xml.Marshal(pc)
+	assert.NoError(t, err)
+	f.Pkg.Store("xl/pivotCache/pivotCacheDefinition1.xml", output)
+	pivotTables, err = f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, pivotTables[0].DataRange)
+	assert.Equal(t, "Sheet1!G2:M34", pivotTables[0].PivotTableRange)
+	assert.Equal(t, []PivotTableField{{Data: "Category", ShowAll: true, InsertBlankRow: true, DefaultSubtotal: true}}, pivotTables[0].Columns)
+	assert.NoError(t, f.Close())
+}
+
+func TestExtractPivotTableSubtotal(t *testing.T) {
+	for subtotal, expected := range map[string]string{
+		"": "Sum", "average": "Average", "countNums": "CountNums",
+		"stdDev": "StdDev", "stdDevp": "StdDevp", "varp": "Varp",
+	} {
+		assert.Equal(t, expected, extractPivotTableSubtotal(subtotal))
+	}
+	// Test extract pivot table fields with data field index out of range
+	opts := PivotTableOptions{}
+	new(File).extractPivotTableFields([]string{"Month"}, &xlsxPivotTableDefinition{
+		PivotFields: &xlsxPivotFields{PivotField: []*xlsxPivotField{{Axis: "axisRow"}, {Axis: "axisRow"}}},
+		DataFields:  &xlsxDataFields{DataField: []*xlsxDataField{{Fld: 1}}},
+	}, &opts)
+	assert.Equal(t, []PivotTableField{{Data: "Month"}}, opts.Rows)
+	assert.Empty(t, opts.Data)
+}
+
+func TestPivotTableSaveData(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Month", "Year", "Type", "Sales", "Region"}))
+	for row, data := range [][]interface{}{
+		{"Jan", 2017, "Meat", 100, "East"},
+		{"Feb", 2018, "Dairy", 200.5, "West"},
+		{"Jan", 2017, "Meat", 300, nil},
+		{"Mar", 2019, 1, nil, "East"},
+	} {
+		cell, err := CoordinatesToCellName(1, row+2)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &data))
+	}
+	opts := &PivotTableOptions{
+		DataRange:       "Sheet1!A1:E5",
+		PivotTableRange: "Sheet1!G4:M20",
+		Rows:            []PivotTableField{{Data: "Month", DefaultSubtotal: true}},
+		Columns:         []PivotTableField{{Data: "Type"}},
+		Filter:          []PivotTableField{{Data: "Region"}},
+		Data:            []PivotTableField{{Data: "Sales", Subtotal: "Sum", Name: "Summarize by Sum"}},
+		SaveData:        true,
+	}
+	assert.NoError(t, f.AddPivotTable(opts))
+	pivotTables, err := f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pivotTables, 1)
+	assert.True(t, pivotTables[0].SaveData)
+
+	pc, err := f.pivotCacheReader("xl/pivotCache/pivotCacheDefinition1.xml")
+	assert.NoError(t, err)
+	assert.True(t, pc.SaveData)
+	assert.Equal(t, 4, pc.RecordCount)
+	assert.Equal(t, "rId1", pc.RID)
+	// Test shared items of the character field on the row axis
+	assert.Equal(t, []xlsxString{{V: "Jan"}, {V: "Feb"}, {V: "Mar"}}, pc.CacheFields.CacheField[0].SharedItems.S)
+	assert.Nil(t, pc.CacheFields.CacheField[0].SharedItems.ContainsString)
+	// Test shared items of the numeric field which not on any axis
+	year := pc.CacheFields.CacheField[1].SharedItems
+	assert.Equal(t, 0, year.Count)
+	assert.Empty(t, year.N)
+	assert.True(t, year.ContainsInteger)
+	assert.Equal(t, boolPtr(false), year.ContainsString)
+	assert.Equal(t, 2017.0, year.MinValue)
+	assert.Equal(t, 2019.0, year.MaxValue)
+	// Test shared items of the mixed types field on the column axis
+	types := pc.CacheFields.CacheField[2].SharedItems
+	assert.True(t, types.ContainsMixedTypes)
+	assert.Equal(t, []xlsxNumber{{V: 1}}, types.N)
+	assert.Equal(t, []xlsxString{{V: "Meat"}, {V: "Dairy"}}, types.S)
+	// Test shared items of the numeric field contains blank value
+	sales := pc.CacheFields.CacheField[3].SharedItems
+	assert.True(t, sales.ContainsBlank)
+	assert.False(t, sales.ContainsInteger)
+	assert.Equal(t, 0, sales.Count)
+
+	records := new(xlsxPivotCacheRecords)
+	content, ok := f.Pkg.Load("xl/pivotCache/pivotCacheRecords1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), records))
+	assert.Equal(t, 4, records.Count)
+	assert.Len(t, records.R, 4)
+
+	pt, err := f.pivotTableReader("xl/pivotTables/pivotTable1.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, pt.PivotFields.PivotField[0].Items.Count)
+	assert.Equal(t, "default", pt.PivotFields.PivotField[0].Items.Item[3].T)
+	assert.Equal(t, 3, pt.PivotFields.PivotField[2].Items.Count)
+	assert.Equal(t, 4, pt.PivotFields.PivotField[4].Items.Count)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPivotTable3.xlsx")))
+
+	// Test delete pivot table with pivot cache records
+	assert.NoError(t, f.DeletePivotTable("Sheet1", "PivotTable1"))
+	_, ok = f.Pkg.Load("xl/pivotCache/pivotCacheRecords1.xml")
+	assert.False(t, ok)
+	assert.NoError(t, f.Close())
+
+	// Test add pivot table save data with numeric formula cell
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Month", "Year"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"Jan", 2017}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"Feb", 2018}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[2].C[1] = xlsxC{R: "B3", F: &xlsxF{Content: "B2+1"}, V: "2018"}
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!A1:B3",
+		PivotTableRange: "Sheet1!D2:F10",
+		Rows:            []PivotTableField{{Data: "Month"}},
+		Data:            []PivotTableField{{Data: "Year", Subtotal: "Sum"}},
+		SaveData:        true,
+	}))
+	pc, err = f.pivotCacheReader("xl/pivotCache/pivotCacheDefinition1.xml")
+	assert.NoError(t, err)
+	year = pc.CacheFields.CacheField[1].SharedItems
+	assert.False(t, year.ContainsMixedTypes)
+	assert.Equal(t, boolPtr(false), year.ContainsString)
+	assert.Equal(t, 2018.0, year.MaxValue)
+	records = new(xlsxPivotCacheRecords)
+	content, ok = f.Pkg.Load("xl/pivotCache/pivotCacheRecords1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), records))
+	assert.Contains(t, string(content.([]byte)), `<n v="2018"></n>`)
+	assert.NoError(t, f.Close())
+
+	// Test add pivot table save data with invalid data sheet
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Month", "Year"}))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row = append(ws.(*xlsxWorksheet).SheetData.Row, xlsxRow{R: 2, C: []xlsxC{{R: "A2", T: "s", V: "1"}}})
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.addPivotCacheRecords(&xlsxPivotCacheDefinition{CacheFields: &xlsxCacheFields{CacheField: []*xlsxCacheField{{}}}},
+		"Sheet1", []int{1, 1, 2, 2}, &PivotTableOptions{}), "XML syntax error on line 1: invalid UTF-8")
+	// Test add pivot fields with unsupported charset pivot cache
+	f.Pkg.Store("xl/pivotCache/pivotCacheDefinition1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.addPivotFields(&xlsxPivotTableDefinition{}, &PivotTableOptions{
+		DataRange:     "Sheet1!A1:B2",
+		SaveData:      true,
+		pivotCacheXML: "xl/pivotCache/pivotCacheDefinition1.xml",
+	}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestPivotTableFieldGroup(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Date", "Time", "Region", "Sales"}))
+	for row, data := range [][]interface{}{
+		{time.Date(2017, 3, 15, 0, 0, 0, 0, time.UTC), time.Date(2017, 3, 15, 8, 30, 0, 0, time.UTC), "East", 120},
+		{time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2018, 7, 1, 13, 0, 0, 0, time.UTC), "West", 2500},
+		{time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC), time.Date(2019, 12, 31, 23, 15, 0, 0, time.UTC), "East", 4990},
+		{nil, nil, "North", 10},
+	} {
+		cell, err := CoordinatesToCellName(1, row+2)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &data))
+	}
+	opts := &PivotTableOptions{
+		DataRange:       "Sheet1!A1:D5",
+		PivotTableRange: "Sheet1!G2:M20",
+		Rows:            []PivotTableField{{Data: "Date", GroupBy: "Months", DefaultSubtotal: true}},
+		Columns:         []PivotTableField{{Data: "Sales", GroupBy: "Range", GroupInterval: 1000}},
+		Filter:          []PivotTableField{{Data: "Time", GroupBy: "hours"}},
+		Data:            []PivotTableField{{Data: "Region", Subtotal: "Count", Name: "Count of Region"}},
+		SaveData:        true,
+	}
+	assert.NoError(t, f.AddPivotTable(opts))
+	pivotTables, err := f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pivotTables, 1)
+	assert.Equal(t, "Months", pivotTables[0].Rows[0].GroupBy)
+	assert.Equal(t, "Range", pivotTables[0].Columns[0].GroupBy)
+	assert.Equal(t, 1000.0, pivotTables[0].Columns[0].GroupInterval)
+	assert.Equal(t, "Hours", pivotTables[0].Filter[0].GroupBy)
+
+	pc, err := f.pivotCacheReader("xl/pivotCache/pivotCacheDefinition1.xml")
+	assert.NoError(t, err)
+	// Test date grouping by months
+	date := pc.CacheFields.CacheField[0]
+	assert.Equal(t, &xlsxRangePr{GroupBy: "months", StartDate: "2017-03-15T00:00:00", EndDate: "2020-01-01T00:00:00"}, date.FieldGroup.RangePr)
+	assert.Equal(t, 14, date.FieldGroup.GroupItems.Count)
+	assert.Equal(t, []xlsxString{{V: "<3/15/2017"}, {V: "Jan"}}, date.FieldGroup.GroupItems.S[:2])
+	assert.Equal(t, xlsxString{V: ">1/1/2020"}, date.FieldGroup.GroupItems.S[13])
+	assert.Equal(t, []xlsxDateTime{{V: "2017-03-15T00:00:00"}, {V: "2018-07-01T00:00:00"}, {V: "2019-12-31T00:00:00"}}, date.SharedItems.D)
+	assert.True(t, date.SharedItems.ContainsDate)
+	assert.True(t, date.SharedItems.ContainsBlank)
+	// Test numeric range grouping
+	sales := pc.CacheFields.CacheField[3]
+	assert.Equal(t, &xlsxRangePr{StartNum: float64Ptr(10), EndNum: float64Ptr(4990), GroupInterval: 1000}, sales.FieldGroup.RangePr)
+	assert.Equal(t, []xlsxString{{V: "<10"}, {V: "10-1009"}, {V: "1010-2009"}, {V: "2010-3009"}, {V: "3010-4009"}, {V: "4010-5009"}, {V: ">5010"}}, sales.FieldGroup.GroupItems.S)
+	assert.Equal(t, []xlsxNumber{{V: 120}, {V: 2500}, {V: 4990}, {V: 10}}, sales.SharedItems.N)
+	assert.False(t, sales.SharedItems.ContainsDate)
+	// Test time grouping by hours
+	assert.Equal(t, 26, pc.CacheFields.CacheField[1].FieldGroup.GroupItems.Count)
+	assert.Equal(t, xlsxString{V: "12 AM"}, pc.CacheFields.CacheField[1].FieldGroup.GroupItems.S[1])
+
+	pt, err := f.pivotTableReader("xl/pivotTables/pivotTable1.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, 15, pt.PivotFields.PivotField[0].Items.Count)
+	assert.Equal(t, 7, pt.PivotFields.PivotField[3].Items.Count)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPivotTable4.xlsx")))
+
+	// Test date grouping by years, quarters, days, minutes and seconds
+	for groupBy, count := range map[string]int{"Years": 5, "Quarters": 6, "Days": 148, "Minutes": 62, "Seconds": 62} {
+		pc := &xlsxPivotCacheDefinition{CacheFields: &xlsxCacheFields{CacheField: []*xlsxCacheField{{Name: "Date"}}}}
+		assert.NoError(t, f.addPivotCacheFieldGroups(pc, "Sheet1", []int{1, 1, 1, 5}, &PivotTableOptions{
+			Rows: []PivotTableField{{Data: "Date", GroupBy: groupBy, GroupInterval: 7}},
+		}))
+		assert.Equal(t, count, pc.CacheFields.CacheField[0].FieldGroup.GroupItems.Count, groupBy)
+		if groupBy == "Days" {
+			items := pc.CacheFields.CacheField[0].FieldGroup.GroupItems.S
+			assert.Equal(t, []xlsxString{{V: "<3/15/2017"}, {V: "3/15/2017 - 3/21/2017"}, {V: "3/22/2017 - 3/28/2017"}}, items[:3])
+			assert.Equal(t, []xlsxString{{V: "12/25/2019 - 12/31/2019"}, {V: ">1/1/2020"}}, items[146:])
+		}
+	}
+	// Test grouping with unsupported group type
+	pc = &xlsxPivotCacheDefinition{CacheFields: &xlsxCacheFields{CacheField: []*xlsxCacheField{{Name: "Date"}}}}
+	assert.Equal(t, ErrParameterInvalid, f.addPivotCacheFieldGroups(pc, "Sheet1", []int{1, 1, 1, 5}, &PivotTableOptions{
+		Rows: []PivotTableField{{Data: "Date", GroupBy: "Unknown"}},
+	}))
+	// Test grouping with the number of group items exceeds the limit
+	for _, maxValue := range []float64{1e12, 1e17} {
+		field := &xlsxCacheField{FieldGroup: &xlsxFieldGroup{}, SharedItems: &xlsxSharedItems{}}
+		assert.Equal(t, ErrPivotTableGroupItems, setPivotCacheFieldRangeGroup(field, 0, maxValue, 1, true))
+	}
+	field := &xlsxCacheField{FieldGroup: &xlsxFieldGroup{}, SharedItems: &xlsxSharedItems{}}
+	assert.Equal(t, ErrPivotTableGroupItems, setPivotCacheFieldDateGroup(field, "days", 1, 2958465, 2, false))
+	// Test grouping days with the interval greater than the number of days
+	assert.NoError(t, setPivotCacheFieldDateGroup(field, "days", 45292, 45294, 1e30, false))
+	assert.Equal(t, []xlsxString{{V: "<1/1/2024"}, {V: "1/1/2024 - 1/3/2024"}, {V: ">1/4/2024"}}, field.FieldGroup.GroupItems.S)
+	// Test grouping the field without numeric values
+	pc = &xlsxPivotCacheDefinition{CacheFields: &xlsxCacheFields{CacheField: []*xlsxCacheField{{Name: "Region"}}}}
+	assert.NoError(t, f.addPivotCacheFieldGroups(pc, "Sheet1", []int{3, 1, 3, 5}, &PivotTableOptions{
+		Rows: []PivotTableField{{Data: "Region", GroupBy: "Range"}},
+	}))
+	assert.Nil(t, pc.CacheFields.CacheField[0].FieldGroup)
+	// Test grouping with invalid data sheet
+	assert.EqualError(t, f.addPivotCacheFieldGroups(pc, "SheetN", []int{3, 1, 3, 5}, &PivotTableOptions{
+		Rows: []PivotTableField{{Data: "Region", GroupBy: "Range"}},
+	}), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
 }
 
 func TestPivotTableDataRange(t *testing.T) {
@@ -524,3 +818,17 @@ func TestDeleteWorkbookPivotCache(t *testing.T) {
 	f.Pkg.Store("xl/_rels/workbook.xml.rels", MacintoshCyrillicCharset)
 	assert.EqualError(t, f.deleteWorkbookPivotCache(PivotTableOptions{pivotCacheXML: "pivotCache/pivotCacheDefinition1.xml"}), "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestDeletePivotCache(t *testing.T) {
+	f := NewFile()
+	// Test delete pivot cache with unsupported pivot cache relationships charset
+	f.Pkg.Store("xl/pivotCache/_rels/pivotCacheDefinition1.xml.rels", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.deletePivotCache(PivotTableOptions{pivotCacheXML: "xl/pivotCache/pivotCacheDefinition1.xml"}), "XML syntax error on line 1: invalid UTF-8")
+	// Test delete pivot cache with pivot cache records
+	f.Pkg.Store("xl/pivotCache/_rels/pivotCacheDefinition1.xml.rels", []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="`+SourceRelationshipPivotCacheRecords+`" Target="pivotCacheRecords1.xml"/></Relationships>`))
+	f.Pkg.Store("xl/pivotCache/pivotCacheRecords1.xml", []byte{})
+	assert.NoError(t, f.deletePivotCache(PivotTableOptions{pivotCacheXML: "xl/pivotCache/pivotCacheDefinition1.xml"}))
+	_, ok := f.Pkg.Load("xl/pivotCache/pivotCacheRecords1.xml")
+	assert.False(t, ok)
+	assert.NoError(t, f.Close())
+}
@@ -17,6 +17,7 @@ import (
 	"io"
 	"math"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -40,9 +41,12 @@ var duplicateHelperFunc = [3]func(*File, *xlsxWorksheet, string, int, int) error
 // a two-dimensional array, where the value of the cell is converted to the
 // string type. If the cell format can be applied to the value of the cell,
 // the applied value will be used, otherwise the original value will be used.
-// GetRows fetched the rows with value or formula cells, the continually blank
-// cells in the tail of each row will be skipped, so the length of each row
-// may be inconsistent.
+// Set the RawCellValue option to get the raw stored values instead. GetRows
+// fetched the rows with value or formula cells, the continually blank cells
+// in the tail of each row will be skipped, so the length of each row may be
+// inconsistent. Set the FullRange option to get rectangular results padded
+// with empty strings to the length of the longest row, and the blank rows
+// between the rows will be returned as slices of empty strings.
 //
 // For example, get and traverse the value of all cells by rows on a worksheet
 // named 'Sheet1':
@@ -78,7 +82,106 @@ func (f *File) GetRows(sheet string, opts ...Options) ([][]string, error) {
 			maxVal = cur
 		}
 	}
-	return results[:maxVal], rows.Close()
+	if err = rows.Error(); err != nil {
+		_ = rows.Close()
+		return results[:maxVal], err
+	}
+	if err = rows.Close(); err != nil || !f.getOptions(opts...).FullRange {
+		return results[:maxVal], err
+	}
+	return fillUsedRange(results[:maxVal]), err
+}
+
+// fillUsedRange pads the given rows or columns cell values with empty strings
+// to make them rectangular with the length of the longest rows or columns, the
+// empty rows or columns will be filled too.
+func fillUsedRange(cells [][]string) [][]string {
+	var inner int
+	for _, values := range cells {
+		if len(values) > inner {
+			inner = len(values)
+		}
+	}
+	return padCells(cells, len(cells), inner)
+}
+
+// padCells pads the given two-dimensional cell values with empty strings to
+// the given outer and inner length.
+func padCells(cells [][]string, outer, inner int) [][]string {
+	for len(cells) < outer {
+		cells = append(cells, nil)
+	}
+	for i, values := range cells {
+		if len(values) < inner {
+			cells[i] = append(values, make([]string, inner-len(values))...)
+		}
+	}
+	return cells
+}
+
+// GetRange provides a function to get the values of the cells in the given
+// range reference on the worksheet by given worksheet name, returned as a
+// two-dimensional array by rows, where the value of the cell is converted to
+// the string type in the same way as GetRows. The worksheet will be read as a
+// stream, and the reading stops after the last row of the range, so it is
+// much faster than GetRows for getting a small region of a worksheet with a
+// large data. The continually blank cells in the tail of each row and the
+// blank rows in the tail of the range will be skipped, set the FullRange
+// option to get rectangular results with the size of the range. For example,
+// get the values of the cells in the range B2:D10 on Sheet1:
+//
+//	rows, err := f.GetRange("Sheet1", "B2:D10")
+func (f *File) GetRange(sheet, rangeRef string, opts ...Options) ([][]string, error) {
+	if !strings.Contains(rangeRef, ":") {
+		rangeRef += ":" + rangeRef
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return nil, err
+	}
+	_ = sortCoordinates(coordinates)
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return nil, err
+	}
+	width, height := coordinates[2]-coordinates[0]+1, coordinates[3]-coordinates[1]+1
+	results := make([][]string, 0, height)
+	for rows.Next() {
+		cur := rows.curRow
+		if cur < coordinates[1] {
+			continue
+		}
+		if cur > coordinates[3] {
+			break
+		}
+		row, err := rows.Columns(opts...)
+		if err != nil {
+			break
+		}
+		if len(row) < coordinates[0] {
+			continue
+		}
+		if row = row[coordinates[0]-1:]; len(row) > width {
+			row = row[:width]
+		}
+		for len(row) > 0 && row[len(row)-1] == "" {
+			row = row[:len(row)-1]
+		}
+		if len(row) > 0 {
+			if emptyRows := cur - coordinates[1] - len(results); emptyRows > 0 {
+				results = append(results, make([][]string, emptyRows)...)
+			}
+			results = append(results, row)
+		}
+	}
+	if err = rows.Error(); err != nil {
+		_ = rows.Close()
+		return results, err
+	}
+	if err = rows.Close(); err != nil || !f.getOptions(opts...).FullRange {
+		return results, err
+	}
+	return padCells(results, height, width), err
 }
 
 // Rows defines an iterator to a sheet.
@@ -103,8 +206,11 @@ func (rows *Rows) Next() bool {
 		return true
 	}
 	for {
-		token, _ := rows.decoder.Token()
+		token, err := rows.decoder.Token()
 		if token == nil {
+			if err != io.EOF {
+				rows.err = err
+			}
 			return false
 		}
 		switch xmlElement := token.(type) {
@@ -131,7 +237,8 @@ func (rows *Rows) GetRowOpts() RowOpts {
 	return rows.curRowOpts
 }
 
-// Error will return the error when the error occurs.
+// Error will return the error when the error occurs, such as the worksheet XML
+// is malformed while iterating rows.
 func (rows *Rows) Error() error {
 	return rows.err
 }
@@ -161,7 +268,11 @@ func (rows *Rows) Columns(opts ...Options) ([]string, error) {
 	for {
 		if rows.token != nil {
 			token = rows.token
-		} else if token, _ = rows.decoder.Token(); token == nil {
+		} else if token, rowIterator.err = rows.decoder.Token(); token == nil {
+			if rowIterator.err == io.EOF {
+				rowIterator.err = nil
+			}
+			rows.err = rowIterator.err
 			break
 		}
 		switch xmlElement := token.(type) {
@@ -182,7 +293,7 @@ func (rows *Rows) Columns(opts ...Options) ([]string, error) {
 				}
 			}
 			if rows.rowXMLHandler(&rowIterator, &xmlElement, rows.rawCellValue); rowIterator.err != nil {
-				rows.token = nil
+				rows.token, rows.err = nil, rowIterator.err
 				return rowIterator.cells, rowIterator.err
 			}
 			rows.token = nil
@@ -231,7 +342,9 @@ func (rows *Rows) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.Sta
 	if rowIterator.inElement == "c" {
 		rowIterator.cellCol++
 		colCell := xlsxC{}
-		_ = rows.decoder.DecodeElement(&colCell, xmlElement)
+		if rowIterator.err = rows.decoder.DecodeElement(&colCell, xmlElement); rowIterator.err != nil {
+			return
+		}
 		if colCell.R != "" {
 			if rowIterator.cellCol, _, rowIterator.err = CellNameToCoordinates(colCell.R); rowIterator.err != nil {
 				return
@@ -288,6 +401,133 @@ func (f *File) Rows(sheet string) (*Rows, error) {
 	return &rows, err
 }
 
+// CellData directly maps the populated cell returned by the cells iterator,
+// which contains the cell reference, the raw value without number format
+// applied, the cell type and the style index of the cell.
+type CellData struct {
+	Cell    string
+	Value   string
+	Type    CellType
+	StyleID int
+}
+
+// Cells defines an iterator to the populated cells of a sheet.
+type Cells struct {
+	err            error
+	curCol, curRow int
+	rows           *Rows
+	cell           CellData
+	sst            *xlsxSST
+}
+
+// Cells returns a cells iterator, used for streaming reading the populated
+// cells with value, formula or style for a worksheet with a large data in
+// document order, the empty cells which not exist in the worksheet will be
+// skipped. This function is concurrency safe. For example, print the
+// reference, value, type and style index of each cell in Sheet1:
+//
+//	cells, err := f.Cells("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for cells.Next() {
+//	    cell := cells.Cell()
+//	    fmt.Println(cell.Cell, cell.Value, cell.Type, cell.StyleID)
+//	}
+//	if err = cells.Error(); err != nil {
+//	    fmt.Println(err)
+//	}
+//	if err = cells.Close(); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) Cells(sheet string) (*Cells, error) {
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return nil, err
+	}
+	cells := Cells{rows: rows}
+	if cells.sst, err = f.sharedStringsReader(); err != nil {
+		_ = rows.Close()
+		return nil, err
+	}
+	return &cells, nil
+}
+
+// Next will return true if it finds the next populated cell.
+func (cells *Cells) Next() bool {
+	for {
+		token, err := cells.rows.decoder.Token()
+		if token == nil {
+			if err != io.EOF {
+				cells.err = err
+			}
+			return false
+		}
+		switch xmlElement := token.(type) {
+		case xml.StartElement:
+			if xmlElement.Name.Local == "row" {
+				cells.curRow++
+				if rowNum, _ := attrValToInt("r", xmlElement.Attr); rowNum != 0 {
+					cells.curRow = rowNum
+				}
+				cells.curCol = 0
+			}
+			if xmlElement.Name.Local == "c" {
+				if cells.cellXMLHandler(&xmlElement); cells.err != nil {
+					return false
+				}
+				if cells.cell.Cell != "" {
+					return true
+				}
+			}
+		case xml.EndElement:
+			if xmlElement.Name.Local == "sheetData" {
+				return false
+			}
+		}
+	}
+}
+
+// cellXMLHandler parse the cell XML element of the worksheet, and set the
+// current cell of the iterator if the cell is populated.
+func (cells *Cells) cellXMLHandler(xmlElement *xml.StartElement) {
+	cells.curCol++
+	cells.cell = CellData{}
+	var c xlsxC
+	if cells.err = cells.rows.decoder.DecodeElement(&c, xmlElement); cells.err != nil {
+		return
+	}
+	if c.R != "" {
+		if cells.curCol, cells.curRow, cells.err = CellNameToCoordinates(c.R); cells.err != nil {
+			return
+		}
+	}
+	if !c.hasValue() {
+		return
+	}
+	cell, _ := CoordinatesToCellName(cells.curCol, cells.curRow)
+	value, _ := c.getValueFrom(cells.rows.f, cells.sst, true)
+	cells.cell = CellData{Cell: cell, Value: value, Type: c.getCellType(true), StyleID: c.S}
+}
+
+// Cell will return the current populated cell.
+func (cells *Cells) Cell() CellData {
+	return cells.cell
+}
+
+// Error will return the error when the error occurs, such as the worksheet XML
+// is malformed while iterating cells.
+func (cells *Cells) Error() error {
+	return cells.err
+}
+
+// Close closes the open worksheet XML file in the system temporary
+// directory.
+func (cells *Cells) Close() error {
+	return cells.rows.Close()
+}
+
 // getFromStringItem build shared string item offset list from system temporary
 // file at one time, and return value by given to string index.
 func (f *File) getFromStringItem(index int) string {
@@ -346,7 +586,7 @@ func (f *File) xmlDecoder(name string) (bool, *xml.Decoder, *os.File, error) {
 		err      error
 		tempFile *os.File
 	)
-	if content = f.readXML(name); len(content) > 0 {
+	if content, err = f.readPart(name, false); err != nil || len(content) > 0 {
 		return false, f.xmlNewDecoder(bytes.NewReader(content)), tempFile, err
 	}
 	tempFile, err = f.readTemp(name)
@@ -360,8 +600,27 @@ func (f *File) xmlDecoder(name string) (bool, *xml.Decoder, *os.File, error) {
 //
 //	err := f.SetRowHeight("Sheet1", 1, 50)
 func (f *File) SetRowHeight(sheet string, row int, height float64) error {
-	if row < 1 {
-		return newInvalidRowNumberError(row)
+	return f.SetRowsHeight(sheet, row, row, height)
+}
+
+// SetRowsHeight provides a function to set the height of multiple rows by
+// given worksheet name, row range and height. The value of height is the same
+// as the SetRowHeight function. If the range covers the entire rows of the
+// worksheet (1 to 1048576), the default row height of the worksheet will be
+// set and only the existing rows will be updated without creating the row
+// records, and the default row height will be restored when the value of
+// height is -1. For example, set the height of the rows 1 to 10 in Sheet1:
+//
+//	err := f.SetRowsHeight("Sheet1", 1, 10, 50)
+func (f *File) SetRowsHeight(sheet string, start, end int, height float64) error {
+	if end < start {
+		start, end = end, start
+	}
+	if start < 1 {
+		return newInvalidRowNumberError(start)
+	}
+	if end > TotalRows {
+		return ErrMaxRows
 	}
 	if height > MaxRowHeight {
 		return ErrMaxRowHeight
@@ -373,20 +632,53 @@ func (f *File) SetRowHeight(sheet string, row int, height float64) error {
 	if err != nil {
 		return err
 	}
+	if start == 1 && end == TotalRows {
+		ws.setAllRowsHeight(height)
+		return err
+	}
 
-	ws.prepareSheetXML(0, row)
+	ws.prepareSheetXML(0, end)
 
-	rowIdx := row - 1
-	if height == -1 {
-		ws.SheetData.Row[rowIdx].Ht = nil
-		ws.SheetData.Row[rowIdx].CustomHeight = false
-		return err
+	for rowIdx := start - 1; rowIdx < end; rowIdx++ {
+		if height == -1 {
+			ws.SheetData.Row[rowIdx].Ht = nil
+			ws.SheetData.Row[rowIdx].CustomHeight = false
+			continue
+		}
+		ws.SheetData.Row[rowIdx].Ht = float64Ptr(height)
+		ws.SheetData.Row[rowIdx].CustomHeight = true
 	}
-	ws.SheetData.Row[rowIdx].Ht = float64Ptr(height)
-	ws.SheetData.Row[rowIdx].CustomHeight = true
 	return err
 }
 
+// setAllRowsHeight provides a function to set the height of the entire rows
+// in the worksheet by given height, which sets the default row height of the
+// worksheet and updates the existing rows without creating the row records.
+func (ws *xlsxWorksheet) setAllRowsHeight(height float64) {
+	if ws.SheetFormatPr == nil {
+		ws.SheetFormatPr = &xlsxSheetFormatPr{DefaultRowHeight: defaultRowHeight}
+	}
+	switch height {
+	case -1:
+		ws.SheetFormatPr.DefaultRowHeight, ws.SheetFormatPr.CustomHeight = defaultRowHeight, false
+		ws.SheetFormatPr.ZeroHeight = false
+	case 0:
+		ws.SheetFormatPr.ZeroHeight = true
+	default:
+		ws.SheetFormatPr.DefaultRowHeight, ws.SheetFormatPr.CustomHeight = height, true
+		ws.SheetFormatPr.ZeroHeight = false
+	}
+	for rowIdx := range ws.SheetData.Row {
+		if height == -1 {
+			ws.SheetData.Row[rowIdx].Ht = nil
+			ws.SheetData.Row[rowIdx].CustomHeight = false
+			continue
+		}
+		ws.SheetData.Row[rowIdx].Ht = float64Ptr(height)
+		ws.SheetData.Row[rowIdx].CustomHeight = true
+	}
+}
+
 // getRowHeight provides a function to get row height in pixels by given sheet
 // name and row number.
 func (f *File) getRowHeight(sheet string, row int) int {
@@ -434,6 +726,80 @@ func (f *File) GetRowHeight(sheet string, row int) (float64, error) {
 	return ht, nil
 }
 
+// GetRowDisplayHeight provides a function to get the effective height of the
+// row as displayed by the spreadsheet application by given worksheet name and
+// row number. Unlike the GetRowHeight function, it returns 0 if the row is
+// hidden, and returns the default row height of the worksheet for the row
+// without custom height. This function is concurrency safe. For example, get
+// the displayed height of the first row in Sheet1:
+//
+//	height, err := f.GetRowDisplayHeight("Sheet1", 1)
+func (f *File) GetRowDisplayHeight(sheet string, row int) (float64, error) {
+	if row < 1 {
+		return defaultRowHeight, newInvalidRowNumberError(row)
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return defaultRowHeight, err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ht, zeroHeight := defaultRowHeight, false
+	if ws.SheetFormatPr != nil {
+		if ws.SheetFormatPr.DefaultRowHeight > 0 {
+			ht = ws.SheetFormatPr.DefaultRowHeight
+		}
+		zeroHeight = ws.SheetFormatPr.ZeroHeight
+	}
+	for i := range ws.SheetData.Row {
+		if r := &ws.SheetData.Row[i]; r.R == row {
+			if r.Hidden {
+				return 0, err
+			}
+			if r.Ht != nil {
+				return *r.Ht, err
+			}
+			return ht, err
+		}
+	}
+	if zeroHeight {
+		return 0, err
+	}
+	return ht, err
+}
+
+// SetDefaultRowHeight provides a function to set the default height of the
+// rows in the worksheet by given worksheet name and height in points, which
+// will be used for the rows without custom height. This function is
+// concurrency safe. For example, set the default row height of Sheet1 to 20:
+//
+//	err := f.SetDefaultRowHeight("Sheet1", 20)
+func (f *File) SetDefaultRowHeight(sheet string, height float64) error {
+	if height > MaxRowHeight {
+		return ErrMaxRowHeight
+	}
+	if height <= 0 {
+		return ErrParameterInvalid
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.SheetFormatPr == nil {
+		ws.SheetFormatPr = &xlsxSheetFormatPr{}
+	}
+	ws.SheetFormatPr.DefaultRowHeight, ws.SheetFormatPr.CustomHeight = height, true
+	return err
+}
+
 // sharedStringsReader provides a function to get the pointer to the structure
 // after deserialization of xl/sharedStrings.xml.
 func (f *File) sharedStringsReader() (*xlsxSST, error) {
@@ -454,12 +820,7 @@ func (f *File) sharedStringsReader() (*xlsxSST, error) {
 		if sharedStrings.UniqueCount == 0 {
 			sharedStrings.UniqueCount = sharedStrings.Count
 		}
-		f.SharedStrings = &sharedStrings
-		for i := range sharedStrings.SI {
-			if sharedStrings.SI[i].T != nil {
-				f.sharedStringsMap[sharedStrings.SI[i].T.Val] = i
-			}
-		}
+		f.SharedStrings, f.sharedStringsMap = &sharedStrings, nil
 		if err = f.addContentTypePart(0, "sharedStrings"); err != nil {
 			return f.SharedStrings, err
 		}
@@ -534,7 +895,9 @@ func (f *File) SetRowOutlineLevel(sheet string, row int, level uint8) error {
 		return err
 	}
 	ws.prepareSheetXML(0, row)
+	prev := ws.SheetData.Row[row-1].OutlineLevel
 	ws.SheetData.Row[row-1].OutlineLevel = level
+	ws.setSheetOutlineLevel(rows, prev, level)
 	return nil
 }
 
@@ -557,6 +920,129 @@ func (f *File) GetRowOutlineLevel(sheet string, row int) (uint8, error) {
 	return ws.SheetData.Row[row-1].OutlineLevel, nil
 }
 
+// SetRowCollapsed provides a function to collapse or expand the outline group
+// of detail rows by given worksheet name, Excel row number of the summary row
+// and collapsed state. The detail rows are the adjacent rows with an outline
+// level greater than the summary row, which are placed above the summary row
+// when summary rows appear below detail (default), or below the summary row
+// otherwise. When expanding, the rows of nested groups which are collapsed
+// remain hidden. For example, group rows 2-4 in Sheet1 summarized by row 5
+// and collapse them:
+//
+//	for r := 2; r <= 4; r++ {
+//	    if err := f.SetRowOutlineLevel("Sheet1", r, 1); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	}
+//	err := f.SetRowCollapsed("Sheet1", 5, true)
+func (f *File) SetRowCollapsed(sheet string, row int, collapsed bool) error {
+	if row < 1 {
+		return newInvalidRowNumberError(row)
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.prepareSheetXML(0, row)
+	step := -1
+	if ws.SheetPr != nil && ws.SheetPr.OutlinePr != nil &&
+		ws.SheetPr.OutlinePr.SummaryBelow != nil && !*ws.SheetPr.OutlinePr.SummaryBelow {
+		step = 1
+	}
+	level, skipLevel := ws.SheetData.Row[row-1].OutlineLevel, uint8(0)
+	for r := row - 1 + step; r >= 0 && r < len(ws.SheetData.Row); r += step {
+		detail := &ws.SheetData.Row[r]
+		if detail.OutlineLevel <= level {
+			break
+		}
+		if collapsed {
+			detail.Hidden = true
+			continue
+		}
+		if skipLevel > 0 && detail.OutlineLevel > skipLevel {
+			continue
+		}
+		skipLevel, detail.Hidden = 0, false
+		if detail.Collapsed {
+			skipLevel = detail.OutlineLevel
+		}
+	}
+	ws.SheetData.Row[row-1].Collapsed = collapsed
+	return nil
+}
+
+// GetRowCollapsed provides a function to get the collapsed state of the
+// outline group summarized by a single row by given worksheet name and Excel
+// row number. For example, get collapsed state of row 5 in Sheet1:
+//
+//	collapsed, err := f.GetRowCollapsed("Sheet1", 5)
+func (f *File) GetRowCollapsed(sheet string, row int) (bool, error) {
+	if row < 1 {
+		return false, newInvalidRowNumberError(row)
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return false, err
+	}
+	if row > len(ws.SheetData.Row) {
+		return false, nil
+	}
+	return ws.SheetData.Row[row-1].Collapsed, nil
+}
+
+// setSheetOutlineLevel updates the maximum outline level of rows or columns
+// in the sheet format properties by given direction, the previous and new
+// outline level of the changed row or column, which is used by the
+// spreadsheet application to render the outline symbols. The rows or columns
+// will be rescanned only when the level of the row or column which has the
+// maximum outline level was decreased.
+func (ws *xlsxWorksheet) setSheetOutlineLevel(dir adjustDirection, prev, level uint8) {
+	var maxLevel uint8
+	if ws.SheetFormatPr != nil {
+		if maxLevel = ws.SheetFormatPr.OutlineLevelCol; dir == rows {
+			maxLevel = ws.SheetFormatPr.OutlineLevelRow
+		}
+	}
+	if level < maxLevel {
+		if prev != maxLevel {
+			return
+		}
+		maxLevel = ws.getMaxOutlineLevel(dir)
+	} else {
+		maxLevel = level
+	}
+	if ws.SheetFormatPr == nil {
+		ws.SheetFormatPr = &xlsxSheetFormatPr{DefaultRowHeight: defaultRowHeight}
+	}
+	if dir == rows {
+		ws.SheetFormatPr.OutlineLevelRow = maxLevel
+		return
+	}
+	ws.SheetFormatPr.OutlineLevelCol = maxLevel
+}
+
+// getMaxOutlineLevel returns the maximum outline level of the rows or columns
+// in the worksheet by given direction.
+func (ws *xlsxWorksheet) getMaxOutlineLevel(dir adjustDirection) uint8 {
+	var maxLevel uint8
+	if dir == rows {
+		for _, row := range ws.SheetData.Row {
+			if row.OutlineLevel > maxLevel {
+				maxLevel = row.OutlineLevel
+			}
+		}
+		return maxLevel
+	}
+	if ws.Cols != nil {
+		for _, col := range ws.Cols.Col {
+			if col.OutlineLevel > maxLevel {
+				maxLevel = col.OutlineLevel
+			}
+		}
+	}
+	return maxLevel
+}
+
 // RemoveRow provides a function to remove single row by given worksheet name
 // and Excel row number. For example, remove row 3 in Sheet1:
 //
@@ -565,7 +1051,9 @@ func (f *File) GetRowOutlineLevel(sheet string, row int) (uint8, error) {
 // Use this method with caution, which will affect changes in references such
 // as formulas, charts, and so on. If there is any referenced value of the
 // worksheet, it will cause a file error when you open it. The excelize only
-// partially updates these references currently.
+// partially updates these references currently. The references to the
+// removed cells in the formulas, defined names and chart series will be
+// replaced with the "#REF!" error value.
 func (f *File) RemoveRow(sheet string, row int) error {
 	if row < 1 {
 		return newInvalidRowNumberError(row)
@@ -691,6 +1179,408 @@ func (f *File) DuplicateRowTo(sheet string, row, row2 int) error {
 	return err
 }
 
+// CopyRange provides a function to copy the values, formulas, styles and
+// merged cells in a range to another location of the worksheet by given
+// worksheet name, source range reference and the top-left cell reference of
+// the destination. The relative references in the formulas will be adjusted
+// by the offset between the source and destination, the absolute references
+// keep unchanged. The existing cells in the destination will be overwritten.
+// For example, copy the range A1:C3 to E5:G7 in Sheet1:
+//
+//	err := f.CopyRange("Sheet1", "A1:C3", "E5")
+func (f *File) CopyRange(sheet, rangeRef, cell string) error {
+	if !strings.Contains(rangeRef, ":") {
+		rangeRef += ":" + rangeRef
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	dCol, dRow := col-coordinates[0], row-coordinates[1]
+	if coordinates[2]+dCol > MaxColumns {
+		return ErrColumnNumber
+	}
+	if coordinates[3]+dRow > TotalRows {
+		return ErrMaxRows
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	maxCol := coordinates[2]
+	if dCol > 0 {
+		maxCol += dCol
+	}
+	ws.mu.Lock()
+	var cells []xlsxC
+	for r := coordinates[1]; r <= coordinates[3]; r++ {
+		ws.prepareSheetXML(maxCol, r)
+		ws.prepareSheetXML(maxCol, r+dRow)
+		for c := coordinates[0]; c <= coordinates[2]; c++ {
+			var srcCell xlsxC
+			deepcopy.Copy(&srcCell, ws.SheetData.Row[r-1].C[c-1])
+			if srcCell.F != nil {
+				srcCell.F = ws.copyFormula(srcCell.F, srcCell.R, dCol, dRow)
+			}
+			cells = append(cells, srcCell)
+		}
+	}
+	for i, srcCell := range cells {
+		r := coordinates[1] + i/(coordinates[2]-coordinates[0]+1) + dRow
+		c := coordinates[0] + i%(coordinates[2]-coordinates[0]+1) + dCol
+		srcCell.R, _ = CoordinatesToCellName(c, r)
+		ws.SheetData.Row[r-1].C[c-1] = srcCell
+	}
+	ws.mu.Unlock()
+	mergeCells, err := f.GetMergeCells(sheet)
+	if err != nil {
+		return err
+	}
+	for _, mergeCell := range mergeCells {
+		rect, err := rangeRefToCoordinates(mergeCell.GetStartAxis() + ":" + mergeCell.GetEndAxis())
+		if err != nil {
+			return err
+		}
+		if rect[0] < coordinates[0] || rect[1] < coordinates[1] || rect[2] > coordinates[2] || rect[3] > coordinates[3] {
+			continue
+		}
+		topLeftCell, _ := CoordinatesToCellName(rect[0]+dCol, rect[1]+dRow)
+		bottomRightCell, _ := CoordinatesToCellName(rect[2]+dCol, rect[3]+dRow)
+		if err = f.MergeCell(sheet, topLeftCell, bottomRightCell); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// DeleteRange provides a function to delete the cells in a range by given
+// worksheet name, range reference and the shift direction, the other cells
+// will be shifted to fill the deleted cells, which is like the "Delete Cells"
+// in Excel. The shift direction should be "up" or "left". The cells below
+// the range in the same columns will be shifted up, or the cells at the right
+// side of the range in the same rows will be shifted left. The formulas,
+// merged cells and hyperlinks referencing the shifted cells will be adjusted,
+// and the references to the deleted cells will be replaced with #REF! error.
+// It returns an error if the operation will change part of a merged cell.
+// Note that the data validations, conditional formats, tables and drawing
+// objects will not be adjusted. For example, delete the range B2:C3 on
+// Sheet1 and shift the cells up:
+//
+//	err := f.DeleteRange("Sheet1", "B2:C3", "up")
+func (f *File) DeleteRange(sheet, rangeRef, shift string) error {
+	dir, ok := map[string]adjustDirection{"up": rows, "left": columns}[shift]
+	if !ok {
+		return newInvalidOptionalValue("shift", shift, []string{"up", "left"})
+	}
+	if !strings.Contains(rangeRef, ":") {
+		rangeRef += ":" + rangeRef
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	return f.shiftCells(sheet, coordinates, dir, false)
+}
+
+// InsertCells provides a function to insert blank cells in a range by given
+// worksheet name, range reference and the shift direction, the existing cells
+// will be shifted to make room for the inserted cells, which is like the
+// "Insert Cells" in Excel. The shift direction should be "down" or "right".
+// The cells in the range and below it in the same columns will be shifted
+// down, or the cells in the range and at the right side of it in the same
+// rows will be shifted right. The formulas, merged cells and hyperlinks
+// referencing the shifted cells will be adjusted. It returns an error if the
+// operation will change part of a merged cell, or the cells will be shifted
+// out of the worksheet. Note that the data validations, conditional formats,
+// tables and drawing objects will not be adjusted. For example, insert cells
+// in the range B2:C3 on Sheet1 and shift the cells down:
+//
+//	err := f.InsertCells("Sheet1", "B2:C3", "down")
+func (f *File) InsertCells(sheet, rangeRef, shift string) error {
+	dir, ok := map[string]adjustDirection{"down": rows, "right": columns}[shift]
+	if !ok {
+		return newInvalidOptionalValue("shift", shift, []string{"down", "right"})
+	}
+	if !strings.Contains(rangeRef, ":") {
+		rangeRef += ":" + rangeRef
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	return f.shiftCells(sheet, coordinates, dir, true)
+}
+
+// copyFormula returns a copy of the cell formula with the relative
+// references adjusted by given formula, cell reference and the offset of the
+// columns and rows. The shared formula will be converted to a normal formula.
+func (ws *xlsxWorksheet) copyFormula(formula *xlsxF, cell string, dCol, dRow int) *xlsxF {
+	content := formula.Content
+	if formula.T == STCellFormulaTypeShared && formula.Si != nil {
+		content = getSharedFormula(ws, *formula.Si, cell)
+	}
+	shifted, start := parseSharedFormula(dCol, dRow, []byte(content))
+	if start < len(content) {
+		shifted += content[start:]
+	}
+	if formula.T != STCellFormulaTypeArray {
+		return &xlsxF{Content: shifted}
+	}
+	newFormula := &xlsxF{T: formula.T, Content: shifted}
+	if coordinates, err := rangeRefToCoordinates(formula.Ref); err == nil {
+		coordinates[0], coordinates[1] = coordinates[0]+dCol, coordinates[1]+dRow
+		coordinates[2], coordinates[3] = coordinates[2]+dCol, coordinates[3]+dRow
+		newFormula.Ref, _ = coordinatesToRangeRef(coordinates)
+	}
+	return newFormula
+}
+
+// sortValue defined the value of the cell used for sorting, the kind orders
+// the values in the custom list, numbers, text, logical values, errors and
+// blank cells.
+type sortValue struct {
+	kind int
+	num  float64
+	str  string
+}
+
+// newSortValue returns the value of the cell used for sorting by given cell,
+// shared string table and the custom list.
+func (f *File) newSortValue(c *xlsxC, sst *xlsxSST, list []string) sortValue {
+	val, _ := c.getValueFrom(f, sst, true)
+	if val == "" {
+		return sortValue{kind: 5}
+	}
+	for i, item := range list {
+		if strings.EqualFold(item, val) {
+			return sortValue{kind: 0, num: float64(i)}
+		}
+	}
+	switch c.T {
+	case "b":
+		if val == "1" || strings.EqualFold(val, "TRUE") {
+			return sortValue{kind: 3, num: 1}
+		}
+		return sortValue{kind: 3}
+	case "e":
+		return sortValue{kind: 4, str: val}
+	case "", "n":
+		if num, err := strconv.ParseFloat(val, 64); err == nil {
+			return sortValue{kind: 1, num: num}
+		}
+	}
+	return sortValue{kind: 2, str: strings.ToLower(val)}
+}
+
+// compareSortValue returns an integer comparing two sort values, the blank
+// cells always be placed at the end regardless of the sort order.
+func compareSortValue(a, b sortValue, descending bool) int {
+	if a.kind == 5 || b.kind == 5 {
+		return a.kind/5 - b.kind/5
+	}
+	var result int
+	switch {
+	case a.kind != b.kind:
+		result = a.kind - b.kind
+	case a.num < b.num, a.str < b.str:
+		result = -1
+	case a.num > b.num, a.str > b.str:
+		result = 1
+	}
+	if descending {
+		return -result
+	}
+	return result
+}
+
+// SortRange provides a function to sort the rows in a range by given
+// worksheet name, range reference, sort keys and optional sort options. The
+// values, formulas and styles of the cells will be moved with the rows, and
+// the relative references in the formulas will be adjusted by the offset of
+// the rows. The sort is stable, and the keys will be applied in order. The
+// numbers are placed before the text, logical values and errors in ascending
+// order, the text is compared in case-insensitive, and the blank cells are
+// always placed at the end. The values in the CustomList of the sort key will
+// be placed in the order of the list before other values. Set the Header
+// option to exclude the first row of the range from sorting. Note that the
+// merged cells in the range will not be moved. For example, sort the range
+// A1:C10 with a header row on Sheet1 by column B in descending order and then
+// by column A in the custom order:
+//
+//	err := f.SortRange("Sheet1", "A1:C10", []excelize.SortKey{
+//	    {Column: "B", Descending: true},
+//	    {Column: "A", CustomList: []string{"High", "Medium", "Low"}},
+//	}, excelize.SortOptions{Header: true})
+func (f *File) SortRange(sheet, rangeRef string, keys []SortKey, opts ...SortOptions) error {
+	if len(keys) == 0 {
+		return ErrParameterRequired
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	for _, opt := range opts {
+		if opt.Header {
+			coordinates[1]++
+		}
+	}
+	cols := make([]int, len(keys))
+	for i, key := range keys {
+		if cols[i], err = ColumnNameToNumber(key.Column); err != nil {
+			return err
+		}
+		if cols[i] < coordinates[0] || cols[i] > coordinates[2] {
+			return ErrParameterInvalid
+		}
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	type sortRow struct {
+		row    int
+		values []sortValue
+	}
+	var rows []sortRow
+	for r := coordinates[1]; r <= coordinates[3]; r++ {
+		ws.prepareSheetXML(coordinates[2], r)
+		row := sortRow{row: r}
+		for i, key := range keys {
+			row.values = append(row.values, f.newSortValue(&ws.SheetData.Row[r-1].C[cols[i]-1], sst, key.CustomList))
+		}
+		rows = append(rows, row)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		for k, key := range keys {
+			if result := compareSortValue(rows[i].values[k], rows[j].values[k], key.Descending); result != 0 {
+				return result < 0
+			}
+		}
+		return false
+	})
+	var cells []xlsxC
+	for i, row := range rows {
+		dRow := coordinates[1] + i - row.row
+		for c := coordinates[0]; c <= coordinates[2]; c++ {
+			var srcCell xlsxC
+			deepcopy.Copy(&srcCell, ws.SheetData.Row[row.row-1].C[c-1])
+			if srcCell.F != nil {
+				srcCell.F = ws.copyFormula(srcCell.F, srcCell.R, 0, dRow)
+			}
+			cells = append(cells, srcCell)
+		}
+	}
+	for i, srcCell := range cells {
+		r := coordinates[1] + i/(coordinates[2]-coordinates[0]+1)
+		c := coordinates[0] + i%(coordinates[2]-coordinates[0]+1)
+		srcCell.R, _ = CoordinatesToCellName(c, r)
+		ws.SheetData.Row[r-1].C[c-1] = srcCell
+	}
+	return err
+}
+
+// RemoveDuplicates provides a function to remove the duplicate rows in a range
+// by given worksheet name, range reference and the key columns. The columns
+// are the one-based column index in the range, such as 1 for the first column
+// of the range, all columns in the range will be used if the columns is
+// empty. The data types and values of the key columns are compared, the text
+// values are compared in case-insensitive, such as the number 1 and the text
+// "1" are different values. The first occurrence of the rows will be kept.
+// The remaining rows in the range will be shifted up, the vacated cells at the
+// bottom of the range keep their styles, and the cells outside the range will
+// not be changed.
+// For example, remove the duplicate rows in the range A2:C10 on Sheet1 based
+// on the first and third columns of the range:
+//
+//	err := f.RemoveDuplicates("Sheet1", "A2:C10", []int{1, 3})
+func (f *File) RemoveDuplicates(sheet, rangeRef string, columns []int) error {
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	width := coordinates[2] - coordinates[0] + 1
+	for _, col := range columns {
+		if col < 1 || col > width {
+			return ErrColumnNumber
+		}
+	}
+	if len(columns) == 0 {
+		for col := 1; col <= width; col++ {
+			columns = append(columns, col)
+		}
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	var (
+		cells []xlsxC
+		keys  = map[string]struct{}{}
+	)
+	for r := coordinates[1]; r <= coordinates[3]; r++ {
+		ws.prepareSheetXML(coordinates[2], r)
+		values := make([]string, len(columns))
+		for i, col := range columns {
+			c := ws.SheetData.Row[r-1].C[coordinates[0]+col-2]
+			val, _ := c.getValueFrom(f, sst, true)
+			kind := c.T
+			switch c.T {
+			case "", "n":
+				kind = "n"
+			case "s", "str", "inlineStr":
+				kind = "s"
+			}
+			values[i] = kind + ":" + strings.ToLower(val)
+		}
+		key := strings.Join(values, "\x00")
+		if _, ok := keys[key]; ok {
+			continue
+		}
+		keys[key] = struct{}{}
+		dRow := coordinates[1] + len(keys) - 1 - r
+		for c := coordinates[0]; c <= coordinates[2]; c++ {
+			var srcCell xlsxC
+			deepcopy.Copy(&srcCell, ws.SheetData.Row[r-1].C[c-1])
+			if srcCell.F != nil {
+				srcCell.F = ws.copyFormula(srcCell.F, srcCell.R, 0, dRow)
+			}
+			cells = append(cells, srcCell)
+		}
+	}
+	for r := coordinates[1]; r <= coordinates[3]; r++ {
+		for c := coordinates[0]; c <= coordinates[2]; c++ {
+			cell := xlsxC{S: ws.SheetData.Row[r-1].C[c-1].S}
+			if i := (r-coordinates[1])*width + c - coordinates[0]; i < len(cells) {
+				cell = cells[i]
+			}
+			cell.R, _ = CoordinatesToCellName(c, r)
+			ws.SheetData.Row[r-1].C[c-1] = cell
+		}
+	}
+	return err
+}
+
 // duplicateSQRefHelper provides a function to adjust conditional formatting and
 // data validations cell reference when duplicate rows.
 func duplicateSQRefHelper(row, row2 int, ref string) (string, error) {
@@ -896,6 +1786,9 @@ func (r *xlsxRow) hasAttr() bool {
 // SetRowStyle provides a function to set the style of rows by given worksheet
 // name, row range, and style ID. Note that this will overwrite the existing
 // styles for the rows, it won't append or merge style with existing styles.
+// The style is stored in the row definition, and only the existing cells in
+// the rows will be updated, so no cell records will be created for the empty
+// cells.
 //
 // For example set style of row 1 on Sheet1:
 //
@@ -927,17 +1820,23 @@ func (f *File) SetRowStyle(sheet string, start, end, styleID int) error {
 	if err != nil {
 		return err
 	}
+	ws.setRowStyle(start, end, styleID)
+	return nil
+}
+
+// setRowStyle provides a function to set style of rows by given row range and
+// style ID, and update the style of the existing cells in the rows.
+func (ws *xlsxWorksheet) setRowStyle(start, end, styleID int) {
 	ws.prepareSheetXML(0, end)
 	for row := start - 1; row < end; row++ {
 		ws.SheetData.Row[row].S = styleID
-		ws.SheetData.Row[row].CustomFormat = true
+		ws.SheetData.Row[row].CustomFormat = styleID != 0
 		for i := range ws.SheetData.Row[row].C {
 			if _, rowNum, err := CellNameToCoordinates(ws.SheetData.Row[row].C[i].R); err == nil && rowNum-1 == row {
 				ws.SheetData.Row[row].C[i].S = styleID
 			}
 		}
 	}
-	return nil
 }
 
 // convertRowHeightToPixels provides a function to convert the height of a
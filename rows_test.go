@@ -5,6 +5,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,6 +22,95 @@ func TestGetRows(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGetRowsFullRange(t *testing.T) {
+	f := NewFile()
+	opts := Options{FullRange: true}
+	// Test get rows and columns with full range on an empty worksheet
+	rows, err := f.GetRows("Sheet1", opts)
+	assert.NoError(t, err)
+	assert.Empty(t, rows)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "A1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", "C1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B3", "B3"))
+	style, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D5", "D5", style))
+
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"A1", "", "C1"}, nil, {"", "B3"}}, rows)
+	rows, err = f.GetRows("Sheet1", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"A1", "", "C1"}, {"", "", ""}, {"", "B3", ""}}, rows)
+	cols, err := f.GetCols("Sheet1", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"A1", "", "", "", ""}, {"", "", "B3", "", ""}, {"C1", "", "", "", ""}, {"", "", "", "", ""},
+	}, cols)
+
+	// Test get rows with full range with the option specified on open
+	f.options.FullRange = true
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+	f.options.FullRange = false
+
+	// Test get rows with full range without loading the worksheet
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	rows, err = f.GetRows("Sheet2", opts)
+	assert.NoError(t, err)
+	for _, row := range rows {
+		assert.Len(t, row, len(rows[0]))
+	}
+	_, ok := f.Sheet.Load("xl/worksheets/sheet2.xml")
+	assert.False(t, ok)
+	assert.NoError(t, f.Close())
+}
+
+func TestGetRange(t *testing.T) {
+	f := NewFile()
+	for r := 1; r <= 10; r++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r), &[]interface{}{r, fmt.Sprintf("B%d", r), nil, fmt.Sprintf("D%d", r)}))
+	}
+	assert.NoError(t, f.SetCellValue("Sheet1", "C5", "C5"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "F12", "F12"))
+
+	rows, err := f.GetRange("Sheet1", "B3:C6")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"B3"}, {"B4"}, {"B5", "C5"}, {"B6"}}, rows)
+	// Test get range with unsorted range reference and blank rows
+	rows, err = f.GetRange("Sheet1", "F13:E4")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{nil, nil, nil, nil, nil, nil, nil, nil, {"", "F12"}}, rows)
+	rows, err = f.GetRange("Sheet1", "C1:C4")
+	assert.NoError(t, err)
+	assert.Empty(t, rows)
+	// Test get range with single cell reference
+	rows, err = f.GetRange("Sheet1", "$D$7")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"D7"}}, rows)
+	// Test get range with full range
+	rows, err = f.GetRange("Sheet1", "C4:D6", Options{FullRange: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"", "D4"}, {"C5", "D5"}, {"", "D6"}}, rows)
+	rows, err = f.GetRange("Sheet1", "G1:H2", Options{FullRange: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"", ""}, {"", ""}}, rows)
+	// Test get range with invalid range reference
+	_, err = f.GetRange("Sheet1", "A:B1")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get range with invalid sheet name
+	_, err = f.GetRange("Sheet:1", "A1:B2")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test get range with malformed worksheet XML
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData><row r="1"><c r="A"><v>1</v></c></row></sheetData></worksheet>`))
+	f.checked = sync.Map{}
+	_, err = f.GetRange("Sheet1", "A1:B2")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+}
+
 func TestRows(t *testing.T) {
 	const sheet2 = "Sheet2"
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
@@ -110,6 +200,32 @@ func TestRowsIterator(t *testing.T) {
 		require.True(t, rowCount <= expectedNumRow, "rowCount is greater than expected")
 	}
 	assert.Equal(t, expectedNumRow, rowCount)
+
+	// Test rows iterator with malformed worksheet XML
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData><row r="1"><c r="A1" t="str"><v>A</v></c></row><row r="2"><c r="A2"><v>1`))
+	rows, err = f.Rows("Sheet1")
+	require.NoError(t, err)
+	assert.True(t, rows.Next())
+	row, err := rows.Columns()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A"}, row)
+	assert.NoError(t, rows.Error())
+	assert.True(t, rows.Next())
+	_, err = rows.Columns()
+	assert.EqualError(t, err, "XML syntax error on line 1: unexpected EOF")
+	assert.EqualError(t, rows.Error(), "XML syntax error on line 1: unexpected EOF")
+	assert.False(t, rows.Next())
+	assert.NoError(t, rows.Close())
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData><row r="1"`))
+	rows, err = f.Rows("Sheet1")
+	require.NoError(t, err)
+	assert.False(t, rows.Next())
+	assert.EqualError(t, rows.Error(), "XML syntax error on line 1: unexpected EOF")
+	assert.NoError(t, rows.Close())
+	_, err = f.GetRows("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: unexpected EOF")
 }
 
 func TestRowsGetRowOpts(t *testing.T) {
@@ -148,6 +264,72 @@ func TestRowsError(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestCells(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Name"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", 1.5))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C1", "C1", style))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B100", "C1*2"))
+	assert.NoError(t, f.SetCellBool("Sheet1", "D100", true))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "E100", "E100", style))
+
+	cells, err := f.Cells("Sheet1")
+	assert.NoError(t, err)
+	var collected []CellData
+	for cells.Next() {
+		collected = append(collected, cells.Cell())
+	}
+	assert.NoError(t, cells.Error())
+	assert.NoError(t, cells.Close())
+	assert.Equal(t, []CellData{
+		{Cell: "A1", Value: "Name", Type: CellTypeSharedString},
+		{Cell: "C1", Value: "1.5", Type: CellTypeNumber, StyleID: style},
+		{Cell: "B100", Type: CellTypeFormula},
+		{Cell: "D100", Value: "1", Type: CellTypeBool},
+		{Cell: "E100", Type: CellTypeUnset, StyleID: style},
+	}, collected)
+
+	// Test iterate cells without cell references
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(`<worksheet xmlns="%s"><sheetData><row><c/><c t="str"><v>B1</v></c></row><row><c t="inlineStr"><is><t>A2</t></is></c></row></sheetData></worksheet>`, NameSpaceSpreadSheet.Value)))
+	f.checked = sync.Map{}
+	cells, err = f.Cells("Sheet1")
+	assert.NoError(t, err)
+	collected = nil
+	for cells.Next() {
+		collected = append(collected, cells.Cell())
+	}
+	assert.NoError(t, cells.Error())
+	assert.Equal(t, []CellData{
+		{Cell: "B1", Value: "B1", Type: CellTypeFormula},
+		{Cell: "A2", Value: "A2", Type: CellTypeInlineString},
+	}, collected)
+
+	// Test iterate cells with invalid cell reference
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(`<worksheet xmlns="%s"><sheetData><row r="1"><c r="A"><v>1</v></c></row></sheetData></worksheet>`, NameSpaceSpreadSheet.Value)))
+	cells, err = f.Cells("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, cells.Next())
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), cells.Error())
+	// Test iterate cells with malformed worksheet XML
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData><row><c><v>1</v></x>`))
+	cells, err = f.Cells("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, cells.Next())
+	assert.Error(t, cells.Error())
+	// Test iterate cells with not exist worksheet
+	_, err = f.Cells("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test iterate cells with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, err = f.Cells("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestRowHeight(t *testing.T) {
 	f := NewFile()
 	sheet1 := f.GetSheetName(0)
@@ -214,6 +396,84 @@ func TestRowHeight(t *testing.T) {
 	assert.Equal(t, 0.0, convertColWidthToPixels(0))
 }
 
+func TestRowDisplayHeight(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetRowsHeight("Sheet1", 4, 2, 30))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 3, false))
+	assert.NoError(t, f.SetDefaultRowHeight("Sheet1", 20))
+	for row, expected := range map[int]float64{1: 20, 2: 30, 3: 0, 4: 30, 5: 20} {
+		height, err := f.GetRowDisplayHeight("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, height, row)
+	}
+	height, err := f.GetRowHeight("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, height)
+	// Test unset the custom height of multiple rows
+	assert.NoError(t, f.SetRowsHeight("Sheet1", 2, 3, -1))
+	for row, expected := range map[int]float64{2: 20, 3: 0, 4: 30} {
+		height, err = f.GetRowDisplayHeight("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, height, row)
+	}
+	// Test get displayed row height with rows hidden by default
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{ZeroHeight: boolPtr(true)}))
+	for row, expected := range map[int]float64{1: 20, 4: 30, 10: 0} {
+		height, err = f.GetRowDisplayHeight("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, height, row)
+	}
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetFormatPr = nil
+	assert.NoError(t, f.SetDefaultRowHeight("Sheet1", 25))
+	props, err := f.GetSheetProps("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 25.0, *props.DefaultRowHeight)
+	assert.True(t, *props.CustomHeight)
+
+	// Test set the height of the entire rows without creating the row records
+	f = NewFile()
+	assert.NoError(t, f.SetRowHeight("Sheet1", 2, 30))
+	assert.NoError(t, f.SetRowsHeight("Sheet1", TotalRows, 1, 40))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Len(t, ws.(*xlsxWorksheet).SheetData.Row, 2)
+	for row, expected := range map[int]float64{1: 40, 2: 40, TotalRows: 40} {
+		height, err = f.GetRowDisplayHeight("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, height, row)
+	}
+	assert.NoError(t, f.SetRowsHeight("Sheet1", 1, TotalRows, 0))
+	for row, expected := range map[int]float64{2: 0, TotalRows: 0} {
+		height, err = f.GetRowDisplayHeight("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, height, row)
+	}
+	assert.NoError(t, f.SetRowsHeight("Sheet1", 1, TotalRows, -1))
+	for row, expected := range map[int]float64{2: defaultRowHeight, TotalRows: defaultRowHeight} {
+		height, err = f.GetRowDisplayHeight("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, height, row)
+	}
+	assert.False(t, ws.(*xlsxWorksheet).SheetFormatPr.CustomHeight)
+	ws.(*xlsxWorksheet).SheetFormatPr = nil
+	assert.NoError(t, f.SetRowsHeight("Sheet1", 1, TotalRows, 50))
+	assert.Equal(t, 50.0, ws.(*xlsxWorksheet).SheetFormatPr.DefaultRowHeight)
+	assert.Len(t, ws.(*xlsxWorksheet).SheetData.Row, 2)
+
+	// Test get and set row height with invalid arguments
+	_, err = f.GetRowDisplayHeight("Sheet1", 0)
+	assert.Equal(t, newInvalidRowNumberError(0), err)
+	_, err = f.GetRowDisplayHeight("SheetN", 1)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.Equal(t, newInvalidRowNumberError(0), f.SetRowsHeight("Sheet1", 0, 2, 10))
+	assert.Equal(t, ErrMaxRows, f.SetRowsHeight("Sheet1", 1, TotalRows+1, 10))
+	assert.Equal(t, ErrMaxRowHeight, f.SetDefaultRowHeight("Sheet1", MaxRowHeight+1))
+	assert.Equal(t, ErrParameterInvalid, f.SetDefaultRowHeight("Sheet1", 0))
+	assert.EqualError(t, f.SetDefaultRowHeight("SheetN", 20), "sheet SheetN does not exist")
+}
+
 func TestColumns(t *testing.T) {
 	f := NewFile()
 	rows, err := f.Rows("Sheet1")
@@ -954,6 +1214,360 @@ func TestDuplicateRowTo(t *testing.T) {
 	assert.EqualError(t, f.DuplicateRowTo("Sheet:1", 1, 2), ErrSheetNameInvalid.Error())
 }
 
+func TestCopyRange(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Item", 1, 2}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "SUM(B1:C1)*$A$5"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	formulaType, sharedRef := STCellFormulaTypeShared, "B2:C2"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B2", "B1+1", FormulaOpts{Type: &formulaType, Ref: &sharedRef}))
+	assert.NoError(t, f.MergeCell("Sheet1", "A2", "A3"))
+	assert.NoError(t, f.MergeCell("Sheet1", "C3", "E3"))
+	assert.NoError(t, f.CopyRange("Sheet1", "A1:D3", "F5"))
+	for cell, expected := range map[string]string{
+		"F5": "Item", "G5": "1", "H5": "2",
+	} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	for cell, expected := range map[string]string{
+		"I5": "SUM(G5:H5)*$A$5", "G6": "G5+1", "H6": "H5+1",
+	} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	styleID, err := f.GetCellStyle("Sheet1", "F5")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	var refs []string
+	for _, mergeCell := range mergeCells {
+		refs = append(refs, mergeCell.GetStartAxis()+":"+mergeCell.GetEndAxis())
+	}
+	assert.Equal(t, []string{"A2:A3", "C3:E3", "F6:F7"}, refs)
+
+	// Test copy overlapped range with array formula
+	arrayType, arrayRef := STCellFormulaTypeArray, "A10:A11"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A10", "B10:B11*2", FormulaOpts{Type: &arrayType, Ref: &arrayRef}))
+	assert.NoError(t, f.CopyRange("Sheet1", "A10:B11", "B10"))
+	formula, err := f.GetCellFormula("Sheet1", "B10")
+	assert.NoError(t, err)
+	assert.Equal(t, "C10:C11*2", formula)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, "B10:B11", ws.(*xlsxWorksheet).SheetData.Row[9].C[1].F.Ref)
+
+	// Test copy range with single cell reference
+	assert.NoError(t, f.CopyRange("Sheet1", "A1", "A20"))
+	val, err := f.GetCellValue("Sheet1", "A20")
+	assert.NoError(t, err)
+	assert.Equal(t, "Item", val)
+	// Test copy range with invalid parameters
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.CopyRange("Sheet1", "A:B1", "A1"))
+	assert.Equal(t, newCellNameToCoordinatesError("-", newInvalidCellNameError("-")), f.CopyRange("Sheet1", "A1:B1", "-"))
+	assert.Equal(t, ErrColumnNumber, f.CopyRange("Sheet1", "A1:B1", "XFD1"))
+	assert.Equal(t, ErrMaxRows, f.CopyRange("Sheet1", "A1:A2", "A1048576"))
+	assert.EqualError(t, f.CopyRange("SheetN", "A1:B1", "A1"), "sheet SheetN does not exist")
+	assert.Equal(t, ErrSheetNameInvalid, f.CopyRange("Sheet:1", "A1:B1", "A1"))
+	// Test copy range with invalid merged cells
+	ws.(*xlsxWorksheet).MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A1:-"}}}
+	assert.Equal(t, newCellNameToCoordinatesError("-", newInvalidCellNameError("-")), f.CopyRange("Sheet1", "A1:B1", "C1"))
+}
+
+func TestDeleteRange(t *testing.T) {
+	f := NewFile()
+	for r := 1; r <= 4; r++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r), &[]interface{}{r, r * 10, r * 100}))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "SUM(B1:B4)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D2", "B3*2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D3", "$B$4+A3"))
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "Sheet1!B4+1"))
+	assert.NoError(t, f.MergeCell("Sheet1", "B5", "B6"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B4", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.DeleteRange("Sheet1", "B2:B3", "up"))
+	for cell, expected := range map[string]string{
+		"A2": "2", "B1": "10", "B2": "40", "B3": "", "B4": "", "C2": "200",
+	} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	for _, c := range [][]string{
+		{"Sheet1", "D1", "SUM(B1:B2)"}, {"Sheet1", "D2", "#REF!*2"}, {"Sheet1", "D3", "$B$2+A3"}, {"Sheet2", "A1", "Sheet1!B2+1"},
+	} {
+		formula, err := f.GetCellFormula(c[0], c[1])
+		assert.NoError(t, err)
+		assert.Equal(t, c[2], formula, c[1])
+	}
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "B3", mergeCells[0].GetStartAxis())
+	assert.Equal(t, "B4", mergeCells[0].GetEndAxis())
+	link, target, err := f.GetCellHyperLink("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/xuri/excelize", target)
+
+	// Test delete range and shift cells left
+	assert.NoError(t, f.DeleteRange("Sheet1", "A1", "left"))
+	for cell, expected := range map[string]string{"A1": "10", "B1": "100", "A2": "2"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	formula, err := f.GetCellFormula("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(B1:B2)", formula)
+	formula, err = f.GetCellFormula("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+
+	// Test delete range with shared formula
+	f = NewFile()
+	formulaType, sharedRef := STCellFormulaTypeShared, "B1:B3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "A1*2", FormulaOpts{Type: &formulaType, Ref: &sharedRef}))
+	assert.NoError(t, f.DeleteRange("Sheet1", "B2", "up"))
+	for cell, expected := range map[string]string{"B1": "A1*2", "B2": "A3*2", "B3": ""} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+
+	// Test delete range which will change part of a merged cell
+	assert.NoError(t, f.MergeCell("Sheet1", "A10", "B10"))
+	assert.Equal(t, ErrShiftMergedCells, f.DeleteRange("Sheet1", "A9", "up"))
+	assert.NoError(t, f.DeleteRange("Sheet1", "A9", "left"))
+	// Test delete range with invalid parameters
+	assert.Equal(t, newInvalidOptionalValue("shift", "down", []string{"up", "left"}), f.DeleteRange("Sheet1", "A1", "down"))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.DeleteRange("Sheet1", "A:B1", "up"))
+	assert.EqualError(t, f.DeleteRange("SheetN", "A1", "up"), "sheet SheetN does not exist")
+	// Test delete range with invalid merged cells
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A1:-"}}}
+	assert.Equal(t, newCellNameToCoordinatesError("-", newInvalidCellNameError("-")), f.DeleteRange("Sheet1", "A1", "up"))
+}
+
+func TestInsertCells(t *testing.T) {
+	f := NewFile()
+	for r := 1; r <= 3; r++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r), &[]interface{}{r, r * 10, r * 100}))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "SUM(B1:B3)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D2", "$B$2+A2"))
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "Sheet1!B3+1"))
+	assert.NoError(t, f.MergeCell("Sheet1", "B4", "B5"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B3", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.InsertCells("Sheet1", "B2:B3", "down"))
+	for cell, expected := range map[string]string{
+		"A2": "2", "B1": "10", "B2": "", "B3": "", "B4": "20", "B5": "30", "C2": "200",
+	} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	for _, c := range [][]string{
+		{"Sheet1", "D1", "SUM(B1:B5)"}, {"Sheet1", "D2", "$B$4+A2"}, {"Sheet2", "A1", "Sheet1!B5+1"},
+	} {
+		formula, err := f.GetCellFormula(c[0], c[1])
+		assert.NoError(t, err)
+		assert.Equal(t, c[2], formula, c[1])
+	}
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "B6", mergeCells[0].GetStartAxis())
+	assert.Equal(t, "B7", mergeCells[0].GetEndAxis())
+	link, target, err := f.GetCellHyperLink("Sheet1", "B5")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/xuri/excelize", target)
+
+	// Test insert cells and shift cells right
+	assert.NoError(t, f.InsertCells("Sheet1", "A1", "right"))
+	for cell, expected := range map[string]string{"A1": "", "B1": "1", "C1": "10", "D1": "100", "A2": "2"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	formula, err := f.GetCellFormula("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(B1:B5)", formula)
+
+	// Test insert cells with shared formula
+	f = NewFile()
+	formulaType, sharedRef := STCellFormulaTypeShared, "B1:B2"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "A1*2", FormulaOpts{Type: &formulaType, Ref: &sharedRef}))
+	assert.NoError(t, f.InsertCells("Sheet1", "A2", "down"))
+	for cell, expected := range map[string]string{"B1": "A1*2", "B2": "A3*2"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+
+	// Test insert cells which will shift cells out of the worksheet
+	assert.NoError(t, f.SetCellValue("Sheet1", "XFD1", 1))
+	assert.Equal(t, ErrColumnNumber, f.InsertCells("Sheet1", "A1", "right"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1048576", 1))
+	assert.Equal(t, ErrMaxRows, f.InsertCells("Sheet1", "A1", "down"))
+	// Test insert cells which will change part of a merged cell
+	assert.NoError(t, f.MergeCell("Sheet1", "C10", "D10"))
+	assert.Equal(t, ErrShiftMergedCells, f.InsertCells("Sheet1", "C9", "down"))
+	// Test insert cells with invalid parameters
+	assert.Equal(t, newInvalidOptionalValue("shift", "up", []string{"down", "right"}), f.InsertCells("Sheet1", "A1", "up"))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.InsertCells("Sheet1", "A:B1", "down"))
+	assert.EqualError(t, f.InsertCells("SheetN", "A1", "down"), "sheet SheetN does not exist")
+}
+
+func TestSortRange(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	for i, row := range [][]interface{}{
+		{"Name", "Priority", "Score"},
+		{"b", "Low", 80},
+		{"A", "High", 95},
+		{"c", "Medium", 80},
+		{"d", nil, 70},
+		{10, "High", 60},
+		{true, "Low", 80},
+	} {
+		cell, err := CoordinatesToCellName(1, i+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A3", "A3", style))
+	for r := 2; r <= 7; r++ {
+		assert.NoError(t, f.SetCellFormula("Sheet1", fmt.Sprintf("D%d", r), fmt.Sprintf("C%d*$F$1", r)))
+	}
+	assert.NoError(t, f.SortRange("Sheet1", "D7:A1", []SortKey{
+		{Column: "C", Descending: true},
+		{Column: "A"},
+	}, SortOptions{Header: true}))
+	cols, err := f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Name", "A", "b", "c", "TRUE", "d", "10"},
+		{"Priority", "High", "Low", "Medium", "Low", "", "High"},
+		{"Score", "95", "80", "80", "80", "70", "60"},
+	}, cols[:3])
+	styleID, err := f.GetCellStyle("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	for cell, expected := range map[string]string{"D2": "C2*$F$1", "D3": "C3*$F$1", "D7": "C7*$F$1"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula)
+	}
+	// Test sort range with custom list and blank cells
+	assert.NoError(t, f.SortRange("Sheet1", "A2:C7", []SortKey{
+		{Column: "B", CustomList: []string{"high", "medium", "low"}},
+		{Column: "C"},
+	}))
+	cols, err = f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Name", "10", "A", "c", "b", "TRUE", "d"}, cols[0])
+	// Test sort range in descending order with blank cells
+	assert.NoError(t, f.SortRange("Sheet1", "A2:A7", []SortKey{{Column: "A", Descending: true}}))
+	cols, err = f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Name", "TRUE", "d", "c", "b", "A", "10"}, cols[0])
+	// Test sort range without sort keys
+	assert.Equal(t, ErrParameterRequired, f.SortRange("Sheet1", "A1:C7", nil))
+	// Test sort range with invalid range reference
+	assert.Equal(t, ErrParameterInvalid, f.SortRange("Sheet1", "A1", []SortKey{{Column: "A"}}))
+	// Test sort range with invalid sort key column
+	assert.Equal(t, newInvalidColumnNameError("-"), f.SortRange("Sheet1", "A1:C7", []SortKey{{Column: "-"}}))
+	assert.Equal(t, ErrParameterInvalid, f.SortRange("Sheet1", "A1:C7", []SortKey{{Column: "D"}}))
+	// Test sort range on not exists worksheet
+	assert.EqualError(t, f.SortRange("SheetN", "A1:C7", []SortKey{{Column: "A"}}), "sheet SheetN does not exist")
+	// Test sort range with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SortRange("Sheet1", "A1:C7", []SortKey{{Column: "A"}}), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestRemoveDuplicates(t *testing.T) {
+	f := NewFile()
+	for i, row := range [][]interface{}{
+		{"Name", "City", "Amount"},
+		{"Alice", "Paris", 10},
+		{"Bob", "Rome", 20},
+		{"alice", "PARIS", 30},
+		{"Bob", "Rome", 20},
+		{"Carol", "Oslo", 40},
+	} {
+		cell, err := CoordinatesToCellName(1, i+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	for r := 2; r <= 6; r++ {
+		assert.NoError(t, f.SetCellFormula("Sheet1", fmt.Sprintf("D%d", r), fmt.Sprintf("C%d*$F$1", r)))
+	}
+	assert.NoError(t, f.SetCellValue("Sheet1", "E6", "Outside"))
+	// Test remove duplicates by all columns in the range
+	assert.NoError(t, f.RemoveDuplicates("Sheet1", "A2:D6", nil))
+	cols, err := f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Name", "Alice", "Bob", "alice", "Carol", ""}, cols[0])
+	assert.Equal(t, []string{"Amount", "10", "20", "30", "40", ""}, cols[2])
+	assert.Equal(t, "Outside", cols[4][5])
+	for cell, expected := range map[string]string{"D3": "C3*$F$1", "D5": "C5*$F$1", "D6": ""} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula)
+	}
+	// Test remove duplicates by the key columns in case-insensitive
+	assert.NoError(t, f.RemoveDuplicates("Sheet1", "C6:A2", []int{1, 2}))
+	cols, err = f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Name", "Alice", "Bob", "Carol", "", ""}, cols[0])
+	assert.Equal(t, []string{"Amount", "10", "20", "40", "", ""}, cols[2])
+	// Test remove duplicates compares the data types and values
+	f = NewFile()
+	style, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A4", style))
+	for cell, value := range map[string]interface{}{"A1": 1, "A2": "1", "A3": "1", "A4": true} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	assert.NoError(t, f.RemoveDuplicates("Sheet1", "A1:A4", nil))
+	for cell, expected := range map[string]CellType{"A2": CellTypeSharedString, "A3": CellTypeBool} {
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, cellType, cell)
+	}
+	cols, err = f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "1", "TRUE", ""}, cols[0])
+	// Test remove duplicates keeps the styles of the vacated cells
+	styleID, err := f.GetCellStyle("Sheet1", "A4")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	// Test remove duplicates with invalid range reference
+	assert.Equal(t, ErrParameterInvalid, f.RemoveDuplicates("Sheet1", "A1", nil))
+	// Test remove duplicates with invalid key columns
+	assert.Equal(t, ErrColumnNumber, f.RemoveDuplicates("Sheet1", "A1:C6", []int{0}))
+	assert.Equal(t, ErrColumnNumber, f.RemoveDuplicates("Sheet1", "A1:C6", []int{4}))
+	// Test remove duplicates on not exists worksheet
+	assert.EqualError(t, f.RemoveDuplicates("SheetN", "A1:C6", nil), "sheet SheetN does not exist")
+	// Test remove duplicates with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RemoveDuplicates("Sheet1", "A1:C6", nil), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestDuplicateMergeCells(t *testing.T) {
 	f := File{}
 	ws := &xlsxWorksheet{MergeCells: &xlsxMergeCells{
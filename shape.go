@@ -12,6 +12,7 @@
 package excelize
 
 import (
+	"path/filepath"
 	"strconv"
 	"strings"
 )
@@ -46,6 +47,22 @@ func parseShapeOptions(opts *Shape) (*Shape, error) {
 	if opts.Line.Width == nil {
 		opts.Line.Width = float64Ptr(defaultShapeLineWidth)
 	}
+	if opts.Line.Dash != "" && inStrSlice(supportedDrawingDashTypes, opts.Line.Dash, true) == -1 {
+		return nil, ErrParameterInvalid
+	}
+	for _, lineEnd := range []string{opts.Line.HeadEnd, opts.Line.TailEnd} {
+		if lineEnd != "" && inStrSlice(supportedDrawingLineEndTypes, lineEnd, true) == -1 {
+			return nil, ErrParameterInvalid
+		}
+	}
+	if opts.Fill.Type == "gradient" && (len(opts.Fill.Color) != 2 || opts.Fill.Shading < 0 || opts.Fill.Shading > 16) {
+		return nil, ErrParameterInvalid
+	}
+	if opts.PictureFill != nil {
+		if _, ok := supportedImageTypes[strings.ToLower(opts.PictureFill.Extension)]; !ok {
+			return nil, ErrImgExt
+		}
+	}
 	return opts, nil
 }
 
@@ -78,6 +95,69 @@ func parseShapeOptions(opts *Shape) (*Shape, error) {
 //	    },
 //	)
 //
+// Each run of the "Paragraph" will be placed in a separate paragraph. Set the
+// "InlineRuns" to place the runs in the same paragraph with their own font
+// settings, and use the line break "\n" in the text of the run to start a new
+// paragraph.
+//
+// The "Rotation" specifies the clockwise rotation of the shape in degrees.
+//
+// The "Fill" of the shape supports solid fill with one color, and gradient
+// fill by setting the type "gradient" with two colors, the shading variants
+// of the gradient fill are the same with the "Fill" of the cell style, and it
+// returns ErrParameterInvalid for the gradient fill with other number of
+// colors or the shading out of the range of 0 to 16. Set the
+// "Extension" and "File" of the "PictureFill" to fill the shape with a
+// picture, the supported image types are the same with the AddPicture.
+//
+// The "Dash" of the "Line" specifies the preset dash style of the shape
+// outline, the following shows the type of dash supported by excelize:
+//
+//	solid
+//	dot
+//	dash
+//	lgDash
+//	dashDot
+//	lgDashDot
+//	lgDashDotDot
+//	sysDash
+//	sysDot
+//	sysDashDot
+//	sysDashDotDot
+//
+// The connector types "bentConnector2" to "bentConnector5", "curvedConnector2"
+// to "curvedConnector5" and "straightConnector1" will be added as connection
+// shapes without text, the connection shapes are not bound to other shapes.
+// The "HeadEnd" and "TailEnd" of the "Line" specifies the decoration of the
+// head and tail of the line, the following shows the type of line end
+// supported by excelize:
+//
+//	none
+//	triangle
+//	stealth
+//	diamond
+//	oval
+//	arrow
+//
+// For example, add a flowchart process shape with gradient fill, dashed
+// outline and two paragraphs, and a connector with arrow next to it:
+//
+//	err := f.AddShape("Sheet1", &excelize.Shape{
+//	    Cell: "B2",
+//	    Type: "flowChartProcess",
+//	    Fill: excelize.Fill{Type: "gradient", Color: []string{"FFFFFF", "8EB9FF"}, Shading: 1},
+//	    Line: excelize.ShapeLine{Color: "4286F4", Dash: "dash"},
+//	    Paragraph: []excelize.RichTextRun{
+//	        {Text: "Step 1: ", Font: &excelize.Font{Bold: true, Color: "2980B9"}},
+//	        {Text: "Collect\nRaw data", Font: &excelize.Font{Italic: true}},
+//	    },
+//	    InlineRuns: true,
+//	})
+//	err = f.AddShape("Sheet1", &excelize.Shape{
+//	    Cell: "E3", Type: "straightConnector1", Width: 60, Height: 1,
+//	    Line: excelize.ShapeLine{Color: "4286F4", TailEnd: "triangle"},
+//	})
+//
 // The following shows the type of shape supported by excelize:
 //
 //	accentBorderCallout1 (Callout 1 with Border and Accent Shape)
@@ -377,9 +457,11 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 			},
 		},
 		SpPr: &xlsxSpPr{
+			Xfrm: xlsxXfrm{Rot: opts.Rotation % 360 * 60000},
 			PrstGeom: xlsxPrstGeom{
 				Prst: opts.Type,
 			},
+			GradFill: newShapeGradFill(&opts.Fill),
 		},
 		Style: &xdrStyle{
 			LnRef:     setShapeRef(opts.Line.Color, 2),
@@ -407,6 +489,41 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 			W: f.ptToEMUs(*opts.Line.Width),
 		}
 	}
+	if opts.Line.Dash != "" {
+		shape.SpPr.Ln.PrstDash = &attrValString{Val: stringPtr(opts.Line.Dash)}
+	}
+	if opts.Line.HeadEnd != "" {
+		shape.SpPr.Ln.HeadEnd = &aLineEnd{Type: opts.Line.HeadEnd}
+	}
+	if opts.Line.TailEnd != "" {
+		shape.SpPr.Ln.TailEnd = &aLineEnd{Type: opts.Line.TailEnd}
+	}
+	if opts.PictureFill != nil {
+		drawingRels := "xl/drawings/_rels/" + filepath.Base(drawingXML) + ".rels"
+		ext := supportedImageTypes[strings.ToLower(opts.PictureFill.Extension)]
+		mediaStr := ".." + strings.TrimPrefix(f.addMedia(opts.PictureFill.File, ext), "xl")
+		rID := f.addRels(drawingRels, SourceRelationshipImage, mediaStr, "")
+		shape.SpPr.BlipFill = &xlsxBlipFill{
+			Blip: xlsxBlip{Embed: "rId" + strconv.Itoa(rID), R: SourceRelationship.Value},
+		}
+	}
+	twoCellAnchor.ClientData = &xdrClientData{
+		FLocksWithSheet:  *opts.Format.Locked,
+		FPrintsWithSheet: *opts.Format.PrintObject,
+	}
+	if isShapeConnector(opts.Type) {
+		twoCellAnchor.CxnSp = &xdrCxnSp{
+			Macro: opts.Macro,
+			NvCxnSpPr: &xdrNvCxnSpPr{
+				CNvPr: &xlsxCNvPr{ID: cNvPrID, Name: "Connector " + strconv.Itoa(cNvPrID)},
+			},
+			SpPr:  shape.SpPr,
+			Style: shape.Style,
+		}
+		content.TwoCellAnchor = append(content.TwoCellAnchor, twoCellAnchor)
+		f.Drawings.Store(drawingXML, content)
+		return err
+	}
 	defaultFont, err := f.GetDefaultFont()
 	if err != nil {
 		return err
@@ -426,56 +543,118 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 			},
 		}
 	}
-	for _, p := range opts.Paragraph {
-		u := "none"
-		font := &Font{}
-		if p.Font != nil {
-			font = p.Font
-		}
-		if idx := inStrSlice(supportedDrawingUnderlineTypes, font.Underline, true); idx != -1 {
-			u = supportedDrawingUnderlineTypes[idx]
-		}
-		text := p.Text
-		if text == "" {
-			text = " "
-		}
-		paragraph := &aP{
-			R: &aR{
-				RPr: aRPr{
-					I:       font.Italic,
-					B:       font.Bold,
-					Lang:    "en-US",
-					AltLang: "en-US",
-					U:       u,
-					Sz:      font.Size * 100,
-					Latin:   &xlsxCTTextFont{Typeface: font.Family},
-				},
-				T: text,
-			},
-			EndParaRPr: &aEndParaRPr{
-				Lang: "en-US",
-			},
-		}
-		srgbClr := strings.ReplaceAll(strings.ToUpper(font.Color), "#", "")
-		if len(srgbClr) == 6 {
-			paragraph.R.RPr.SolidFill = &aSolidFill{
-				SrgbClr: &attrValString{
-					Val: stringPtr(srgbClr),
-				},
+	if opts.InlineRuns {
+		shape.TxBody.P = newShapeInlineParagraphs(opts.Paragraph)
+	} else {
+		for _, p := range opts.Paragraph {
+			text := p.Text
+			if text == "" {
+				text = " "
 			}
+			shape.TxBody.P = append(shape.TxBody.P, &aP{
+				R:          []*aR{newShapeTextRun(text, p.Font)},
+				EndParaRPr: &aEndParaRPr{Lang: "en-US"},
+			})
 		}
-		shape.TxBody.P = append(shape.TxBody.P, paragraph)
 	}
 	twoCellAnchor.Sp = &shape
-	twoCellAnchor.ClientData = &xdrClientData{
-		FLocksWithSheet:  *opts.Format.Locked,
-		FPrintsWithSheet: *opts.Format.PrintObject,
-	}
 	content.TwoCellAnchor = append(content.TwoCellAnchor, twoCellAnchor)
 	f.Drawings.Store(drawingXML, content)
 	return err
 }
 
+// isShapeConnector returns whether the given preset geometry type of the shape
+// is a connection shape.
+func isShapeConnector(typ string) bool {
+	for _, prefix := range []string{"bentConnector", "curvedConnector", "straightConnector"} {
+		if strings.HasPrefix(typ, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// newShapeInlineParagraphs provides a function to place the runs in the same
+// paragraph with their own font settings, the line break "\n" in the text of
+// the run starts a new paragraph.
+func newShapeInlineParagraphs(runs []RichTextRun) []*aP {
+	paragraph := &aP{EndParaRPr: &aEndParaRPr{Lang: "en-US"}}
+	paragraphs := []*aP{paragraph}
+	for _, p := range runs {
+		for i, text := range strings.Split(p.Text, "\n") {
+			if i > 0 {
+				paragraph = &aP{EndParaRPr: &aEndParaRPr{Lang: "en-US"}}
+				paragraphs = append(paragraphs, paragraph)
+			}
+			if text != "" {
+				paragraph.R = append(paragraph.R, newShapeTextRun(text, p.Font))
+			}
+		}
+	}
+	return paragraphs
+}
+
+// newShapeTextRun provides a function to create a text run of the shape by
+// given text and font settings.
+func newShapeTextRun(text string, font *Font) *aR {
+	u := "none"
+	if font == nil {
+		font = &Font{}
+	}
+	if idx := inStrSlice(supportedDrawingUnderlineTypes, font.Underline, true); idx != -1 {
+		u = supportedDrawingUnderlineTypes[idx]
+	}
+	run := &aR{
+		RPr: aRPr{
+			I:       font.Italic,
+			B:       font.Bold,
+			Lang:    "en-US",
+			AltLang: "en-US",
+			U:       u,
+			Sz:      font.Size * 100,
+			Latin:   &xlsxCTTextFont{Typeface: font.Family},
+		},
+		T: text,
+	}
+	srgbClr := strings.ReplaceAll(strings.ToUpper(font.Color), "#", "")
+	if len(srgbClr) == 6 {
+		run.RPr.SolidFill = &aSolidFill{
+			SrgbClr: &attrValString{
+				Val: stringPtr(srgbClr),
+			},
+		}
+	}
+	return run
+}
+
+// newShapeGradFill provides a function to create the gradient fill of the
+// shape by given fill settings, the shading variants are the same with the
+// gradient fill of the cell style. It returns nil if the fill isn't a valid
+// gradient fill.
+func newShapeGradFill(fill *Fill) *aGradFill {
+	if fill.Type != "gradient" || len(fill.Color) != 2 || fill.Shading < 0 || fill.Shading > 16 {
+		return nil
+	}
+	variant := styleFillVariants()[fill.Shading]
+	gradFill := &aGradFill{RotWithShape: true}
+	for i, stop := range variant.Stop {
+		color := fill.Color[i%2]
+		gradFill.GsLst = append(gradFill.GsLst, &aGs{
+			Pos:     int(stop.Position * 100000),
+			SrgbClr: &attrValString{Val: stringPtr(strings.ReplaceAll(strings.ToUpper(color), "#", ""))},
+		})
+	}
+	if variant.Type == "path" {
+		gradFill.Path = &aPath{Path: "rect", FillToRect: &aFillToRect{
+			L: int(variant.Left * 100000), T: int(variant.Top * 100000),
+			R: int((1 - variant.Right) * 100000), B: int((1 - variant.Bottom) * 100000),
+		}}
+		return gradFill
+	}
+	gradFill.Lin = &aLin{Ang: int(variant.Degree * 60000)}
+	return gradFill
+}
+
 // setShapeRef provides a function to set color with hex model by given actual
 // color value.
 func setShapeRef(color string, i int) *aRef {
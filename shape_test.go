@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -98,6 +99,95 @@ func TestAddShape(t *testing.T) {
 	assert.EqualError(t, f.AddShape("Sheet1", &Shape{Cell: "B30", Type: "rect", Paragraph: []RichTextRun{{Text: "Rectangle"}, {}}}), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestAddShapeFormat(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{
+		Cell:     "B2",
+		Type:     "flowChartProcess",
+		Rotation: 450,
+		Fill:     Fill{Type: "gradient", Color: []string{"FFFFFF", "#8EB9FF"}, Shading: 2},
+		Line:     ShapeLine{Color: "4286F4", Dash: "dash"},
+		Paragraph: []RichTextRun{
+			{Text: "Step 1: ", Font: &Font{Bold: true, Color: "2980B9"}},
+			{Text: "Collect\nRaw data", Font: &Font{Italic: true}},
+		},
+		InlineRuns: true,
+	}))
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{
+		Cell: "E2",
+		Type: "ellipse",
+		Fill: Fill{Type: "gradient", Color: []string{"FFFFFF", "8EB9FF"}, Shading: 16},
+	}))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	sp := drawing.(*xlsxWsDr).TwoCellAnchor[0].Sp
+	assert.Equal(t, 5400000, sp.SpPr.Xfrm.Rot)
+	assert.Equal(t, &aLin{Ang: 5400000}, sp.SpPr.GradFill.Lin)
+	assert.Len(t, sp.SpPr.GradFill.GsLst, 3)
+	assert.Equal(t, []int{0, 50000, 100000}, []int{sp.SpPr.GradFill.GsLst[0].Pos, sp.SpPr.GradFill.GsLst[1].Pos, sp.SpPr.GradFill.GsLst[2].Pos})
+	assert.Equal(t, "8EB9FF", *sp.SpPr.GradFill.GsLst[1].SrgbClr.Val)
+	assert.Equal(t, "dash", *sp.SpPr.Ln.PrstDash.Val)
+	assert.Len(t, sp.TxBody.P, 2)
+	assert.Len(t, sp.TxBody.P[0].R, 2)
+	assert.Equal(t, "Step 1: ", sp.TxBody.P[0].R[0].T)
+	assert.True(t, sp.TxBody.P[0].R[0].RPr.B)
+	assert.Equal(t, "Collect", sp.TxBody.P[0].R[1].T)
+	assert.True(t, sp.TxBody.P[0].R[1].RPr.I)
+	assert.Equal(t, "Raw data", sp.TxBody.P[1].R[0].T)
+	sp = drawing.(*xlsxWsDr).TwoCellAnchor[1].Sp
+	assert.Nil(t, sp.SpPr.GradFill.Lin)
+	assert.Equal(t, &aPath{Path: "rect", FillToRect: &aFillToRect{L: 50000, T: 50000, R: 50000, B: 50000}}, sp.SpPr.GradFill.Path)
+	// Test add shape with invalid gradient fill colors and shading
+	for _, fill := range []Fill{
+		{Type: "gradient", Color: []string{"FFFFFF"}},
+		{Type: "gradient", Color: []string{"FFFFFF", "8EB9FF", "4286F4"}},
+		{Type: "gradient", Color: []string{"FFFFFF", "8EB9FF"}, Shading: -1},
+		{Type: "gradient", Color: []string{"FFFFFF", "8EB9FF"}, Shading: 17},
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.AddShape("Sheet1", &Shape{Cell: "H2", Type: "rect", Fill: fill}))
+	}
+	assert.Len(t, drawing.(*xlsxWsDr).TwoCellAnchor, 2)
+	// Test add shape with each run in a separate paragraph
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "H6", Type: "rect", Paragraph: []RichTextRun{{Text: "Step 1"}, {}}}))
+	sp = drawing.(*xlsxWsDr).TwoCellAnchor[2].Sp
+	assert.Len(t, sp.TxBody.P, 2)
+	assert.Equal(t, "Step 1", sp.TxBody.P[0].R[0].T)
+	assert.Equal(t, " ", sp.TxBody.P[1].R[0].T)
+	// Test add shape with picture fill
+	file, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "H10", Type: "ellipse", PictureFill: &Picture{Extension: ".png", File: file}}))
+	sp = drawing.(*xlsxWsDr).TwoCellAnchor[3].Sp
+	assert.Equal(t, "rId1", sp.SpPr.BlipFill.Blip.Embed)
+	rels, err := f.relsReader("xl/drawings/_rels/drawing1.xml.rels")
+	assert.NoError(t, err)
+	assert.Equal(t, SourceRelationshipImage, rels.Relationships[0].Type)
+	assert.Equal(t, "../media/image1.png", rels.Relationships[0].Target)
+	// Test add connection shape with line ends
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "H14", Type: "straightConnector1", Height: 1, Line: ShapeLine{HeadEnd: "oval", TailEnd: "triangle"}}))
+	anchor := drawing.(*xlsxWsDr).TwoCellAnchor[4]
+	assert.Nil(t, anchor.Sp)
+	assert.Equal(t, "Connector 6", anchor.CxnSp.NvCxnSpPr.CNvPr.Name)
+	assert.Equal(t, &aLineEnd{Type: "oval"}, anchor.CxnSp.SpPr.Ln.HeadEnd)
+	assert.Equal(t, &aLineEnd{Type: "triangle"}, anchor.CxnSp.SpPr.Ln.TailEnd)
+	// Test add shape with unsupported line dash type
+	assert.Equal(t, ErrParameterInvalid, f.AddShape("Sheet1", &Shape{Cell: "H2", Type: "rect", Line: ShapeLine{Dash: "unknown"}}))
+	// Test add shape with unsupported line end type
+	assert.Equal(t, ErrParameterInvalid, f.AddShape("Sheet1", &Shape{Cell: "H2", Type: "rect", Line: ShapeLine{TailEnd: "unknown"}}))
+	// Test add shape with unsupported picture fill image type
+	assert.Equal(t, ErrImgExt, f.AddShape("Sheet1", &Shape{Cell: "H2", Type: "rect", PictureFill: &Picture{Extension: ".txt", File: file}}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddShapeFormat.xlsx")))
+	assert.NoError(t, f.Close())
+	// Test the connection shape and picture fill keeps after reopen the workbook
+	f, err = OpenFile(filepath.Join("test", "TestAddShapeFormat.xlsx"))
+	assert.NoError(t, err)
+	content, ok := f.Pkg.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), "<xdr:cxnSp macro=\"\">")
+	assert.Contains(t, string(content.([]byte)), "<a:blipFill><a:blip r:embed=\"rId1\"")
+	assert.NoError(t, f.Close())
+}
+
 func TestAddDrawingShape(t *testing.T) {
 	f := NewFile()
 	path := "xl/drawings/drawing1.xml"
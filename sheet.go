@@ -24,6 +24,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"unicode"
 	"unicode/utf16"
 	"unicode/utf8"
 
@@ -46,6 +47,22 @@ const (
 	IgnoredErrorsCalculatedColumn
 )
 
+// HeaderFooterFieldType is the type of header and footer field.
+type HeaderFooterFieldType byte
+
+// Header and footer field types enumeration.
+const (
+	HeaderFooterFieldText HeaderFooterFieldType = iota
+	HeaderFooterFieldPageNumber
+	HeaderFooterFieldTotalPages
+	HeaderFooterFieldDate
+	HeaderFooterFieldTime
+	HeaderFooterFieldFileName
+	HeaderFooterFieldFilePath
+	HeaderFooterFieldSheetName
+	HeaderFooterFieldPicture
+)
+
 // NewSheet provides the function to create a new sheet by given a worksheet
 // name and returns the index of the sheets in the workbook after it appended.
 // Note that when creating a new workbook, the default worksheet named
@@ -151,12 +168,14 @@ func (f *File) mergeExpandedCols(ws *xlsxWorksheet) {
 }
 
 // workSheetWriter provides a function to save xl/worksheets/sheet%d.xml after
-// serialize structure.
-func (f *File) workSheetWriter() {
+// serialize structure. The worksheets will be encoded into the system temporary
+// directory directly when the UseTempFiles option was enabled.
+func (f *File) workSheetWriter() error {
 	var (
 		arr     []byte
 		buffer  = bytes.NewBuffer(arr)
 		encoder = xml.NewEncoder(buffer)
+		err     error
 	)
 	f.Sheet.Range(func(p, ws interface{}) bool {
 		if ws != nil {
@@ -168,6 +187,7 @@ func (f *File) workSheetWriter() {
 				f.mergeExpandedCols(sheet)
 			}
 			sheet.SheetData.Row = trimRow(&sheet.SheetData)
+			sheet.updateDimension()
 			if sheet.SheetPr != nil || sheet.Drawing != nil || sheet.Hyperlinks != nil || sheet.Picture != nil || sheet.TableParts != nil {
 				f.addNameSpaces(p.(string), SourceRelationship)
 			}
@@ -178,9 +198,17 @@ func (f *File) workSheetWriter() {
 				}
 			}
 			sheet.DecodeAlternateContent = nil
-			// reusing buffer
-			_ = encoder.Encode(sheet)
-			f.saveFileList(p.(string), replaceRelationshipsBytes(f.replaceNameSpaceBytes(p.(string), buffer.Bytes())))
+			if f.options != nil && f.options.UseTempFiles {
+				if err = f.saveTempFile(p.(string), func(w io.Writer) error {
+					return f.writeWorksheet(w, p.(string), sheet)
+				}); err != nil {
+					return false
+				}
+			} else {
+				// reusing buffer
+				_ = encoder.Encode(sheet)
+				f.saveFileList(p.(string), replaceRelationshipsBytes(f.replaceNameSpaceBytes(p.(string), buffer.Bytes())))
+			}
 			_, ok := f.checked.Load(p.(string))
 			if ok {
 				f.Sheet.Delete(p.(string))
@@ -190,6 +218,33 @@ func (f *File) workSheetWriter() {
 		}
 		return true
 	})
+	return err
+}
+
+// writeWorksheet provides a function to encode the worksheet into the given
+// writer row by row, without holding the whole serialized worksheet in memory.
+func (f *File) writeWorksheet(w io.Writer, path string, ws *xlsxWorksheet) error {
+	rows := ws.SheetData.Row
+	ws.SheetData.Row = nil
+	output, err := xml.Marshal(ws)
+	ws.SheetData.Row = rows
+	if err != nil {
+		return err
+	}
+	output = replaceRelationshipsBytes(f.replaceNameSpaceBytes(path, output))
+	tag := []byte("<sheetData>")
+	idx := bytes.Index(output, tag) + len(tag)
+	if _, err = w.Write(append([]byte(xml.Header), output[:idx]...)); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	for i := range rows {
+		if err = enc.EncodeElement(&rows[i], xml.StartElement{Name: xml.Name{Local: "row"}}); err != nil {
+			return err
+		}
+	}
+	_, err = w.Write(output[idx:])
+	return err
 }
 
 // trimRow provides a function to trim empty rows.
@@ -503,6 +558,9 @@ func (f *File) getSheetMap() (map[string]string, error) {
 				if _, ok := f.tempFiles.Load(sheetXMLPath); ok {
 					maps[v.Name] = sheetXMLPath
 				}
+				if _, ok := f.zipFiles.Load(sheetXMLPath); ok {
+					maps[v.Name] = sheetXMLPath
+				}
 			}
 		}
 	}
@@ -592,20 +650,29 @@ func (f *File) DeleteSheet(sheet string) error {
 
 		wb.Sheets.Sheet = append(wb.Sheets.Sheet[:idx], wb.Sheets.Sheet[idx+1:]...)
 		var sheetXML, rels string
+		contentType := ContentTypeSpreadSheetMLWorksheet
 		if wbRels != nil {
 			for _, rel := range wbRels.Relationships {
 				if rel.ID == v.ID {
 					sheetXML = f.getWorksheetPath(rel.Target)
 					sheetXMLPath, _ := f.getSheetXMLPath(sheet)
 					rels = "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+					if rel.Type == SourceRelationshipChartsheet {
+						contentType = ContentTypeSpreadSheetMLChartsheet
+						rels = "xl/chartsheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/chartsheets/") + ".rels"
+					}
 				}
 			}
 		}
 		target := f.deleteSheetFromWorkbookRels(v.ID)
-		_ = f.removeContentTypesPart(ContentTypeSpreadSheetMLWorksheet, target)
+		_ = f.removeContentTypesPart(contentType, target)
 		_ = f.deleteCalcChain(f.getSheetID(sheet), "")
 		delete(f.sheetMap, v.Name)
 		f.Pkg.Delete(sheetXML)
+		f.zipFiles.Delete(sheetXML)
+		if path, ok := f.tempFiles.LoadAndDelete(sheetXML); ok {
+			_ = os.Remove(path.(string))
+		}
 		f.Pkg.Delete(rels)
 		f.Relationships.Delete(rels)
 		f.Sheet.Delete(sheetXML)
@@ -789,6 +856,461 @@ func (f *File) copySheet(from, to int) error {
 	return err
 }
 
+// CopySheetBetweenFiles provides a function to duplicate a worksheet from
+// another workbook by given source workbook, source worksheet name and the
+// new worksheet name, the new worksheet must not exist in the workbook. The
+// cell values, styles, merged cells, conditional formats, hyperlinks,
+// comments, background picture and drawing objects (such as pictures, shapes
+// and charts) of the worksheet will be copied, and the style indexes will be
+// re-indexed in the workbook. The source worksheet name in the data
+// references of the copied charts will be replaced with the new worksheet
+// name. Note that currently doesn't support copy tables, slicers, form
+// controls and the pictures placed in cells, and the references to other
+// worksheets in the formulas will not be changed. For example, copy the
+// worksheet named Template in the workbook Book1.xlsx as a worksheet named
+// Report:
+//
+//	src, err := excelize.OpenFile("Book1.xlsx")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.CopySheetBetweenFiles(src, "Template", "Report")
+func (f *File) CopySheetBetweenFiles(src *File, srcSheet, sheet string) error {
+	if src == nil {
+		return ErrParameterInvalid
+	}
+	idx, err := f.GetSheetIndex(sheet)
+	if err != nil {
+		return err
+	}
+	if idx != -1 {
+		return ErrExistsSheet
+	}
+	srcWs, err := src.workSheetReader(srcSheet)
+	if err != nil {
+		return err
+	}
+	ws := &xlsxWorksheet{}
+	deepcopy.Copy(ws, srcWs)
+	if err = f.copySheetCells(src, ws); err != nil {
+		return err
+	}
+	if err = f.copySheetConditionalFormats(src, ws); err != nil {
+		return err
+	}
+	if ws.SheetViews != nil && len(ws.SheetViews.SheetView) > 0 {
+		ws.SheetViews.SheetView[0].TabSelected = false
+	}
+	ws.Drawing, ws.LegacyDrawing, ws.LegacyDrawingHF, ws.DrawingHF = nil, nil, nil, nil
+	ws.Picture, ws.OleObjects, ws.Controls, ws.TableParts = nil, nil, nil, nil
+	if ws.PageSetUp != nil {
+		ws.PageSetUp.RID = ""
+	}
+	if err = f.copySheetExtLst(ws); err != nil {
+		return err
+	}
+	if _, err = f.NewSheet(sheet); err != nil {
+		return err
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	if srcSheetXMLPath, ok := src.getSheetXMLPath(srcSheet); ok {
+		if attrs, ok := src.xmlAttr.Load(srcSheetXMLPath); ok && attrs != nil {
+			f.xmlAttr.Store(sheetXMLPath, append([]xml.Attr{}, attrs.([]xml.Attr)...))
+		}
+	}
+	f.Sheet.Store(sheetXMLPath, ws)
+	if err = f.copySheetRels(src, srcSheet, sheet, srcWs, ws); err == nil {
+		err = f.copySheetComments(src, srcSheet, sheet)
+	}
+	if err != nil {
+		_ = f.DeleteSheet(sheet)
+	}
+	return err
+}
+
+// copySheetCells provides a function to copy the shared strings and styles
+// used by the cells, rows and columns of the given worksheet from the source
+// workbook, and update their indexes for the workbook.
+func (f *File) copySheetCells(src *File, ws *xlsxWorksheet) error {
+	if err := src.sharedStringsLoader(); err != nil {
+		return err
+	}
+	sst, err := src.sharedStringsReader()
+	if err != nil {
+		return err
+	}
+	styles := map[int]int{}
+	for r := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[r]
+		if row.S, err = f.copyStyleFrom(src, row.S, styles); err != nil {
+			return err
+		}
+		for c := range row.C {
+			cell := &row.C[c]
+			if cell.S, err = f.copyStyleFrom(src, cell.S, styles); err != nil {
+				return err
+			}
+			cell.Cm, cell.Vm = nil, nil
+			if cell.T != "s" || cell.V == "" {
+				continue
+			}
+			idx, _ := strconv.Atoi(strings.TrimSpace(cell.V))
+			sst.mu.Lock()
+			if idx < 0 || idx >= len(sst.SI) {
+				sst.mu.Unlock()
+				continue
+			}
+			var si xlsxSI
+			deepcopy.Copy(&si, sst.SI[idx])
+			sst.mu.Unlock()
+			if idx, err = f.setSharedStringItem(si); err != nil {
+				return err
+			}
+			cell.V = strconv.Itoa(idx)
+		}
+	}
+	if ws.Cols != nil {
+		for c := range ws.Cols.Col {
+			if ws.Cols.Col[c].Style, err = f.copyStyleFrom(src, ws.Cols.Col[c].Style, styles); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// copyStyleFrom provides a function to create the cell style in the workbook
+// by given source workbook and style index of the source workbook, and
+// returns the style index in the workbook. The created styles will be cached
+// in the given map to avoid duplicate creation.
+func (f *File) copyStyleFrom(src *File, styleIdx int, styles map[int]int) (int, error) {
+	if styleIdx == 0 {
+		return styleIdx, nil
+	}
+	if idx, ok := styles[styleIdx]; ok {
+		return idx, nil
+	}
+	style, err := src.GetStyle(styleIdx)
+	if err != nil {
+		return 0, err
+	}
+	idx, err := f.NewStyle(style)
+	if err != nil {
+		return 0, err
+	}
+	styles[styleIdx] = idx
+	return idx, err
+}
+
+// setSharedStringItem provides a function to add a string item which may
+// contain rich text runs to the shared string table.
+func (f *File) setSharedStringItem(si xlsxSI) (int, error) {
+	if si.T != nil && len(si.R) == 0 && len(si.RPh) == 0 && si.PhoneticPr == nil {
+		return f.setSharedString(si.T.Val)
+	}
+	if err := f.sharedStringsLoader(); err != nil {
+		return 0, err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return 0, err
+	}
+	sst.mu.Lock()
+	defer sst.mu.Unlock()
+	sst.SI = append(sst.SI, si)
+	sst.Count = len(sst.SI)
+	sst.UniqueCount = sst.Count
+	return sst.UniqueCount - 1, nil
+}
+
+// copySheetConditionalFormats provides a function to copy the differential
+// formats used by the conditional formatting rules of the given worksheet
+// from the source workbook, and update their indexes for the workbook.
+func (f *File) copySheetConditionalFormats(src *File, ws *xlsxWorksheet) error {
+	formats := map[int]int{}
+	for _, cf := range ws.ConditionalFormatting {
+		for _, rule := range cf.CfRule {
+			if rule.DxfID == nil {
+				continue
+			}
+			if idx, ok := formats[*rule.DxfID]; ok {
+				rule.DxfID = intPtr(idx)
+				continue
+			}
+			style, err := src.GetConditionalStyle(*rule.DxfID)
+			if err != nil {
+				return err
+			}
+			idx, err := f.NewConditionalStyle(style)
+			if err != nil {
+				return err
+			}
+			formats[*rule.DxfID] = idx
+			rule.DxfID = intPtr(idx)
+		}
+	}
+	return nil
+}
+
+// copySheetExtLst provides a function to remove the extensions which
+// reference the parts of the source workbook in the extension list of the
+// given worksheet.
+func (f *File) copySheetExtLst(ws *xlsxWorksheet) error {
+	if ws.ExtLst == nil {
+		return nil
+	}
+	decodeExtLst := new(decodeExtLst)
+	if err := f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return err
+	}
+	for i := 0; i < len(decodeExtLst.Ext); i++ {
+		if inStrSlice([]string{ExtURISlicerListX14, ExtURISlicerListX15, ExtURITimelineRefs},
+			decodeExtLst.Ext[i].URI, true) != -1 {
+			decodeExtLst.Ext = append(decodeExtLst.Ext[:i], decodeExtLst.Ext[i+1:]...)
+			i--
+		}
+	}
+	if len(decodeExtLst.Ext) == 0 {
+		ws.ExtLst = nil
+		return nil
+	}
+	extLstBytes, err := xml.Marshal(decodeExtLst)
+	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+	return err
+}
+
+// copySheetRels provides a function to copy the hyperlinks, background
+// picture and drawing of the source worksheet with their related parts from
+// the source workbook, and add the relationships for the given worksheet.
+func (f *File) copySheetRels(src *File, srcSheet, sheet string, srcWs, ws *xlsxWorksheet) error {
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+	copied := map[string]string{}
+	copyPart := func(rID, relType string) (string, error) {
+		target := src.getSheetRelationshipsTargetByID(srcSheet, rID)
+		if target == "" {
+			return "", nil
+		}
+		part, err := f.copyPartFrom(src, strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/"), copied)
+		if err != nil {
+			return "", err
+		}
+		f.addSheetNameSpace(sheet, SourceRelationship)
+		return "rId" + strconv.Itoa(f.addRels(sheetRels, relType, strings.Replace(part, "xl", "..", 1), "")), err
+	}
+	if ws.Hyperlinks != nil {
+		for i, link := range ws.Hyperlinks.Hyperlink {
+			if link.RID == "" {
+				continue
+			}
+			rID := f.addRels(sheetRels, SourceRelationshipHyperLink,
+				src.getSheetRelationshipsTargetByID(srcSheet, link.RID), "External")
+			ws.Hyperlinks.Hyperlink[i].RID = "rId" + strconv.Itoa(rID)
+			f.addSheetNameSpace(sheet, SourceRelationship)
+		}
+	}
+	if srcWs.Picture != nil {
+		rID, err := copyPart(srcWs.Picture.RID, SourceRelationshipImage)
+		if err != nil {
+			return err
+		}
+		if rID != "" {
+			ws.Picture = &xlsxPicture{RID: rID}
+		}
+	}
+	if srcWs.Drawing != nil {
+		rID, err := copyPart(srcWs.Drawing.RID, SourceRelationshipDrawingML)
+		if err != nil {
+			return err
+		}
+		if rID != "" {
+			ws.Drawing = &xlsxDrawing{RID: rID}
+		}
+	}
+	for _, part := range copied {
+		if strings.HasPrefix(part, "xl/charts/chart") {
+			f.copyChartSheetRefs(part, srcSheet, sheet)
+		}
+	}
+	return nil
+}
+
+// copyChartSheetRefs provides a function to replace the source worksheet name
+// in the data references of the copied chart with the new worksheet name by
+// given chart part path, source and new worksheet name.
+func (f *File) copyChartSheetRefs(chartXML, srcSheet, sheet string) {
+	content := chartFormulaRegexp.ReplaceAllStringFunc(string(f.readXML(chartXML)), func(match string) string {
+		parts := chartFormulaRegexp.FindStringSubmatch(match)
+		formula := sheetRefRegexp.ReplaceAllStringFunc(chartFormulaUnescaper.Replace(parts[2]), func(ref string) string {
+			name := strings.TrimSuffix(ref, "!")
+			if strings.HasPrefix(name, "'") {
+				name = strings.ReplaceAll(name[1:len(name)-1], "''", "'")
+			}
+			if !strings.EqualFold(name, srcSheet) {
+				return ref
+			}
+			return escapeSheetName(sheet) + "!"
+		})
+		var buf bytes.Buffer
+		_ = xml.EscapeText(&buf, []byte(formula))
+		return parts[1] + buf.String() + parts[3]
+	})
+	f.Pkg.Store(chartXML, []byte(content))
+}
+
+// copyPartFrom provides a function to copy the part and the parts related to
+// it from the source workbook by given source workbook, part path and a map
+// of copied parts, and returns the path of the new part in the workbook. The
+// new parts keep in the same folders of the source parts with next available
+// file name index.
+func (f *File) copyPartFrom(src *File, part string, copied map[string]string) (string, error) {
+	if newPart, ok := copied[part]; ok {
+		return newPart, nil
+	}
+	content, err := src.readPart(part, true)
+	if err != nil {
+		return "", err
+	}
+	if wsDr, ok := src.Drawings.Load(part); ok && wsDr != nil {
+		wsDr.(*xlsxWsDr).mu.Lock()
+		content, _ = xml.Marshal(wsDr.(*xlsxWsDr))
+		wsDr.(*xlsxWsDr).mu.Unlock()
+	}
+	if strings.HasPrefix(part, "xl/media/") {
+		newPart := f.addMedia(content, path.Ext(part))
+		copied[part] = newPart
+		return newPart, f.copyContentTypeFrom(src, part, newPart)
+	}
+	newPart := f.getNextPartName(part)
+	copied[part] = newPart
+	f.Pkg.Store(newPart, content)
+	if err := f.copyContentTypeFrom(src, part, newPart); err != nil {
+		return newPart, err
+	}
+	rels, err := src.relsReader(path.Join(path.Dir(part), "_rels", path.Base(part)+".rels"))
+	if err != nil || rels == nil {
+		return newPart, err
+	}
+	rels.mu.Lock()
+	relationships := append([]xlsxRelationship{}, rels.Relationships...)
+	rels.mu.Unlock()
+	newRels := &xlsxRelationships{}
+	for _, rel := range relationships {
+		if rel.TargetMode != "External" {
+			target := path.Join(path.Dir(part), rel.Target)
+			if strings.HasPrefix(rel.Target, "/") {
+				target = strings.TrimPrefix(rel.Target, "/")
+			}
+			newTarget, err := f.copyPartFrom(src, target, copied)
+			if err != nil {
+				return newPart, err
+			}
+			rel.Target = path.Join(path.Dir(rel.Target), path.Base(newTarget))
+		}
+		newRels.Relationships = append(newRels.Relationships, rel)
+	}
+	f.Relationships.Store(path.Join(path.Dir(newPart), "_rels", path.Base(newPart)+".rels"), newRels)
+	return newPart, err
+}
+
+// getNextPartName provides a function to get the part path with the next
+// available file name index in the workbook by given part path, such as
+// xl/charts/chart1.xml.
+func (f *File) getNextPartName(part string) string {
+	dir, ext := path.Dir(part), path.Ext(part)
+	name := strings.TrimRight(strings.TrimSuffix(path.Base(part), ext), "0123456789")
+	for idx := 1; ; idx++ {
+		newPart := path.Join(dir, name+strconv.Itoa(idx)+ext)
+		_, inPkg := f.Pkg.Load(newPart)
+		_, inDrawings := f.Drawings.Load(newPart)
+		_, inTemp := f.tempFiles.Load(newPart)
+		_, inZip := f.zipFiles.Load(newPart)
+		if !inPkg && !inDrawings && !inTemp && !inZip {
+			return newPart
+		}
+	}
+}
+
+// copyContentTypeFrom provides a function to set the content type of the new
+// part in the workbook by given source workbook, source part path and the new
+// part path.
+func (f *File) copyContentTypeFrom(src *File, part, newPart string) error {
+	srcContent, err := src.contentTypesReader()
+	if err != nil {
+		return err
+	}
+	var override *xlsxOverride
+	var defaults *xlsxDefault
+	extension := strings.TrimPrefix(path.Ext(part), ".")
+	srcContent.mu.Lock()
+	for _, o := range srcContent.Overrides {
+		if o.PartName == "/"+part {
+			override = &xlsxOverride{PartName: "/" + newPart, ContentType: o.ContentType}
+			break
+		}
+	}
+	for _, d := range srcContent.Defaults {
+		if strings.EqualFold(d.Extension, extension) {
+			defaults = &xlsxDefault{Extension: d.Extension, ContentType: d.ContentType}
+			break
+		}
+	}
+	srcContent.mu.Unlock()
+	content, err := f.contentTypesReader()
+	if err != nil {
+		return err
+	}
+	content.mu.Lock()
+	defer content.mu.Unlock()
+	if override != nil {
+		for _, o := range content.Overrides {
+			if o.PartName == override.PartName {
+				return err
+			}
+		}
+		content.Overrides = append(content.Overrides, *override)
+		return err
+	}
+	if defaults != nil {
+		for _, d := range content.Defaults {
+			if strings.EqualFold(d.Extension, extension) {
+				return err
+			}
+		}
+		content.Defaults = append(content.Defaults, *defaults)
+	}
+	return err
+}
+
+// copySheetComments provides a function to copy the comments and threaded
+// comments of the source worksheet to the given worksheet.
+func (f *File) copySheetComments(src *File, srcSheet, sheet string) error {
+	comments, err := src.GetComments(srcSheet)
+	if err != nil {
+		return err
+	}
+	for _, comment := range comments {
+		if strings.HasPrefix(comment.Author, "tc=") {
+			continue
+		}
+		if err = f.AddComment(sheet, comment); err != nil {
+			return err
+		}
+	}
+	threadedComments, err := src.GetThreadedComments(srcSheet)
+	if err != nil {
+		return err
+	}
+	for _, comment := range threadedComments {
+		if err = f.AddThreadedComment(sheet, comment); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
 // getSheetState returns sheet visible enumeration by given hidden status.
 func getSheetState(visible bool, veryHidden []bool) string {
 	state := "hidden"
@@ -806,6 +1328,12 @@ func getSheetState(visible bool, veryHidden []bool) string {
 // For example, hide Sheet1:
 //
 //	err := f.SetSheetVisible("Sheet1", false)
+//
+// Set the Sheet2 as very hidden, which can't be unhidden by the user in the
+// spreadsheet application, and get the state by the GetSheetVisibleState
+// function:
+//
+//	err := f.SetSheetVisible("Sheet2", false, true)
 func (f *File) SetSheetVisible(sheet string, visible bool, veryHidden ...bool) error {
 	if err := checkSheetName(sheet); err != nil {
 		return err
@@ -849,11 +1377,64 @@ func (f *File) SetSheetVisible(sheet string, visible bool, veryHidden ...bool) e
 	return err
 }
 
+// paneTypes defined the list of valid pane enumeration values.
+var paneTypes = []string{"bottomLeft", "bottomRight", "topLeft", "topRight"}
+
+// checkPanes provides a function to validate the panes options and fill the
+// default top left visible cell and active pane of the frozen panes.
+func (panes *Panes) checkPanes() error {
+	if panes.XSplit < 0 || panes.YSplit < 0 {
+		return ErrPanesSplit
+	}
+	if panes.Freeze && (panes.XSplit >= MaxColumns || panes.YSplit >= TotalRows) {
+		return ErrPanesSplit
+	}
+	if panes.ActivePane != "" && inStrSlice(paneTypes, panes.ActivePane, true) == -1 {
+		return newInvalidPanesValueError("ActivePane", panes.ActivePane)
+	}
+	if panes.TopLeftCell != "" {
+		if _, _, err := CellNameToCoordinates(panes.TopLeftCell); err != nil {
+			return err
+		}
+	}
+	for _, s := range panes.Selection {
+		if s.Pane != "" && inStrSlice(paneTypes, s.Pane, true) == -1 {
+			return newInvalidPanesValueError("Pane", s.Pane)
+		}
+		if s.ActiveCell != "" {
+			if _, _, err := CellNameToCoordinates(s.ActiveCell); err != nil {
+				return err
+			}
+		}
+	}
+	if !panes.Freeze {
+		return nil
+	}
+	if panes.TopLeftCell == "" {
+		panes.TopLeftCell, _ = CoordinatesToCellName(panes.XSplit+1, panes.YSplit+1)
+	}
+	if panes.ActivePane == "" {
+		panes.ActivePane = "bottomRight"
+		if panes.YSplit == 0 {
+			panes.ActivePane = "topRight"
+		}
+		if panes.XSplit == 0 {
+			panes.ActivePane = "bottomLeft"
+		}
+	}
+	return nil
+}
+
 // setPanes set create freeze panes and split panes by given options.
 func (ws *xlsxWorksheet) setPanes(panes *Panes) error {
 	if panes == nil {
 		return ErrParameterInvalid
 	}
+	opts := *panes
+	if err := opts.checkPanes(); err != nil {
+		return err
+	}
+	panes = &opts
 	p := &xlsxPane{
 		ActivePane:  panes.ActivePane,
 		TopLeftCell: panes.TopLeftCell,
@@ -935,6 +1516,12 @@ func (ws *xlsxWorksheet) setPanes(panes *Panes) error {
 //	 split (Split)                   | Panes are split, but not frozen. In this state, the split
 //	                                 | bars are adjustable by the user.
 //
+// The ActivePane and the Pane of each selection must be one of the enumeration
+// values above. When the panes are frozen, the XSplit and YSplit must be less
+// than the maximum number of columns and rows of the worksheet, the
+// TopLeftCell defaults to the first cell after the frozen rows and columns,
+// and the ActivePane defaults to the pane next to the frozen area.
+//
 // XSplit (Horizontal Split Position): Horizontal position of the split, in
 // 1/20th of a point; 0 (zero) if none. If the pane is frozen, this value
 // indicates the number of columns visible in the top pane.
@@ -1033,8 +1620,13 @@ func (ws *xlsxWorksheet) getPanes() Panes {
 		return panes
 	}
 	panes.ActivePane = sw.Pane.ActivePane
-	if sw.Pane.State == "frozen" {
+	switch sw.Pane.State {
+	case "frozen":
 		panes.Freeze = true
+	case "frozenSplit":
+		panes.Freeze, panes.Split = true, true
+	default:
+		panes.Split = true
 	}
 	panes.TopLeftCell = sw.Pane.TopLeftCell
 	panes.XSplit = int(sw.Pane.XSplit)
@@ -1053,6 +1645,43 @@ func (f *File) GetPanes(sheet string) (Panes, error) {
 	return ws.getPanes(), err
 }
 
+// FreezePanes provides a function to freeze the rows above and the columns to
+// the left of the given cell by given worksheet name and cell reference, and
+// set the cell as the active cell of the worksheet. Freeze panes on "A1" will
+// remove all panes of the worksheet. For example, freeze the first two rows
+// and the first column in the Sheet1:
+//
+//	err := f.FreezePanes("Sheet1", "B3")
+func (f *File) FreezePanes(sheet, cell string) error {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	panes := Panes{Freeze: true, XSplit: col - 1, YSplit: row - 1}
+	if col == 1 && row == 1 {
+		panes.Freeze = false
+	}
+	if err = panes.checkPanes(); err != nil {
+		return err
+	}
+	if panes.Freeze {
+		panes.Selection = []Selection{{SQRef: cell, ActiveCell: cell, Pane: panes.ActivePane}}
+	}
+	return f.SetPanes(sheet, &panes)
+}
+
+// FreezeTopRow provides a function to freeze the first row of the worksheet by
+// given worksheet name.
+func (f *File) FreezeTopRow(sheet string) error {
+	return f.FreezePanes(sheet, "A2")
+}
+
+// FreezeFirstColumn provides a function to freeze the first column of the
+// worksheet by given worksheet name.
+func (f *File) FreezeFirstColumn(sheet string) error {
+	return f.FreezePanes(sheet, "B1")
+}
+
 // GetSheetVisible provides a function to get worksheet visible by given worksheet
 // name. For example, get visible state of Sheet1:
 //
@@ -1073,13 +1702,39 @@ func (f *File) GetSheetVisible(sheet string) (bool, error) {
 	return visible, nil
 }
 
-// SearchSheet provides a function to get cell reference by given worksheet name,
-// cell value, and regular expression. The function doesn't support searching
-// on the calculated result, formatted numbers and conditional lookup
-// currently. If it is a merged cell, it will return the cell reference of the
-// upper left cell of the merged range reference.
+// GetSheetVisibleState provides a function to get worksheet visible state by
+// given worksheet name. The state will be one of "visible", "hidden" and
+// "veryHidden", the very hidden worksheet can't be unhidden by the user in the
+// spreadsheet application, only through the VBA or the SetSheetVisible
+// function. For example, get visible state of Sheet1:
 //
-// An example of search the cell reference of the value of "100" on Sheet1:
+//	state, err := f.GetSheetVisibleState("Sheet1")
+func (f *File) GetSheetVisibleState(sheet string) (string, error) {
+	if err := checkSheetName(sheet); err != nil {
+		return "", err
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return "", err
+	}
+	for _, v := range wb.Sheets.Sheet {
+		if strings.EqualFold(v.Name, sheet) {
+			if v.State == "" {
+				return "visible", err
+			}
+			return v.State, err
+		}
+	}
+	return "", ErrSheetNotExist{sheet}
+}
+
+// SearchSheet provides a function to get cell reference by given worksheet name,
+// cell value, and regular expression. The function doesn't support searching
+// on the calculated result, formatted numbers and conditional lookup
+// currently. If it is a merged cell, it will return the cell reference of the
+// upper left cell of the merged range reference.
+//
+// An example of search the cell reference of the value of "100" on Sheet1:
 //
 //	result, err := f.SearchSheet("Sheet1", "100")
 //
@@ -1087,6 +1742,9 @@ func (f *File) GetSheetVisible(sheet string) (bool, error) {
 // of "0-9" of Sheet1 is described:
 //
 //	result, err := f.SearchSheet("Sheet1", "[0-9]", true)
+//
+// Use the FindSheet function to find the cells with the case-insensitive,
+// partial cell content or formulas matching.
 func (f *File) SearchSheet(sheet, value string, reg ...bool) ([]string, error) {
 	var (
 		regSearch bool
@@ -1122,8 +1780,17 @@ func (f *File) searchSheet(name, value string, regSearch bool) (result []string,
 	if sst, err = f.sharedStringsReader(); err != nil {
 		return
 	}
-	regex := regexp.MustCompile(value)
-	decoder := f.xmlNewDecoder(bytes.NewReader(f.readBytes(name)))
+	var regex *regexp.Regexp
+	if regSearch {
+		if regex, err = regexp.Compile(value); err != nil {
+			return
+		}
+	}
+	var content []byte
+	if content, err = f.readPart(name, true); err != nil {
+		return
+	}
+	decoder := f.xmlNewDecoder(bytes.NewReader(content))
 	for {
 		var token xml.Token
 		token, err = decoder.Token()
@@ -1171,6 +1838,199 @@ func (f *File) searchSheet(name, value string, regSearch bool) (result []string,
 	return
 }
 
+// findCellMatch defined the cell matched by find and replace.
+type findCellMatch struct {
+	cell, value      string
+	formula, numeric bool
+}
+
+// compile provides a function to compile the regular expression by given
+// value to find and the find options.
+func (opts *FindOptions) compile(value string) (*regexp.Regexp, error) {
+	if value == "" {
+		return nil, ErrParameterRequired
+	}
+	if opts.LookIn != "" && inStrSlice(supportedFindLookIn, opts.LookIn, true) == -1 {
+		return nil, newInvalidOptionalValue("LookIn", opts.LookIn, supportedFindLookIn)
+	}
+	expr := value
+	if !opts.RegExp {
+		expr = regexp.QuoteMeta(value)
+	}
+	if opts.MatchEntireCell {
+		expr = "^(?:" + expr + ")$"
+	}
+	if !opts.MatchCase {
+		expr = "(?i)" + expr
+	}
+	return regexp.Compile(expr)
+}
+
+// findCells provides a function to find the cells matched by given worksheet
+// name, regular expression and find options. The formula of the cell will be
+// matched instead of the value if formula is true. When raw is true, the raw
+// value of the constant cells will be matched, and the cells that value
+// can't be replaced will be skipped.
+func (f *File) findCells(sheet string, regex *regexp.Regexp, formula, raw bool) ([]findCellMatch, error) {
+	var matches []findCellMatch
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return matches, err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return matches, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for r := range ws.SheetData.Row {
+		for i := range ws.SheetData.Row[r].C {
+			c := &ws.SheetData.Row[r].C[i]
+			if formula && c.F != nil {
+				content := c.F.Content
+				if c.F.T == STCellFormulaTypeShared && c.F.Si != nil {
+					content = getSharedFormula(ws, *c.F.Si, c.R)
+				}
+				if regex.MatchString(content) {
+					matches = append(matches, findCellMatch{cell: c.R, value: content, formula: true})
+				}
+				continue
+			}
+			if raw && (c.F != nil || inStrSlice([]string{"", "n", "s", "inlineStr"}, c.T, true) == -1) {
+				continue
+			}
+			val, err := c.getValueFrom(f, sst, raw)
+			if err != nil {
+				return matches, err
+			}
+			if regex.MatchString(val) {
+				matches = append(matches, findCellMatch{cell: c.R, value: val, numeric: c.T == "" || c.T == "n"})
+			}
+		}
+	}
+	return matches, err
+}
+
+// FindSheet provides a function to find the cells by given worksheet name, the
+// value to find and the find options, and returns the cell references of the
+// matched cells. The cells will be matched if the cell contains the value in
+// case-insensitive by default. Set the MatchCase option to perform a case
+// sensitive search, set the MatchEntireCell option to only match the cells
+// which entire content equals the value, and set the RegExp option to find
+// by regular expression. The optional value of the LookIn option is "values"
+// and "formulas", which specifies to find in the formatted cell values or in
+// the formulas of the cells, the value of the cells which doesn't contain the
+// formula will be used when it is "formulas". For example, find the cells
+// which contains "excelize" on Sheet1:
+//
+//	result, err := f.FindSheet("Sheet1", "excelize", nil)
+//
+// Find the cells which formula contains SUM function on Sheet1:
+//
+//	result, err := f.FindSheet("Sheet1", "SUM(", &excelize.FindOptions{
+//	    LookIn: "formulas",
+//	})
+func (f *File) FindSheet(sheet, value string, opts *FindOptions) ([]string, error) {
+	var result []string
+	if opts == nil {
+		opts = &FindOptions{}
+	}
+	regex, err := opts.compile(value)
+	if err != nil {
+		return result, err
+	}
+	matches, err := f.findCells(sheet, regex, opts.LookIn == "formulas", false)
+	for _, match := range matches {
+		result = append(result, match.cell)
+	}
+	return result, err
+}
+
+// ReplaceSheet provides a function to replace the matched text in the cells by
+// given worksheet name, the value to find, the replacement and the find
+// options, and returns the count of the changed cells. The text in the
+// formulas will be replaced for the cells which contains formula, and the
+// text in the raw values will be replaced for the string and numeric cells,
+// the LookIn option will be ignored. The replacement could be contains
+// submatches like $1 when the RegExp option is enabled. The numeric cells
+// will be changed to the text cells if the replaced value isn't numeric, and
+// the text cells will be kept as text even if the replaced value is numeric,
+// for example, the text "1" will be stored after replacing "ID-00" with empty
+// in the text "ID-001". Set the ConvertNumbers option to store them as
+// numeric cells. For example, replace "2024" with "2025" on Sheet1:
+//
+//	count, err := f.ReplaceSheet("Sheet1", "2024", "2025", nil)
+func (f *File) ReplaceSheet(sheet, value, replacement string, opts *FindOptions) (int, error) {
+	if opts == nil {
+		opts = &FindOptions{}
+	}
+	regex, err := opts.compile(value)
+	if err != nil {
+		return 0, err
+	}
+	return f.replaceSheet(sheet, regex, replacement, opts)
+}
+
+// ReplaceAll provides a function to replace the matched text in the cells of
+// all worksheets in the workbook by given value to find, the replacement and
+// the find options, and returns the count of the changed cells. Please
+// reference the ReplaceSheet function for the details of the replacement.
+// For example, replace "Q1" with "Q2" in the whole workbook:
+//
+//	count, err := f.ReplaceAll("Q1", "Q2", nil)
+func (f *File) ReplaceAll(value, replacement string, opts *FindOptions) (int, error) {
+	var count int
+	if opts == nil {
+		opts = &FindOptions{}
+	}
+	regex, err := opts.compile(value)
+	if err != nil {
+		return count, err
+	}
+	for _, sheet := range f.GetSheetList() {
+		n, err := f.replaceSheet(sheet, regex, replacement, opts)
+		if count += n; err != nil {
+			if err.Error() == newNotWorksheetError(sheet).Error() {
+				continue
+			}
+			return count, err
+		}
+	}
+	return count, err
+}
+
+// replaceSheet provides a function to replace the matched text in the cells by
+// given worksheet name, regular expression, the replacement and the find
+// options.
+func (f *File) replaceSheet(sheet string, regex *regexp.Regexp, replacement string, opts *FindOptions) (int, error) {
+	var count int
+	matches, err := f.findCells(sheet, regex, true, true)
+	if err != nil {
+		return count, err
+	}
+	for _, match := range matches {
+		value := regex.ReplaceAllLiteralString(match.value, replacement)
+		if opts.RegExp {
+			value = regex.ReplaceAllString(match.value, replacement)
+		}
+		if value == match.value {
+			continue
+		}
+		if match.formula {
+			err = f.SetCellFormula(sheet, match.cell, value)
+		} else if isNum, _, num := isNumeric(value); isNum && (match.numeric || opts.ConvertNumbers) {
+			err = f.SetCellFloat(sheet, match.cell, num, -1, 64)
+		} else {
+			err = f.SetCellStr(sheet, match.cell, value)
+		}
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, err
+}
+
 // attrValToInt provides a function to convert the local names to an integer
 // by given XML attributes and specified names.
 func attrValToInt(name string, attrs []xml.Attr) (val int, err error) {
@@ -1232,7 +2092,9 @@ func attrValToBool(name string, attrs []xml.Attr) (val bool, err error) {
 //	 FirstHeader      | First Page Header
 //
 // The following formatting codes can be used in 6 string type fields:
-// OddHeader, OddFooter, EvenHeader, EvenFooter, FirstFooter, FirstHeader
+// OddHeader, OddFooter, EvenHeader, EvenFooter, FirstFooter, FirstHeader, and
+// the FormatHeaderFooter function can be used to build these fields without
+// writing the formatting codes
 //
 //	 Formatting Code        | Description
 //	------------------------+-------------------------------------------------------------------------
@@ -1341,7 +2203,7 @@ func (f *File) SetHeaderFooter(sheet string, opts *HeaderFooterOptions) error {
 	v := reflect.ValueOf(*opts)
 	// Check 6 string type fields: OddHeader, OddFooter, EvenHeader, EvenFooter,
 	// FirstFooter, FirstHeader
-	for i := 4; i < v.NumField()-1; i++ {
+	for i := 4; i < v.NumField(); i++ {
 		if len(utf16.Encode([]rune(v.Field(i).String()))) > MaxFieldLength {
 			return newFieldLengthError(v.Type().Field(i).Name)
 		}
@@ -1387,12 +2249,129 @@ func (f *File) GetHeaderFooter(sheet string) (*HeaderFooterOptions, error) {
 	return opts, err
 }
 
+// FormatHeaderFooter provides a function to build the header or footer with
+// formatting codes by given left, center and right sections, which can be
+// used in the OddHeader, OddFooter, EvenHeader, EvenFooter, FirstHeader and
+// FirstFooter fields of the HeaderFooterOptions. Each section contains a
+// list of fields, the field type should be one of the following values:
+//
+//	 Type                        | Description
+//	-----------------------------+---------------------------------------------
+//	 HeaderFooterFieldText       | The text specified by Text field
+//	 HeaderFooterFieldPageNumber | Current page number
+//	 HeaderFooterFieldTotalPages | Total number of pages
+//	 HeaderFooterFieldDate       | Current date
+//	 HeaderFooterFieldTime       | Current time
+//	 HeaderFooterFieldFileName   | Current workbook's file name
+//	 HeaderFooterFieldFilePath   | Current workbook's file path
+//	 HeaderFooterFieldSheetName  | Current worksheet name
+//	 HeaderFooterFieldPicture    | Picture set by AddHeaderFooterImage
+//
+// The Bold, Italic, Underline, Family, Size, Strike, Color and VertAlign of
+// the Font can be used to format the field. The underline, strike and
+// vertical alignment apply to the field only, the font name, style, size and
+// color apply to the following fields in the same section unless they
+// specify their own font. The "&" in the text will be escaped. It returns an
+// error if the header or footer exceeds 255 characters. For example, set the
+// odd page footer with the bold file name on the left section and the page
+// number in the format "Page 1 of 10" on the right section:
+//
+//	footer, err := excelize.FormatHeaderFooter(excelize.HeaderFooterSections{
+//	    Left: []excelize.HeaderFooterField{
+//	        {Type: excelize.HeaderFooterFieldFileName, Font: &excelize.Font{Bold: true}},
+//	    },
+//	    Right: []excelize.HeaderFooterField{
+//	        {Text: "Page "},
+//	        {Type: excelize.HeaderFooterFieldPageNumber},
+//	        {Text: " of "},
+//	        {Type: excelize.HeaderFooterFieldTotalPages},
+//	    },
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.SetHeaderFooter("Sheet1", &excelize.HeaderFooterOptions{OddFooter: footer})
+func FormatHeaderFooter(sections HeaderFooterSections) (string, error) {
+	var val string
+	for _, section := range []struct {
+		code   string
+		fields []HeaderFooterField
+	}{
+		{"&L", sections.Left}, {"&C", sections.Center}, {"&R", sections.Right},
+	} {
+		if len(section.fields) == 0 {
+			continue
+		}
+		val += section.code
+		for _, field := range section.fields {
+			code, ok := map[HeaderFooterFieldType]string{
+				HeaderFooterFieldText:       strings.ReplaceAll(field.Text, "&", "&&"),
+				HeaderFooterFieldPageNumber: "&P",
+				HeaderFooterFieldTotalPages: "&N",
+				HeaderFooterFieldDate:       "&D",
+				HeaderFooterFieldTime:       "&T",
+				HeaderFooterFieldFileName:   "&F",
+				HeaderFooterFieldFilePath:   "&Z",
+				HeaderFooterFieldSheetName:  "&A",
+				HeaderFooterFieldPicture:    "&G",
+			}[field.Type]
+			if !ok {
+				return "", ErrParameterInvalid
+			}
+			prefix, suffix := getHeaderFooterFontCodes(field.Font)
+			val += prefix + code + suffix
+		}
+	}
+	if len(utf16.Encode([]rune(val))) > MaxFieldLength {
+		return "", ErrHeaderFooterLength
+	}
+	return val, nil
+}
+
+// getHeaderFooterFontCodes returns the formatting codes before and after the
+// header or footer field by given font settings.
+func getHeaderFooterFontCodes(font *Font) (string, string) {
+	if font == nil {
+		return "", ""
+	}
+	var prefix, toggles string
+	if font.Size > 0 {
+		prefix += "&" + strconv.FormatFloat(font.Size, 'f', -1, 64)
+	}
+	if font.Family != "" || font.Bold || font.Italic || font.Size > 0 {
+		family, style := font.Family, "Regular"
+		if family == "" {
+			family = "-"
+		}
+		switch {
+		case font.Bold && font.Italic:
+			style = "Bold Italic"
+		case font.Bold:
+			style = "Bold"
+		case font.Italic:
+			style = "Italic"
+		}
+		prefix += "&\"" + family + "," + style + "\""
+	}
+	if font.Color != "" {
+		prefix += "&K" + strings.ToUpper(strings.TrimPrefix(font.Color, "#"))
+	}
+	toggles += map[string]string{"single": "&U", "double": "&E"}[font.Underline]
+	if font.Strike {
+		toggles += "&S"
+	}
+	toggles += map[string]string{"superscript": "&X", "subscript": "&Y"}[font.VertAlign]
+	return prefix + toggles, toggles
+}
+
 // ProtectSheet provides a function to prevent other users from accidentally or
 // deliberately changing, moving, or deleting data in a worksheet. The
 // optional field AlgorithmName specified hash algorithm, support XOR, MD4,
-// MD5, SHA-1, SHA2-56, SHA-384, and SHA-512 currently, if no hash algorithm
-// specified, will be using the XOR algorithm as default. For example, protect
-// Sheet1 with protection settings:
+// MD5, SHA-1, SHA-256, SHA-384, and SHA-512 currently, if no hash algorithm
+// specified, will be using the XOR algorithm as default. The SHA-512 algorithm
+// with salt and spin count is recommended, which is used by Excel 2013 and
+// later. For example, protect Sheet1 with protection settings:
 //
 //	err := f.ProtectSheet("Sheet1", &excelize.SheetProtectionOptions{
 //	    AlgorithmName:       "SHA-512",
@@ -1401,6 +2380,39 @@ func (f *File) GetHeaderFooter(sheet string) (*HeaderFooterOptions, error) {
 //	    SelectUnlockedCells: true,
 //	    EditScenarios:       true,
 //	})
+//
+// All cells are locked by default once the worksheet is protected. To leave
+// some input cells editable, apply a style with the Locked protection flag
+// disabled to these cells, and apply a style with the Hidden protection flag
+// to hide the formulas of the cells in the formula bar. For example, allow
+// editing the cells A1:B2 and hide the formula of cell C1 on Sheet1:
+//
+//	unlocked, err := f.NewStyle(&excelize.Style{
+//	    Protection: &excelize.Protection{Locked: false},
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.SetCellStyle("Sheet1", "A1", "B2", unlocked); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	hidden, err := f.NewStyle(&excelize.Style{
+//	    Protection: &excelize.Protection{Hidden: true, Locked: true},
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.SetCellStyle("Sheet1", "C1", "C1", hidden); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.ProtectSheet("Sheet1", &excelize.SheetProtectionOptions{
+//	    Password:            "password",
+//	    SelectUnlockedCells: true,
+//	})
 func (f *File) ProtectSheet(sheet string, opts *SheetProtectionOptions) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -1428,7 +2440,7 @@ func (f *File) ProtectSheet(sheet string, opts *SheetProtectionOptions) error {
 		Sort:                !opts.Sort,
 	}
 	if opts.Password != "" {
-		if opts.AlgorithmName == "" {
+		if opts.AlgorithmName == "" || opts.AlgorithmName == "XOR" {
 			ws.SheetProtection.Password = genSheetPasswd(opts.Password)
 			return err
 		}
@@ -1724,9 +2736,265 @@ func (f *File) GetPageLayout(sheet string) (PageLayoutOptions, error) {
 	return opts, err
 }
 
+// SetPageSetup provides a function to set the printing page setup of the
+// worksheet by given worksheet name and page setup options, which covers the
+// page layout, page margins, header and footer, print area and print titles.
+// The fit to page print option will be enabled if the FitToHeight or
+// FitToWidth of the page layout settings specified without the FitToPage.
+// The empty print area and print titles will keep the existing settings
+// unchanged. For example, set an A4 landscape page which fit all columns on
+// one page, print the cell range A1:F30, repeat the first row at the top of
+// each printed page and show the page number in the footer of the Sheet1:
+//
+//	size, orientation, fitToWidth, fitToHeight, margin := 9, "landscape", 1, 0, 0.5
+//	err := f.SetPageSetup("Sheet1", &excelize.PageSetupOptions{
+//	    Layout: &excelize.PageLayoutOptions{
+//	        Size:        &size,
+//	        Orientation: &orientation,
+//	        FitToWidth:  &fitToWidth,
+//	        FitToHeight: &fitToHeight,
+//	    },
+//	    Margins: &excelize.PageLayoutMarginsOptions{
+//	        Top:    &margin,
+//	        Bottom: &margin,
+//	    },
+//	    HeaderFooter: &excelize.HeaderFooterOptions{
+//	        OddFooter: "&CPage &P of &N",
+//	    },
+//	    PrintArea:      "A1:F30",
+//	    PrintTitleRows: "1:1",
+//	})
+func (f *File) SetPageSetup(sheet string, opts *PageSetupOptions) error {
+	if opts == nil {
+		return ErrParameterInvalid
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	var printArea []string
+	var titleCols, titleRows string
+	if opts.PrintArea != "" {
+		if printArea, err = printAreaToRefs(sheet, opts.PrintArea); err != nil {
+			return err
+		}
+	}
+	if opts.PrintTitleCols != "" {
+		if titleCols, err = printTitlesToRef(sheet, opts.PrintTitleCols, false); err != nil {
+			return err
+		}
+	}
+	if opts.PrintTitleRows != "" {
+		if titleRows, err = printTitlesToRef(sheet, opts.PrintTitleRows, true); err != nil {
+			return err
+		}
+	}
+	if opts.Layout != nil {
+		if err = ws.setPageSetUp(opts.Layout); err != nil {
+			return err
+		}
+	}
+	if opts.Margins != nil {
+		if err = f.SetPageMargins(sheet, opts.Margins); err != nil {
+			return err
+		}
+	}
+	if opts.HeaderFooter != nil {
+		if err = f.SetHeaderFooter(sheet, opts.HeaderFooter); err != nil {
+			return err
+		}
+	}
+	fitToPage := opts.FitToPage
+	if fitToPage == nil && opts.Layout != nil && (opts.Layout.FitToHeight != nil || opts.Layout.FitToWidth != nil) {
+		fitToPage = boolPtr(true)
+	}
+	if fitToPage != nil {
+		ws.setSheetProps(&SheetPropsOptions{FitToPage: fitToPage})
+	}
+	if len(printArea) > 0 {
+		if err = f.setPrintDefinedName(sheet, builtInDefinedNames[0], strings.Join(printArea, ",")); err != nil {
+			return err
+		}
+	}
+	if titleCols == "" && titleRows == "" {
+		return err
+	}
+	cols, rows, err := f.getPrintTitles(sheet)
+	if err != nil {
+		return err
+	}
+	if titleCols != "" {
+		cols = titleCols
+	}
+	if titleRows != "" {
+		rows = titleRows
+	}
+	var printTitles []string
+	for _, ref := range []string{cols, rows} {
+		if ref != "" {
+			printTitles = append(printTitles, ref)
+		}
+	}
+	return f.setPrintDefinedName(sheet, builtInDefinedNames[1], strings.Join(printTitles, ","))
+}
+
+// getPrintTitles provides a function to get the existing columns and rows
+// references to repeat on each printed page of the worksheet by given
+// worksheet name.
+func (f *File) getPrintTitles(sheet string) (cols, rows string, err error) {
+	wb, err := f.workbookReader()
+	if err != nil || wb.DefinedNames == nil {
+		return
+	}
+	sheetID, _ := f.GetSheetIndex(sheet)
+	for _, dn := range wb.DefinedNames.DefinedName {
+		if dn.Name != builtInDefinedNames[1] || dn.LocalSheetID == nil || *dn.LocalSheetID != sheetID {
+			continue
+		}
+		for _, ref := range splitRefs(dn.Data) {
+			if strings.IndexFunc(ref[strings.LastIndex(ref, "!")+1:], unicode.IsLetter) == -1 {
+				rows = ref
+				continue
+			}
+			cols = ref
+		}
+	}
+	return
+}
+
+// printAreaToRefs convert the print area cell ranges to the absolute
+// references with the worksheet name.
+func printAreaToRefs(sheet, printArea string) ([]string, error) {
+	var refs []string
+	for _, rng := range splitRefs(printArea) {
+		var cells []string
+		for _, cell := range strings.Split(strings.TrimSpace(rng), ":") {
+			col, row, err := CellNameToCoordinates(cell)
+			if err != nil {
+				return refs, err
+			}
+			ref, _ := CoordinatesToCellName(col, row, true)
+			cells = append(cells, ref)
+		}
+		if len(cells) > 2 {
+			return refs, ErrParameterInvalid
+		}
+		refs = append(refs, escapeSheetName(sheet)+"!"+strings.Join(cells, ":"))
+	}
+	return refs, nil
+}
+
+// printTitlesToRef convert the rows or columns to repeat on each printed page
+// to the absolute reference with the worksheet name.
+func printTitlesToRef(sheet, titles string, isRow bool) (string, error) {
+	parts := strings.Split(strings.ReplaceAll(titles, "$", ""), ":")
+	if len(parts) == 1 {
+		parts = append(parts, parts[0])
+	}
+	if len(parts) != 2 {
+		return "", ErrParameterInvalid
+	}
+	for i, part := range parts {
+		if isRow {
+			row, err := strconv.Atoi(part)
+			if err != nil || row < 1 || row > TotalRows {
+				return "", newInvalidRowNumberError(row)
+			}
+			parts[i] = "$" + part
+			continue
+		}
+		if _, err := ColumnNameToNumber(part); err != nil {
+			return "", err
+		}
+		parts[i] = "$" + strings.ToUpper(part)
+	}
+	return escapeSheetName(sheet) + "!" + strings.Join(parts, ":"), nil
+}
+
+// setPrintDefinedName create or update the built-in defined name of the
+// worksheet scope by given worksheet name, defined name and references.
+func (f *File) setPrintDefinedName(sheet, name, refersTo string) error {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	sheetID, err := f.GetSheetIndex(sheet)
+	if err != nil {
+		return err
+	}
+	if wb.DefinedNames == nil {
+		wb.DefinedNames = &xlsxDefinedNames{}
+	}
+	for idx, dn := range wb.DefinedNames.DefinedName {
+		if dn.Name == name && dn.LocalSheetID != nil && *dn.LocalSheetID == sheetID {
+			wb.DefinedNames.DefinedName[idx].Data = refersTo
+			return err
+		}
+	}
+	wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName, xlsxDefinedName{
+		Name: name, LocalSheetID: intPtr(sheetID), Data: refersTo,
+	})
+	return err
+}
+
+// GetPageSetup provides a function to get the printing page setup of the
+// worksheet by given worksheet name.
+func (f *File) GetPageSetup(sheet string) (PageSetupOptions, error) {
+	var opts PageSetupOptions
+	layout, err := f.GetPageLayout(sheet)
+	if err != nil {
+		return opts, err
+	}
+	opts.Layout = &layout
+	margins, _ := f.GetPageMargins(sheet)
+	opts.Margins = &margins
+	opts.HeaderFooter, _ = f.GetHeaderFooter(sheet)
+	props, _ := f.GetSheetProps(sheet)
+	opts.FitToPage = props.FitToPage
+	if opts.FitToPage == nil {
+		opts.FitToPage = boolPtr(false)
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return opts, err
+	}
+	sheetID, _ := f.GetSheetIndex(sheet)
+	if wb.DefinedNames == nil {
+		return opts, err
+	}
+	for _, dn := range wb.DefinedNames.DefinedName {
+		if dn.LocalSheetID == nil || *dn.LocalSheetID != sheetID {
+			continue
+		}
+		var refs []string
+		for _, ref := range splitRefs(dn.Data) {
+			if idx := strings.LastIndex(ref, "!"); idx != -1 {
+				ref = ref[idx+1:]
+			}
+			refs = append(refs, strings.ReplaceAll(ref, "$", ""))
+		}
+		switch dn.Name {
+		case builtInDefinedNames[0]:
+			opts.PrintArea = strings.Join(refs, ",")
+		case builtInDefinedNames[1]:
+			for _, ref := range refs {
+				if strings.IndexFunc(ref, unicode.IsLetter) == -1 {
+					opts.PrintTitleRows = ref
+					continue
+				}
+				opts.PrintTitleCols = ref
+			}
+		}
+	}
+	return opts, err
+}
+
 // SetDefinedName provides a function to set the defined names of the workbook
-// or worksheet. If not specified scope, the default scope is workbook.
-// For example:
+// or worksheet. If not specified scope or the scope is "Workbook", the default
+// scope is workbook, otherwise the scope should be an existing worksheet name.
+// The built-in defined names with a "_xlnm." prefix, such as
+// "_xlnm.Print_Area" and "_xlnm.Print_Titles", are supported, they should be
+// set with a worksheet scope. For example:
 //
 //	err := f.SetDefinedName(&excelize.DefinedName{
 //	    Name:     "Amount",
@@ -1756,7 +3024,7 @@ func (f *File) SetDefinedName(definedName *DefinedName) error {
 	if definedName.Name == "" || definedName.RefersTo == "" {
 		return ErrParameterInvalid
 	}
-	if err := checkDefinedName(definedName.Name); err != nil && inStrSlice(builtInDefinedNames[:2], definedName.Name, false) == -1 {
+	if err := checkDefinedName(definedName.Name); err != nil && inStrSlice(builtInDefinedNames, definedName.Name, false) == -1 {
 		return err
 	}
 	wb, err := f.workbookReader()
@@ -1766,20 +3034,25 @@ func (f *File) SetDefinedName(definedName *DefinedName) error {
 	d := xlsxDefinedName{
 		Name:    definedName.Name,
 		Comment: definedName.Comment,
+		Hidden:  definedName.Name == builtInDefinedNames[3],
 		Data:    definedName.RefersTo,
 	}
 	if definedName.Scope != "" {
-		if sheetIndex, _ := f.GetSheetIndex(definedName.Scope); sheetIndex >= 0 {
+		sheetIndex, err := f.GetSheetIndex(definedName.Scope)
+		if err != nil && definedName.Scope != definedNameScopeWorkbook {
+			return err
+		}
+		if sheetIndex == -1 && definedName.Scope != definedNameScopeWorkbook {
+			return ErrSheetNotExist{definedName.Scope}
+		}
+		if sheetIndex >= 0 {
 			d.LocalSheetID = &sheetIndex
 		}
 	}
 	if wb.DefinedNames != nil {
 		for _, dn := range wb.DefinedNames.DefinedName {
-			var scope string
-			if dn.LocalSheetID != nil {
-				scope = f.GetSheetName(*dn.LocalSheetID)
-			}
-			if scope == definedName.Scope && dn.Name == definedName.Name {
+			if strings.EqualFold(dn.Name, d.Name) && (dn.LocalSheetID == nil && d.LocalSheetID == nil ||
+				dn.LocalSheetID != nil && d.LocalSheetID != nil && *dn.LocalSheetID == *d.LocalSheetID) {
 				return ErrDefinedNameDuplicate
 			}
 		}
@@ -1807,10 +3080,10 @@ func (f *File) DeleteDefinedName(definedName *DefinedName) error {
 	}
 	if wb.DefinedNames != nil {
 		for idx, dn := range wb.DefinedNames.DefinedName {
-			scope := "Workbook"
+			scope := definedNameScopeWorkbook
 			deleteScope := definedName.Scope
 			if deleteScope == "" {
-				deleteScope = "Workbook"
+				deleteScope = definedNameScopeWorkbook
 			}
 			if dn.LocalSheetID != nil {
 				scope = f.GetSheetName(*dn.LocalSheetID)
@@ -1835,7 +3108,7 @@ func (f *File) GetDefinedName() []DefinedName {
 				Name:     dn.Name,
 				Comment:  dn.Comment,
 				RefersTo: dn.Data,
-				Scope:    "Workbook",
+				Scope:    definedNameScopeWorkbook,
 			}
 			if dn.LocalSheetID != nil && *dn.LocalSheetID >= 0 {
 				definedName.Scope = f.GetSheetName(*dn.LocalSheetID)
@@ -1900,7 +3173,12 @@ func (f *File) UngroupSheets() error {
 // InsertPageBreak create a page break to determine where the printed page
 // ends and where begins the next one by given worksheet name and cell
 // reference, so the content before the page break will be printed on one page
-// and after the page break on another.
+// and after the page break on another. A horizontal page break will be
+// inserted above the row of the cell, and a vertical page break will be
+// inserted at the left of the column of the cell. For example, insert a
+// horizontal page break above row 10 on Sheet1:
+//
+//	err := f.InsertPageBreak("Sheet1", "A10")
 func (f *File) InsertPageBreak(sheet, cell string) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -1912,111 +3190,122 @@ func (f *File) InsertPageBreak(sheet, cell string) error {
 // insertPageBreak create a page break in the worksheet by specific cell
 // reference.
 func (ws *xlsxWorksheet) insertPageBreak(cell string) error {
-	var (
-		row, col       int
-		err            error
-		rowBrk, colBrk = -1, -1
-	)
-	if col, row, err = CellNameToCoordinates(cell); err != nil {
-		return err
-	}
-	col--
-	row--
-	if col == row && col == 0 {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
 		return err
 	}
-	if ws.RowBreaks == nil {
-		ws.RowBreaks = &xlsxRowBreaks{}
+	if row--; row != 0 {
+		if ws.RowBreaks == nil {
+			ws.RowBreaks = &xlsxRowBreaks{}
+		}
+		ws.RowBreaks.xlsxBreaks = insertBrk(ws.RowBreaks.xlsxBreaks, row, MaxColumns-1)
 	}
-	if ws.ColBreaks == nil {
-		ws.ColBreaks = &xlsxColBreaks{}
+	if col--; col != 0 {
+		if ws.ColBreaks == nil {
+			ws.ColBreaks = &xlsxColBreaks{}
+		}
+		ws.ColBreaks.xlsxBreaks = insertBrk(ws.ColBreaks.xlsxBreaks, col, TotalRows-1)
 	}
+	return err
+}
 
-	for idx, brk := range ws.RowBreaks.Brk {
-		if brk.ID == row {
-			rowBrk = idx
+// insertBrk provides a function to insert a manual page break by given page
+// breaks, the ID and the maximum position of the page break.
+func insertBrk(brks xlsxBreaks, ID, maxVal int) xlsxBreaks {
+	for _, brk := range brks.Brk {
+		if brk.ID == ID {
+			return brks
 		}
 	}
-	for idx, brk := range ws.ColBreaks.Brk {
-		if brk.ID == col {
-			colBrk = idx
+	brks.Brk = append(brks.Brk, &xlsxBrk{ID: ID, Max: maxVal, Man: true})
+	return brks.count()
+}
+
+// removeBrk provides a function to remove the page break by given page
+// breaks and the ID of the page break.
+func removeBrk(brks xlsxBreaks, ID int) xlsxBreaks {
+	for i := 0; i < len(brks.Brk); i++ {
+		if brks.Brk[i].ID == ID {
+			brks.Brk = append(brks.Brk[:i], brks.Brk[i+1:]...)
+			i--
 		}
 	}
+	return brks.count()
+}
 
-	if row != 0 && rowBrk == -1 {
-		ws.RowBreaks.Brk = append(ws.RowBreaks.Brk, &xlsxBrk{
-			ID:  row,
-			Max: MaxColumns - 1,
-			Man: true,
-		})
-		ws.RowBreaks.ManualBreakCount++
-	}
-	if col != 0 && colBrk == -1 {
-		ws.ColBreaks.Brk = append(ws.ColBreaks.Brk, &xlsxBrk{
-			ID:  col,
-			Max: TotalRows - 1,
-			Man: true,
-		})
-		ws.ColBreaks.ManualBreakCount++
+// count provides a function to update the number of page breaks and the
+// number of manual page breaks.
+func (brks xlsxBreaks) count() xlsxBreaks {
+	brks.Count, brks.ManualBreakCount = len(brks.Brk), 0
+	for _, brk := range brks.Brk {
+		if brk.Man {
+			brks.ManualBreakCount++
+		}
 	}
-	ws.RowBreaks.Count = len(ws.RowBreaks.Brk)
-	ws.ColBreaks.Count = len(ws.ColBreaks.Brk)
-	return err
+	return brks
 }
 
 // RemovePageBreak remove a page break by given worksheet name and cell
-// reference.
+// reference. The horizontal page break above the row of the cell and the
+// vertical page break at the left of the column of the cell will be removed.
 func (f *File) RemovePageBreak(sheet, cell string) error {
-	var (
-		ws       *xlsxWorksheet
-		row, col int
-		err      error
-	)
-	if ws, err = f.workSheetReader(sheet); err != nil {
-		return err
-	}
-	if col, row, err = CellNameToCoordinates(cell); err != nil {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
 		return err
 	}
-	col--
-	row--
-	if col == row && col == 0 {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
 		return err
 	}
-	removeBrk := func(ID int, brks []*xlsxBrk) []*xlsxBrk {
-		for i, brk := range brks {
-			if brk.ID == ID {
-				brks = append(brks[:i], brks[i+1:]...)
-			}
+	if ws.RowBreaks != nil {
+		if ws.RowBreaks.xlsxBreaks = removeBrk(ws.RowBreaks.xlsxBreaks, row-1); ws.RowBreaks.Count == 0 {
+			ws.RowBreaks = nil
 		}
-		return brks
 	}
-	if ws.RowBreaks == nil || ws.ColBreaks == nil {
-		return err
+	if ws.ColBreaks != nil {
+		if ws.ColBreaks.xlsxBreaks = removeBrk(ws.ColBreaks.xlsxBreaks, col-1); ws.ColBreaks.Count == 0 {
+			ws.ColBreaks = nil
+		}
 	}
-	rowBrks := len(ws.RowBreaks.Brk)
-	colBrks := len(ws.ColBreaks.Brk)
-	if rowBrks > 0 && rowBrks == colBrks {
-		ws.RowBreaks.Brk = removeBrk(row, ws.RowBreaks.Brk)
-		ws.ColBreaks.Brk = removeBrk(col, ws.ColBreaks.Brk)
-		ws.RowBreaks.Count = len(ws.RowBreaks.Brk)
-		ws.ColBreaks.Count = len(ws.ColBreaks.Brk)
-		ws.RowBreaks.ManualBreakCount--
-		ws.ColBreaks.ManualBreakCount--
-		return err
+	return err
+}
+
+// GetPageBreaks provides a function to get the page breaks of the worksheet
+// by given worksheet name. The row numbers of the horizontal page breaks and
+// the column numbers of the vertical page breaks will be returned in
+// ascending order, the page break is located above the row or at the left of
+// the column. For example, get the page breaks on Sheet1:
+//
+//	pageBreaks, err := f.GetPageBreaks("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, row := range pageBreaks.Rows {
+//	    fmt.Println("horizontal page break above row", row)
+//	}
+//	for _, col := range pageBreaks.Cols {
+//	    fmt.Println("vertical page break at the left of column", col)
+//	}
+func (f *File) GetPageBreaks(sheet string) (PageBreaks, error) {
+	var pageBreaks PageBreaks
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return pageBreaks, err
 	}
-	if rowBrks > 0 && rowBrks > colBrks {
-		ws.RowBreaks.Brk = removeBrk(row, ws.RowBreaks.Brk)
-		ws.RowBreaks.Count = len(ws.RowBreaks.Brk)
-		ws.RowBreaks.ManualBreakCount--
-		return err
+	if ws.RowBreaks != nil {
+		for _, brk := range ws.RowBreaks.Brk {
+			pageBreaks.Rows = append(pageBreaks.Rows, brk.ID+1)
+		}
+		sort.Ints(pageBreaks.Rows)
 	}
-	if colBrks > 0 && colBrks > rowBrks {
-		ws.ColBreaks.Brk = removeBrk(col, ws.ColBreaks.Brk)
-		ws.ColBreaks.Count = len(ws.ColBreaks.Brk)
-		ws.ColBreaks.ManualBreakCount--
+	if ws.ColBreaks != nil {
+		for _, brk := range ws.ColBreaks.Brk {
+			pageBreaks.Cols = append(pageBreaks.Cols, brk.ID+1)
+		}
+		sort.Ints(pageBreaks.Cols)
 	}
-	return err
+	return pageBreaks, err
 }
 
 // relsReader provides a function to get the pointer to the structure
@@ -2102,7 +3391,7 @@ func (f *File) SetSheetDimension(sheet, rangeRef string) error {
 	if parts == 1 {
 		_, _, err = CellNameToCoordinates(rangeRef)
 		if err == nil {
-			ws.Dimension = &xlsxDimension{Ref: strings.ToUpper(rangeRef)}
+			ws.Dimension = &xlsxDimension{Ref: strings.ToUpper(rangeRef), custom: true}
 		}
 		return err
 	}
@@ -2115,11 +3404,15 @@ func (f *File) SetSheetDimension(sheet, rangeRef string) error {
 	}
 	_ = sortCoordinates(coordinates)
 	ref, err := coordinatesToRangeRef(coordinates)
-	ws.Dimension = &xlsxDimension{Ref: ref}
+	ws.Dimension = &xlsxDimension{Ref: ref, custom: true}
 	return err
 }
 
-// GetSheetDimension provides the method to get the used range of the worksheet.
+// GetSheetDimension provides the method to get the used range of the worksheet
+// which stored in the dimension element. The dimension will be recalculated by
+// the populated cells when saving the workbook, unless it was set by
+// SetSheetDimension. Use GetUsedRange to get the actual used range of the
+// worksheet.
 func (f *File) GetSheetDimension(sheet string) (string, error) {
 	var ref string
 	ws, err := f.workSheetReader(sheet)
@@ -2132,6 +3425,96 @@ func (f *File) GetSheetDimension(sheet string) (string, error) {
 	return ref, err
 }
 
+// GetUsedRange provides the method to get the actual used range of the
+// worksheet by given worksheet name, which is the bounding range of the cells
+// with value, formula or style. It is recalculated from the cells rather than
+// read from the stored dimension element. It returns an empty string if the
+// worksheet has no populated cells. For example, get the used range of
+// Sheet1:
+//
+//	ref, err := f.GetUsedRange("Sheet1")
+func (f *File) GetUsedRange(sheet string) (string, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return "", err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	return ws.usedRangeRef(), err
+}
+
+// usedRange returns the sorted range coordinates of the populated cells in the
+// worksheet, it returns nil if the worksheet has no populated cells.
+func (ws *xlsxWorksheet) usedRange() []int {
+	var rect []int
+	for _, row := range ws.SheetData.Row {
+		first, last := -1, -1
+		for i := range row.C {
+			if row.C[i].hasValue() {
+				if first == -1 {
+					first = i
+				}
+				last = i
+			}
+		}
+		if first == -1 {
+			continue
+		}
+		col1, row1, err := CellNameToCoordinates(row.C[first].R)
+		if err != nil {
+			continue
+		}
+		col2, _, err := CellNameToCoordinates(row.C[last].R)
+		if err != nil {
+			continue
+		}
+		if rect == nil {
+			rect = []int{col1, row1, col2, row1}
+			continue
+		}
+		if col1 < rect[0] {
+			rect[0] = col1
+		}
+		if row1 < rect[1] {
+			rect[1] = row1
+		}
+		if col2 > rect[2] {
+			rect[2] = col2
+		}
+		if row1 > rect[3] {
+			rect[3] = row1
+		}
+	}
+	return rect
+}
+
+// usedRangeRef returns the range reference of the populated cells in the
+// worksheet, a single cell reference will be returned if the used range only
+// contains one cell.
+func (ws *xlsxWorksheet) usedRangeRef() string {
+	rect := ws.usedRange()
+	if rect == nil {
+		return ""
+	}
+	ref, _ := CoordinatesToCellName(rect[0], rect[1])
+	if rect[0] != rect[2] || rect[1] != rect[3] {
+		ref, _ = coordinatesToRangeRef(rect)
+	}
+	return ref
+}
+
+// updateDimension provides a function to update the dimension element of the
+// worksheet by the populated cells, the dimension which was set by
+// SetSheetDimension or has been removed keeps unchanged.
+func (ws *xlsxWorksheet) updateDimension() {
+	if ws.Dimension == nil || ws.Dimension.custom {
+		return
+	}
+	if ws.Dimension.Ref = ws.usedRangeRef(); ws.Dimension.Ref == "" {
+		ws.Dimension.Ref = "A1"
+	}
+}
+
 // AddIgnoredErrors provides the method to ignored error for a range of cells.
 func (f *File) AddIgnoredErrors(sheet, rangeRef string, ignoredErrorsType IgnoredErrorsType) error {
 	ws, err := f.workSheetReader(sheet)
@@ -2141,20 +3524,13 @@ func (f *File) AddIgnoredErrors(sheet, rangeRef string, ignoredErrorsType Ignore
 	if rangeRef == "" {
 		return ErrParameterInvalid
 	}
+	ie := xlsxIgnoredError{Sqref: rangeRef}
+	if !ie.setType(ignoredErrorsType) {
+		return ErrParameterInvalid
+	}
 	if ws.IgnoredErrors == nil {
 		ws.IgnoredErrors = &xlsxIgnoredErrors{}
 	}
-	ie := map[IgnoredErrorsType]xlsxIgnoredError{
-		IgnoredErrorsEvalError:          {Sqref: rangeRef, EvalError: true},
-		IgnoredErrorsTwoDigitTextYear:   {Sqref: rangeRef, TwoDigitTextYear: true},
-		IgnoredErrorsNumberStoredAsText: {Sqref: rangeRef, NumberStoredAsText: true},
-		IgnoredErrorsFormula:            {Sqref: rangeRef, Formula: true},
-		IgnoredErrorsFormulaRange:       {Sqref: rangeRef, FormulaRange: true},
-		IgnoredErrorsUnlockedFormula:    {Sqref: rangeRef, UnlockedFormula: true},
-		IgnoredErrorsEmptyCellReference: {Sqref: rangeRef, EmptyCellReference: true},
-		IgnoredErrorsListDataValidation: {Sqref: rangeRef, ListDataValidation: true},
-		IgnoredErrorsCalculatedColumn:   {Sqref: rangeRef, CalculatedColumn: true},
-	}[ignoredErrorsType]
 	for _, val := range ws.IgnoredErrors.IgnoredError {
 		if reflect.DeepEqual(val, ie) {
 			return err
@@ -2163,3 +3539,84 @@ func (f *File) AddIgnoredErrors(sheet, rangeRef string, ignoredErrorsType Ignore
 	ws.IgnoredErrors.IgnoredError = append(ws.IgnoredErrors.IgnoredError, ie)
 	return err
 }
+
+// SetIgnoredErrors provides the method to set the ignored errors for a range
+// of cells by given worksheet name, range reference and the error types, which
+// suppresses the error indicators (green triangles) of the cells in the
+// spreadsheet application. The existing ignored errors of the same range
+// reference will be replaced, and passing empty error types will remove the
+// ignored errors of the range. The range reference can contain multiple
+// ranges separated by spaces. For example, ignore the "number stored as text"
+// and "formula omits adjacent cells" errors of the range A1:D10 on Sheet1:
+//
+//	err := f.SetIgnoredErrors("Sheet1", "A1:D10", []excelize.IgnoredErrorsType{
+//	    excelize.IgnoredErrorsNumberStoredAsText,
+//	    excelize.IgnoredErrorsFormulaRange,
+//	})
+func (f *File) SetIgnoredErrors(sheet, rangeRef string, types []IgnoredErrorsType) error {
+	if rangeRef == "" {
+		return ErrParameterInvalid
+	}
+	for _, ref := range strings.Fields(rangeRef) {
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		if _, err := rangeRefToCoordinates(ref); err != nil {
+			return err
+		}
+	}
+	ie := xlsxIgnoredError{Sqref: rangeRef}
+	for _, t := range types {
+		if !ie.setType(t) {
+			return ErrParameterInvalid
+		}
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.IgnoredErrors == nil {
+		ws.IgnoredErrors = &xlsxIgnoredErrors{}
+	}
+	ignoredErrors := ws.IgnoredErrors.IgnoredError[:0]
+	for _, val := range ws.IgnoredErrors.IgnoredError {
+		if val.Sqref != rangeRef {
+			ignoredErrors = append(ignoredErrors, val)
+		}
+	}
+	if len(types) > 0 {
+		ignoredErrors = append(ignoredErrors, ie)
+	}
+	if ws.IgnoredErrors.IgnoredError = ignoredErrors; len(ignoredErrors) == 0 && ws.IgnoredErrors.ExtLst == nil {
+		ws.IgnoredErrors = nil
+	}
+	return err
+}
+
+// setType provides a function to set the ignored error by given ignored
+// errors type, it returns false if the type is invalid.
+func (ie *xlsxIgnoredError) setType(ignoredErrorsType IgnoredErrorsType) bool {
+	switch ignoredErrorsType {
+	case IgnoredErrorsEvalError:
+		ie.EvalError = true
+	case IgnoredErrorsTwoDigitTextYear:
+		ie.TwoDigitTextYear = true
+	case IgnoredErrorsNumberStoredAsText:
+		ie.NumberStoredAsText = true
+	case IgnoredErrorsFormula:
+		ie.Formula = true
+	case IgnoredErrorsFormulaRange:
+		ie.FormulaRange = true
+	case IgnoredErrorsUnlockedFormula:
+		ie.UnlockedFormula = true
+	case IgnoredErrorsEmptyCellReference:
+		ie.EmptyCellReference = true
+	case IgnoredErrorsListDataValidation:
+		ie.ListDataValidation = true
+	case IgnoredErrorsCalculatedColumn:
+		ie.CalculatedColumn = true
+	default:
+		return false
+	}
+	return true
+}
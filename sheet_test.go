@@ -94,6 +94,39 @@ func TestPanes(t *testing.T) {
 			},
 		},
 	))
+	panes, err = f.GetPanes("Panes 3")
+	assert.NoError(t, err)
+	assert.True(t, panes.Split)
+	assert.False(t, panes.Freeze)
+	// Test set frozen panes with default top left cell and active pane
+	for _, c := range []struct {
+		xSplit, ySplit          int
+		topLeftCell, activePane string
+	}{
+		{2, 0, "C1", "topRight"},
+		{0, 3, "A4", "bottomLeft"},
+		{2, 3, "C4", "bottomRight"},
+	} {
+		assert.NoError(t, f.SetPanes("Panes 4", &Panes{Freeze: true, XSplit: c.xSplit, YSplit: c.ySplit}))
+		panes, err = f.GetPanes("Panes 4")
+		assert.NoError(t, err)
+		assert.Equal(t, Panes{Freeze: true, XSplit: c.xSplit, YSplit: c.ySplit, TopLeftCell: c.topLeftCell, ActivePane: c.activePane}, panes)
+	}
+	// Test set panes with invalid options
+	for _, c := range []struct {
+		panes *Panes
+		err   error
+	}{
+		{&Panes{Split: true, XSplit: -1}, ErrPanesSplit},
+		{&Panes{Freeze: true, YSplit: TotalRows}, ErrPanesSplit},
+		{&Panes{Freeze: true, XSplit: MaxColumns}, ErrPanesSplit},
+		{&Panes{Freeze: true, ActivePane: "left"}, newInvalidPanesValueError("ActivePane", "left")},
+		{&Panes{Freeze: true, TopLeftCell: "A"}, newCellNameToCoordinatesError("A", newInvalidCellNameError("A"))},
+		{&Panes{Freeze: true, Selection: []Selection{{Pane: "right"}}}, newInvalidPanesValueError("Pane", "right")},
+		{&Panes{Freeze: true, Selection: []Selection{{ActiveCell: "A"}}}, newCellNameToCoordinatesError("A", newInvalidCellNameError("A"))},
+	} {
+		assert.Equal(t, c.err, f.SetPanes("Panes 4", c.panes))
+	}
 	assert.EqualError(t, f.SetPanes("Panes 4", nil), ErrParameterInvalid.Error())
 	assert.EqualError(t, f.SetPanes("SheetN", nil), "sheet SheetN does not exist")
 	// Test set panes with invalid sheet name
@@ -139,6 +172,41 @@ func TestPanes(t *testing.T) {
 	))
 }
 
+func TestFreezePanes(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.FreezeTopRow("Sheet1"))
+	panes, err := f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{
+		Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft",
+		Selection: []Selection{{SQRef: "A2", ActiveCell: "A2", Pane: "bottomLeft"}},
+	}, panes)
+	assert.NoError(t, f.FreezeFirstColumn("Sheet1"))
+	panes, err = f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{
+		Freeze: true, XSplit: 1, TopLeftCell: "B1", ActivePane: "topRight",
+		Selection: []Selection{{SQRef: "B1", ActiveCell: "B1", Pane: "topRight"}},
+	}, panes)
+	assert.NoError(t, f.FreezePanes("Sheet1", "C3"))
+	panes, err = f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{
+		Freeze: true, XSplit: 2, YSplit: 2, TopLeftCell: "C3", ActivePane: "bottomRight",
+		Selection: []Selection{{SQRef: "C3", ActiveCell: "C3", Pane: "bottomRight"}},
+	}, panes)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestFreezePanes.xlsx")))
+	// Test remove panes by freeze panes on the first cell
+	assert.NoError(t, f.FreezePanes("Sheet1", "A1"))
+	panes, err = f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{}, panes)
+	// Test freeze panes with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.FreezePanes("Sheet1", "A"))
+	// Test freeze panes on not exists worksheet
+	assert.EqualError(t, f.FreezeTopRow("SheetN"), "sheet SheetN does not exist")
+}
+
 func TestSearchSheet(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "SharedStrings.xlsx"))
 	if !assert.NoError(t, err) {
@@ -194,6 +262,156 @@ func TestSearchSheet(t *testing.T) {
 	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
 	_, err = f.SearchSheet("Sheet1", "A")
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+
+	// Test search sheet with invalid regular expression
+	f = NewFile()
+	_, err = f.SearchSheet("Sheet1", "(", true)
+	assert.EqualError(t, err, "error parsing regexp: missing closing ): `(`")
+	result, err = f.SearchSheet("Sheet1", "(")
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestFindSheet(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Excelize", "excelize go", 100, true}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A2", "SUM(C1,10)"))
+	formulaType, ref := STCellFormulaTypeShared, "B2:C2"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B2", "A1&\"X\"", FormulaOpts{Ref: &ref, Type: &formulaType}))
+	for _, c := range []struct {
+		value    string
+		opts     *FindOptions
+		expected []string
+	}{
+		{"excelize", nil, []string{"A1", "B1"}},
+		{"excelize", &FindOptions{MatchCase: true}, []string{"B1"}},
+		{"excelize", &FindOptions{MatchEntireCell: true}, []string{"A1"}},
+		{"^[a-z]+ go$", &FindOptions{RegExp: true}, []string{"B1"}},
+		{"TRUE", &FindOptions{MatchEntireCell: true}, []string{"D1"}},
+		{"sum(", nil, nil},
+		{"sum(", &FindOptions{LookIn: "formulas"}, []string{"A2"}},
+		{"B1&", &FindOptions{LookIn: "formulas"}, []string{"C2"}},
+		{"100", &FindOptions{LookIn: "formulas"}, []string{"C1"}},
+	} {
+		result, err := f.FindSheet("Sheet1", c.value, c.opts)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result, c.value)
+	}
+	// Test find sheet with empty value
+	_, err := f.FindSheet("Sheet1", "", nil)
+	assert.Equal(t, ErrParameterRequired, err)
+	// Test find sheet with invalid look in option
+	_, err = f.FindSheet("Sheet1", "A", &FindOptions{LookIn: "comments"})
+	assert.Equal(t, newInvalidOptionalValue("LookIn", "comments", supportedFindLookIn), err)
+	// Test find sheet with invalid regular expression
+	_, err = f.FindSheet("Sheet1", "(", &FindOptions{RegExp: true})
+	assert.EqualError(t, err, "error parsing regexp: missing closing ): `(?i)(`")
+	// Test find sheet on not exists worksheet
+	_, err = f.FindSheet("SheetN", "A", nil)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test find sheet with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, err = f.FindSheet("Sheet1", "A", nil)
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestReplaceSheet(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Report 2024", "report-2024-Q1", 2024, true, 12.5}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A2", "SUM(C1,2024)"))
+	count, err := f.ReplaceSheet("Sheet1", "2024", "2025", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, count)
+	for cell, expected := range map[string]string{"A1": "Report 2025", "B1": "report-2025-Q1", "C1": "2025", "D1": "TRUE"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, xlsxC{R: "C1", V: "2025"}, ws.(*xlsxWorksheet).SheetData.Row[0].C[2])
+	formula, err := f.GetCellFormula("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(C1,2025)", formula)
+	// Test replace sheet with regular expression submatches
+	count, err = f.ReplaceSheet("Sheet1", `^(\w+)-(\d+)-(\w+)$`, "$3 $2", &FindOptions{RegExp: true, MatchCase: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	val, err := f.GetCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Q1 2025", val)
+	// Test replace sheet change the numeric cell to string cell
+	count, err = f.ReplaceSheet("Sheet1", ".5", " and half", &FindOptions{MatchCase: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	val, err = f.GetCellValue("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, "12 and half", val)
+	// Test replace sheet keep the text cell type with the numeric value
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"ID-001", "ID-002"}))
+	count, err = f.ReplaceSheet("Sheet1", "ID-00", "", &FindOptions{MatchCase: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	for _, cell := range []string{"A3", "B3"} {
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, CellTypeSharedString, cellType)
+	}
+	val, err = f.GetCellValue("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", val)
+	// Test replace sheet convert the text cells to numeric cells
+	count, err = f.ReplaceSheet("Sheet1", "2", "3", &FindOptions{MatchEntireCell: true, ConvertNumbers: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	cellType, err := f.GetCellType("Sheet1", "B3")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeNumber, cellType)
+	val, err = f.GetCellValue("Sheet1", "B3")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", val)
+	// Test replace sheet with empty value
+	_, err = f.ReplaceSheet("Sheet1", "", "A", nil)
+	assert.Equal(t, ErrParameterRequired, err)
+	// Test replace sheet on not exists worksheet
+	_, err = f.ReplaceSheet("SheetN", "A", "B", nil)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test replace sheet with invalid cell reference
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].R = "A"
+	_, err = f.ReplaceSheet("Sheet1", "Report", "Summary", nil)
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+}
+
+func TestReplaceAll(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$A$1", Values: "Sheet1!$B$1"}},
+	}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Q1"))
+	assert.NoError(t, f.SetCellValue("Sheet2", "A1", "q1 sales"))
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A2", "Sheet1!A1&\" Q1\""))
+	count, err := f.ReplaceAll("Q1", "Q2", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	result, err := f.FindSheet("Sheet2", "Q2", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A1"}, result)
+	formula, err := f.GetCellFormula("Sheet2", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet1!A1&\" Q2\"", formula)
+	// Test replace all with empty value
+	_, err = f.ReplaceAll("", "A", nil)
+	assert.Equal(t, ErrParameterRequired, err)
+	// Test replace all with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet2.xml")
+	f.Pkg.Store("xl/worksheets/sheet2.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	_, err = f.ReplaceAll("Q2", "Q3", nil)
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
 func TestSetPageLayout(t *testing.T) {
@@ -242,6 +460,105 @@ func TestGetPageLayout(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestPageSetup(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetPageSetup("Sheet 2", &PageSetupOptions{
+		Layout: &PageLayoutOptions{
+			Size:        intPtr(9),
+			Orientation: stringPtr("landscape"),
+			FitToWidth:  intPtr(1),
+			FitToHeight: intPtr(0),
+		},
+		Margins:        &PageLayoutMarginsOptions{Top: float64Ptr(0.5), Bottom: float64Ptr(0.5)},
+		HeaderFooter:   &HeaderFooterOptions{OddHeader: "&L&D&R&P of &N", OddFooter: "&C&F"},
+		PrintArea:      "A1:F30, H1",
+		PrintTitleRows: "1:2",
+		PrintTitleCols: "a",
+	}))
+	opts, err := f.GetPageSetup("Sheet 2")
+	assert.NoError(t, err)
+	assert.Equal(t, "landscape", *opts.Layout.Orientation)
+	assert.Equal(t, 9, *opts.Layout.Size)
+	assert.Equal(t, 1, *opts.Layout.FitToWidth)
+	assert.Equal(t, 0.5, *opts.Margins.Top)
+	assert.Equal(t, "&C&F", opts.HeaderFooter.OddFooter)
+	assert.True(t, *opts.FitToPage)
+	assert.Equal(t, "A1:F30,H1", opts.PrintArea)
+	assert.Equal(t, "1:2", opts.PrintTitleRows)
+	assert.Equal(t, "A:A", opts.PrintTitleCols)
+	definedNames := f.GetDefinedName()
+	assert.Equal(t, []DefinedName{
+		{Name: "_xlnm.Print_Area", RefersTo: "'Sheet 2'!$A$1:$F$30,'Sheet 2'!$H$1", Scope: "Sheet 2"},
+		{Name: "_xlnm.Print_Titles", RefersTo: "'Sheet 2'!$A:$A,'Sheet 2'!$1:$2", Scope: "Sheet 2"},
+	}, definedNames)
+	// Test update print area and disable fit to page
+	assert.NoError(t, f.SetPageSetup("Sheet 2", &PageSetupOptions{FitToPage: boolPtr(false), PrintArea: "B2:C3"}))
+	opts, err = f.GetPageSetup("Sheet 2")
+	assert.NoError(t, err)
+	assert.False(t, *opts.FitToPage)
+	assert.Equal(t, "B2:C3", opts.PrintArea)
+	assert.Equal(t, "1:2", opts.PrintTitleRows)
+	assert.Len(t, f.GetDefinedName(), 2)
+	// Test update the print title rows only keeps the existing title columns
+	assert.NoError(t, f.SetPageSetup("Sheet 2", &PageSetupOptions{PrintTitleRows: "3"}))
+	opts, err = f.GetPageSetup("Sheet 2")
+	assert.NoError(t, err)
+	assert.Equal(t, "3:3", opts.PrintTitleRows)
+	assert.Equal(t, "A:A", opts.PrintTitleCols)
+	// Test update the print title columns only keeps the existing title rows
+	assert.NoError(t, f.SetPageSetup("Sheet 2", &PageSetupOptions{PrintTitleCols: "B:C"}))
+	assert.Equal(t, "'Sheet 2'!$B:$C,'Sheet 2'!$3:$3", f.GetDefinedName()[1].RefersTo)
+	// Test set and get page setup on the worksheet which name contains comma
+	_, err = f.NewSheet("Q1,Q2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetPageSetup("Q1,Q2", &PageSetupOptions{PrintArea: "A1:B2,D1", PrintTitleRows: "1", PrintTitleCols: "A"}))
+	assert.NoError(t, f.SetPageSetup("Q1,Q2", &PageSetupOptions{PrintTitleRows: "2"}))
+	opts, err = f.GetPageSetup("Q1,Q2")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:B2,D1", opts.PrintArea)
+	assert.Equal(t, "2:2", opts.PrintTitleRows)
+	assert.Equal(t, "A:A", opts.PrintTitleCols)
+	assert.Equal(t, "'Q1,Q2'!$A:$A,'Q1,Q2'!$2:$2", f.GetDefinedName()[3].RefersTo)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestPageSetup.xlsx")))
+	// Test get page setup without print settings
+	opts, err = f.GetPageSetup("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, opts.PrintArea)
+	assert.Nil(t, opts.HeaderFooter)
+	assert.False(t, *opts.FitToPage)
+	// Test set page setup with invalid options
+	for _, c := range []struct {
+		opts *PageSetupOptions
+		err  error
+	}{
+		{nil, ErrParameterInvalid},
+		{&PageSetupOptions{PrintArea: "A1:B"}, newCellNameToCoordinatesError("B", newInvalidCellNameError("B"))},
+		{&PageSetupOptions{PrintArea: "A1:B2:C3"}, ErrParameterInvalid},
+		{&PageSetupOptions{PrintTitleRows: "1:A"}, newInvalidRowNumberError(0)},
+		{&PageSetupOptions{PrintTitleRows: "1:2:3"}, ErrParameterInvalid},
+		{&PageSetupOptions{PrintTitleCols: "A:1"}, newInvalidColumnNameError("1")},
+		{&PageSetupOptions{Layout: &PageLayoutOptions{AdjustTo: uintPtr(5)}}, ErrPageSetupAdjustTo},
+		{&PageSetupOptions{Margins: &PageLayoutMarginsOptions{}, HeaderFooter: &HeaderFooterOptions{OddHeader: strings.Repeat("c", MaxFieldLength+1)}}, newFieldLengthError("OddHeader")},
+	} {
+		assert.Equal(t, c.err, f.SetPageSetup("Sheet1", c.opts))
+	}
+	// Test page setup on not exists worksheet
+	assert.EqualError(t, f.SetPageSetup("SheetN", &PageSetupOptions{}), "sheet SheetN does not exist")
+	_, err = f.GetPageSetup("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test page setup with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetPageSetup("Sheet1", &PageSetupOptions{PrintArea: "A1"}), "XML syntax error on line 1: invalid UTF-8")
+	f.WorkBook = nil
+	assert.EqualError(t, f.SetPageSetup("Sheet1", &PageSetupOptions{PrintTitleRows: "1"}), "XML syntax error on line 1: invalid UTF-8")
+	f.WorkBook = nil
+	_, err = f.GetPageSetup("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestHeaderFooter(t *testing.T) {
 	f := NewFile()
 	// Test get header and footer with default header and footer settings
@@ -285,6 +602,46 @@ func TestHeaderFooter(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expected, opts)
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetHeaderFooter.xlsx")))
+	// Test set header and footer with the first footer exceeds the limit
+	assert.Equal(t, newFieldLengthError("FirstFooter"), f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{
+		FirstFooter: strings.Repeat("c", MaxFieldLength+1),
+	}))
+}
+
+func TestFormatHeaderFooter(t *testing.T) {
+	val, err := FormatHeaderFooter(HeaderFooterSections{})
+	assert.NoError(t, err)
+	assert.Empty(t, val)
+	val, err = FormatHeaderFooter(HeaderFooterSections{
+		Left: []HeaderFooterField{
+			{Type: HeaderFooterFieldFileName, Font: &Font{Bold: true}},
+			{Text: " - "},
+			{Type: HeaderFooterFieldSheetName},
+		},
+		Center: []HeaderFooterField{
+			{Text: "R&D", Font: &Font{Family: "Arial", Size: 14, Italic: true, Color: "#ff0000", Underline: "double"}},
+			{Type: HeaderFooterFieldPicture},
+		},
+		Right: []HeaderFooterField{
+			{Text: "Page "},
+			{Type: HeaderFooterFieldPageNumber, Font: &Font{Bold: true, Italic: true, Strike: true, Underline: "single", VertAlign: "superscript"}},
+			{Text: " of "},
+			{Type: HeaderFooterFieldTotalPages},
+			{Type: HeaderFooterFieldDate},
+			{Type: HeaderFooterFieldTime},
+			{Type: HeaderFooterFieldFilePath, Font: &Font{}},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `&L&"-,Bold"&F - &A&C&14&"Arial,Italic"&KFF0000&ER&&D&E&G&RPage &"-,Bold Italic"&U&S&X&P&U&S&X of &N&D&T&Z`, val)
+	f := NewFile()
+	assert.NoError(t, f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{OddFooter: val}))
+	// Test format header and footer with invalid field type
+	_, err = FormatHeaderFooter(HeaderFooterSections{Left: []HeaderFooterField{{Type: 100}}})
+	assert.Equal(t, ErrParameterInvalid, err)
+	// Test format header and footer exceeds the limit
+	_, err = FormatHeaderFooter(HeaderFooterSections{Left: []HeaderFooterField{{Text: strings.Repeat("&", 128)}}})
+	assert.Equal(t, ErrHeaderFooterLength, err)
 }
 
 func TestDefinedName(t *testing.T) {
@@ -315,9 +672,26 @@ func TestDefinedName(t *testing.T) {
 		RefersTo: "Sheet1!$A$2:$D$5",
 		Comment:  "defined name comment",
 	}), ErrDefinedNameDuplicate.Error())
+	assert.Equal(t, ErrDefinedNameDuplicate, f.SetDefinedName(&DefinedName{
+		Name:     "amount",
+		RefersTo: "Sheet1!$A$2:$D$5",
+		Scope:    "Workbook",
+	}))
 	assert.EqualError(t, f.DeleteDefinedName(&DefinedName{
 		Name: "No Exist Defined Name",
 	}), ErrDefinedNameScope.Error())
+	// Test set defined name with not exist worksheet scope
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, f.SetDefinedName(&DefinedName{
+		Name:     "Amount",
+		RefersTo: "Sheet1!$A$2:$D$5",
+		Scope:    "SheetN",
+	}))
+	// Test set defined name with invalid worksheet scope
+	assert.Equal(t, ErrSheetNameInvalid, f.SetDefinedName(&DefinedName{
+		Name:     "Amount",
+		RefersTo: "Sheet1!$A$2:$D$5",
+		Scope:    "Sheet:1",
+	}))
 	// Test set defined name without name
 	assert.EqualError(t, f.SetDefinedName(&DefinedName{
 		RefersTo: "Sheet1!$A$2:$D$5",
@@ -332,6 +706,20 @@ func TestDefinedName(t *testing.T) {
 	}))
 	assert.Exactly(t, "Sheet1!$A$2:$D$5", f.GetDefinedName()[0].RefersTo)
 	assert.Len(t, f.GetDefinedName(), 3)
+	// Test set built-in defined names
+	assert.NoError(t, f.SetDefinedName(&DefinedName{
+		Name:     builtInDefinedNames[3],
+		RefersTo: "Sheet1!$A$1:$D$5",
+		Scope:    "Sheet1",
+	}))
+	assert.True(t, f.WorkBook.DefinedNames.DefinedName[3].Hidden)
+	assert.NoError(t, f.SetDefinedName(&DefinedName{
+		Name:     builtInDefinedNames[2],
+		RefersTo: "Sheet1!$F$1:$F$2",
+		Scope:    "Sheet1",
+	}))
+	assert.False(t, f.WorkBook.DefinedNames.DefinedName[4].Hidden)
+	assert.Len(t, f.GetDefinedName(), 5)
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDefinedName.xlsx")))
 	// Test set defined name with unsupported charset workbook
 	f.WorkBook = nil
@@ -413,6 +801,37 @@ func TestRemovePageBreak(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemovePageBreak.xlsx")))
 }
 
+func TestGetPageBreaks(t *testing.T) {
+	f := NewFile()
+	pageBreaks, err := f.GetPageBreaks("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, pageBreaks.Rows)
+	assert.Empty(t, pageBreaks.Cols)
+	for _, cell := range []string{"A10", "C5", "A5", "D1", "C5"} {
+		assert.NoError(t, f.InsertPageBreak("Sheet1", cell))
+	}
+	pageBreaks, err = f.GetPageBreaks("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, PageBreaks{Rows: []int{5, 10}, Cols: []int{3, 4}}, pageBreaks)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, 2, ws.(*xlsxWorksheet).RowBreaks.ManualBreakCount)
+	// Test remove the horizontal page break only
+	assert.NoError(t, f.RemovePageBreak("Sheet1", "A10"))
+	pageBreaks, err = f.GetPageBreaks("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, PageBreaks{Rows: []int{5}, Cols: []int{3, 4}}, pageBreaks)
+	assert.Equal(t, 1, ws.(*xlsxWorksheet).RowBreaks.Count)
+	// Test remove all page breaks
+	assert.NoError(t, f.RemovePageBreak("Sheet1", "C5"))
+	assert.NoError(t, f.RemovePageBreak("Sheet1", "D1"))
+	assert.Nil(t, ws.(*xlsxWorksheet).RowBreaks)
+	assert.Nil(t, ws.(*xlsxWorksheet).ColBreaks)
+	// Test get page breaks on not exists worksheet
+	_, err = f.GetPageBreaks("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
 func TestGetSheetName(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
@@ -510,7 +929,7 @@ func TestWorksheetWriter(t *testing.T) {
 	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(worksheet, 1)))
 	f.checked = sync.Map{}
 	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 2))
-	f.workSheetWriter()
+	assert.NoError(t, f.workSheetWriter())
 	value, ok := f.Pkg.Load("xl/worksheets/sheet1.xml")
 	assert.True(t, ok)
 	assert.Equal(t, fmt.Sprintf(worksheet, 2), string(value.([]byte)))
@@ -640,6 +1059,32 @@ func TestGetSheetVisible(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestGetSheetVisibleState(t *testing.T) {
+	f := NewFile()
+	for _, name := range []string{"Sheet2", "Sheet3"} {
+		_, err := f.NewSheet(name)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, f.SetSheetVisible("Sheet2", false))
+	assert.NoError(t, f.SetSheetVisible("Sheet3", false, true))
+	for sheet, expected := range map[string]string{"Sheet1": "visible", "Sheet2": "hidden", "Sheet3": "veryHidden"} {
+		state, err := f.GetSheetVisibleState(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, state)
+	}
+	// Test get sheet visible state with not exist worksheet
+	_, err := f.GetSheetVisibleState("SheetN")
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, err)
+	// Test get sheet visible state with invalid sheet name
+	_, err = f.GetSheetVisibleState("Sheet:1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test get sheet visible state with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetSheetVisibleState("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestGetSheetIndex(t *testing.T) {
 	f := NewFile()
 	// Test get sheet index with invalid sheet name
@@ -822,6 +1267,56 @@ func TestSheetDimension(t *testing.T) {
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 }
 
+func TestGetUsedRange(t *testing.T) {
+	f := NewFile()
+	ref, err := f.GetUsedRange("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, ref)
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", "value"))
+	ref, err = f.GetUsedRange("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "C3", ref)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E2", "C3"))
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B6", "B6", style))
+	ref, err = f.GetUsedRange("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B2:E6", ref)
+	// Test the dimension will be updated when saving the workbook
+	dimension, err := f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1", dimension)
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet2", "B2", 1))
+	assert.NoError(t, f.SetSheetDimension("Sheet2", "A1:D4"))
+	path := filepath.Join("test", "TestGetUsedRange.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	for sheet, expected := range map[string]string{"Sheet1": "B2:E6", "Sheet2": "A1:D4"} {
+		dimension, err = f.GetSheetDimension(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, dimension, sheet)
+	}
+	ref, err = f.GetUsedRange("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, "B2", ref)
+	// Test get used range with invalid cell reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[1].C[1].R = "-"
+	ref, err = f.GetUsedRange("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, ref)
+	assert.NoError(t, f.Close())
+	// Test get used range on not exists worksheet
+	_, err = f.GetUsedRange("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
 func TestAddIgnoredErrors(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.AddIgnoredErrors("Sheet1", "A1", IgnoredErrorsEvalError))
@@ -838,6 +1333,34 @@ func TestAddIgnoredErrors(t *testing.T) {
 	assert.Equal(t, ErrSheetNotExist{"SheetN"}, f.AddIgnoredErrors("SheetN", "A1", IgnoredErrorsEvalError))
 	assert.Equal(t, ErrParameterInvalid, f.AddIgnoredErrors("Sheet1", "", IgnoredErrorsEvalError))
 
+	assert.Equal(t, ErrParameterInvalid, f.AddIgnoredErrors("Sheet1", "A1", 100))
+
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddIgnoredErrors.xlsx")))
 	assert.NoError(t, f.Close())
 }
+
+func TestSetIgnoredErrors(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddIgnoredErrors("Sheet1", "E1", IgnoredErrorsEvalError))
+	assert.NoError(t, f.SetIgnoredErrors("Sheet1", "A1:D10 F1", []IgnoredErrorsType{IgnoredErrorsNumberStoredAsText}))
+	assert.NoError(t, f.SetIgnoredErrors("Sheet1", "A1:D10 F1", []IgnoredErrorsType{IgnoredErrorsNumberStoredAsText, IgnoredErrorsFormulaRange}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, []xlsxIgnoredError{
+		{Sqref: "E1", EvalError: true},
+		{Sqref: "A1:D10 F1", NumberStoredAsText: true, FormulaRange: true},
+	}, ws.(*xlsxWorksheet).IgnoredErrors.IgnoredError)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetIgnoredErrors.xlsx")))
+	// Test remove ignored errors
+	assert.NoError(t, f.SetIgnoredErrors("Sheet1", "A1:D10 F1", nil))
+	assert.NoError(t, f.SetIgnoredErrors("Sheet1", "E1", nil))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Nil(t, ws.(*xlsxWorksheet).IgnoredErrors)
+	// Test set ignored errors with invalid parameters
+	assert.Equal(t, ErrParameterInvalid, f.SetIgnoredErrors("Sheet1", "", nil))
+	assert.Equal(t, ErrParameterInvalid, f.SetIgnoredErrors("Sheet1", "A1", []IgnoredErrorsType{100}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetIgnoredErrors("Sheet1", "A", nil))
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, f.SetIgnoredErrors("SheetN", "A1", nil))
+	assert.NoError(t, f.Close())
+}
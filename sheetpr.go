@@ -155,7 +155,14 @@ func (ws *xlsxWorksheet) setSheetProps(opts *SheetPropsOptions) {
 	}
 }
 
-// SetSheetProps provides a function to set worksheet properties.
+// SetSheetProps provides a function to set worksheet properties, such as the
+// code name, tab color and the default row height of the worksheet. For
+// example, set the tab color of the worksheet named Sheet1 to red:
+//
+//	tabColor := "FF0000"
+//	err := f.SetSheetProps("Sheet1", &excelize.SheetPropsOptions{
+//	    TabColorRGB: &tabColor,
+//	})
 func (f *File) SetSheetProps(sheet string, opts *SheetPropsOptions) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -71,7 +71,30 @@ func (view *xlsxSheetView) setSheetView(opts *ViewOptions) {
 }
 
 // SetSheetView sets sheet view options. The viewIndex may be negative and if
-// so is counted backward (-1 is the last view).
+// so is counted backward (-1 is the last view). For example, hide the grid
+// lines, zoom to 150 percent, display the sheet in right-to-left mode with
+// formulas and without zero values, and switch to the page break preview of
+// the worksheet named Sheet1:
+//
+//	err := f.SetSheetView("Sheet1", -1, &excelize.ViewOptions{
+//	    ShowGridLines: &disable,
+//	    ZoomScale:     &zoomScale,
+//	    RightToLeft:   &enable,
+//	    ShowFormulas:  &enable,
+//	    ShowZeros:     &disable,
+//	    View:          &view,
+//	})
+//
+// where the variables are declared as:
+//
+//	enable, disable, zoomScale, view := true, false, 150.0, "pageBreakPreview"
+//
+// The tab color of the worksheet is a sheet property rather than a view
+// option, use the SetSheetProps function to set it, for example:
+//
+//	err := f.SetSheetProps("Sheet1", &excelize.SheetPropsOptions{
+//	    TabColorRGB: &tabColor,
+//	})
 func (f *File) SetSheetView(sheet string, viewIndex int, opts *ViewOptions) error {
 	view, err := f.getSheetView(sheet, viewIndex)
 	if err != nil {
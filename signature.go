@@ -0,0 +1,894 @@
+// Copyright 2016 - 2025 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.20 or later.
+
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"math/big"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	signatureAlgorithmC14N             = "http://www.w3.org/TR/2001/REC-xml-c14n-20010315"
+	signatureAlgorithmC14NWithComments = "http://www.w3.org/TR/2001/REC-xml-c14n-20010315#WithComments"
+	signatureAlgorithmExcC14N          = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	signatureAlgorithmRelationship     = "http://schemas.openxmlformats.org/package/2006/RelationshipTransform"
+	signatureAlgorithmSHA256           = "http://www.w3.org/2001/04/xmlenc#sha256"
+	signatureAlgorithmRSASHA256        = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	signatureAlgorithmECDSASHA256      = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha256"
+	signatureReferenceTypeObject       = "http://www.w3.org/2000/09/xmldsig#Object"
+	signatureReferenceTypeProperties   = "http://uri.etsi.org/01903#SignedProperties"
+	signatureTimeFormat                = "2006-01-02T15:04:05Z"
+)
+
+var (
+	// signatureDigestMethods defined the hash functions of the supported
+	// digest methods for the XML signature.
+	signatureDigestMethods = map[string]crypto.Hash{
+		"http://www.w3.org/2000/09/xmldsig#sha1":        crypto.SHA1,
+		signatureAlgorithmSHA256:                        crypto.SHA256,
+		"http://www.w3.org/2001/04/xmldsig-more#sha384": crypto.SHA384,
+		"http://www.w3.org/2001/04/xmlenc#sha512":       crypto.SHA512,
+	}
+	// signatureMethods defined the hash functions of the supported signature
+	// methods for the XML signature.
+	signatureMethods = map[string]crypto.Hash{
+		"http://www.w3.org/2000/09/xmldsig#rsa-sha1":          crypto.SHA1,
+		signatureAlgorithmRSASHA256:                           crypto.SHA256,
+		"http://www.w3.org/2001/04/xmldsig-more#rsa-sha384":   crypto.SHA384,
+		"http://www.w3.org/2001/04/xmldsig-more#rsa-sha512":   crypto.SHA512,
+		"http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha1":   crypto.SHA1,
+		signatureAlgorithmECDSASHA256:                         crypto.SHA256,
+		"http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha384": crypto.SHA384,
+		"http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha512": crypto.SHA512,
+	}
+	// canonicalTextReplacer and canonicalAttrReplacer defined the character
+	// escaping rules of the text nodes and attribute values in the canonical
+	// XML.
+	canonicalTextReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+	canonicalAttrReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", "\"", "&quot;", "\t", "&#x9;", "\n", "&#xA;", "\r", "&#xD;")
+)
+
+// AddDigitalSignature provides a function to sign the workbook with an X.509
+// certificate and its private key, the RSA and ECDSA keys are supported. The
+// signature will be calculated over all package parts of the workbook and
+// stored in the XML signature part under the _xmlsignatures folder when
+// saving the workbook, so any changes of the workbook before saving will be
+// signed, and the signature will be invalid if the saved workbook has been
+// changed. For example, sign the workbook with the certificate and RSA
+// private key in the PEM files:
+//
+//	certPEM, err := os.ReadFile("cert.pem")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	keyPEM, err := os.ReadFile("key.pem")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	block, _ := pem.Decode(certPEM)
+//	cert, err := x509.ParseCertificate(block.Bytes)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	block, _ = pem.Decode(keyPEM)
+//	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.AddDigitalSignature(&excelize.DigitalSignatureOptions{
+//	    Certificate: cert,
+//	    PrivateKey:  key,
+//	    Comment:     "Approved for distribution",
+//	}); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.SaveAs("Book1.xlsx"); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) AddDigitalSignature(opts *DigitalSignatureOptions) error {
+	if opts == nil || opts.Certificate == nil || opts.PrivateKey == nil {
+		return ErrParameterRequired
+	}
+	if _, err := getSignatureMethod(opts.PrivateKey.Public()); err != nil {
+		return err
+	}
+	if key, ok := opts.PrivateKey.Public().(interface {
+		Equal(crypto.PublicKey) bool
+	}); !ok || !key.Equal(opts.Certificate.PublicKey) {
+		return ErrParameterInvalid
+	}
+	originPath, err := f.getSignatureOriginPath()
+	if err != nil {
+		return err
+	}
+	if originPath == "" {
+		originPath = defaultXMLPathSignatureOrigin
+		f.Pkg.Store(originPath, []byte{})
+		f.addRels(defaultXMLPathRels, SourceRelationshipDigitalSignatureOrigin, originPath, "")
+		if err = f.setContentTypePartDefaultExtension("sigs", ContentTypeDigitalSignatureOrigin); err != nil {
+			return err
+		}
+	}
+	relsPath := getSignatureOriginRelsPath(originPath)
+	rels, err := f.relsReader(relsPath)
+	if err != nil {
+		return err
+	}
+	targets := map[string]bool{}
+	if rels != nil {
+		for _, rel := range rels.Relationships {
+			targets[getSignaturePartPath(originPath, rel.Target)] = true
+		}
+	}
+	sigPath := path.Join(path.Dir(originPath), "sig1.xml")
+	for idx := 2; targets[sigPath]; idx++ {
+		sigPath = path.Join(path.Dir(originPath), "sig"+strconv.Itoa(idx)+".xml")
+	}
+	f.addRels(relsPath, SourceRelationshipDigitalSignature, path.Base(sigPath), "")
+	f.signatures[sigPath] = opts
+	return f.setContentTypes("/"+sigPath, ContentTypeDigitalSignatureXML)
+}
+
+// GetDigitalSignatures provides a function to get and verify the digital
+// signatures of the workbook. The digest values of the signed package parts
+// and the signature value will be verified for each signature, but the trust
+// chain of the certificate will not be verified, so the caller should verify
+// the Certificate of the signature if needed. The signatures added by the
+// AddDigitalSignature function will be returned after saving the workbook.
+// For example, get and verify the digital signatures of the workbook:
+//
+//	signatures, err := f.GetDigitalSignatures()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, signature := range signatures {
+//	    fmt.Println(signature.Certificate.Subject, signature.SigningTime, signature.Valid)
+//	}
+func (f *File) GetDigitalSignatures() ([]DigitalSignature, error) {
+	var signatures []DigitalSignature
+	originPath, err := f.getSignatureOriginPath()
+	if err != nil || originPath == "" {
+		return signatures, err
+	}
+	rels, err := f.relsReader(getSignatureOriginRelsPath(originPath))
+	if err != nil || rels == nil {
+		return signatures, err
+	}
+	for _, rel := range rels.Relationships {
+		if rel.Type != SourceRelationshipDigitalSignature {
+			continue
+		}
+		content := f.readXML(getSignaturePartPath(originPath, rel.Target))
+		if len(content) == 0 {
+			continue
+		}
+		signature, err := f.verifySignature(content)
+		if err != nil {
+			return signatures, err
+		}
+		signatures = append(signatures, signature)
+	}
+	return signatures, err
+}
+
+// getSignatureOriginPath provides a function to get the path of the digital
+// signature origin part of the package, and returns an empty string if the
+// package has not been signed.
+func (f *File) getSignatureOriginPath() (string, error) {
+	rels, err := f.relsReader(defaultXMLPathRels)
+	if err != nil || rels == nil {
+		return "", err
+	}
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipDigitalSignatureOrigin {
+			return strings.TrimPrefix(rel.Target, "/"), err
+		}
+	}
+	return "", err
+}
+
+// getSignatureOriginRelsPath returns the relationships part path of the
+// digital signature origin part.
+func getSignatureOriginRelsPath(originPath string) string {
+	return path.Join(path.Dir(originPath), "_rels", path.Base(originPath)+".rels")
+}
+
+// getSignaturePartPath returns the path of the XML signature part by given
+// digital signature origin part path and the relationship target.
+func getSignaturePartPath(originPath, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Join(path.Dir(originPath), target)
+}
+
+// getSignatureMethod returns the signature method for the public key.
+func getSignatureMethod(pub crypto.PublicKey) (string, error) {
+	switch pub.(type) {
+	case *rsa.PublicKey:
+		return signatureAlgorithmRSASHA256, nil
+	case *ecdsa.PublicKey:
+		return signatureAlgorithmECDSASHA256, nil
+	}
+	return "", ErrUnsupportedSignatureAlgorithm
+}
+
+// signaturesWriter provides a function to calculate the digital signatures
+// added by the AddDigitalSignature function, and save the XML signature
+// parts after serializing all other package parts.
+func (f *File) signaturesWriter() error {
+	if len(f.signatures) == 0 {
+		return nil
+	}
+	refs, err := f.getSignatureManifest()
+	if err != nil {
+		return err
+	}
+	for sigPath, opts := range f.signatures {
+		content, err := newSignature(refs, opts)
+		if err != nil {
+			return err
+		}
+		f.Pkg.Store(sigPath, content)
+	}
+	return err
+}
+
+// getSignatureManifest provides a function to get the references of all
+// package parts to be signed, excluding the content types part and the
+// digital signature parts.
+func (f *File) getSignatureManifest() ([]xlsxSignatureReference, error) {
+	var (
+		refs  []xlsxSignatureReference
+		parts []string
+		names = map[string]bool{}
+		add   = func(name, _ interface{}) bool {
+			names[name.(string)] = true
+			return true
+		}
+	)
+	f.Pkg.Range(add)
+	f.tempFiles.Range(add)
+	f.zipFiles.Range(add)
+	for name := range f.streams {
+		names[name] = true
+	}
+	for name := range names {
+		if name != defaultXMLPathContentTypes && !strings.HasPrefix(name, "_xmlsignatures/") {
+			parts = append(parts, name)
+		}
+	}
+	sort.Strings(parts)
+	contentTypes, err := f.contentTypesReader()
+	if err != nil {
+		return refs, err
+	}
+	for _, name := range parts {
+		ref := xlsxSignatureReference{
+			URI:          (&url.URL{Path: "/" + name}).EscapedPath() + "?ContentType=" + getPartContentType(contentTypes, name),
+			DigestMethod: xlsxSignatureAlgorithm{Algorithm: signatureAlgorithmSHA256},
+		}
+		h := sha256.New()
+		if strings.HasSuffix(name, ".rels") {
+			var buf bytes.Buffer
+			if _, err = f.writePartTo(name, &buf); err != nil {
+				return refs, err
+			}
+			transform := xlsxSignatureTransform{Algorithm: signatureAlgorithmRelationship}
+			var rels xlsxRelationships
+			if err = xml.Unmarshal(buf.Bytes(), &rels); err != nil {
+				return refs, err
+			}
+			var sourceIDs []string
+			for _, rel := range rels.Relationships {
+				if rel.Type != SourceRelationshipDigitalSignatureOrigin {
+					sourceIDs = append(sourceIDs, rel.ID)
+					transform.RelationshipReference = append(transform.RelationshipReference,
+						xlsxRelationshipReference{XMLNSMdssi: NameSpaceDigitalSignature, SourceID: rel.ID})
+				}
+			}
+			if len(sourceIDs) == 0 {
+				continue
+			}
+			ref.Transforms = &xlsxSignatureTransforms{Transform: []xlsxSignatureTransform{
+				transform, {Algorithm: signatureAlgorithmC14N},
+			}}
+			content, err := relationshipTransform(buf.Bytes(), sourceIDs, nil)
+			if err != nil {
+				return refs, err
+			}
+			_, _ = h.Write(content)
+		} else if _, err = f.writePartTo(name, h); err != nil {
+			return refs, err
+		}
+		ref.DigestValue = base64.StdEncoding.EncodeToString(h.Sum(nil))
+		refs = append(refs, ref)
+	}
+	return refs, err
+}
+
+// getPartContentType returns the content type of the package part by given
+// part path.
+func getPartContentType(contentTypes *xlsxTypes, name string) string {
+	contentTypes.mu.Lock()
+	defer contentTypes.mu.Unlock()
+	for _, override := range contentTypes.Overrides {
+		if strings.EqualFold(strings.TrimPrefix(override.PartName, "/"), name) {
+			return override.ContentType
+		}
+	}
+	ext := strings.TrimPrefix(path.Ext(name), ".")
+	for _, def := range contentTypes.Defaults {
+		if strings.EqualFold(def.Extension, ext) {
+			return def.ContentType
+		}
+	}
+	return ""
+}
+
+// writePartTo provides a function to write the content of the package part
+// by given path to the io.Writer, as it will be saved in the spreadsheet.
+// This function returns false if the part doesn't exist.
+func (f *File) writePartTo(name string, w io.Writer) (bool, error) {
+	if stream, ok := f.streams[name]; ok {
+		r, err := stream.rawData.Reader()
+		if err != nil {
+			return true, err
+		}
+		_, err = io.Copy(w, r)
+		return true, err
+	}
+	if content, ok := f.Pkg.Load(name); ok {
+		_, err := w.Write(content.([]byte))
+		return true, err
+	}
+	var rc io.ReadCloser
+	if _, ok := f.tempFiles.Load(name); ok {
+		file, err := f.readTemp(name)
+		if err != nil {
+			return true, err
+		}
+		rc = file
+	} else if file, ok := f.zipFiles.Load(name); ok {
+		r, err := file.(*zip.File).Open()
+		if err != nil {
+			return true, err
+		}
+		rc = r
+	}
+	if rc == nil {
+		return false, nil
+	}
+	defer rc.Close()
+	_, err := io.Copy(w, rc)
+	return true, err
+}
+
+// newSignature provides a function to create the XML signature part content
+// by given references of the signed package parts and the digital signature
+// options.
+func newSignature(refs []xlsxSignatureReference, opts *DigitalSignatureOptions) ([]byte, error) {
+	method, err := getSignatureMethod(opts.PrivateKey.Public())
+	if err != nil {
+		return nil, err
+	}
+	signingTime := opts.SigningTime
+	if signingTime.IsZero() {
+		signingTime = time.Now()
+	}
+	certDigest := sha256.Sum256(opts.Certificate.Raw)
+	sig := xlsxSignature{
+		XMLNS: NameSpaceXMLDigitalSignature,
+		ID:    "idPackageSignature",
+		SignedInfo: xlsxSignedInfo{
+			CanonicalizationMethod: xlsxSignatureAlgorithm{Algorithm: signatureAlgorithmC14N},
+			SignatureMethod:        xlsxSignatureAlgorithm{Algorithm: method},
+			Reference: []xlsxSignatureReference{
+				{Type: signatureReferenceTypeObject, URI: "#idPackageObject"},
+				{Type: signatureReferenceTypeObject, URI: "#idOfficeObject"},
+				{
+					Type: signatureReferenceTypeProperties, URI: "#idSignedProperties",
+					Transforms: &xlsxSignatureTransforms{Transform: []xlsxSignatureTransform{{Algorithm: signatureAlgorithmC14N}}},
+				},
+			},
+		},
+		KeyInfo: xlsxKeyInfo{X509Certificate: base64.StdEncoding.EncodeToString(opts.Certificate.Raw)},
+		Object: []xlsxSignatureObject{
+			{
+				ID:       "idPackageObject",
+				Manifest: &xlsxSignatureManifest{Reference: refs},
+				SignatureProperties: &xlsxSignatureProperties{SignatureProperty: []xlsxSignatureProperty{{
+					ID: "idSignatureTime", Target: "#idPackageSignature",
+					SignatureTime: &xlsxSignatureTime{
+						XMLNSMdssi: NameSpaceDigitalSignature,
+						Format:     "YYYY-MM-DDThh:mm:ssTZD",
+						Value:      signingTime.UTC().Format(signatureTimeFormat),
+					},
+				}}},
+			},
+			{
+				ID: "idOfficeObject",
+				SignatureProperties: &xlsxSignatureProperties{SignatureProperty: []xlsxSignatureProperty{{
+					ID: "idOfficeV1Details", Target: "#idPackageSignature",
+					SignatureInfoV1: &xlsxSignatureInfoV1{
+						XMLNS:                    NameSpaceOfficeDigitalSignature,
+						SignatureComments:        opts.Comment,
+						WindowsVersion:           "10.0",
+						OfficeVersion:            "16.0",
+						ApplicationVersion:       "16.0",
+						Monitors:                 1,
+						HorizontalResolution:     1920,
+						VerticalResolution:       1080,
+						ColorDepth:               32,
+						SignatureProviderID:      "{00000000-0000-0000-0000-000000000000}",
+						SignatureProviderDetails: 9,
+						SignatureType:            1,
+					},
+				}}},
+			},
+			{
+				QualifyingProperties: &xlsxQualifyingProperties{
+					XMLNSXd: NameSpaceXAdES,
+					Target:  "#idPackageSignature",
+					SignedProperties: xlsxSignedProperties{
+						ID: "idSignedProperties",
+						SignedSignatureProperties: xlsxSignedSignatureProperties{
+							SigningTime: signingTime.UTC().Format(signatureTimeFormat),
+							SigningCertificate: xlsxSigningCertificate{
+								CertDigest: xlsxSignatureCertDigest{
+									DigestMethod: xlsxSignatureAlgorithm{Algorithm: signatureAlgorithmSHA256},
+									DigestValue:  base64.StdEncoding.EncodeToString(certDigest[:]),
+								},
+								X509IssuerName:   opts.Certificate.Issuer.String(),
+								X509SerialNumber: opts.Certificate.SerialNumber.String(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	content, err := xml.Marshal(sig)
+	if err != nil {
+		return nil, err
+	}
+	for i, ref := range sig.SignedInfo.Reference {
+		canonical, err := canonicalizeXML(content, matchSignatureID(strings.TrimPrefix(ref.URI, "#")), false)
+		if err != nil {
+			return nil, err
+		}
+		digest := sha256.Sum256(canonical)
+		sig.SignedInfo.Reference[i].DigestMethod.Algorithm = signatureAlgorithmSHA256
+		sig.SignedInfo.Reference[i].DigestValue = base64.StdEncoding.EncodeToString(digest[:])
+	}
+	if content, err = xml.Marshal(sig); err != nil {
+		return nil, err
+	}
+	canonical, err := canonicalizeXML(content, matchSignedInfo, false)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256(canonical)
+	value, err := signDigest(opts.PrivateKey, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	sig.SignatureValue = base64.StdEncoding.EncodeToString(value)
+	content, err = xml.Marshal(sig)
+	return append([]byte(xml.Header), content...), err
+}
+
+// signDigest provides a function to sign the SHA-256 digest with the private
+// key. The ECDSA signature will be converted from the ASN.1 DER encoding to
+// the concatenation of the r and s values required by the XML signature.
+func signDigest(signer crypto.Signer, digest []byte) ([]byte, error) {
+	value, err := signer.Sign(rand.Reader, digest, crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	if pub, ok := signer.Public().(*ecdsa.PublicKey); ok {
+		var sig struct{ R, S *big.Int }
+		if _, err = asn1.Unmarshal(value, &sig); err != nil {
+			return nil, err
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		value = make([]byte, size*2)
+		sig.R.FillBytes(value[:size])
+		sig.S.FillBytes(value[size:])
+	}
+	return value, err
+}
+
+// verifySignature provides a function to verify the XML signature by given
+// XML signature part content.
+func (f *File) verifySignature(content []byte) (DigitalSignature, error) {
+	var (
+		sig       decodeSignature
+		signature DigitalSignature
+	)
+	if err := f.xmlNewDecoder(bytes.NewReader(content)).Decode(&sig); err != nil && err != io.EOF {
+		return signature, err
+	}
+	for _, obj := range sig.Object {
+		for _, prop := range obj.SignatureProperty {
+			if prop.SignatureComments != "" {
+				signature.Comment = prop.SignatureComments
+			}
+			if t, err := time.Parse(time.RFC3339, prop.SignatureTime); err == nil {
+				signature.SigningTime = t
+			}
+		}
+		if t, err := time.Parse(time.RFC3339, obj.SigningTime); err == nil && signature.SigningTime.IsZero() {
+			signature.SigningTime = t
+		}
+	}
+	if len(sig.X509Certificate) == 0 {
+		return signature, nil
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(sig.X509Certificate[0]), ""))
+	if err != nil {
+		return signature, err
+	}
+	if signature.Certificate, err = x509.ParseCertificate(raw); err != nil {
+		return signature, err
+	}
+	signature.Valid = true
+	refs := sig.SignedInfo.Reference
+	for _, obj := range sig.Object {
+		refs = append(refs, obj.Reference...)
+	}
+	for _, ref := range refs {
+		valid, err := f.verifySignatureReference(content, ref)
+		if err != nil {
+			return signature, err
+		}
+		signature.Valid = signature.Valid && valid
+	}
+	hash, ok := signatureMethods[sig.SignedInfo.SignatureMethod.Algorithm]
+	if !ok {
+		return signature, ErrUnsupportedSignatureAlgorithm
+	}
+	exclusive, err := isExclusiveCanonicalization([]string{sig.SignedInfo.CanonicalizationMethod.Algorithm})
+	if err != nil {
+		return signature, err
+	}
+	canonical, err := canonicalizeXML(content, matchSignedInfo, exclusive)
+	if err != nil {
+		return signature, err
+	}
+	value, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(sig.SignatureValue), ""))
+	if err != nil {
+		return signature, err
+	}
+	h := hash.New()
+	_, _ = h.Write(canonical)
+	signature.Valid = signature.Valid && verifySignatureValue(signature.Certificate.PublicKey, hash, h.Sum(nil), value)
+	return signature, err
+}
+
+// verifySignatureReference provides a function to verify the digest value of
+// the referenced content by given XML signature part content and the
+// reference. The reference could be an element with the Id attribute in the
+// XML signature part, or a package part with an optional relationship
+// transform.
+func (f *File) verifySignatureReference(content []byte, ref decodeSignatureReference) (bool, error) {
+	hash, ok := signatureDigestMethods[ref.DigestMethod.Algorithm]
+	if !ok {
+		return false, ErrUnsupportedHashAlgorithm
+	}
+	var (
+		algorithms, sourceIDs, sourceTypes []string
+		data                               []byte
+	)
+	for _, transform := range ref.Transform {
+		if transform.Algorithm == signatureAlgorithmRelationship {
+			for _, r := range transform.RelationshipReference {
+				sourceIDs = append(sourceIDs, r.SourceID)
+			}
+			for _, r := range transform.RelationshipsGroupReference {
+				sourceTypes = append(sourceTypes, r.SourceType)
+			}
+			continue
+		}
+		algorithms = append(algorithms, transform.Algorithm)
+	}
+	exclusive, err := isExclusiveCanonicalization(algorithms)
+	if err != nil {
+		return false, err
+	}
+	if id := strings.TrimPrefix(ref.URI, "#"); id != ref.URI {
+		if data, err = canonicalizeXML(content, matchSignatureID(id), exclusive); err != nil || data == nil {
+			return false, err
+		}
+	} else {
+		name, err := url.PathUnescape(strings.TrimPrefix(strings.SplitN(ref.URI, "?", 2)[0], "/"))
+		if err != nil {
+			return false, nil
+		}
+		var buf bytes.Buffer
+		if ok, err := f.writePartTo(name, &buf); !ok || err != nil {
+			return false, err
+		}
+		if data = buf.Bytes(); len(sourceIDs) > 0 || len(sourceTypes) > 0 {
+			if data, err = relationshipTransform(data, sourceIDs, sourceTypes); err != nil {
+				return false, err
+			}
+		}
+		if len(algorithms) > 0 {
+			if data, err = canonicalizeXML(data, nil, exclusive); err != nil {
+				return false, err
+			}
+		}
+	}
+	h := hash.New()
+	_, _ = h.Write(data)
+	return base64.StdEncoding.EncodeToString(h.Sum(nil)) == strings.Join(strings.Fields(ref.DigestValue), ""), err
+}
+
+// verifySignatureValue returns true if the signature value of the digest
+// matches the public key.
+func verifySignatureValue(pub crypto.PublicKey, hash crypto.Hash, digest, value []byte) bool {
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, hash, digest, value) == nil
+	case *ecdsa.PublicKey:
+		size := len(value) / 2
+		r, s := new(big.Int).SetBytes(value[:size]), new(big.Int).SetBytes(value[size:])
+		return ecdsa.Verify(key, digest, r, s)
+	}
+	return false
+}
+
+// isExclusiveCanonicalization returns true if the exclusive canonicalization
+// algorithm was specified in the given transform algorithms.
+func isExclusiveCanonicalization(algorithms []string) (bool, error) {
+	var exclusive bool
+	for _, algorithm := range algorithms {
+		switch algorithm {
+		case signatureAlgorithmC14N, signatureAlgorithmC14NWithComments:
+		case signatureAlgorithmExcC14N:
+			exclusive = true
+		default:
+			return exclusive, ErrUnsupportedSignatureAlgorithm
+		}
+	}
+	return exclusive, nil
+}
+
+// matchSignedInfo returns true if the element is the SignedInfo element.
+func matchSignedInfo(start xml.StartElement) bool {
+	return start.Name.Local == "SignedInfo"
+}
+
+// matchSignatureID returns the function to match the element by given value
+// of the Id attribute.
+func matchSignatureID(id string) func(start xml.StartElement) bool {
+	return func(start xml.StartElement) bool {
+		for _, attr := range start.Attr {
+			if attr.Name.Space == "" && attr.Name.Local == "Id" && attr.Value == id {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// relationshipTransform provides a function to apply the relationship
+// transform of the Open Packaging Conventions on the relationships part
+// content. The relationships matched by given IDs or types will be sorted by
+// ID with default target mode, and returned in canonical form.
+func relationshipTransform(content []byte, sourceIDs, sourceTypes []string) ([]byte, error) {
+	var rels xlsxRelationships
+	if err := xml.Unmarshal(content, &rels); err != nil {
+		return nil, err
+	}
+	sort.Slice(rels.Relationships, func(i, j int) bool {
+		return rels.Relationships[i].ID < rels.Relationships[j].ID
+	})
+	var buf bytes.Buffer
+	buf.WriteString(`<Relationships xmlns="` + NameSpacePackageRelationships + `">`)
+	for _, rel := range rels.Relationships {
+		if inStrSlice(sourceIDs, rel.ID, true) == -1 && inStrSlice(sourceTypes, rel.Type, true) == -1 {
+			continue
+		}
+		targetMode := rel.TargetMode
+		if targetMode == "" {
+			targetMode = "Internal"
+		}
+		buf.WriteString(`<Relationship Id="` + canonicalAttrReplacer.Replace(rel.ID) +
+			`" Target="` + canonicalAttrReplacer.Replace(rel.Target) +
+			`" TargetMode="` + canonicalAttrReplacer.Replace(targetMode) +
+			`" Type="` + canonicalAttrReplacer.Replace(rel.Type) + `"></Relationship>`)
+	}
+	buf.WriteString("</Relationships>")
+	return buf.Bytes(), nil
+}
+
+// canonicalizeXML provides a function to get the canonical form of the first
+// element matched by given function in the XML document, as specified by the
+// Canonical XML 1.0 or the Exclusive XML Canonicalization 1.0 without
+// comments. The document element will be used if the match function is nil,
+// and nil will be returned if no element matched.
+func canonicalizeXML(content []byte, match func(start xml.StartElement) bool, exclusive bool) ([]byte, error) {
+	type scope struct {
+		name              string
+		inScope, rendered map[string]string
+	}
+	var (
+		buf     bytes.Buffer
+		depth   int
+		stack   []scope
+		decoder = xml.NewDecoder(bytes.NewReader(content))
+	)
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			parent := scope{inScope: map[string]string{}, rendered: map[string]string{}}
+			if len(stack) > 0 {
+				parent = stack[len(stack)-1]
+			}
+			current := scope{name: getCanonicalName(t.Name), inScope: map[string]string{}, rendered: parent.rendered}
+			for prefix, uri := range parent.inScope {
+				current.inScope[prefix] = uri
+			}
+			var attrs []xml.Attr
+			for _, attr := range t.Attr {
+				if attr.Name.Space == "xmlns" {
+					current.inScope[attr.Name.Local] = attr.Value
+					continue
+				}
+				if attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+					current.inScope[""] = attr.Value
+					continue
+				}
+				attrs = append(attrs, attr)
+			}
+			if depth > 0 {
+				depth++
+			} else if match == nil || match(t) {
+				depth = 1
+			}
+			if depth > 0 {
+				current.rendered = writeCanonicalStartElement(&buf, current.name, t.Name.Space, attrs, current.inScope, parent.rendered, exclusive)
+			}
+			stack = append(stack, current)
+		case xml.EndElement:
+			if len(stack) == 0 {
+				return nil, nil
+			}
+			current := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if depth > 0 {
+				buf.WriteString("</" + current.name + ">")
+				if depth--; depth == 0 {
+					return buf.Bytes(), nil
+				}
+			}
+		case xml.CharData:
+			if depth > 0 {
+				buf.WriteString(canonicalTextReplacer.Replace(string(t)))
+			}
+		case xml.ProcInst:
+			if depth > 0 {
+				buf.WriteString("<?" + t.Target)
+				if len(t.Inst) > 0 {
+					buf.WriteString(" " + string(t.Inst))
+				}
+				buf.WriteString("?>")
+			}
+		}
+	}
+}
+
+// getCanonicalName returns the qualified name of the element or attribute in
+// the canonical XML.
+func getCanonicalName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// writeCanonicalStartElement provides a function to write the start tag of
+// the element with the namespace declarations and sorted attributes in the
+// canonical XML, and returns the rendered namespace declarations for the
+// descendant elements.
+func writeCanonicalStartElement(buf *bytes.Buffer, name, prefix string, attrs []xml.Attr, inScope, parentRendered map[string]string, exclusive bool) map[string]string {
+	prefixes := map[string]bool{}
+	if exclusive {
+		prefixes[prefix] = true
+		for _, attr := range attrs {
+			if attr.Name.Space != "" {
+				prefixes[attr.Name.Space] = true
+			}
+		}
+	} else {
+		for p := range inScope {
+			prefixes[p] = true
+		}
+		for p := range parentRendered {
+			prefixes[p] = true
+		}
+	}
+	var declarations []string
+	for p := range prefixes {
+		if p != "xml" && inScope[p] != parentRendered[p] {
+			declarations = append(declarations, p)
+		}
+	}
+	sort.Strings(declarations)
+	rendered := parentRendered
+	if len(declarations) > 0 {
+		rendered = map[string]string{}
+		for p, uri := range parentRendered {
+			rendered[p] = uri
+		}
+	}
+	buf.WriteString("<" + name)
+	for _, p := range declarations {
+		if rendered[p] = inScope[p]; p == "" {
+			buf.WriteString(` xmlns="` + canonicalAttrReplacer.Replace(inScope[p]) + `"`)
+			continue
+		}
+		buf.WriteString(" xmlns:" + p + `="` + canonicalAttrReplacer.Replace(inScope[p]) + `"`)
+	}
+	getNameSpace := func(attr xml.Attr) string {
+		if attr.Name.Space == "" {
+			return ""
+		}
+		if attr.Name.Space == "xml" {
+			return NameSpaceXML
+		}
+		return inScope[attr.Name.Space]
+	}
+	sort.SliceStable(attrs, func(i, j int) bool {
+		if ns1, ns2 := getNameSpace(attrs[i]), getNameSpace(attrs[j]); ns1 != ns2 {
+			return ns1 < ns2
+		}
+		return attrs[i].Name.Local < attrs[j].Name.Local
+	})
+	for _, attr := range attrs {
+		buf.WriteString(" " + getCanonicalName(attr.Name) + `="` + canonicalAttrReplacer.Replace(attr.Value) + `"`)
+	}
+	buf.WriteString(">")
+	return rendered
+}
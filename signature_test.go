@@ -0,0 +1,217 @@
+package excelize
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/xml"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCertificate(t *testing.T, key crypto.Signer) *x509.Certificate {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Excelize"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(raw)
+	assert.NoError(t, err)
+	return cert
+}
+
+func TestDigitalSignature(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	rsaCert, ecdsaCert := newTestCertificate(t, rsaKey), newTestCertificate(t, ecdsaKey)
+	signingTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Report"))
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Report", 100}))
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.AddDigitalSignature(&DigitalSignatureOptions{
+		Certificate: rsaCert, PrivateKey: rsaKey, Comment: "Approved", SigningTime: signingTime,
+	}))
+	assert.NoError(t, f.AddDigitalSignature(&DigitalSignatureOptions{Certificate: ecdsaCert, PrivateKey: ecdsaKey}))
+	// Test get digital signatures before saving the workbook
+	signatures, err := f.GetDigitalSignatures()
+	assert.NoError(t, err)
+	assert.Empty(t, signatures)
+	path := filepath.Join("test", "TestDigitalSignature.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	signatures, err = f.GetDigitalSignatures()
+	assert.NoError(t, err)
+	assert.Len(t, signatures, 2)
+	assert.Equal(t, DigitalSignature{Certificate: rsaCert, Comment: "Approved", SigningTime: signingTime, Valid: true}, signatures[0])
+	assert.Equal(t, ecdsaCert, signatures[1].Certificate)
+	assert.True(t, signatures[1].Valid)
+	// Test get digital signatures with changed package part
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte("<worksheet/>"))
+	signatures, err = f.GetDigitalSignatures()
+	assert.NoError(t, err)
+	assert.False(t, signatures[0].Valid)
+	assert.False(t, signatures[1].Valid)
+	// Test get digital signatures with deleted package part
+	f.Pkg.Delete("xl/worksheets/sheet1.xml")
+	f.zipFiles.Delete("xl/worksheets/sheet1.xml")
+	signatures, err = f.GetDigitalSignatures()
+	assert.NoError(t, err)
+	assert.False(t, signatures[0].Valid)
+	assert.NoError(t, f.Close())
+
+	// Test get digital signatures with the worksheet in the temporary file
+	f, err = OpenFile(path, Options{UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
+	signatures, err = f.GetDigitalSignatures()
+	assert.NoError(t, err)
+	assert.True(t, signatures[0].Valid)
+	// Test add digital signature on the signed workbook
+	assert.NoError(t, f.AddDigitalSignature(&DigitalSignatureOptions{Certificate: rsaCert, PrivateKey: rsaKey}))
+	_, ok := f.signatures["_xmlsignatures/sig3.xml"]
+	assert.True(t, ok)
+	assert.NoError(t, f.Close())
+
+	// Test add digital signature with invalid options
+	f = NewFile()
+	assert.Equal(t, ErrParameterRequired, f.AddDigitalSignature(nil))
+	assert.Equal(t, ErrParameterRequired, f.AddDigitalSignature(&DigitalSignatureOptions{Certificate: rsaCert}))
+	assert.Equal(t, ErrParameterInvalid, f.AddDigitalSignature(&DigitalSignatureOptions{Certificate: ecdsaCert, PrivateKey: rsaKey}))
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrUnsupportedSignatureAlgorithm, f.AddDigitalSignature(&DigitalSignatureOptions{
+		Certificate: newTestCertificate(t, ed25519Key), PrivateKey: ed25519Key,
+	}))
+	// Test add digital signature with unsupported charset relationships
+	f.Relationships.Delete(defaultXMLPathRels)
+	f.Pkg.Store(defaultXMLPathRels, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddDigitalSignature(&DigitalSignatureOptions{Certificate: rsaCert, PrivateKey: rsaKey}), "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.GetDigitalSignatures()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test add digital signature with unsupported charset content types
+	f = NewFile()
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddDigitalSignature(&DigitalSignatureOptions{Certificate: rsaCert, PrivateKey: rsaKey}), "XML syntax error on line 1: invalid UTF-8")
+	// Test add and get digital signatures with unsupported charset origin relationships
+	f = NewFile()
+	f.addRels(defaultXMLPathRels, SourceRelationshipDigitalSignatureOrigin, defaultXMLPathSignatureOrigin, "")
+	f.Pkg.Store("_xmlsignatures/_rels/origin.sigs.rels", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddDigitalSignature(&DigitalSignatureOptions{Certificate: rsaCert, PrivateKey: rsaKey}), "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.GetDigitalSignatures()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test save workbook with unsupported charset content types and pending signature
+	f = NewFile()
+	assert.NoError(t, f.AddDigitalSignature(&DigitalSignatureOptions{Certificate: rsaCert, PrivateKey: rsaKey}))
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.signaturesWriter(), "XML syntax error on line 1: invalid UTF-8")
+	// Test save workbook with invalid relationships part and pending signature
+	f = NewFile()
+	assert.NoError(t, f.AddDigitalSignature(&DigitalSignatureOptions{Certificate: rsaCert, PrivateKey: rsaKey}))
+	f.Pkg.Store("xl/_rels/workbook.xml.rels", []byte("<Relationships"))
+	assert.EqualError(t, f.signaturesWriter(), "XML syntax error on line 1: unexpected EOF")
+}
+
+func TestVerifySignature(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	f := NewFile()
+	assert.NoError(t, f.AddDigitalSignature(&DigitalSignatureOptions{Certificate: newTestCertificate(t, key), PrivateKey: key}))
+	assert.NoError(t, f.signaturesWriter())
+	content := f.readXML("_xmlsignatures/sig1.xml")
+	signature, err := f.verifySignature(content)
+	assert.NoError(t, err)
+	assert.True(t, signature.Valid)
+
+	for _, c := range []struct {
+		old, new, err string
+	}{
+		{"<Signature ", "<Signature", "XML syntax error on line 2: expected attribute name in element"},
+		{"<X509Certificate>", "<X509Certificate>-", "illegal base64 data at input byte 0"},
+		{"<X509Certificate>", "<X509Certificate>AAAA", "x509: malformed certificate"},
+		{"xmldsig-more#rsa-sha256", "xmldsig-more#rsa-md5", ErrUnsupportedSignatureAlgorithm.Error()},
+		{"REC-xml-c14n-20010315\"></CanonicalizationMethod>", "REC-xml-c14n11\"></CanonicalizationMethod>", ErrUnsupportedSignatureAlgorithm.Error()},
+		{"REC-xml-c14n-20010315\"></Transform></Transforms><DigestMethod", "REC-xml-c14n11\"></Transform></Transforms><DigestMethod", ErrUnsupportedSignatureAlgorithm.Error()},
+		{"xmlenc#sha256", "xmldsig#md5", ErrUnsupportedHashAlgorithm.Error()},
+		{"<SignatureValue>", "<SignatureValue>-", "illegal base64 data at input byte 0"},
+	} {
+		_, err = f.verifySignature([]byte(strings.Replace(string(content), c.old, c.new, 1)))
+		assert.EqualError(t, err, c.err)
+	}
+	for _, c := range []struct{ old, new string }{
+		{"URI=\"#idOfficeObject\"", "URI=\"#idNotExists\""},
+		{"URI=\"/xl/workbook.xml", "URI=\"/xl/%zz"},
+		{"<SignatureValue>", "<SignatureValue>AAAA"},
+		{"<SignatureComments></SignatureComments>", "<SignatureComments>Changed</SignatureComments>"},
+	} {
+		signature, err = f.verifySignature([]byte(strings.Replace(string(content), c.old, c.new, 1)))
+		assert.NoError(t, err)
+		assert.False(t, signature.Valid)
+	}
+	// Test verify signature without certificate
+	signature, err = f.verifySignature([]byte(`<Signature xmlns="http://www.w3.org/2000/09/xmldsig#"></Signature>`))
+	assert.NoError(t, err)
+	assert.Equal(t, DigitalSignature{}, signature)
+	// Test verify signature with invalid relationships part
+	f.Pkg.Store(defaultXMLPathRels, []byte("<Relationships"))
+	_, err = f.verifySignature(content)
+	assert.EqualError(t, err, "XML syntax error on line 1: unexpected EOF")
+	// Test verify signature with unsupported public key
+	assert.False(t, verifySignatureValue(ed25519.PublicKey{}, crypto.SHA256, nil, nil))
+}
+
+func TestCanonicalizeXML(t *testing.T) {
+	content := []byte(`<?xml version="1.0"?><!-- comment --><a:r xmlns:a="urn:a" xmlns="urn:d" xmlns:b="urn:b"><a:x b:z="1" y="2" a:w="3" xml:lang="en" xmlns:c="urn:c"><?pi data?><!-- comment --><c:q xmlns="">&lt;&amp;&gt;&#13;</c:q><c:q a:t="&quot;&#9;&#10;"/></a:x></a:r>`)
+	matchX := func(start xml.StartElement) bool { return start.Name.Local == "x" }
+	canonical, err := canonicalizeXML(content, matchX, false)
+	assert.NoError(t, err)
+	assert.Equal(t, `<a:x xmlns="urn:d" xmlns:a="urn:a" xmlns:b="urn:b" xmlns:c="urn:c" y="2" xml:lang="en" a:w="3" b:z="1"><?pi data?><c:q xmlns="">&lt;&amp;&gt;&#xD;</c:q><c:q a:t="&quot;&#x9;&#xA;"></c:q></a:x>`, string(canonical))
+	canonical, err = canonicalizeXML(content, matchX, true)
+	assert.NoError(t, err)
+	assert.Equal(t, `<a:x xmlns:a="urn:a" xmlns:b="urn:b" y="2" xml:lang="en" a:w="3" b:z="1"><?pi data?><c:q xmlns:c="urn:c">&lt;&amp;&gt;&#xD;</c:q><c:q xmlns:c="urn:c" a:t="&quot;&#x9;&#xA;"></c:q></a:x>`, string(canonical))
+	canonical, err = canonicalizeXML(content, nil, false)
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(canonical, []byte(`<a:r xmlns="urn:d" xmlns:a="urn:a" xmlns:b="urn:b">`)))
+	// Test canonicalize XML without matched element
+	canonical, err = canonicalizeXML(content, matchSignedInfo, false)
+	assert.NoError(t, err)
+	assert.Nil(t, canonical)
+	canonical, err = canonicalizeXML([]byte("</a>"), nil, false)
+	assert.NoError(t, err)
+	assert.Nil(t, canonical)
+	// Test canonicalize XML with invalid content
+	_, err = canonicalizeXML([]byte("<a"), nil, false)
+	assert.EqualError(t, err, "XML syntax error on line 1: unexpected EOF")
+}
+
+func TestRelationshipTransform(t *testing.T) {
+	content := []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId2" Type="urn:b" Target="https://example.com/?a&amp;b" TargetMode="External"/><Relationship Id="rId1" Type="urn:a" Target="a.xml"/><Relationship Id="rId3" Type="urn:c" Target="c.xml"/></Relationships>`)
+	transformed, err := relationshipTransform(content, []string{"rId2"}, []string{"urn:a"})
+	assert.NoError(t, err)
+	assert.Equal(t, `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Target="a.xml" TargetMode="Internal" Type="urn:a"></Relationship><Relationship Id="rId2" Target="https://example.com/?a&amp;b" TargetMode="External" Type="urn:b"></Relationship></Relationships>`, string(transformed))
+	_, err = relationshipTransform([]byte("<Relationships"), nil, nil)
+	assert.EqualError(t, err, "XML syntax error on line 1: unexpected EOF")
+}
@@ -87,6 +87,18 @@ type SlicerOptions struct {
 //	    Width:      200,
 //	    Height:     200,
 //	})
+//
+// Insert a slicer on the Sheet1!H1 with the field Region for the pivot table
+// named PivotTable1, the slicer cache, the pivot cache definition extensions
+// and the drawing anchor will be created:
+//
+//	err := f.AddSlicer("Sheet1", &excelize.SlicerOptions{
+//	    Name:       "Region",
+//	    Cell:       "H1",
+//	    TableSheet: "Sheet1",
+//	    TableName:  "PivotTable1",
+//	    Caption:    "Region",
+//	})
 func (f *File) AddSlicer(sheet string, opts *SlicerOptions) error {
 	opts, err := parseSlicerOptions(opts)
 	if err != nil {
@@ -363,7 +375,7 @@ func (f *File) genSlicerCacheName(name string) string {
 		slicerCacheName string
 	)
 	for _, dn := range f.GetDefinedName() {
-		if dn.Scope == "Workbook" {
+		if dn.Scope == definedNameScopeWorkbook {
 			definedNames = append(definedNames, dn.Name)
 		}
 	}
@@ -642,8 +654,8 @@ func (f *File) addDrawingSlicer(sheet, slicerName string, ns xml.Attr, opts *Sli
 		TxBody: &xdrTxBody{
 			BodyPr: &aBodyPr{VertOverflow: "clip", HorzOverflow: "clip"},
 			P: []*aP{
-				{R: &aR{T: "This shape represents a table slicer. Table slicers are not supported in this version of Excel."}},
-				{R: &aR{T: "If the shape was modified in an earlier version of Excel, or if the workbook was saved in Excel 2007 or earlier, the slicer can't be used."}},
+				{R: []*aR{{T: "This shape represents a table slicer. Table slicers are not supported in this version of Excel."}}},
+				{R: []*aR{{T: "If the shape was modified in an earlier version of Excel, or if the workbook was saved in Excel 2007 or earlier, the slicer can't be used."}}},
 			},
 		},
 	}
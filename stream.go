@@ -29,7 +29,6 @@ type StreamWriter struct {
 	Sheet           string
 	SheetID         int
 	sheetWritten    bool
-	cols            strings.Builder
 	worksheet       *xlsxWorksheet
 	rawData         bufferedWriter
 	rows            int
@@ -452,17 +451,155 @@ func (sw *StreamWriter) SetColWidth(minVal, maxVal int, width float64) error {
 	if minVal > maxVal {
 		minVal, maxVal = maxVal, minVal
 	}
+	sw.setCols(xlsxCol{
+		Min:         minVal,
+		Max:         maxVal,
+		Width:       float64Ptr(width),
+		CustomWidth: true,
+	}, func(fc, c xlsxCol) xlsxCol {
+		fc.BestFit = c.BestFit
+		fc.Collapsed = c.Collapsed
+		fc.Hidden = c.Hidden
+		fc.OutlineLevel = c.OutlineLevel
+		fc.Phonetic = c.Phonetic
+		fc.Style = c.Style
+		return fc
+	})
+	return nil
+}
+
+// SetColStyle provides a function to set the style of a single column or
+// multiple columns for the StreamWriter. Note that you must call
+// the 'SetColStyle' function before the 'SetRow' function. For example set
+// style of column H:
+//
+//	err := sw.SetColStyle(8, 8, style)
+func (sw *StreamWriter) SetColStyle(minVal, maxVal, styleID int) error {
+	if sw.sheetWritten {
+		return ErrStreamSetColStyle
+	}
+	minVal, maxVal, err := checkStreamColRange(minVal, maxVal)
+	if err != nil {
+		return err
+	}
+	s, err := sw.file.stylesReader()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	if styleID < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
+		s.mu.Unlock()
+		return newInvalidStyleID(styleID)
+	}
+	s.mu.Unlock()
+	sw.setCols(xlsxCol{
+		Min:   minVal,
+		Max:   maxVal,
+		Width: float64Ptr(defaultColWidth),
+		Style: styleID,
+	}, func(fc, c xlsxCol) xlsxCol {
+		fc.BestFit = c.BestFit
+		fc.Collapsed = c.Collapsed
+		fc.CustomWidth = c.CustomWidth
+		fc.Hidden = c.Hidden
+		fc.OutlineLevel = c.OutlineLevel
+		fc.Phonetic = c.Phonetic
+		fc.Width = c.Width
+		return fc
+	})
+	return nil
+}
 
-	sw.cols.WriteString(`<col min="`)
-	sw.cols.WriteString(strconv.Itoa(minVal))
-	sw.cols.WriteString(`" max="`)
-	sw.cols.WriteString(strconv.Itoa(maxVal))
-	sw.cols.WriteString(`" width="`)
-	sw.cols.WriteString(strconv.FormatFloat(width, 'f', -1, 64))
-	sw.cols.WriteString(`" customWidth="1"/>`)
+// SetColVisible provides a function to set the visible of a single column or
+// multiple columns for the StreamWriter. Note that you must call
+// the 'SetColVisible' function before the 'SetRow' function. For example
+// hide column D:F:
+//
+//	err := sw.SetColVisible(4, 6, false)
+func (sw *StreamWriter) SetColVisible(minVal, maxVal int, visible bool) error {
+	if sw.sheetWritten {
+		return ErrStreamSetColVisible
+	}
+	minVal, maxVal, err := checkStreamColRange(minVal, maxVal)
+	if err != nil {
+		return err
+	}
+	sw.setCols(xlsxCol{
+		Min:         minVal,
+		Max:         maxVal,
+		Width:       float64Ptr(defaultColWidth),
+		Hidden:      !visible,
+		CustomWidth: true,
+	}, func(fc, c xlsxCol) xlsxCol {
+		fc.BestFit = c.BestFit
+		fc.Collapsed = c.Collapsed
+		fc.CustomWidth = c.CustomWidth
+		fc.OutlineLevel = c.OutlineLevel
+		fc.Phonetic = c.Phonetic
+		fc.Style = c.Style
+		fc.Width = c.Width
+		return fc
+	})
 	return nil
 }
 
+// SetColOutlineLevel provides a function to set outline level of a single
+// column or multiple columns for the StreamWriter. The value of parameter
+// 'level' is 1-7. Note that you must call the 'SetColOutlineLevel' function
+// before the 'SetRow' function. For example, set outline level of column B:D
+// to 2:
+//
+//	err := sw.SetColOutlineLevel(2, 4, 2)
+func (sw *StreamWriter) SetColOutlineLevel(minVal, maxVal int, level uint8) error {
+	if sw.sheetWritten {
+		return ErrStreamSetColOutlineLevel
+	}
+	if level > 7 || level < 1 {
+		return ErrOutlineLevel
+	}
+	minVal, maxVal, err := checkStreamColRange(minVal, maxVal)
+	if err != nil {
+		return err
+	}
+	sw.setCols(xlsxCol{
+		Min:          minVal,
+		Max:          maxVal,
+		OutlineLevel: level,
+	}, func(fc, c xlsxCol) xlsxCol {
+		fc.BestFit = c.BestFit
+		fc.Collapsed = c.Collapsed
+		fc.CustomWidth = c.CustomWidth
+		fc.Hidden = c.Hidden
+		fc.Phonetic = c.Phonetic
+		fc.Style = c.Style
+		fc.Width = c.Width
+		return fc
+	})
+	return nil
+}
+
+// checkStreamColRange checks the column range for the StreamWriter column
+// settings, and returns the column numbers in ascending order.
+func checkStreamColRange(minVal, maxVal int) (int, int, error) {
+	if minVal < MinColumns || minVal > MaxColumns || maxVal < MinColumns || maxVal > MaxColumns {
+		return minVal, maxVal, ErrColumnNumber
+	}
+	if minVal > maxVal {
+		minVal, maxVal = maxVal, minVal
+	}
+	return minVal, maxVal, nil
+}
+
+// setCols merges the given column settings into the columns of the
+// StreamWriter, the columns will be written before the sheet data.
+func (sw *StreamWriter) setCols(col xlsxCol, replacer func(fc, c xlsxCol) xlsxCol) {
+	if sw.worksheet.Cols == nil {
+		sw.worksheet.Cols = &xlsxCols{Col: []xlsxCol{col}}
+		return
+	}
+	sw.worksheet.Cols.Col = flatCols(col, sw.worksheet.Cols.Col, replacer)
+}
+
 // InsertPageBreak creates a page break to determine where the printed page ends
 // and where begins the next one by a given cell reference, the content before
 // the page break will be printed on one page and after the page break on
@@ -642,10 +779,9 @@ func writeCell(buf *bufferedWriter, c xlsxC) {
 func (sw *StreamWriter) writeSheetData() {
 	if !sw.sheetWritten {
 		bulkAppendFields(&sw.rawData, sw.worksheet, 4, 5)
-		if sw.cols.Len() > 0 {
-			_, _ = sw.rawData.WriteString("<cols>")
-			_, _ = sw.rawData.WriteString(sw.cols.String())
-			_, _ = sw.rawData.WriteString("</cols>")
+		if sw.worksheet.Cols != nil && len(sw.worksheet.Cols.Col) > 0 {
+			sw.file.mergeExpandedCols(sw.worksheet)
+			bulkAppendFields(&sw.rawData, sw.worksheet, 6, 6)
 		}
 		_, _ = sw.rawData.WriteString(`<sheetData>`)
 		sw.sheetWritten = true
@@ -169,6 +169,66 @@ func TestStreamSetColWidth(t *testing.T) {
 	assert.Equal(t, ErrStreamSetColWidth, streamWriter.SetColWidth(2, 3, 20))
 }
 
+func TestStreamSetColStyleVisibleOutlineLevel(t *testing.T) {
+	file := NewFile()
+	styleID, err := file.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	streamWriter, err := file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.SetColWidth(1, 4, 20))
+	assert.NoError(t, streamWriter.SetColStyle(4, 2, styleID))
+	assert.NoError(t, streamWriter.SetColVisible(3, 3, false))
+	assert.NoError(t, streamWriter.SetColOutlineLevel(4, 5, 2))
+	// Test set column style, visible and outline level with invalid parameters
+	assert.Equal(t, ErrColumnNumber, streamWriter.SetColStyle(0, 3, styleID))
+	assert.Equal(t, newInvalidStyleID(10), streamWriter.SetColStyle(1, 3, 10))
+	assert.Equal(t, ErrColumnNumber, streamWriter.SetColVisible(MaxColumns+1, 3, false))
+	assert.Equal(t, ErrColumnNumber, streamWriter.SetColOutlineLevel(0, 3, 1))
+	assert.Equal(t, ErrOutlineLevel, streamWriter.SetColOutlineLevel(1, 3, 8))
+	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{"A", "B", "C", "D", "E"}))
+	assert.Equal(t, ErrStreamSetColStyle, streamWriter.SetColStyle(2, 3, styleID))
+	assert.Equal(t, ErrStreamSetColVisible, streamWriter.SetColVisible(2, 3, true))
+	assert.Equal(t, ErrStreamSetColOutlineLevel, streamWriter.SetColOutlineLevel(2, 3, 1))
+	assert.NoError(t, streamWriter.Flush())
+	assert.NoError(t, file.SaveAs(filepath.Join("test", "TestStreamSetColStyle.xlsx")))
+	assert.NoError(t, file.Close())
+
+	file, err = OpenFile(filepath.Join("test", "TestStreamSetColStyle.xlsx"))
+	assert.NoError(t, err)
+	width, err := file.GetColWidth("Sheet1", "A")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	width, err = file.GetColWidth("Sheet1", "E")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultColWidth, width)
+	for col, expected := range map[string]int{"A": 0, "B": styleID, "D": styleID, "E": 0} {
+		style, err := file.GetColStyle("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, style, col)
+	}
+	visible, err := file.GetColVisible("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	visible, err = file.GetColVisible("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.True(t, visible)
+	for col, expected := range map[string]uint8{"C": 0, "D": 2, "E": 2} {
+		level, err := file.GetColOutlineLevel("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, level, col)
+	}
+	assert.NoError(t, file.Close())
+
+	// Test set column style with unsupported charset style sheet
+	file = NewFile()
+	streamWriter, err = file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	file.Styles = nil
+	file.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, streamWriter.SetColStyle(1, 1, 0), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, file.Close())
+}
+
 func TestStreamSetPanes(t *testing.T) {
 	file, paneOpts := NewFile(), &Panes{
 		Freeze:      true,
@@ -21,6 +21,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 // stylesReader provides a function to get the pointer to the structure after
@@ -144,7 +145,19 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 // options, and returns style index. The same style index can not be used
 // across different workbook. This function is concurrency safe. Note that
 // the 'Font.Color' field uses an RGB color represented in 'RRGGBB' hexadecimal
-// notation.
+// notation. The 'Font.ColorTheme' field and each item of the 'Fill.ColorTheme'
+// field specify the zero-based index of the theme colors, and the tint value
+// applied to these colors can be specified by the 'Font.ColorTint' and
+// 'Fill.ColorTint' fields. For example, create a style with the fill color of
+// the theme color "Accent 1", lighter 40%:
+//
+//	accent1 := 4
+//	style, err := f.NewStyle(&excelize.Style{
+//	    Fill: excelize.Fill{
+//	        Type: "pattern", Pattern: 1,
+//	        ColorTheme: []*int{&accent1}, ColorTint: []float64{0.4},
+//	    },
+//	})
 //
 // The following table shows the border types used in 'Border.Type' supported by
 // excelize:
@@ -200,6 +213,12 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 //	 3-5   | Vertical        | 12-15 | From corner
 //	 6-8   | Diagonal Up     | 16    | From center
 //
+// The shading styles require two colors in the 'Fill.Color' field. To create a
+// linear gradient fill with more than two colors or a custom angle, specify
+// the colors in the 'Fill.Color' field and the angle of the linear gradient in
+// degrees in the 'Fill.Degree' field, the colors will be evenly distributed
+// along the gradient and the 'Fill.Shading' field will be ignored.
+//
 // The following table shows the pattern styles used in 'Fill.Pattern' supported
 // by excelize index number:
 //
@@ -216,6 +235,10 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 //	 8     | darkUp          | 18    | gray0625
 //	 9     | darkGrid        |       |
 //
+// The first color in the 'Fill.Color' field is used as the foreground color of
+// the pattern fill, and the second color, if specified, is used as the
+// background color of the pattern fill.
+//
 // The 'Alignment.Indent' is an integer value, where an increment of 1
 // represents 3 spaces. Indicates the number of spaces (of the normal style
 // font) of indentation for text in a cell. The number of spaces to indent is
@@ -959,10 +982,9 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 // part, or only the positive part.
 func (f *File) NewStyle(style *Style) (int, error) {
 	var (
-		fs                                  *Style
-		font                                *xlsxFont
-		err                                 error
-		cellXfsID, fontID, borderID, fillID int
+		fs        *Style
+		err       error
+		cellXfsID int
 	)
 	if style == nil {
 		return cellXfsID, err
@@ -987,8 +1009,26 @@ func (f *File) NewStyle(style *Style) (int, error) {
 	if cellXfsID, err = f.getStyleID(s, fs); err != nil || cellXfsID != -1 {
 		return cellXfsID, err
 	}
+	xf, err := f.newXf(s, fs)
+	if err != nil {
+		return cellXfsID, err
+	}
+	return setCellXfs(s, xf)
+}
 
-	numFmtID := newNumFmt(s, fs)
+// newXf provides a function to create the number format, font, border and fill
+// records for the given style if not exist, and returns the formatting record
+// which references them.
+func (f *File) newXf(s *xlsxStyleSheet, fs *Style) (xlsxXf, error) {
+	var (
+		xf                       xlsxXf
+		font                     *xlsxFont
+		fontID, borderID, fillID int
+	)
+	numFmtID, err := newNumFmt(s, fs)
+	if err != nil {
+		return xf, err
+	}
 
 	if fs.Font != nil {
 		fontID, _ = f.getFontID(s, fs)
@@ -1021,12 +1061,51 @@ func (f *File) NewStyle(style *Style) (int, error) {
 		}
 	}
 
-	applyAlignment, alignment := fs.Alignment != nil, newAlignment(fs)
-	applyProtection, protection := fs.Protection != nil, newProtection(fs)
-	return setCellXfs(s, fontID, numFmtID, fillID, borderID, applyAlignment, applyProtection, alignment, protection)
+	xf.FontID = intPtr(fontID)
+	if fontID != 0 {
+		xf.ApplyFont = boolPtr(true)
+	}
+	xf.NumFmtID = intPtr(numFmtID)
+	if numFmtID != 0 {
+		xf.ApplyNumberFormat = boolPtr(true)
+	}
+	xf.FillID = intPtr(fillID)
+	if fillID != 0 {
+		xf.ApplyFill = boolPtr(true)
+	}
+	xf.BorderID = intPtr(borderID)
+	if borderID != 0 {
+		xf.ApplyBorder = boolPtr(true)
+	}
+	if xf.Alignment = newAlignment(fs); xf.Alignment != nil {
+		xf.ApplyAlignment = boolPtr(fs.Alignment != nil)
+	}
+	if fs.Protection != nil {
+		xf.ApplyProtection = boolPtr(true)
+		xf.Protection = newProtection(fs)
+	}
+	return xf, err
 }
 
 var (
+	// builtInCellStyles defined the built-in cell style names and the
+	// corresponding built-in cell style IDs.
+	builtInCellStyles = map[string]int{
+		"Normal": 0, "Comma": 3, "Currency": 4, "Percent": 5, "Comma [0]": 6,
+		"Currency [0]": 7, "Hyperlink": 8, "Followed Hyperlink": 9, "Note": 10,
+		"Warning Text": 11, "Title": 15, "Heading 1": 16, "Heading 2": 17,
+		"Heading 3": 18, "Heading 4": 19, "Input": 20, "Output": 21,
+		"Calculation": 22, "Check Cell": 23, "Linked Cell": 24, "Total": 25,
+		"Good": 26, "Bad": 27, "Neutral": 28, "Accent1": 29, "20% - Accent1": 30,
+		"40% - Accent1": 31, "60% - Accent1": 32, "Accent2": 33,
+		"20% - Accent2": 34, "40% - Accent2": 35, "60% - Accent2": 36,
+		"Accent3": 37, "20% - Accent3": 38, "40% - Accent3": 39,
+		"60% - Accent3": 40, "Accent4": 41, "20% - Accent4": 42,
+		"40% - Accent4": 43, "60% - Accent4": 44, "Accent5": 45,
+		"20% - Accent5": 46, "40% - Accent5": 47, "60% - Accent5": 48,
+		"Accent6": 49, "20% - Accent6": 50, "40% - Accent6": 51,
+		"60% - Accent6": 52, "Explanatory Text": 53,
+	}
 	// styleBorders list all types of the cell border style.
 	styleBorders = []string{
 		"none",
@@ -1393,16 +1472,18 @@ func (f *File) GetBaseColor(hexColor string, indexedColor int, themeColor *int)
 	if f.Theme != nil && themeColor != nil {
 		clrScheme := f.Theme.ThemeElements.ClrScheme
 		if val, ok := map[int]*string{
-			0: clrScheme.Lt1.colorChoice(),
-			1: clrScheme.Dk1.colorChoice(),
-			2: clrScheme.Lt2.colorChoice(),
-			3: clrScheme.Dk2.colorChoice(),
-			4: clrScheme.Accent1.colorChoice(),
-			5: clrScheme.Accent2.colorChoice(),
-			6: clrScheme.Accent3.colorChoice(),
-			7: clrScheme.Accent4.colorChoice(),
-			8: clrScheme.Accent5.colorChoice(),
-			9: clrScheme.Accent6.colorChoice(),
+			0:  clrScheme.Lt1.colorChoice(),
+			1:  clrScheme.Dk1.colorChoice(),
+			2:  clrScheme.Lt2.colorChoice(),
+			3:  clrScheme.Dk2.colorChoice(),
+			4:  clrScheme.Accent1.colorChoice(),
+			5:  clrScheme.Accent2.colorChoice(),
+			6:  clrScheme.Accent3.colorChoice(),
+			7:  clrScheme.Accent4.colorChoice(),
+			8:  clrScheme.Accent5.colorChoice(),
+			9:  clrScheme.Accent6.colorChoice(),
+			10: clrScheme.Hlink.colorChoice(),
+			11: clrScheme.FolHlink.colorChoice(),
 		}[*themeColor]; ok && val != nil {
 			return *val
 		}
@@ -1423,6 +1504,32 @@ func (f *File) GetBaseColor(hexColor string, indexedColor int, themeColor *int)
 	return hexColor
 }
 
+// GetThemeColor provides a function to get the RGB color in 'RRGGBB'
+// hexadecimal notation by given zero-based index of the theme colors and the
+// tint value applied to the color. The index 0 to 11 represents the theme
+// colors "Light 1", "Dark 1", "Light 2", "Dark 2", "Accent 1" to "Accent 6",
+// "Hyperlink" and "Followed Hyperlink". The tint value should be in the range
+// of -1 to 1, the negative value darkens the color and the positive value
+// lightens the color. For example, get the theme color "Accent 1", lighter
+// 40%:
+//
+//	color, err := f.GetThemeColor(4, 0.4)
+func (f *File) GetThemeColor(index int, tint float64) (string, error) {
+	if index < 0 || index > 11 || tint < -1 || tint > 1 {
+		return "", ErrParameterInvalid
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Theme == nil {
+		var err error
+		if f.Theme, err = f.themeReader(); err != nil {
+			f.Theme = nil
+			return "", err
+		}
+	}
+	return f.getThemeColor(&xlsxColor{Theme: &index, Tint: tint}), nil
+}
+
 // getThemeColor provides a function to convert theme color or index color to
 // RGB color.
 func (f *File) getThemeColor(clr *xlsxColor) string {
@@ -1474,35 +1581,77 @@ func (f *File) extractFills(fl *xlsxFill, s *xlsxStyleSheet, style *Style) {
 		var fill Fill
 		if fl.GradientFill != nil {
 			fill.Type = "gradient"
-			for shading, variants := range styleFillVariants() {
-				if fl.GradientFill.Bottom == variants.Bottom &&
-					fl.GradientFill.Degree == variants.Degree &&
-					fl.GradientFill.Left == variants.Left &&
-					fl.GradientFill.Right == variants.Right &&
-					fl.GradientFill.Top == variants.Top &&
-					fl.GradientFill.Type == variants.Type {
-					fill.Shading = shading
-					break
-				}
-			}
 			for _, stop := range fl.GradientFill.Stop {
 				fill.Color = append(fill.Color, f.getThemeColor(&stop.Color))
+				fill.ColorTheme = append(fill.ColorTheme, stop.Color.Theme)
+				fill.ColorTint = append(fill.ColorTint, stop.Color.Tint)
+			}
+			if shading := getFillShading(fl.GradientFill); shading != -1 {
+				fill.Shading = shading
+				if len(fill.Color) > 2 {
+					fill.Color, fill.ColorTheme, fill.ColorTint = fill.Color[:2], fill.ColorTheme[:2], fill.ColorTint[:2]
+				}
+			} else if fl.GradientFill.Type != "path" {
+				fill.Degree = float64Ptr(fl.GradientFill.Degree)
 			}
 		}
 		if fl.PatternFill != nil {
 			fill.Type = "pattern"
 			fill.Pattern = inStrSlice(styleFillPatterns, fl.PatternFill.PatternType, false)
-			if fl.PatternFill.BgColor != nil {
-				fill.Color = []string{f.getThemeColor(fl.PatternFill.BgColor)}
+			colors := []*xlsxColor{fl.PatternFill.FgColor}
+			if fl.PatternFill.FgColor == nil {
+				colors = []*xlsxColor{fl.PatternFill.BgColor}
+			} else if fl.PatternFill.BgColor != nil && fill.Pattern != 1 {
+				colors = append(colors, fl.PatternFill.BgColor)
 			}
-			if fl.PatternFill.FgColor != nil {
-				fill.Color = []string{f.getThemeColor(fl.PatternFill.FgColor)}
+			for _, clr := range colors {
+				if clr != nil {
+					fill.Color = append(fill.Color, f.getThemeColor(clr))
+					fill.ColorTheme, fill.ColorTint = append(fill.ColorTheme, clr.Theme), append(fill.ColorTint, clr.Tint)
+				}
 			}
 		}
+		extractFillThemeColors(&fill)
 		style.Fill = fill
 	}
 }
 
+// getFillShading provides a function to get the index of the gradient fill
+// shading styles by given gradient fill definition. If the gradient fill
+// doesn't match any of the shading styles, will return -1.
+func getFillShading(gradient *xlsxGradientFill) int {
+	for shading, variants := range styleFillVariants() {
+		if gradient.Bottom != variants.Bottom || gradient.Degree != variants.Degree ||
+			gradient.Left != variants.Left || gradient.Right != variants.Right ||
+			gradient.Top != variants.Top || gradient.Type != variants.Type ||
+			len(gradient.Stop) != len(variants.Stop) {
+			continue
+		}
+		matched := true
+		for i, stop := range gradient.Stop {
+			matched = matched && stop.Position == variants.Stop[i].Position
+		}
+		if len(gradient.Stop) == 3 {
+			matched = matched && reflect.DeepEqual(gradient.Stop[0].Color, gradient.Stop[2].Color)
+		}
+		if matched {
+			return shading
+		}
+	}
+	return -1
+}
+
+// extractFillThemeColors provides a function to omit the theme colors and
+// tint values of the fill if none of the fill colors based on the theme color.
+func extractFillThemeColors(fill *Fill) {
+	for i := range fill.ColorTheme {
+		if fill.ColorTheme[i] != nil || fill.ColorTint[i] != 0 {
+			return
+		}
+	}
+	fill.ColorTheme, fill.ColorTint = nil, nil
+}
+
 // extractFont provides a function to extract font styles settings by given
 // font styles definition.
 func (f *File) extractFont(fnt *xlsxFont, s *xlsxStyleSheet, style *Style) {
@@ -1530,6 +1679,9 @@ func (f *File) extractFont(fnt *xlsxFont, s *xlsxStyleSheet, style *Style) {
 		}
 		if fnt.Color != nil {
 			font.Color = strings.TrimPrefix(fnt.Color.RGB, "FF")
+			if font.Color == "" && fnt.Color.Theme != nil {
+				font.Color = f.getThemeColor(fnt.Color)
+			}
 			font.ColorIndexed = fnt.Color.Indexed
 			font.ColorTheme = fnt.Color.Theme
 			font.ColorTint = fnt.Color.Tint
@@ -1594,10 +1746,11 @@ func (f *File) extractAlignment(a *xlsxAlignment, s *xlsxStyleSheet, style *Styl
 }
 
 // extractProtection provides a function to extract protection settings by
-// given format definition.
+// given format definition. The cell is locked by default if the locked
+// attribute is omitted.
 func (f *File) extractProtection(p *xlsxProtection, s *xlsxStyleSheet, style *Style) {
 	if p != nil {
-		style.Protection = &Protection{}
+		style.Protection = &Protection{Locked: true}
 		if p.Hidden != nil {
 			style.Protection.Hidden = *p.Hidden
 		}
@@ -1608,11 +1761,38 @@ func (f *File) extractProtection(p *xlsxProtection, s *xlsxStyleSheet, style *St
 }
 
 // GetStyle provides a function to get style definition by given style index.
+// The font, fill, border, alignment, number format and protection settings
+// are resolved from the style sheet, and the attributes inherited from the
+// named cell style are included. The returned style definition can be
+// modified and passed to the NewStyle function to create a new style. For
+// example, copy the style of the cell Sheet1!A1 with bold font and apply it
+// for the cell Sheet1!B1:
+//
+//	styleID, err := f.GetCellStyle("Sheet1", "A1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	style, err := f.GetStyle(styleID)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if style.Font == nil {
+//	    style.Font = &excelize.Font{}
+//	}
+//	style.Font.Bold = true
+//	if styleID, err = f.NewStyle(style); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.SetCellStyle("Sheet1", "B1", "B1", styleID)
 func (f *File) GetStyle(idx int) (*Style, error) {
 	var style *Style
 	f.mu.Lock()
 	s, err := f.stylesReader()
 	if err != nil {
+		f.mu.Unlock()
 		return style, err
 	}
 	f.mu.Unlock()
@@ -1620,7 +1800,7 @@ func (f *File) GetStyle(idx int) (*Style, error) {
 		return style, newInvalidStyleID(idx)
 	}
 	style = &Style{}
-	xf := s.CellXfs.Xf[idx]
+	xf := s.resolveXf(s.CellXfs.Xf[idx])
 	if extractStyleCondFuncs["fill"](xf, s) {
 		f.extractFills(s.Fills.Fill[*xf.FillID], s, style)
 	}
@@ -1640,6 +1820,167 @@ func (f *File) GetStyle(idx int) (*Style, error) {
 	return style, nil
 }
 
+// resolveXf provides a function to resolve the formatting record of the cell
+// by inheriting the number format, font, fill, border, alignment and
+// protection which not specified or not applied in the cell formatting record
+// from the named cell style it based on. The attributes of the "Normal" cell
+// style are the defaults of the workbook, and won't be inherited.
+func (s *xlsxStyleSheet) resolveXf(xf xlsxXf) xlsxXf {
+	if xf.XfID == nil || *xf.XfID <= 0 || s.CellStyleXfs == nil || *xf.XfID >= len(s.CellStyleXfs.Xf) {
+		return xf
+	}
+	parent := s.CellStyleXfs.Xf[*xf.XfID]
+	inherit := func(ID *int, apply *bool) bool {
+		return ID == nil || (apply != nil && !*apply)
+	}
+	if inherit(xf.NumFmtID, xf.ApplyNumberFormat) {
+		xf.NumFmtID, xf.ApplyNumberFormat = parent.NumFmtID, parent.ApplyNumberFormat
+	}
+	if inherit(xf.FontID, xf.ApplyFont) {
+		xf.FontID, xf.ApplyFont = parent.FontID, parent.ApplyFont
+	}
+	if inherit(xf.FillID, xf.ApplyFill) {
+		xf.FillID, xf.ApplyFill = parent.FillID, parent.ApplyFill
+	}
+	if inherit(xf.BorderID, xf.ApplyBorder) {
+		xf.BorderID, xf.ApplyBorder = parent.BorderID, parent.ApplyBorder
+	}
+	if xf.Alignment == nil || (xf.ApplyAlignment != nil && !*xf.ApplyAlignment) {
+		xf.Alignment, xf.ApplyAlignment = parent.Alignment, parent.ApplyAlignment
+	}
+	if xf.Protection == nil || (xf.ApplyProtection != nil && !*xf.ApplyProtection) {
+		xf.Protection, xf.ApplyProtection = parent.Protection, parent.ApplyProtection
+	}
+	return xf
+}
+
+// NewNamedStyle provides a function to create a named cell style by given
+// cell style name and style definition, and returns the style index which
+// applies the named cell style, the returned index can be used with the
+// SetCellStyle function. The named cell style will be listed in the cell
+// styles gallery of the spreadsheet application, and the name of the built-in
+// cell styles such as "Good", "Bad", "Neutral" and "Heading 1" can be used to
+// customize the built-in cell styles. The style definition is the same with
+// the NewStyle function. For example, create a named cell style "Heading 1"
+// and apply it for the cell Sheet1!A1:
+//
+//	style, err := f.NewNamedStyle("Heading 1", &excelize.Style{
+//	    Font:   &excelize.Font{Bold: true, Size: 15, Color: "44546A"},
+//	    Border: []excelize.Border{{Type: "bottom", Color: "4472C4", Style: 5}},
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.SetCellStyle("Sheet1", "A1", "A1", style)
+func (f *File) NewNamedStyle(name string, style *Style) (int, error) {
+	if name == "" {
+		return 0, ErrParameterRequired
+	}
+	if utf8.RuneCountInString(name) > MaxFieldLength {
+		return 0, ErrNameLength
+	}
+	if style == nil {
+		style = &Style{}
+	}
+	fs, err := parseFormatStyleSet(style)
+	if err != nil {
+		return 0, err
+	}
+	if fs.DecimalPlaces != nil && (*fs.DecimalPlaces < 0 || *fs.DecimalPlaces > 30) {
+		fs.DecimalPlaces = intPtr(2)
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return 0, err
+	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.CellStyles == nil {
+		s.CellStyles = &xlsxCellStyles{}
+	}
+	for _, cellStyle := range s.CellStyles.CellStyle {
+		if strings.EqualFold(cellStyle.Name, name) {
+			return 0, ErrExistsNamedStyle
+		}
+	}
+	if s.CellStyleXfs == nil {
+		s.CellStyleXfs = &xlsxCellStyleXfs{}
+	}
+	xf, err := f.newXf(s, fs)
+	if err != nil {
+		return 0, err
+	}
+	// Append the cell style formatting record and the cell style only after
+	// the cell formatting record which applies them has been created
+	xfID, cellXf := len(s.CellStyleXfs.Xf), xf
+	cellXf.XfID = intPtr(xfID)
+	styleID, err := setCellXfs(s, cellXf)
+	if err != nil {
+		return styleID, err
+	}
+	s.CellStyleXfs.Xf = append(s.CellStyleXfs.Xf, xf)
+	s.CellStyleXfs.Count = len(s.CellStyleXfs.Xf)
+	cellStyle := &xlsxCellStyle{Name: name, XfID: xfID}
+	for builtInName, builtInID := range builtInCellStyles {
+		if strings.EqualFold(builtInName, name) {
+			cellStyle.Name, cellStyle.BuiltInID, cellStyle.CustomBuiltIn = builtInName, intPtr(builtInID), boolPtr(true)
+		}
+	}
+	s.CellStyles.CellStyle = append(s.CellStyles.CellStyle, cellStyle)
+	s.CellStyles.Count = len(s.CellStyles.CellStyle)
+	return styleID, err
+}
+
+// GetNamedStyleID provides a function to get the style index which applies the
+// named cell style by given cell style name, the returned index can be used
+// with the SetCellStyle function. This function can be used to apply the named
+// cell styles which already exist in the workbook, for example:
+//
+//	style, err := f.GetNamedStyleID("Good")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.SetCellStyle("Sheet1", "A1", "A1", style)
+func (f *File) GetNamedStyleID(name string) (int, error) {
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return 0, err
+	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	xfID := -1
+	if s.CellStyles != nil && s.CellStyleXfs != nil {
+		for _, cellStyle := range s.CellStyles.CellStyle {
+			if strings.EqualFold(cellStyle.Name, name) && cellStyle.XfID < len(s.CellStyleXfs.Xf) {
+				xfID = cellStyle.XfID
+				break
+			}
+		}
+	}
+	if xfID == -1 {
+		return 0, newNoExistNamedStyleError(name)
+	}
+	xf := s.CellStyleXfs.Xf[xfID]
+	xf.XfID = intPtr(xfID)
+	if s.CellXfs == nil {
+		s.CellXfs = &xlsxCellXfs{}
+	}
+	for idx, cellXf := range s.CellXfs.Xf {
+		if cellXf.XfID != nil && *cellXf.XfID == xfID && reflect.DeepEqual(cellXf, xf) {
+			return idx, err
+		}
+	}
+	return setCellXfs(s, xf)
+}
+
 // getStyleID provides a function to get styleID by given style. If given
 // style does not exist, will return -1.
 func (f *File) getStyleID(ss *xlsxStyleSheet, style *Style) (int, error) {
@@ -1659,6 +2000,9 @@ func (f *File) getStyleID(ss *xlsxStyleSheet, style *Style) (int, error) {
 		numFmtID = getCustomNumFmtID(ss, style)
 	}
 	for xfID, xf := range ss.CellXfs.Xf {
+		if xf.XfID != nil && *xf.XfID != 0 {
+			continue
+		}
 		if getXfIDFuncs["numFmt"](numFmtID, xf, style) &&
 			getXfIDFuncs["font"](fontID, xf, style) &&
 			getXfIDFuncs["fill"](fillID, xf, style) &&
@@ -1721,6 +2065,7 @@ func (f *File) GetConditionalStyle(idx int) (*Style, error) {
 	f.mu.Lock()
 	s, err := f.stylesReader()
 	if err != nil {
+		f.mu.Unlock()
 		return style, err
 	}
 	f.mu.Unlock()
@@ -1747,7 +2092,7 @@ func (f *File) GetConditionalStyle(idx int) (*Style, error) {
 // newDxfNumFmt provides a function to create number format for conditional
 // format styles.
 func newDxfNumFmt(styleSheet *xlsxStyleSheet, style *Style, dxf *xlsxDxf) *xlsxNumFmt {
-	dp, numFmtID := "0", 164 // Default custom number format code from 164.
+	dp := "0"
 	if style.DecimalPlaces != nil && *style.DecimalPlaces > 0 {
 		dp += "."
 		for i := 0; i < *style.DecimalPlaces; i++ {
@@ -1755,14 +2100,7 @@ func newDxfNumFmt(styleSheet *xlsxStyleSheet, style *Style, dxf *xlsxDxf) *xlsxN
 		}
 	}
 	if style.CustomNumFmt != nil {
-		if styleSheet.Dxfs != nil {
-			for _, d := range styleSheet.Dxfs.Dxfs {
-				if d != nil && d.NumFmt != nil && d.NumFmt.NumFmtID > numFmtID {
-					numFmtID = d.NumFmt.NumFmtID
-				}
-			}
-		}
-		return &xlsxNumFmt{NumFmtID: numFmtID + 1, FormatCode: *style.CustomNumFmt}
+		return &xlsxNumFmt{NumFmtID: getDxfNumFmtID(styleSheet, *style.CustomNumFmt), FormatCode: *style.CustomNumFmt}
 	}
 	numFmtCode, ok := builtInNumFmt[style.NumFmt]
 	if style.NumFmt > 0 && ok {
@@ -1778,7 +2116,45 @@ func newDxfNumFmt(styleSheet *xlsxStyleSheet, style *Style, dxf *xlsxDxf) *xlsxN
 	if style.NegRed {
 		fc = fc + ";[Red]" + fc
 	}
-	return &xlsxNumFmt{NumFmtID: numFmtID, FormatCode: fc}
+	return &xlsxNumFmt{NumFmtID: getDxfNumFmtID(styleSheet, fc), FormatCode: fc}
+}
+
+// getDxfNumFmtID provides a function to get the number format ID for the
+// conditional format styles by given number format code. The ID of the same
+// custom number format code will be reused.
+func getDxfNumFmtID(styleSheet *xlsxStyleSheet, code string) int {
+	if numFmtID := getCustomNumFmtID(styleSheet, &Style{CustomNumFmt: &code}); numFmtID != -1 {
+		return numFmtID
+	}
+	if styleSheet.Dxfs != nil {
+		for _, d := range styleSheet.Dxfs.Dxfs {
+			if d != nil && d.NumFmt != nil && d.NumFmt.FormatCode == code {
+				return d.NumFmt.NumFmtID
+			}
+		}
+	}
+	return getNumFmtMaxID(styleSheet) + 1
+}
+
+// getNumFmtMaxID provides a function to get the maximum custom number format
+// ID which used in the cell styles and the conditional format styles.
+func getNumFmtMaxID(styleSheet *xlsxStyleSheet) int {
+	numFmtID := 163 // Default custom number format code from 164.
+	if styleSheet.NumFmts != nil {
+		for _, numFmt := range styleSheet.NumFmts.NumFmt {
+			if numFmt != nil && numFmt.NumFmtID > numFmtID {
+				numFmtID = numFmt.NumFmtID
+			}
+		}
+	}
+	if styleSheet.Dxfs != nil {
+		for _, d := range styleSheet.Dxfs.Dxfs {
+			if d != nil && d.NumFmt != nil && d.NumFmt.NumFmtID > numFmtID {
+				numFmtID = d.NumFmt.NumFmtID
+			}
+		}
+	}
+	return numFmtID
 }
 
 // GetDefaultFont provides the default font name currently set in the
@@ -1900,6 +2276,65 @@ func (f *File) newFont(style *Style) (*xlsxFont, error) {
 	return &fnt, err
 }
 
+// NewNumFmt provides a function to register the custom number format code in
+// the workbook, and returns the number format ID. The ID of the built-in number
+// format will be returned if the given number format code is the same with a
+// built-in number format, and the ID of the existing custom number format will
+// be reused if the same number format code already exists. Note that the
+// number of custom number formats in a workbook is limited. The returned ID
+// can be applied by the 'NumFmt' field of the style definition, and it takes
+// precedence over the currency number format with the same ID. For example:
+//
+//	numFmtID, err := f.NewNumFmt("#,##0.000;[Red]-#,##0.000")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	style, err := f.NewStyle(&excelize.Style{NumFmt: numFmtID})
+func (f *File) NewNumFmt(code string) (int, error) {
+	if code == "" {
+		return 0, ErrCustomNumFmt
+	}
+	for numFmtID, numFmtCode := range builtInNumFmt {
+		if numFmtCode == code {
+			return numFmtID, nil
+		}
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return 0, err
+	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return setCustomNumFmt(s, code)
+}
+
+// GetNumFmts provides a function to get all custom number formats in the
+// workbook, and returns a map of number format ID and number format code.
+func (f *File) GetNumFmts() (map[int]string, error) {
+	numFmts := map[int]string{}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return numFmts, err
+	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.NumFmts != nil {
+		for _, numFmt := range s.NumFmts.NumFmt {
+			if numFmt != nil {
+				numFmts[numFmt.NumFmtID] = numFmt.FormatCode
+			}
+		}
+	}
+	return numFmts, err
+}
+
 // getNumFmtID provides a function to get number format code ID.
 // If given number format code does not exist, will return -1.
 func getNumFmtID(styleSheet *xlsxStyleSheet, style *Style) int {
@@ -1910,6 +2345,9 @@ func getNumFmtID(styleSheet *xlsxStyleSheet, style *Style) int {
 	if (27 <= style.NumFmt && style.NumFmt <= 36) || (50 <= style.NumFmt && style.NumFmt <= 81) {
 		return style.NumFmt
 	}
+	if inCustomNumFmts(styleSheet, style.NumFmt) {
+		return style.NumFmt
+	}
 	if fmtCode, ok := currencyNumFmt[style.NumFmt]; ok {
 		numFmtID = style.NumFmt
 		if styleSheet.NumFmts != nil {
@@ -1925,8 +2363,8 @@ func getNumFmtID(styleSheet *xlsxStyleSheet, style *Style) int {
 
 // newNumFmt provides a function to check if number format code in the range
 // of built-in values.
-func newNumFmt(styleSheet *xlsxStyleSheet, style *Style) int {
-	dp, numFmtID := "0", 164 // Default custom number format code from 164.
+func newNumFmt(styleSheet *xlsxStyleSheet, style *Style) (int, error) {
+	dp := "0"
 	if style.DecimalPlaces != nil && *style.DecimalPlaces > 0 {
 		dp += "."
 		for i := 0; i < *style.DecimalPlaces; i++ {
@@ -1934,15 +2372,15 @@ func newNumFmt(styleSheet *xlsxStyleSheet, style *Style) int {
 		}
 	}
 	if style.CustomNumFmt != nil {
-		if customNumFmtID := getCustomNumFmtID(styleSheet, style); customNumFmtID != -1 {
-			return customNumFmtID
-		}
-		return setCustomNumFmt(styleSheet, style)
+		return setCustomNumFmt(styleSheet, *style.CustomNumFmt)
+	}
+	if inCustomNumFmts(styleSheet, style.NumFmt) {
+		return style.NumFmt, nil
 	}
 	if _, ok := builtInNumFmt[style.NumFmt]; !ok {
 		fc, currency := currencyNumFmt[style.NumFmt]
 		if !currency {
-			return setLangNumFmt(style)
+			return setLangNumFmt(style), nil
 		}
 		if style.DecimalPlaces != nil {
 			fc = strings.ReplaceAll(fc, "0.00", dp)
@@ -1950,35 +2388,42 @@ func newNumFmt(styleSheet *xlsxStyleSheet, style *Style) int {
 		if style.NegRed {
 			fc = fc + ";[Red]" + fc
 		}
-		if styleSheet.NumFmts == nil {
-			styleSheet.NumFmts = &xlsxNumFmts{NumFmt: []*xlsxNumFmt{}}
-		} else {
-			numFmtID = styleSheet.NumFmts.NumFmt[len(styleSheet.NumFmts.NumFmt)-1].NumFmtID + 1
-		}
-		styleSheet.NumFmts.NumFmt = append(styleSheet.NumFmts.NumFmt, &xlsxNumFmt{
-			FormatCode: fc, NumFmtID: numFmtID,
-		})
-		styleSheet.NumFmts.Count++
-		return numFmtID
+		return setCustomNumFmt(styleSheet, fc)
 	}
-	return style.NumFmt
+	return style.NumFmt, nil
 }
 
-// setCustomNumFmt provides a function to set custom number format code.
-func setCustomNumFmt(styleSheet *xlsxStyleSheet, style *Style) int {
-	nf := xlsxNumFmt{NumFmtID: 163, FormatCode: *style.CustomNumFmt}
+// setCustomNumFmt provides a function to set custom number format code, the
+// ID of the same custom number format code will be reused.
+func setCustomNumFmt(styleSheet *xlsxStyleSheet, code string) (int, error) {
+	if numFmtID := getCustomNumFmtID(styleSheet, &Style{CustomNumFmt: &code}); numFmtID != -1 {
+		return numFmtID, nil
+	}
 	if styleSheet.NumFmts == nil {
 		styleSheet.NumFmts = &xlsxNumFmts{}
 	}
-	for _, numFmt := range styleSheet.NumFmts.NumFmt {
-		if numFmt != nil && nf.NumFmtID < numFmt.NumFmtID {
-			nf.NumFmtID = numFmt.NumFmtID
-		}
+	if len(styleSheet.NumFmts.NumFmt) >= MaxCustomNumFmts {
+		return 0, ErrNumFmts
 	}
-	nf.NumFmtID++
+	nf := xlsxNumFmt{NumFmtID: getNumFmtMaxID(styleSheet) + 1, FormatCode: code}
 	styleSheet.NumFmts.NumFmt = append(styleSheet.NumFmts.NumFmt, &nf)
 	styleSheet.NumFmts.Count = len(styleSheet.NumFmts.NumFmt)
-	return nf.NumFmtID
+	return nf.NumFmtID, nil
+}
+
+// inCustomNumFmts provides a function to check if the given number format ID
+// exists in the custom number formats of the style sheet, such as the ID
+// returned by the NewNumFmt function.
+func inCustomNumFmts(styleSheet *xlsxStyleSheet, ID int) bool {
+	if styleSheet.NumFmts == nil {
+		return false
+	}
+	for _, numFmt := range styleSheet.NumFmts.NumFmt {
+		if numFmt != nil && numFmt.NumFmtID == ID {
+			return true
+		}
+	}
+	return false
 }
 
 // getCustomNumFmtID provides a function to get custom number format code ID.
@@ -2037,14 +2482,28 @@ func newFills(style *Style, fg bool) *xlsxFill {
 	var fill xlsxFill
 	switch style.Fill.Type {
 	case "gradient":
-		if len(style.Fill.Color) != 2 || style.Fill.Shading < 0 || style.Fill.Shading > 16 {
+		count := getFillColorCount(style.Fill)
+		if count >= 2 && (count > 2 || style.Fill.Degree != nil) {
+			gradient := xlsxGradientFill{}
+			if style.Fill.Degree != nil {
+				gradient.Degree = *style.Fill.Degree
+			}
+			for i := 0; i < count; i++ {
+				gradient.Stop = append(gradient.Stop, &xlsxGradientFillStop{
+					Position: float64(i) / float64(count-1), Color: *newFillColor(style.Fill, i),
+				})
+			}
+			fill.GradientFill = &gradient
+			break
+		}
+		if count != 2 || style.Fill.Shading < 0 || style.Fill.Shading > 16 {
 			break
 		}
 		gradient := styleFillVariants()[style.Fill.Shading]
-		gradient.Stop[0].Color.RGB = getPaletteColor(style.Fill.Color[0])
-		gradient.Stop[1].Color.RGB = getPaletteColor(style.Fill.Color[1])
+		gradient.Stop[0].Color = *newFillColor(style.Fill, 0)
+		gradient.Stop[1].Color = *newFillColor(style.Fill, 1)
 		if len(gradient.Stop) == 3 {
-			gradient.Stop[2].Color.RGB = getPaletteColor(style.Fill.Color[0])
+			gradient.Stop[2].Color = *newFillColor(style.Fill, 0)
 		}
 		fill.GradientFill = &gradient
 	case "pattern":
@@ -2053,20 +2512,17 @@ func newFills(style *Style, fg bool) *xlsxFill {
 		}
 		var pattern xlsxPatternFill
 		pattern.PatternType = styleFillPatterns[style.Fill.Pattern]
-		if len(style.Fill.Color) < 1 {
+		if getFillColorCount(style.Fill) < 1 {
 			fill.PatternFill = &pattern
 			break
 		}
 		if fg {
-			if pattern.FgColor == nil {
-				pattern.FgColor = new(xlsxColor)
+			pattern.FgColor = newFillColor(style.Fill, 0)
+			if getFillColorCount(style.Fill) > 1 {
+				pattern.BgColor = newFillColor(style.Fill, 1)
 			}
-			pattern.FgColor.RGB = getPaletteColor(style.Fill.Color[0])
 		} else {
-			if pattern.BgColor == nil {
-				pattern.BgColor = new(xlsxColor)
-			}
-			pattern.BgColor.RGB = getPaletteColor(style.Fill.Color[0])
+			pattern.BgColor = newFillColor(style.Fill, 0)
 		}
 		fill.PatternFill = &pattern
 	default:
@@ -2075,6 +2531,31 @@ func newFills(style *Style, fg bool) *xlsxFill {
 	return &fill
 }
 
+// getFillColorCount provides a function to get the number of the fill colors
+// specified by the RGB colors or the theme colors.
+func getFillColorCount(fill Fill) int {
+	if len(fill.ColorTheme) > len(fill.Color) {
+		return len(fill.ColorTheme)
+	}
+	return len(fill.Color)
+}
+
+// newFillColor provides a function to create the fill color by given fill
+// settings and the index of the fill colors.
+func newFillColor(fill Fill, idx int) *xlsxColor {
+	var clr xlsxColor
+	if idx < len(fill.Color) && fill.Color[idx] != "" {
+		clr.RGB = getPaletteColor(fill.Color[idx])
+	}
+	if idx < len(fill.ColorTheme) {
+		clr.Theme = fill.ColorTheme[idx]
+	}
+	if idx < len(fill.ColorTint) {
+		clr.Tint = fill.ColorTint[idx]
+	}
+	return &clr
+}
+
 // newAlignment provides a function to formatting information pertaining to
 // text alignment in cells. There are a variety of choices for how text is
 // aligned both horizontally and vertically, as well as indentation settings,
@@ -2159,44 +2640,21 @@ func newBorders(style *Style) *xlsxBorder {
 
 // setCellXfs provides a function to set describes all the formatting for a
 // cell.
-func setCellXfs(style *xlsxStyleSheet, fontID, numFmtID, fillID, borderID int, applyAlignment, applyProtection bool, alignment *xlsxAlignment, protection *xlsxProtection) (int, error) {
-	var xf xlsxXf
-	xf.FontID = intPtr(fontID)
-	if fontID != 0 {
-		xf.ApplyFont = boolPtr(true)
-	}
-	xf.NumFmtID = intPtr(numFmtID)
-	if numFmtID != 0 {
-		xf.ApplyNumberFormat = boolPtr(true)
-	}
-	xf.FillID = intPtr(fillID)
-	if fillID != 0 {
-		xf.ApplyFill = boolPtr(true)
-	}
-	xf.BorderID = intPtr(borderID)
-	if borderID != 0 {
-		xf.ApplyBorder = boolPtr(true)
-	}
+func setCellXfs(style *xlsxStyleSheet, xf xlsxXf) (int, error) {
 	if len(style.CellXfs.Xf) == MaxCellStyles {
 		return 0, ErrCellStyles
 	}
 	style.CellXfs.Count = len(style.CellXfs.Xf) + 1
-	xf.Alignment = alignment
-	if alignment != nil {
-		xf.ApplyAlignment = boolPtr(applyAlignment)
-	}
-	if applyProtection {
-		xf.ApplyProtection = boolPtr(applyProtection)
-		xf.Protection = protection
+	if xf.XfID == nil {
+		xf.XfID = intPtr(0)
 	}
-	xfID := 0
-	xf.XfID = &xfID
 	style.CellXfs.Xf = append(style.CellXfs.Xf, xf)
 	return style.CellXfs.Count - 1, nil
 }
 
 // GetCellStyle provides a function to get cell style index by given worksheet
-// name and cell reference. This function is concurrency safe.
+// name and cell reference. This function is concurrency safe. Use the GetStyle
+// function to get the style definition by the returned style index.
 func (f *File) GetCellStyle(sheet, cell string) (int, error) {
 	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
@@ -2220,6 +2678,14 @@ func (f *File) GetCellStyle(sheet, cell string) (int, error) {
 // safe. Note that diagonalDown and diagonalUp type border should be use same
 // color in the same range. SetCellStyle will overwrite the existing
 // styles for the cell, it won't append or merge style with existing styles.
+// If the range covers entire columns (e.g. A1:B1048576) or entire rows (e.g.
+// A1:XFD2), the style will be set for the columns or rows without creating
+// cell records for the empty cells, for example, only the row records will be
+// created for the range A1:XFD100000. Note that a cell record will be created
+// for each cell in the other ranges, including the partial range which
+// reaches the last row or column such as B1:XFD2 or A2:B1048576, because the
+// style of the columns or rows would also apply to the cells outside of the
+// range, so avoid setting the style for such a huge range.
 //
 // For example create a borders of cell H9 on Sheet1:
 //
@@ -2249,6 +2715,22 @@ func (f *File) GetCellStyle(sheet, cell string) (int, error) {
 //	}
 //	err = f.SetCellStyle("Sheet1", "H9", "H9", style)
 //
+// Set linear gradient fill with three colors and 45 degrees angle for cell H9
+// on Sheet1:
+//
+//	degree := 45.0
+//	style, err := f.NewStyle(&excelize.Style{
+//	    Fill: excelize.Fill{
+//	        Type:   "gradient",
+//	        Color:  []string{"63BE7B", "FFEB84", "F8696B"},
+//	        Degree: &degree,
+//	    },
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	err = f.SetCellStyle("Sheet1", "H9", "H9", style)
+//
 // Set solid style pattern fill for cell H9 on Sheet1:
 //
 //	style, err := f.NewStyle(&excelize.Style{
@@ -2359,12 +2841,20 @@ func (f *File) SetCellStyle(sheet, topLeftCell, bottomRightCell string, styleID
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
 
-	ws.prepareSheetXML(vCol, vRow)
-	ws.makeContiguousColumns(hRow, vRow, vCol)
-
 	if styleID < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
 		return newInvalidStyleID(styleID)
 	}
+	if hRow == 1 && vRow == TotalRows {
+		ws.setColStyle(hCol, vCol, styleID)
+		return err
+	}
+	if hCol == 1 && vCol == MaxColumns {
+		ws.setRowStyle(hRow, vRow, styleID)
+		return err
+	}
+
+	ws.prepareSheetXML(vCol, vRow)
+	ws.makeContiguousColumns(hRow, vRow, vCol)
 
 	for r := hRowIdx; r <= vRowIdx; r++ {
 		for k := hColIdx; k <= vColIdx; k++ {
@@ -2374,6 +2864,59 @@ func (f *File) SetCellStyle(sheet, topLeftCell, bottomRightCell string, styleID
 	return err
 }
 
+// ClearCellStyle provides a function to clear the styles of cells by given
+// worksheet name and range reference, the cells will use the default style of
+// the workbook. Only the existing cells will be updated, and no cell records
+// will be created for the empty cells. If the range covers entire columns or
+// entire rows, the styles of the columns or rows will be cleared too. This
+// function is concurrency safe. For example, clear the styles of cells
+// A1:D10 on Sheet1:
+//
+//	err := f.ClearCellStyle("Sheet1", "A1", "D10")
+func (f *File) ClearCellStyle(sheet, topLeftCell, bottomRightCell string) error {
+	hCol, hRow, err := CellNameToCoordinates(topLeftCell)
+	if err != nil {
+		return err
+	}
+	vCol, vRow, err := CellNameToCoordinates(bottomRightCell)
+	if err != nil {
+		return err
+	}
+	if vCol < hCol {
+		vCol, hCol = hCol, vCol
+	}
+	if vRow < hRow {
+		vRow, hRow = hRow, vRow
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if hRow == 1 && vRow == TotalRows && ws.Cols != nil {
+		ws.setColStyle(hCol, vCol, 0)
+	}
+	for rowIdx := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[rowIdx]
+		if row.R < hRow || row.R > vRow {
+			continue
+		}
+		if hCol == 1 && vCol == MaxColumns {
+			row.S, row.CustomFormat = 0, false
+		}
+		for cellIdx, c := range row.C {
+			if col, _, err := CellNameToCoordinates(c.R); err == nil && hCol <= col && col <= vCol {
+				row.C[cellIdx].S = 0
+			}
+		}
+	}
+	return err
+}
+
 // SetConditionalFormat provides a function to create conditional formatting
 // rule for cell value. Conditional formatting is a feature of Excel which
 // allows you to apply a format to a cell or a range of cells based on certain
@@ -2427,6 +2970,7 @@ func (f *File) SetCellStyle(sheet, topLeftCell, bottomRightCell string, styleID
 //	               | BarOnly
 //	               | BarSolid
 //	 icon_set      | IconStyle
+//	               | IconThresholds
 //	               | ReverseIcons
 //	               | IconsOnly
 //	 formula       | Criteria
@@ -2778,6 +3322,28 @@ func (f *File) SetCellStyle(sheet, topLeftCell, bottomRightCell string, styleID
 //	5Quarters
 //	5Rating
 //
+// IconThresholds - Used for set the custom thresholds of the icons in the icon
+// set, the number of thresholds must be the number of icons minus one, and
+// the first icon is always shown for the values below the first threshold.
+// The Type of threshold, the available options are: num, percent, percentile
+// and formula. Set GreaterThan to true to use greater than rather than greater
+// than or equal to for the threshold value. For example, highlight cells with
+// 3 traffic lights icons by values greater than 50 and greater than or equal to
+// 80 on Sheet1!A1:A10:
+//
+//	err := f.SetConditionalFormat("Sheet1", "A1:A10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {
+//	            Type:      "icon_set",
+//	            IconStyle: "3TrafficLights1",
+//	            IconThresholds: []excelize.ConditionalFormatIconThreshold{
+//	                {Type: "num", Value: "50", GreaterThan: true},
+//	                {Type: "num", Value: "80"},
+//	            },
+//	        },
+//	    },
+//	)
+//
 // ReverseIcons - Used for set reversed icons sets.
 //
 // IconsOnly - Used for set displayed without the cell value.
@@ -3165,10 +3731,41 @@ func (f *File) extractCondFmtIconSet(c *xlsxCfRule, extLst *xlsxExtLst) Conditio
 		}
 		format.IconStyle = c.IconSet.IconSet
 		format.ReverseIcons = c.IconSet.Reverse
+		format.IconThresholds = extractCondFmtIconThresholds(c.IconSet)
 	}
 	return format
 }
 
+// extractCondFmtIconThresholds provides a function to extract the icon
+// thresholds of the icon set conditional formatting rule, returns nil if the
+// thresholds are the same as the default settings of the icon style.
+func extractCondFmtIconThresholds(iconSet *xlsxIconSet) []ConditionalFormatIconThreshold {
+	var (
+		thresholds []ConditionalFormatIconThreshold
+		isDefault  = true
+	)
+	preset, ok := condFmtIconSetPresets[iconSet.IconSet]
+	if !ok || len(preset.IconSet.Cfvo) != len(iconSet.Cfvo) {
+		isDefault = false
+	}
+	for i, cfvo := range iconSet.Cfvo {
+		if i == 0 {
+			continue
+		}
+		threshold := ConditionalFormatIconThreshold{
+			Type: cfvo.Type, Value: cfvo.Val, GreaterThan: cfvo.Gte != nil && !*cfvo.Gte,
+		}
+		if isDefault && (threshold.GreaterThan || preset.IconSet.Cfvo[i].Type != cfvo.Type || preset.IconSet.Cfvo[i].Val != cfvo.Val) {
+			isDefault = false
+		}
+		thresholds = append(thresholds, threshold)
+	}
+	if isDefault {
+		return nil
+	}
+	return thresholds
+}
+
 // GetConditionalFormats returns conditional format settings by given worksheet
 // name.
 func (f *File) GetConditionalFormats(sheet string) (map[string][]ConditionalFormatOptions, error) {
@@ -3465,15 +4062,38 @@ func drawCondFmtNoBlanks(p int, ct, ref, GUID string, format *ConditionalFormatO
 // drawCondFmtIconSet provides a function to create conditional formatting rule
 // for icon set by given priority, criteria type and format settings.
 func drawCondFmtIconSet(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
-	cfRule, ok := condFmtIconSetPresets[format.IconStyle]
+	preset, ok := condFmtIconSetPresets[format.IconStyle]
 	if !ok {
 		return nil, nil
 	}
-	cfRule.Priority = p + 1
-	cfRule.IconSet.IconSet = format.IconStyle
-	cfRule.IconSet.Reverse = format.ReverseIcons
-	cfRule.IconSet.ShowValue = boolPtr(!format.IconsOnly)
-	cfRule.Type = validType[format.Type]
+	cfRule := &xlsxCfRule{
+		Priority: p + 1,
+		Type:     validType[format.Type],
+		IconSet: &xlsxIconSet{
+			IconSet:   format.IconStyle,
+			Reverse:   format.ReverseIcons,
+			ShowValue: boolPtr(!format.IconsOnly),
+		},
+	}
+	for _, cfvo := range preset.IconSet.Cfvo {
+		cfRule.IconSet.Cfvo = append(cfRule.IconSet.Cfvo, &xlsxCfvo{Type: cfvo.Type, Val: cfvo.Val})
+	}
+	if format.IconThresholds == nil {
+		return cfRule, nil
+	}
+	if len(format.IconThresholds) != len(preset.IconSet.Cfvo)-1 {
+		return nil, nil
+	}
+	for i, threshold := range format.IconThresholds {
+		if inStrSlice([]string{"num", "percent", "percentile", "formula"}, threshold.Type, true) == -1 {
+			return nil, nil
+		}
+		cfvo := &xlsxCfvo{Type: threshold.Type, Val: threshold.Value}
+		if threshold.GreaterThan {
+			cfvo.Gte = boolPtr(false)
+		}
+		cfRule.IconSet.Cfvo[i+1] = cfvo
+	}
 	return cfRule, nil
 }
 
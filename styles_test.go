@@ -45,6 +45,37 @@ func TestStyleFill(t *testing.T) {
 	styleID2, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"000000"}}})
 	assert.NoError(t, err)
 	assert.Equal(t, styleID1, styleID2)
+
+	// Test create and get gradient fill and pattern fill with colors
+	degree, angle := 45.0, 30.0
+	for _, fill := range []Fill{
+		{Type: "gradient", Color: []string{"63BE7B", "FFEB84", "F8696B"}, Degree: &degree},
+		{Type: "gradient", Color: []string{"FFFFFF", "E0EBF5"}, Degree: &angle},
+		{Type: "gradient", Color: []string{"FFFFFF", "E0EBF5"}, Shading: 2},
+		{Type: "pattern", Color: []string{"FF0000", "FFFF00"}, Pattern: 13},
+		{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1},
+	} {
+		styleID, err := f.NewStyle(&Style{Fill: fill})
+		assert.NoError(t, err)
+		style, err := f.GetStyle(styleID)
+		assert.NoError(t, err)
+		assert.Equal(t, fill, style.Fill)
+	}
+	gradient := f.Styles.Fills.Fill[len(f.Styles.Fills.Fill)-5].GradientFill
+	assert.Equal(t, 45.0, gradient.Degree)
+	assert.Len(t, gradient.Stop, 3)
+	assert.Equal(t, 0.5, gradient.Stop[1].Position)
+	pattern := f.Styles.Fills.Fill[len(f.Styles.Fills.Fill)-2].PatternFill
+	assert.Equal(t, "lightDown", pattern.PatternType)
+	assert.Equal(t, "FFFF0000", pattern.FgColor.RGB)
+	assert.Equal(t, "FFFFFF00", pattern.BgColor.RGB)
+	// Test get gradient fill which not match any shading styles
+	styleID, err := f.NewStyle(&Style{Fill: Fill{Type: "gradient", Color: []string{"FFFFFF", "E0EBF5"}, Shading: 16}})
+	assert.NoError(t, err)
+	f.Styles.Fills.Fill[*f.Styles.CellXfs.Xf[styleID].FillID].GradientFill.Left = 0.2
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, Fill{Type: "gradient", Color: []string{"FFFFFF", "E0EBF5"}}, style.Fill)
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStyleFill.xlsx")))
 }
 
@@ -195,6 +226,9 @@ func TestSetConditionalFormat(t *testing.T) {
 	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "A1:A2", condFmts), "XML syntax error on line 1: element <conditionalFormattings> closed by </conditionalFormatting>")
 	// Test creating a conditional format with invalid icon set style
 	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "A1:A2", []ConditionalFormatOptions{{Type: "icon_set", IconStyle: "unknown"}}))
+	// Test set icon set conditional formatting with invalid icon thresholds
+	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "A1:A2", []ConditionalFormatOptions{{Type: "icon_set", IconStyle: "3Arrows", IconThresholds: []ConditionalFormatIconThreshold{{Type: "num", Value: "1"}}}}))
+	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "A1:A2", []ConditionalFormatOptions{{Type: "icon_set", IconStyle: "3Arrows", IconThresholds: []ConditionalFormatIconThreshold{{Type: "min"}, {Type: "max"}}}}))
 	// Test unsupported conditional formatting rule types
 	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "A1", []ConditionalFormatOptions{{Type: "unsupported"}}))
 
@@ -274,6 +308,7 @@ func TestGetConditionalFormats(t *testing.T) {
 		{{Type: "errors", Format: intPtr(1)}},
 		{{Type: "no_errors", Format: intPtr(1)}},
 		{{Type: "icon_set", IconStyle: "3Arrows", ReverseIcons: true, IconsOnly: true}},
+		{{Type: "icon_set", IconStyle: "4Rating", IconThresholds: []ConditionalFormatIconThreshold{{Type: "num", Value: "10", GreaterThan: true}, {Type: "percentile", Value: "50"}, {Type: "formula", Value: "$B$1"}}}},
 	} {
 		f := NewFile()
 		err := f.SetConditionalFormat("Sheet1", "A2:A1,B:B,2:2", format)
@@ -294,6 +329,17 @@ func TestGetConditionalFormats(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expected, opts["A1:A2"])
 
+	// Test get multiple icon set conditional formats with the same icon count
+	f = NewFile()
+	expected = []ConditionalFormatOptions{
+		{Type: "icon_set", IconStyle: "3Arrows"},
+		{Type: "icon_set", IconStyle: "3Flags", IconThresholds: []ConditionalFormatIconThreshold{{Type: "percent", Value: "10"}, {Type: "percent", Value: "90"}}},
+	}
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A2", expected))
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, opts["A1:A2"])
+
 	// Test get conditional formats on no exists worksheet
 	f = NewFile()
 	_, err = f.GetConditionalFormats("SheetN")
@@ -551,6 +597,53 @@ func TestSetCellStyle(t *testing.T) {
 	f.Styles = nil
 	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
 	assert.EqualError(t, f.SetCellStyle("Sheet1", "A1", "A2", 1), "XML syntax error on line 1: invalid UTF-8")
+
+	// Test set cell style for entire columns and entire rows
+	f = NewFile()
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", "C3"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "C1048576", styleID))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Len(t, ws.(*xlsxWorksheet).SheetData.Row, 3)
+	for _, col := range []string{"B", "C"} {
+		colStyleID, err := f.GetColStyle("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, styleID, colStyleID)
+	}
+	assert.Equal(t, styleID, ws.(*xlsxWorksheet).SheetData.Row[2].C[2].S)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "XFD5", "A6", styleID))
+	assert.Len(t, ws.(*xlsxWorksheet).SheetData.Row, 6)
+	assert.Empty(t, ws.(*xlsxWorksheet).SheetData.Row[4].C)
+	assert.Equal(t, styleID, ws.(*xlsxWorksheet).SheetData.Row[5].S)
+	for _, cell := range []string{"B100", "A5", "Z6"} {
+		cellStyleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, styleID, cellStyleID, cell)
+	}
+	// Test set cell style for entire rows without creating cell records
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "XFD100000", styleID))
+	assert.Len(t, ws.(*xlsxWorksheet).SheetData.Row, 100000)
+	assert.Empty(t, ws.(*xlsxWorksheet).SheetData.Row[99999].C)
+	assert.Equal(t, styleID, ws.(*xlsxWorksheet).SheetData.Row[99999].S)
+
+	// Test clear cell style
+	assert.NoError(t, f.SetCellStyle("Sheet1", "E1", "F2", styleID))
+	assert.NoError(t, f.ClearCellStyle("Sheet1", "F2", "E1"))
+	assert.Equal(t, 0, ws.(*xlsxWorksheet).SheetData.Row[0].C[4].S)
+	assert.Equal(t, 0, ws.(*xlsxWorksheet).SheetData.Row[1].C[5].S)
+	assert.NoError(t, f.ClearCellStyle("Sheet1", "A1048576", "XFD1"))
+	colStyleID, err := f.GetColStyle("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, colStyleID)
+	assert.Equal(t, 0, ws.(*xlsxWorksheet).SheetData.Row[2].C[2].S)
+	assert.Equal(t, 0, ws.(*xlsxWorksheet).SheetData.Row[5].S)
+	assert.False(t, ws.(*xlsxWorksheet).SheetData.Row[5].CustomFormat)
+	// Test clear cell style with invalid parameters
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.ClearCellStyle("Sheet1", "A", "B1"))
+	assert.Equal(t, newCellNameToCoordinatesError("B", newInvalidCellNameError("B")), f.ClearCellStyle("Sheet1", "A1", "B"))
+	assert.EqualError(t, f.ClearCellStyle("SheetN", "A1", "B1"), "sheet SheetN does not exist")
 }
 
 func TestGetStyleID(t *testing.T) {
@@ -570,6 +663,87 @@ func TestGetStyleID(t *testing.T) {
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestNamedStyle(t *testing.T) {
+	f := NewFile()
+	expected := &Style{
+		Font: &Font{Bold: true, Color: "006100", Family: "Calibri", Size: 11},
+		Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"C6EFCE"}},
+	}
+	styleID, err := f.NewNamedStyle("good", expected)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, styleID)
+	assert.Equal(t, &xlsxCellStyle{Name: "Good", XfID: 1, BuiltInID: intPtr(26), CustomBuiltIn: boolPtr(true)}, f.Styles.CellStyles.CellStyle[1])
+	assert.Equal(t, 2, f.Styles.CellStyleXfs.Count)
+	assert.Equal(t, 1, *f.Styles.CellXfs.Xf[styleID].XfID)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styleID))
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Font, style.Font)
+	assert.Equal(t, expected.Fill, style.Fill)
+
+	// Test create a style with the same definition of the named cell style
+	normalStyleID, err := f.NewStyle(expected)
+	assert.NoError(t, err)
+	assert.NotEqual(t, styleID, normalStyleID)
+	assert.Equal(t, 0, *f.Styles.CellXfs.Xf[normalStyleID].XfID)
+
+	// Test get style index of the named cell style
+	idx, err := f.GetNamedStyleID("Good")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, idx)
+	idx, err = f.GetNamedStyleID("Normal")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, idx)
+	_, err = f.GetNamedStyleID("Bad")
+	assert.EqualError(t, err, "cell style Bad does not exist")
+
+	// Test create a custom named cell style without style definition
+	styleID, err = f.NewNamedStyle("Corporate", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &xlsxCellStyle{Name: "Corporate", XfID: 2}, f.Styles.CellStyles.CellStyle[2])
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestNamedStyle.xlsx")))
+
+	// Test get style inherits the attributes from the named cell style
+	f.Styles.CellXfs.Xf = append(f.Styles.CellXfs.Xf, xlsxXf{
+		NumFmtID: intPtr(0), FontID: intPtr(0), ApplyFont: boolPtr(false),
+		FillID: intPtr(0), ApplyFill: boolPtr(false), XfID: intPtr(1),
+	})
+	style, err = f.GetStyle(len(f.Styles.CellXfs.Xf) - 1)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Font, style.Font)
+	assert.Equal(t, expected.Fill, style.Fill)
+
+	// Test create named cell style with invalid parameters
+	_, err = f.NewNamedStyle("", nil)
+	assert.Equal(t, ErrParameterRequired, err)
+	_, err = f.NewNamedStyle(strings.Repeat("c", MaxFieldLength+1), nil)
+	assert.Equal(t, ErrNameLength, err)
+	_, err = f.NewNamedStyle("GOOD", nil)
+	assert.Equal(t, ErrExistsNamedStyle, err)
+	_, err = f.NewNamedStyle("Style", &Style{Font: &Font{Family: strings.Repeat("s", MaxFontFamilyLength+1)}})
+	assert.Equal(t, ErrFontLength, err)
+	// Test create named cell style exceeds the maximum number of cell styles
+	cellStyleXfs, cellStyles := len(f.Styles.CellStyleXfs.Xf), len(f.Styles.CellStyles.CellStyle)
+	cellXfs := f.Styles.CellXfs.Xf
+	f.Styles.CellXfs.Xf = make([]xlsxXf, MaxCellStyles)
+	_, err = f.NewNamedStyle("Style", nil)
+	assert.Equal(t, ErrCellStyles, err)
+	assert.Len(t, f.Styles.CellStyleXfs.Xf, cellStyleXfs)
+	assert.Len(t, f.Styles.CellStyles.CellStyle, cellStyles)
+	_, err = f.GetNamedStyleID("Style")
+	assert.EqualError(t, err, "cell style Style does not exist")
+	f.Styles.CellXfs.Xf = cellXfs
+	// Test create and get named cell style with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.NewNamedStyle("Style", nil)
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	f.Styles = nil
+	_, err = f.GetNamedStyleID("Good")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestGetFillID(t *testing.T) {
 	styles, err := NewFile().stylesReader()
 	assert.NoError(t, err)
@@ -604,6 +778,81 @@ func TestGetNumFmtID(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStyleNumFmt.xlsx")))
 }
 
+func TestNumFmts(t *testing.T) {
+	f := NewFile()
+	numFmtID, err := f.NewNumFmt("#,##0.000")
+	assert.NoError(t, err)
+	assert.Equal(t, 164, numFmtID)
+	// Test register the same custom number format code
+	numFmtID, err = f.NewNumFmt("#,##0.000")
+	assert.NoError(t, err)
+	assert.Equal(t, 164, numFmtID)
+	// Test register the built-in number format code
+	numFmtID, err = f.NewNumFmt("0.00%")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, numFmtID)
+	_, err = f.NewNumFmt("")
+	assert.Equal(t, ErrCustomNumFmt, err)
+
+	// Test create styles with the same custom and currency number format
+	code := "#,##0.000"
+	styleID, err := f.NewStyle(&Style{CustomNumFmt: &code, Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.Equal(t, 164, *f.Styles.CellXfs.Xf[styleID].NumFmtID)
+	for i := 0; i < 2; i++ {
+		styleID, err = f.NewStyle(&Style{NumFmt: 166, DecimalPlaces: intPtr(3), Font: &Font{Italic: i == 0}})
+		assert.NoError(t, err)
+		assert.Equal(t, 165, *f.Styles.CellXfs.Xf[styleID].NumFmtID)
+	}
+	// Test create style with the custom number format ID returned by NewNumFmt
+	styleID, err = f.NewStyle(&Style{NumFmt: 164})
+	assert.NoError(t, err)
+	assert.Equal(t, 164, *f.Styles.CellXfs.Xf[styleID].NumFmtID)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, "#,##0.000", *style.CustomNumFmt)
+	styleID, err = f.NewStyle(style)
+	assert.NoError(t, err)
+	assert.Equal(t, 164, *f.Styles.CellXfs.Xf[styleID].NumFmtID)
+	// Test create conditional style reuses the custom number format ID
+	styleID, err = f.NewConditionalStyle(&Style{CustomNumFmt: &code})
+	assert.NoError(t, err)
+	assert.Equal(t, 164, f.Styles.Dxfs.Dxfs[styleID].NumFmt.NumFmtID)
+	code = "0.0000"
+	styleID, err = f.NewConditionalStyle(&Style{CustomNumFmt: &code})
+	assert.NoError(t, err)
+	assert.Equal(t, 166, f.Styles.Dxfs.Dxfs[styleID].NumFmt.NumFmtID)
+	numFmtID, err = f.NewNumFmt("0.00000")
+	assert.NoError(t, err)
+	assert.Equal(t, 167, numFmtID)
+
+	numFmts, err := f.GetNumFmts()
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]string{164: "#,##0.000", 165: "[$$-45C]#,##0.000", 167: "0.00000"}, numFmts)
+
+	// Test register custom number format exceeds the limit
+	for i := len(numFmts); i < MaxCustomNumFmts; i++ {
+		_, err = f.NewNumFmt(fmt.Sprintf("0.0%d", i))
+		assert.NoError(t, err)
+	}
+	_, err = f.NewNumFmt("0.0_")
+	assert.Equal(t, ErrNumFmts, err)
+	code = "0.0_"
+	_, err = f.NewStyle(&Style{CustomNumFmt: &code})
+	assert.Equal(t, ErrNumFmts, err)
+	_, err = f.NewNamedStyle("Style", &Style{CustomNumFmt: &code})
+	assert.Equal(t, ErrNumFmts, err)
+
+	// Test register and get custom number formats with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.NewNumFmt("0.0_")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	f.Styles = nil
+	_, err = f.GetNumFmts()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestGetThemeColor(t *testing.T) {
 	assert.Empty(t, (&File{}).getThemeColor(&xlsxColor{}))
 	f := NewFile()
@@ -615,6 +864,52 @@ func TestGetThemeColor(t *testing.T) {
 	assert.Empty(t, f.getThemeColor(&xlsxColor{Indexed: len(IndexedColorMapping), Tint: 0.5}))
 	clr := &decodeCTColor{}
 	assert.Nil(t, clr.colorChoice())
+
+	// Test get theme color with tint value
+	for _, c := range []struct {
+		index    int
+		tint     float64
+		expected string
+	}{
+		{0, 0, "FFFFFF"}, {1, 0, "000000"}, {4, 0, "5B9BD5"}, {4, 0.4, "9DC3E6"},
+		{4, -0.5, "1F4E79"}, {10, 0, "0563C1"}, {11, 0, "954F72"},
+	} {
+		color, err := f.GetThemeColor(c.index, c.tint)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, color)
+	}
+	for _, c := range [][]float64{{-1, 0}, {12, 0}, {0, 1.5}} {
+		_, err := f.GetThemeColor(int(c[0]), c[1])
+		assert.Equal(t, ErrParameterInvalid, err)
+	}
+
+	// Test create and get style with theme colors
+	accent1, accent2 := 4, 5
+	styleID, err := f.NewStyle(&Style{
+		Font: &Font{ColorTheme: &accent2},
+		Fill: Fill{Type: "pattern", Pattern: 1, ColorTheme: []*int{&accent1}, ColorTint: []float64{0.4}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &xlsxColor{Theme: &accent1, Tint: 0.4}, f.Styles.Fills.Fill[2].PatternFill.FgColor)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, Fill{Type: "pattern", Pattern: 1, Color: []string{"9DC3E6"}, ColorTheme: []*int{&accent1}, ColorTint: []float64{0.4}}, style.Fill)
+	assert.Equal(t, "ED7D31", style.Font.Color)
+	assert.Equal(t, &accent2, style.Font.ColorTheme)
+	styleID, err = f.NewStyle(&Style{
+		Fill: Fill{Type: "gradient", Shading: 0, Color: []string{"FFFFFF"}, ColorTheme: []*int{nil, &accent1}},
+	})
+	assert.NoError(t, err)
+	style, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"FFFFFF", "5B9BD5"}, style.Fill.Color)
+	assert.Equal(t, []*int{nil, &accent1}, style.Fill.ColorTheme)
+
+	// Test get theme color with unsupported charset theme
+	f.Theme = nil
+	f.Pkg.Store(defaultXMLPathTheme, MacintoshCyrillicCharset)
+	_, err = f.GetThemeColor(0, 0)
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
 func TestGetStyle(t *testing.T) {
@@ -658,6 +953,18 @@ func TestGetStyle(t *testing.T) {
 	assert.Equal(t, expected.Protection, style.Protection)
 	assert.Equal(t, expected.NumFmt, style.NumFmt)
 	assert.Nil(t, style.DecimalPlaces)
+	// Test create style with the returned style definition
+	idx, err := f.NewStyle(style)
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, idx)
+
+	// Test get style with the omitted locked protection attribute
+	styleID, err = f.NewStyle(&Style{Protection: &Protection{Hidden: true}})
+	assert.NoError(t, err)
+	f.Styles.CellXfs.Xf[styleID].Protection.Locked = nil
+	style, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, &Protection{Hidden: true, Locked: true}, style.Protection)
 
 	expected = &Style{
 		Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"0000FF"}},
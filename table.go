@@ -27,6 +27,15 @@ var (
 	conditionFormat  = regexp.MustCompile(`(or|\|\|)`)
 	blankFormat      = regexp.MustCompile("blanks|nonblanks")
 	matchFormat      = regexp.MustCompile("[*?]")
+	// tableTotalsRowFunctions defined the functions in the totals row of the
+	// table and the function number of the SUBTOTAL function for them.
+	tableTotalsRowFunctions = map[string]int{
+		"": 0, "none": 0, "custom": 0, "average": 101, "countNums": 102, "count": 103,
+		"max": 104, "min": 105, "stdDev": 107, "sum": 109, "var": 110,
+	}
+	// tableColumnNameEscaper escapes the special characters in the table
+	// column name for the structured reference.
+	tableColumnNameEscaper = strings.NewReplacer("'", "''", "[", "'[", "]", "']", "#", "'#")
 )
 
 // parseTableOptions provides a function to parse the format settings of the
@@ -42,6 +51,11 @@ func parseTableOptions(opts *Table) (*Table, error) {
 	if err = checkDefinedName(opts.Name); err != nil {
 		return opts, err
 	}
+	for _, column := range opts.Columns {
+		if _, ok := tableTotalsRowFunctions[column.TotalsRowFunction]; !ok {
+			return opts, ErrParameterInvalid
+		}
+	}
 	return opts, err
 }
 
@@ -78,6 +92,48 @@ func parseTableOptions(opts *Table) (*Table, error) {
 //	TableStyleLight1 - TableStyleLight21
 //	TableStyleMedium1 - TableStyleMedium28
 //	TableStyleDark1 - TableStyleDark11
+//
+// ShowTotalsRow: Specifies whether to show the totals row of the table, the
+// last row of the table range will be used as the totals row.
+//
+// Columns: Specifies the settings of the table columns in order, the Name
+// field will be used as the header cell value of the column if it isn't
+// empty. The Formula field specifies the formula of the calculated column,
+// which will be set for all data cells in the column, for example:
+// "Table1[[#This Row],[Qty]]*Table1[[#This Row],[Price]]". The
+// TotalsRowLabel field specifies the text in the totals row cell of the
+// column. The TotalsRowFunction field specifies the function in the totals
+// row cell of the column, the TotalsRowFormula field specifies the formula in
+// the totals row cell when the function is "custom". The supported functions
+// are:
+//
+//	none
+//	average
+//	count
+//	countNums
+//	max
+//	min
+//	stdDev
+//	sum
+//	var
+//	custom
+//
+// For example, create a table with a calculated column and totals row:
+//
+//	err := f.AddTable("Sheet1", &excelize.Table{
+//	    Range:         "A1:C5",
+//	    Name:          "Sales",
+//	    ShowTotalsRow: true,
+//	    Columns: []excelize.TableColumn{
+//	        {Name: "Qty", TotalsRowLabel: "Total"},
+//	        {Name: "Price", TotalsRowFunction: "average"},
+//	        {
+//	            Name:              "Amount",
+//	            Formula:           "Sales[[#This Row],[Qty]]*Sales[[#This Row],[Price]]",
+//	            TotalsRowFunction: "sum",
+//	        },
+//	    },
+//	})
 func (f *File) AddTable(sheet string, table *Table) error {
 	options, err := parseTableOptions(table)
 	if err != nil {
@@ -162,6 +218,23 @@ func (f *File) GetTables(sheet string) ([]Table, error) {
 				table.ShowLastColumn = t.TableStyleInfo.ShowLastColumn
 				table.ShowRowStripes = &t.TableStyleInfo.ShowRowStripes
 			}
+			table.ShowTotalsRow = t.TotalsRowCount > 0
+			if t.TableColumns != nil {
+				for _, column := range t.TableColumns.TableColumn {
+					tableColumn := TableColumn{
+						Name:              column.Name,
+						TotalsRowFunction: column.TotalsRowFunction,
+						TotalsRowLabel:    column.TotalsRowLabel,
+					}
+					if column.CalculatedColumnFormula != nil {
+						tableColumn.Formula = column.CalculatedColumnFormula.Content
+					}
+					if column.TotalsRowFormula != nil {
+						tableColumn.TotalsRowFormula = column.TotalsRowFormula.Content
+					}
+					table.Columns = append(table.Columns, tableColumn)
+				}
+			}
 			tables = append(tables, table)
 		}
 	}
@@ -359,6 +432,10 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, opts *Tab
 	if y1 == y2 {
 		y2++
 	}
+	showTotalsRow := opts != nil && opts.ShowTotalsRow
+	if showTotalsRow && y2-y1 < 2 {
+		y2 = y1 + 2
+	}
 	hideHeaderRow := opts != nil && opts.ShowHeaderRow != nil && !*opts.ShowHeaderRow
 	if hideHeaderRow {
 		y1++
@@ -368,6 +445,12 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, opts *Tab
 	if err != nil {
 		return err
 	}
+	filterRef := ref
+	if showTotalsRow {
+		if filterRef, err = coordinatesToRangeRef([]int{x1, y1, x2, y2 - 1}); err != nil {
+			return err
+		}
+	}
 	name := opts.Name
 	if name == "" {
 		name = "Table" + strconv.Itoa(i)
@@ -379,7 +462,7 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, opts *Tab
 		DisplayName: name,
 		Ref:         ref,
 		AutoFilter: &xlsxAutoFilter{
-			Ref: ref,
+			Ref: filterRef,
 		},
 		TableStyleInfo: &xlsxTableStyleInfo{
 			Name:              opts.StyleName,
@@ -389,16 +472,85 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, opts *Tab
 			ShowColumnStripes: opts.ShowColumnStripes,
 		},
 	}
+	if !hideHeaderRow {
+		for i, column := range opts.Columns {
+			if column.Name == "" || x1+i > x2 {
+				continue
+			}
+			cell, _ := CoordinatesToCellName(x1+i, y1)
+			if err = f.SetCellStr(sheet, cell, column.Name); err != nil {
+				return err
+			}
+		}
+	}
 	_ = f.setTableColumns(sheet, !hideHeaderRow, x1, y1, x2, &t)
 	if hideHeaderRow {
 		t.AutoFilter = nil
 		t.HeaderRowCount = intPtr(0)
+	} else {
+		y1++
+	}
+	if showTotalsRow {
+		t.TotalsRowCount = 1
+	}
+	if err = f.setTableColumnsOptions(sheet, x1, y1, y2, opts, &t); err != nil {
+		return err
 	}
 	table, err := xml.Marshal(t)
 	f.saveFileList(tableXML, table)
 	return err
 }
 
+// setTableColumnsOptions provides a function to set the calculated column
+// formulas and totals row functions of the table columns by given worksheet
+// name, the first column number, the first data row number, the last row
+// number of the table and table options.
+func (f *File) setTableColumnsOptions(sheet string, x1, y1, y2 int, opts *Table, tbl *xlsxTable) error {
+	dataRows := y2
+	if opts.ShowTotalsRow {
+		dataRows--
+	}
+	for i, column := range tbl.TableColumns.TableColumn {
+		if i >= len(opts.Columns) {
+			break
+		}
+		options := opts.Columns[i]
+		if options.Formula != "" {
+			column.CalculatedColumnFormula = &xlsxTableFormula{Content: options.Formula}
+			for row := y1; row <= dataRows; row++ {
+				cell, _ := CoordinatesToCellName(x1+i, row)
+				if err := f.SetCellFormula(sheet, cell, options.Formula); err != nil {
+					return err
+				}
+			}
+		}
+		if !opts.ShowTotalsRow {
+			continue
+		}
+		cell, _ := CoordinatesToCellName(x1+i, y2)
+		if column.TotalsRowLabel = options.TotalsRowLabel; column.TotalsRowLabel != "" {
+			if err := f.SetCellStr(sheet, cell, column.TotalsRowLabel); err != nil {
+				return err
+			}
+		}
+		formula := options.TotalsRowFormula
+		switch options.TotalsRowFunction {
+		case "", "none":
+			continue
+		case "custom":
+			column.TotalsRowFormula = &xlsxTableFormula{Content: formula}
+		default:
+			formula = fmt.Sprintf("SUBTOTAL(%d,%s[%s])", tableTotalsRowFunctions[options.TotalsRowFunction],
+				tbl.Name, tableColumnNameEscaper.Replace(column.Name))
+		}
+		column.TotalsRowFunction = options.TotalsRowFunction
+		if err := f.SetCellFormula(sheet, cell, formula); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // AutoFilter provides the method to add auto filter in a worksheet by given
 // worksheet name, range reference and settings. An auto filter in Excel is a
 // way of filtering a 2D range of data based on some simple criteria. For
@@ -412,13 +564,24 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, opts *Tab
 //	    {Column: "B", Expression: "x != blanks"},
 //	})
 //
+// Filter data by multiple values, cell fill color or font color in an auto
+// filter:
+//
+//	err := f.AutoFilter("Sheet1", "A1:D4", []excelize.AutoFilterOptions{
+//	    {Column: "A", Values: []string{"East", "West", ""}},
+//	    {Column: "B", CellColor: "FFFF00"},
+//	    {Column: "C", FontColor: "FF0000"},
+//	})
+//
 // Column defines the filter columns in an auto filter range based on simple
-// criteria
+// criteria. Only one of the Expression, Values, CellColor and FontColor can
+// be used for a filter column, the empty string in the Values means blanks.
 //
-// It isn't sufficient to just specify the filter condition. You must also
-// hide any rows that don't match the filter condition. Rows are hidden using
-// the SetRowVisible function. Excelize can't filter rows automatically since
-// this isn't part of the file format.
+// The rows in the auto filter range that don't match the filter criteria will
+// be hidden, and the rows that match the criteria will be shown. The first
+// row of the range is the header row, which is always visible. The filter by
+// color compares the color of the cell style, the dynamic, top 10 and icon
+// filters in the existing auto filter aren't applied.
 //
 // Setting a filter criteria for a column:
 //
@@ -524,16 +687,17 @@ func (f *File) autoFilter(sheet, ref string, columns, col int, opts []AutoFilter
 	if err != nil {
 		return err
 	}
-	if ws.SheetPr != nil {
-		ws.SheetPr.FilterMode = true
+	if ws.SheetPr == nil {
+		ws.SheetPr = &xlsxSheetPr{}
 	}
-	ws.SheetPr = &xlsxSheetPr{FilterMode: true}
+	ws.SheetPr.FilterMode = true
 	filter := &xlsxAutoFilter{
 		Ref: ref,
 	}
 	ws.AutoFilter = filter
 	for _, opt := range opts {
-		if opt.Column == "" || opt.Expression == "" {
+		if opt.Column == "" || (opt.Expression == "" && len(opt.Values) == 0 &&
+			opt.CellColor == "" && opt.FontColor == "") {
 			continue
 		}
 		fsCol, err := ColumnNameToNumber(opt.Column)
@@ -545,6 +709,19 @@ func (f *File) autoFilter(sheet, ref string, columns, col int, opts []AutoFilter
 			return newInvalidAutoFilterColumnError(opt.Column)
 		}
 		fc := &xlsxFilterColumn{ColID: offset}
+		if err = f.setFilterColumn(fc, opt); err != nil {
+			return err
+		}
+		filter.FilterColumn = append(filter.FilterColumn, fc)
+	}
+	ws.AutoFilter = filter
+	return f.applyAutoFilter(sheet, ws, filter)
+}
+
+// setFilterColumn provides a function to set the criteria of the filter
+// column by given auto filter options.
+func (f *File) setFilterColumn(fc *xlsxFilterColumn, opt AutoFilterOptions) error {
+	if opt.Expression != "" {
 		token := expressionFormat.FindAllString(opt.Expression, -1)
 		if len(token) != 3 && len(token) != 7 {
 			return newInvalidAutoFilterExpError(opt.Expression)
@@ -554,29 +731,249 @@ func (f *File) autoFilter(sheet, ref string, columns, col int, opts []AutoFilter
 			return err
 		}
 		f.writeAutoFilter(fc, expressions, tokens)
-		filter.FilterColumn = append(filter.FilterColumn, fc)
+		return err
 	}
-	ws.AutoFilter = filter
-	return nil
+	if len(opt.Values) > 0 {
+		fc.Filters = &xlsxFilters{}
+		for _, val := range opt.Values {
+			if val == "" {
+				fc.Filters.Blank = true
+				continue
+			}
+			fc.Filters.Filter = append(fc.Filters.Filter, &xlsxFilter{Val: val})
+		}
+		return nil
+	}
+	style := &Style{Font: &Font{Color: opt.FontColor}}
+	if opt.CellColor != "" {
+		style = &Style{Fill: Fill{Type: "pattern", Color: []string{opt.CellColor}, Pattern: 1}}
+	}
+	dxfID, err := f.NewConditionalStyle(style)
+	if err != nil {
+		return err
+	}
+	fc.ColorFilter = &xlsxColorFilter{CellColor: opt.CellColor != "", DxfID: dxfID}
+	return err
+}
+
+// applyAutoFilter provides a function to hide the rows which don't match the
+// criteria of the auto filter, and show the rows which match the criteria by
+// given worksheet name, worksheet and the auto filter.
+func (f *File) applyAutoFilter(sheet string, ws *xlsxWorksheet, filter *xlsxAutoFilter) error {
+	if len(filter.FilterColumn) == 0 {
+		return nil
+	}
+	ref := filter.Ref
+	if !strings.Contains(ref, ":") {
+		ref += ":" + ref
+	}
+	coordinates, err := rangeRefToCoordinates(ref)
+	if err != nil {
+		return err
+	}
+	for row := coordinates[1] + 1; row <= coordinates[3] && row <= len(ws.SheetData.Row); row++ {
+		visible := true
+		for _, fc := range filter.FilterColumn {
+			cell, err := CoordinatesToCellName(coordinates[0]+fc.ColID, row)
+			if err != nil {
+				return err
+			}
+			if visible, err = f.matchFilterColumn(sheet, cell, fc); err != nil {
+				return err
+			}
+			if !visible {
+				break
+			}
+		}
+		ws.SheetData.Row[row-1].Hidden = !visible
+	}
+	return err
+}
+
+// matchFilterColumn provides a function to check if the cell value matches
+// the criteria of the filter column by given worksheet name, cell reference
+// and filter column.
+func (f *File) matchFilterColumn(sheet, cell string, fc *xlsxFilterColumn) (bool, error) {
+	val, err := f.GetCellValue(sheet, cell)
+	if err != nil {
+		return false, err
+	}
+	if fc.Filters != nil {
+		if val == "" {
+			return fc.Filters.Blank, err
+		}
+		for _, filter := range fc.Filters.Filter {
+			if strings.EqualFold(filter.Val, val) {
+				return true, err
+			}
+		}
+		return len(fc.Filters.DateGroupItem) > 0, err
+	}
+	if fc.CustomFilters != nil && len(fc.CustomFilters.CustomFilter) > 0 {
+		raw, err := f.GetCellValue(sheet, cell, Options{RawCellValue: true})
+		if err != nil {
+			return false, err
+		}
+		for _, customFilter := range fc.CustomFilters.CustomFilter {
+			matched := matchCustomFilter(customFilter, val, raw)
+			if fc.CustomFilters.And != matched {
+				return matched, err
+			}
+		}
+		return fc.CustomFilters.And, err
+	}
+	if fc.ColorFilter != nil {
+		return f.matchColorFilter(sheet, cell, fc.ColorFilter)
+	}
+	return true, err
+}
+
+// matchCustomFilter provides a function to check if the cell value matches
+// the custom filter criteria by given custom filter, formatted and raw value
+// of the cell.
+func matchCustomFilter(customFilter *xlsxCustomFilter, val, raw string) bool {
+	criteria := customFilter.Val
+	switch customFilter.Operator {
+	case "", "equal", "notEqual":
+		matched := strings.EqualFold(val, criteria) || strings.EqualFold(raw, criteria)
+		if criteria == " " {
+			matched = val == ""
+		} else if matchFormat.MatchString(criteria) {
+			exp, _ := matchPatternToRegExp(criteria, false)
+			matched, _ = regexp.MatchString("(?i)"+exp+"$", val)
+		}
+		return matched != (customFilter.Operator == "notEqual")
+	}
+	if val == "" {
+		return false
+	}
+	result := strings.Compare(strings.ToLower(val), strings.ToLower(criteria))
+	if isNum, _, lhs := isNumeric(raw); isNum {
+		if isNum, _, rhs := isNumeric(criteria); isNum {
+			result = 0
+			if lhs < rhs {
+				result = -1
+			} else if lhs > rhs {
+				result = 1
+			}
+		}
+	}
+	switch customFilter.Operator {
+	case "lessThan":
+		return result < 0
+	case "lessThanOrEqual":
+		return result <= 0
+	case "greaterThan":
+		return result > 0
+	case "greaterThanOrEqual":
+		return result >= 0
+	}
+	return true
+}
+
+// matchColorFilter provides a function to check if the cell fill color or
+// font color matches the color of color filter by given worksheet name, cell
+// reference and the color filter.
+func (f *File) matchColorFilter(sheet, cell string, colorFilter *xlsxColorFilter) (bool, error) {
+	style, err := f.GetConditionalStyle(colorFilter.DxfID)
+	if err != nil {
+		return false, err
+	}
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return false, err
+	}
+	cellStyle, err := f.GetStyle(styleID)
+	if err != nil {
+		return false, err
+	}
+	if colorFilter.CellColor {
+		return len(style.Fill.Color) > 0 && len(cellStyle.Fill.Color) > 0 &&
+			strings.EqualFold(style.Fill.Color[0], cellStyle.Fill.Color[0]), err
+	}
+	return style.Font != nil && cellStyle.Font != nil &&
+		strings.EqualFold(style.Font.Color, cellStyle.Font.Color), err
+}
+
+// GetAutoFilter provides the method to get the range reference and the
+// filter settings of the auto filter in a worksheet by given worksheet name.
+// The filter criteria of the custom filters will be returned as expression.
+// For example, get the auto filter in Sheet1:
+//
+//	rangeRef, opts, err := f.GetAutoFilter("Sheet1")
+func (f *File) GetAutoFilter(sheet string) (string, []AutoFilterOptions, error) {
+	var opts []AutoFilterOptions
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.AutoFilter == nil {
+		return "", opts, err
+	}
+	ref := strings.ReplaceAll(ws.AutoFilter.Ref, "$", "")
+	col, _, err := CellNameToCoordinates(strings.Split(ref, ":")[0])
+	if err != nil {
+		return ref, opts, err
+	}
+	operators := map[string]string{
+		"": "==", "equal": "==", "notEqual": "!=", "lessThan": "<",
+		"lessThanOrEqual": "<=", "greaterThan": ">", "greaterThanOrEqual": ">=",
+	}
+	for _, fc := range ws.AutoFilter.FilterColumn {
+		var opt AutoFilterOptions
+		if opt.Column, err = ColumnNumberToName(col + fc.ColID); err != nil {
+			return ref, opts, err
+		}
+		if fc.Filters != nil {
+			for _, filter := range fc.Filters.Filter {
+				opt.Values = append(opt.Values, filter.Val)
+			}
+			if fc.Filters.Blank {
+				opt.Values = append(opt.Values, "")
+			}
+		}
+		if fc.CustomFilters != nil {
+			var expressions []string
+			for _, customFilter := range fc.CustomFilters.CustomFilter {
+				val := customFilter.Val
+				if val == " " {
+					val = "blanks"
+				}
+				expressions = append(expressions, fmt.Sprintf("x %s %s", operators[customFilter.Operator], val))
+			}
+			conditional := " or "
+			if fc.CustomFilters.And {
+				conditional = " and "
+			}
+			opt.Expression = strings.Join(expressions, conditional)
+		}
+		if fc.ColorFilter != nil {
+			style, err := f.GetConditionalStyle(fc.ColorFilter.DxfID)
+			if err != nil {
+				return ref, opts, err
+			}
+			if fc.ColorFilter.CellColor && len(style.Fill.Color) > 0 {
+				opt.CellColor = style.Fill.Color[0]
+			}
+			if !fc.ColorFilter.CellColor && style.Font != nil {
+				opt.FontColor = style.Font.Color
+			}
+		}
+		opts = append(opts, opt)
+	}
+	return ref, opts, err
 }
 
 // writeAutoFilter provides a function to check for single or double custom
 // filters as default filters and handle them accordingly.
 func (f *File) writeAutoFilter(fc *xlsxFilterColumn, exp []int, tokens []string) {
-	if len(exp) == 1 && exp[0] == 2 {
-		// Single equality.
-		var filters []*xlsxFilter
-		filters = append(filters, &xlsxFilter{Val: tokens[0]})
-		fc.Filters = &xlsxFilters{Filter: filters}
-		return
-	}
-	if len(exp) == 3 && exp[0] == 2 && exp[1] == 1 && exp[2] == 2 {
-		// Double equality with "or" operator.
-		var filters []*xlsxFilter
+	if (len(exp) == 1 && exp[0] == 2) || (len(exp) == 3 && exp[0] == 2 && exp[1] == 1 && exp[2] == 2) {
+		// Single equality or double equality with "or" operator.
+		fc.Filters = &xlsxFilters{}
 		for _, v := range tokens {
-			filters = append(filters, &xlsxFilter{Val: v})
+			if v == "blanks" {
+				fc.Filters.Blank = true
+				continue
+			}
+			fc.Filters.Filter = append(fc.Filters.Filter, &xlsxFilter{Val: v})
 		}
-		fc.Filters = &xlsxFilters{Filter: filters}
 		return
 	}
 	// Non default custom filter.
@@ -87,6 +87,94 @@ func TestAddTable(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestAddTableColumns(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"Qty", "Price"}, {2, 1.5}, {3, 2}, {4, 2.5}} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	columns := []TableColumn{
+		{Name: "Qty", TotalsRowLabel: "Total"},
+		{Name: "Price", TotalsRowFunction: "average"},
+		{
+			Name:              "Amount",
+			Formula:           "Sales[[#This Row],[Qty]]*Sales[[#This Row],[Price]]",
+			TotalsRowFunction: "sum",
+		},
+		{Name: "Note's", TotalsRowFunction: "custom", TotalsRowFormula: "COUNTA(Sales[Note''s])"},
+	}
+	assert.NoError(t, f.AddTable("Sheet1", &Table{
+		Range:             "A1:D5",
+		Name:              "Sales",
+		StyleName:         "TableStyleMedium2",
+		ShowFirstColumn:   true,
+		ShowLastColumn:    true,
+		ShowColumnStripes: true,
+		ShowTotalsRow:     true,
+		Columns:           columns,
+	}))
+	for cell, expected := range map[string]string{
+		"C1": "Amount", "D1": "Note's", "A5": "Total",
+	} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+	for cell, expected := range map[string]string{
+		"C2": columns[2].Formula, "C4": columns[2].Formula,
+		"B5": "SUBTOTAL(101,Sales[Price])", "C5": "SUBTOTAL(109,Sales[Amount])", "D5": "COUNTA(Sales[Note''s])",
+	} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula)
+	}
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, tables, 1)
+	assert.True(t, tables[0].ShowTotalsRow)
+	assert.True(t, tables[0].ShowFirstColumn)
+	assert.True(t, tables[0].ShowLastColumn)
+	assert.True(t, tables[0].ShowColumnStripes)
+	assert.Equal(t, columns, tables[0].Columns)
+	content, ok := f.Pkg.Load("xl/tables/table1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), `<autoFilter ref="A1:D4">`)
+	// Test insert rows in the table with totals row
+	assert.NoError(t, f.InsertRows("Sheet1", 3, 1))
+	content, ok = f.Pkg.Load("xl/tables/table1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), `ref="A1:D6" totalsRowCount="1"`)
+	assert.Contains(t, string(content.([]byte)), `<autoFilter ref="A1:D5">`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddTableColumns.xlsx")))
+
+	// Test add table with totals row and hidden header row in a single row range
+	f = NewFile()
+	assert.NoError(t, f.AddTable("Sheet1", &Table{
+		Range: "A1:B1", ShowHeaderRow: boolPtr(false), ShowTotalsRow: true,
+		Columns: []TableColumn{{TotalsRowFunction: "count"}},
+	}))
+	formula, err := f.GetCellFormula("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUBTOTAL(103,Table1[Column1])", formula)
+	// Test add table with unsupported totals row function
+	assert.Equal(t, ErrParameterInvalid, f.AddTable("Sheet1", &Table{
+		Range: "D1:E3", Columns: []TableColumn{{TotalsRowFunction: "median"}},
+	}))
+	// Test add table on not exists worksheet
+	assert.EqualError(t, f.addTable("SheetN", "", 1, 1, 2, 3, 1, &Table{
+		ShowRowStripes: boolPtr(true), Columns: []TableColumn{{Name: "Qty"}},
+	}), "sheet SheetN does not exist")
+	tbl := &xlsxTable{Name: "Table1", TableColumns: &xlsxTableColumns{TableColumn: []*xlsxTableColumn{{Name: "Column1"}}}}
+	for _, column := range []TableColumn{
+		{Formula: "1"}, {TotalsRowLabel: "Total"}, {TotalsRowFunction: "sum"},
+	} {
+		assert.EqualError(t, f.setTableColumnsOptions("SheetN", 1, 2, 3, &Table{
+			ShowTotalsRow: true, Columns: []TableColumn{column},
+		}, tbl), "sheet SheetN does not exist")
+	}
+}
+
 func TestGetTables(t *testing.T) {
 	f := NewFile()
 	// Test get tables in none table worksheet
@@ -178,6 +266,135 @@ func TestAutoFilter(t *testing.T) {
 	assert.NoError(t, f.AutoFilter("Sheet1", "A1:B1", nil))
 }
 
+func TestApplyAutoFilter(t *testing.T) {
+	f := NewFile()
+	fill, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1}})
+	assert.NoError(t, err)
+	font, err := f.NewStyle(&Style{Font: &Font{Color: "FF0000"}})
+	assert.NoError(t, err)
+	for idx, row := range [][]interface{}{
+		{"Region", "Sales", "Product"},
+		{"East", 100, "Apple"},
+		{"West", 200, "Banana"},
+		{"North", 300, "Apricot"},
+		{nil, 400, "Cherry"},
+		{"East", 500, "Avocado"},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B3", "B4", fill))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C2", "C3", font))
+	expectVisible := func(rows ...bool) {
+		for idx, expected := range rows {
+			visible, err := f.GetRowVisible("Sheet1", idx+2)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, visible, idx+2)
+		}
+	}
+	for _, c := range []struct {
+		opts    []AutoFilterOptions
+		visible []bool
+	}{
+		{opts: []AutoFilterOptions{{Column: "A", Values: []string{"east", ""}}}, visible: []bool{true, false, false, true, true}},
+		{opts: []AutoFilterOptions{{Column: "A", Expression: "x == blanks"}}, visible: []bool{false, false, false, true, false}},
+		{opts: []AutoFilterOptions{{Column: "A", Expression: "x != blanks"}}, visible: []bool{true, true, true, false, true}},
+		{opts: []AutoFilterOptions{{Column: "B", Expression: "x >= 200 and x <= 400"}}, visible: []bool{false, true, true, true, false}},
+		{opts: []AutoFilterOptions{{Column: "B", Expression: "x < 200 or x > 400"}}, visible: []bool{true, false, false, false, true}},
+		{opts: []AutoFilterOptions{{Column: "C", Expression: "x == A*"}}, visible: []bool{true, false, true, false, true}},
+		{opts: []AutoFilterOptions{{Column: "C", Expression: "x != *an*"}}, visible: []bool{true, false, true, true, true}},
+		{opts: []AutoFilterOptions{{Column: "C", Expression: "x > B"}}, visible: []bool{false, true, false, true, false}},
+		{opts: []AutoFilterOptions{{Column: "B", CellColor: "FFFF00"}}, visible: []bool{false, true, true, false, false}},
+		{opts: []AutoFilterOptions{{Column: "C", FontColor: "FF0000"}}, visible: []bool{true, true, false, false, false}},
+		{
+			opts:    []AutoFilterOptions{{Column: "A", Values: []string{"East"}}, {Column: "C", Expression: "x == *o"}},
+			visible: []bool{false, false, false, false, true},
+		},
+	} {
+		assert.NoError(t, f.AutoFilter("Sheet1", "A1:C10", c.opts))
+		expectVisible(c.visible...)
+		ref, opts, err := f.GetAutoFilter("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, "A1:C10", ref)
+		assert.Len(t, opts, len(c.opts))
+	}
+	// Test get auto filter settings
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1:C6", []AutoFilterOptions{
+		{Column: "A", Values: []string{"East", ""}},
+		{Column: "B", Expression: "x >= 200 and x <= 400"},
+		{Column: "C", FontColor: "FF0000"},
+	}))
+	ref, opts, err := f.GetAutoFilter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:C6", ref)
+	assert.Equal(t, []AutoFilterOptions{
+		{Column: "A", Values: []string{"East", ""}},
+		{Column: "B", Expression: "x >= 200 and x <= 400"},
+		{Column: "C", FontColor: "FF0000"},
+	}, opts)
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1:C6", []AutoFilterOptions{
+		{Column: "A", Expression: "x != blanks"}, {Column: "B", CellColor: "FFFF00"},
+	}))
+	_, opts, err = f.GetAutoFilter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []AutoFilterOptions{{Column: "A", Expression: "x != blanks"}, {Column: "B", CellColor: "FFFF00"}}, opts)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestApplyAutoFilter.xlsx")))
+	// Test filter rows are shown when filter criteria are cleared
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1:C6", []AutoFilterOptions{{Column: "A", Values: []string{"West"}}}))
+	expectVisible(false, true, false, false, false)
+	// Test apply unsupported filters
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, f.applyAutoFilter("Sheet1", ws.(*xlsxWorksheet), &xlsxAutoFilter{Ref: "A1:C6", FilterColumn: []*xlsxFilterColumn{
+		{ColID: 1, Top10: &xlsxTop10{Val: 1}},
+	}}))
+	expectVisible(true, true, true, true, true)
+	assert.True(t, matchCustomFilter(&xlsxCustomFilter{Operator: "unknown", Val: "1"}, "1", "1"))
+	assert.False(t, matchCustomFilter(&xlsxCustomFilter{Operator: "lessThan", Val: "1"}, "", ""))
+
+	// Test get auto filter without auto filter
+	f = NewFile()
+	ref, opts, err = f.GetAutoFilter("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, ref)
+	assert.Empty(t, opts)
+	// Test get auto filter on not exists worksheet
+	_, _, err = f.GetAutoFilter("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get auto filter with invalid range reference
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).AutoFilter = &xlsxAutoFilter{Ref: "A:C1"}
+	_, _, err = f.GetAutoFilter("Sheet1")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	ws.(*xlsxWorksheet).AutoFilter = &xlsxAutoFilter{Ref: "XFD1", FilterColumn: []*xlsxFilterColumn{{ColID: 1}}}
+	_, _, err = f.GetAutoFilter("Sheet1")
+	assert.Equal(t, ErrColumnNumber, err)
+	// Test get auto filter with invalid color filter format
+	ws.(*xlsxWorksheet).AutoFilter = &xlsxAutoFilter{Ref: "A1", FilterColumn: []*xlsxFilterColumn{{ColorFilter: &xlsxColorFilter{}}}}
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, _, err = f.GetAutoFilter("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test apply auto filter with unsupported charset style sheet
+	f.Styles = nil
+	assert.EqualError(t, f.AutoFilter("Sheet1", "A1:B2", []AutoFilterOptions{{Column: "A", CellColor: "FFFF00"}}),
+		"XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "A"))
+	f.Styles = nil
+	assert.EqualError(t, f.applyAutoFilter("Sheet1", ws.(*xlsxWorksheet), &xlsxAutoFilter{Ref: "A1:A2", FilterColumn: []*xlsxFilterColumn{
+		{ColorFilter: &xlsxColorFilter{}},
+	}}), "XML syntax error on line 1: invalid UTF-8")
+	// Test apply auto filter with invalid range reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.applyAutoFilter("Sheet1", ws.(*xlsxWorksheet), &xlsxAutoFilter{
+		Ref: "A:A2", FilterColumn: []*xlsxFilterColumn{{}},
+	}))
+	assert.Equal(t, ErrColumnNumber, f.applyAutoFilter("Sheet1", ws.(*xlsxWorksheet), &xlsxAutoFilter{
+		Ref: "XFD1:XFD2", FilterColumn: []*xlsxFilterColumn{{ColID: 1}},
+	}))
+}
+
 func TestAutoFilterError(t *testing.T) {
 	outFile := filepath.Join("test", "TestAutoFilterError%d.xlsx")
 	f, err := prepareTestBook1()
@@ -0,0 +1,304 @@
+// Copyright 2016 - 2025 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.20 or later.
+
+package excelize
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+var (
+	// templatePlaceholderRegexp matches the placeholders in the template, such
+	// as {{.Customer.Name}}, {{$.Title}} and {{.}}.
+	templatePlaceholderRegexp = regexp.MustCompile(`\{\{\s*(\$|\$?\.[\w.]*)\s*\}\}`)
+	// templateRangeRegexp matches the start marker of the repeating region.
+	templateRangeRegexp = regexp.MustCompile(`^\{\{\s*range\s+(\$|\$?\.[\w.]*)\s*\}\}$`)
+	// templateEndRegexp matches the end marker of the repeating region.
+	templateEndRegexp = regexp.MustCompile(`^\{\{\s*end\s*\}\}$`)
+)
+
+// templateRegion directly maps the repeating region in the template.
+type templateRegion struct {
+	start, end int
+	field      string
+}
+
+// ExecuteTemplate provides a function to fill the placeholders in the
+// worksheet by given worksheet name and the data, which could be a struct, a
+// map with string keys or the pointers to those types. The placeholder like
+// {{.Customer.Name}} refers to the field or the map value of the data, the
+// cell only contains a placeholder will be set with the typed value, such as
+// number, boolean and time, and the placeholders in the text will be replaced
+// with the formatted values. The styles of the cells will be kept.
+//
+// The rows between the {{range .Items}} and {{end}} marker rows are the
+// repeating region, which will be cloned for each element of the slice with
+// the styles, merged cells, formulas and row heights, and the marker rows will
+// be removed. Inside the repeating region, the placeholder like {{.Name}}
+// refers to the field of the element, {{.}} refers to the element itself, and
+// the placeholder starts with $ refers to the data, such as {{$.Title}}. The
+// nested repeating regions are not supported. For example, fill the invoice
+// template on Sheet1:
+//
+//	type Item struct {
+//	    Name  string
+//	    Price float64
+//	}
+//	err := f.ExecuteTemplate("Sheet1", map[string]interface{}{
+//	    "Customer": map[string]string{"Name": "Excelize"},
+//	    "Items": []Item{{Name: "Apple", Price: 1.5}, {Name: "Banana", Price: 2}},
+//	})
+//
+// with the template:
+//
+//	   |         A             |       B
+//	---+-----------------------+-----------------
+//	 1 | {{.Customer.Name}}    |
+//	 2 | {{range .Items}}      |
+//	 3 | {{.Name}}             | {{.Price}}
+//	 4 | {{end}}               |
+func (f *File) ExecuteTemplate(sheet string, data interface{}) error {
+	rows, err := f.GetRows(sheet)
+	if err != nil {
+		return err
+	}
+	var regions []templateRegion
+	for r := 0; r < len(rows); r++ {
+		for _, val := range rows[r] {
+			if matches := templateRangeRegexp.FindStringSubmatch(strings.TrimSpace(val)); matches != nil {
+				region := templateRegion{start: r + 1, field: matches[1]}
+				for e := r + 1; e < len(rows) && region.end == 0; e++ {
+					for _, val := range rows[e] {
+						if templateEndRegexp.MatchString(strings.TrimSpace(val)) {
+							region.end = e + 1
+							break
+						}
+					}
+				}
+				if region.end == 0 {
+					return ErrTemplateRange
+				}
+				regions = append(regions, region)
+				r = region.end - 1
+				break
+			}
+		}
+	}
+	root := reflect.ValueOf(data)
+	for r := 0; r < len(rows); r++ {
+		inRegion := false
+		for _, region := range regions {
+			inRegion = inRegion || (r+1 >= region.start && r+1 <= region.end)
+		}
+		if inRegion {
+			continue
+		}
+		if err = f.fillTemplateRow(sheet, r+1, rows[r], root, root); err != nil {
+			return err
+		}
+	}
+	for i := len(regions) - 1; i >= 0; i-- {
+		if err = f.executeTemplateRegion(sheet, regions[i], rows[regions[i].start:regions[i].end-1], root); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// executeTemplateRegion provides a function to clone the repeating region for
+// each element of the slice and fill the placeholders by given worksheet name,
+// the repeating region, the cell values of the rows in the region and data.
+func (f *File) executeTemplateRegion(sheet string, region templateRegion, rows [][]string, root reflect.Value) error {
+	items, err := getTemplateValue(region.field, root, root)
+	if err != nil {
+		return err
+	}
+	if items.Kind() != reflect.Slice && items.Kind() != reflect.Array && items.IsValid() {
+		return newInvalidTemplateFieldError(region.field)
+	}
+	if err = f.RemoveRow(sheet, region.end); err != nil {
+		return err
+	}
+	if err = f.RemoveRow(sheet, region.start); err != nil {
+		return err
+	}
+	n, count := len(rows), 0
+	if items.IsValid() {
+		count = items.Len()
+	}
+	if n == 0 {
+		return err
+	}
+	if count == 0 {
+		for i := 0; i < n; i++ {
+			if err = f.RemoveRow(sheet, region.start); err != nil {
+				return err
+			}
+		}
+		return err
+	}
+	if count > 1 {
+		if err = f.InsertRows(sheet, region.start+n, n*(count-1)); err != nil {
+			return err
+		}
+		if err = f.cloneTemplateRegion(sheet, region.start, n, count); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < count; i++ {
+		for j, row := range rows {
+			if err = f.fillTemplateRow(sheet, region.start+i*n+j, row, items.Index(i), root); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// cloneTemplateRegion provides a function to clone the cells, merged cells
+// and row heights of the rows by given worksheet name, the first row number,
+// the number of rows in the region and the count of the clones.
+func (f *File) cloneTemplateRegion(sheet string, start, n, count int) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	maxCol, rowAttrs := 1, map[int]xlsxRow{}
+	for _, row := range ws.SheetData.Row {
+		if row.R < start || row.R >= start+n {
+			continue
+		}
+		rowAttrs[row.R] = row
+		for _, c := range row.C {
+			if col, _, err := CellNameToCoordinates(c.R); err == nil && col > maxCol {
+				maxCol = col
+			}
+		}
+	}
+	endCell, _ := CoordinatesToCellName(maxCol, start+n-1)
+	for i := 1; i < count; i++ {
+		if err = f.CopyRange(sheet, fmt.Sprintf("A%d:%s", start, endCell), fmt.Sprintf("A%d", start+i*n)); err != nil {
+			return err
+		}
+		for r, row := range rowAttrs {
+			if !row.CustomHeight || row.Ht == nil {
+				continue
+			}
+			if err = f.SetRowHeight(sheet, r+i*n, *row.Ht); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// fillTemplateRow provides a function to fill the placeholders in the row by
+// given worksheet name, row number, the cell values of the row, the data of
+// the current context and the data.
+func (f *File) fillTemplateRow(sheet string, row int, values []string, ctx, root reflect.Value) error {
+	for c, val := range values {
+		matches := templatePlaceholderRegexp.FindAllStringSubmatchIndex(val, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		cell, err := CoordinatesToCellName(c+1, row)
+		if err != nil {
+			return err
+		}
+		if len(matches) == 1 && matches[0][0] == 0 && matches[0][1] == len(val) {
+			v, err := getTemplateValue(val[matches[0][2]:matches[0][3]], ctx, root)
+			if err != nil {
+				return err
+			}
+			var value interface{}
+			if v.IsValid() {
+				value = v.Interface()
+			}
+			if err = f.SetCellValue(sheet, cell, value); err != nil {
+				return err
+			}
+			continue
+		}
+		var (
+			text  strings.Builder
+			start int
+		)
+		for _, match := range matches {
+			v, err := getTemplateValue(val[match[2]:match[3]], ctx, root)
+			if err != nil {
+				return err
+			}
+			text.WriteString(val[start:match[0]])
+			if v.IsValid() {
+				text.WriteString(fmt.Sprint(v.Interface()))
+			}
+			start = match[1]
+		}
+		text.WriteString(val[start:])
+		if err = f.SetCellStr(sheet, cell, text.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getTemplateValue returns the value of the field by given field path of the
+// placeholder, the data of the current context and the data. The returned
+// value will be invalid if the field refers to a nil value.
+func getTemplateValue(field string, ctx, root reflect.Value) (reflect.Value, error) {
+	v, path := ctx, field
+	if strings.HasPrefix(field, "$") {
+		v, path = root, strings.TrimPrefix(field, "$")
+	}
+	indirect := func() bool {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return false
+			}
+			v = v.Elem()
+		}
+		return v.IsValid()
+	}
+	for _, name := range strings.Split(strings.TrimPrefix(path, "."), ".") {
+		if !indirect() {
+			return reflect.Value{}, nil
+		}
+		if name == "" {
+			continue
+		}
+		switch v.Kind() {
+		case reflect.Struct:
+			if sf, ok := v.Type().FieldByName(name); ok && sf.IsExported() {
+				fv, err := v.FieldByIndexErr(sf.Index)
+				if err != nil {
+					// The field is promoted through a nil embedded pointer
+					return reflect.Value{}, nil
+				}
+				v = fv
+				continue
+			}
+		case reflect.Map:
+			if v.Type().Key().Kind() == reflect.String {
+				if v = v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key())); v.IsValid() {
+					continue
+				}
+			}
+		}
+		return reflect.Value{}, newInvalidTemplateFieldError(field)
+	}
+	if !indirect() {
+		return reflect.Value{}, nil
+	}
+	return v, nil
+}
@@ -0,0 +1,140 @@
+package excelize
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteTemplate(t *testing.T) {
+	type Item struct {
+		Name  string
+		Price float64
+		Tags  []string
+	}
+	type Customer struct {
+		Name    string
+		Address *string
+	}
+	address := "Street 1"
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	for cell, value := range map[string]string{
+		"A1": "Invoice for {{.Customer.Name}} at {{ .Customer.Address }}",
+		"B1": "{{.Date}}",
+		"A2": "{{range .Items}}",
+		"A3": "{{.Name}}", "B3": "{{.Price}}", "C3": "{{$.Customer.Name}}: {{.Name}}",
+		"A4": "Note",
+		"A5": "{{end}}",
+		"A6": "Total", "B6": "{{.Total}}",
+		"A7":  "{{range .Empty}}",
+		"A8":  "{{.Name}}",
+		"A9":  "{{end}}",
+		"A10": "{{range .Tags}}",
+		"A11": "{{.}}",
+		"A12": "{{end}}",
+		"A13": "{{.Missing}}",
+	} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A3", "B3", style))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D3", "B3*2"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A4", "B4"))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 4, 30))
+	date := time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC)
+	data := map[string]interface{}{
+		"Customer": Customer{Name: "Excelize", Address: &address},
+		"Date":     date,
+		"Items":    []*Item{{Name: "Apple", Price: 1.5}, {Name: "Banana", Price: 2}, {Name: "Cherry", Price: 3}},
+		"Total":    6.5,
+		"Empty":    []Item{},
+		"Tags":     []string{"red", "green"},
+		"Missing":  nil,
+	}
+	assert.NoError(t, f.ExecuteTemplate("Sheet1", &data))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Invoice for Excelize at Street 1", "01-02-25"},
+		{"Apple", "1.5", "Excelize: Apple", ""},
+		{"Note"},
+		{"Banana", "2", "Excelize: Banana", ""},
+		{"Note"},
+		{"Cherry", "3", "Excelize: Cherry", ""},
+		{"Note"},
+		{"Total", "6.5"},
+		{"red"},
+		{"green"},
+	}, rows)
+	for cell, expected := range map[string]string{"D2": "B2*2", "D4": "B4*2", "D6": "B6*2"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula)
+	}
+	for _, cell := range []string{"A2", "B4", "A6"} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, style, styleID, cell)
+	}
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	var refs []string
+	for _, mergeCell := range mergeCells {
+		refs = append(refs, mergeCell.GetStartAxis()+":"+mergeCell.GetEndAxis())
+	}
+	assert.ElementsMatch(t, []string{"A3:B3", "A5:B5", "A7:B7"}, refs)
+	for _, row := range []int{3, 5, 7} {
+		height, err := f.GetRowHeight("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, 30.0, height)
+	}
+	cellType, err := f.GetCellType("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeNumber, cellType)
+}
+
+func TestExecuteTemplateErrors(t *testing.T) {
+	f := NewFile()
+	// Test execute template on not exists worksheet
+	assert.EqualError(t, f.ExecuteTemplate("SheetN", nil), "sheet SheetN does not exist")
+	// Test execute template without end marker
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "{{range .Items}}"))
+	assert.Equal(t, ErrTemplateRange, f.ExecuteTemplate("Sheet1", nil))
+	// Test execute template with not slice range field
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "{{end}}"))
+	assert.Equal(t, newInvalidTemplateFieldError(".Items"), f.ExecuteTemplate("Sheet1", map[string]int{"Items": 1}))
+	// Test execute template with not exists range field
+	assert.Equal(t, newInvalidTemplateFieldError(".Items"), f.ExecuteTemplate("Sheet1", struct{}{}))
+	// Test execute template with not exists fields
+	for _, value := range []string{"{{.Name}}", "Hello {{.Name}}"} {
+		f = NewFile()
+		assert.NoError(t, f.SetCellValue("Sheet1", "A1", value))
+		assert.Equal(t, newInvalidTemplateFieldError(".Name"), f.ExecuteTemplate("Sheet1", map[string]string{}))
+		assert.Equal(t, newInvalidTemplateFieldError(".Name"), f.ExecuteTemplate("Sheet1", struct{ name string }{}))
+	}
+	// Test execute template with not exists fields in the repeating region
+	f = NewFile()
+	assert.NoError(t, f.SetSheetCol("Sheet1", "A1", &[]string{"{{range .}}", "{{.Name}}", "{{end}}"}))
+	assert.Equal(t, newInvalidTemplateFieldError(".Name"), f.ExecuteTemplate("Sheet1", []int{1}))
+	// Test execute template with nil data
+	f = NewFile()
+	assert.NoError(t, f.SetSheetCol("Sheet1", "A1", &[]string{"{{.Name}}", "{{range $.Items}}", "{{end}}", "A {{.Name}}"}))
+	assert.NoError(t, f.ExecuteTemplate("Sheet1", nil))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{nil, {"A "}}, rows)
+	// Test execute template with the field promoted through nil embedded pointer
+	type base struct{ Name string }
+	type data struct {
+		*base
+		ID int
+	}
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"{{.Name}}", "ID {{.ID}}"}))
+	assert.NoError(t, f.ExecuteTemplate("Sheet1", data{ID: 1}))
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"", "ID 1"}}, rows)
+}
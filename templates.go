@@ -43,47 +43,78 @@ var (
 // Source relationship and namespace.
 const (
 	ContentTypeAddinMacro                         = "application/vnd.ms-excel.addin.macroEnabled.main+xml"
+	ContentTypeCoreProperties                     = "application/vnd.openxmlformats-package.core-properties+xml"
+	ContentTypeCustomProperties                   = "application/vnd.openxmlformats-officedocument.custom-properties+xml"
+	ContentTypeDigitalSignatureOrigin             = "application/vnd.openxmlformats-package.digital-signature-origin"
+	ContentTypeDigitalSignatureXML                = "application/vnd.openxmlformats-package.digital-signature-xmlsignature+xml"
 	ContentTypeDrawing                            = "application/vnd.openxmlformats-officedocument.drawing+xml"
 	ContentTypeDrawingML                          = "application/vnd.openxmlformats-officedocument.drawingml.chart+xml"
+	ContentTypeExtendedProperties                 = "application/vnd.openxmlformats-officedocument.extended-properties+xml"
 	ContentTypeMacro                              = "application/vnd.ms-excel.sheet.macroEnabled.main+xml"
+	ContentTypeOLEObject                          = "application/vnd.openxmlformats-officedocument.oleObject"
+	ContentTypePerson                             = "application/vnd.ms-excel.person+xml"
 	ContentTypeRelationships                      = "application/vnd.openxmlformats-package.relationships+xml"
 	ContentTypeSheetML                            = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"
 	ContentTypeSlicer                             = "application/vnd.ms-excel.slicer+xml"
 	ContentTypeSlicerCache                        = "application/vnd.ms-excel.slicerCache+xml"
 	ContentTypeSpreadSheetMLChartsheet            = "application/vnd.openxmlformats-officedocument.spreadsheetml.chartsheet+xml"
 	ContentTypeSpreadSheetMLComments              = "application/vnd.openxmlformats-officedocument.spreadsheetml.comments+xml"
+	ContentTypeSpreadSheetMLExternalLink          = "application/vnd.openxmlformats-officedocument.spreadsheetml.externalLink+xml"
 	ContentTypeSpreadSheetMLPivotCacheDefinition  = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotCacheDefinition+xml"
+	ContentTypeSpreadSheetMLPivotCacheRecords     = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotCacheRecords+xml"
 	ContentTypeSpreadSheetMLPivotTable            = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotTable+xml"
+	ContentTypeSpreadSheetMLPrinterSettings       = "application/vnd.openxmlformats-officedocument.spreadsheetml.printerSettings"
 	ContentTypeSpreadSheetMLSharedStrings         = "application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml"
+	ContentTypeSpreadSheetMLStyles                = "application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"
 	ContentTypeSpreadSheetMLTable                 = "application/vnd.openxmlformats-officedocument.spreadsheetml.table+xml"
 	ContentTypeSpreadSheetMLWorksheet             = "application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"
+	ContentTypeThreadedComments                   = "application/vnd.ms-excel.threadedcomments+xml"
 	ContentTypeTemplate                           = "application/vnd.openxmlformats-officedocument.spreadsheetml.template.main+xml"
 	ContentTypeTemplateMacro                      = "application/vnd.ms-excel.template.macroEnabled.main+xml"
 	ContentTypeVBA                                = "application/vnd.ms-office.vbaProject"
 	ContentTypeVML                                = "application/vnd.openxmlformats-officedocument.vmlDrawing"
+	NameSpaceDigitalSignature                     = "http://schemas.openxmlformats.org/package/2006/digital-signature"
 	NameSpaceDrawingMLMain                        = "http://schemas.openxmlformats.org/drawingml/2006/main"
 	NameSpaceDublinCore                           = "http://purl.org/dc/elements/1.1/"
 	NameSpaceDublinCoreMetadataInitiative         = "http://purl.org/dc/dcmitype/"
 	NameSpaceDublinCoreTerms                      = "http://purl.org/dc/terms/"
 	NameSpaceExtendedProperties                   = "http://schemas.openxmlformats.org/officeDocument/2006/extended-properties"
+	NameSpaceOfficeDigitalSignature               = "http://schemas.microsoft.com/office/2006/digsig"
+	NameSpacePackageRelationships                 = "http://schemas.openxmlformats.org/package/2006/relationships"
+	NameSpaceXAdES                                = "http://uri.etsi.org/01903/v1.3.2#"
 	NameSpaceXML                                  = "http://www.w3.org/XML/1998/namespace"
+	NameSpaceXMLDigitalSignature                  = "http://www.w3.org/2000/09/xmldsig#"
 	NameSpaceXMLSchemaInstance                    = "http://www.w3.org/2001/XMLSchema-instance"
+	SourceRelationshipCalcChain                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/calcChain"
 	SourceRelationshipChart                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chart"
 	SourceRelationshipChartsheet                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chartsheet"
 	SourceRelationshipComments                    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/comments"
+	SourceRelationshipCoreProperties              = "http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties"
+	SourceRelationshipCustomProperties            = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/custom-properties"
 	SourceRelationshipDialogsheet                 = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/dialogsheet"
+	SourceRelationshipDigitalSignature            = "http://schemas.openxmlformats.org/package/2006/relationships/digital-signature/signature"
+	SourceRelationshipDigitalSignatureOrigin      = "http://schemas.openxmlformats.org/package/2006/relationships/digital-signature/origin"
 	SourceRelationshipDrawingML                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/drawing"
 	SourceRelationshipDrawingVML                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/vmlDrawing"
 	SourceRelationshipExtendProperties            = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/extended-properties"
+	SourceRelationshipExternalLink                = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/externalLink"
+	SourceRelationshipExternalLinkPath            = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/externalLinkPath"
 	SourceRelationshipHyperLink                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink"
 	SourceRelationshipImage                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/image"
 	SourceRelationshipOfficeDocument              = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument"
+	SourceRelationshipOLEObject                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/oleObject"
+	SourceRelationshipPackage                     = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/package"
+	SourceRelationshipPerson                      = "http://schemas.microsoft.com/office/2017/10/relationships/person"
 	SourceRelationshipPivotCache                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotCacheDefinition"
+	SourceRelationshipPivotCacheRecords           = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotCacheRecords"
 	SourceRelationshipPivotTable                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotTable"
+	SourceRelationshipPrinterSettings             = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/printerSettings"
 	SourceRelationshipSharedStrings               = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings"
 	SourceRelationshipSlicer                      = "http://schemas.microsoft.com/office/2007/relationships/slicer"
 	SourceRelationshipSlicerCache                 = "http://schemas.microsoft.com/office/2007/relationships/slicerCache"
+	SourceRelationshipStyles                      = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles"
 	SourceRelationshipTable                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/table"
+	SourceRelationshipThreadedComment             = "http://schemas.microsoft.com/office/2017/10/relationships/threadedComment"
 	SourceRelationshipVBAProject                  = "http://schemas.microsoft.com/office/2006/relationships/vbaProject"
 	SourceRelationshipWorkSheet                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet"
 	StrictNameSpaceDocumentPropertiesVariantTypes = "http://purl.oclc.org/ooxml/officeDocument/docPropsVTypes"
@@ -170,6 +201,7 @@ const (
 	MaxCellStyles        = 65430
 	MaxColumns           = 16384
 	MaxColumnWidth       = 255
+	MaxCustomNumFmts     = 250
 	MaxFieldLength       = 255
 	MaxFilePathLength    = 207
 	MaxFormControlValue  = 30000
@@ -199,6 +231,7 @@ const (
 	defaultChartShowBlanksAs    = "gap"
 	defaultShapeSize            = 160
 	defaultShapeLineWidth       = 1
+	pivotCacheDateLayout        = "2006-01-02T15:04:05"
 )
 
 // ColorMappingType is the type of color transformation.
@@ -278,7 +311,10 @@ const (
 	defaultXMLPathContentTypes            = "[Content_Types].xml"
 	defaultXMLPathDocPropsApp             = "docProps/app.xml"
 	defaultXMLPathDocPropsCore            = "docProps/core.xml"
+	defaultXMLPathDocPropsCustom          = "docProps/custom.xml"
+	defaultXMLPathRels                    = "_rels/.rels"
 	defaultXMLPathSharedStrings           = "xl/sharedStrings.xml"
+	defaultXMLPathSignatureOrigin         = "_xmlsignatures/origin.sigs"
 	defaultXMLPathStyles                  = "xl/styles.xml"
 	defaultXMLPathTheme                   = "xl/theme/theme1.xml"
 	defaultXMLPathVolatileDeps            = "xl/volatileDependencies.xml"
@@ -291,6 +327,10 @@ const (
 	defaultXMLRdRichValueWebImagePartRels = "xl/richData/_rels/rdRichValueWebImage.xml.rels"
 )
 
+// definedNameScopeWorkbook is the scope of the defined names which are
+// available in the whole workbook.
+const definedNameScopeWorkbook = "Workbook"
+
 // IndexedColorMapping is the table of default mappings from indexed color value
 // to RGB value. Note that 0-7 are redundant of 8-15 to preserve backwards
 // compatibility. A legacy indexing scheme for colors that is still required
@@ -476,6 +516,17 @@ var supportedImageTypes = map[string]string{
 	".tif": ".tiff", ".tiff": ".tiff", ".wmf": ".wmf", ".wmz": ".wmz",
 }
 
+// supportedOLEObjectTypes defined supported embedded package file types of
+// the OLE object, the values are the default program ID and the content type.
+var supportedOLEObjectTypes = map[string][]string{
+	".docm": {"Word.DocumentMacroEnabled.12", "application/vnd.ms-word.document.macroEnabled.12"},
+	".docx": {"Word.Document.12", "application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+	".pptm": {"PowerPoint.ShowMacroEnabled.12", "application/vnd.ms-powerpoint.presentation.macroEnabled.12"},
+	".pptx": {"PowerPoint.Show.12", "application/vnd.openxmlformats-officedocument.presentationml.presentation"},
+	".xlsm": {"Excel.SheetMacroEnabled.12", "application/vnd.ms-excel.sheet.macroEnabled.12"},
+	".xlsx": {"Excel.Sheet.12", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+}
+
 // supportedContentTypes defined supported file format types.
 var supportedContentTypes = map[string]string{
 	".xlam": ContentTypeAddinMacro,
@@ -488,6 +539,16 @@ var supportedContentTypes = map[string]string{
 // supportedUnderlineTypes defined supported underline types.
 var supportedUnderlineTypes = []string{"none", "single", "double"}
 
+// supportedDrawingDashTypes defined supported preset line dash types in
+// drawing markup language.
+var supportedDrawingDashTypes = []string{
+	"solid", "dot", "dash", "lgDash", "dashDot", "lgDashDot", "lgDashDotDot", "sysDash", "sysDot", "sysDashDot", "sysDashDotDot",
+}
+
+// supportedDrawingLineEndTypes defined supported line end types of the head
+// and tail of the line in drawing markup language.
+var supportedDrawingLineEndTypes = []string{"none", "triangle", "stealth", "diamond", "oval", "arrow"}
+
 // supportedDrawingUnderlineTypes defined supported underline types in drawing
 // markup language.
 var supportedDrawingUnderlineTypes = []string{
@@ -508,6 +569,21 @@ var supportedPageOrientation = []string{"portrait", "landscape"}
 // supportedPageOrder defined supported page setup page order.
 var supportedPageOrder = []string{"overThenDown", "downThenOver"}
 
+// supportedCalcMode defined supported formula calculation mode.
+var supportedCalcMode = []string{"manual", "auto", "autoNoTable"}
+
+// supportedRefMode defined supported formula reference mode.
+var supportedRefMode = []string{"A1", "R1C1"}
+
+// supportedFindLookIn defined supported find and replace look in types.
+var supportedFindLookIn = []string{"values", "formulas"}
+
+// supportedPhoneticTypes defined supported phonetic text character types.
+var supportedPhoneticTypes = []string{"halfwidthKatakana", "fullwidthKatakana", "Hiragana", "noConversion"}
+
+// supportedPhoneticAlignments defined supported phonetic text alignments.
+var supportedPhoneticAlignments = []string{"noControl", "left", "center", "distributed"}
+
 // builtInDefinedNames defined built-in defined names are built with a _xlnm prefix.
 var builtInDefinedNames = []string{"_xlnm.Print_Area", "_xlnm.Print_Titles", "_xlnm.Criteria", "_xlnm._FilterDatabase", "_xlnm.Extract", "_xlnm.Consolidate_Area", "_xlnm.Database", "_xlnm.Sheet_Title"}
 
@@ -523,7 +599,7 @@ const templateSheet = `<worksheet xmlns="http://schemas.openxmlformats.org/sprea
 
 const templateWorkbookRels = `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/><Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/theme" Target="theme/theme1.xml"/></Relationships>`
 
-const templateDocpropsCore = `<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" xmlns:dcmitype="http://purl.org/dc/dcmitype/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><dc:creator>xuri</dc:creator><dcterms:created xsi:type="dcterms:W3CDTF">2006-09-16T00:00:00Z</dcterms:created><dcterms:modified xsi:type="dcterms:W3CDTF">2006-09-16T00:00:00Z</dcterms:modified></cp:coreProperties>`
+const templateDocpropsCore = `<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" xmlns:dcmitype="http://purl.org/dc/dcmitype/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><dc:creator>xuri</dc:creator><dcterms:created xsi:type="dcterms:W3CDTF">%[1]s</dcterms:created><dcterms:modified xsi:type="dcterms:W3CDTF">%[1]s</dcterms:modified></cp:coreProperties>`
 
 const templateRels = `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/extended-properties" Target="docProps/app.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
 
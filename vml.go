@@ -16,9 +16,12 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"path"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 // FormControlType is the type of supported form controls.
@@ -34,6 +37,7 @@ const (
 	FormControlGroupBox
 	FormControlLabel
 	FormControlScrollBar
+	FormControlDropDown
 )
 
 // HeaderFooterImagePositionType is the type of header and footer image position.
@@ -49,6 +53,8 @@ const (
 // GetComments retrieves all comments in a worksheet by given worksheet name.
 func (f *File) GetComments(sheet string) ([]Comment, error) {
 	var comments []Comment
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
 	if !ok {
 		return comments, ErrSheetNotExist{sheet}
@@ -91,12 +97,19 @@ func (f *File) GetComments(sheet string) ([]Comment, error) {
 // getSheetComments provides the method to get the target comment reference by
 // given worksheet file path.
 func (f *File) getSheetComments(sheetFile string) string {
+	return f.getSheetRelsTargetByType(sheetFile, SourceRelationshipComments)
+}
+
+// getSheetRelsTargetByType provides the method to get the first target
+// reference of the worksheet relationships by given worksheet file path and
+// relationship type.
+func (f *File) getSheetRelsTargetByType(sheetFile, relType string) string {
 	rels, _ := f.relsReader("xl/worksheets/_rels/" + sheetFile + ".rels")
 	if sheetRels := rels; sheetRels != nil {
 		sheetRels.mu.Lock()
 		defer sheetRels.mu.Unlock()
 		for _, v := range sheetRels.Relationships {
-			if v.Type == SourceRelationshipComments {
+			if v.Type == relType {
 				return v.Target
 			}
 		}
@@ -120,6 +133,27 @@ func (f *File) getSheetComments(sheetFile string) string {
 //	    Height: 40,
 //	    Width:  180,
 //	})
+//
+// The optional parameter "Visible" specifies if the comment box is always
+// shown, the comment box will be shown only when hovering the cell by default.
+//
+// The optional parameter "FillColor" specifies the solid background color of
+// the comment box in hex format, such as "FFFF00".
+//
+// The optional parameter "Format" specifies the position of the comment box,
+// only the "OffsetX" and "OffsetY" settings will be applied, which specifies
+// the horizontal and vertical offset of the comment box with the cell in
+// pixels. For example, add a comment which is always shown with light blue
+// background color on the right side of the cell Sheet1!B3:
+//
+//	err := f.AddComment("Sheet1", excelize.Comment{
+//	    Cell:      "B3",
+//	    Author:    "Excelize",
+//	    Text:      "This is a comment.",
+//	    Visible:   true,
+//	    FillColor: "DDEBF7",
+//	    Format:    excelize.GraphicOptions{OffsetX: 20, OffsetY: 5},
+//	})
 func (f *File) AddComment(sheet string, opts Comment) error {
 	return f.addVMLObject(vmlOptions{
 		sheet: sheet, Comment: opts,
@@ -130,6 +164,10 @@ func (f *File) AddComment(sheet string, opts Comment) error {
 			Paragraph: opts.Paragraph,
 			Width:     opts.Width,
 			Height:    opts.Height,
+			Format: GraphicOptions{
+				OffsetX: opts.Format.OffsetX,
+				OffsetY: opts.Format.OffsetY,
+			},
 		},
 	})
 }
@@ -140,6 +178,8 @@ func (f *File) AddComment(sheet string, opts Comment) error {
 //
 //	err := f.DeleteComment("Sheet1", "A30")
 func (f *File) DeleteComment(sheet, cell string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -175,10 +215,351 @@ func (f *File) DeleteComment(sheet, cell string) error {
 		}
 		f.Comments[commentsXML] = cmts
 	}
+	if err = f.deleteThreadedComment(sheet, cell); err != nil {
+		return err
+	}
 	sheetRelationshipsDrawingVML := f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID)
 	return f.deleteFormControl(sheetRelationshipsDrawingVML, cell, true)
 }
 
+// GetThreadedComments retrieves all threaded comments with replies in a
+// worksheet by given worksheet name.
+func (f *File) GetThreadedComments(sheet string) ([]ThreadedComment, error) {
+	var comments []ThreadedComment
+	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return comments, ErrSheetNotExist{sheet}
+	}
+	threadedCommentsXML := f.getSheetThreadedComments(filepath.Base(sheetXMLPath))
+	if threadedCommentsXML == "" {
+		return comments, nil
+	}
+	tcs, err := f.threadedCommentsReader(threadedCommentsXML)
+	if err != nil {
+		return comments, err
+	}
+	persons, err := f.personListReader()
+	if err != nil {
+		return comments, err
+	}
+	authors, threads := map[string]string{}, map[string]int{}
+	for _, person := range persons.Person {
+		authors[person.ID] = person.DisplayName
+	}
+	for _, tc := range tcs.ThreadedComment {
+		comment := ThreadedComment{
+			Cell: tc.Ref, Author: authors[tc.PersonID], Text: tc.Text, Done: tc.Done,
+		}
+		if tc.DT != "" {
+			if comment.Date, err = time.Parse("2006-01-02T15:04:05", tc.DT); err != nil {
+				return comments, err
+			}
+		}
+		if idx, ok := threads[tc.ParentID]; ok {
+			comment.Cell, comment.Done = comments[idx].Cell, false
+			comments[idx].Replies = append(comments[idx].Replies, comment)
+			continue
+		}
+		threads[tc.ID] = len(comments)
+		comments = append(comments, comment)
+	}
+	return comments, nil
+}
+
+// AddThreadedComment provides the method to add threaded comment in a
+// worksheet by given worksheet name and threaded comment options. If the cell
+// doesn't have a threaded comment, it will create a new thread with the
+// comment and its replies, otherwise the comment will be added as a reply to
+// the existing thread, and the resolution state of the thread will be kept. A
+// legacy comment will be added for the compatibility with the spreadsheet
+// applications which doesn't support threaded comments. Note that the max text
+// length is 32767. For example, add a threaded comment with a reply in
+// Sheet1!A5:
+//
+//	err := f.AddThreadedComment("Sheet1", excelize.ThreadedComment{
+//	    Cell:   "A5",
+//	    Author: "Excelize",
+//	    Text:   "This is a threaded comment.",
+//	    Replies: []excelize.ThreadedComment{
+//	        {Author: "Excelize", Text: "This is a reply."},
+//	    },
+//	})
+func (f *File) AddThreadedComment(sheet string, opts ThreadedComment) error {
+	if _, _, err := CellNameToCoordinates(opts.Cell); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	tcs, threadID, isReply, err := f.addThreadedComment(sheet, opts)
+	f.mu.Unlock()
+	if err != nil || isReply {
+		return err
+	}
+	return f.AddComment(sheet, Comment{
+		Cell:   opts.Cell,
+		Author: "tc=" + threadID,
+		Text:   threadedCommentLegacyText(tcs, threadID),
+	})
+}
+
+// addThreadedComment provides a function to add the threaded comment with
+// replies in a worksheet by given worksheet name and threaded comment
+// options, returns the threaded comments, thread ID and if the comments are
+// replies of an existing thread.
+func (f *File) addThreadedComment(sheet string, opts ThreadedComment) (*xlsxThreadedComments, string, bool, error) {
+	if _, err := f.workSheetReader(sheet); err != nil {
+		return nil, "", false, err
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	threadedCommentsXML := f.getSheetThreadedComments(filepath.Base(sheetXMLPath))
+	if threadedCommentsXML == "" {
+		threadedCommentsID := f.countThreadedComments() + 1
+		threadedCommentsXML = "xl/threadedComments/threadedComment" + strconv.Itoa(threadedCommentsID) + ".xml"
+		sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+		f.addRels(sheetRels, SourceRelationshipThreadedComment, "../threadedComments/threadedComment"+strconv.Itoa(threadedCommentsID)+".xml", "")
+		if err := f.addContentTypePart(threadedCommentsID, "threadedComments"); err != nil {
+			return nil, "", false, err
+		}
+	}
+	tcs, err := f.threadedCommentsReader(threadedCommentsXML)
+	if err != nil {
+		return tcs, "", false, err
+	}
+	var parent *xlsxThreadedComment
+	for i := range tcs.ThreadedComment {
+		if tc := &tcs.ThreadedComment[i]; tc.ParentID == "" && tc.Ref == opts.Cell {
+			parent = tc
+			break
+		}
+	}
+	var (
+		threadID string
+		thread   []xlsxThreadedComment
+		pos      = len(tcs.ThreadedComment)
+	)
+	if parent != nil {
+		threadID = parent.ID
+		for i, tc := range tcs.ThreadedComment {
+			if tc.ID == threadID || tc.ParentID == threadID {
+				pos = i + 1
+			}
+		}
+	}
+	for i, comment := range append([]ThreadedComment{opts}, opts.Replies...) {
+		tc, err := f.newThreadedComment(opts.Cell, comment)
+		if err != nil {
+			return tcs, threadID, false, err
+		}
+		if i == 0 && parent == nil {
+			tc.Done, threadID = opts.Done, tc.ID
+		} else {
+			tc.ParentID = threadID
+		}
+		thread = append(thread, tc)
+	}
+	tcs.ThreadedComment = append(tcs.ThreadedComment[:pos], append(thread, tcs.ThreadedComment[pos:]...)...)
+	output, err := xml.Marshal(tcs)
+	if err != nil {
+		return tcs, threadID, false, err
+	}
+	f.saveFileList(threadedCommentsXML, output)
+	if parent != nil {
+		return tcs, threadID, true, f.setThreadedCommentLegacyText(sheet, opts.Cell, tcs, threadID)
+	}
+	return tcs, threadID, false, nil
+}
+
+// newThreadedComment provides a function to create a threaded comment by
+// given cell reference and threaded comment options.
+func (f *File) newThreadedComment(cell string, opts ThreadedComment) (xlsxThreadedComment, error) {
+	if opts.Author == "" {
+		opts.Author = "Author"
+	}
+	if utf8.RuneCountInString(opts.Author) > MaxFieldLength {
+		opts.Author = string([]rune(opts.Author)[:MaxFieldLength])
+	}
+	if utf8.RuneCountInString(opts.Text) > TotalCellChars {
+		opts.Text = string([]rune(opts.Text)[:TotalCellChars])
+	}
+	if opts.Date.IsZero() {
+		opts.Date = time.Now()
+	}
+	personID, err := f.addPerson(opts.Author)
+	return xlsxThreadedComment{
+		Ref:      cell,
+		DT:       opts.Date.UTC().Format("2006-01-02T15:04:05.00"),
+		PersonID: personID,
+		ID:       genGUID(),
+		Text:     opts.Text,
+	}, err
+}
+
+// threadedCommentLegacyText provides a function to generate the legacy
+// comment text of the thread by given threaded comments and thread ID.
+func threadedCommentLegacyText(tcs *xlsxThreadedComments, threadID string) string {
+	var text strings.Builder
+	text.WriteString("[Threaded comment]\n\nYour version of Excel allows you to read this threaded comment; however, any edits to it will get removed if the file is opened in a newer version of Excel. Learn more: https://go.microsoft.com/fwlink/?linkid=870924\n")
+	for _, tc := range tcs.ThreadedComment {
+		if tc.ID == threadID {
+			text.WriteString("\nComment:\n    " + tc.Text)
+		}
+		if tc.ParentID == threadID {
+			text.WriteString("\nReply:\n    " + tc.Text)
+		}
+	}
+	return text.String()
+}
+
+// setThreadedCommentLegacyText provides a function to update the legacy
+// comment text of the thread by given worksheet name, cell reference,
+// threaded comments and thread ID.
+func (f *File) setThreadedCommentLegacyText(sheet, cell string, tcs *xlsxThreadedComments, threadID string) error {
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	commentsXML := f.getSheetComments(filepath.Base(sheetXMLPath))
+	if !strings.HasPrefix(commentsXML, "/") {
+		commentsXML = "xl" + strings.TrimPrefix(commentsXML, "..")
+	}
+	commentsXML = strings.TrimPrefix(commentsXML, "/")
+	cmts, err := f.commentsReader(commentsXML)
+	if err != nil || cmts == nil {
+		return err
+	}
+	for i := range cmts.CommentList.Comment {
+		if cmt := &cmts.CommentList.Comment[i]; cmt.Ref == cell {
+			cmt.Text = xlsxText{T: stringPtr(threadedCommentLegacyText(tcs, threadID))}
+		}
+	}
+	return err
+}
+
+// deleteThreadedComment provides a function to delete the threaded comment
+// and its replies in a worksheet by given worksheet name and cell reference.
+func (f *File) deleteThreadedComment(sheet, cell string) error {
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	threadedCommentsXML := f.getSheetThreadedComments(filepath.Base(sheetXMLPath))
+	if threadedCommentsXML == "" {
+		return nil
+	}
+	tcs, err := f.threadedCommentsReader(threadedCommentsXML)
+	if err != nil {
+		return err
+	}
+	threadedComments := make([]xlsxThreadedComment, 0, len(tcs.ThreadedComment))
+	for _, tc := range tcs.ThreadedComment {
+		if tc.Ref != cell {
+			threadedComments = append(threadedComments, tc)
+		}
+	}
+	tcs.ThreadedComment = threadedComments
+	output, err := xml.Marshal(tcs)
+	f.saveFileList(threadedCommentsXML, output)
+	return err
+}
+
+// getSheetThreadedComments provides the method to get the threaded comments
+// part path by given worksheet file path.
+func (f *File) getSheetThreadedComments(sheetFile string) string {
+	target := f.getSheetRelsTargetByType(sheetFile, SourceRelationshipThreadedComment)
+	if target == "" {
+		return target
+	}
+	if !strings.HasPrefix(target, "/") {
+		target = "xl" + strings.TrimPrefix(target, "..")
+	}
+	return strings.TrimPrefix(target, "/")
+}
+
+// countThreadedComments provides a function to get the max index of the
+// threaded comments files storage in the folder xl/threadedComments.
+func (f *File) countThreadedComments() int {
+	count := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if name := k.(string); strings.HasPrefix(name, "xl/threadedComments/threadedComment") {
+			if idx, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, "xl/threadedComments/threadedComment"), ".xml")); err == nil && idx > count {
+				count = idx
+			}
+		}
+		return true
+	})
+	return count
+}
+
+// threadedCommentsReader provides a function to get the pointer to the
+// structure after deserialization of xl/threadedComments/threadedComment%d.xml.
+func (f *File) threadedCommentsReader(path string) (*xlsxThreadedComments, error) {
+	content, ok := f.Pkg.Load(path)
+	tcs := &xlsxThreadedComments{}
+	if ok && content != nil {
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+			Decode(tcs); err != nil && err != io.EOF {
+			return nil, err
+		}
+	}
+	tcs.XMLNSX = NameSpaceSpreadSheet.Value
+	return tcs, nil
+}
+
+// getPersonListPath provides a function to get the persons part path of the
+// workbook.
+func (f *File) getPersonListPath() string {
+	rels, _ := f.relsReader(f.getWorkbookRelsPath())
+	if rels != nil {
+		rels.mu.Lock()
+		defer rels.mu.Unlock()
+		for _, rel := range rels.Relationships {
+			if rel.Type == SourceRelationshipPerson {
+				if strings.HasPrefix(rel.Target, "/") {
+					return strings.TrimPrefix(rel.Target, "/")
+				}
+				return path.Join(path.Dir(f.getWorkbookPath()), rel.Target)
+			}
+		}
+	}
+	return ""
+}
+
+// personListReader provides a function to get the pointer to the structure
+// after deserialization of xl/persons/person.xml.
+func (f *File) personListReader() (*xlsxPersonList, error) {
+	persons := &xlsxPersonList{}
+	if personListXML := f.getPersonListPath(); personListXML != "" {
+		if content, ok := f.Pkg.Load(personListXML); ok && content != nil {
+			if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+				Decode(persons); err != nil && err != io.EOF {
+				return nil, err
+			}
+		}
+	}
+	persons.XMLNSX = NameSpaceSpreadSheet.Value
+	return persons, nil
+}
+
+// addPerson provides a function to add an author of threaded comments into
+// the workbook persons part by given display name, and returns the person ID.
+func (f *File) addPerson(name string) (string, error) {
+	persons, err := f.personListReader()
+	if err != nil {
+		return "", err
+	}
+	for _, person := range persons.Person {
+		if person.DisplayName == name {
+			return person.ID, err
+		}
+	}
+	personListXML := f.getPersonListPath()
+	if personListXML == "" {
+		personListXML = "xl/persons/person.xml"
+		f.addRels(f.getWorkbookRelsPath(), SourceRelationshipPerson, "persons/person.xml", "")
+		if err = f.addContentTypePart(0, "person"); err != nil {
+			return "", err
+		}
+	}
+	person := xlsxPerson{DisplayName: name, ID: genGUID(), UserID: name, ProviderID: "None"}
+	persons.Person = append(persons.Person, person)
+	output, err := xml.Marshal(persons)
+	f.saveFileList(personListXML, output)
+	return person.ID, err
+}
+
 // deleteFormControl provides the method to delete shape from
 // xl/drawings/vmlDrawing%d.xml by giving path, cell and shape type.
 func (f *File) deleteFormControl(sheetRelationshipsDrawingVML, cell string, isComment bool) error {
@@ -198,10 +579,6 @@ func (f *File) deleteFormControl(sheetRelationshipsDrawingVML, cell string, isCo
 			ShapeLayout: &xlsxShapeLayout{
 				Ext: "edit", IDmap: &xlsxIDmap{Ext: "edit", Data: vmlID},
 			},
-			ShapeType: &xlsxShapeType{
-				Stroke: &xlsxStroke{JoinStyle: "miter"},
-				VPath:  &vPath{GradientShapeOK: "t", ConnectType: "rect"},
-			},
 		}
 		// Load exist VML shapes from xl/drawings/vmlDrawing%d.vml
 		d, err := f.decodeVMLDrawingReader(drawingVML)
@@ -209,10 +586,7 @@ func (f *File) deleteFormControl(sheetRelationshipsDrawingVML, cell string, isCo
 			return err
 		}
 		if d != nil {
-			vml.ShapeType.ID = d.ShapeType.ID
-			vml.ShapeType.CoordSize = d.ShapeType.CoordSize
-			vml.ShapeType.Spt = d.ShapeType.Spt
-			vml.ShapeType.Path = d.ShapeType.Path
+			vml.ShapeType = d.shapeTypes()
 			for _, v := range d.Shape {
 				s := xlsxShape{
 					ID:          v.ID,
@@ -275,10 +649,6 @@ func (f *File) addComment(commentsXML string, opts vmlOptions) error {
 		cmts.Authors.Author = append(cmts.Authors.Author, opts.Author)
 		authorID = len(cmts.Authors.Author) - 1
 	}
-	defaultFont, err := f.GetDefaultFont()
-	if err != nil {
-		return err
-	}
 	chars, cmt := 0, xlsxComment{
 		Ref:      opts.Comment.Cell,
 		AuthorID: authorID,
@@ -305,7 +675,7 @@ func (f *File) addComment(commentsXML string, opts vmlOptions) error {
 				Color: &xlsxColor{
 					Indexed: 81,
 				},
-				RFont:  &attrValString{Val: stringPtr(defaultFont)},
+				RFont:  &attrValString{Val: stringPtr(opts.defaultFont)},
 				Family: &attrValInt{Val: intPtr(2)},
 			},
 			T: &xlsxT{Val: run.Text, Space: xml.Attr{
@@ -370,9 +740,11 @@ func (f *File) commentsWriter() {
 
 // AddFormControl provides the method to add form control button in a worksheet
 // by given worksheet name and form control options. Supported form control
-// type: button, check box, group box, label, option button, scroll bar and
-// spinner. If set macro for the form control, the workbook extension should be
-// XLSM or XLTM. Scroll value must be between 0 and 30000.
+// type: button, check box, drop-down, group box, label, option button, scroll
+// bar and spinner. If set macro for the form control, the workbook extension
+// should be XLSM or XLTM. Scroll value must be between 0 and 30000. The check
+// box, option button, drop-down, scroll bar and spinner could be linked to a
+// cell by the "CellLink" option.
 //
 // Example 1, add button form control with macro, rich-text, custom button size,
 // print property on Sheet1!A2, and let the button do not move or size with
@@ -446,6 +818,31 @@ func (f *File) commentsWriter() {
 //	    CellLink:     "A1",
 //	    Horizontally: true,
 //	})
+//
+// Example 5, add check box form control on Sheet1!B2 linked to the cell
+// Sheet1!A2, the linked cell will be TRUE or FALSE by the checked status:
+//
+//	err := f.AddFormControl("Sheet1", excelize.FormControl{
+//	    Cell:     "B2",
+//	    Type:     excelize.FormControlCheckBox,
+//	    Text:     "Check Box 1",
+//	    Checked:  true,
+//	    CellLink: "A2",
+//	})
+//
+// Example 6, add drop-down form control on Sheet1!B3 with the items in the
+// range Sheet1!$D$1:$D$5, the index of the selected item will be set in the
+// cell Sheet1!A3, and select the second item by default:
+//
+//	err := f.AddFormControl("Sheet1", excelize.FormControl{
+//	    Cell:       "B3",
+//	    Type:       excelize.FormControlDropDown,
+//	    Width:      100,
+//	    Height:     20,
+//	    InputRange: "$D$1:$D$5",
+//	    CellLink:   "A3",
+//	    CurrentVal: 2,
+//	})
 func (f *File) AddFormControl(sheet string, opts FormControl) error {
 	return f.addVMLObject(vmlOptions{
 		formCtrl: true, sheet: sheet, FormControl: opts,
@@ -458,6 +855,8 @@ func (f *File) AddFormControl(sheet string, opts FormControl) error {
 //
 //	err := f.DeleteFormControl("Sheet1", "A1")
 func (f *File) DeleteFormControl(sheet, cell string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -517,6 +916,14 @@ func (f *File) vmlDrawingWriter() {
 // addVMLObject provides a function to create VML drawing parts and
 // relationships for comments and form controls.
 func (f *File) addVMLObject(opts vmlOptions) error {
+	var err error
+	if !opts.formCtrl {
+		if opts.defaultFont, err = f.GetDefaultFont(); err != nil {
+			return err
+		}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	// Read sheet data
 	ws, err := f.workSheetReader(opts.sheet)
 	if err != nil {
@@ -524,7 +931,7 @@ func (f *File) addVMLObject(opts vmlOptions) error {
 	}
 	vmlID := f.countComments() + 1
 	if opts.formCtrl {
-		if opts.Type > FormControlScrollBar {
+		if opts.Type > FormControlDropDown {
 			return ErrParameterInvalid
 		}
 		vmlID = f.countVMLDrawing() + 1
@@ -564,11 +971,11 @@ func (f *File) addVMLObject(opts vmlOptions) error {
 // prepareFormCtrlOptions provides a function to parse the format settings of
 // the form control with default value.
 func prepareFormCtrlOptions(opts *vmlOptions) *vmlOptions {
-	if opts.Format.ScaleX == 0 {
-		opts.Format.ScaleX = 1
+	if opts.FormControl.Format.ScaleX == 0 {
+		opts.FormControl.Format.ScaleX = 1
 	}
-	if opts.Format.ScaleY == 0 {
-		opts.Format.ScaleY = 1
+	if opts.FormControl.Format.ScaleY == 0 {
+		opts.FormControl.Format.ScaleY = 1
 	}
 	if opts.FormControl.Width == 0 {
 		opts.FormControl.Width = 140
@@ -732,6 +1139,21 @@ var formCtrlPresets = map[FormControlType]formCtrlPreset{
 		firstButton:  nil,
 		shadow:       nil,
 	},
+	FormControlDropDown: {
+		objectType:   "Drop",
+		autoFill:     "False",
+		filled:       "",
+		fillColor:    "",
+		stroked:      "f",
+		strokeColor:  "windowText [64]",
+		strokeButton: "",
+		fill:         nil,
+		textHAlign:   "",
+		textVAlign:   "",
+		noThreeD:     nil,
+		firstButton:  nil,
+		shadow:       nil,
+	},
 	FormControlSpinButton: {
 		objectType:   "Spin",
 		autoFill:     "False",
@@ -749,9 +1171,19 @@ var formCtrlPresets = map[FormControlType]formCtrlPreset{
 	},
 }
 
-// addFormCtrl check and add scroll bar or spinner form control by given options.
+// addFormCtrl check and add the linked cell of the check box, option button,
+// drop-down, scroll bar or spinner form control by given options.
 func (sp *encodeShape) addFormCtrl(opts *vmlOptions) error {
-	if opts.Type != FormControlScrollBar && opts.Type != FormControlSpinButton {
+	if opts.Type == FormControlButton || opts.Type == FormControlGroupBox || opts.Type == FormControlLabel {
+		return nil
+	}
+	if opts.CellLink != "" {
+		if _, _, err := CellNameToCoordinates(opts.CellLink); err != nil {
+			return err
+		}
+	}
+	sp.ClientData.FmlaLink = opts.CellLink
+	if opts.Type == FormControlCheckBox || opts.Type == FormControlOptionButton {
 		return nil
 	}
 	if opts.CurrentVal > MaxFormControlValue ||
@@ -761,12 +1193,14 @@ func (sp *encodeShape) addFormCtrl(opts *vmlOptions) error {
 		opts.PageChange > MaxFormControlValue {
 		return ErrFormControlValue
 	}
-	if opts.CellLink != "" {
-		if _, _, err := CellNameToCoordinates(opts.CellLink); err != nil {
-			return err
-		}
+	if opts.Type == FormControlDropDown {
+		sp.ClientData.FmlaRange = opts.InputRange
+		sp.ClientData.Sel = opts.CurrentVal
+		sp.ClientData.DropStyle = "Combo"
+		sp.ClientData.DropLines = 8
+		sp.ClientData.Dx = 22
+		return nil
 	}
-	sp.ClientData.FmlaLink = opts.CellLink
 	sp.ClientData.Val = opts.CurrentVal
 	sp.ClientData.Min = opts.MinVal
 	sp.ClientData.Max = opts.MaxVal
@@ -803,11 +1237,11 @@ func (f *File) addFormCtrlShape(preset formCtrlPreset, col, row int, anchor stri
 			FirstButton: preset.firstButton,
 		},
 	}
-	if opts.Format.PrintObject != nil && !*opts.Format.PrintObject {
+	if opts.FormControl.Format.PrintObject != nil && !*opts.FormControl.Format.PrintObject {
 		sp.ClientData.PrintObject = "False"
 	}
-	if opts.Format.Positioning != "" {
-		idx := inStrSlice(supportedPositioning, opts.Format.Positioning, true)
+	if opts.FormControl.Format.Positioning != "" {
+		idx := inStrSlice(supportedPositioning, opts.FormControl.Format.Positioning, true)
 		if idx == -1 {
 			return &sp, ErrParameterInvalid
 		}
@@ -817,6 +1251,9 @@ func (f *File) addFormCtrlShape(preset formCtrlPreset, col, row int, anchor stri
 	if opts.FormControl.Type == FormControlNote {
 		sp.ClientData.MoveWithCells = stringPtr("")
 		sp.ClientData.SizeWithCells = stringPtr("")
+		if opts.Comment.Visible {
+			sp.ClientData.Visible = stringPtr("")
+		}
 	}
 	if !opts.formCtrl {
 		return &sp, nil
@@ -841,12 +1278,25 @@ func (f *File) addDrawingVML(sheetID int, drawingVML string, opts *vmlOptions) e
 	}
 	leftOffset, vmlID, vml, preset := 23, 202, f.VMLDrawing[drawingVML], formCtrlPresets[opts.Type]
 	style := "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;visibility:hidden"
+	if opts.Comment.Visible {
+		style = strings.Replace(style, "visibility:hidden", "visibility:visible", 1)
+	}
+	if opts.Comment.FillColor != "" {
+		preset.fill, preset.fillColor = nil, "#"+strings.TrimPrefix(strings.ToUpper(opts.Comment.FillColor), "#")
+	}
 	if opts.formCtrl {
 		leftOffset, vmlID = 0, 201
 		style = "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;mso-wrap-style:tight"
 	}
-	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(opts.sheet, col, row, opts.Format.OffsetX, opts.Format.OffsetY, int(opts.FormControl.Width), int(opts.FormControl.Height))
-	anchor := fmt.Sprintf("%d, %d, %d, 0, %d, %d, %d, %d", colStart, leftOffset, rowStart, colEnd, x2, rowEnd, y2)
+	x1, y1 := opts.FormControl.Format.OffsetX, opts.FormControl.Format.OffsetY
+	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(opts.sheet, col, row, x1, y1, int(opts.FormControl.Width), int(opts.FormControl.Height))
+	for c := col; c <= colStart; c++ {
+		x1 -= f.getColWidth(opts.sheet, c)
+	}
+	for r := row; r <= rowStart; r++ {
+		y1 -= f.getRowHeight(opts.sheet, r)
+	}
+	anchor := fmt.Sprintf("%d, %d, %d, %d, %d, %d, %d, %d", colStart, leftOffset+x1, rowStart, y1, colEnd, x2, rowEnd, y2)
 	if vml == nil {
 		vml = &vmlDrawing{
 			XMLNSv:  "urn:schemas-microsoft-com:vml",
@@ -856,14 +1306,6 @@ func (f *File) addDrawingVML(sheetID int, drawingVML string, opts *vmlOptions) e
 			ShapeLayout: &xlsxShapeLayout{
 				Ext: "edit", IDmap: &xlsxIDmap{Ext: "edit", Data: sheetID},
 			},
-			ShapeType: &xlsxShapeType{
-				ID:        fmt.Sprintf("_x0000_t%d", vmlID),
-				CoordSize: "21600,21600",
-				Spt:       202,
-				Path:      "m0,0l0,21600,21600,21600,21600,0xe",
-				Stroke:    &xlsxStroke{JoinStyle: "miter"},
-				VPath:     &vPath{GradientShapeOK: "t", ConnectType: "rect"},
-			},
 		}
 		// Load exist VML shapes from xl/drawings/vmlDrawing%d.vml
 		d, err := f.decodeVMLDrawingReader(drawingVML)
@@ -871,10 +1313,7 @@ func (f *File) addDrawingVML(sheetID int, drawingVML string, opts *vmlOptions) e
 			return err
 		}
 		if d != nil {
-			vml.ShapeType.ID = d.ShapeType.ID
-			vml.ShapeType.CoordSize = d.ShapeType.CoordSize
-			vml.ShapeType.Spt = d.ShapeType.Spt
-			vml.ShapeType.Path = d.ShapeType.Path
+			vml.ShapeType = d.shapeTypes()
 			for _, v := range d.Shape {
 				s := xlsxShape{
 					ID:          v.ID,
@@ -892,6 +1331,14 @@ func (f *File) addDrawingVML(sheetID int, drawingVML string, opts *vmlOptions) e
 			}
 		}
 	}
+	vml.addShapeType(&xlsxShapeType{
+		ID:        fmt.Sprintf("_x0000_t%d", vmlID),
+		CoordSize: "21600,21600",
+		Spt:       202,
+		Path:      "m0,0l0,21600,21600,21600,21600,0xe",
+		Stroke:    &xlsxStroke{JoinStyle: "miter"},
+		VPath:     &vPath{GradientShapeOK: "t", ConnectType: "rect"},
+	})
 	sp, err := f.addFormCtrlShape(preset, col, row, anchor, opts)
 	if err != nil {
 		return err
@@ -918,6 +1365,8 @@ func (f *File) addDrawingVML(sheetID int, drawingVML string, opts *vmlOptions) e
 // and height of the form controls currently.
 func (f *File) GetFormControls(sheet string) ([]FormControl, error) {
 	var formControls []FormControl
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	// Read sheet data
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -995,7 +1444,11 @@ func extractFormControl(clientData string) (FormControl, error) {
 			formControl.Macro = shapeVal.ClientData.FmlaMacro
 			formControl.Checked = shapeVal.ClientData.Checked != 0
 			formControl.CellLink = shapeVal.ClientData.FmlaLink
+			formControl.InputRange = shapeVal.ClientData.FmlaRange
 			formControl.CurrentVal = shapeVal.ClientData.Val
+			if formCtrlType == FormControlDropDown {
+				formControl.CurrentVal = shapeVal.ClientData.Sel
+			}
 			formControl.MinVal = shapeVal.ClientData.Min
 			formControl.MaxVal = shapeVal.ClientData.Max
 			formControl.IncChange = shapeVal.ClientData.Inc
@@ -1082,17 +1535,115 @@ func extractVMLFont(font []decodeVMLFont) []RichTextRun {
 	return runs
 }
 
+// shapeTypes returns the shape types of the decoded VML drawing, the child
+// elements of each shape type will be kept as is.
+func (d *decodeVmlDrawing) shapeTypes() []*xlsxShapeType {
+	var shapeTypes []*xlsxShapeType
+	for _, st := range d.ShapeType {
+		shapeTypes = append(shapeTypes, &xlsxShapeType{
+			ID:             st.ID,
+			CoordSize:      st.CoordSize,
+			Spt:            st.Spt,
+			PreferRelative: st.PreferRelative,
+			Path:           st.Path,
+			Filled:         st.Filled,
+			Stroked:        st.Stroked,
+			Val:            st.Val,
+		})
+	}
+	return shapeTypes
+}
+
+// addShapeType provides a function to add the shape type into the VML
+// drawing if the shape type with the same ID doesn't exist, so that the
+// comments, form controls, pictures and OLE objects can be stored in the
+// same VML drawing.
+func (vml *vmlDrawing) addShapeType(shapeType *xlsxShapeType) {
+	for _, st := range vml.ShapeType {
+		if st.ID == shapeType.ID {
+			return
+		}
+	}
+	vml.ShapeType = append(vml.ShapeType, shapeType)
+}
+
+// newVMLPictureShapeType returns the VML shape type for the pictures.
+func newVMLPictureShapeType() *xlsxShapeType {
+	return &xlsxShapeType{
+		ID:             "_x0000_t75",
+		CoordSize:      "21600,21600",
+		Spt:            75,
+		PreferRelative: "t",
+		Path:           "m@4@5l@4@11@9@11@9@5xe",
+		Filled:         "f",
+		Stroked:        "f",
+		Stroke:         &xlsxStroke{JoinStyle: "miter"},
+		VFormulas: &vFormulas{
+			Formulas: []vFormula{
+				{Equation: "if lineDrawn pixelLineWidth 0"},
+				{Equation: "sum @0 1 0"},
+				{Equation: "sum 0 0 @1"},
+				{Equation: "prod @2 1 2"},
+				{Equation: "prod @3 21600 pixelWidth"},
+				{Equation: "prod @3 21600 pixelHeight"},
+				{Equation: "sum @0 0 1"},
+				{Equation: "prod @6 1 2"},
+				{Equation: "prod @7 21600 pixelWidth"},
+				{Equation: "sum @8 21600 0"},
+				{Equation: "prod @7 21600 pixelHeight"},
+				{Equation: "sum @10 21600 0"},
+			},
+		},
+		VPath: &vPath{ExtrusionOK: "f", GradientShapeOK: "t", ConnectType: "rect"},
+		Lock:  &oLock{Ext: "edit", AspectRatio: "t"},
+	}
+}
+
 // AddHeaderFooterImage provides a mechanism to set the graphics that can be
 // referenced in the header and footer definitions via &G, supported image
 // types: EMF, EMZ, GIF, JPEG, JPG, PNG, SVG, TIF, TIFF, WMF, and WMZ.
 //
 // The extension should be provided with a "." in front, e.g. ".png".
 // The width and height should have units in them, e.g. "100pt".
+//
+// The image will be placed in the odd page header or footer by default, set
+// FirstPage or EvenPage to place the image in the first page or even page
+// header or footer, which requires the DifferentFirst or DifferentOddEven of
+// the header and footer settings to be enabled. For example, add a logo in
+// the left section of the header on every printed page of Sheet1:
+//
+//	err := f.SetHeaderFooter("Sheet1", &excelize.HeaderFooterOptions{
+//	    DifferentOddEven: true,
+//	    OddHeader:        "&L&G",
+//	    EvenHeader:       "&L&G",
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, evenPage := range []bool{false, true} {
+//	    if err := f.AddHeaderFooterImage("Sheet1", &excelize.HeaderFooterImageOptions{
+//	        Position:  excelize.HeaderFooterImagePositionLeft,
+//	        File:      logo,
+//	        EvenPage:  evenPage,
+//	        Extension: ".png",
+//	        Width:     "50pt",
+//	        Height:    "32pt",
+//	    }); err != nil {
+//	        fmt.Println(err)
+//	        return
+//	    }
+//	}
 func (f *File) AddHeaderFooterImage(sheet string, opts *HeaderFooterImageOptions) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
+	if opts == nil || (opts.FirstPage && opts.EvenPage) {
+		return ErrParameterInvalid
+	}
 	ext, ok := supportedImageTypes[strings.ToLower(opts.Extension)]
 	if !ok {
 		return ErrImgExt
@@ -1121,7 +1672,8 @@ func (f *File) AddHeaderFooterImage(sheet string, opts *HeaderFooterImageOptions
 		HeaderFooterImagePositionRight:  "R",
 	}[opts.Position] +
 		map[bool]string{false: "H", true: "F"}[opts.IsFooter] +
-		map[bool]string{false: "", true: "FIRST"}[opts.FirstPage]
+		map[bool]string{false: "", true: "FIRST"}[opts.FirstPage] +
+		map[bool]string{false: "", true: "EVEN"}[opts.EvenPage]
 	vml := f.VMLDrawing[drawingVML]
 	if vml == nil {
 		vml = &vmlDrawing{
@@ -1131,34 +1683,6 @@ func (f *File) AddHeaderFooterImage(sheet string, opts *HeaderFooterImageOptions
 			ShapeLayout: &xlsxShapeLayout{
 				Ext: "edit", IDmap: &xlsxIDmap{Ext: "edit", Data: sheetID},
 			},
-			ShapeType: &xlsxShapeType{
-				ID:             "_x0000_t75",
-				CoordSize:      "21600,21600",
-				Spt:            75,
-				PreferRelative: "t",
-				Path:           "m@4@5l@4@11@9@11@9@5xe",
-				Filled:         "f",
-				Stroked:        "f",
-				Stroke:         &xlsxStroke{JoinStyle: "miter"},
-				VFormulas: &vFormulas{
-					Formulas: []vFormula{
-						{Equation: "if lineDrawn pixelLineWidth 0"},
-						{Equation: "sum @0 1 0"},
-						{Equation: "sum 0 0 @1"},
-						{Equation: "prod @2 1 2"},
-						{Equation: "prod @3 21600 pixelWidth"},
-						{Equation: "prod @3 21600 pixelHeight"},
-						{Equation: "sum @0 0 1"},
-						{Equation: "prod @6 1 2"},
-						{Equation: "prod @7 21600 pixelWidth"},
-						{Equation: "sum @8 21600 0"},
-						{Equation: "prod @7 21600 pixelHeight"},
-						{Equation: "sum @10 21600 0"},
-					},
-				},
-				VPath: &vPath{ExtrusionOK: "f", GradientShapeOK: "t", ConnectType: "rect"},
-				Lock:  &oLock{Ext: "edit", AspectRatio: "t"},
-			},
 		}
 		// Load exist VML shapes from xl/drawings/vmlDrawing%d.vml
 		d, err := f.decodeVMLDrawingReader(drawingVML)
@@ -1166,13 +1690,7 @@ func (f *File) AddHeaderFooterImage(sheet string, opts *HeaderFooterImageOptions
 			return err
 		}
 		if d != nil {
-			vml.ShapeType.ID = d.ShapeType.ID
-			vml.ShapeType.CoordSize = d.ShapeType.CoordSize
-			vml.ShapeType.Spt = d.ShapeType.Spt
-			vml.ShapeType.PreferRelative = d.ShapeType.PreferRelative
-			vml.ShapeType.Path = d.ShapeType.Path
-			vml.ShapeType.Filled = d.ShapeType.Filled
-			vml.ShapeType.Stroked = d.ShapeType.Stroked
+			vml.ShapeType = d.shapeTypes()
 			for _, v := range d.Shape {
 				s := xlsxShape{
 					ID:    v.ID,
@@ -1186,6 +1704,7 @@ func (f *File) AddHeaderFooterImage(sheet string, opts *HeaderFooterImageOptions
 		}
 	}
 
+	vml.addShapeType(newVMLPictureShapeType())
 	for idx, shape := range vml.Shape {
 		if shape.ID == shapeID {
 			vml.Shape = append(vml.Shape[:idx], vml.Shape[idx+1:]...)
@@ -22,7 +22,7 @@ type vmlDrawing struct {
 	XMLNSx      string           `xml:"xmlns:x,attr"`
 	XMLNSmv     string           `xml:"xmlns:mv,attr,omitempty"`
 	ShapeLayout *xlsxShapeLayout `xml:"o:shapelayout"`
-	ShapeType   *xlsxShapeType   `xml:"v:shapetype"`
+	ShapeType   []*xlsxShapeType `xml:"v:shapetype"`
 	Shape       []xlsxShape      `xml:"v:shape"`
 }
 
@@ -69,6 +69,7 @@ type xlsxShapeType struct {
 	VFormulas      *vFormulas  `xml:"v:formulas"`
 	VPath          *vPath      `xml:"v:path"`
 	Lock           *oLock      `xml:"o:lock"`
+	Val            string      `xml:",innerxml"`
 }
 
 // xlsxStroke directly maps the stroke element.
@@ -170,8 +171,10 @@ type xClientData struct {
 	TextVAlign    string  `xml:"x:TextVAlign,omitempty"`
 	Row           *int    `xml:"x:Row"`
 	Column        *int    `xml:"x:Column"`
+	Visible       *string `xml:"x:Visible"`
 	Checked       int     `xml:"x:Checked,omitempty"`
 	FmlaLink      string  `xml:"x:FmlaLink,omitempty"`
+	FmlaRange     string  `xml:"x:FmlaRange,omitempty"`
 	NoThreeD      *string `xml:"x:NoThreeD"`
 	FirstButton   *string `xml:"x:FirstButton"`
 	Val           uint    `xml:"x:Val,omitempty"`
@@ -181,13 +184,18 @@ type xClientData struct {
 	Page          uint    `xml:"x:Page,omitempty"`
 	Horiz         *string `xml:"x:Horiz"`
 	Dx            uint    `xml:"x:Dx,omitempty"`
+	Sel           uint    `xml:"x:Sel,omitempty"`
+	DropStyle     string  `xml:"x:DropStyle,omitempty"`
+	DropLines     uint    `xml:"x:DropLines,omitempty"`
+	CF            string  `xml:"x:CF,omitempty"`
+	AutoPict      *string `xml:"x:AutoPict"`
 }
 
 // decodeVmlDrawing defines the structure used to parse the file
 // xl/drawings/vmlDrawing%d.vml.
 type decodeVmlDrawing struct {
-	ShapeType decodeShapeType `xml:"urn:schemas-microsoft-com:vml shapetype"`
-	Shape     []decodeShape   `xml:"urn:schemas-microsoft-com:vml shape"`
+	ShapeType []decodeShapeType `xml:"urn:schemas-microsoft-com:vml shapetype"`
+	Shape     []decodeShape     `xml:"urn:schemas-microsoft-com:vml shape"`
 }
 
 // decodeShapeType defines the structure used to parse the shapetype element in
@@ -200,6 +208,7 @@ type decodeShapeType struct {
 	Path           string `xml:"path,attr"`
 	Filled         string `xml:"filled,attr,omitempty"`
 	Stroked        string `xml:"stroked,attr,omitempty"`
+	Val            string `xml:",innerxml"`
 }
 
 // decodeShape defines the structure used to parse the particular shape element.
@@ -275,6 +284,8 @@ type decodeVMLClientData struct {
 	Row        *int
 	Checked    int
 	FmlaLink   string
+	FmlaRange  string
+	Sel        uint
 	Val        uint
 	Min        uint
 	Max        uint
@@ -313,8 +324,9 @@ type formCtrlPreset struct {
 
 // vmlOptions defines the structure used to internal comments and form controls.
 type vmlOptions struct {
-	formCtrl bool
-	sheet    string
+	formCtrl    bool
+	sheet       string
+	defaultFont string
 	Comment
 	FormControl
 }
@@ -333,6 +345,7 @@ type FormControl struct {
 	PageChange   uint
 	Horizontally bool
 	CellLink     string
+	InputRange   string
 	Text         string
 	Paragraph    []RichTextRun
 	Type         FormControlType
@@ -346,6 +359,7 @@ type HeaderFooterImageOptions struct {
 	File      []byte
 	IsFooter  bool
 	FirstPage bool
+	EvenPage  bool
 	Extension string
 	Width     string
 	Height    string
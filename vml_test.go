@@ -18,6 +18,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -81,6 +82,27 @@ func TestAddComment(t *testing.T) {
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 }
 
+func TestAddCommentFormat(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Hidden"}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{
+		Cell: "B3", Author: "Excelize", Text: "Visible", Visible: true,
+		FillColor: "#ddebf7", Format: GraphicOptions{OffsetX: 20, OffsetY: 5},
+	}))
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	assert.Len(t, vml.Shape, 2)
+	assert.Contains(t, vml.Shape[0].Style, "visibility:hidden")
+	assert.Equal(t, "#FBF6D6", vml.Shape[0].FillColor)
+	assert.Contains(t, vml.Shape[0].Val, "<v:fill")
+	assert.NotContains(t, vml.Shape[0].Val, "<x:Visible>")
+	assert.Contains(t, vml.Shape[1].Style, "visibility:visible")
+	assert.Equal(t, "#DDEBF7", vml.Shape[1].FillColor)
+	assert.NotContains(t, vml.Shape[1].Val, "<v:fill")
+	assert.Contains(t, vml.Shape[1].Val, "<x:Anchor>1, 43, 2, 5, 3, 32, 5, 11</x:Anchor>")
+	assert.Contains(t, vml.Shape[1].Val, "<x:Visible></x:Visible>")
+	assert.NoError(t, f.SaveAs(filepath.Join(t.TempDir(), "TestAddCommentFormat.xlsx")))
+}
+
 func TestDeleteComment(t *testing.T) {
 	f, err := prepareTestBook1()
 	if !assert.NoError(t, err) {
@@ -128,6 +150,111 @@ func TestDeleteComment(t *testing.T) {
 	assert.NoError(t, f.DeleteComment("Sheet1", "A1"))
 }
 
+func TestThreadedComment(t *testing.T) {
+	f := NewFile()
+	date := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{
+		Cell: "A1", Author: "Excelize", Text: "Thread 1", Date: date, Done: true,
+		Replies: []ThreadedComment{{Author: "Reviewer", Text: "Reply 1-1", Date: date}},
+	}))
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "B2", Text: "Thread 2"}))
+	// Test add reply to the existing thread keeps the resolution state
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Author: "Excelize", Text: "Reply 1-2"}))
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "B2", Text: "Reply 2-1", Done: true}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestThreadedComment.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(filepath.Join("test", "TestThreadedComment.xlsx"))
+	assert.NoError(t, err)
+	comments, err := f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "A1", comments[0].Cell)
+	assert.Equal(t, "Excelize", comments[0].Author)
+	assert.Equal(t, "Thread 1", comments[0].Text)
+	assert.Equal(t, date, comments[0].Date)
+	assert.True(t, comments[0].Done)
+	assert.Len(t, comments[0].Replies, 2)
+	assert.Equal(t, ThreadedComment{Cell: "A1", Author: "Reviewer", Text: "Reply 1-1", Date: date}, comments[0].Replies[0])
+	assert.Equal(t, "Reply 1-2", comments[0].Replies[1].Text)
+	assert.Equal(t, "Author", comments[1].Author)
+	assert.False(t, comments[1].Done)
+	assert.Len(t, comments[1].Replies, 1)
+	// Test get legacy comments of the threaded comments
+	legacyComments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, legacyComments, 2)
+	assert.True(t, strings.HasPrefix(legacyComments[0].Author, "tc={"))
+	assert.True(t, strings.HasSuffix(legacyComments[0].Text, "Comment:\n    Thread 1\nReply:\n    Reply 1-1\nReply:\n    Reply 1-2"))
+	persons, err := f.personListReader()
+	assert.NoError(t, err)
+	assert.Len(t, persons.Person, 3)
+	// Test delete threaded comment with replies
+	assert.NoError(t, f.DeleteComment("Sheet1", "A1"))
+	comments, err = f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "B2", comments[0].Cell)
+	legacyComments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, legacyComments, 1)
+	// Test get threaded comments on the worksheet without threaded comments
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	comments, err = f.GetThreadedComments("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 0)
+	// Test get and add threaded comments on not exists worksheet
+	_, err = f.GetThreadedComments("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.EqualError(t, f.AddThreadedComment("SheetN", ThreadedComment{Cell: "A1"}), "sheet SheetN does not exist")
+	// Test add threaded comment with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A"}))
+	// Test get threaded comments with invalid date time
+	f.Pkg.Store("xl/threadedComments/threadedComment1.xml", []byte(`<ThreadedComments xmlns="http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments"><threadedComment ref="A1" dT="-" id="{0}" personId="{0}"/></ThreadedComments>`))
+	_, err = f.GetThreadedComments("Sheet1")
+	assert.Equal(t, `parsing time "-" as "2006-01-02T15:04:05": cannot parse "-" as "2006"`, err.Error())
+	// Test threaded comments and persons with unsupported charset
+	f.Pkg.Store("xl/threadedComments/threadedComment1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetThreadedComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.DeleteComment("Sheet1", "A1"), "XML syntax error on line 1: invalid UTF-8")
+	f.Pkg.Store("xl/threadedComments/threadedComment1.xml", []byte(`<ThreadedComments xmlns="http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments"/>`))
+	f.Pkg.Store("xl/persons/person.xml", MacintoshCyrillicCharset)
+	_, err = f.GetThreadedComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddThreadedComment(t *testing.T) {
+	f := NewFile()
+	// Test truncate the author and text of the threaded comment by characters
+	author, text := strings.Repeat("作", MaxFieldLength+1), strings.Repeat("文", TotalCellChars+1)
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Author: author, Text: text}))
+	comments, err := f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, strings.Repeat("作", MaxFieldLength), comments[0].Author)
+	assert.Equal(t, strings.Repeat("文", TotalCellChars), comments[0].Text)
+	// Test add threaded comments after the threaded comments part of the
+	// previous worksheet was deleted
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddThreadedComment("Sheet2", ThreadedComment{Cell: "A1", Text: "Thread"}))
+	assert.NoError(t, f.DeleteSheet("Sheet1"))
+	assert.NoError(t, f.AddThreadedComment("Sheet3", ThreadedComment{Cell: "A1", Text: "Thread"}))
+	for sheet, expected := range map[string]string{"Sheet2": "xl/threadedComments/threadedComment2.xml", "Sheet3": "xl/threadedComments/threadedComment3.xml"} {
+		sheetXMLPath, ok := f.getSheetXMLPath(sheet)
+		assert.True(t, ok)
+		assert.Equal(t, expected, f.getSheetThreadedComments(filepath.Base(sheetXMLPath)), sheet)
+	}
+	assert.NoError(t, f.Close())
+}
+
 func TestDecodeVMLDrawingReader(t *testing.T) {
 	f := NewFile()
 	path := "xl/drawings/vmlDrawing1.xml"
@@ -192,7 +319,7 @@ func TestFormControl(t *testing.T) {
 		},
 		{
 			Cell: "A6", Type: FormControlCheckBox, Text: "Check Box 2",
-			Format: GraphicOptions{Positioning: "twoCell"},
+			Format: GraphicOptions{Positioning: "twoCell"}, CellLink: "B6",
 		},
 		{
 			Cell: "A7", Type: FormControlOptionButton, Text: "Option Button 1", Checked: true,
@@ -219,6 +346,13 @@ func TestFormControl(t *testing.T) {
 			Cell: "G1", Type: FormControlScrollBar, Width: 20, Height: 140,
 			CurrentVal: 50, MinVal: 1000, MaxVal: 100, IncChange: 1, PageChange: 1, CellLink: "C4",
 		},
+		{
+			Cell: "A10", Type: FormControlOptionButton, Text: "Option Button 3", CellLink: "B10",
+		},
+		{
+			Cell: "A11", Type: FormControlDropDown, Width: 100, Height: 20,
+			InputRange: "$H$1:$H$5", CellLink: "B11", CurrentVal: 2,
+		},
 	}
 	for _, formCtrl := range formControls {
 		assert.NoError(t, f.AddFormControl("Sheet1", formCtrl))
@@ -226,7 +360,7 @@ func TestFormControl(t *testing.T) {
 	// Test get from controls
 	result, err := f.GetFormControls("Sheet1")
 	assert.NoError(t, err)
-	assert.Len(t, result, 11)
+	assert.Len(t, result, 13)
 	for i, formCtrl := range formControls {
 		assert.Equal(t, formCtrl.Type, result[i].Type)
 		assert.Equal(t, formCtrl.Cell, result[i].Cell)
@@ -238,6 +372,7 @@ func TestFormControl(t *testing.T) {
 		assert.Equal(t, formCtrl.IncChange, result[i].IncChange)
 		assert.Equal(t, formCtrl.Horizontally, result[i].Horizontally)
 		assert.Equal(t, formCtrl.CellLink, result[i].CellLink)
+		assert.Equal(t, formCtrl.InputRange, result[i].InputRange)
 		assert.Equal(t, formCtrl.Text, result[i].Text)
 		assert.Equal(t, len(formCtrl.Paragraph), len(result[i].Paragraph))
 	}
@@ -252,7 +387,7 @@ func TestFormControl(t *testing.T) {
 	// Test get from controls before add form controls
 	result, err = f.GetFormControls("Sheet1")
 	assert.NoError(t, err)
-	assert.Len(t, result, 11)
+	assert.Len(t, result, 13)
 	// Test add from control to a worksheet which already contains form controls
 	assert.NoError(t, f.AddFormControl("Sheet1", FormControl{
 		Cell: "D4", Type: FormControlButton, Macro: "Button1_Click",
@@ -261,7 +396,7 @@ func TestFormControl(t *testing.T) {
 	// Test get from controls after add form controls
 	result, err = f.GetFormControls("Sheet1")
 	assert.NoError(t, err)
-	assert.Len(t, result, 12)
+	assert.Len(t, result, 14)
 	// Test add unsupported form control
 	assert.Equal(t, f.AddFormControl("Sheet1", FormControl{
 		Cell: "A1", Type: 0x37, Macro: "Button1_Click",
@@ -279,6 +414,14 @@ func TestFormControl(t *testing.T) {
 	assert.Equal(t, f.AddFormControl("Sheet1", FormControl{
 		Cell: "C5", Type: FormControlSpinButton, CellLink: "*",
 	}), newCellNameToCoordinatesError("*", newInvalidCellNameError("*")))
+	// Test add check box form control with illegal cell link reference
+	assert.Equal(t, f.AddFormControl("Sheet1", FormControl{
+		Cell: "A5", Type: FormControlCheckBox, CellLink: "*",
+	}), newCellNameToCoordinatesError("*", newInvalidCellNameError("*")))
+	// Test add drop-down form control with invalid selected value
+	assert.Equal(t, f.AddFormControl("Sheet1", FormControl{
+		Cell: "A11", Type: FormControlDropDown, CurrentVal: MaxFormControlValue + 1,
+	}), ErrFormControlValue)
 	// Test add spin form control with invalid scroll value
 	assert.Equal(t, f.AddFormControl("Sheet1", FormControl{
 		Cell: "C5", Type: FormControlSpinButton, CurrentVal: MaxFormControlValue + 1,
@@ -292,7 +435,7 @@ func TestFormControl(t *testing.T) {
 	// Test get from controls after delete form controls
 	result, err = f.GetFormControls("Sheet1")
 	assert.NoError(t, err)
-	assert.Len(t, result, 9)
+	assert.Len(t, result, 11)
 	// Test delete form control on not exists worksheet
 	assert.Equal(t, ErrSheetNotExist{"SheetN"}, f.DeleteFormControl("SheetN", "A1"))
 	// Test delete form control with illegal cell link reference
@@ -416,11 +559,14 @@ func TestExtractFormControl(t *testing.T) {
 func TestAddHeaderFooterImage(t *testing.T) {
 	f, sheet, wb := NewFile(), "Sheet1", filepath.Join("test", "TestAddHeaderFooterImage.xlsx")
 	headerFooterOptions := HeaderFooterOptions{
-		DifferentFirst: true,
-		OddHeader:      "&L&GExcelize&C&G&R&G",
-		OddFooter:      "&L&GExcelize&C&G&R&G",
-		FirstHeader:    "&L&GExcelize&C&G&R&G",
-		FirstFooter:    "&L&GExcelize&C&G&R&G",
+		DifferentFirst:   true,
+		DifferentOddEven: true,
+		OddHeader:        "&L&GExcelize&C&G&R&G",
+		OddFooter:        "&L&GExcelize&C&G&R&G",
+		EvenHeader:       "&L&GExcelize&C&G&R&G",
+		EvenFooter:       "&L&GExcelize&C&G&R&G",
+		FirstHeader:      "&L&GExcelize&C&G&R&G",
+		FirstFooter:      "&L&GExcelize&C&G&R&G",
 	}
 	assert.NoError(t, f.SetHeaderFooter(sheet, &headerFooterOptions))
 	assert.NoError(t, f.SetSheetView(sheet, -1, &ViewOptions{View: stringPtr("pageLayout")}))
@@ -438,6 +584,7 @@ func TestAddHeaderFooterImage(t *testing.T) {
 		file      []byte
 		isFooter  bool
 		firstPage bool
+		evenPage  bool
 		ext       string
 	}{
 		{position: HeaderFooterImagePositionLeft, file: images[".tif"], firstPage: true, ext: ".tif"},
@@ -452,12 +599,15 @@ func TestAddHeaderFooterImage(t *testing.T) {
 		{position: HeaderFooterImagePositionLeft, file: images[".tif"], isFooter: true, ext: ".tif"},
 		{position: HeaderFooterImagePositionCenter, file: images[".tif"], isFooter: true, ext: ".tif"},
 		{position: HeaderFooterImagePositionRight, file: images[".tif"], isFooter: true, ext: ".tif"},
+		{position: HeaderFooterImagePositionLeft, file: images[".gif"], evenPage: true, ext: ".gif"},
+		{position: HeaderFooterImagePositionRight, file: images[".gif"], isFooter: true, evenPage: true, ext: ".gif"},
 	} {
 		assert.NoError(t, f.AddHeaderFooterImage(sheet, &HeaderFooterImageOptions{
 			Position:  opt.position,
 			File:      opt.file,
 			IsFooter:  opt.isFooter,
 			FirstPage: opt.firstPage,
+			EvenPage:  opt.evenPage,
 			Extension: opt.ext,
 			Width:     "50pt",
 			Height:    "32pt",
@@ -467,10 +617,21 @@ func TestAddHeaderFooterImage(t *testing.T) {
 
 	// Test add header footer image with not exist sheet
 	assert.EqualError(t, f.AddHeaderFooterImage("SheetN", nil), "sheet SheetN does not exist")
+	// Test add header footer image with invalid options
+	assert.Equal(t, ErrParameterInvalid, f.AddHeaderFooterImage(sheet, nil))
+	assert.Equal(t, ErrParameterInvalid, f.AddHeaderFooterImage(sheet, &HeaderFooterImageOptions{
+		FirstPage: true, EvenPage: true, Extension: ".png",
+	}))
 	// Test add header footer image with unsupported file type
 	assert.Equal(t, f.AddHeaderFooterImage(sheet, &HeaderFooterImageOptions{
 		Extension: "jpg",
 	}), ErrImgExt)
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	var shapeIDs []string
+	for _, shape := range vml.Shape {
+		shapeIDs = append(shapeIDs, shape.ID)
+	}
+	assert.Equal(t, []string{"LHFIRST", "CHFIRST", "RHFIRST", "LFFIRST", "CFFIRST", "RFFIRST", "LH", "CH", "RH", "LF", "CF", "RF", "LHEVEN", "RFEVEN"}, shapeIDs)
 	assert.NoError(t, f.SaveAs(wb))
 	assert.NoError(t, f.Close())
 	// Test change already exist header image with the different image
@@ -20,7 +20,15 @@ import (
 	"strings"
 )
 
-// SetWorkbookProps provides a function to sets workbook properties.
+// SetWorkbookProps provides a function to sets workbook properties. The
+// Date1904 option specifies if the workbook uses the 1904 date system, which
+// is used by the workbooks created by the spreadsheet applications on macOS,
+// the date and time cell values and the date and time formula functions will
+// be stored and calculated in the specified date system. For example, use the
+// 1904 date system in the workbook:
+//
+//	date1904 := true
+//	err := f.SetWorkbookProps(&excelize.WorkbookPropsOptions{Date1904: &date1904})
 func (f *File) SetWorkbookProps(opts *WorkbookPropsOptions) error {
 	wb, err := f.workbookReader()
 	if err != nil {
@@ -59,13 +67,256 @@ func (f *File) GetWorkbookProps() (WorkbookPropsOptions, error) {
 	return opts, err
 }
 
+// getWorkbookView returns the first workbook view, create it if not exists.
+func (wb *xlsxWorkbook) getWorkbookView() *xlsxWorkBookView {
+	if wb.BookViews == nil {
+		wb.BookViews = &xlsxBookViews{}
+	}
+	if len(wb.BookViews.WorkBookView) == 0 {
+		wb.BookViews.WorkBookView = append(wb.BookViews.WorkBookView, xlsxWorkBookView{})
+	}
+	return &wb.BookViews.WorkBookView[0]
+}
+
+// SetWorkbookView provides a function to set the workbook view settings, such
+// as the active sheet when the workbook opened, the position and size of the
+// workbook window, the visibility of the scroll bars and sheet tabs, and the
+// ratio between the sheet tabs bar and the horizontal scroll bar. The window
+// position and size are in twips. For example, activate the second sheet,
+// hide the horizontal scroll bar and resize the workbook window:
+//
+//	activeTab, width, height, show := 1, 20000, 10000, false
+//	err := f.SetWorkbookView(&excelize.WorkbookViewOptions{
+//	    ActiveTab:            &activeTab,
+//	    WindowWidth:          &width,
+//	    WindowHeight:         &height,
+//	    ShowHorizontalScroll: &show,
+//	})
+func (f *File) SetWorkbookView(opts *WorkbookViewOptions) error {
+	wb, err := f.workbookReader()
+	if err != nil || opts == nil {
+		return err
+	}
+	view := wb.getWorkbookView()
+	if opts.FirstSheet != nil && *opts.FirstSheet >= 0 && *opts.FirstSheet < len(wb.Sheets.Sheet) {
+		view.FirstSheet = *opts.FirstSheet
+	}
+	if opts.XWindow != nil {
+		view.XWindow = strconv.Itoa(*opts.XWindow)
+	}
+	if opts.YWindow != nil {
+		view.YWindow = strconv.Itoa(*opts.YWindow)
+	}
+	if opts.WindowWidth != nil {
+		view.WindowWidth = *opts.WindowWidth
+	}
+	if opts.WindowHeight != nil {
+		view.WindowHeight = *opts.WindowHeight
+	}
+	if opts.ShowHorizontalScroll != nil {
+		view.ShowHorizontalScroll = opts.ShowHorizontalScroll
+	}
+	if opts.ShowVerticalScroll != nil {
+		view.ShowVerticalScroll = opts.ShowVerticalScroll
+	}
+	if opts.ShowSheetTabs != nil {
+		view.ShowSheetTabs = opts.ShowSheetTabs
+	}
+	if opts.TabRatio != nil && *opts.TabRatio >= 0 && *opts.TabRatio <= 1000 {
+		view.TabRatio = *opts.TabRatio
+	}
+	if opts.ActiveTab != nil {
+		f.SetActiveSheet(*opts.ActiveTab)
+	}
+	return err
+}
+
+// GetWorkbookView provides a function to get the workbook view settings.
+func (f *File) GetWorkbookView() (WorkbookViewOptions, error) {
+	opts := WorkbookViewOptions{
+		ActiveTab:            intPtr(0),
+		FirstSheet:           intPtr(0),
+		ShowHorizontalScroll: boolPtr(true),
+		ShowVerticalScroll:   boolPtr(true),
+		ShowSheetTabs:        boolPtr(true),
+		TabRatio:             float64Ptr(600),
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return opts, err
+	}
+	if wb.BookViews == nil || len(wb.BookViews.WorkBookView) == 0 {
+		return opts, err
+	}
+	view := wb.BookViews.WorkBookView[0]
+	opts.ActiveTab = intPtr(view.ActiveTab)
+	opts.FirstSheet = intPtr(view.FirstSheet)
+	if x, err := strconv.Atoi(view.XWindow); err == nil {
+		opts.XWindow = intPtr(x)
+	}
+	if y, err := strconv.Atoi(view.YWindow); err == nil {
+		opts.YWindow = intPtr(y)
+	}
+	if view.WindowWidth != 0 {
+		opts.WindowWidth = intPtr(view.WindowWidth)
+	}
+	if view.WindowHeight != 0 {
+		opts.WindowHeight = intPtr(view.WindowHeight)
+	}
+	if view.ShowHorizontalScroll != nil {
+		opts.ShowHorizontalScroll = view.ShowHorizontalScroll
+	}
+	if view.ShowVerticalScroll != nil {
+		opts.ShowVerticalScroll = view.ShowVerticalScroll
+	}
+	if view.ShowSheetTabs != nil {
+		opts.ShowSheetTabs = view.ShowSheetTabs
+	}
+	if view.TabRatio != 0 {
+		opts.TabRatio = float64Ptr(view.TabRatio)
+	}
+	return opts, err
+}
+
+// SetCalcProps provides a function to sets calculation properties. The
+// optional value of CalcMode is "manual", "auto" and "autoNoTable", and the
+// optional value of RefMode is "A1" and "R1C1". When the FullCalcOnLoad
+// option is enabled, the spreadsheet application will recalculate all
+// formulas in the workbook when it is opened, and the calculation chain of
+// the workbook will be removed because it will be rebuilt on load. For
+// example, mark the workbook for full recalculation on open:
+//
+//	fullCalcOnLoad := true
+//	err := f.SetCalcProps(&excelize.CalcPropsOptions{
+//	    FullCalcOnLoad: &fullCalcOnLoad,
+//	})
+func (f *File) SetCalcProps(opts *CalcPropsOptions) error {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.CalcPr == nil {
+		wb.CalcPr = new(xlsxCalcPr)
+	}
+	if opts == nil {
+		return err
+	}
+	if opts.CalcMode != nil {
+		if inStrSlice(supportedCalcMode, *opts.CalcMode, true) == -1 {
+			return newInvalidOptionalValue("CalcMode", *opts.CalcMode, supportedCalcMode)
+		}
+		wb.CalcPr.CalcMode = *opts.CalcMode
+	}
+	if opts.RefMode != nil {
+		if inStrSlice(supportedRefMode, *opts.RefMode, true) == -1 {
+			return newInvalidOptionalValue("RefMode", *opts.RefMode, supportedRefMode)
+		}
+		wb.CalcPr.RefMode = *opts.RefMode
+	}
+	if opts.CalcID != nil {
+		wb.CalcPr.CalcID = strconv.FormatUint(uint64(*opts.CalcID), 10)
+	}
+	if opts.FullCalcOnLoad != nil {
+		wb.CalcPr.FullCalcOnLoad = *opts.FullCalcOnLoad
+	}
+	if opts.Iterate != nil {
+		wb.CalcPr.Iterate = *opts.Iterate
+	}
+	if opts.IterateCount != nil {
+		wb.CalcPr.IterateCount = int(*opts.IterateCount)
+	}
+	if opts.IterateDelta != nil {
+		wb.CalcPr.IterateDelta = *opts.IterateDelta
+	}
+	if opts.FullPrecision != nil {
+		wb.CalcPr.FullPrecision = boolPtr(*opts.FullPrecision)
+	}
+	if opts.CalcCompleted != nil {
+		wb.CalcPr.CalcCompleted = boolPtr(*opts.CalcCompleted)
+	}
+	if opts.CalcOnSave != nil {
+		wb.CalcPr.CalcOnSave = boolPtr(*opts.CalcOnSave)
+	}
+	if opts.ConcurrentCalc != nil {
+		wb.CalcPr.ConcurrentCalc = opts.ConcurrentCalc
+	}
+	if opts.ConcurrentManualCount != nil {
+		wb.CalcPr.ConcurrentManualCount = int(*opts.ConcurrentManualCount)
+	}
+	if opts.ForceFullCalc != nil {
+		wb.CalcPr.ForceFullCalc = *opts.ForceFullCalc
+	}
+	if wb.CalcPr.FullCalcOnLoad {
+		return f.removeCalcChain()
+	}
+	return err
+}
+
+// GetCalcProps provides a function to gets calculation properties.
+func (f *File) GetCalcProps() (CalcPropsOptions, error) {
+	opts := CalcPropsOptions{
+		CalcMode:              stringPtr("auto"),
+		FullCalcOnLoad:        boolPtr(false),
+		RefMode:               stringPtr("A1"),
+		Iterate:               boolPtr(false),
+		IterateCount:          uintPtr(100),
+		IterateDelta:          float64Ptr(0.001),
+		FullPrecision:         boolPtr(true),
+		CalcCompleted:         boolPtr(true),
+		CalcOnSave:            boolPtr(true),
+		ConcurrentCalc:        boolPtr(true),
+		ConcurrentManualCount: uintPtr(0),
+		ForceFullCalc:         boolPtr(false),
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return opts, err
+	}
+	if wb.CalcPr == nil {
+		return opts, err
+	}
+	if calcID, err := strconv.ParseUint(wb.CalcPr.CalcID, 10, 32); err == nil {
+		opts.CalcID = uintPtr(uint(calcID))
+	}
+	if wb.CalcPr.CalcMode != "" {
+		opts.CalcMode = stringPtr(wb.CalcPr.CalcMode)
+	}
+	if wb.CalcPr.RefMode != "" {
+		opts.RefMode = stringPtr(wb.CalcPr.RefMode)
+	}
+	opts.FullCalcOnLoad = boolPtr(wb.CalcPr.FullCalcOnLoad)
+	opts.Iterate = boolPtr(wb.CalcPr.Iterate)
+	if wb.CalcPr.IterateCount != 0 {
+		opts.IterateCount = uintPtr(uint(wb.CalcPr.IterateCount))
+	}
+	if wb.CalcPr.IterateDelta != 0 {
+		opts.IterateDelta = float64Ptr(wb.CalcPr.IterateDelta)
+	}
+	if wb.CalcPr.FullPrecision != nil {
+		opts.FullPrecision = boolPtr(*wb.CalcPr.FullPrecision)
+	}
+	if wb.CalcPr.CalcCompleted != nil {
+		opts.CalcCompleted = boolPtr(*wb.CalcPr.CalcCompleted)
+	}
+	if wb.CalcPr.CalcOnSave != nil {
+		opts.CalcOnSave = boolPtr(*wb.CalcPr.CalcOnSave)
+	}
+	if wb.CalcPr.ConcurrentCalc != nil {
+		opts.ConcurrentCalc = boolPtr(*wb.CalcPr.ConcurrentCalc)
+	}
+	opts.ConcurrentManualCount = uintPtr(uint(wb.CalcPr.ConcurrentManualCount))
+	opts.ForceFullCalc = boolPtr(wb.CalcPr.ForceFullCalc)
+	return opts, err
+}
+
 // ProtectWorkbook provides a function to prevent other users from viewing
 // hidden worksheets, adding, moving, deleting, or hiding worksheets, and
 // renaming worksheets in a workbook. The optional field AlgorithmName
-// specified hash algorithm, support XOR, MD4, MD5, SHA-1, SHA2-56, SHA-384,
-// and SHA-512 currently, if no hash algorithm specified, will be using the XOR
-// algorithm as default. The generated workbook only works on Microsoft Office
-// 2007 and later. For example, protect workbook with protection settings:
+// specified hash algorithm, support XOR, MD4, MD5, SHA-1, SHA-256, SHA-384,
+// and SHA-512 currently, if no hash algorithm specified, will be using the
+// SHA-512 algorithm with salt and spin count as default. The generated
+// workbook only works on Microsoft Office 2007 and later. For example, protect
+// workbook with protection settings:
 //
 //	err := f.ProtectWorkbook(&excelize.WorkbookProtectionOptions{
 //	    Password:      "password",
@@ -87,6 +338,10 @@ func (f *File) ProtectWorkbook(opts *WorkbookProtectionOptions) error {
 		LockWindows:   opts.LockWindows,
 	}
 	if opts.Password != "" {
+		if opts.AlgorithmName == "XOR" {
+			wb.WorkbookProtection.WorkbookPassword = genSheetPasswd(opts.Password)
+			return nil
+		}
 		if opts.AlgorithmName == "" {
 			opts.AlgorithmName = "SHA-512"
 		}
@@ -115,6 +370,10 @@ func (f *File) UnprotectWorkbook(password ...string) error {
 		if wb.WorkbookProtection == nil {
 			return ErrUnprotectWorkbook
 		}
+		if wb.WorkbookProtection.WorkbookAlgorithmName == "" && wb.WorkbookProtection.WorkbookPassword != "" &&
+			wb.WorkbookProtection.WorkbookPassword != genSheetPasswd(password[0]) {
+			return ErrUnprotectWorkbookPassword
+		}
 		if wb.WorkbookProtection.WorkbookAlgorithmName != "" {
 			// check with given salt value
 			hashValue, _, err := genISOPasswdHash(password[0], wb.WorkbookProtection.WorkbookAlgorithmName, wb.WorkbookProtection.WorkbookSaltValue, wb.WorkbookProtection.WorkbookSpinCount)
@@ -130,6 +389,168 @@ func (f *File) UnprotectWorkbook(password ...string) error {
 	return err
 }
 
+// RemovePersonalInfo provides a function to remove the personal and hidden
+// information from the workbook before distributing it, like the Document
+// Inspector of the Office Excel application. This function removes the
+// following information, and enables removing the personal information from
+// the file properties on save:
+//
+//	 Information        | Description
+//	--------------------+------------------------------------------------------
+//	 Author metadata    | The author and last modified by of the document
+//	                    | properties, the manager and company of the
+//	                    | application properties, and the user who refreshed
+//	                    | the pivot caches.
+//	                    |
+//	 Absolute path      | The absolute path of the folder where the workbook
+//	                    | was saved by the spreadsheet application, and the
+//	                    | folder of the absolute file path of the linked
+//	                    | workbooks, which keeps the file name only.
+//	                    |
+//	 Printer settings   | The printer settings binary parts of the worksheets,
+//	                    | which may contain the printer name and paths.
+//	                    |
+//	 Comments           | All comments, threaded comments and the authors of
+//	                    | threaded comments in the worksheets.
+//
+// For example:
+//
+//	err := f.RemovePersonalInfo()
+func (f *File) RemovePersonalInfo() error {
+	if err := f.removeDocPropsPersonalInfo(); err != nil {
+		return err
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.WorkbookPr == nil {
+		wb.WorkbookPr = &xlsxWorkbookPr{}
+	}
+	wb.WorkbookPr.FilterPrivacy = true
+	if wb.DecodeAlternateContent != nil && strings.Contains(wb.DecodeAlternateContent.Content, "absPath") {
+		wb.DecodeAlternateContent = nil
+	}
+	if wb.AlternateContent != nil && strings.Contains(wb.AlternateContent.Content, "absPath") {
+		wb.AlternateContent = nil
+	}
+	for _, sheet := range f.GetSheetList() {
+		if err = f.removeSheetPersonalInfo(sheet); err != nil {
+			return err
+		}
+	}
+	if f.getPersonListPath() != "" {
+		persons, err := f.personListReader()
+		if err != nil {
+			return err
+		}
+		persons.Person = nil
+		output, _ := xml.Marshal(persons)
+		f.saveFileList(f.getPersonListPath(), output)
+	}
+	if err = f.removeExternalLinksPersonalInfo(); err != nil {
+		return err
+	}
+	for _, pivotCacheXML := range f.getPartNames("xl/pivotCache/pivotCacheDefinition") {
+		pc, err := f.pivotCacheReader(pivotCacheXML)
+		if err != nil {
+			return err
+		}
+		if pc.RefreshedBy == "" {
+			continue
+		}
+		pc.RefreshedBy = ""
+		pivotCache, _ := xml.Marshal(pc)
+		f.saveFileList(pivotCacheXML, pivotCache)
+	}
+	return err
+}
+
+// removeExternalLinksPersonalInfo provides a function to remove the folder of
+// the absolute file path of the linked workbooks, and keep the file name only.
+func (f *File) removeExternalLinksPersonalInfo() error {
+	linkPaths, err := f.getExternalLinkPaths()
+	if err != nil {
+		return err
+	}
+	for _, linkPath := range linkPaths {
+		if linkPath == "" {
+			continue
+		}
+		externalLink, err := f.externalLinkReader(linkPath)
+		if err != nil {
+			return err
+		}
+		if externalLink.ExternalBook == nil {
+			continue
+		}
+		rel, err := f.getExternalLinkRel(linkPath, externalLink.ExternalBook.RID)
+		if err != nil {
+			return err
+		}
+		if rel != nil && isAbsoluteFilePath(rel.Target) {
+			rel.Target = rel.Target[strings.LastIndexAny(rel.Target, "/\\")+1:]
+		}
+	}
+	return err
+}
+
+// isAbsoluteFilePath returns if the given target of the relationship is an
+// absolute file path, like the file URI, the UNC path, the path begins with
+// the root directory or the drive letter.
+func isAbsoluteFilePath(target string) bool {
+	if strings.HasPrefix(strings.ToLower(target), "file:") ||
+		strings.HasPrefix(target, "/") || strings.HasPrefix(target, "\\") {
+		return true
+	}
+	return len(target) > 1 && target[1] == ':' &&
+		('A' <= target[0] && target[0] <= 'Z' || 'a' <= target[0] && target[0] <= 'z')
+}
+
+// removeSheetPersonalInfo provides a function to remove the comments,
+// threaded comments and printer settings of the worksheet by given worksheet
+// name. The chart sheets and dialog sheets will be skipped.
+func (f *File) removeSheetPersonalInfo(sheet string) error {
+	name, _ := f.getSheetXMLPath(sheet)
+	if !strings.HasPrefix(name, "xl/worksheets/") {
+		return nil
+	}
+	comments, err := f.GetComments(sheet)
+	if err != nil {
+		return err
+	}
+	threadedComments, err := f.GetThreadedComments(sheet)
+	if err != nil {
+		return err
+	}
+	for _, comment := range comments {
+		if err = f.DeleteComment(sheet, comment.Cell); err != nil {
+			return err
+		}
+	}
+	for _, comment := range threadedComments {
+		if err = f.DeleteComment(sheet, comment.Cell); err != nil {
+			return err
+		}
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.PageSetUp == nil || ws.PageSetUp.RID == "" {
+		return err
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.PageSetUp.RID)
+	f.deleteSheetRelationships(sheet, ws.PageSetUp.RID)
+	ws.PageSetUp.RID = ""
+	if target == "" {
+		return err
+	}
+	if !strings.HasPrefix(target, "/") {
+		target = "xl" + strings.TrimPrefix(target, "..")
+	}
+	target = strings.TrimPrefix(target, "/")
+	f.deletePart(target)
+	return f.removeContentTypesPart(ContentTypeSpreadSheetMLPrinterSettings, "/"+target)
+}
+
 // setWorkbook update workbook property of the spreadsheet. Maximum 31
 // characters are allowed in sheet title.
 func (f *File) setWorkbook(name string, sheetID, rid int) {
@@ -144,7 +565,7 @@ func (f *File) setWorkbook(name string, sheetID, rid int) {
 // getWorkbookPath provides a function to get the path of the workbook.xml in
 // the spreadsheet.
 func (f *File) getWorkbookPath() (path string) {
-	if rels, _ := f.relsReader("_rels/.rels"); rels != nil {
+	if rels, _ := f.relsReader(defaultXMLPathRels); rels != nil {
 		rels.mu.Lock()
 		defer rels.mu.Unlock()
 		for _, rel := range rels.Relationships {
@@ -310,28 +731,34 @@ func (f *File) addContentTypePart(index int, contentType string) error {
 		"drawings": f.setContentTypePartImageExtensions,
 	}
 	partNames := map[string]string{
-		"chart":         "/xl/charts/chart" + strconv.Itoa(index) + ".xml",
-		"chartsheet":    "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
-		"comments":      "/xl/comments" + strconv.Itoa(index) + ".xml",
-		"drawings":      "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
-		"table":         "/xl/tables/table" + strconv.Itoa(index) + ".xml",
-		"pivotTable":    "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
-		"pivotCache":    "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
-		"sharedStrings": "/xl/sharedStrings.xml",
-		"slicer":        "/xl/slicers/slicer" + strconv.Itoa(index) + ".xml",
-		"slicerCache":   "/xl/slicerCaches/slicerCache" + strconv.Itoa(index) + ".xml",
+		"chart":            "/xl/charts/chart" + strconv.Itoa(index) + ".xml",
+		"chartsheet":       "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
+		"comments":         "/xl/comments" + strconv.Itoa(index) + ".xml",
+		"drawings":         "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
+		"table":            "/xl/tables/table" + strconv.Itoa(index) + ".xml",
+		"person":           "/xl/persons/person.xml",
+		"pivotTable":       "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
+		"pivotCache":       "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
+		"pivotRecords":     "/xl/pivotCache/pivotCacheRecords" + strconv.Itoa(index) + ".xml",
+		"sharedStrings":    "/xl/sharedStrings.xml",
+		"slicer":           "/xl/slicers/slicer" + strconv.Itoa(index) + ".xml",
+		"slicerCache":      "/xl/slicerCaches/slicerCache" + strconv.Itoa(index) + ".xml",
+		"threadedComments": "/xl/threadedComments/threadedComment" + strconv.Itoa(index) + ".xml",
 	}
 	contentTypes := map[string]string{
-		"chart":         ContentTypeDrawingML,
-		"chartsheet":    ContentTypeSpreadSheetMLChartsheet,
-		"comments":      ContentTypeSpreadSheetMLComments,
-		"drawings":      ContentTypeDrawing,
-		"table":         ContentTypeSpreadSheetMLTable,
-		"pivotTable":    ContentTypeSpreadSheetMLPivotTable,
-		"pivotCache":    ContentTypeSpreadSheetMLPivotCacheDefinition,
-		"sharedStrings": ContentTypeSpreadSheetMLSharedStrings,
-		"slicer":        ContentTypeSlicer,
-		"slicerCache":   ContentTypeSlicerCache,
+		"chart":            ContentTypeDrawingML,
+		"chartsheet":       ContentTypeSpreadSheetMLChartsheet,
+		"comments":         ContentTypeSpreadSheetMLComments,
+		"drawings":         ContentTypeDrawing,
+		"table":            ContentTypeSpreadSheetMLTable,
+		"person":           ContentTypePerson,
+		"pivotTable":       ContentTypeSpreadSheetMLPivotTable,
+		"pivotCache":       ContentTypeSpreadSheetMLPivotCacheDefinition,
+		"pivotRecords":     ContentTypeSpreadSheetMLPivotCacheRecords,
+		"sharedStrings":    ContentTypeSpreadSheetMLSharedStrings,
+		"slicer":           ContentTypeSlicer,
+		"slicerCache":      ContentTypeSlicerCache,
+		"threadedComments": ContentTypeThreadedComments,
 	}
 	s, ok := setContentType[contentType]
 	if ok {
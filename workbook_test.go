@@ -1,7 +1,13 @@
 package excelize
 
 import (
+	"encoding/xml"
+	"io"
+	"path/filepath"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -32,6 +38,236 @@ func TestWorkbookProps(t *testing.T) {
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestWorkbookView(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetWorkbookView(nil))
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	opts, err := f.GetWorkbookView()
+	assert.NoError(t, err)
+	assert.Equal(t, WorkbookViewOptions{
+		ActiveTab:            intPtr(0),
+		FirstSheet:           intPtr(0),
+		XWindow:              intPtr(0),
+		YWindow:              intPtr(0),
+		WindowWidth:          intPtr(14805),
+		WindowHeight:         intPtr(8010),
+		ShowHorizontalScroll: boolPtr(true),
+		ShowVerticalScroll:   boolPtr(true),
+		ShowSheetTabs:        boolPtr(true),
+		TabRatio:             float64Ptr(600),
+	}, opts)
+	expected := WorkbookViewOptions{
+		ActiveTab:            intPtr(1),
+		FirstSheet:           intPtr(1),
+		XWindow:              intPtr(120),
+		YWindow:              intPtr(240),
+		WindowWidth:          intPtr(20000),
+		WindowHeight:         intPtr(10000),
+		ShowHorizontalScroll: boolPtr(false),
+		ShowVerticalScroll:   boolPtr(false),
+		ShowSheetTabs:        boolPtr(false),
+		TabRatio:             float64Ptr(300),
+	}
+	assert.NoError(t, f.SetWorkbookView(&expected))
+	opts, err = f.GetWorkbookView()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, opts)
+	assert.Equal(t, 1, f.GetActiveSheetIndex())
+	// Test set workbook view with invalid first sheet and tab ratio
+	assert.NoError(t, f.SetWorkbookView(&WorkbookViewOptions{FirstSheet: intPtr(2), TabRatio: float64Ptr(1001)}))
+	opts, err = f.GetWorkbookView()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, opts)
+	// Test get and set workbook view without workbook view
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	wb.BookViews = nil
+	opts, err = f.GetWorkbookView()
+	assert.NoError(t, err)
+	assert.Nil(t, opts.WindowWidth)
+	assert.NoError(t, f.SetWorkbookView(&WorkbookViewOptions{WindowWidth: intPtr(100)}))
+	opts, err = f.GetWorkbookView()
+	assert.NoError(t, err)
+	assert.Equal(t, 100, *opts.WindowWidth)
+	// Test set workbook view with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetWorkbookView(&expected), "XML syntax error on line 1: invalid UTF-8")
+	// Test get workbook view with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetWorkbookView()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestCalcProps(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCalcProps(nil))
+	opts, err := f.GetCalcProps()
+	assert.NoError(t, err)
+	assert.Equal(t, uint(122211), *opts.CalcID)
+	assert.Equal(t, "auto", *opts.CalcMode)
+	assert.Equal(t, "A1", *opts.RefMode)
+	assert.False(t, *opts.FullCalcOnLoad)
+	assert.True(t, *opts.FullPrecision)
+	assert.True(t, *opts.CalcCompleted)
+	assert.True(t, *opts.CalcOnSave)
+	assert.True(t, *opts.ConcurrentCalc)
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	wb.CalcPr = nil
+	opts, err = f.GetCalcProps()
+	assert.NoError(t, err)
+	assert.Equal(t, CalcPropsOptions{
+		CalcMode:              stringPtr("auto"),
+		FullCalcOnLoad:        boolPtr(false),
+		RefMode:               stringPtr("A1"),
+		Iterate:               boolPtr(false),
+		IterateCount:          uintPtr(100),
+		IterateDelta:          float64Ptr(0.001),
+		FullPrecision:         boolPtr(true),
+		CalcCompleted:         boolPtr(true),
+		CalcOnSave:            boolPtr(true),
+		ConcurrentCalc:        boolPtr(true),
+		ConcurrentManualCount: uintPtr(0),
+		ForceFullCalc:         boolPtr(false),
+	}, opts)
+	// Test set calculation properties with the default false value of the
+	// attributes which default value is true
+	assert.NoError(t, f.SetCalcProps(&CalcPropsOptions{
+		FullPrecision: boolPtr(false), CalcCompleted: boolPtr(false), CalcOnSave: boolPtr(false),
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCalcProps.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestCalcProps.xlsx"))
+	assert.NoError(t, err)
+	opts, err = f.GetCalcProps()
+	assert.NoError(t, err)
+	assert.False(t, *opts.FullPrecision)
+	assert.False(t, *opts.CalcCompleted)
+	assert.False(t, *opts.CalcOnSave)
+	expected := CalcPropsOptions{
+		CalcID:                uintPtr(122211),
+		CalcMode:              stringPtr("manual"),
+		FullCalcOnLoad:        boolPtr(false),
+		RefMode:               stringPtr("R1C1"),
+		Iterate:               boolPtr(true),
+		IterateCount:          uintPtr(10),
+		IterateDelta:          float64Ptr(0.0001),
+		FullPrecision:         boolPtr(true),
+		CalcCompleted:         boolPtr(true),
+		CalcOnSave:            boolPtr(true),
+		ConcurrentCalc:        boolPtr(true),
+		ConcurrentManualCount: uintPtr(5),
+		ForceFullCalc:         boolPtr(true),
+	}
+	assert.NoError(t, f.SetCalcProps(&expected))
+	opts, err = f.GetCalcProps()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, opts)
+	// Test set calculation properties with invalid calculation mode
+	assert.Equal(t, newInvalidOptionalValue("CalcMode", "AUTO", supportedCalcMode),
+		f.SetCalcProps(&CalcPropsOptions{CalcMode: stringPtr("AUTO")}))
+	// Test set calculation properties with invalid reference mode
+	assert.EqualError(t, f.SetCalcProps(&CalcPropsOptions{RefMode: stringPtr("a1")}),
+		"invalid RefMode value \"a1\", acceptable value should be one of A1, R1C1")
+	// Test set full calculation on load remove the calculation chain
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "1+2"))
+	f.CalcChain = &xlsxCalcChain{C: []xlsxCalcChainC{{R: "A1", I: 1}}}
+	f.Pkg.Store(defaultXMLPathCalcChain, []byte(xml.Header+`<calcChain xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><c r="A1" i="1"/></calcChain>`))
+	content, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	content.Overrides = append(content.Overrides, xlsxOverride{PartName: "/xl/calcChain.xml"})
+	assert.NoError(t, f.SetCalcProps(&CalcPropsOptions{FullCalcOnLoad: boolPtr(true)}))
+	assert.Nil(t, f.CalcChain)
+	_, ok := f.Pkg.Load(defaultXMLPathCalcChain)
+	assert.False(t, ok)
+	for _, override := range content.Overrides {
+		assert.NotEqual(t, "/xl/calcChain.xml", override.PartName)
+	}
+	opts, err = f.GetCalcProps()
+	assert.NoError(t, err)
+	assert.True(t, *opts.FullCalcOnLoad)
+	// Test set full calculation on load with unsupported charset content types
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCalcProps(&CalcPropsOptions{FullCalcOnLoad: boolPtr(true)}), "XML syntax error on line 1: invalid UTF-8")
+	// Test set calculation properties with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCalcProps(&expected), "XML syntax error on line 1: invalid UTF-8")
+	// Test get calculation properties with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetCalcProps()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestWorkbookPropsDate1904(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetWorkbookProps(&WorkbookPropsOptions{Date1904: boolPtr(true)}))
+	date := time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", date))
+	// Test the date value stored in the 1904 date system
+	val, err := f.GetCellValue("Sheet1", "A1", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "43904", val)
+	val, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "03-15-24", val)
+	// Test get ISO 8601 date cell value in the 1904 date system
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "A2", 1))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[1].C[0] = xlsxC{R: "A2", S: 1, T: "d", V: "2024-03-15T00:00:00Z"}
+	val, err = f.GetCellValue("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "03-15-24", val)
+	// Test calculate date and time formula functions in the 1904 date system
+	for formula, expected := range map[string]string{
+		"YEAR(A1)":                           "2024",
+		"MONTH(A1)":                          "3",
+		"DAY(A1)":                            "15",
+		"WEEKDAY(A1)":                        "6",
+		"WEEKNUM(A1)":                        "11",
+		"ISOWEEKNUM(A1)":                     "11",
+		"DATE(2024,3,15)":                    "43904",
+		"DATEVALUE(\"2024-03-15\")":          "43904",
+		"VALUE(\"2024-03-15\")":              "43904",
+		"EDATE(A1,1)":                        "43935",
+		"EOMONTH(A1,0)":                      "43920",
+		"DAYS360(A1,DATE(2024,4,15))":        "30",
+		"DAY(DATE(1904,1,6))":                "6",
+		"HOUR(A1+0.5)":                       "12",
+		"MINUTE(A1+0.75)":                    "0",
+		"SECOND(A1+0.5001)":                  "9",
+		"DATEDIF(DATE(2024,1,15),A1,\"md\")": "0",
+		"WORKDAY(DATE(2024,1,5),1)-DATE(2024,1,5)":                                   "3",
+		"WORKDAY(DATE(2024,1,5),1,\"2024-01-08\")-DATE(2024,1,5)":                    "4",
+		"NETWORKDAYS(DATE(2024,1,1),DATE(2024,1,31))":                                "23",
+		"YEARFRAC(DATE(2024,1,1),DATE(2024,7,1))":                                    "0.5",
+		"COUPPCD(A1,DATE(2025,1,1),2)":                                               "43830",
+		"COUPNCD(A1,DATE(2025,1,1),2)":                                               "44012",
+		"COUPDAYBS(A1,DATE(2025,1,1),2,1)":                                           "74",
+		"COUPDAYSNC(A1,DATE(2025,1,1),2,1)":                                          "108",
+		"ODDFPRICE(A1,DATE(2030,1,1),DATE(2024,1,1),DATE(2024,7,1),0.05,0.06,100,2)": "95.1569796652871",
+		"ODDLPRICE(A1,DATE(2024,9,1),DATE(2024,1,1),0.05,0.06,100,2)":                "99.5236331855696",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "B1", formula))
+		result, err := f.CalcCellValue("Sheet1", "B1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	for _, formula := range []string{"TODAY()", "NOW()"} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "YEAR("+formula+")"))
+		result, err := f.CalcCellValue("Sheet1", "B1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, strconv.Itoa(time.Now().Year()), result, formula)
+	}
+	assert.NoError(t, f.Close())
+}
+
 func TestDeleteWorkbookRels(t *testing.T) {
 	f := NewFile()
 	// Test delete pivot table without worksheet relationships
@@ -41,3 +277,162 @@ func TestDeleteWorkbookRels(t *testing.T) {
 	assert.Empty(t, rID)
 	assert.NoError(t, err)
 }
+
+func TestRemovePersonalInfo(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetAppProps(&AppProperties{Application: "Microsoft Excel", Company: "Company Name"}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "B2", Author: "Excelize", Text: "Threaded comment"}))
+	f.Pkg.Store("xl/printerSettings/printerSettings1.bin", []byte("Printer"))
+	rID := f.addRels("xl/worksheets/_rels/sheet1.xml.rels", SourceRelationshipPrinterSettings, "../printerSettings/printerSettings1.bin", "")
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.PageSetUp = &xlsxPageSetUp{RID: "rId" + strconv.Itoa(rID)}
+	pivotCacheXML := "xl/pivotCache/pivotCacheDefinition1.xml"
+	f.Pkg.Store(pivotCacheXML, []byte(`<pivotCacheDefinition xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" refreshedBy="Excelize"/>`))
+	assert.NoError(t, f.AddChartSheet("Chart", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$A$1", Values: "Sheet1!$B$1"}},
+	}))
+
+	assert.NoError(t, f.RemovePersonalInfo())
+	docProps, err := f.GetDocProps()
+	assert.NoError(t, err)
+	assert.Empty(t, docProps.Creator)
+	assert.Empty(t, docProps.LastModifiedBy)
+	assert.Equal(t, "2016-08-29T12:40:10Z", docProps.Created)
+	appProps, err := f.GetAppProps()
+	assert.NoError(t, err)
+	assert.Empty(t, appProps.Company)
+	assert.Equal(t, "Microsoft Excel", appProps.Application)
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	assert.True(t, wb.WorkbookPr.FilterPrivacy)
+	assert.Nil(t, wb.DecodeAlternateContent)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, comments)
+	threadedComments, err := f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, threadedComments)
+	persons, err := f.personListReader()
+	assert.NoError(t, err)
+	assert.Empty(t, persons.Person)
+	assert.Empty(t, ws.PageSetUp.RID)
+	assert.Empty(t, f.getSheetRelationshipsTargetByID("Sheet1", "rId"+strconv.Itoa(rID)))
+	_, ok := f.Pkg.Load("xl/printerSettings/printerSettings1.bin")
+	assert.False(t, ok)
+	pc, err := f.pivotCacheReader(pivotCacheXML)
+	assert.NoError(t, err)
+	assert.Empty(t, pc.RefreshedBy)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemovePersonalInfo.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test remove personal information with the absolute path in the workbook
+	f = NewFile()
+	wb, err = f.workbookReader()
+	assert.NoError(t, err)
+	wb.AlternateContent = &xlsxAlternateContent{Content: `<mc:Choice Requires="x15"><x15ac:absPath url="C:\Users\" xmlns:x15ac="http://schemas.microsoft.com/office/spreadsheetml/2010/11/ac"/></mc:Choice>`}
+	assert.NoError(t, f.RemovePersonalInfo())
+	assert.Nil(t, wb.AlternateContent)
+
+	// Test remove personal information with the absolute path of the linked workbooks
+	f = NewFile()
+	prepareExternalLinks(t, f, "Book2.xlsx", "file:///C:\\Users\\Excelize\\Book3.xlsx", "\\\\server\\share\\Book4.xlsx", "/home/excelize/Book5.xlsx", "D:/Book6.xlsx", "../Book7.xlsx")
+	wb, err = f.workbookReader()
+	assert.NoError(t, err)
+	wb.ExternalReferences.ExternalReference = append(wb.ExternalReferences.ExternalReference, xlsxExternalReference{RID: "rId100"})
+	f.Pkg.Store("xl/externalLinks/externalLink6.xml", []byte(`<externalLink xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"/>`))
+	assert.NoError(t, f.RemovePersonalInfo())
+	links, err := f.GetExternalLinks()
+	assert.NoError(t, err)
+	var targets []string
+	for _, link := range links {
+		targets = append(targets, link.Target)
+	}
+	assert.Equal(t, []string{"Book2.xlsx", "Book3.xlsx", "Book4.xlsx", "Book5.xlsx", "Book6.xlsx", "", ""}, targets)
+	// Test remove personal information with unsupported charset external link
+	f.Pkg.Store("xl/externalLinks/externalLink1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RemovePersonalInfo(), "XML syntax error on line 1: invalid UTF-8")
+	// Test remove personal information with unsupported charset external link relationships
+	f.Pkg.Store("xl/externalLinks/externalLink1.xml", []byte(`<externalLink xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><externalBook r:id="rId1"/></externalLink>`))
+	f.Relationships.Delete("xl/externalLinks/_rels/externalLink1.xml.rels")
+	f.Pkg.Store("xl/externalLinks/_rels/externalLink1.xml.rels", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RemovePersonalInfo(), "XML syntax error on line 1: invalid UTF-8")
+	// Test remove personal information with unsupported charset workbook relationships
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RemovePersonalInfo(), "XML syntax error on line 1: invalid UTF-8")
+
+	// Test remove personal information with the parts in the temporary files
+	f = NewFile()
+	for name, content := range map[string]string{
+		"xl/printerSettings/printerSettings1.bin": "Printer",
+		pivotCacheXML: `<pivotCacheDefinition xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" refreshedBy="Excelize"/>`,
+	} {
+		assert.NoError(t, f.saveTempFile(name, func(w io.Writer) error {
+			_, err := w.Write([]byte(content))
+			return err
+		}))
+	}
+	rID = f.addRels("xl/worksheets/_rels/sheet1.xml.rels", SourceRelationshipPrinterSettings, "../printerSettings/printerSettings1.bin", "")
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.PageSetUp = &xlsxPageSetUp{RID: "rId" + strconv.Itoa(rID)}
+	assert.NoError(t, f.RemovePersonalInfo())
+	_, ok = f.tempFiles.Load("xl/printerSettings/printerSettings1.bin")
+	assert.False(t, ok)
+	pc, err = f.pivotCacheReader(pivotCacheXML)
+	assert.NoError(t, err)
+	assert.Empty(t, pc.RefreshedBy)
+	assert.NoError(t, f.Close())
+
+	// Test remove personal information with unsupported charset document properties
+	for _, partName := range []string{defaultXMLPathDocPropsCore, defaultXMLPathDocPropsApp} {
+		f = NewFile()
+		f.Pkg.Store(partName, MacintoshCyrillicCharset)
+		assert.EqualError(t, f.RemovePersonalInfo(), "XML syntax error on line 1: invalid UTF-8")
+	}
+	// Test remove personal information with unsupported charset workbook
+	f = NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RemovePersonalInfo(), "XML syntax error on line 1: invalid UTF-8")
+	// Test remove personal information with unsupported charset worksheet
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	assert.EqualError(t, f.RemovePersonalInfo(), "XML syntax error on line 1: invalid UTF-8")
+	// Test remove personal information with unsupported charset comments
+	f = NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	f.Comments["xl/comments1.xml"] = nil
+	f.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RemovePersonalInfo(), "XML syntax error on line 1: invalid UTF-8")
+	// Test remove personal information with unsupported charset threaded comments
+	f = NewFile()
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	f.Pkg.Store("xl/threadedComments/threadedComment1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RemovePersonalInfo(), "XML syntax error on line 1: invalid UTF-8")
+	// Test remove personal information with unsupported charset persons
+	f = NewFile()
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	f.Pkg.Store("xl/persons/person.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RemovePersonalInfo(), "XML syntax error on line 1: invalid UTF-8")
+	// Test remove personal information with unsupported charset pivot cache
+	f = NewFile()
+	f.Pkg.Store(pivotCacheXML, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RemovePersonalInfo(), "XML syntax error on line 1: invalid UTF-8")
+	// Test remove personal information with unsupported charset content types
+	f = NewFile()
+	f.Pkg.Store("xl/printerSettings/printerSettings1.bin", []byte("Printer"))
+	rID = f.addRels("xl/worksheets/_rels/sheet1.xml.rels", SourceRelationshipPrinterSettings, "../printerSettings/printerSettings1.bin", "")
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.PageSetUp = &xlsxPageSetUp{RID: "rId" + strconv.Itoa(rID)}
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RemovePersonalInfo(), "XML syntax error on line 1: invalid UTF-8")
+}
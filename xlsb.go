@@ -0,0 +1,486 @@
+// Copyright 2016 - 2025 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.20 or later.
+
+package excelize
+
+import (
+	"encoding/binary"
+	"encoding/xml"
+	"math"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Binary record types of the XLSB (BIFF12) workbook parts used by the reader.
+const (
+	xlsbRowHdr      = 0x0000
+	xlsbCellBlank   = 0x0001
+	xlsbCellRk      = 0x0002
+	xlsbCellError   = 0x0003
+	xlsbCellBool    = 0x0004
+	xlsbCellReal    = 0x0005
+	xlsbCellSt      = 0x0006
+	xlsbCellIsst    = 0x0007
+	xlsbFmlaString  = 0x0008
+	xlsbFmlaNum     = 0x0009
+	xlsbFmlaBool    = 0x000A
+	xlsbFmlaError   = 0x000B
+	xlsbSSTItem     = 0x0013
+	xlsbFmt         = 0x002C
+	xlsbXF          = 0x002F
+	xlsbColInfo     = 0x003C
+	xlsbWbProp      = 0x0099
+	xlsbBundleSh    = 0x009C
+	xlsbMergeCell   = 0x00B0
+	xlsbBeginCellXF = 0x0269
+	xlsbEndCellXF   = 0x026A
+)
+
+// xlsbErrors defined the error values of the cells by the binary error code.
+var xlsbErrors = map[byte]string{
+	0x00: formulaErrorNULL, 0x07: formulaErrorDIV, 0x0F: formulaErrorVALUE,
+	0x17: formulaErrorREF, 0x1D: formulaErrorNAME, 0x24: formulaErrorNUM,
+	0x2A: formulaErrorNA, 0x2B: formulaErrorGETTINGDATA,
+}
+
+// xlsbRecord directly maps a record in the binary workbook parts.
+type xlsbRecord struct {
+	typ  int
+	data []byte
+}
+
+// xlsbReader provides a reader for the records and the fields of the records
+// in the binary workbook parts.
+type xlsbReader struct {
+	buf []byte
+	pos int
+	err error
+}
+
+// varint read a variable-length integer with the given max length in bytes.
+func (r *xlsbReader) varint(maxLen int) int {
+	var v int
+	for i := 0; i < maxLen; i++ {
+		if r.pos >= len(r.buf) {
+			r.err = ErrWorkbookFileFormat
+			return 0
+		}
+		b := r.buf[r.pos]
+		r.pos++
+		v |= int(b&0x7F) << (7 * i)
+		if b&0x80 == 0 {
+			break
+		}
+	}
+	return v
+}
+
+// next read the next record, returns false on the end of the part or when
+// the part was truncated.
+func (r *xlsbReader) next() (xlsbRecord, bool) {
+	if r.err != nil || r.pos >= len(r.buf) {
+		return xlsbRecord{}, false
+	}
+	typ, size := r.varint(2), r.varint(4)
+	if r.err != nil || r.pos+size > len(r.buf) {
+		r.err = ErrWorkbookFileFormat
+		return xlsbRecord{}, false
+	}
+	rec := xlsbRecord{typ: typ, data: r.buf[r.pos : r.pos+size]}
+	r.pos += size
+	return rec, true
+}
+
+// bytes read n bytes of the record fields.
+func (r *xlsbReader) bytes(n int) []byte {
+	if r.err != nil || n < 0 || r.pos+n > len(r.buf) {
+		r.err = ErrWorkbookFileFormat
+		return make([]byte, int(math.Max(float64(n), 0)))
+	}
+	b := r.buf[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+// uint8 read an unsigned 8-bit integer of the record fields.
+func (r *xlsbReader) uint8() uint8 { return r.bytes(1)[0] }
+
+// uint16 read an unsigned 16-bit integer of the record fields.
+func (r *xlsbReader) uint16() uint16 { return binary.LittleEndian.Uint16(r.bytes(2)) }
+
+// uint32 read an unsigned 32-bit integer of the record fields.
+func (r *xlsbReader) uint32() uint32 { return binary.LittleEndian.Uint32(r.bytes(4)) }
+
+// float64 read a double precision floating number of the record fields.
+func (r *xlsbReader) float64() float64 {
+	return math.Float64frombits(binary.LittleEndian.Uint64(r.bytes(8)))
+}
+
+// wideString read a length-prefixed UTF-16 string of the record fields, the
+// nullable string with the length 0xFFFFFFFF will be returned as empty.
+func (r *xlsbReader) wideString() string {
+	n := r.uint32()
+	if n == math.MaxUint32 || r.err != nil {
+		return ""
+	}
+	if int(n) > (len(r.buf)-r.pos)/2 {
+		r.err = ErrWorkbookFileFormat
+		return ""
+	}
+	b, u := r.bytes(int(n)*2), make([]uint16, n)
+	for i := range u {
+		u[i] = binary.LittleEndian.Uint16(b[i*2:])
+	}
+	return string(utf16.Decode(u))
+}
+
+// xlsbRkNumber returns the number by given RK encoded value.
+func xlsbRkNumber(rk uint32) float64 {
+	var num float64
+	if rk&0x02 != 0 {
+		num = float64(int32(rk) >> 2)
+	} else {
+		num = math.Float64frombits(uint64(rk&^0x03) << 32)
+	}
+	if rk&0x01 != 0 {
+		num /= 100
+	}
+	return num
+}
+
+// xlsbTarget returns the part path of converted XML part by given path of
+// the binary part.
+func xlsbTarget(name string) string {
+	if strings.HasSuffix(strings.ToLower(name), ".bin") {
+		return name[:len(name)-4] + ".xml"
+	}
+	return name
+}
+
+// readXLSBPart read the binary part by given path, and delete the part from
+// the package.
+func (f *File) readXLSBPart(name string) ([]byte, error) {
+	content, err := f.readPart(name, true)
+	f.Pkg.Delete(name)
+	if tempPath, ok := f.tempFiles.Load(name); ok {
+		f.tempFiles.Delete(name)
+		_ = os.Remove(tempPath.(string))
+	}
+	return content, err
+}
+
+// convertXLSB provides a function to convert the parts of the XLSB (binary
+// workbook) to the XML parts, the worksheets structure, cell values, shared
+// strings and cell number formats will be converted, and the formulas will
+// be read as the cached values.
+func (f *File) convertXLSB() error {
+	wbPath := f.getWorkbookPath()
+	if !strings.HasSuffix(strings.ToLower(wbPath), ".bin") {
+		return nil
+	}
+	rootRels, _ := f.relsReader(defaultXMLPathRels)
+	wbRelsPath := f.getWorkbookRelsPath()
+	content, err := f.readXLSBPart(wbPath)
+	if err != nil {
+		return err
+	}
+	wb, err := xlsbWorkbook(content)
+	if err != nil {
+		return err
+	}
+	rels, err := f.relsReader(wbRelsPath)
+	if err != nil {
+		return err
+	}
+	if rels == nil {
+		return ErrWorkbookFileFormat
+	}
+	f.Relationships.Delete(wbRelsPath)
+	f.Pkg.Delete(wbRelsPath)
+	var (
+		wbDir      = path.Dir(wbPath)
+		partTypes  = map[string]string{}
+		partWriter = map[string]func([]byte) ([]byte, error){
+			SourceRelationshipWorkSheet:     xlsbWorksheet,
+			SourceRelationshipSharedStrings: xlsbSharedStrings,
+			SourceRelationshipStyles:        xlsbStyles,
+		}
+		contentTypes = map[string]string{
+			SourceRelationshipWorkSheet:     ContentTypeSpreadSheetMLWorksheet,
+			SourceRelationshipSharedStrings: ContentTypeSpreadSheetMLSharedStrings,
+			SourceRelationshipStyles:        ContentTypeSpreadSheetMLStyles,
+		}
+		relationships []xlsxRelationship
+	)
+	for _, rel := range rels.Relationships {
+		if rel.TargetMode == "External" || !strings.HasSuffix(strings.ToLower(rel.Target), ".bin") {
+			relationships = append(relationships, rel)
+			continue
+		}
+		partPath := strings.TrimPrefix(rel.Target, "/")
+		if !strings.HasPrefix(rel.Target, "/") {
+			partPath = path.Join(wbDir, rel.Target)
+		}
+		content, err := f.readXLSBPart(partPath)
+		if err != nil {
+			return err
+		}
+		writer, ok := partWriter[rel.Type]
+		if !ok {
+			continue
+		}
+		output, err := writer(content)
+		if err != nil {
+			return err
+		}
+		f.Pkg.Store(xlsbTarget(partPath), output)
+		partTypes["/"+xlsbTarget(partPath)] = contentTypes[rel.Type]
+		rel.Target = xlsbTarget(rel.Target)
+		relationships = append(relationships, rel)
+	}
+	newWbPath := xlsbTarget(wbPath)
+	output, _ := xml.Marshal(&xlsxRelationships{Relationships: relationships})
+	f.Pkg.Store(path.Join(wbDir, "_rels", path.Base(newWbPath)+".rels"), output)
+	output, _ = xml.Marshal(wb)
+	f.Pkg.Store(newWbPath, replaceRelationshipsBytes(f.replaceNameSpaceBytes(newWbPath, output)))
+	partTypes["/"+newWbPath] = ContentTypeSheetML
+	for idx, rel := range rootRels.Relationships {
+		if rel.Type == SourceRelationshipOfficeDocument {
+			rootRels.Relationships[idx].Target = xlsbTarget(rel.Target)
+		}
+	}
+	f.relsWriter()
+	f.Relationships.Delete(defaultXMLPathRels)
+	return f.convertXLSBContentTypes(partTypes)
+}
+
+// convertXLSBContentTypes provides a function to replace the content types of
+// the binary parts by given converted XML parts content types.
+func (f *File) convertXLSBContentTypes(partTypes map[string]string) error {
+	content, err := f.contentTypesReader()
+	if err != nil {
+		return err
+	}
+	var overrides []xlsxOverride
+	for _, override := range content.Overrides {
+		if !strings.HasSuffix(strings.ToLower(override.PartName), ".bin") {
+			overrides = append(overrides, override)
+		}
+	}
+	partNames := make([]string, 0, len(partTypes))
+	for partName := range partTypes {
+		partNames = append(partNames, partName)
+	}
+	sort.Strings(partNames)
+	for _, partName := range partNames {
+		overrides = append(overrides, xlsxOverride{PartName: partName, ContentType: partTypes[partName]})
+	}
+	content.Overrides = overrides
+	f.contentTypesWriter()
+	f.ContentTypes = nil
+	return nil
+}
+
+// xlsbWorkbook provides a function to convert the binary workbook part to
+// the workbook.
+func xlsbWorkbook(content []byte) (*xlsxWorkbook, error) {
+	wb := &xlsxWorkbook{WorkbookPr: &xlsxWorkbookPr{}}
+	r := &xlsbReader{buf: content}
+	for rec, ok := r.next(); ok; rec, ok = r.next() {
+		fields := &xlsbReader{buf: rec.data}
+		switch rec.typ {
+		case xlsbWbProp:
+			wb.WorkbookPr.Date1904 = fields.uint32()&0x01 != 0
+		case xlsbBundleSh:
+			sheet := xlsxSheet{}
+			state := fields.uint32()
+			sheet.SheetID = int(fields.uint32())
+			sheet.ID = fields.wideString()
+			sheet.Name = fields.wideString()
+			if state == 1 {
+				sheet.State = "hidden"
+			}
+			if state == 2 {
+				sheet.State = "veryHidden"
+			}
+			wb.Sheets.Sheet = append(wb.Sheets.Sheet, sheet)
+		}
+		if fields.err != nil {
+			return wb, fields.err
+		}
+	}
+	return wb, r.err
+}
+
+// xlsbSharedStrings provides a function to convert the binary shared strings
+// part to the XML part.
+func xlsbSharedStrings(content []byte) ([]byte, error) {
+	sst := xlsxSST{}
+	r := &xlsbReader{buf: content}
+	for rec, ok := r.next(); ok; rec, ok = r.next() {
+		if rec.typ != xlsbSSTItem {
+			continue
+		}
+		fields := &xlsbReader{buf: rec.data}
+		_ = fields.uint8()
+		si := xlsxSI{T: &xlsxT{Val: fields.wideString()}}
+		if fields.err != nil {
+			return nil, fields.err
+		}
+		si.T.Space = xml.Attr{Name: xml.Name{Space: NameSpaceXML, Local: "space"}, Value: "preserve"}
+		sst.SI = append(sst.SI, si)
+	}
+	sst.Count, sst.UniqueCount = len(sst.SI), len(sst.SI)
+	output, _ := xml.Marshal(&sst)
+	return output, r.err
+}
+
+// xlsbStyles provides a function to convert the number formats of the cell
+// formats in the binary styles part to the XML part, the fonts, fills and
+// borders of the cells will be reset to default.
+func xlsbStyles(content []byte) ([]byte, error) {
+	var (
+		styleSheet xlsxStyleSheet
+		numFmts    xlsxNumFmts
+		cellXfs    xlsxCellXfs
+		inCellXfs  bool
+	)
+	_ = xml.Unmarshal([]byte(templateStyles), &styleSheet)
+	r := &xlsbReader{buf: content}
+	for rec, ok := r.next(); ok; rec, ok = r.next() {
+		fields := &xlsbReader{buf: rec.data}
+		switch rec.typ {
+		case xlsbFmt:
+			numFmt := &xlsxNumFmt{NumFmtID: int(fields.uint16())}
+			numFmt.FormatCode = fields.wideString()
+			numFmts.NumFmt = append(numFmts.NumFmt, numFmt)
+		case xlsbBeginCellXF:
+			inCellXfs = true
+		case xlsbEndCellXF:
+			inCellXfs = false
+		case xlsbXF:
+			if inCellXfs {
+				_ = fields.uint16()
+				numFmtID := int(fields.uint16())
+				cellXfs.Xf = append(cellXfs.Xf, xlsxXf{
+					NumFmtID: &numFmtID, FontID: intPtr(0), FillID: intPtr(0),
+					BorderID: intPtr(0), XfID: intPtr(0), ApplyNumberFormat: boolPtr(numFmtID != 0),
+				})
+			}
+		}
+		if fields.err != nil {
+			return nil, fields.err
+		}
+	}
+	if numFmts.Count = len(numFmts.NumFmt); numFmts.Count > 0 {
+		styleSheet.NumFmts = &numFmts
+	}
+	if cellXfs.Count = len(cellXfs.Xf); cellXfs.Count > 0 {
+		styleSheet.CellXfs = &cellXfs
+	}
+	output, _ := xml.Marshal(&styleSheet)
+	return output, r.err
+}
+
+// xlsbCell provides a function to convert the binary cell record to the
+// cell, returns false if the record isn't a cell record.
+func xlsbCell(rec xlsbRecord, row int) (xlsxC, bool, error) {
+	if rec.typ < xlsbCellBlank || rec.typ > xlsbFmlaError {
+		return xlsxC{}, false, nil
+	}
+	fields := &xlsbReader{buf: rec.data}
+	col := int(fields.uint32()) + 1
+	c := xlsxC{S: int(fields.uint32() & 0xFFFFFF)}
+	c.R, _ = CoordinatesToCellName(col, row)
+	switch rec.typ {
+	case xlsbCellRk:
+		c.V = strconv.FormatFloat(xlsbRkNumber(fields.uint32()), 'f', -1, 64)
+	case xlsbCellError, xlsbFmlaError:
+		c.T, c.V = "e", xlsbErrors[fields.uint8()]
+	case xlsbCellBool, xlsbFmlaBool:
+		c.T, c.V = "b", strconv.Itoa(int(fields.uint8()&0x01))
+	case xlsbCellReal, xlsbFmlaNum:
+		c.V = strconv.FormatFloat(fields.float64(), 'f', -1, 64)
+	case xlsbCellSt, xlsbFmlaString:
+		c.T, c.V = "str", fields.wideString()
+	case xlsbCellIsst:
+		c.T, c.V = "s", strconv.FormatUint(uint64(fields.uint32()), 10)
+	}
+	return c, true, fields.err
+}
+
+// xlsbWorksheet provides a function to convert the binary worksheet part to
+// the XML part, includes the cell values, merged cells, columns and rows
+// properties.
+func xlsbWorksheet(content []byte) ([]byte, error) {
+	var (
+		ws  = xlsxWorksheet{SheetFormatPr: &xlsxSheetFormatPr{DefaultRowHeight: defaultRowHeight}}
+		row *xlsxRow
+		r   = &xlsbReader{buf: content}
+	)
+	for rec, ok := r.next(); ok; rec, ok = r.next() {
+		fields := &xlsbReader{buf: rec.data}
+		switch rec.typ {
+		case xlsbRowHdr:
+			ws.SheetData.Row = append(ws.SheetData.Row, xlsxRow{R: int(fields.uint32()) + 1})
+			row = &ws.SheetData.Row[len(ws.SheetData.Row)-1]
+			_ = fields.uint32()
+			height := float64(fields.uint16()) / 20
+			_ = fields.uint8()
+			flags := fields.uint8()
+			row.Hidden = flags&0x10 != 0
+			if row.CustomHeight = flags&0x20 != 0; row.CustomHeight {
+				row.Ht = &height
+			}
+		case xlsbMergeCell:
+			rect := []int{int(fields.uint32()) + 1, int(fields.uint32()) + 1, int(fields.uint32()) + 1, int(fields.uint32()) + 1}
+			if fields.err == nil {
+				if ws.MergeCells == nil {
+					ws.MergeCells = &xlsxMergeCells{}
+				}
+				ref, _ := coordinatesToRangeRef([]int{rect[2], rect[0], rect[3], rect[1]})
+				ws.MergeCells.Cells = append(ws.MergeCells.Cells, &xlsxMergeCell{Ref: ref})
+				ws.MergeCells.Count = len(ws.MergeCells.Cells)
+			}
+		case xlsbColInfo:
+			col := xlsxCol{Min: int(fields.uint32()) + 1, Max: int(fields.uint32()) + 1}
+			width := float64(fields.uint32()) / 256
+			col.Style = int(fields.uint32())
+			flags := fields.uint16()
+			col.Hidden, col.CustomWidth = flags&0x01 != 0, flags&0x02 != 0
+			col.Width = &width
+			if ws.Cols == nil {
+				ws.Cols = &xlsxCols{}
+			}
+			ws.Cols.Col = append(ws.Cols.Col, col)
+		default:
+			if row == nil {
+				break
+			}
+			c, ok, err := xlsbCell(rec, row.R)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				row.C = append(row.C, c)
+			}
+		}
+		if fields.err != nil {
+			return nil, fields.err
+		}
+	}
+	output, _ := xml.Marshal(&ws)
+	return output, r.err
+}
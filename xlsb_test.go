@@ -0,0 +1,219 @@
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"math"
+	"path/filepath"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// xlsbTestRecord returns the binary record by given record type and fields.
+func xlsbTestRecord(typ int, fields ...interface{}) []byte {
+	var data bytes.Buffer
+	for _, field := range fields {
+		switch v := field.(type) {
+		case string:
+			u := utf16.Encode([]rune(v))
+			_ = binary.Write(&data, binary.LittleEndian, uint32(len(u)))
+			_ = binary.Write(&data, binary.LittleEndian, u)
+		default:
+			_ = binary.Write(&data, binary.LittleEndian, v)
+		}
+	}
+	var buf bytes.Buffer
+	for i, v := range []int{typ, data.Len()} {
+		for n := 0; n < []int{2, 4}[i]; n++ {
+			b := byte(v & 0x7F)
+			if v >>= 7; v > 0 {
+				b |= 0x80
+			}
+			buf.WriteByte(b)
+			if v == 0 {
+				break
+			}
+		}
+	}
+	buf.Write(data.Bytes())
+	return buf.Bytes()
+}
+
+// xlsbTestFile returns the XLSB workbook by given parts.
+func xlsbTestFile(t *testing.T, parts map[string][]byte) []byte {
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for name, content := range parts {
+		fi, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = fi.Write(content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+// xlsbTestParts returns the parts of a XLSB workbook with two worksheets.
+func xlsbTestParts() map[string][]byte {
+	join := func(records ...[]byte) []byte { return bytes.Join(records, nil) }
+	cell := func(typ, col, style int, value ...interface{}) []byte {
+		return xlsbTestRecord(typ, append([]interface{}{uint32(col), uint32(style)}, value...)...)
+	}
+	return map[string][]byte{
+		"[Content_Types].xml":        []byte(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="bin" ContentType="application/vnd.ms-excel.sheet.binary.macroEnabled.main"/><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/worksheets/sheet1.bin" ContentType="application/vnd.ms-excel.worksheet"/><Override PartName="/xl/worksheets/sheet2.bin" ContentType="application/vnd.ms-excel.worksheet"/><Override PartName="/xl/sharedStrings.bin" ContentType="application/vnd.ms-excel.sharedStrings"/><Override PartName="/xl/styles.bin" ContentType="application/vnd.ms-excel.styles"/><Override PartName="/xl/calcChain.bin" ContentType="application/vnd.ms-excel.calcChain"/></Types>`),
+		"_rels/.rels":                []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.bin"/></Relationships>`),
+		"xl/_rels/workbook.bin.rels": []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.bin"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="/xl/worksheets/sheet2.bin"/><Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="sharedStrings.bin"/><Relationship Id="rId4" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.bin"/><Relationship Id="rId5" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/calcChain" Target="calcChain.bin"/><Relationship Id="rId6" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" Target="https://github.com/xuri/excelize" TargetMode="External"/></Relationships>`),
+		"xl/workbook.bin": join(
+			xlsbTestRecord(xlsbWbProp, uint32(0), uint32(0)),
+			xlsbTestRecord(xlsbBundleSh, uint32(0), uint32(1), "rId1", "Sheet1"),
+			xlsbTestRecord(xlsbBundleSh, uint32(1), uint32(2), "rId2", "Hidden"),
+		),
+		"xl/sharedStrings.bin": join(
+			xlsbTestRecord(xlsbSSTItem, uint8(0), "Name"),
+			xlsbTestRecord(xlsbSSTItem, uint8(0), "Amount"),
+			xlsbTestRecord(xlsbSSTItem, uint8(0), "Date"),
+		),
+		"xl/styles.bin": join(
+			xlsbTestRecord(xlsbFmt, uint16(164), "yyyy-mm-dd"),
+			xlsbTestRecord(xlsbXF, uint16(0xFFFF), uint16(0)),
+			xlsbTestRecord(xlsbBeginCellXF, uint32(2)),
+			xlsbTestRecord(xlsbXF, uint16(0), uint16(0)),
+			xlsbTestRecord(xlsbXF, uint16(0), uint16(164)),
+			xlsbTestRecord(xlsbEndCellXF),
+		),
+		"xl/calcChain.bin": {},
+		"xl/worksheets/sheet1.bin": join(
+			xlsbTestRecord(xlsbColInfo, uint32(0), uint32(1), uint32(20*256), uint32(0), uint16(0x02)),
+			xlsbTestRecord(xlsbColInfo, uint32(4), uint32(4), uint32(9*256), uint32(0), uint16(0x01)),
+			cell(xlsbCellIsst, 0, 0, uint32(0)),
+			xlsbTestRecord(xlsbRowHdr, uint32(0), uint32(0), uint16(600), uint8(0), uint8(0x20)),
+			cell(xlsbCellIsst, 0, 0, uint32(0)),
+			cell(xlsbCellIsst, 1, 0, uint32(1)),
+			cell(xlsbCellIsst, 2, 0, uint32(2)),
+			xlsbTestRecord(xlsbRowHdr, uint32(1), uint32(0), uint16(300), uint8(0), uint8(0)),
+			cell(xlsbCellSt, 0, 0, "Apple"),
+			cell(xlsbCellRk, 1, 0, uint32(1234<<2|0x02)),
+			cell(xlsbCellReal, 2, 1, 45292.0),
+			cell(xlsbCellBool, 3, 0, uint8(1)),
+			cell(xlsbCellBlank, 4, 0),
+			xlsbTestRecord(xlsbRowHdr, uint32(2), uint32(0), uint16(300), uint8(0), uint8(0x10)),
+			cell(xlsbCellRk, 0, 0, uint32(12345<<2|0x03)),
+			cell(xlsbCellRk, 1, 0, uint32(math.Float64bits(2.5)>>32)),
+			cell(xlsbCellError, 2, 0, uint8(0x07)),
+			cell(xlsbFmlaString, 3, 0, "Formula", uint16(0)),
+			cell(xlsbFmlaNum, 4, 0, 3.5, uint16(0)),
+			xlsbTestRecord(xlsbRowHdr, uint32(3), uint32(0), uint16(300), uint8(0), uint8(0)),
+			cell(xlsbFmlaBool, 0, 0, uint8(0), uint16(0)),
+			cell(xlsbFmlaError, 1, 0, uint8(0x2A), uint16(0)),
+			cell(xlsbCellSt, 2, 0, "Merged"),
+			xlsbTestRecord(xlsbMergeCell, uint32(3), uint32(4), uint32(2), uint32(3)),
+		),
+		"xl/worksheets/sheet2.bin": join(
+			xlsbTestRecord(xlsbRowHdr, uint32(0), uint32(0), uint16(300), uint8(0), uint8(0)),
+			cell(xlsbCellSt, 0, 0, "Hidden"),
+		),
+	}
+}
+
+func TestOpenXLSB(t *testing.T) {
+	f, err := OpenReader(bytes.NewReader(xlsbTestFile(t, xlsbTestParts())))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1", "Hidden"}, f.GetSheetList())
+	visible, err := f.GetSheetVisible("Hidden")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Name", "Amount", "Date"},
+		{"Apple", "1234", "2024-01-01", "TRUE"},
+		{"123.45", "2.5", "#DIV/0!", "Formula", "3.5"},
+		{"FALSE", "#N/A", "Merged"},
+	}, rows)
+	value, err := f.GetCellValue("Hidden", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Hidden", value)
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "C4:D5", mergeCells[0][0])
+	height, err := f.GetRowHeight("Sheet1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, height)
+	rowVisible, err := f.GetRowVisible("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.False(t, rowVisible)
+	width, err := f.GetColWidth("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	colVisible, err := f.GetColVisible("Sheet1", "E")
+	assert.NoError(t, err)
+	assert.False(t, colVisible)
+	for _, part := range []string{"xl/workbook.bin", "xl/styles.bin", "xl/calcChain.bin", "xl/worksheets/sheet1.bin"} {
+		_, ok := f.Pkg.Load(part)
+		assert.False(t, ok, part)
+	}
+	// Test save the converted workbook as XLSX
+	assert.NoError(t, f.SetCellValue("Sheet1", "A6", "Saved"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOpenXLSB.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestOpenXLSB.xlsx"))
+	assert.NoError(t, err)
+	value, err = f.GetCellValue("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-01-01", value)
+	value, err = f.GetCellValue("Sheet1", "A6")
+	assert.NoError(t, err)
+	assert.Equal(t, "Saved", value)
+	assert.NoError(t, f.Close())
+
+	// Test open the workbook with truncated binary parts
+	for _, part := range []string{"xl/workbook.bin", "xl/sharedStrings.bin", "xl/styles.bin", "xl/worksheets/sheet1.bin"} {
+		parts := xlsbTestParts()
+		parts[part] = parts[part][:len(parts[part])-1]
+		_, err = OpenReader(bytes.NewReader(xlsbTestFile(t, parts)))
+		assert.Equal(t, ErrWorkbookFileFormat, err, part)
+	}
+	// Test open the workbook with invalid record fields
+	for part, content := range map[string][]byte{
+		"xl/workbook.bin":          xlsbTestRecord(xlsbBundleSh, uint32(0)),
+		"xl/sharedStrings.bin":     xlsbTestRecord(xlsbSSTItem, uint8(0), uint32(2)),
+		"xl/styles.bin":            xlsbTestRecord(xlsbFmt, uint16(164)),
+		"xl/worksheets/sheet1.bin": xlsbTestRecord(xlsbRowHdr, uint32(0)),
+	} {
+		parts := xlsbTestParts()
+		parts[part] = content
+		_, err = OpenReader(bytes.NewReader(xlsbTestFile(t, parts)))
+		assert.Equal(t, ErrWorkbookFileFormat, err, part)
+	}
+	parts := xlsbTestParts()
+	parts["xl/worksheets/sheet1.bin"] = bytes.Join([][]byte{
+		xlsbTestRecord(xlsbRowHdr, uint32(0), uint32(0), uint16(300), uint8(0), uint8(0)),
+		xlsbTestRecord(xlsbCellRk, uint32(0)),
+	}, nil)
+	_, err = OpenReader(bytes.NewReader(xlsbTestFile(t, parts)))
+	assert.Equal(t, ErrWorkbookFileFormat, err)
+	// Test open the workbook without workbook relationships
+	parts = xlsbTestParts()
+	delete(parts, "xl/_rels/workbook.bin.rels")
+	_, err = OpenReader(bytes.NewReader(xlsbTestFile(t, parts)))
+	assert.Equal(t, ErrWorkbookFileFormat, err)
+	// Test open the workbook with unsupported charset workbook relationships
+	parts = xlsbTestParts()
+	parts["xl/_rels/workbook.bin.rels"] = MacintoshCyrillicCharset
+	_, err = OpenReader(bytes.NewReader(xlsbTestFile(t, parts)))
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test open the workbook with unsupported charset content types
+	parts = xlsbTestParts()
+	parts["[Content_Types].xml"] = MacintoshCyrillicCharset
+	_, err = OpenReader(bytes.NewReader(xlsbTestFile(t, parts)))
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestXLSBRkNumber(t *testing.T) {
+	assert.Equal(t, -5.0, xlsbRkNumber(uint32(0xFFFFFFEC|0x02)))
+	assert.Equal(t, 0.01, xlsbRkNumber(uint32(math.Float64bits(1)>>32|0x01)))
+}
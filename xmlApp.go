@@ -73,3 +73,54 @@ type xlsxVectorLpstr struct {
 type xlsxDigSig struct {
 	Content string `xml:",innerxml"`
 }
+
+// CustomProperty directly maps the custom property of the workbook. The value
+// date type should be one of the following: int32, int, int64, float32,
+// float64, bool, string, time.Time or nil.
+type CustomProperty struct {
+	Name  string
+	Value interface{}
+}
+
+// xlsxCustomProperties specifies the custom properties of the document, which
+// are defined by the document author.
+type xlsxCustomProperties struct {
+	XMLName  xml.Name             `xml:"http://schemas.openxmlformats.org/officeDocument/2006/custom-properties Properties"`
+	Vt       string               `xml:"xmlns:vt,attr"`
+	Property []xlsxCustomProperty `xml:"property"`
+}
+
+// xlsxCustomProperty specifies a single custom property of the document, the
+// value of the property is one of the document property variant types.
+type xlsxCustomProperty struct {
+	FmtID      string `xml:"fmtid,attr"`
+	PID        int    `xml:"pid,attr"`
+	Name       string `xml:"name,attr,omitempty"`
+	LinkTarget string `xml:"linkTarget,attr,omitempty"`
+	Value      string `xml:",innerxml"`
+}
+
+// decodeCustomProperties directly maps the root element of the custom
+// document properties part.
+type decodeCustomProperties struct {
+	XMLName  xml.Name               `xml:"Properties"`
+	Property []decodeCustomProperty `xml:"property"`
+}
+
+// decodeCustomProperty directly maps the property element in the custom
+// document properties part.
+type decodeCustomProperty struct {
+	FmtID      string                    `xml:"fmtid,attr"`
+	PID        int                       `xml:"pid,attr"`
+	Name       string                    `xml:"name,attr"`
+	LinkTarget string                    `xml:"linkTarget,attr"`
+	Value      decodeCustomPropertyValue `xml:",any"`
+	Content    string                    `xml:",innerxml"`
+}
+
+// decodeCustomPropertyValue directly maps the variant type value element of
+// the custom document property.
+type decodeCustomPropertyValue struct {
+	XMLName xml.Name
+	Text    string `xml:",chardata"`
+}
@@ -105,7 +105,7 @@ type aBodyPr struct {
 // paragraph of content in the document.
 type aP struct {
 	PPr        *aPPr        `xml:"a:pPr"`
-	R          *aR          `xml:"a:r"`
+	R          []*aR        `xml:"a:r"`
 	EndParaRPr *aEndParaRPr `xml:"a:endParaRPr"`
 }
 
@@ -404,6 +404,8 @@ type cSer struct {
 	DLbls            *cDLbls      `xml:"dLbls"`
 	Marker           *cMarker     `xml:"marker"`
 	InvertIfNegative *attrValBool `xml:"invertIfNegative"`
+	Trendline        *cTrendline  `xml:"trendline"`
+	ErrBars          *cErrBars    `xml:"errBars"`
 	Cat              *cCat        `xml:"cat"`
 	Val              *cVal        `xml:"val"`
 	XVal             *cCat        `xml:"xVal"`
@@ -413,6 +415,31 @@ type cSer struct {
 	Bubble3D         *attrValBool `xml:"bubble3D"`
 }
 
+// cTrendline (Trendline) directly maps the trendline element. This element
+// specifies a trendline.
+type cTrendline struct {
+	SpPr          *cSpPr         `xml:"spPr"`
+	TrendlineType *attrValString `xml:"trendlineType"`
+	Order         *attrValInt    `xml:"order"`
+	Period        *attrValInt    `xml:"period"`
+	Forward       *attrValFloat  `xml:"forward"`
+	Backward      *attrValFloat  `xml:"backward"`
+	Intercept     *attrValFloat  `xml:"intercept"`
+	DispRSqr      *attrValBool   `xml:"dispRSqr"`
+	DispEq        *attrValBool   `xml:"dispEq"`
+}
+
+// cErrBars (Error Bars) directly maps the errBars element. This element
+// specifies the error bars.
+type cErrBars struct {
+	ErrDir     *attrValString `xml:"errDir"`
+	ErrBarType *attrValString `xml:"errBarType"`
+	ErrValType *attrValString `xml:"errValType"`
+	NoEndCap   *attrValBool   `xml:"noEndCap"`
+	Val        *attrValFloat  `xml:"val"`
+	SpPr       *cSpPr         `xml:"spPr"`
+}
+
 // cMarker (Marker) directly maps the marker element. This element specifies a
 // data marker.
 type cMarker struct {
@@ -433,6 +460,7 @@ type cDPt struct {
 // specifies the data used for the category axis.
 type cCat struct {
 	StrRef *cStrRef `xml:"strRef"`
+	NumRef *cNumRef `xml:"numRef"`
 }
 
 // cStrRef (String Reference) directly maps the strRef element. This element
@@ -610,6 +638,28 @@ type ChartLine struct {
 	Width  float64
 }
 
+// ChartTrendline directly maps the format settings of the chart series
+// trendline.
+type ChartTrendline struct {
+	Type            ChartTrendlineType
+	Order           int
+	Period          int
+	Forward         float64
+	Backward        float64
+	Intercept       *float64
+	DisplayEquation bool
+	DisplayRSquared bool
+}
+
+// ChartErrorBars directly maps the format settings of the chart series error
+// bars.
+type ChartErrorBars struct {
+	Type      ChartErrorBarsType
+	Direction string
+	Value     float64
+	NoEndCap  bool
+}
+
 // ChartSeries directly maps the format settings of the chart series.
 type ChartSeries struct {
 	Name              string
@@ -620,4 +670,6 @@ type ChartSeries struct {
 	Line              ChartLine
 	Marker            ChartMarker
 	DataLabelPosition ChartDataLabelPositionType
+	Trendline         ChartTrendline
+	ErrorBars         ChartErrorBars
 }
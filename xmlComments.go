@@ -11,7 +11,10 @@
 
 package excelize
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"time"
+)
 
 // xlsxComments directly maps the comments element from the namespace
 // http://schemas.openxmlformats.org/spreadsheetml/2006/main. A comment is a
@@ -72,6 +75,51 @@ type xlsxPhoneticRun struct {
 	T  string `xml:"t"`
 }
 
+// xlsxThreadedComments directly maps the ThreadedComments element from the
+// namespace http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments.
+// This element is the root of the threaded comments part, each threaded
+// comment is a top-level comment or a reply to a top-level comment.
+type xlsxThreadedComments struct {
+	XMLName         xml.Name              `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments ThreadedComments"`
+	XMLNSX          string                `xml:"xmlns:x,attr"`
+	ThreadedComment []xlsxThreadedComment `xml:"threadedComment"`
+	ExtLst          *xlsxInnerXML         `xml:"extLst"`
+}
+
+// xlsxThreadedComment directly maps the threadedComment element. This element
+// represents a single comment in a thread, the parentId attribute references
+// the top-level comment of the thread for replies.
+type xlsxThreadedComment struct {
+	Ref      string        `xml:"ref,attr,omitempty"`
+	DT       string        `xml:"dT,attr,omitempty"`
+	PersonID string        `xml:"personId,attr"`
+	ID       string        `xml:"id,attr"`
+	ParentID string        `xml:"parentId,attr,omitempty"`
+	Done     bool          `xml:"done,attr,omitempty"`
+	Text     string        `xml:"text"`
+	Mentions *xlsxInnerXML `xml:"mentions"`
+	ExtLst   *xlsxInnerXML `xml:"extLst"`
+}
+
+// xlsxPersonList directly maps the personList element. This element is the
+// root of the persons part, contains the authors of the threaded comments in
+// the workbook.
+type xlsxPersonList struct {
+	XMLName xml.Name      `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments personList"`
+	XMLNSX  string        `xml:"xmlns:x,attr"`
+	Person  []xlsxPerson  `xml:"person"`
+	ExtLst  *xlsxInnerXML `xml:"extLst"`
+}
+
+// xlsxPerson directly maps the person element. This element represents an
+// author of the threaded comments.
+type xlsxPerson struct {
+	DisplayName string `xml:"displayName,attr"`
+	ID          string `xml:"id,attr"`
+	UserID      string `xml:"userId,attr,omitempty"`
+	ProviderID  string `xml:"providerId,attr,omitempty"`
+}
+
 // Comment directly maps the comment information.
 type Comment struct {
 	Author    string
@@ -80,5 +128,20 @@ type Comment struct {
 	Text      string
 	Width     uint
 	Height    uint
+	Visible   bool
+	FillColor string
 	Paragraph []RichTextRun
+	Format    GraphicOptions
+}
+
+// ThreadedComment directly maps the threaded comment information. Replies
+// specifies the replies of the top-level comment in the thread, and Done
+// specifies the resolution state of the thread.
+type ThreadedComment struct {
+	Cell    string
+	Author  string
+	Text    string
+	Date    time.Time
+	Done    bool
+	Replies []ThreadedComment
 }
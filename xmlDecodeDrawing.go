@@ -23,6 +23,7 @@ type decodeCellAnchor struct {
 	To               *decodeTo               `xml:"to"`
 	Sp               *decodeSp               `xml:"sp"`
 	Pic              *decodePic              `xml:"pic"`
+	GraphicFrame     *decodeGraphicFrame     `xml:"graphicFrame"`
 	ClientData       *decodeClientData       `xml:"clientData"`
 	AlternateContent []*xlsxAlternateContent `xml:"AlternateContent"`
 	Content          string                  `xml:",innerxml"`
@@ -60,6 +61,26 @@ type decodeChoice struct {
 type decodeGraphicFrame struct {
 	Macro            string                 `xml:"macro,attr"`
 	NvGraphicFramePr decodeNvGraphicFramePr `xml:"nvGraphicFramePr"`
+	Graphic          *decodeGraphic         `xml:"graphic"`
+}
+
+// decodeGraphic defines the structure used to deserialize the a:graphic
+// element.
+type decodeGraphic struct {
+	GraphicData decodeGraphicData `xml:"graphicData"`
+}
+
+// decodeGraphicData defines the structure used to deserialize the
+// a:graphicData element.
+type decodeGraphicData struct {
+	URI   string            `xml:"uri,attr"`
+	Chart *decodeGraphicRef `xml:"chart"`
+}
+
+// decodeGraphicRef defines the structure used to deserialize the c:chart
+// element in the graphic data.
+type decodeGraphicRef struct {
+	RID string `xml:"id,attr"`
 }
 
 // decodeNvGraphicFramePr defines the structure used to deserialize the
@@ -274,3 +295,52 @@ type decodeCellImages struct {
 type decodeCellImage struct {
 	Pic decodePic `xml:"pic"`
 }
+
+// decodeChartSpace defines the structure used to deserialize the rich text
+// of the chart and axis titles in the chart part.
+type decodeChartSpace struct {
+	Chart decodeChart `xml:"chart"`
+}
+
+// decodeChart defines the structure used to deserialize the c:chart element.
+type decodeChart struct {
+	Title    *decodeChartTitle    `xml:"title"`
+	PlotArea *decodeChartPlotArea `xml:"plotArea"`
+}
+
+// decodeChartPlotArea defines the structure used to deserialize the axes in
+// the c:plotArea element.
+type decodeChartPlotArea struct {
+	CatAx []decodeChartAxis `xml:"catAx"`
+	ValAx []decodeChartAxis `xml:"valAx"`
+	SerAx []decodeChartAxis `xml:"serAx"`
+}
+
+// decodeChartAxis defines the structure used to deserialize the c:catAx,
+// c:valAx and c:serAx elements.
+type decodeChartAxis struct {
+	AxID  attrValInt        `xml:"axId"`
+	Title *decodeChartTitle `xml:"title"`
+}
+
+// decodeChartTitle defines the structure used to deserialize the c:title
+// element.
+type decodeChartTitle struct {
+	Rich *decodeChartRich `xml:"tx>rich"`
+}
+
+// decodeChartRich defines the structure used to deserialize the c:rich
+// element.
+type decodeChartRich struct {
+	P []decodeChartP `xml:"p"`
+}
+
+// decodeChartP defines the structure used to deserialize the a:p element.
+type decodeChartP struct {
+	R []decodeChartR `xml:"r"`
+}
+
+// decodeChartR defines the structure used to deserialize the a:r element.
+type decodeChartR struct {
+	T string `xml:"t"`
+}
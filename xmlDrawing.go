@@ -102,6 +102,7 @@ type xlsxPrstGeom struct {
 // frame. This transformation is applied to the graphic frame just as it would
 // be for a shape or group shape.
 type xlsxXfrm struct {
+	Rot int     `xml:"rot,attr,omitempty"`
 	Off xlsxOff `xml:"a:off"`
 	Ext aExt    `xml:"a:ext"`
 }
@@ -161,8 +162,17 @@ type xlsxBlipFill struct {
 // has a minimum value of greater than or equal to 0. This simple type has a
 // maximum value of less than or equal to 20116800.
 type xlsxLineProperties struct {
-	W         int           `xml:"w,attr,omitempty"`
-	SolidFill *xlsxInnerXML `xml:"a:solidFill"`
+	W         int            `xml:"w,attr,omitempty"`
+	SolidFill *xlsxInnerXML  `xml:"a:solidFill"`
+	PrstDash  *attrValString `xml:"a:prstDash"`
+	HeadEnd   *aLineEnd      `xml:"a:headEnd"`
+	TailEnd   *aLineEnd      `xml:"a:tailEnd"`
+}
+
+// aLineEnd directly maps the a:headEnd and a:tailEnd element. This element
+// specifies the decoration added to the head or tail of a line.
+type aLineEnd struct {
+	Type string `xml:"type,attr,omitempty"`
 }
 
 // xlsxSpPr directly maps the spPr (Shape Properties). This element specifies
@@ -174,9 +184,52 @@ type xlsxSpPr struct {
 	Xfrm      xlsxXfrm           `xml:"a:xfrm"`
 	PrstGeom  xlsxPrstGeom       `xml:"a:prstGeom"`
 	SolidFill *xlsxInnerXML      `xml:"a:solidFill"`
+	GradFill  *aGradFill         `xml:"a:gradFill"`
+	BlipFill  *xlsxBlipFill      `xml:"a:blipFill"`
 	Ln        xlsxLineProperties `xml:"a:ln"`
 }
 
+// aGradFill (Gradient Fill) directly maps the a:gradFill element. This element
+// defines a gradient fill, the gradient is specified by a list of gradient
+// stops and the linear or path shade properties.
+type aGradFill struct {
+	RotWithShape bool   `xml:"rotWithShape,attr"`
+	GsLst        []*aGs `xml:"a:gsLst>a:gs"`
+	Lin          *aLin  `xml:"a:lin"`
+	Path         *aPath `xml:"a:path"`
+}
+
+// aGs (Gradient stops) directly maps the a:gs element. This element defines a
+// gradient stop, the position is specified in 1000th of a percent.
+type aGs struct {
+	Pos     int            `xml:"pos,attr"`
+	SrgbClr *attrValString `xml:"a:srgbClr"`
+}
+
+// aLin (Linear Gradient Fill) directly maps the a:lin element. This element
+// specifies a linear gradient, the angle is specified in 60000th of a degree.
+type aLin struct {
+	Ang    int  `xml:"ang,attr"`
+	Scaled bool `xml:"scaled,attr"`
+}
+
+// aPath (Path Gradient) directly maps the a:path element. This element
+// defines that a gradient fill follows a path vs. a linear line.
+type aPath struct {
+	Path       string       `xml:"path,attr"`
+	FillToRect *aFillToRect `xml:"a:fillToRect"`
+}
+
+// aFillToRect (Fill To Rectangle) directly maps the a:fillToRect element. This
+// element defines the focus rectangle for the center shade, specified in
+// 1000th of a percent relative to the bounding box of the shape.
+type aFillToRect struct {
+	L int `xml:"l,attr"`
+	T int `xml:"t,attr"`
+	R int `xml:"r,attr"`
+	B int `xml:"b,attr"`
+}
+
 // xlsxPic elements encompass the definition of pictures within the DrawingML
 // framework. While pictures are in many ways very similar to shapes they have
 // specific properties that are unique in order to optimize for picture-
@@ -224,6 +277,7 @@ type xdrCellAnchor struct {
 	To               *xlsxTo                 `xml:"xdr:to"`
 	Ext              *aExt                   `xml:"xdr:ext"`
 	Sp               *xdrSp                  `xml:"xdr:sp"`
+	CxnSp            *xdrCxnSp               `xml:"xdr:cxnSp"`
 	Pic              *xlsxPic                `xml:"xdr:pic,omitempty"`
 	GraphicFrame     string                  `xml:",innerxml"`
 	AlternateContent []*xlsxAlternateContent `xml:"mc:AlternateContent"`
@@ -357,6 +411,25 @@ type xdrSp struct {
 	TxBody   *xdrTxBody `xml:"xdr:txBody"`
 }
 
+// xdrCxnSp (Connection Shape) directly maps the xdr:cxnSp element. This
+// element specifies a connection shape that is used to connect two shapes,
+// the connection shape has no text body.
+type xdrCxnSp struct {
+	XMLName   xml.Name      `xml:"xdr:cxnSp"`
+	Macro     string        `xml:"macro,attr"`
+	NvCxnSpPr *xdrNvCxnSpPr `xml:"xdr:nvCxnSpPr"`
+	SpPr      *xlsxSpPr     `xml:"xdr:spPr"`
+	Style     *xdrStyle     `xml:"xdr:style"`
+}
+
+// xdrNvCxnSpPr (Non-Visual Properties for a Connection Shape) directly maps
+// the xdr:nvCxnSpPr element. This element specifies all non-visual properties
+// for a connection shape.
+type xdrNvCxnSpPr struct {
+	CNvPr      *xlsxCNvPr `xml:"xdr:cNvPr"`
+	CNvCxnSpPr struct{}   `xml:"xdr:cNvCxnSpPr"`
+}
+
 // xdrNvSpPr (Non-Visual Properties for a Shape) directly maps the xdr:nvSpPr
 // element. This element specifies all non-visual properties for a shape. This
 // element is a container for the non-visual identification properties, shape
@@ -442,6 +515,8 @@ type GraphicOptions struct {
 	OffsetY             int
 	ScaleX              float64
 	ScaleY              float64
+	Width               uint
+	Height              uint
 	Hyperlink           string
 	HyperlinkType       string
 	Positioning         string
@@ -449,19 +524,25 @@ type GraphicOptions struct {
 
 // Shape directly maps the format settings of the shape.
 type Shape struct {
-	Cell      string
-	Type      string
-	Macro     string
-	Width     uint
-	Height    uint
-	Format    GraphicOptions
-	Fill      Fill
-	Line      ShapeLine
-	Paragraph []RichTextRun
+	Cell        string
+	Type        string
+	Macro       string
+	Width       uint
+	Height      uint
+	Rotation    int
+	Format      GraphicOptions
+	Fill        Fill
+	PictureFill *Picture
+	Line        ShapeLine
+	Paragraph   []RichTextRun
+	InlineRuns  bool
 }
 
 // ShapeLine directly maps the line settings of the shape.
 type ShapeLine struct {
-	Color string
-	Width *float64
+	Color   string
+	Width   *float64
+	Dash    string
+	HeadEnd string
+	TailEnd string
 }
@@ -0,0 +1,45 @@
+// Copyright 2016 - 2025 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.20 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxExternalLink directly maps the externalLink element. This part holds
+// data for an external reference to another workbook, a DDE or an OLE data
+// source.
+type xlsxExternalLink struct {
+	XMLName      xml.Name          `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main externalLink"`
+	ExternalBook *xlsxExternalBook `xml:"externalBook"`
+}
+
+// xlsxExternalBook directly maps the externalBook element. This element
+// specifies the relationship to the external workbook and the names of the
+// worksheets in the external workbook.
+type xlsxExternalBook struct {
+	RID        string             `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr,omitempty"`
+	SheetNames *xlsxExtSheetNames `xml:"sheetNames"`
+}
+
+// xlsxExtSheetNames directly maps the sheetNames element. This element
+// specifies the collection of the worksheet names in the external workbook.
+type xlsxExtSheetNames struct {
+	SheetName []attrValString `xml:"sheetName"`
+}
+
+// ExternalLink directly maps the settings of an external workbook reference.
+// The Index is the 1-based index used in the formulas to reference the
+// external workbook, such as the 1 in "[1]Sheet1!A1".
+type ExternalLink struct {
+	Index      int
+	Target     string
+	SheetNames []string
+}
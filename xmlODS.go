@@ -0,0 +1,171 @@
+// Copyright 2016 - 2025 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.20 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// odsManifest directly maps the manifest element in the META-INF/manifest.xml
+// of the OpenDocument spreadsheet package.
+type odsManifest struct {
+	XMLName       xml.Name           `xml:"manifest:manifest"`
+	XMLNSManifest string             `xml:"xmlns:manifest,attr"`
+	Version       string             `xml:"manifest:version,attr"`
+	FileEntry     []odsManifestEntry `xml:"manifest:file-entry"`
+}
+
+// odsManifestEntry directly maps the file-entry element, which specifies the
+// full path and the media type of a file in the package.
+type odsManifestEntry struct {
+	FullPath  string `xml:"manifest:full-path,attr"`
+	MediaType string `xml:"manifest:media-type,attr"`
+	Version   string `xml:"manifest:version,attr,omitempty"`
+}
+
+// odsDocumentStyles directly maps the document-styles element, which is the
+// root element of the styles.xml in the OpenDocument spreadsheet package.
+type odsDocumentStyles struct {
+	XMLName     xml.Name  `xml:"office:document-styles"`
+	XMLNSOffice string    `xml:"xmlns:office,attr"`
+	XMLNSStyle  string    `xml:"xmlns:style,attr"`
+	XMLNSFo     string    `xml:"xmlns:fo,attr"`
+	Version     string    `xml:"office:version,attr"`
+	Styles      odsStyles `xml:"office:styles"`
+}
+
+// odsStyles directly maps the styles element, which contains the common
+// styles of the document.
+type odsStyles struct {
+	DefaultStyle []odsStyle `xml:"style:default-style"`
+}
+
+// odsDocumentContent directly maps the document-content element, which is
+// the root element of the content.xml in the OpenDocument spreadsheet
+// package.
+type odsDocumentContent struct {
+	XMLName         xml.Name           `xml:"office:document-content"`
+	XMLNSOffice     string             `xml:"xmlns:office,attr"`
+	XMLNSStyle      string             `xml:"xmlns:style,attr"`
+	XMLNSText       string             `xml:"xmlns:text,attr"`
+	XMLNSTable      string             `xml:"xmlns:table,attr"`
+	XMLNSFo         string             `xml:"xmlns:fo,attr"`
+	Version         string             `xml:"office:version,attr"`
+	AutomaticStyles odsAutomaticStyles `xml:"office:automatic-styles"`
+	Body            odsBody            `xml:"office:body"`
+}
+
+// odsAutomaticStyles directly maps the automatic-styles element, which
+// contains the styles used by the tables, columns, rows and cells.
+type odsAutomaticStyles struct {
+	Style []*odsStyle `xml:"style:style"`
+}
+
+// odsStyle directly maps the style and the default-style element.
+type odsStyle struct {
+	Name                  string                    `xml:"style:name,attr,omitempty"`
+	Family                string                    `xml:"style:family,attr"`
+	TableProperties       *odsTableProperties       `xml:"style:table-properties"`
+	TableColumnProperties *odsTableColumnProperties `xml:"style:table-column-properties"`
+	TableRowProperties    *odsTableRowProperties    `xml:"style:table-row-properties"`
+	TableCellProperties   *odsTableCellProperties   `xml:"style:table-cell-properties"`
+	ParagraphProperties   *odsParagraphProperties   `xml:"style:paragraph-properties"`
+	TextProperties        *odsTextProperties        `xml:"style:text-properties"`
+}
+
+// odsTableProperties directly maps the table-properties element.
+type odsTableProperties struct {
+	Display bool `xml:"table:display,attr"`
+}
+
+// odsTableColumnProperties directly maps the table-column-properties element.
+type odsTableColumnProperties struct {
+	ColumnWidth string `xml:"style:column-width,attr"`
+}
+
+// odsTableRowProperties directly maps the table-row-properties element.
+type odsTableRowProperties struct {
+	RowHeight           string `xml:"style:row-height,attr"`
+	UseOptimalRowHeight bool   `xml:"style:use-optimal-row-height,attr"`
+}
+
+// odsTableCellProperties directly maps the table-cell-properties element.
+type odsTableCellProperties struct {
+	BackgroundColor string `xml:"fo:background-color,attr,omitempty"`
+	BorderLeft      string `xml:"fo:border-left,attr,omitempty"`
+	BorderRight     string `xml:"fo:border-right,attr,omitempty"`
+	BorderTop       string `xml:"fo:border-top,attr,omitempty"`
+	BorderBottom    string `xml:"fo:border-bottom,attr,omitempty"`
+	VerticalAlign   string `xml:"style:vertical-align,attr,omitempty"`
+	WrapOption      string `xml:"fo:wrap-option,attr,omitempty"`
+}
+
+// odsParagraphProperties directly maps the paragraph-properties element.
+type odsParagraphProperties struct {
+	TextAlign  string `xml:"fo:text-align,attr,omitempty"`
+	MarginLeft string `xml:"fo:margin-left,attr,omitempty"`
+}
+
+// odsTextProperties directly maps the text-properties element.
+type odsTextProperties struct {
+	FontFamily           string `xml:"fo:font-family,attr,omitempty"`
+	FontSize             string `xml:"fo:font-size,attr,omitempty"`
+	FontWeight           string `xml:"fo:font-weight,attr,omitempty"`
+	FontStyle            string `xml:"fo:font-style,attr,omitempty"`
+	Color                string `xml:"fo:color,attr,omitempty"`
+	TextUnderlineStyle   string `xml:"style:text-underline-style,attr,omitempty"`
+	TextLineThroughStyle string `xml:"style:text-line-through-style,attr,omitempty"`
+}
+
+// odsBody directly maps the body element.
+type odsBody struct {
+	Spreadsheet odsSpreadsheet `xml:"office:spreadsheet"`
+}
+
+// odsSpreadsheet directly maps the spreadsheet element, which contains the
+// tables of the document.
+type odsSpreadsheet struct {
+	Table []*odsTable `xml:"table:table"`
+}
+
+// odsTable directly maps the table element, which represents a worksheet.
+type odsTable struct {
+	Name      string           `xml:"table:name,attr"`
+	StyleName string           `xml:"table:style-name,attr"`
+	Column    []odsTableColumn `xml:"table:table-column"`
+	Row       []*odsTableRow   `xml:"table:table-row"`
+}
+
+// odsTableColumn directly maps the table-column element.
+type odsTableColumn struct {
+	StyleName  string `xml:"table:style-name,attr"`
+	Visibility string `xml:"table:visibility,attr,omitempty"`
+}
+
+// odsTableRow directly maps the table-row element.
+type odsTableRow struct {
+	StyleName  string         `xml:"table:style-name,attr"`
+	Visibility string         `xml:"table:visibility,attr,omitempty"`
+	Cell       []odsTableCell `xml:",any"`
+}
+
+// odsTableCell directly maps the table-cell and the covered-table-cell
+// element.
+type odsTableCell struct {
+	XMLName        xml.Name
+	StyleName      string   `xml:"table:style-name,attr,omitempty"`
+	ValueType      string   `xml:"office:value-type,attr,omitempty"`
+	Value          string   `xml:"office:value,attr,omitempty"`
+	DateValue      string   `xml:"office:date-value,attr,omitempty"`
+	BooleanValue   string   `xml:"office:boolean-value,attr,omitempty"`
+	ColumnsSpanned int      `xml:"table:number-columns-spanned,attr,omitempty"`
+	RowsSpanned    int      `xml:"table:number-rows-spanned,attr,omitempty"`
+	P              []string `xml:"text:p"`
+}
@@ -0,0 +1,100 @@
+// Copyright 2016 - 2025 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.20 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxOleObject directly maps the oleObject element. This element specifies
+// an embedded or linked OLE object in the worksheet.
+type xlsxOleObject struct {
+	XMLName  xml.Name      `xml:"oleObject"`
+	ProgID   string        `xml:"progId,attr,omitempty"`
+	DvAspect string        `xml:"dvAspect,attr,omitempty"`
+	ShapeID  int           `xml:"shapeId,attr"`
+	RID      string        `xml:"r:id,attr,omitempty"`
+	ObjectPr *xlsxObjectPr `xml:"objectPr"`
+}
+
+// xlsxObjectPr directly maps the objectPr element. This element specifies
+// the properties of the embedded object, the relationship to the picture
+// representing the object and the anchor of the object.
+type xlsxObjectPr struct {
+	DefaultSize bool              `xml:"defaultSize,attr"`
+	AutoPict    bool              `xml:"autoPict,attr"`
+	RID         string            `xml:"r:id,attr,omitempty"`
+	Anchor      *xlsxObjectAnchor `xml:"anchor"`
+}
+
+// xlsxObjectAnchor directly maps the anchor element of the embedded object.
+type xlsxObjectAnchor struct {
+	MoveWithCells bool     `xml:"moveWithCells,attr,omitempty"`
+	SizeWithCells bool     `xml:"sizeWithCells,attr,omitempty"`
+	From          xlsxFrom `xml:"from"`
+	To            xlsxTo   `xml:"to"`
+}
+
+// decodeOleObjects defines the structure used to parse the oleObjects element
+// in the worksheet.
+type decodeOleObjects struct {
+	AlternateContent []decodeOleObjectAlternateContent `xml:"AlternateContent"`
+	OleObject        []decodeOleObject                 `xml:"oleObject"`
+}
+
+// decodeOleObjectAlternateContent defines the structure used to parse the
+// AlternateContent element in the oleObjects element.
+type decodeOleObjectAlternateContent struct {
+	Choice   *decodeOleObjectContent `xml:"Choice"`
+	Fallback *decodeOleObjectContent `xml:"Fallback"`
+}
+
+// decodeOleObjectContent defines the structure used to parse the Choice and
+// Fallback element in the AlternateContent element.
+type decodeOleObjectContent struct {
+	OleObject *decodeOleObject `xml:"oleObject"`
+}
+
+// decodeOleObject defines the structure used to parse the oleObject element.
+type decodeOleObject struct {
+	ProgID   string          `xml:"progId,attr"`
+	ShapeID  int             `xml:"shapeId,attr"`
+	RID      string          `xml:"id,attr"`
+	ObjectPr *decodeObjectPr `xml:"objectPr"`
+}
+
+// decodeObjectPr defines the structure used to parse the objectPr element.
+type decodeObjectPr struct {
+	RID    string              `xml:"id,attr"`
+	Anchor *decodeObjectAnchor `xml:"anchor"`
+}
+
+// decodeObjectAnchor defines the structure used to parse the anchor element
+// of the embedded object.
+type decodeObjectAnchor struct {
+	From decodeFrom `xml:"from"`
+}
+
+// OLEObject directly maps the settings of the embedded OLE object. The
+// Extension specifies the file extension of the embedded file, the Data
+// specifies the raw content of the embedded file, the Icon and the
+// IconExtension specifies the picture displayed for the object in the
+// worksheet.
+type OLEObject struct {
+	Cell          string
+	ProgID        string
+	FileName      string
+	Extension     string
+	Data          []byte
+	Icon          []byte
+	IconExtension string
+	Width         uint
+	Height        uint
+}
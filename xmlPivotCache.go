@@ -120,10 +120,10 @@ type xlsxCacheField struct {
 // those values that are referenced in multiple places across all the
 // PivotTable parts.
 type xlsxSharedItems struct {
-	ContainsSemiMixedTypes bool           `xml:"containsSemiMixedTypes,attr,omitempty"`
-	ContainsNonDate        bool           `xml:"containsNonDate,attr,omitempty"`
+	ContainsSemiMixedTypes *bool          `xml:"containsSemiMixedTypes,attr"`
+	ContainsNonDate        *bool          `xml:"containsNonDate,attr"`
 	ContainsDate           bool           `xml:"containsDate,attr,omitempty"`
-	ContainsString         bool           `xml:"containsString,attr,omitempty"`
+	ContainsString         *bool          `xml:"containsString,attr"`
 	ContainsBlank          bool           `xml:"containsBlank,attr,omitempty"`
 	ContainsMixedTypes     bool           `xml:"containsMixedTypes,attr,omitempty"`
 	ContainsNumber         bool           `xml:"containsNumber,attr,omitempty"`
@@ -142,6 +142,28 @@ type xlsxSharedItems struct {
 	D                      []xlsxDateTime `xml:"d"`
 }
 
+// xlsxPivotCacheRecords represents the pivotCacheRecords part. This part
+// contains the underlying source data of the pivot cache, each record
+// represents a row of the source data.
+type xlsxPivotCacheRecords struct {
+	XMLName xml.Name               `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main pivotCacheRecords"`
+	Count   int                    `xml:"count,attr"`
+	R       []xlsxPivotCacheRecord `xml:"r"`
+}
+
+// xlsxPivotCacheRecord represents a single record of data in the pivot cache.
+type xlsxPivotCacheRecord struct {
+	Values []xlsxPivotCacheRecordValue
+}
+
+// xlsxPivotCacheRecordValue represents a value of the pivot cache record. The
+// element name of the value is one of "m" (missing), "n" (numeric), "s"
+// (character) or "x" (index of the shared item in the cache field).
+type xlsxPivotCacheRecordValue struct {
+	XMLName xml.Name
+	V       string `xml:"v,attr,omitempty"`
+}
+
 // xlsxMissing represents a value that was not specified.
 type xlsxMissing struct{}
 
@@ -194,10 +216,37 @@ type xlsxString struct {
 }
 
 // xlsxDateTime represents a date-time value in the PivotTable.
-type xlsxDateTime struct{}
+type xlsxDateTime struct {
+	V string `xml:"v,attr"`
+}
 
 // xlsxFieldGroup represents the collection of properties for a field group.
-type xlsxFieldGroup struct{}
+type xlsxFieldGroup struct {
+	Par        int             `xml:"par,attr,omitempty"`
+	Base       *int            `xml:"base,attr"`
+	RangePr    *xlsxRangePr    `xml:"rangePr"`
+	GroupItems *xlsxGroupItems `xml:"groupItems"`
+}
+
+// xlsxRangePr represents the properties of a range grouping. The range
+// grouping groups the numeric or date-time values of the field by the given
+// interval between the start and end value.
+type xlsxRangePr struct {
+	AutoStart     *bool    `xml:"autoStart,attr"`
+	AutoEnd       *bool    `xml:"autoEnd,attr"`
+	GroupBy       string   `xml:"groupBy,attr,omitempty"`
+	StartNum      *float64 `xml:"startNum,attr"`
+	EndNum        *float64 `xml:"endNum,attr"`
+	StartDate     string   `xml:"startDate,attr,omitempty"`
+	EndDate       string   `xml:"endDate,attr,omitempty"`
+	GroupInterval float64  `xml:"groupInterval,attr,omitempty"`
+}
+
+// xlsxGroupItems represents the collection of items in a range grouping.
+type xlsxGroupItems struct {
+	Count int          `xml:"count,attr"`
+	S     []xlsxString `xml:"s"`
+}
 
 // xlsxCacheHierarchies represents the collection of OLAP hierarchies in the
 // PivotCache.
@@ -90,3 +90,22 @@ type RichTextRun struct {
 	Font *Font
 	Text string
 }
+
+// PhoneticRun directly maps the settings of the phonetic run, which
+// specifies the phonetic text of the characters in the base text from the
+// Start index to the End index (exclusive), the indexes are counted in
+// characters.
+type PhoneticRun struct {
+	Start int
+	End   int
+	Text  string
+}
+
+// Phonetic directly maps the settings of the phonetic guide (furigana) of
+// the cell.
+type Phonetic struct {
+	Runs      []PhoneticRun
+	Type      string
+	Alignment string
+	Show      bool
+}
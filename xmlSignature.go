@@ -0,0 +1,274 @@
+// Copyright 2016 - 2025 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.20 or later.
+
+package excelize
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/xml"
+	"time"
+)
+
+// xlsxSignature directly maps the Signature element in the XML signature
+// part of the package, which is specified by the XML Signature Syntax and
+// Processing and the Open Packaging Conventions.
+type xlsxSignature struct {
+	XMLName        xml.Name              `xml:"Signature"`
+	XMLNS          string                `xml:"xmlns,attr"`
+	ID             string                `xml:"Id,attr"`
+	SignedInfo     xlsxSignedInfo        `xml:"SignedInfo"`
+	SignatureValue string                `xml:"SignatureValue"`
+	KeyInfo        xlsxKeyInfo           `xml:"KeyInfo"`
+	Object         []xlsxSignatureObject `xml:"Object"`
+}
+
+// xlsxSignedInfo directly maps the SignedInfo element, which contains the
+// canonicalization algorithm, the signature algorithm and the references of
+// the signed content.
+type xlsxSignedInfo struct {
+	CanonicalizationMethod xlsxSignatureAlgorithm   `xml:"CanonicalizationMethod"`
+	SignatureMethod        xlsxSignatureAlgorithm   `xml:"SignatureMethod"`
+	Reference              []xlsxSignatureReference `xml:"Reference"`
+}
+
+// xlsxSignatureAlgorithm directly maps the element which specifies an
+// algorithm by the Algorithm attribute, such as the CanonicalizationMethod,
+// SignatureMethod, DigestMethod and Transform element.
+type xlsxSignatureAlgorithm struct {
+	Algorithm string `xml:",attr"`
+}
+
+// xlsxSignatureReference directly maps the Reference element, which
+// specifies the digest method and digest value of the referenced content.
+type xlsxSignatureReference struct {
+	Type         string                   `xml:",attr,omitempty"`
+	URI          string                   `xml:",attr"`
+	Transforms   *xlsxSignatureTransforms `xml:"Transforms"`
+	DigestMethod xlsxSignatureAlgorithm   `xml:"DigestMethod"`
+	DigestValue  string                   `xml:"DigestValue"`
+}
+
+// xlsxSignatureTransforms directly maps the Transforms element, which
+// contains the ordered list of the transform steps for the referenced
+// content.
+type xlsxSignatureTransforms struct {
+	Transform []xlsxSignatureTransform `xml:"Transform"`
+}
+
+// xlsxSignatureTransform directly maps the Transform element. The
+// RelationshipReference elements specify the relationships to be signed by
+// the relationship transform.
+type xlsxSignatureTransform struct {
+	Algorithm             string                      `xml:",attr"`
+	RelationshipReference []xlsxRelationshipReference `xml:"mdssi:RelationshipReference"`
+}
+
+// xlsxRelationshipReference directly maps the RelationshipReference element,
+// which specifies the ID of the relationship to be signed.
+type xlsxRelationshipReference struct {
+	XMLNSMdssi string `xml:"xmlns:mdssi,attr"`
+	SourceID   string `xml:"SourceId,attr"`
+}
+
+// xlsxKeyInfo directly maps the KeyInfo element, which contains the X.509
+// certificate of the signer.
+type xlsxKeyInfo struct {
+	X509Certificate string `xml:"X509Data>X509Certificate"`
+}
+
+// xlsxSignatureObject directly maps the Object element, which contains the
+// package manifest, the signature properties or the XAdES qualifying
+// properties.
+type xlsxSignatureObject struct {
+	ID                   string                    `xml:"Id,attr,omitempty"`
+	Manifest             *xlsxSignatureManifest    `xml:"Manifest"`
+	SignatureProperties  *xlsxSignatureProperties  `xml:"SignatureProperties"`
+	QualifyingProperties *xlsxQualifyingProperties `xml:"xd:QualifyingProperties"`
+}
+
+// xlsxSignatureManifest directly maps the Manifest element, which contains
+// the references of the signed package parts.
+type xlsxSignatureManifest struct {
+	Reference []xlsxSignatureReference `xml:"Reference"`
+}
+
+// xlsxSignatureProperties directly maps the SignatureProperties element.
+type xlsxSignatureProperties struct {
+	SignatureProperty []xlsxSignatureProperty `xml:"SignatureProperty"`
+}
+
+// xlsxSignatureProperty directly maps the SignatureProperty element, which
+// contains the signing time of the package or the signature information of
+// the application.
+type xlsxSignatureProperty struct {
+	ID              string               `xml:"Id,attr"`
+	Target          string               `xml:",attr"`
+	SignatureTime   *xlsxSignatureTime   `xml:"mdssi:SignatureTime"`
+	SignatureInfoV1 *xlsxSignatureInfoV1 `xml:"SignatureInfoV1"`
+}
+
+// xlsxSignatureTime directly maps the SignatureTime element, which specifies
+// the format and the value of the signing time.
+type xlsxSignatureTime struct {
+	XMLNSMdssi string `xml:"xmlns:mdssi,attr"`
+	Format     string `xml:"mdssi:Format"`
+	Value      string `xml:"mdssi:Value"`
+}
+
+// xlsxSignatureInfoV1 directly maps the SignatureInfoV1 element, which
+// contains the signature information of the application, such as the
+// signature comments.
+type xlsxSignatureInfoV1 struct {
+	XMLNS                    string `xml:"xmlns,attr"`
+	SetupID                  string `xml:"SetupID"`
+	SignatureText            string `xml:"SignatureText"`
+	SignatureImage           string `xml:"SignatureImage"`
+	SignatureComments        string `xml:"SignatureComments"`
+	WindowsVersion           string `xml:"WindowsVersion"`
+	OfficeVersion            string `xml:"OfficeVersion"`
+	ApplicationVersion       string `xml:"ApplicationVersion"`
+	Monitors                 int    `xml:"Monitors"`
+	HorizontalResolution     int    `xml:"HorizontalResolution"`
+	VerticalResolution       int    `xml:"VerticalResolution"`
+	ColorDepth               int    `xml:"ColorDepth"`
+	SignatureProviderID      string `xml:"SignatureProviderId"`
+	SignatureProviderURL     string `xml:"SignatureProviderUrl"`
+	SignatureProviderDetails int    `xml:"SignatureProviderDetails"`
+	SignatureType            int    `xml:"SignatureType"`
+}
+
+// xlsxQualifyingProperties directly maps the QualifyingProperties element of
+// the XML Advanced Electronic Signatures (XAdES).
+type xlsxQualifyingProperties struct {
+	XMLNSXd          string               `xml:"xmlns:xd,attr"`
+	Target           string               `xml:",attr"`
+	SignedProperties xlsxSignedProperties `xml:"xd:SignedProperties"`
+}
+
+// xlsxSignedProperties directly maps the SignedProperties element, which
+// contains the signed properties qualifying the signature.
+type xlsxSignedProperties struct {
+	ID                        string                        `xml:"Id,attr"`
+	SignedSignatureProperties xlsxSignedSignatureProperties `xml:"xd:SignedSignatureProperties"`
+}
+
+// xlsxSignedSignatureProperties directly maps the SignedSignatureProperties
+// element, which specifies the signing time and the signing certificate.
+type xlsxSignedSignatureProperties struct {
+	SigningTime               string                 `xml:"xd:SigningTime"`
+	SigningCertificate        xlsxSigningCertificate `xml:"xd:SigningCertificate"`
+	SignaturePolicyIdentifier string                 `xml:"xd:SignaturePolicyIdentifier>xd:SignaturePolicyImplied"`
+}
+
+// xlsxSigningCertificate directly maps the SigningCertificate element, which
+// contains the digest and the issuer serial of the signing certificate.
+type xlsxSigningCertificate struct {
+	CertDigest       xlsxSignatureCertDigest `xml:"xd:Cert>xd:CertDigest"`
+	X509IssuerName   string                  `xml:"xd:Cert>xd:IssuerSerial>X509IssuerName"`
+	X509SerialNumber string                  `xml:"xd:Cert>xd:IssuerSerial>X509SerialNumber"`
+}
+
+// xlsxSignatureCertDigest directly maps the CertDigest element.
+type xlsxSignatureCertDigest struct {
+	DigestMethod xlsxSignatureAlgorithm `xml:"DigestMethod"`
+	DigestValue  string                 `xml:"DigestValue"`
+}
+
+// decodeSignature defines the structure used to parse the Signature element
+// in the XML signature part of the package.
+type decodeSignature struct {
+	SignedInfo      decodeSignedInfo        `xml:"SignedInfo"`
+	SignatureValue  string                  `xml:"SignatureValue"`
+	X509Certificate []string                `xml:"KeyInfo>X509Data>X509Certificate"`
+	Object          []decodeSignatureObject `xml:"Object"`
+}
+
+// decodeSignedInfo defines the structure used to parse the SignedInfo
+// element.
+type decodeSignedInfo struct {
+	CanonicalizationMethod decodeSignatureAlgorithm   `xml:"CanonicalizationMethod"`
+	SignatureMethod        decodeSignatureAlgorithm   `xml:"SignatureMethod"`
+	Reference              []decodeSignatureReference `xml:"Reference"`
+}
+
+// decodeSignatureAlgorithm defines the structure used to parse the element
+// which specifies an algorithm by the Algorithm attribute.
+type decodeSignatureAlgorithm struct {
+	Algorithm string `xml:",attr"`
+}
+
+// decodeSignatureReference defines the structure used to parse the Reference
+// element.
+type decodeSignatureReference struct {
+	URI          string                     `xml:",attr"`
+	Transform    []decodeSignatureTransform `xml:"Transforms>Transform"`
+	DigestMethod decodeSignatureAlgorithm   `xml:"DigestMethod"`
+	DigestValue  string                     `xml:"DigestValue"`
+}
+
+// decodeSignatureTransform defines the structure used to parse the Transform
+// element.
+type decodeSignatureTransform struct {
+	Algorithm                   string                              `xml:",attr"`
+	RelationshipReference       []decodeRelationshipReference       `xml:"RelationshipReference"`
+	RelationshipsGroupReference []decodeRelationshipsGroupReference `xml:"RelationshipsGroupReference"`
+}
+
+// decodeRelationshipReference defines the structure used to parse the
+// RelationshipReference element.
+type decodeRelationshipReference struct {
+	SourceID string `xml:"SourceId,attr"`
+}
+
+// decodeRelationshipsGroupReference defines the structure used to parse the
+// RelationshipsGroupReference element, which specifies the type of the
+// relationships to be signed.
+type decodeRelationshipsGroupReference struct {
+	SourceType string `xml:"SourceType,attr"`
+}
+
+// decodeSignatureObject defines the structure used to parse the Object
+// element.
+type decodeSignatureObject struct {
+	Reference         []decodeSignatureReference `xml:"Manifest>Reference"`
+	SignatureProperty []decodeSignatureProperty  `xml:"SignatureProperties>SignatureProperty"`
+	SigningTime       string                     `xml:"QualifyingProperties>SignedProperties>SignedSignatureProperties>SigningTime"`
+}
+
+// decodeSignatureProperty defines the structure used to parse the
+// SignatureProperty element.
+type decodeSignatureProperty struct {
+	SignatureTime     string `xml:"SignatureTime>Value"`
+	SignatureComments string `xml:"SignatureInfoV1>SignatureComments"`
+}
+
+// DigitalSignatureOptions directly maps the settings of the digital
+// signature. The Certificate and PrivateKey are required, the PrivateKey
+// should be an RSA or ECDSA private key which matches the public key of the
+// Certificate. The current time will be used if the SigningTime is not
+// specified.
+type DigitalSignatureOptions struct {
+	Certificate *x509.Certificate
+	PrivateKey  crypto.Signer
+	Comment     string
+	SigningTime time.Time
+}
+
+// DigitalSignature directly maps the digital signature of the workbook. The
+// Valid is true if the signed content of the package has not been changed
+// and the signature value matches the public key of the Certificate.
+type DigitalSignature struct {
+	Certificate *x509.Certificate
+	Comment     string
+	SigningTime time.Time
+	Valid       bool
+}
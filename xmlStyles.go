@@ -354,10 +354,13 @@ type Font struct {
 
 // Fill directly maps the fill settings of the cells.
 type Fill struct {
-	Type    string
-	Pattern int
-	Color   []string
-	Shading int
+	Type       string
+	Pattern    int
+	Color      []string
+	ColorTheme []*int
+	ColorTint  []float64
+	Shading    int
+	Degree     *float64
 }
 
 // Protection directly maps the protection settings of the cells.
@@ -177,18 +177,28 @@ type xlsxTableColumns struct {
 // xlsxTableColumn directly maps the element representing a single column for
 // this table.
 type xlsxTableColumn struct {
-	ID                 int    `xml:"id,attr"`
-	UniqueName         string `xml:"uniqueName,attr,omitempty"`
-	Name               string `xml:"name,attr"`
-	TotalsRowFunction  string `xml:"totalsRowFunction,attr,omitempty"`
-	TotalsRowLabel     string `xml:"totalsRowLabel,attr,omitempty"`
-	QueryTableFieldID  int    `xml:"queryTableFieldId,attr,omitempty"`
-	HeaderRowDxfID     int    `xml:"headerRowDxfId,attr,omitempty"`
-	DataDxfID          int    `xml:"dataDxfId,attr,omitempty"`
-	TotalsRowDxfID     int    `xml:"totalsRowDxfId,attr,omitempty"`
-	HeaderRowCellStyle string `xml:"headerRowCellStyle,attr,omitempty"`
-	DataCellStyle      string `xml:"dataCellStyle,attr,omitempty"`
-	TotalsRowCellStyle string `xml:"totalsRowCellStyle,attr,omitempty"`
+	ID                      int               `xml:"id,attr"`
+	UniqueName              string            `xml:"uniqueName,attr,omitempty"`
+	Name                    string            `xml:"name,attr"`
+	TotalsRowFunction       string            `xml:"totalsRowFunction,attr,omitempty"`
+	TotalsRowLabel          string            `xml:"totalsRowLabel,attr,omitempty"`
+	QueryTableFieldID       int               `xml:"queryTableFieldId,attr,omitempty"`
+	HeaderRowDxfID          int               `xml:"headerRowDxfId,attr,omitempty"`
+	DataDxfID               int               `xml:"dataDxfId,attr,omitempty"`
+	TotalsRowDxfID          int               `xml:"totalsRowDxfId,attr,omitempty"`
+	HeaderRowCellStyle      string            `xml:"headerRowCellStyle,attr,omitempty"`
+	DataCellStyle           string            `xml:"dataCellStyle,attr,omitempty"`
+	TotalsRowCellStyle      string            `xml:"totalsRowCellStyle,attr,omitempty"`
+	CalculatedColumnFormula *xlsxTableFormula `xml:"calculatedColumnFormula"`
+	TotalsRowFormula        *xlsxTableFormula `xml:"totalsRowFormula"`
+}
+
+// xlsxTableFormula directly maps the calculatedColumnFormula and
+// totalsRowFormula element. This element specifies the formula used to
+// calculate the cells in the table column or the totals row cell.
+type xlsxTableFormula struct {
+	Array   bool   `xml:"array,attr,omitempty"`
+	Content string `xml:",chardata"`
 }
 
 // xlsxTableStyleInfo directly maps the tableStyleInfo element. This element
@@ -244,10 +254,24 @@ type Table struct {
 	ShowHeaderRow     *bool
 	ShowLastColumn    bool
 	ShowRowStripes    *bool
+	ShowTotalsRow     bool
+	Columns           []TableColumn
+}
+
+// TableColumn directly maps the settings of the table column.
+type TableColumn struct {
+	Name              string
+	Formula           string
+	TotalsRowFunction string
+	TotalsRowLabel    string
+	TotalsRowFormula  string
 }
 
 // AutoFilterOptions directly maps the auto filter settings.
 type AutoFilterOptions struct {
 	Column     string
 	Expression string
+	Values     []string
+	CellColor  string
+	FontColor  string
 }
@@ -85,10 +85,12 @@ type xlsxWorkbookProtection struct {
 	LockRevision           bool   `xml:"lockRevision,attr,omitempty"`
 	LockStructure          bool   `xml:"lockStructure,attr,omitempty"`
 	LockWindows            bool   `xml:"lockWindows,attr,omitempty"`
+	RevisionsPassword      string `xml:"revisionsPassword,attr,omitempty"`
 	RevisionsAlgorithmName string `xml:"revisionsAlgorithmName,attr,omitempty"`
 	RevisionsHashValue     string `xml:"revisionsHashValue,attr,omitempty"`
 	RevisionsSaltValue     string `xml:"revisionsSaltValue,attr,omitempty"`
 	RevisionsSpinCount     int    `xml:"revisionsSpinCount,attr,omitempty"`
+	WorkbookPassword       string `xml:"workbookPassword,attr,omitempty"`
 	WorkbookAlgorithmName  string `xml:"workbookAlgorithmName,attr,omitempty"`
 	WorkbookHashValue      string `xml:"workbookHashValue,attr,omitempty"`
 	WorkbookSaltValue      string `xml:"workbookSaltValue,attr,omitempty"`
@@ -314,15 +316,15 @@ type xlsxDefinedName struct {
 // and details. Calculation is the process of computing formulas and then
 // displaying the results as values in the cells that contain the formulas.
 type xlsxCalcPr struct {
-	CalcCompleted         bool    `xml:"calcCompleted,attr,omitempty"`
+	CalcCompleted         *bool   `xml:"calcCompleted,attr"`
 	CalcID                string  `xml:"calcId,attr,omitempty"`
 	CalcMode              string  `xml:"calcMode,attr,omitempty"`
-	CalcOnSave            bool    `xml:"calcOnSave,attr,omitempty"`
+	CalcOnSave            *bool   `xml:"calcOnSave,attr"`
 	ConcurrentCalc        *bool   `xml:"concurrentCalc,attr"`
 	ConcurrentManualCount int     `xml:"concurrentManualCount,attr,omitempty"`
 	ForceFullCalc         bool    `xml:"forceFullCalc,attr,omitempty"`
 	FullCalcOnLoad        bool    `xml:"fullCalcOnLoad,attr,omitempty"`
-	FullPrecision         bool    `xml:"fullPrecision,attr,omitempty"`
+	FullPrecision         *bool   `xml:"fullPrecision,attr"`
 	Iterate               bool    `xml:"iterate,attr,omitempty"`
 	IterateCount          int     `xml:"iterateCount,attr,omitempty"`
 	IterateDelta          float64 `xml:"iterateDelta,attr,omitempty"`
@@ -391,6 +393,56 @@ type WorkbookPropsOptions struct {
 	CodeName      *string
 }
 
+// WorkbookViewOptions directly maps the settings of the workbook view.
+type WorkbookViewOptions struct {
+	// ActiveTab specifies the index of the active sheet when the workbook
+	// opened.
+	ActiveTab *int
+	// FirstSheet specifies the index of the first sheet displayed in the
+	// sheet tabs bar.
+	FirstSheet *int
+	// XWindow specifies the horizontal position of the upper-left corner of
+	// the workbook window, in twips.
+	XWindow *int
+	// YWindow specifies the vertical position of the upper-left corner of the
+	// workbook window, in twips.
+	YWindow *int
+	// WindowWidth specifies the width of the workbook window, in twips.
+	WindowWidth *int
+	// WindowHeight specifies the height of the workbook window, in twips.
+	WindowHeight *int
+	// ShowHorizontalScroll indicating whether to display the horizontal
+	// scroll bar.
+	ShowHorizontalScroll *bool
+	// ShowVerticalScroll indicating whether to display the vertical scroll
+	// bar.
+	ShowVerticalScroll *bool
+	// ShowSheetTabs indicating whether to display the sheet tabs.
+	ShowSheetTabs *bool
+	// TabRatio specifies the ratio between the sheet tabs bar and the
+	// horizontal scroll bar, in per mille. This value is restricted to
+	// values ranging from 0 to 1000.
+	TabRatio *float64
+}
+
+// CalcPropsOptions defines the collection of properties the application uses
+// to record calculation status and details.
+type CalcPropsOptions struct {
+	CalcID                *uint
+	CalcMode              *string
+	FullCalcOnLoad        *bool
+	RefMode               *string
+	Iterate               *bool
+	IterateCount          *uint
+	IterateDelta          *float64
+	FullPrecision         *bool
+	CalcCompleted         *bool
+	CalcOnSave            *bool
+	ConcurrentCalc        *bool
+	ConcurrentManualCount *uint
+	ForceFullCalc         *bool
+}
+
 // WorkbookProtectionOptions directly maps the settings of workbook protection.
 type WorkbookProtectionOptions struct {
 	AlgorithmName string
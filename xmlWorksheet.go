@@ -293,6 +293,7 @@ type xlsxCol struct {
 type xlsxDimension struct {
 	XMLName xml.Name `xml:"dimension"`
 	Ref     string   `xml:"ref,attr"`
+	custom  bool
 }
 
 // xlsxSheetData collection represents the cell table itself. This collection
@@ -441,10 +442,39 @@ type xlsxDataValidation struct {
 	ShowErrorMessage bool          `xml:"showErrorMessage,attr,omitempty"`
 	ShowInputMessage bool          `xml:"showInputMessage,attr,omitempty"`
 	Sqref            string        `xml:"sqref,attr"`
-	XMSqref          string        `xml:"sqref,omitempty"`
 	Type             string        `xml:"type,attr,omitempty"`
 	Formula1         *xlsxInnerXML `xml:"formula1"`
 	Formula2         *xlsxInnerXML `xml:"formula2"`
+	XMSqref          string        `xml:"sqref,omitempty"`
+}
+
+// xlsxX14DataValidations directly maps the dataValidations element in the
+// worksheet extension list.
+type xlsxX14DataValidations struct {
+	XMLName        xml.Name                 `xml:"x14:dataValidations"`
+	XMLNSXM        string                   `xml:"xmlns:xm,attr"`
+	Count          int                      `xml:"count,attr,omitempty"`
+	DataValidation []*xlsxX14DataValidation `xml:"x14:dataValidation"`
+}
+
+// xlsxX14DataValidation directly maps the single item of data validation in
+// the worksheet extension list.
+type xlsxX14DataValidation struct {
+	AllowBlank       bool          `xml:"allowBlank,attr"`
+	Error            *string       `xml:"error,attr"`
+	ErrorStyle       *string       `xml:"errorStyle,attr"`
+	ErrorTitle       *string       `xml:"errorTitle,attr"`
+	Operator         string        `xml:"operator,attr,omitempty"`
+	Prompt           *string       `xml:"prompt,attr"`
+	PromptTitle      *string       `xml:"promptTitle,attr"`
+	ShowDropDown     bool          `xml:"showDropDown,attr,omitempty"`
+	ShowErrorMessage bool          `xml:"showErrorMessage,attr,omitempty"`
+	ShowInputMessage bool          `xml:"showInputMessage,attr,omitempty"`
+	Sqref            string        `xml:"sqref,attr,omitempty"`
+	Type             string        `xml:"type,attr,omitempty"`
+	Formula1         *xlsxInnerXML `xml:"x14:formula1"`
+	Formula2         *xlsxInnerXML `xml:"x14:formula2"`
+	XMSqref          string        `xml:"xm:sqref,omitempty"`
 }
 
 // xlsxC collection represents a cell in the worksheet. Information about the
@@ -602,7 +632,7 @@ type xlsxIconSet struct {
 // cfvo (Conditional Format Value Object) describes the values of the
 // interpolation points in a gradient scale.
 type xlsxCfvo struct {
-	Gte    bool        `xml:"gte,attr,omitempty"`
+	Gte    *bool       `xml:"gte,attr"`
 	Type   string      `xml:"type,attr,omitempty"`
 	Val    string      `xml:"val,attr,omitempty"`
 	ExtLst *xlsxExtLst `xml:"extLst"`
@@ -957,11 +987,20 @@ type ConditionalFormatOptions struct {
 	BarOnly        bool
 	BarSolid       bool
 	IconStyle      string
+	IconThresholds []ConditionalFormatIconThreshold
 	ReverseIcons   bool
 	IconsOnly      bool
 	StopIfTrue     bool
 }
 
+// ConditionalFormatIconThreshold directly maps the threshold settings of an
+// icon in the icon set conditional formatting.
+type ConditionalFormatIconThreshold struct {
+	Type        string
+	Value       string
+	GreaterThan bool
+}
+
 // SheetProtectionOptions directly maps the settings of worksheet protection.
 type SheetProtectionOptions struct {
 	AlgorithmName       string
@@ -997,6 +1036,22 @@ type HeaderFooterOptions struct {
 	FirstFooter      string
 }
 
+// HeaderFooterField directly maps the field in the section of header or
+// footer.
+type HeaderFooterField struct {
+	Type HeaderFooterFieldType
+	Text string
+	Font *Font
+}
+
+// HeaderFooterSections directly maps the left, center and right sections of
+// header or footer.
+type HeaderFooterSections struct {
+	Left   []HeaderFooterField
+	Center []HeaderFooterField
+	Right  []HeaderFooterField
+}
+
 // PageLayoutMarginsOptions directly maps the settings of page layout margins.
 type PageLayoutMarginsOptions struct {
 	Bottom       *float64
@@ -1033,6 +1088,27 @@ type PageLayoutOptions struct {
 	PageOrder *string
 }
 
+// PageSetupOptions directly maps the settings of printing page setup,
+// including page layout, margins, header and footer, print area and print
+// titles.
+type PageSetupOptions struct {
+	// Layout specified the page layout settings of the worksheet.
+	Layout *PageLayoutOptions
+	// Margins specified the page margins settings of the worksheet.
+	Margins *PageLayoutMarginsOptions
+	// HeaderFooter specified the header and footer settings of the worksheet.
+	HeaderFooter *HeaderFooterOptions
+	// FitToPage indicating whether the Fit to Page print option is enabled.
+	FitToPage *bool
+	// PrintArea specified the cell range of the print area, multiple ranges
+	// should be separated by a comma, such as "A1:F30" or "A1:C5,E1:G5".
+	PrintArea string
+	// PrintTitleRows specified the rows to repeat at top, such as "1:2".
+	PrintTitleRows string
+	// PrintTitleCols specified the columns to repeat at left, such as "A:B".
+	PrintTitleCols string
+}
+
 // ViewOptions directly maps the settings of sheet view.
 type ViewOptions struct {
 	// DefaultGridColor indicating that the consuming application should use
@@ -1071,6 +1147,59 @@ type ViewOptions struct {
 	ZoomScale *float64
 }
 
+// SortKey directly maps the settings of the sort key.
+type SortKey struct {
+	// Column specifies the column name of the sort key, such as "B".
+	Column string
+	// Descending specifies whether to sort in descending order.
+	Descending bool
+	// CustomList specifies the custom order of the values, the values in the
+	// list will be placed in the order of the list before other values.
+	CustomList []string
+}
+
+// SortOptions directly maps the settings of sort range.
+type SortOptions struct {
+	// Header specifies whether the first row of the range is a header row,
+	// which will not be sorted.
+	Header bool
+}
+
+// ClearOptions directly maps the settings of clear cells.
+type ClearOptions struct {
+	// Styles specifies whether to reset the cell styles to the default style
+	// in the range.
+	Styles bool
+}
+
+// PageBreaks directly maps the page breaks of the worksheet.
+type PageBreaks struct {
+	// Rows specifies the row numbers of the horizontal page breaks, the page
+	// break is located above the row.
+	Rows []int
+	// Cols specifies the column numbers of the vertical page breaks, the page
+	// break is located at the left of the column.
+	Cols []int
+}
+
+// FindOptions directly maps the settings of find and replace.
+type FindOptions struct {
+	// RegExp specifies whether the value to find is a regular expression.
+	RegExp bool
+	// MatchCase specifies whether to perform a case-sensitive search.
+	MatchCase bool
+	// MatchEntireCell specifies whether to only match the cells which entire
+	// content equals the value to find.
+	MatchEntireCell bool
+	// LookIn specifies where to find, available options: values and
+	// formulas, by default it finds in the values of the cells.
+	LookIn string
+	// ConvertNumbers specifies whether to store the text cells as numeric
+	// cells when the replaced value is numeric, by default the replaced text
+	// cells will be kept as text.
+	ConvertNumbers bool
+}
+
 // SheetPropsOptions directly maps the settings of sheet view.
 type SheetPropsOptions struct {
 	// Specifies a stable name of the sheet, which should not change over time,